@@ -18,14 +18,28 @@ limitations under the License.
 package apis
 
 import (
+	"sort"
+	"strings"
+
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	cachev1beta1 "github.com/crossplane/provider-azure/apis/cache/v1beta1"
+	cognitiveservicesv1alpha1 "github.com/crossplane/provider-azure/apis/cognitiveservices/v1alpha1"
 	computev1alpha3 "github.com/crossplane/provider-azure/apis/compute/v1alpha3"
 	databasev1alpha3 "github.com/crossplane/provider-azure/apis/database/v1alpha3"
 	databasev1beta1 "github.com/crossplane/provider-azure/apis/database/v1beta1"
+	eventhubv1alpha1 "github.com/crossplane/provider-azure/apis/eventhub/v1alpha1"
+	kubernetesv1alpha1 "github.com/crossplane/provider-azure/apis/kubernetes/v1alpha1"
+	kustov1alpha1 "github.com/crossplane/provider-azure/apis/kusto/v1alpha1"
+	monitorv1alpha1 "github.com/crossplane/provider-azure/apis/monitor/v1alpha1"
 	networkv1alpha3 "github.com/crossplane/provider-azure/apis/network/v1alpha3"
+	networkv1beta1 "github.com/crossplane/provider-azure/apis/network/v1beta1"
 	storagev1alpha3 "github.com/crossplane/provider-azure/apis/storage/v1alpha3"
+	storagesyncv1alpha1 "github.com/crossplane/provider-azure/apis/storagesync/v1alpha1"
 	azurev1alpha3 "github.com/crossplane/provider-azure/apis/v1alpha3"
 	azurev1beta1 "github.com/crossplane/provider-azure/apis/v1beta1"
 )
@@ -36,11 +50,18 @@ func init() {
 		azurev1alpha3.SchemeBuilder.AddToScheme,
 		azurev1beta1.SchemeBuilder.AddToScheme,
 		cachev1beta1.SchemeBuilder.AddToScheme,
+		cognitiveservicesv1alpha1.SchemeBuilder.AddToScheme,
 		computev1alpha3.SchemeBuilder.AddToScheme,
 		databasev1alpha3.SchemeBuilder.AddToScheme,
 		databasev1beta1.SchemeBuilder.AddToScheme,
+		eventhubv1alpha1.SchemeBuilder.AddToScheme,
+		kubernetesv1alpha1.SchemeBuilder.AddToScheme,
+		kustov1alpha1.SchemeBuilder.AddToScheme,
+		monitorv1alpha1.SchemeBuilder.AddToScheme,
 		networkv1alpha3.SchemeBuilder.AddToScheme,
+		networkv1beta1.SchemeBuilder.AddToScheme,
 		storagev1alpha3.SchemeBuilder.AddToScheme,
+		storagesyncv1alpha1.SchemeBuilder.AddToScheme,
 	)
 }
 
@@ -51,3 +72,73 @@ var AddToSchemes runtime.SchemeBuilder
 func AddToScheme(s *runtime.Scheme) error {
 	return AddToSchemes.AddToScheme(s)
 }
+
+// ManagedLists returns an empty list of every kind of managed resource known
+// to the supplied scheme, ordered by group, version and kind. A kind served at
+// several versions is only listed at its conversion hub version, since the API
+// server returns the same resources at every version.
+func ManagedLists(s *runtime.Scheme) []resource.ManagedList {
+	hubs := map[schema.GroupKind]bool{}
+	gvks := make([]schema.GroupVersionKind, 0)
+	for gvk := range s.AllKnownTypes() {
+		if strings.HasSuffix(gvk.Kind, "List") {
+			gvks = append(gvks, gvk)
+		}
+		if isHub(s, gvk) {
+			hubs[gvk.GroupKind()] = true
+		}
+	}
+	sort.Slice(gvks, func(i, j int) bool { return gvks[i].String() < gvks[j].String() })
+
+	lists := make([]resource.ManagedList, 0, len(gvks))
+	for _, gvk := range gvks {
+		item := gvk.GroupVersion().WithKind(strings.TrimSuffix(gvk.Kind, "List"))
+		if hubs[item.GroupKind()] && !isHub(s, item) {
+			continue
+		}
+		o, err := s.New(gvk)
+		if err != nil {
+			continue
+		}
+		if l, ok := o.(resource.ManagedList); ok {
+			lists = append(lists, l)
+		}
+	}
+	return lists
+}
+
+// Hubs returns an object of every conversion hub known to the supplied scheme,
+// i.e. the version of every kind that is served at several versions that its
+// other versions convert to and from, ordered by group, version and kind.
+// Graduating a kind to a new version takes marking that version as its hub
+// and making the older versions convertible to and from it. The hub need not
+// be the storage version; objects stored at an older version are converted
+// through it.
+func Hubs(s *runtime.Scheme) ([]conversion.Hub, error) {
+	gvks := make([]schema.GroupVersionKind, 0)
+	for gvk := range s.AllKnownTypes() {
+		if isHub(s, gvk) {
+			gvks = append(gvks, gvk)
+		}
+	}
+	sort.Slice(gvks, func(i, j int) bool { return gvks[i].String() < gvks[j].String() })
+
+	hubs := make([]conversion.Hub, 0, len(gvks))
+	for _, gvk := range gvks {
+		o, err := s.New(gvk)
+		if err != nil {
+			return nil, err
+		}
+		hubs = append(hubs, o.(conversion.Hub))
+	}
+	return hubs, nil
+}
+
+func isHub(s *runtime.Scheme, gvk schema.GroupVersionKind) bool {
+	o, err := s.New(gvk)
+	if err != nil {
+		return false
+	}
+	_, ok := o.(conversion.Hub)
+	return ok
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apis
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	networkv1beta1 "github.com/crossplane/provider-azure/apis/network/v1beta1"
+)
+
+func TestHubs(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	hubs, err := Hubs(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make([]string, 0)
+	for _, h := range hubs {
+		gvks, _, err := s.ObjectKinds(h)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, gvks[0].String())
+	}
+	want := []string{
+		networkv1beta1.SubnetGroupVersionKind.String(),
+		networkv1beta1.VirtualNetworkGroupVersionKind.String(),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Hubs(...): -want, +got:\n%s", diff)
+	}
+}
+
+// TestConvertible ensures that every kind served at several versions has one
+// conversion hub that its other versions are convertible to and from, so its
+// stored objects survive being read at any of them.
+func TestConvertible(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	versions := map[schema.GroupKind][]schema.GroupVersionKind{}
+	for gvk, typ := range s.AllKnownTypes() {
+		// Every group version also knows the kinds of the meta API, e.g.
+		// WatchEvent, which are not stored.
+		if !strings.HasPrefix(typ.PkgPath(), "github.com/crossplane/provider-azure/") || strings.HasSuffix(gvk.Kind, "List") {
+			continue
+		}
+		versions[gvk.GroupKind()] = append(versions[gvk.GroupKind()], gvk)
+	}
+
+	for gk, gvks := range versions {
+		if len(gvks) < 2 {
+			continue
+		}
+		hubs := 0
+		for _, gvk := range gvks {
+			o, err := s.New(gvk)
+			if err != nil {
+				t.Fatal(err)
+			}
+			switch o.(type) {
+			case conversion.Hub:
+				hubs++
+			case conversion.Convertible:
+			default:
+				t.Errorf("%s is neither a conversion hub nor convertible", gvk)
+			}
+		}
+		if hubs != 1 {
+			t.Errorf("%s is served at %d versions with %d conversion hubs, want 1", gk, len(gvks), hubs)
+		}
+	}
+}
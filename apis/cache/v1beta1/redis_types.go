@@ -20,6 +20,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	apisv1alpha3 "github.com/crossplane/provider-azure/apis/v1alpha3"
 )
 
 const (
@@ -52,6 +54,19 @@ type SKU struct {
 	Capacity int `json:"capacity"`
 }
 
+// A RedisImport seeds a new Redis cache with the data of Redis database (RDB)
+// files, e.g. a snapshot exported from another cache.
+type RedisImport struct {
+	// FilesSecretRef references a secret key whose value lists the SAS URLs
+	// of the blobs of the files to import, one per line. The URLs must
+	// allow the files to be read.
+	FilesSecretRef xpv1.SecretKeySelector `json:"filesSecretRef"`
+
+	// Format of the files to import. Azure imports RDB files by default.
+	// +optional
+	Format *string `json:"format,omitempty"`
+}
+
 // RedisParameters define the desired state of an Azure Redis cluster.
 // https://docs.microsoft.com/en-us/rest/api/redis/redis/create#redisresource
 type RedisParameters struct {
@@ -72,12 +87,20 @@ type RedisParameters struct {
 	// +immutable
 	ResourceGroupNameSelector *xpv1.Selector `json:"resourceGroupNameSelector,omitempty"`
 
+	// SubscriptionID of the subscription the Redis cache is in. Defaults to the
+	// subscription of the credentials of the provider.
+	// +immutable
+	// +optional
+	SubscriptionID *string `json:"subscriptionID,omitempty"`
+
 	// Sku - The SKU of the Redis cache to deploy.
 	SKU SKU `json:"sku"`
 
-	// Location in which to create this resource.
+	// Location in which to create this resource. Defaults to the
+	// defaultLocation of the ProviderConfig.
 	// +immutable
-	Location string `json:"location"`
+	// +optional
+	Location string `json:"location,omitempty"`
 
 	// SubnetID specifies the full resource ID of a subnet in a virtual network
 	// to deploy the Redis cache in. Example format:
@@ -131,6 +154,14 @@ type RedisParameters struct {
 	// Tags - Resource tags.
 	// +optional
 	Tags map[string]string `json:"tags,omitempty"`
+
+	// ImportFrom seeds the Redis cache with the data of the supplied files
+	// once it is created. The Redis is not Available, and thus not bound to
+	// its claim, until the import succeeded. The data is imported only once.
+	// Only Premium caches can import data.
+	// +immutable
+	// +optional
+	ImportFrom *RedisImport `json:"importFrom,omitempty"`
 }
 
 // A RedisSpec defines the desired state of a Redis.
@@ -167,6 +198,9 @@ type RedisObservation struct {
 
 	// Name - Resource name.
 	Name string `json:"name,omitempty"`
+
+	// Import is the operation that imports the files of ImportFrom, if any.
+	Import *apisv1alpha3.AsyncOperation `json:"import,omitempty"`
 }
 
 // A RedisStatus represents the observed state of a Redis.
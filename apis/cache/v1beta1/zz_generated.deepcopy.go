@@ -22,6 +22,7 @@ package v1beta1
 
 import (
 	"github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/provider-azure/apis/v1alpha3"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -52,6 +53,27 @@ func (in *Redis) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisImport) DeepCopyInto(out *RedisImport) {
+	*out = *in
+	out.FilesSecretRef = in.FilesSecretRef
+	if in.Format != nil {
+		in, out := &in.Format, &out.Format
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisImport.
+func (in *RedisImport) DeepCopy() *RedisImport {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisImport)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RedisList) DeepCopyInto(out *RedisList) {
 	*out = *in
@@ -92,6 +114,11 @@ func (in *RedisObservation) DeepCopyInto(out *RedisObservation) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Import != nil {
+		in, out := &in.Import, &out.Import
+		*out = new(v1alpha3.AsyncOperation)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisObservation.
@@ -117,6 +144,11 @@ func (in *RedisParameters) DeepCopyInto(out *RedisParameters) {
 		*out = new(v1.Selector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SubscriptionID != nil {
+		in, out := &in.SubscriptionID, &out.SubscriptionID
+		*out = new(string)
+		**out = **in
+	}
 	out.SKU = in.SKU
 	if in.SubnetID != nil {
 		in, out := &in.SubnetID, &out.SubnetID
@@ -169,6 +201,11 @@ func (in *RedisParameters) DeepCopyInto(out *RedisParameters) {
 			(*out)[key] = val
 		}
 	}
+	if in.ImportFrom != nil {
+		in, out := &in.ImportFrom, &out.ImportFrom
+		*out = new(RedisImport)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisParameters.
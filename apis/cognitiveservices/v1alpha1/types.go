@@ -0,0 +1,171 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// A VersionUpgradeOption determines when Azure upgrades the model of a
+// deployment to a new version.
+// +kubebuilder:validation:Enum=OnceNewDefaultVersionAvailable;OnceCurrentVersionExpired;NoAutoUpgrade
+type VersionUpgradeOption string
+
+// Version upgrade options.
+const (
+	// VersionUpgradeOptionOnceNewDefaultVersionAvailable upgrades the model
+	// as soon as a new default version is available.
+	VersionUpgradeOptionOnceNewDefaultVersionAvailable VersionUpgradeOption = "OnceNewDefaultVersionAvailable"
+
+	// VersionUpgradeOptionOnceCurrentVersionExpired upgrades the model once
+	// its current version is retired.
+	VersionUpgradeOptionOnceCurrentVersionExpired VersionUpgradeOption = "OnceCurrentVersionExpired"
+
+	// VersionUpgradeOptionNoAutoUpgrade never upgrades the model. The
+	// deployment stops serving once its version is retired.
+	VersionUpgradeOptionNoAutoUpgrade VersionUpgradeOption = "NoAutoUpgrade"
+)
+
+// An OpenAIModel identifies the model an OpenAIDeployment serves.
+type OpenAIModel struct {
+	// Format of the model. Defaults to OpenAI.
+	// +immutable
+	// +optional
+	Format *string `json:"format,omitempty"`
+
+	// Name of the model, e.g. gpt-4o.
+	// +immutable
+	Name string `json:"name"`
+
+	// Version of the model, e.g. 2024-05-13. Defaults to the default version
+	// of the model in the location of the account.
+	// +optional
+	Version *string `json:"version,omitempty"`
+}
+
+// An OpenAISKU determines the kind and capacity of an OpenAIDeployment.
+type OpenAISKU struct {
+	// Name of the SKU, e.g. Standard, GlobalStandard or ProvisionedManaged.
+	Name string `json:"name"`
+
+	// Capacity of the deployment. Standard deployments measure it in
+	// thousands of tokens per minute, provisioned deployments in provisioned
+	// throughput units.
+	// +kubebuilder:validation:Minimum=1
+	Capacity int32 `json:"capacity"`
+}
+
+// OpenAIDeploymentParameters define the desired state of the deployment of a
+// model to an Azure OpenAI account.
+// https://docs.microsoft.com/en-us/rest/api/cognitiveservices/accountmanagement/deployments/create-or-update
+type OpenAIDeploymentParameters struct {
+	// ResourceGroupName of the account.
+	// +immutable
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// ResourceGroupNameRef to fetch resource group name.
+	// +immutable
+	ResourceGroupNameRef *xpv1.Reference `json:"resourceGroupNameRef,omitempty"`
+
+	// ResourceGroupNameSelector to select a reference to a resource group.
+	// +immutable
+	ResourceGroupNameSelector *xpv1.Selector `json:"resourceGroupNameSelector,omitempty"`
+
+	// SubscriptionID of the subscription the account is in. Defaults to the
+	// subscription of the credentials of the provider.
+	// +immutable
+	// +optional
+	SubscriptionID *string `json:"subscriptionID,omitempty"`
+
+	// AccountName is the name of the Cognitive Services account of kind
+	// OpenAI the model is deployed to.
+	// +immutable
+	AccountName string `json:"accountName"`
+
+	// Model the deployment serves.
+	Model OpenAIModel `json:"model"`
+
+	// SKU of the deployment.
+	SKU OpenAISKU `json:"sku"`
+
+	// VersionUpgradeOption determines when Azure upgrades the model to a new
+	// version. Defaults to OnceNewDefaultVersionAvailable.
+	// +optional
+	VersionUpgradeOption *VersionUpgradeOption `json:"versionUpgradeOption,omitempty"`
+
+	// RAIPolicyName is the name of the content filter of the deployment.
+	// Defaults to the default content filter of the account.
+	// +optional
+	RAIPolicyName *string `json:"raiPolicyName,omitempty"`
+}
+
+// An OpenAIDeploymentSpec defines the desired state of an OpenAIDeployment.
+type OpenAIDeploymentSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       OpenAIDeploymentParameters `json:"forProvider"`
+}
+
+// An OpenAIDeploymentObservation represents the observed state of the
+// deployment of a model to an Azure OpenAI account.
+type OpenAIDeploymentObservation struct {
+	// ID - Resource ID.
+	ID string `json:"id,omitempty"`
+
+	// ProvisioningState - Deployment provisioning state.
+	ProvisioningState string `json:"provisioningState,omitempty"`
+
+	// Endpoint of the account that serves the deployment.
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
+// An OpenAIDeploymentStatus represents the observed state of an
+// OpenAIDeployment.
+type OpenAIDeploymentStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          OpenAIDeploymentObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An OpenAIDeployment is a managed resource that represents the deployment of
+// a model to an Azure OpenAI account, which serves the model at the endpoint
+// of the account with the capacity of its SKU.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="MODEL",type="string",JSONPath=".spec.forProvider.model.name"
+// +kubebuilder:printcolumn:name="CAPACITY",type="integer",JSONPath=".spec.forProvider.sku.capacity"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,azure}
+type OpenAIDeployment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OpenAIDeploymentSpec   `json:"spec"`
+	Status OpenAIDeploymentStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OpenAIDeploymentList contains a list of OpenAIDeployment.
+type OpenAIDeploymentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OpenAIDeployment `json:"items"`
+}
@@ -0,0 +1,252 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// CapacityReservationGroupParameters define the desired state of an Azure
+// capacity reservation group.
+// https://docs.microsoft.com/en-us/rest/api/compute/capacity-reservation-groups/create-or-update
+type CapacityReservationGroupParameters struct {
+	// ResourceGroupName in which to create this resource.
+	// +immutable
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// ResourceGroupNameRef to fetch resource group name.
+	// +immutable
+	ResourceGroupNameRef *xpv1.Reference `json:"resourceGroupNameRef,omitempty"`
+
+	// ResourceGroupNameSelector to select a reference to a resource group.
+	// +immutable
+	ResourceGroupNameSelector *xpv1.Selector `json:"resourceGroupNameSelector,omitempty"`
+
+	// SubscriptionID of the subscription the capacity reservation group is
+	// in. Defaults to the subscription of the credentials of the provider.
+	// +immutable
+	// +optional
+	SubscriptionID *string `json:"subscriptionID,omitempty"`
+
+	// Location in which to create this resource. Defaults to the
+	// defaultLocation of the ProviderConfig.
+	// +immutable
+	// +optional
+	Location string `json:"location,omitempty"`
+
+	// Zones - Availability zones the capacity reservations of the group may
+	// be in. The reservations of a group without zones are regional.
+	// +immutable
+	// +optional
+	Zones []string `json:"zones,omitempty"`
+
+	// Tags - Resource tags.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// A CapacityReservationGroupSpec defines the desired state of a
+// CapacityReservationGroup.
+type CapacityReservationGroupSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       CapacityReservationGroupParameters `json:"forProvider"`
+}
+
+// A CapacityReservationGroupObservation represents the observed state of a
+// capacity reservation group in Azure.
+type CapacityReservationGroupObservation struct {
+	// ID - Resource ID.
+	ID string `json:"id,omitempty"`
+
+	// CapacityReservations - Resource IDs of the capacity reservations in
+	// the group.
+	CapacityReservations []string `json:"capacityReservations,omitempty"`
+
+	// VirtualMachinesAssociated - Resource IDs of the VMs and VM scale sets
+	// that are associated with the group.
+	VirtualMachinesAssociated []string `json:"virtualMachinesAssociated,omitempty"`
+}
+
+// A CapacityReservationGroupStatus represents the observed state of a
+// CapacityReservationGroup.
+type CapacityReservationGroupStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          CapacityReservationGroupObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A CapacityReservationGroup is a managed resource that represents an Azure
+// capacity reservation group, a collection of capacity reservations that VMs,
+// VM scale sets and AKS node pools can be associated with.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="LOCATION",type="string",JSONPath=".spec.forProvider.location"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,azure}
+type CapacityReservationGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CapacityReservationGroupSpec   `json:"spec"`
+	Status CapacityReservationGroupStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CapacityReservationGroupList contains a list of CapacityReservationGroup.
+type CapacityReservationGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CapacityReservationGroup `json:"items"`
+}
+
+// CapacityReservationParameters define the desired state of an Azure capacity
+// reservation.
+// https://docs.microsoft.com/en-us/rest/api/compute/capacity-reservations/create-or-update
+type CapacityReservationParameters struct {
+	// ResourceGroupName of the capacity reservation group of the
+	// reservation.
+	// +immutable
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// ResourceGroupNameRef to fetch resource group name.
+	// +immutable
+	ResourceGroupNameRef *xpv1.Reference `json:"resourceGroupNameRef,omitempty"`
+
+	// ResourceGroupNameSelector to select a reference to a resource group.
+	// +immutable
+	ResourceGroupNameSelector *xpv1.Selector `json:"resourceGroupNameSelector,omitempty"`
+
+	// SubscriptionID of the subscription the capacity reservation is in.
+	// Defaults to the subscription of the credentials of the provider.
+	// +immutable
+	// +optional
+	SubscriptionID *string `json:"subscriptionID,omitempty"`
+
+	// CapacityReservationGroupName is the name of the capacity reservation
+	// group of the reservation.
+	// +immutable
+	CapacityReservationGroupName string `json:"capacityReservationGroupName,omitempty"`
+
+	// CapacityReservationGroupNameRef to fetch the name of the capacity
+	// reservation group.
+	// +immutable
+	CapacityReservationGroupNameRef *xpv1.Reference `json:"capacityReservationGroupNameRef,omitempty"`
+
+	// CapacityReservationGroupNameSelector to select a reference to a
+	// capacity reservation group.
+	// +immutable
+	CapacityReservationGroupNameSelector *xpv1.Selector `json:"capacityReservationGroupNameSelector,omitempty"`
+
+	// Location in which to create this resource. It must be the location of
+	// the capacity reservation group. Defaults to the defaultLocation of the
+	// ProviderConfig.
+	// +immutable
+	// +optional
+	Location string `json:"location,omitempty"`
+
+	// SKU is the VM size the capacity is reserved for, e.g.
+	// Standard_D2s_v3.
+	// +immutable
+	SKU string `json:"sku"`
+
+	// Capacity is the number of VMs of the SKU that are reserved. It can be
+	// changed to scale the reservation up or down.
+	// +kubebuilder:validation:Minimum=0
+	Capacity int `json:"capacity"`
+
+	// Zones - Availability zone of the capacity reservation. It must be one
+	// of the zones of the capacity reservation group.
+	// +immutable
+	// +kubebuilder:validation:MaxItems=1
+	// +optional
+	Zones []string `json:"zones,omitempty"`
+
+	// Tags - Resource tags.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// A CapacityReservationSpec defines the desired state of a
+// CapacityReservation.
+type CapacityReservationSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       CapacityReservationParameters `json:"forProvider"`
+}
+
+// A CapacityReservationObservation represents the observed state of a
+// capacity reservation in Azure.
+type CapacityReservationObservation struct {
+	// ID - Resource ID.
+	ID string `json:"id,omitempty"`
+
+	// ReservationID - Unique ID Azure assigned to the capacity reservation.
+	ReservationID string `json:"reservationId,omitempty"`
+
+	// ProvisioningState - Capacity reservation provisioning state.
+	ProvisioningState string `json:"provisioningState,omitempty"`
+
+	// ProvisioningTime - Time at which the capacity reservation was last
+	// provisioned.
+	ProvisioningTime *metav1.Time `json:"provisioningTime,omitempty"`
+
+	// VirtualMachinesAssociated - Resource IDs of the VMs that use the
+	// reserved capacity.
+	VirtualMachinesAssociated []string `json:"virtualMachinesAssociated,omitempty"`
+}
+
+// A CapacityReservationStatus represents the observed state of a
+// CapacityReservation.
+type CapacityReservationStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          CapacityReservationObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A CapacityReservation is a managed resource that represents an Azure
+// capacity reservation, compute capacity of a VM size that is reserved in a
+// location or zone whether or not VMs use it.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.provisioningState"
+// +kubebuilder:printcolumn:name="GROUP",type="string",JSONPath=".spec.forProvider.capacityReservationGroupName"
+// +kubebuilder:printcolumn:name="SKU",type="string",JSONPath=".spec.forProvider.sku"
+// +kubebuilder:printcolumn:name="CAPACITY",type="integer",JSONPath=".spec.forProvider.capacity"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,azure}
+type CapacityReservation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CapacityReservationSpec   `json:"spec"`
+	Status CapacityReservationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CapacityReservationList contains a list of CapacityReservation.
+type CapacityReservationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CapacityReservation `json:"items"`
+}
@@ -0,0 +1,251 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// DedicatedHostGroupParameters define the desired state of an Azure dedicated
+// host group.
+// https://docs.microsoft.com/en-us/rest/api/compute/dedicatedhostgroups/createorupdate
+type DedicatedHostGroupParameters struct {
+	// ResourceGroupName in which to create this resource.
+	// +immutable
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// ResourceGroupNameRef to fetch resource group name.
+	// +immutable
+	ResourceGroupNameRef *xpv1.Reference `json:"resourceGroupNameRef,omitempty"`
+
+	// ResourceGroupNameSelector to select a reference to a resource group.
+	// +immutable
+	ResourceGroupNameSelector *xpv1.Selector `json:"resourceGroupNameSelector,omitempty"`
+
+	// SubscriptionID of the subscription the dedicated host group is in.
+	// Defaults to the subscription of the credentials of the provider.
+	// +immutable
+	// +optional
+	SubscriptionID *string `json:"subscriptionID,omitempty"`
+
+	// Location in which to create this resource. Defaults to the
+	// defaultLocation of the ProviderConfig.
+	// +immutable
+	// +optional
+	Location string `json:"location,omitempty"`
+
+	// Zones - Availability zone of the dedicated host group. Only a single
+	// zone is supported. The hosts of a group without a zone may be in any
+	// zone of its location.
+	// +immutable
+	// +kubebuilder:validation:MaxItems=1
+	// +optional
+	Zones []string `json:"zones,omitempty"`
+
+	// PlatformFaultDomainCount - Number of fault domains that the host group
+	// can span.
+	// +immutable
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=3
+	PlatformFaultDomainCount int `json:"platformFaultDomainCount"`
+
+	// Tags - Resource tags.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// A DedicatedHostGroupSpec defines the desired state of a DedicatedHostGroup.
+type DedicatedHostGroupSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       DedicatedHostGroupParameters `json:"forProvider"`
+}
+
+// A DedicatedHostGroupObservation represents the observed state of a
+// dedicated host group in Azure.
+type DedicatedHostGroupObservation struct {
+	// ID - Resource ID.
+	ID string `json:"id,omitempty"`
+
+	// Hosts - Resource IDs of the dedicated hosts in the group.
+	Hosts []string `json:"hosts,omitempty"`
+}
+
+// A DedicatedHostGroupStatus represents the observed state of a
+// DedicatedHostGroup.
+type DedicatedHostGroupStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          DedicatedHostGroupObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A DedicatedHostGroup is a managed resource that represents an Azure
+// dedicated host group, a collection of physical servers that are dedicated
+// to a single subscription.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="LOCATION",type="string",JSONPath=".spec.forProvider.location"
+// +kubebuilder:printcolumn:name="FAULT-DOMAINS",type="integer",JSONPath=".spec.forProvider.platformFaultDomainCount"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,azure}
+type DedicatedHostGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DedicatedHostGroupSpec   `json:"spec"`
+	Status DedicatedHostGroupStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DedicatedHostGroupList contains a list of DedicatedHostGroup.
+type DedicatedHostGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DedicatedHostGroup `json:"items"`
+}
+
+// DedicatedHostParameters define the desired state of an Azure dedicated
+// host.
+// https://docs.microsoft.com/en-us/rest/api/compute/dedicatedhosts/createorupdate
+type DedicatedHostParameters struct {
+	// ResourceGroupName of the dedicated host group of the host.
+	// +immutable
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// ResourceGroupNameRef to fetch resource group name.
+	// +immutable
+	ResourceGroupNameRef *xpv1.Reference `json:"resourceGroupNameRef,omitempty"`
+
+	// ResourceGroupNameSelector to select a reference to a resource group.
+	// +immutable
+	ResourceGroupNameSelector *xpv1.Selector `json:"resourceGroupNameSelector,omitempty"`
+
+	// SubscriptionID of the subscription the dedicated host is in. Defaults
+	// to the subscription of the credentials of the provider.
+	// +immutable
+	// +optional
+	SubscriptionID *string `json:"subscriptionID,omitempty"`
+
+	// HostGroupName is the name of the dedicated host group of the host. A
+	// host cannot be moved to another group once it is created.
+	// +immutable
+	HostGroupName string `json:"hostGroupName,omitempty"`
+
+	// HostGroupNameRef to fetch the name of the dedicated host group.
+	// +immutable
+	HostGroupNameRef *xpv1.Reference `json:"hostGroupNameRef,omitempty"`
+
+	// HostGroupNameSelector to select a reference to a dedicated host group.
+	// +immutable
+	HostGroupNameSelector *xpv1.Selector `json:"hostGroupNameSelector,omitempty"`
+
+	// Location in which to create this resource. It must be the location of
+	// the dedicated host group. Defaults to the defaultLocation of the
+	// ProviderConfig.
+	// +immutable
+	// +optional
+	Location string `json:"location,omitempty"`
+
+	// SKU of the dedicated host, i.e. its hardware generation and VM family,
+	// e.g. DSv3-Type1.
+	// +immutable
+	SKU string `json:"sku"`
+
+	// PlatformFaultDomain - Fault domain of the dedicated host within its
+	// group. Must be less than the platformFaultDomainCount of the group.
+	// +immutable
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	PlatformFaultDomain *int `json:"platformFaultDomain,omitempty"`
+
+	// AutoReplaceOnFailure specifies whether the dedicated host is replaced
+	// automatically in case of a failure. Azure defaults to true.
+	// +optional
+	AutoReplaceOnFailure *bool `json:"autoReplaceOnFailure,omitempty"`
+
+	// LicenseType - The software license type that is applied to the VMs
+	// deployed on the dedicated host. Azure defaults to None.
+	// +kubebuilder:validation:Enum=None;Windows_Server_Hybrid;Windows_Server_Perpetual
+	// +optional
+	LicenseType *string `json:"licenseType,omitempty"`
+
+	// Tags - Resource tags.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// A DedicatedHostSpec defines the desired state of a DedicatedHost.
+type DedicatedHostSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       DedicatedHostParameters `json:"forProvider"`
+}
+
+// A DedicatedHostObservation represents the observed state of a dedicated
+// host in Azure.
+type DedicatedHostObservation struct {
+	// ID - Resource ID.
+	ID string `json:"id,omitempty"`
+
+	// HostID - Unique ID Azure assigned to the dedicated host.
+	HostID string `json:"hostId,omitempty"`
+
+	// ProvisioningState - Dedicated host provisioning state.
+	ProvisioningState string `json:"provisioningState,omitempty"`
+
+	// VirtualMachines - Resource IDs of the VMs on the dedicated host.
+	VirtualMachines []string `json:"virtualMachines,omitempty"`
+}
+
+// A DedicatedHostStatus represents the observed state of a DedicatedHost.
+type DedicatedHostStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          DedicatedHostObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A DedicatedHost is a managed resource that represents an Azure dedicated
+// host, a physical server of a dedicated host group that only runs the VMs of
+// a single subscription.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.provisioningState"
+// +kubebuilder:printcolumn:name="GROUP",type="string",JSONPath=".spec.forProvider.hostGroupName"
+// +kubebuilder:printcolumn:name="SKU",type="string",JSONPath=".spec.forProvider.sku"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,azure}
+type DedicatedHost struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DedicatedHostSpec   `json:"spec"`
+	Status DedicatedHostStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DedicatedHostList contains a list of DedicatedHost.
+type DedicatedHostList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DedicatedHost `json:"items"`
+}
@@ -0,0 +1,363 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// Image template source types.
+const (
+	ImageSourcePlatformImage      = "PlatformImage"
+	ImageSourceManagedImage       = "ManagedImage"
+	ImageSourceSharedImageVersion = "SharedImageVersion"
+)
+
+// Image template customizer types.
+const (
+	ImageCustomizerShell          = "Shell"
+	ImageCustomizerPowerShell     = "PowerShell"
+	ImageCustomizerFile           = "File"
+	ImageCustomizerWindowsRestart = "WindowsRestart"
+	ImageCustomizerWindowsUpdate  = "WindowsUpdate"
+)
+
+// Image template distributor types.
+const (
+	ImageDistributorSharedImage  = "SharedImage"
+	ImageDistributorManagedImage = "ManagedImage"
+	ImageDistributorVHD          = "VHD"
+)
+
+// An ImageTemplateSource is the image an image template customizes.
+type ImageTemplateSource struct {
+	// Type of the source image. A PlatformImage is an Azure Marketplace
+	// image, a ManagedImage a managed image and a SharedImageVersion an
+	// image version of an Azure Compute Gallery.
+	// +kubebuilder:validation:Enum=PlatformImage;ManagedImage;SharedImageVersion
+	Type string `json:"type"`
+
+	// Publisher of the PlatformImage, e.g. Canonical.
+	// +optional
+	Publisher *string `json:"publisher,omitempty"`
+
+	// Offer of the PlatformImage, e.g. 0001-com-ubuntu-server-jammy.
+	// +optional
+	Offer *string `json:"offer,omitempty"`
+
+	// SKU of the PlatformImage, e.g. 22_04-lts-gen2.
+	// +optional
+	SKU *string `json:"sku,omitempty"`
+
+	// Version of the PlatformImage, e.g. latest.
+	// +optional
+	Version *string `json:"version,omitempty"`
+
+	// ImageID is the resource ID of the ManagedImage.
+	// +optional
+	ImageID *string `json:"imageId,omitempty"`
+
+	// ImageVersionID is the resource ID of the SharedImageVersion.
+	// +optional
+	ImageVersionID *string `json:"imageVersionId,omitempty"`
+}
+
+// A ConfigMapKeySelector selects a key of a ConfigMap.
+type ConfigMapKeySelector struct {
+	// Name of the ConfigMap.
+	Name string `json:"name"`
+
+	// Namespace of the ConfigMap.
+	Namespace string `json:"namespace"`
+
+	// Key of the ConfigMap to select.
+	Key string `json:"key"`
+}
+
+// An ImageTemplateCustomizer customizes the source image of an image
+// template, e.g. by running a script on it.
+type ImageTemplateCustomizer struct {
+	// Type of the customizer. Shell and PowerShell customizers run commands
+	// or a script, File customizers download a file to the image, and
+	// WindowsRestart and WindowsUpdate customizers restart or update
+	// Windows.
+	// +kubebuilder:validation:Enum=Shell;PowerShell;File;WindowsRestart;WindowsUpdate
+	Type string `json:"type"`
+
+	// Name of the customizer, shown in the logs of builds.
+	// +optional
+	Name *string `json:"name,omitempty"`
+
+	// ScriptURI is the URI of the script a Shell or PowerShell customizer
+	// runs.
+	// +optional
+	ScriptURI *string `json:"scriptUri,omitempty"`
+
+	// SHA256Checksum of the script or file a customizer downloads.
+	// +optional
+	SHA256Checksum *string `json:"sha256Checksum,omitempty"`
+
+	// Inline commands a Shell or PowerShell customizer runs.
+	// +optional
+	Inline []string `json:"inline,omitempty"`
+
+	// InlineConfigMapRef selects a key of a ConfigMap whose lines are the
+	// inline commands a Shell or PowerShell customizer runs, so that scripts
+	// can be maintained apart from the image template. It is read when the
+	// image template is created.
+	// +optional
+	InlineConfigMapRef *ConfigMapKeySelector `json:"inlineConfigMapRef,omitempty"`
+
+	// RunElevated runs the commands of a PowerShell customizer with elevated
+	// privileges.
+	// +optional
+	RunElevated *bool `json:"runElevated,omitempty"`
+
+	// SourceURI is the URI of the file a File customizer downloads.
+	// +optional
+	SourceURI *string `json:"sourceUri,omitempty"`
+
+	// Destination is the absolute path a File customizer downloads its file
+	// to.
+	// +optional
+	Destination *string `json:"destination,omitempty"`
+}
+
+// An ImageTemplateDistributor distributes the image an image template
+// builds.
+type ImageTemplateDistributor struct {
+	// Type of the distributor. SharedImage distributors publish an image
+	// version to an Azure Compute Gallery, ManagedImage distributors create
+	// a managed image and VHD distributors write a VHD to a storage account
+	// of the staging resource group.
+	// +kubebuilder:validation:Enum=SharedImage;ManagedImage;VHD
+	Type string `json:"type"`
+
+	// RunOutputName is the name of the run output of the distributor, which
+	// reports the resource ID of the image it distributed.
+	RunOutputName string `json:"runOutputName"`
+
+	// GalleryImageID is the resource ID of the gallery image definition a
+	// SharedImage distributor publishes an image version of.
+	// +optional
+	GalleryImageID *string `json:"galleryImageId,omitempty"`
+
+	// ReplicationRegions are the regions a SharedImage distributor
+	// replicates its image version to.
+	// +optional
+	ReplicationRegions []string `json:"replicationRegions,omitempty"`
+
+	// StorageAccountType of the image version of a SharedImage distributor.
+	// +kubebuilder:validation:Enum=Standard_LRS;Standard_ZRS
+	// +optional
+	StorageAccountType *string `json:"storageAccountType,omitempty"`
+
+	// ExcludeFromLatest excludes the image version of a SharedImage
+	// distributor from the latest version of its image definition.
+	// +optional
+	ExcludeFromLatest *bool `json:"excludeFromLatest,omitempty"`
+
+	// ImageID is the resource ID of the managed image a ManagedImage
+	// distributor creates.
+	// +optional
+	ImageID *string `json:"imageId,omitempty"`
+
+	// Location of the managed image a ManagedImage distributor creates.
+	// +optional
+	Location *string `json:"location,omitempty"`
+
+	// ArtifactTags are the tags of the image a distributor distributes.
+	// +optional
+	ArtifactTags map[string]string `json:"artifactTags,omitempty"`
+}
+
+// ImageTemplateParameters define the desired state of an Azure VM Image
+// Builder image template. Azure does not support updating image templates,
+// so only their tags can be changed once they exist.
+// https://docs.microsoft.com/en-us/rest/api/imagebuilder/virtual-machine-image-templates/create-or-update
+type ImageTemplateParameters struct {
+	// ResourceGroupName in which to create this resource.
+	// +immutable
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// ResourceGroupNameRef to fetch resource group name.
+	// +immutable
+	ResourceGroupNameRef *xpv1.Reference `json:"resourceGroupNameRef,omitempty"`
+
+	// ResourceGroupNameSelector to select a reference to a resource group.
+	// +immutable
+	ResourceGroupNameSelector *xpv1.Selector `json:"resourceGroupNameSelector,omitempty"`
+
+	// SubscriptionID of the subscription the image template is in. Defaults
+	// to the subscription of the credentials of the provider.
+	// +immutable
+	// +optional
+	SubscriptionID *string `json:"subscriptionID,omitempty"`
+
+	// Location in which to create this resource. Defaults to the
+	// defaultLocation of the ProviderConfig.
+	// +immutable
+	// +optional
+	Location string `json:"location,omitempty"`
+
+	// IdentityID is the resource ID of the user assigned managed identity
+	// Image Builder uses to read the source image and customizer files and
+	// to write the distributed images.
+	// +immutable
+	IdentityID string `json:"identityId"`
+
+	// Source image to customize.
+	// +immutable
+	Source ImageTemplateSource `json:"source"`
+
+	// Customizers customize the source image, in order.
+	// +immutable
+	// +optional
+	Customizers []ImageTemplateCustomizer `json:"customizers,omitempty"`
+
+	// Distributors distribute the customized image.
+	// +immutable
+	// +kubebuilder:validation:MinItems=1
+	Distributors []ImageTemplateDistributor `json:"distributors"`
+
+	// BuildTimeoutInMinutes is the maximum duration of a build. Azure
+	// defaults it to 240 minutes.
+	// +immutable
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=960
+	// +optional
+	BuildTimeoutInMinutes *int32 `json:"buildTimeoutInMinutes,omitempty"`
+
+	// VMSize of the VM that builds the image, e.g. Standard_D2s_v3.
+	// +immutable
+	// +optional
+	VMSize *string `json:"vmSize,omitempty"`
+
+	// OSDiskSizeGB is the size of the OS disk of the VM that builds the
+	// image. Defaults to the size of the OS disk of the source image.
+	// +immutable
+	// +optional
+	OSDiskSizeGB *int32 `json:"osDiskSizeGB,omitempty"`
+
+	// Tags - Resource tags.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// An ImageTemplateSpec defines the desired state of an ImageTemplate.
+type ImageTemplateSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       ImageTemplateParameters `json:"forProvider"`
+}
+
+// An ImageTemplateRunStatus is the status of the last build of an image
+// template.
+type ImageTemplateRunStatus struct {
+	// RunState of the build, e.g. Running, Succeeded or Failed.
+	RunState string `json:"runState,omitempty"`
+
+	// RunSubState of the build, e.g. Building, Customizing or Distributing.
+	RunSubState string `json:"runSubState,omitempty"`
+
+	// Message of the build, e.g. why it failed.
+	Message string `json:"message,omitempty"`
+
+	// StartTime of the build.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// EndTime of the build.
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+}
+
+// An ImageTemplateRunOutput is an image a distributor of an image template
+// distributed.
+type ImageTemplateRunOutput struct {
+	// Name of the run output, i.e. the runOutputName of its distributor.
+	Name string `json:"name"`
+
+	// ArtifactID is the resource ID of the distributed image, e.g. of a
+	// gallery image version.
+	ArtifactID string `json:"artifactId,omitempty"`
+
+	// ArtifactURI is the URI of the distributed VHD.
+	ArtifactURI string `json:"artifactUri,omitempty"`
+
+	// ProvisioningState of the run output.
+	ProvisioningState string `json:"provisioningState,omitempty"`
+}
+
+// An ImageTemplateObservation represents the observed state of an image
+// template in Azure.
+type ImageTemplateObservation struct {
+	// ID - Resource ID.
+	ID string `json:"id,omitempty"`
+
+	// ProvisioningState - Image template provisioning state.
+	ProvisioningState string `json:"provisioningState,omitempty"`
+
+	// ProvisioningError - Why the image template failed to provision.
+	ProvisioningError string `json:"provisioningError,omitempty"`
+
+	// LastBuild is the value of the azure.crossplane.io/build annotation the
+	// last build was started for.
+	LastBuild string `json:"lastBuild,omitempty"`
+
+	// LastRunStatus - Status of the last build.
+	LastRunStatus *ImageTemplateRunStatus `json:"lastRunStatus,omitempty"`
+
+	// RunOutputs - Images the distributors of the last successful builds
+	// distributed.
+	RunOutputs []ImageTemplateRunOutput `json:"runOutputs,omitempty"`
+}
+
+// An ImageTemplateStatus represents the observed state of an ImageTemplate.
+type ImageTemplateStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          ImageTemplateObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An ImageTemplate is a managed resource that represents an Azure VM Image
+// Builder image template, which customizes a source image and distributes
+// the result, e.g. to an Azure Compute Gallery. A build is started whenever
+// the value of its azure.crossplane.io/build annotation changes.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="BUILD",type="string",JSONPath=".status.atProvider.lastRunStatus.runState"
+// +kubebuilder:printcolumn:name="LOCATION",type="string",JSONPath=".spec.forProvider.location"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,azure}
+type ImageTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ImageTemplateSpec   `json:"spec"`
+	Status ImageTemplateStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ImageTemplateList contains a list of ImageTemplate.
+type ImageTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ImageTemplate `json:"items"`
+}
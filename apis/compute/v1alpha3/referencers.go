@@ -23,11 +23,37 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
-	networkv1alpha3 "github.com/crossplane/provider-azure/apis/network/v1alpha3"
+	networkv1beta1 "github.com/crossplane/provider-azure/apis/network/v1beta1"
 	"github.com/crossplane/provider-azure/apis/v1alpha3"
 )
 
+// AKSClusterID extracts the resource ID of an AKSCluster, for the specs of
+// resources that are associated with it.
+func AKSClusterID() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		c, ok := mg.(*AKSCluster)
+		if !ok {
+			return ""
+		}
+		return c.Status.ProviderID
+	}
+}
+
+// CapacityReservationGroupID extracts the resource ID of a
+// CapacityReservationGroup, for the specs of virtual machines, scale sets and
+// AKS node pools that use its reserved capacity.
+func CapacityReservationGroupID() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		g, ok := mg.(*CapacityReservationGroup)
+		if !ok {
+			return ""
+		}
+		return g.Status.AtProvider.ID
+	}
+}
+
 // ResolveReferences of this AKSCluster.
 func (mg *AKSCluster) ResolveReferences(ctx context.Context, c client.Reader) error {
 	r := reference.NewAPIResolver(c, mg)
@@ -51,8 +77,8 @@ func (mg *AKSCluster) ResolveReferences(ctx context.Context, c client.Reader) er
 		CurrentValue: mg.Spec.VnetSubnetID,
 		Reference:    mg.Spec.VnetSubnetIDRef,
 		Selector:     mg.Spec.VnetSubnetIDSelector,
-		To:           reference.To{Managed: &networkv1alpha3.Subnet{}, List: &networkv1alpha3.SubnetList{}},
-		Extract:      networkv1alpha3.SubnetID(),
+		To:           reference.To{Managed: &networkv1beta1.Subnet{}, List: &networkv1beta1.SubnetList{}},
+		Extract:      networkv1beta1.SubnetID(),
 	})
 	if err != nil {
 		return errors.Wrap(err, "spec.vnetSubnetID")
@@ -62,3 +88,136 @@ func (mg *AKSCluster) ResolveReferences(ctx context.Context, c client.Reader) er
 
 	return nil
 }
+
+// ResolveReferences of this DedicatedHostGroup.
+func (mg *DedicatedHostGroup) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.forProvider.resourceGroupName
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.ResourceGroupName,
+		Reference:    mg.Spec.ForProvider.ResourceGroupNameRef,
+		Selector:     mg.Spec.ForProvider.ResourceGroupNameSelector,
+		To:           reference.To{Managed: &v1alpha3.ResourceGroup{}, List: &v1alpha3.ResourceGroupList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.resourceGroupName")
+	}
+	mg.Spec.ForProvider.ResourceGroupName = rsp.ResolvedValue
+	mg.Spec.ForProvider.ResourceGroupNameRef = rsp.ResolvedReference
+
+	return nil
+}
+
+// ResolveReferences of this DedicatedHost.
+func (mg *DedicatedHost) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.forProvider.resourceGroupName
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.ResourceGroupName,
+		Reference:    mg.Spec.ForProvider.ResourceGroupNameRef,
+		Selector:     mg.Spec.ForProvider.ResourceGroupNameSelector,
+		To:           reference.To{Managed: &v1alpha3.ResourceGroup{}, List: &v1alpha3.ResourceGroupList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.resourceGroupName")
+	}
+	mg.Spec.ForProvider.ResourceGroupName = rsp.ResolvedValue
+	mg.Spec.ForProvider.ResourceGroupNameRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.hostGroupName
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.HostGroupName,
+		Reference:    mg.Spec.ForProvider.HostGroupNameRef,
+		Selector:     mg.Spec.ForProvider.HostGroupNameSelector,
+		To:           reference.To{Managed: &DedicatedHostGroup{}, List: &DedicatedHostGroupList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.hostGroupName")
+	}
+	mg.Spec.ForProvider.HostGroupName = rsp.ResolvedValue
+	mg.Spec.ForProvider.HostGroupNameRef = rsp.ResolvedReference
+
+	return nil
+}
+
+// ResolveReferences of this CapacityReservationGroup.
+func (mg *CapacityReservationGroup) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.forProvider.resourceGroupName
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.ResourceGroupName,
+		Reference:    mg.Spec.ForProvider.ResourceGroupNameRef,
+		Selector:     mg.Spec.ForProvider.ResourceGroupNameSelector,
+		To:           reference.To{Managed: &v1alpha3.ResourceGroup{}, List: &v1alpha3.ResourceGroupList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.resourceGroupName")
+	}
+	mg.Spec.ForProvider.ResourceGroupName = rsp.ResolvedValue
+	mg.Spec.ForProvider.ResourceGroupNameRef = rsp.ResolvedReference
+
+	return nil
+}
+
+// ResolveReferences of this CapacityReservation.
+func (mg *CapacityReservation) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.forProvider.resourceGroupName
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.ResourceGroupName,
+		Reference:    mg.Spec.ForProvider.ResourceGroupNameRef,
+		Selector:     mg.Spec.ForProvider.ResourceGroupNameSelector,
+		To:           reference.To{Managed: &v1alpha3.ResourceGroup{}, List: &v1alpha3.ResourceGroupList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.resourceGroupName")
+	}
+	mg.Spec.ForProvider.ResourceGroupName = rsp.ResolvedValue
+	mg.Spec.ForProvider.ResourceGroupNameRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.capacityReservationGroupName
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.CapacityReservationGroupName,
+		Reference:    mg.Spec.ForProvider.CapacityReservationGroupNameRef,
+		Selector:     mg.Spec.ForProvider.CapacityReservationGroupNameSelector,
+		To:           reference.To{Managed: &CapacityReservationGroup{}, List: &CapacityReservationGroupList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.capacityReservationGroupName")
+	}
+	mg.Spec.ForProvider.CapacityReservationGroupName = rsp.ResolvedValue
+	mg.Spec.ForProvider.CapacityReservationGroupNameRef = rsp.ResolvedReference
+
+	return nil
+}
+
+// ResolveReferences of this ImageTemplate.
+func (mg *ImageTemplate) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.forProvider.resourceGroupName
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.ResourceGroupName,
+		Reference:    mg.Spec.ForProvider.ResourceGroupNameRef,
+		Selector:     mg.Spec.ForProvider.ResourceGroupNameSelector,
+		To:           reference.To{Managed: &v1alpha3.ResourceGroup{}, List: &v1alpha3.ResourceGroupList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.resourceGroupName")
+	}
+	mg.Spec.ForProvider.ResourceGroupName = rsp.ResolvedValue
+	mg.Spec.ForProvider.ResourceGroupNameRef = rsp.ResolvedReference
+
+	return nil
+}
@@ -45,6 +45,54 @@ var (
 	AKSClusterGroupVersionKind = SchemeGroupVersion.WithKind(AKSClusterKind)
 )
 
+// DedicatedHostGroup type metadata.
+var (
+	DedicatedHostGroupKind             = reflect.TypeOf(DedicatedHostGroup{}).Name()
+	DedicatedHostGroupGroupKind        = schema.GroupKind{Group: Group, Kind: DedicatedHostGroupKind}.String()
+	DedicatedHostGroupKindAPIVersion   = DedicatedHostGroupKind + "." + SchemeGroupVersion.String()
+	DedicatedHostGroupGroupVersionKind = SchemeGroupVersion.WithKind(DedicatedHostGroupKind)
+)
+
+// DedicatedHost type metadata. Its group kind is DedicatedHostGroupKindName
+// because DedicatedHostGroupKind is the kind of a DedicatedHostGroup.
+var (
+	DedicatedHostKind             = reflect.TypeOf(DedicatedHost{}).Name()
+	DedicatedHostGroupKindName    = schema.GroupKind{Group: Group, Kind: DedicatedHostKind}.String()
+	DedicatedHostKindAPIVersion   = DedicatedHostKind + "." + SchemeGroupVersion.String()
+	DedicatedHostGroupVersionKind = SchemeGroupVersion.WithKind(DedicatedHostKind)
+)
+
+// CapacityReservationGroup type metadata.
+var (
+	CapacityReservationGroupKind             = reflect.TypeOf(CapacityReservationGroup{}).Name()
+	CapacityReservationGroupGroupKind        = schema.GroupKind{Group: Group, Kind: CapacityReservationGroupKind}.String()
+	CapacityReservationGroupKindAPIVersion   = CapacityReservationGroupKind + "." + SchemeGroupVersion.String()
+	CapacityReservationGroupGroupVersionKind = SchemeGroupVersion.WithKind(CapacityReservationGroupKind)
+)
+
+// CapacityReservation type metadata. Its group kind is
+// CapacityReservationGroupKindName because CapacityReservationGroupKind is
+// the kind of a CapacityReservationGroup.
+var (
+	CapacityReservationKind             = reflect.TypeOf(CapacityReservation{}).Name()
+	CapacityReservationGroupKindName    = schema.GroupKind{Group: Group, Kind: CapacityReservationKind}.String()
+	CapacityReservationKindAPIVersion   = CapacityReservationKind + "." + SchemeGroupVersion.String()
+	CapacityReservationGroupVersionKind = SchemeGroupVersion.WithKind(CapacityReservationKind)
+)
+
+// ImageTemplate type metadata.
+var (
+	ImageTemplateKind             = reflect.TypeOf(ImageTemplate{}).Name()
+	ImageTemplateGroupKind        = schema.GroupKind{Group: Group, Kind: ImageTemplateKind}.String()
+	ImageTemplateKindAPIVersion   = ImageTemplateKind + "." + SchemeGroupVersion.String()
+	ImageTemplateGroupVersionKind = SchemeGroupVersion.WithKind(ImageTemplateKind)
+)
+
 func init() {
 	SchemeBuilder.Register(&AKSCluster{}, &AKSClusterList{})
+	SchemeBuilder.Register(&DedicatedHostGroup{}, &DedicatedHostGroupList{})
+	SchemeBuilder.Register(&DedicatedHost{}, &DedicatedHostList{})
+	SchemeBuilder.Register(&CapacityReservationGroup{}, &CapacityReservationGroupList{})
+	SchemeBuilder.Register(&CapacityReservation{}, &CapacityReservationList{})
+	SchemeBuilder.Register(&ImageTemplate{}, &ImageTemplateList{})
 }
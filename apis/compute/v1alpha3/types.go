@@ -42,8 +42,10 @@ type AKSClusterParameters struct {
 	// retrieve its name
 	ResourceGroupNameSelector *xpv1.Selector `json:"resourceGroupNameSelector,omitempty"`
 
-	// Location is the Azure location that the cluster will be created in
-	Location string `json:"location"`
+	// Location is the Azure location that the cluster will be created in.
+	// Defaults to the defaultLocation of the ProviderConfig.
+	// +optional
+	Location string `json:"location,omitempty"`
 
 	// Version is the Kubernetes version that will be deployed to the cluster
 	Version string `json:"version"`
@@ -81,6 +83,16 @@ type AKSClusterParameters struct {
 	// cluster.
 	// +optional
 	DisableRBAC bool `json:"disableRBAC,omitempty"`
+
+	// AvailabilityZones to spread the nodes of the cluster across. The
+	// location of the cluster must support availability zones.
+	// +immutable
+	// +optional
+	AvailabilityZones []string `json:"availabilityZones,omitempty"`
+
+	// Tags of the cluster.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
 }
 
 // An AKSClusterSpec defines the desired state of a AKSCluster.
@@ -75,8 +75,10 @@ type CosmosDBAccountParameters struct {
 
 	// Location - The location of the resource. This will be one of the
 	// supported and registered Azure Geo Regions (e.g. West US, East US,
-	// Southeast Asia, etc.).
-	Location string `json:"location"`
+	// Southeast Asia, etc.). Defaults to the defaultLocation of the
+	// ProviderConfig.
+	// +optional
+	Location string `json:"location,omitempty"`
 
 	// Properties - Account properties like databaseAccountOfferType,
 	// ipRangeFilters, etc.
@@ -25,7 +25,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/reference"
 
 	"github.com/crossplane/provider-azure/apis/database/v1beta1"
-	networkv1alpha3 "github.com/crossplane/provider-azure/apis/network/v1alpha3"
+	networkv1beta1 "github.com/crossplane/provider-azure/apis/network/v1beta1"
 	"github.com/crossplane/provider-azure/apis/v1alpha3"
 )
 
@@ -52,8 +52,8 @@ func (mg *MySQLServerVirtualNetworkRule) ResolveReferences(ctx context.Context,
 		CurrentValue: mg.Spec.VirtualNetworkSubnetID,
 		Reference:    mg.Spec.VirtualNetworkSubnetIDRef,
 		Selector:     mg.Spec.VirtualNetworkSubnetIDSelector,
-		To:           reference.To{Managed: &networkv1alpha3.Subnet{}, List: &networkv1alpha3.SubnetList{}},
-		Extract:      networkv1alpha3.SubnetID(),
+		To:           reference.To{Managed: &networkv1beta1.Subnet{}, List: &networkv1beta1.SubnetList{}},
+		Extract:      networkv1beta1.SubnetID(),
 	})
 	if err != nil {
 		return errors.Wrap(err, "spec.virtualNetworkSubnetId")
@@ -101,8 +101,8 @@ func (mg *PostgreSQLServerVirtualNetworkRule) ResolveReferences(ctx context.Cont
 		CurrentValue: mg.Spec.VirtualNetworkSubnetID,
 		Reference:    mg.Spec.VirtualNetworkSubnetIDRef,
 		Selector:     mg.Spec.VirtualNetworkSubnetIDSelector,
-		To:           reference.To{Managed: &networkv1alpha3.Subnet{}, List: &networkv1alpha3.SubnetList{}},
-		Extract:      networkv1alpha3.SubnetID(),
+		To:           reference.To{Managed: &networkv1beta1.Subnet{}, List: &networkv1beta1.SubnetList{}},
+		Extract:      networkv1beta1.SubnetID(),
 	})
 	if err != nil {
 		return errors.Wrap(err, "spec.virtualNetworkSubnetId")
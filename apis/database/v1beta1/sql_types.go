@@ -34,6 +34,9 @@ const (
 // PostgreSQLServerPort is the port PostgreSQLServer listens to.
 const PostgreSQLServerPort = "5432"
 
+// MySQLServerPort is the port MySQLServer listens to.
+const MySQLServerPort = "3306"
+
 // +kubebuilder:object:root=true
 
 // A MySQLServer is a managed resource that represents an Azure MySQL Database
@@ -146,12 +149,20 @@ type SQLServerParameters struct {
 	// +immutable
 	ResourceGroupNameSelector *xpv1.Selector `json:"resourceGroupNameSelector,omitempty"`
 
+	// SubscriptionID of the subscription the SQLServer is in. Defaults to the
+	// subscription of the credentials of the provider.
+	// +immutable
+	// +optional
+	SubscriptionID *string `json:"subscriptionID,omitempty"`
+
 	// SKU is the billing information related properties of the server.
 	SKU SKU `json:"sku"`
 
-	// Location specifies the location of this SQLServer.
+	// Location specifies the location of this SQLServer. Defaults to the
+	// defaultLocation of the ProviderConfig.
 	// +immutable
-	Location string `json:"location"`
+	// +optional
+	Location string `json:"location,omitempty"`
 
 	// AdministratorLogin - The administrator's login name of a server. Can only be specified when the server is being created (and is required for creation).
 	// +immutable
@@ -164,7 +175,10 @@ type SQLServerParameters struct {
 
 	// TODO(hasheddan): support InfrastructureEncryption
 
-	// TODO(hasheddan): support PublicNetworkAccess
+	// PublicNetworkAccess - Whether or not public network access is allowed for this server. Possible values include: 'Enabled', 'Disabled'
+	// +kubebuilder:validation:Enum=Enabled;Disabled
+	// +optional
+	PublicNetworkAccess *string `json:"publicNetworkAccess,omitempty"`
 
 	// CreateMode - Possible values include: 'CreateModeDefault', 'CreateModePointInTimeRestore', 'CreateModeGeoRestore', 'CreateModeReplica'
 	// +optional
@@ -232,6 +246,10 @@ type SQLServerObservation struct {
 	// MasterServerID - The master server id of a replica server.
 	MasterServerID string `json:"masterServerId,omitempty"`
 
+	// SecondaryLocation - The paired location geo-redundant backups of the
+	// server are stored in, if enabled.
+	SecondaryLocation string `json:"secondaryLocation,omitempty"`
+
 	// LastOperation represents the state of the last operation started by the
 	// controller.
 	LastOperation apisv1alpha3.AsyncOperation `json:"lastOperation,omitempty"`
@@ -166,7 +166,7 @@ func (in *SKU) DeepCopy() *SKU {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SQLServerObservation) DeepCopyInto(out *SQLServerObservation) {
 	*out = *in
-	out.LastOperation = in.LastOperation
+	in.LastOperation.DeepCopyInto(&out.LastOperation)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLServerObservation.
@@ -192,7 +192,17 @@ func (in *SQLServerParameters) DeepCopyInto(out *SQLServerParameters) {
 		*out = new(v1.Selector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SubscriptionID != nil {
+		in, out := &in.SubscriptionID, &out.SubscriptionID
+		*out = new(string)
+		**out = **in
+	}
 	in.SKU.DeepCopyInto(&out.SKU)
+	if in.PublicNetworkAccess != nil {
+		in, out := &in.PublicNetworkAccess, &out.PublicNetworkAccess
+		*out = new(string)
+		**out = **in
+	}
 	if in.CreateMode != nil {
 		in, out := &in.CreateMode, &out.CreateMode
 		*out = new(CreateMode)
@@ -248,7 +258,7 @@ func (in *SQLServerSpec) DeepCopy() *SQLServerSpec {
 func (in *SQLServerStatus) DeepCopyInto(out *SQLServerStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
-	out.AtProvider = in.AtProvider
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLServerStatus.
@@ -0,0 +1,156 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// A SchemaCompatibility determines which changes may be made to the schemas
+// of a schema registry group.
+// +kubebuilder:validation:Enum=None;Backward;Forward
+type SchemaCompatibility string
+
+// Schema compatibilities.
+const (
+	// SchemaCompatibilityNone allows any change.
+	SchemaCompatibilityNone SchemaCompatibility = "None"
+
+	// SchemaCompatibilityBackward only allows changes that let consumers
+	// using a new schema read payloads written with the previous one.
+	SchemaCompatibilityBackward SchemaCompatibility = "Backward"
+
+	// SchemaCompatibilityForward only allows changes that let consumers
+	// using the previous schema read payloads written with a new one.
+	SchemaCompatibilityForward SchemaCompatibility = "Forward"
+)
+
+// A SchemaType is the serialization format of the schemas of a schema
+// registry group.
+// +kubebuilder:validation:Enum=Avro
+type SchemaType string
+
+// Schema types.
+const (
+	// SchemaTypeAvro schemas are Apache Avro schemas.
+	SchemaTypeAvro SchemaType = "Avro"
+)
+
+// SchemaRegistryGroupParameters define the desired state of an Azure Event
+// Hubs schema registry group.
+// https://docs.microsoft.com/en-us/rest/api/eventhub/preview/schema-registry/create-or-update
+type SchemaRegistryGroupParameters struct {
+	// ResourceGroupName of the Event Hubs namespace.
+	// +immutable
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// ResourceGroupNameRef to fetch resource group name.
+	// +immutable
+	ResourceGroupNameRef *xpv1.Reference `json:"resourceGroupNameRef,omitempty"`
+
+	// ResourceGroupNameSelector to select a reference to a resource group.
+	// +immutable
+	ResourceGroupNameSelector *xpv1.Selector `json:"resourceGroupNameSelector,omitempty"`
+
+	// SubscriptionID of the subscription the Event Hubs namespace is in.
+	// Defaults to the subscription of the credentials of the provider.
+	// +immutable
+	// +optional
+	SubscriptionID *string `json:"subscriptionID,omitempty"`
+
+	// NamespaceName is the name of the Event Hubs namespace the schema
+	// registry group is in. The namespace must be of the Standard tier or
+	// above.
+	// +immutable
+	NamespaceName string `json:"namespaceName"`
+
+	// SchemaCompatibility determines which changes may be made to the
+	// schemas of the group. Defaults to None.
+	// +optional
+	SchemaCompatibility *SchemaCompatibility `json:"schemaCompatibility,omitempty"`
+
+	// SchemaType is the serialization format of the schemas of the group.
+	// Defaults to Avro.
+	// +immutable
+	// +optional
+	SchemaType *SchemaType `json:"schemaType,omitempty"`
+
+	// GroupProperties are user defined properties of the group.
+	// +optional
+	GroupProperties map[string]string `json:"groupProperties,omitempty"`
+}
+
+// A SchemaRegistryGroupSpec defines the desired state of a
+// SchemaRegistryGroup.
+type SchemaRegistryGroupSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       SchemaRegistryGroupParameters `json:"forProvider"`
+}
+
+// A SchemaRegistryGroupObservation represents the observed state of an Azure
+// Event Hubs schema registry group in Azure.
+type SchemaRegistryGroupObservation struct {
+	// ID - Resource ID.
+	ID string `json:"id,omitempty"`
+
+	// ETag of the schema registry group.
+	ETag string `json:"eTag,omitempty"`
+
+	// CreatedAtUTC - Time the schema registry group was created.
+	CreatedAtUTC string `json:"createdAtUTC,omitempty"`
+
+	// UpdatedAtUTC - Time the schema registry group was last updated.
+	UpdatedAtUTC string `json:"updatedAtUTC,omitempty"`
+}
+
+// A SchemaRegistryGroupStatus represents the observed state of a
+// SchemaRegistryGroup.
+type SchemaRegistryGroupStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          SchemaRegistryGroupObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A SchemaRegistryGroup is a managed resource that represents an Azure Event
+// Hubs schema registry group, which holds the schemas that producers and
+// consumers of the event hubs of a namespace agree on.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="NAMESPACE",type="string",JSONPath=".spec.forProvider.namespaceName"
+// +kubebuilder:printcolumn:name="COMPATIBILITY",type="string",JSONPath=".spec.forProvider.schemaCompatibility"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,azure}
+type SchemaRegistryGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SchemaRegistryGroupSpec   `json:"spec"`
+	Status SchemaRegistryGroupStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SchemaRegistryGroupList contains a list of SchemaRegistryGroup.
+type SchemaRegistryGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SchemaRegistryGroup `json:"items"`
+}
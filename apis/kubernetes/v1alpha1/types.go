@@ -0,0 +1,131 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// ConnectedClusterParameters define the desired state of an Azure Arc
+// connected cluster.
+// https://docs.microsoft.com/en-us/rest/api/hybridkubernetes/connectedcluster/create
+type ConnectedClusterParameters struct {
+	// ResourceGroupName in which to create this resource.
+	// +immutable
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// ResourceGroupNameRef to fetch resource group name.
+	// +immutable
+	ResourceGroupNameRef *xpv1.Reference `json:"resourceGroupNameRef,omitempty"`
+
+	// ResourceGroupNameSelector to select a reference to a resource group.
+	// +immutable
+	ResourceGroupNameSelector *xpv1.Selector `json:"resourceGroupNameSelector,omitempty"`
+
+	// SubscriptionID of the subscription the connected cluster is in.
+	// Defaults to the subscription of the credentials of the provider.
+	// +immutable
+	// +optional
+	SubscriptionID *string `json:"subscriptionID,omitempty"`
+
+	// Location in which to create this resource. Defaults to the
+	// defaultLocation of the ProviderConfig.
+	// +immutable
+	// +optional
+	Location string `json:"location,omitempty"`
+
+	// AgentPublicKeyCertificate is the base64 encoded public key the Azure
+	// Arc agents of the cluster use for their initial handshake with Azure.
+	// If it is omitted a key pair is generated when the connected cluster is
+	// created, and its private key is written to the connection secret.
+	// +immutable
+	// +optional
+	AgentPublicKeyCertificate *string `json:"agentPublicKeyCertificate,omitempty"`
+
+	// Tags - Resource tags.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// A ConnectedClusterSpec defines the desired state of a ConnectedCluster.
+type ConnectedClusterSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       ConnectedClusterParameters `json:"forProvider"`
+}
+
+// A ConnectedClusterObservation represents the observed state of a connected
+// cluster in Azure.
+type ConnectedClusterObservation struct {
+	// ID - Resource ID.
+	ID string `json:"id,omitempty"`
+
+	// ProvisioningState - Connected cluster provisioning state.
+	ProvisioningState string `json:"provisioningState,omitempty"`
+
+	// KubernetesVersion - Kubernetes version the Azure Arc agents report.
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+
+	// TotalNodeCount - Number of nodes the Azure Arc agents report.
+	TotalNodeCount int `json:"totalNodeCount,omitempty"`
+
+	// AgentVersion - Version of the Azure Arc agents that run in the cluster.
+	AgentVersion string `json:"agentVersion,omitempty"`
+
+	// IdentityPrincipalID - Principal ID of the system assigned identity of
+	// the connected cluster.
+	IdentityPrincipalID string `json:"identityPrincipalId,omitempty"`
+}
+
+// A ConnectedClusterStatus represents the observed state of a
+// ConnectedCluster.
+type ConnectedClusterStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          ConnectedClusterObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A ConnectedCluster is a managed resource that represents an Azure Arc
+// connected cluster, the projection into Azure of a Kubernetes cluster that
+// runs outside of it, e.g. to apply Azure Policy to it or monitor it with
+// Azure Monitor. The cluster is connected by installing the Azure Arc agents
+// in it with the values its connection secret contains.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.provisioningState"
+// +kubebuilder:printcolumn:name="VERSION",type="string",JSONPath=".status.atProvider.kubernetesVersion"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,azure}
+type ConnectedCluster struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ConnectedClusterSpec   `json:"spec"`
+	Status ConnectedClusterStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ConnectedClusterList contains a list of ConnectedCluster.
+type ConnectedClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ConnectedCluster `json:"items"`
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/scheme"
+)
+
+// Package type metadata.
+const (
+	Group   = "kusto.azure.crossplane.io"
+	Version = "v1alpha1"
+)
+
+var (
+	// SchemeGroupVersion is group version used to register these objects
+	SchemeGroupVersion = schema.GroupVersion{Group: Group, Version: Version}
+
+	// SchemeBuilder is used to add go types to the GroupVersionKind scheme
+	SchemeBuilder = &scheme.Builder{GroupVersion: SchemeGroupVersion}
+)
+
+// KustoCluster type metadata.
+var (
+	KustoClusterKind             = reflect.TypeOf(KustoCluster{}).Name()
+	KustoClusterGroupKind        = schema.GroupKind{Group: Group, Kind: KustoClusterKind}.String()
+	KustoClusterKindAPIVersion   = KustoClusterKind + "." + SchemeGroupVersion.String()
+	KustoClusterGroupVersionKind = SchemeGroupVersion.WithKind(KustoClusterKind)
+)
+
+// KustoDatabase type metadata.
+var (
+	KustoDatabaseKind             = reflect.TypeOf(KustoDatabase{}).Name()
+	KustoDatabaseGroupKind        = schema.GroupKind{Group: Group, Kind: KustoDatabaseKind}.String()
+	KustoDatabaseKindAPIVersion   = KustoDatabaseKind + "." + SchemeGroupVersion.String()
+	KustoDatabaseGroupVersionKind = SchemeGroupVersion.WithKind(KustoDatabaseKind)
+)
+
+// KustoScript type metadata.
+var (
+	KustoScriptKind             = reflect.TypeOf(KustoScript{}).Name()
+	KustoScriptGroupKind        = schema.GroupKind{Group: Group, Kind: KustoScriptKind}.String()
+	KustoScriptKindAPIVersion   = KustoScriptKind + "." + SchemeGroupVersion.String()
+	KustoScriptGroupVersionKind = SchemeGroupVersion.WithKind(KustoScriptKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&KustoCluster{}, &KustoClusterList{})
+	SchemeBuilder.Register(&KustoDatabase{}, &KustoDatabaseList{})
+	SchemeBuilder.Register(&KustoScript{}, &KustoScriptList{})
+}
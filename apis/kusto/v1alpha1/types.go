@@ -0,0 +1,403 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// A KustoClusterSKU determines the VM size, tier and number of instances of a
+// KustoCluster.
+type KustoClusterSKU struct {
+	// Name of the SKU, e.g. Standard_E8ads_v5 or
+	// Dev(No SLA)_Standard_E2a_v4.
+	Name string `json:"name"`
+
+	// Tier of the SKU. Dev SKUs are Basic, all others Standard.
+	// +kubebuilder:validation:Enum=Basic;Standard
+	Tier string `json:"tier"`
+
+	// Capacity is the number of instances of the cluster. It is managed by
+	// Azure if the cluster scales automatically.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Capacity *int32 `json:"capacity,omitempty"`
+}
+
+// An OptimizedAutoscale scales a KustoCluster automatically between a minimum
+// and a maximum number of instances, based on its load.
+type OptimizedAutoscale struct {
+	// Enabled scales the cluster automatically.
+	Enabled bool `json:"enabled"`
+
+	// Minimum number of instances of the cluster.
+	// +kubebuilder:validation:Minimum=1
+	Minimum int32 `json:"minimum"`
+
+	// Maximum number of instances of the cluster.
+	// +kubebuilder:validation:Minimum=1
+	Maximum int32 `json:"maximum"`
+}
+
+// KustoClusterParameters define the desired state of an Azure Data Explorer
+// cluster.
+// https://docs.microsoft.com/en-us/rest/api/azurerekusto/clusters/create-or-update
+type KustoClusterParameters struct {
+	// ResourceGroupName in which to create this resource.
+	// +immutable
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// ResourceGroupNameRef to fetch resource group name.
+	// +immutable
+	ResourceGroupNameRef *xpv1.Reference `json:"resourceGroupNameRef,omitempty"`
+
+	// ResourceGroupNameSelector to select a reference to a resource group.
+	// +immutable
+	ResourceGroupNameSelector *xpv1.Selector `json:"resourceGroupNameSelector,omitempty"`
+
+	// SubscriptionID of the subscription the cluster is in. Defaults to the
+	// subscription of the credentials of the provider.
+	// +immutable
+	// +optional
+	SubscriptionID *string `json:"subscriptionID,omitempty"`
+
+	// Location in which to create this resource. Defaults to the
+	// defaultLocation of the ProviderConfig.
+	// +immutable
+	// +optional
+	Location string `json:"location,omitempty"`
+
+	// Zones - Availability zones the instances of the cluster are spread
+	// across.
+	// +immutable
+	// +optional
+	Zones []string `json:"zones,omitempty"`
+
+	// SKU of the cluster.
+	SKU KustoClusterSKU `json:"sku"`
+
+	// OptimizedAutoscale scales the cluster automatically.
+	// +optional
+	OptimizedAutoscale *OptimizedAutoscale `json:"optimizedAutoscale,omitempty"`
+
+	// EnableStreamingIngest enables the streaming ingestion of data, with
+	// lower latency than queued ingestion.
+	// +optional
+	EnableStreamingIngest *bool `json:"enableStreamingIngest,omitempty"`
+
+	// EnablePurge enables purging data, e.g. to comply with GDPR requests.
+	// +optional
+	EnablePurge *bool `json:"enablePurge,omitempty"`
+
+	// EnableDiskEncryption encrypts the disks of the cluster.
+	// +optional
+	EnableDiskEncryption *bool `json:"enableDiskEncryption,omitempty"`
+
+	// PublicNetworkAccess to the cluster.
+	// +kubebuilder:validation:Enum=Enabled;Disabled
+	// +optional
+	PublicNetworkAccess *string `json:"publicNetworkAccess,omitempty"`
+
+	// Tags - Resource tags.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// A KustoClusterSpec defines the desired state of a KustoCluster.
+type KustoClusterSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       KustoClusterParameters `json:"forProvider"`
+}
+
+// A KustoClusterObservation represents the observed state of an Azure Data
+// Explorer cluster.
+type KustoClusterObservation struct {
+	// ID - Resource ID.
+	ID string `json:"id,omitempty"`
+
+	// State of the cluster, e.g. Running or Stopped.
+	State string `json:"state,omitempty"`
+
+	// StateReason - Why the cluster is in its state.
+	StateReason string `json:"stateReason,omitempty"`
+
+	// ProvisioningState - Cluster provisioning state.
+	ProvisioningState string `json:"provisioningState,omitempty"`
+
+	// URI the cluster is queried at.
+	URI string `json:"uri,omitempty"`
+
+	// DataIngestionURI data is ingested into the cluster at.
+	DataIngestionURI string `json:"dataIngestionUri,omitempty"`
+}
+
+// A KustoClusterStatus represents the observed state of a KustoCluster.
+type KustoClusterStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          KustoClusterObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A KustoCluster is a managed resource that represents an Azure Data Explorer
+// cluster. It publishes the URIs it is queried at and ingests data at as
+// connection details.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.state"
+// +kubebuilder:printcolumn:name="SKU",type="string",JSONPath=".spec.forProvider.sku.name"
+// +kubebuilder:printcolumn:name="LOCATION",type="string",JSONPath=".spec.forProvider.location"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,azure}
+type KustoCluster struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KustoClusterSpec   `json:"spec"`
+	Status KustoClusterStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// KustoClusterList contains a list of KustoCluster.
+type KustoClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KustoCluster `json:"items"`
+}
+
+// KustoDatabaseParameters define the desired state of a read-write database
+// of an Azure Data Explorer cluster.
+// https://docs.microsoft.com/en-us/rest/api/azurerekusto/databases/create-or-update
+type KustoDatabaseParameters struct {
+	// ResourceGroupName of the cluster of the database.
+	// +immutable
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// ResourceGroupNameRef to fetch resource group name.
+	// +immutable
+	ResourceGroupNameRef *xpv1.Reference `json:"resourceGroupNameRef,omitempty"`
+
+	// ResourceGroupNameSelector to select a reference to a resource group.
+	// +immutable
+	ResourceGroupNameSelector *xpv1.Selector `json:"resourceGroupNameSelector,omitempty"`
+
+	// SubscriptionID of the subscription the database is in. Defaults to
+	// the subscription of the credentials of the provider.
+	// +immutable
+	// +optional
+	SubscriptionID *string `json:"subscriptionID,omitempty"`
+
+	// ClusterName is the name of the cluster of the database.
+	// +immutable
+	ClusterName string `json:"clusterName,omitempty"`
+
+	// ClusterNameRef to fetch the name of the cluster.
+	// +immutable
+	ClusterNameRef *xpv1.Reference `json:"clusterNameRef,omitempty"`
+
+	// ClusterNameSelector to select a reference to a cluster.
+	// +immutable
+	ClusterNameSelector *xpv1.Selector `json:"clusterNameSelector,omitempty"`
+
+	// Location of the database. It must be the location of its cluster.
+	// Defaults to the defaultLocation of the ProviderConfig.
+	// +immutable
+	// +optional
+	Location string `json:"location,omitempty"`
+
+	// SoftDeletePeriod is how long data is kept queryable, as an ISO 8601
+	// duration, e.g. P365D. Data is kept forever if unset.
+	// +optional
+	SoftDeletePeriod *string `json:"softDeletePeriod,omitempty"`
+
+	// HotCachePeriod is how long data is kept in the cache of the cluster
+	// for fast queries, as an ISO 8601 duration, e.g. P31D.
+	// +optional
+	HotCachePeriod *string `json:"hotCachePeriod,omitempty"`
+}
+
+// A KustoDatabaseSpec defines the desired state of a KustoDatabase.
+type KustoDatabaseSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       KustoDatabaseParameters `json:"forProvider"`
+}
+
+// A KustoDatabaseObservation represents the observed state of a database of
+// an Azure Data Explorer cluster.
+type KustoDatabaseObservation struct {
+	// ID - Resource ID.
+	ID string `json:"id,omitempty"`
+
+	// ProvisioningState - Database provisioning state.
+	ProvisioningState string `json:"provisioningState,omitempty"`
+}
+
+// A KustoDatabaseStatus represents the observed state of a KustoDatabase.
+type KustoDatabaseStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          KustoDatabaseObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A KustoDatabase is a managed resource that represents a read-write database
+// of an Azure Data Explorer cluster.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="CLUSTER",type="string",JSONPath=".spec.forProvider.clusterName"
+// +kubebuilder:printcolumn:name="HOT-CACHE",type="string",JSONPath=".spec.forProvider.hotCachePeriod"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,azure}
+type KustoDatabase struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KustoDatabaseSpec   `json:"spec"`
+	Status KustoDatabaseStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// KustoDatabaseList contains a list of KustoDatabase.
+type KustoDatabaseList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KustoDatabase `json:"items"`
+}
+
+// KustoScriptParameters define the desired state of a script of a database of
+// an Azure Data Explorer cluster.
+// https://docs.microsoft.com/en-us/rest/api/azurerekusto/scripts/create-or-update
+type KustoScriptParameters struct {
+	// ResourceGroupName of the cluster of the script.
+	// +immutable
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// ResourceGroupNameRef to fetch resource group name.
+	// +immutable
+	ResourceGroupNameRef *xpv1.Reference `json:"resourceGroupNameRef,omitempty"`
+
+	// ResourceGroupNameSelector to select a reference to a resource group.
+	// +immutable
+	ResourceGroupNameSelector *xpv1.Selector `json:"resourceGroupNameSelector,omitempty"`
+
+	// SubscriptionID of the subscription the script is in. Defaults to the
+	// subscription of the credentials of the provider.
+	// +immutable
+	// +optional
+	SubscriptionID *string `json:"subscriptionID,omitempty"`
+
+	// ClusterName is the name of the cluster of the script.
+	// +immutable
+	ClusterName string `json:"clusterName,omitempty"`
+
+	// ClusterNameRef to fetch the name of the cluster.
+	// +immutable
+	ClusterNameRef *xpv1.Reference `json:"clusterNameRef,omitempty"`
+
+	// ClusterNameSelector to select a reference to a cluster.
+	// +immutable
+	ClusterNameSelector *xpv1.Selector `json:"clusterNameSelector,omitempty"`
+
+	// DatabaseName is the name of the database the script runs in.
+	// +immutable
+	DatabaseName string `json:"databaseName,omitempty"`
+
+	// DatabaseNameRef to fetch the name of the database.
+	// +immutable
+	DatabaseNameRef *xpv1.Reference `json:"databaseNameRef,omitempty"`
+
+	// DatabaseNameSelector to select a reference to a database.
+	// +immutable
+	DatabaseNameSelector *xpv1.Selector `json:"databaseNameSelector,omitempty"`
+
+	// Content of the script, i.e. Kusto control commands such as
+	// .create-merge table. The script runs again whenever its content
+	// changes, so its commands should be idempotent.
+	Content string `json:"content"`
+
+	// ForceUpdateTag runs the script again whenever it changes, even if its
+	// content did not. Defaults to a hash of the content.
+	// +optional
+	ForceUpdateTag *string `json:"forceUpdateTag,omitempty"`
+
+	// ContinueOnErrors runs the remaining commands of the script if one of
+	// them fails.
+	// +optional
+	ContinueOnErrors *bool `json:"continueOnErrors,omitempty"`
+}
+
+// A KustoScriptSpec defines the desired state of a KustoScript.
+type KustoScriptSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       KustoScriptParameters `json:"forProvider"`
+}
+
+// A KustoScriptObservation represents the observed state of a script of a
+// database of an Azure Data Explorer cluster.
+type KustoScriptObservation struct {
+	// ID - Resource ID.
+	ID string `json:"id,omitempty"`
+
+	// ProvisioningState - Script provisioning state. It is Succeeded once
+	// the script ran.
+	ProvisioningState string `json:"provisioningState,omitempty"`
+
+	// ForceUpdateTag of the last run of the script.
+	ForceUpdateTag string `json:"forceUpdateTag,omitempty"`
+}
+
+// A KustoScriptStatus represents the observed state of a KustoScript.
+type KustoScriptStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          KustoScriptObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A KustoScript is a managed resource that represents a script of a database
+// of an Azure Data Explorer cluster, which runs control commands, e.g. to
+// create its tables and functions, so that the schema of a database is
+// reconciled along with it. Deleting a script does not undo its commands.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="DATABASE",type="string",JSONPath=".spec.forProvider.databaseName"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.provisioningState"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,azure}
+type KustoScript struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KustoScriptSpec   `json:"spec"`
+	Status KustoScriptStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// KustoScriptList contains a list of KustoScript.
+type KustoScriptList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KustoScript `json:"items"`
+}
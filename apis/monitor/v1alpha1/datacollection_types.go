@@ -0,0 +1,561 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// A DataCollectionKind determines the operating system of the machines data
+// collection endpoints and rules collect data from.
+// +kubebuilder:validation:Enum=Linux;Windows
+type DataCollectionKind string
+
+// Data collection kinds.
+const (
+	DataCollectionKindLinux   DataCollectionKind = "Linux"
+	DataCollectionKindWindows DataCollectionKind = "Windows"
+)
+
+// Streams of the data collected by data collection rules.
+const (
+	// StreamPrometheusMetrics is the stream of the metrics scraped by the
+	// managed service for Prometheus.
+	StreamPrometheusMetrics = "Microsoft-PrometheusMetrics"
+
+	// StreamPerf is the stream of the performance counters the Azure Monitor
+	// agent collects, which are sent to the Perf table of a Log Analytics
+	// workspace.
+	StreamPerf = "Microsoft-Perf"
+
+	// StreamInsightsMetrics is the stream of the performance counters the
+	// Azure Monitor agent collects as Azure Monitor Metrics.
+	StreamInsightsMetrics = "Microsoft-InsightsMetrics"
+
+	// StreamSyslog is the stream of the syslog events of Linux machines.
+	StreamSyslog = "Microsoft-Syslog"
+
+	// StreamEvent is the stream of the Windows event logs of Windows
+	// machines.
+	StreamEvent = "Microsoft-Event"
+)
+
+// DataCollectionEndpointParameters define the desired state of an Azure
+// Monitor data collection endpoint.
+// https://learn.microsoft.com/en-us/rest/api/monitor/data-collection-endpoints/create
+type DataCollectionEndpointParameters struct {
+	// ResourceGroupName in which to create this resource.
+	// +immutable
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// ResourceGroupNameRef to fetch resource group name.
+	// +immutable
+	ResourceGroupNameRef *xpv1.Reference `json:"resourceGroupNameRef,omitempty"`
+
+	// ResourceGroupNameSelector to select a reference to a resource group.
+	// +immutable
+	ResourceGroupNameSelector *xpv1.Selector `json:"resourceGroupNameSelector,omitempty"`
+
+	// SubscriptionID of the subscription the data collection endpoint is in.
+	// Defaults to the subscription of the credentials of the provider.
+	// +immutable
+	// +optional
+	SubscriptionID *string `json:"subscriptionID,omitempty"`
+
+	// Location of the data collection endpoint. It must be the location of
+	// the Azure Monitor workspace its metrics are sent to. Defaults to the
+	// default location of the ProviderConfig.
+	// +immutable
+	// +optional
+	Location string `json:"location,omitempty"`
+
+	// Kind of the machines the data collection endpoint collects data from.
+	// +immutable
+	// +optional
+	Kind *DataCollectionKind `json:"kind,omitempty"`
+
+	// Description of the data collection endpoint.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// PublicNetworkAccess determines whether data may be sent to the data
+	// collection endpoint from public networks. Defaults to Enabled.
+	// +optional
+	PublicNetworkAccess *PublicNetworkAccess `json:"publicNetworkAccess,omitempty"`
+
+	// Tags - Resource tags.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// A DataCollectionEndpointSpec defines the desired state of a
+// DataCollectionEndpoint.
+type DataCollectionEndpointSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       DataCollectionEndpointParameters `json:"forProvider"`
+}
+
+// A DataCollectionEndpointObservation represents the observed state of an
+// Azure Monitor data collection endpoint in Azure.
+type DataCollectionEndpointObservation struct {
+	// ID - Resource ID.
+	ID string `json:"id,omitempty"`
+
+	// ProvisioningState - Data collection endpoint provisioning state.
+	ProvisioningState string `json:"provisioningState,omitempty"`
+
+	// ConfigurationAccessEndpoint is the endpoint agents read the data
+	// collection rules associated with them from.
+	ConfigurationAccessEndpoint string `json:"configurationAccessEndpoint,omitempty"`
+
+	// LogsIngestionEndpoint is the endpoint logs are sent to.
+	LogsIngestionEndpoint string `json:"logsIngestionEndpoint,omitempty"`
+
+	// MetricsIngestionEndpoint is the endpoint metrics are sent to.
+	MetricsIngestionEndpoint string `json:"metricsIngestionEndpoint,omitempty"`
+}
+
+// A DataCollectionEndpointStatus represents the observed state of a
+// DataCollectionEndpoint.
+type DataCollectionEndpointStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          DataCollectionEndpointObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A DataCollectionEndpoint is a managed resource that represents an Azure
+// Monitor data collection endpoint, which Azure Monitor agents send the
+// data they collect to.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.provisioningState"
+// +kubebuilder:printcolumn:name="LOCATION",type="string",JSONPath=".spec.forProvider.location"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,azure}
+type DataCollectionEndpoint struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DataCollectionEndpointSpec   `json:"spec"`
+	Status DataCollectionEndpointStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DataCollectionEndpointList contains a list of DataCollectionEndpoint.
+type DataCollectionEndpointList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DataCollectionEndpoint `json:"items"`
+}
+
+// A PrometheusForwarderDataSource forwards the metrics the managed service
+// for Prometheus scrapes.
+type PrometheusForwarderDataSource struct {
+	// Name of the data source, which is unique within the data collection
+	// rule.
+	Name string `json:"name"`
+
+	// Streams the data source sends data to.
+	// +kubebuilder:validation:MinItems=1
+	Streams []string `json:"streams"`
+
+	// LabelIncludeFilter limits the forwarded metrics to those with the
+	// supplied label values.
+	// +optional
+	LabelIncludeFilter map[string]string `json:"labelIncludeFilter,omitempty"`
+}
+
+// A PerformanceCounterDataSource collects the performance counters of the
+// machines the Azure Monitor agent runs on.
+type PerformanceCounterDataSource struct {
+	// Name of the data source, which is unique within the data collection
+	// rule.
+	Name string `json:"name"`
+
+	// Streams the data source sends data to, i.e. Microsoft-Perf or
+	// Microsoft-InsightsMetrics.
+	// +kubebuilder:validation:MinItems=1
+	Streams []string `json:"streams"`
+
+	// SamplingFrequencyInSeconds is the interval at which the counters are
+	// sampled.
+	// +kubebuilder:validation:Minimum=1
+	SamplingFrequencyInSeconds int32 `json:"samplingFrequencyInSeconds"`
+
+	// CounterSpecifiers of the collected counters, e.g.
+	// \Processor(_Total)\% Processor Time.
+	// +kubebuilder:validation:MinItems=1
+	CounterSpecifiers []string `json:"counterSpecifiers"`
+}
+
+// A SyslogDataSource collects the syslog events of Linux machines.
+type SyslogDataSource struct {
+	// Name of the data source, which is unique within the data collection
+	// rule.
+	Name string `json:"name"`
+
+	// Streams the data source sends data to, i.e. Microsoft-Syslog.
+	// +kubebuilder:validation:MinItems=1
+	Streams []string `json:"streams"`
+
+	// FacilityNames of the collected events, e.g. auth or daemon. Events of
+	// all facilities are collected if * is one of them.
+	// +kubebuilder:validation:MinItems=1
+	FacilityNames []string `json:"facilityNames"`
+
+	// LogLevels of the collected events, e.g. Warning or Error.
+	// +kubebuilder:validation:MinItems=1
+	LogLevels []string `json:"logLevels"`
+}
+
+// A WindowsEventLogDataSource collects the Windows event logs of Windows
+// machines.
+type WindowsEventLogDataSource struct {
+	// Name of the data source, which is unique within the data collection
+	// rule.
+	Name string `json:"name"`
+
+	// Streams the data source sends data to, i.e. Microsoft-Event.
+	// +kubebuilder:validation:MinItems=1
+	Streams []string `json:"streams"`
+
+	// XPathQueries of the collected events, e.g.
+	// System!*[System[(Level=1 or Level=2)]].
+	// +kubebuilder:validation:MinItems=1
+	XPathQueries []string `json:"xPathQueries"`
+}
+
+// DataCollectionRuleDataSources are the sources a data collection rule
+// collects data from.
+type DataCollectionRuleDataSources struct {
+	// PrometheusForwarder data sources forward the metrics scraped by the
+	// managed service for Prometheus.
+	// +optional
+	PrometheusForwarder []PrometheusForwarderDataSource `json:"prometheusForwarder,omitempty"`
+
+	// PerformanceCounters data sources collect the performance counters of
+	// virtual machines and AKS nodes.
+	// +optional
+	PerformanceCounters []PerformanceCounterDataSource `json:"performanceCounters,omitempty"`
+
+	// Syslog data sources collect the syslog events of Linux virtual
+	// machines and AKS nodes.
+	// +optional
+	Syslog []SyslogDataSource `json:"syslog,omitempty"`
+
+	// WindowsEventLogs data sources collect the Windows event logs of
+	// Windows virtual machines and AKS nodes.
+	// +optional
+	WindowsEventLogs []WindowsEventLogDataSource `json:"windowsEventLogs,omitempty"`
+}
+
+// A MonitoringAccountDestination sends data to an Azure Monitor workspace.
+type MonitoringAccountDestination struct {
+	// Name of the destination, which is unique within the data collection
+	// rule.
+	Name string `json:"name"`
+
+	// AccountResourceID is the resource ID of the Azure Monitor workspace.
+	// +optional
+	AccountResourceID string `json:"accountResourceID,omitempty"`
+
+	// AccountResourceIDRef to fetch the resource ID of an
+	// AzureMonitorWorkspace.
+	// +optional
+	AccountResourceIDRef *xpv1.Reference `json:"accountResourceIDRef,omitempty"`
+
+	// AccountResourceIDSelector to select a reference to an
+	// AzureMonitorWorkspace.
+	// +optional
+	AccountResourceIDSelector *xpv1.Selector `json:"accountResourceIDSelector,omitempty"`
+}
+
+// A LogAnalyticsDestination sends data to a Log Analytics workspace.
+type LogAnalyticsDestination struct {
+	// Name of the destination, which is unique within the data collection
+	// rule.
+	Name string `json:"name"`
+
+	// WorkspaceResourceID is the resource ID of the Log Analytics workspace.
+	WorkspaceResourceID string `json:"workspaceResourceID"`
+}
+
+// DataCollectionRuleDestinations are the destinations a data collection rule
+// sends data to.
+type DataCollectionRuleDestinations struct {
+	// MonitoringAccounts are the Azure Monitor workspaces the rule sends
+	// metrics to.
+	// +optional
+	MonitoringAccounts []MonitoringAccountDestination `json:"monitoringAccounts,omitempty"`
+
+	// LogAnalytics are the Log Analytics workspaces the rule sends logs and
+	// performance counters to.
+	// +optional
+	LogAnalytics []LogAnalyticsDestination `json:"logAnalytics,omitempty"`
+}
+
+// A DataFlow sends the data of some streams to some destinations.
+type DataFlow struct {
+	// Streams whose data is sent.
+	// +kubebuilder:validation:MinItems=1
+	Streams []string `json:"streams"`
+
+	// Destinations the data is sent to, by their name.
+	// +kubebuilder:validation:MinItems=1
+	Destinations []string `json:"destinations"`
+}
+
+// DataCollectionRuleParameters define the desired state of an Azure Monitor
+// data collection rule.
+// https://learn.microsoft.com/en-us/rest/api/monitor/data-collection-rules/create
+type DataCollectionRuleParameters struct {
+	// ResourceGroupName in which to create this resource.
+	// +immutable
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// ResourceGroupNameRef to fetch resource group name.
+	// +immutable
+	ResourceGroupNameRef *xpv1.Reference `json:"resourceGroupNameRef,omitempty"`
+
+	// ResourceGroupNameSelector to select a reference to a resource group.
+	// +immutable
+	ResourceGroupNameSelector *xpv1.Selector `json:"resourceGroupNameSelector,omitempty"`
+
+	// SubscriptionID of the subscription the data collection rule is in.
+	// Defaults to the subscription of the credentials of the provider.
+	// +immutable
+	// +optional
+	SubscriptionID *string `json:"subscriptionID,omitempty"`
+
+	// Location of the data collection rule. It must be the location of the
+	// Azure Monitor workspace its metrics are sent to. Defaults to the
+	// default location of the ProviderConfig.
+	// +immutable
+	// +optional
+	Location string `json:"location,omitempty"`
+
+	// Kind of the machines the data collection rule collects data from.
+	// +immutable
+	// +optional
+	Kind *DataCollectionKind `json:"kind,omitempty"`
+
+	// Description of the data collection rule.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// DataCollectionEndpointID is the resource ID of the data collection
+	// endpoint the data is sent to.
+	// +optional
+	DataCollectionEndpointID *string `json:"dataCollectionEndpointID,omitempty"`
+
+	// DataCollectionEndpointIDRef to fetch the resource ID of a
+	// DataCollectionEndpoint.
+	// +optional
+	DataCollectionEndpointIDRef *xpv1.Reference `json:"dataCollectionEndpointIDRef,omitempty"`
+
+	// DataCollectionEndpointIDSelector to select a reference to a
+	// DataCollectionEndpoint.
+	// +optional
+	DataCollectionEndpointIDSelector *xpv1.Selector `json:"dataCollectionEndpointIDSelector,omitempty"`
+
+	// DataSources the data collection rule collects data from.
+	// +optional
+	DataSources *DataCollectionRuleDataSources `json:"dataSources,omitempty"`
+
+	// Destinations the data collection rule sends data to.
+	Destinations DataCollectionRuleDestinations `json:"destinations"`
+
+	// DataFlows send the data of the data sources to the destinations.
+	// +kubebuilder:validation:MinItems=1
+	DataFlows []DataFlow `json:"dataFlows"`
+
+	// Tags - Resource tags.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// A DataCollectionRuleSpec defines the desired state of a
+// DataCollectionRule.
+type DataCollectionRuleSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       DataCollectionRuleParameters `json:"forProvider"`
+}
+
+// A DataCollectionRuleObservation represents the observed state of an Azure
+// Monitor data collection rule in Azure.
+type DataCollectionRuleObservation struct {
+	// ID - Resource ID.
+	ID string `json:"id,omitempty"`
+
+	// ProvisioningState - Data collection rule provisioning state.
+	ProvisioningState string `json:"provisioningState,omitempty"`
+
+	// ImmutableID is the ID agents identify the data collection rule with.
+	ImmutableID string `json:"immutableID,omitempty"`
+}
+
+// A DataCollectionRuleStatus represents the observed state of a
+// DataCollectionRule.
+type DataCollectionRuleStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          DataCollectionRuleObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A DataCollectionRule is a managed resource that represents an Azure
+// Monitor data collection rule, which determines the data Azure Monitor
+// agents collect and where they send it.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.provisioningState"
+// +kubebuilder:printcolumn:name="LOCATION",type="string",JSONPath=".spec.forProvider.location"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,azure}
+type DataCollectionRule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DataCollectionRuleSpec   `json:"spec"`
+	Status DataCollectionRuleStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DataCollectionRuleList contains a list of DataCollectionRule.
+type DataCollectionRuleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DataCollectionRule `json:"items"`
+}
+
+// DataCollectionRuleAssociationParameters define the desired state of the
+// association of a data collection rule or endpoint with a resource.
+// https://learn.microsoft.com/en-us/rest/api/monitor/data-collection-rule-associations/create
+type DataCollectionRuleAssociationParameters struct {
+	// TargetResourceID is the resource ID of the resource, e.g. an AKS
+	// cluster or a virtual machine, whose data is collected.
+	// +immutable
+	// +optional
+	TargetResourceID string `json:"targetResourceID,omitempty"`
+
+	// TargetResourceIDRef to fetch the resource ID of an AKSCluster.
+	// +immutable
+	// +optional
+	TargetResourceIDRef *xpv1.Reference `json:"targetResourceIDRef,omitempty"`
+
+	// TargetResourceIDSelector to select a reference to an AKSCluster.
+	// +immutable
+	// +optional
+	TargetResourceIDSelector *xpv1.Selector `json:"targetResourceIDSelector,omitempty"`
+
+	// DataCollectionRuleID is the resource ID of the associated data
+	// collection rule. Exactly one of a data collection rule and a data
+	// collection endpoint must be associated.
+	// +optional
+	DataCollectionRuleID *string `json:"dataCollectionRuleID,omitempty"`
+
+	// DataCollectionRuleIDRef to fetch the resource ID of a
+	// DataCollectionRule.
+	// +optional
+	DataCollectionRuleIDRef *xpv1.Reference `json:"dataCollectionRuleIDRef,omitempty"`
+
+	// DataCollectionRuleIDSelector to select a reference to a
+	// DataCollectionRule.
+	// +optional
+	DataCollectionRuleIDSelector *xpv1.Selector `json:"dataCollectionRuleIDSelector,omitempty"`
+
+	// DataCollectionEndpointID is the resource ID of the associated data
+	// collection endpoint. The association of an endpoint must be named
+	// configurationAccessEndpoint.
+	// +optional
+	DataCollectionEndpointID *string `json:"dataCollectionEndpointID,omitempty"`
+
+	// DataCollectionEndpointIDRef to fetch the resource ID of a
+	// DataCollectionEndpoint.
+	// +optional
+	DataCollectionEndpointIDRef *xpv1.Reference `json:"dataCollectionEndpointIDRef,omitempty"`
+
+	// DataCollectionEndpointIDSelector to select a reference to a
+	// DataCollectionEndpoint.
+	// +optional
+	DataCollectionEndpointIDSelector *xpv1.Selector `json:"dataCollectionEndpointIDSelector,omitempty"`
+
+	// Description of the association.
+	// +optional
+	Description *string `json:"description,omitempty"`
+}
+
+// A DataCollectionRuleAssociationSpec defines the desired state of a
+// DataCollectionRuleAssociation.
+type DataCollectionRuleAssociationSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       DataCollectionRuleAssociationParameters `json:"forProvider"`
+}
+
+// A DataCollectionRuleAssociationObservation represents the observed state
+// of the association of a data collection rule or endpoint with a resource
+// in Azure.
+type DataCollectionRuleAssociationObservation struct {
+	// ID - Resource ID.
+	ID string `json:"id,omitempty"`
+
+	// ProvisioningState - Association provisioning state.
+	ProvisioningState string `json:"provisioningState,omitempty"`
+}
+
+// A DataCollectionRuleAssociationStatus represents the observed state of a
+// DataCollectionRuleAssociation.
+type DataCollectionRuleAssociationStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          DataCollectionRuleAssociationObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A DataCollectionRuleAssociation is a managed resource that represents the
+// association of an Azure Monitor data collection rule or endpoint with the
+// resource whose data it collects, e.g. an AKS cluster.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.provisioningState"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,azure}
+type DataCollectionRuleAssociation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DataCollectionRuleAssociationSpec   `json:"spec"`
+	Status DataCollectionRuleAssociationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DataCollectionRuleAssociationList contains a list of
+// DataCollectionRuleAssociation.
+type DataCollectionRuleAssociationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DataCollectionRuleAssociation `json:"items"`
+}
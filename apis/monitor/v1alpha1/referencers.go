@@ -0,0 +1,266 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	computev1alpha3 "github.com/crossplane/provider-azure/apis/compute/v1alpha3"
+	"github.com/crossplane/provider-azure/apis/v1alpha3"
+)
+
+// AzureMonitorWorkspaceID extracts the resource ID of an
+// AzureMonitorWorkspace, for the destinations of data collection rules.
+func AzureMonitorWorkspaceID() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		w, ok := mg.(*AzureMonitorWorkspace)
+		if !ok {
+			return ""
+		}
+		return w.Status.AtProvider.ID
+	}
+}
+
+// DataCollectionEndpointID extracts the resource ID of a
+// DataCollectionEndpoint, for the data collection rules that send data to it
+// and the associations that associate it with a resource.
+func DataCollectionEndpointID() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		e, ok := mg.(*DataCollectionEndpoint)
+		if !ok {
+			return ""
+		}
+		return e.Status.AtProvider.ID
+	}
+}
+
+// DataCollectionRuleID extracts the resource ID of a DataCollectionRule, for
+// the associations that associate it with a resource.
+func DataCollectionRuleID() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		r, ok := mg.(*DataCollectionRule)
+		if !ok {
+			return ""
+		}
+		return r.Status.AtProvider.ID
+	}
+}
+
+// ResolveReferences of this AzureMonitorPrivateLinkScope.
+func (mg *AzureMonitorPrivateLinkScope) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.forProvider.resourceGroupName
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.ResourceGroupName,
+		Reference:    mg.Spec.ForProvider.ResourceGroupNameRef,
+		Selector:     mg.Spec.ForProvider.ResourceGroupNameSelector,
+		To:           reference.To{Managed: &v1alpha3.ResourceGroup{}, List: &v1alpha3.ResourceGroupList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.resourceGroupName")
+	}
+	mg.Spec.ForProvider.ResourceGroupName = rsp.ResolvedValue
+	mg.Spec.ForProvider.ResourceGroupNameRef = rsp.ResolvedReference
+
+	return nil
+}
+
+// ResolveReferences of this AzureMonitorPrivateLinkScopedResource.
+func (mg *AzureMonitorPrivateLinkScopedResource) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.forProvider.resourceGroupName
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.ResourceGroupName,
+		Reference:    mg.Spec.ForProvider.ResourceGroupNameRef,
+		Selector:     mg.Spec.ForProvider.ResourceGroupNameSelector,
+		To:           reference.To{Managed: &v1alpha3.ResourceGroup{}, List: &v1alpha3.ResourceGroupList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.resourceGroupName")
+	}
+	mg.Spec.ForProvider.ResourceGroupName = rsp.ResolvedValue
+	mg.Spec.ForProvider.ResourceGroupNameRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.privateLinkScopeName
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.PrivateLinkScopeName,
+		Reference:    mg.Spec.ForProvider.PrivateLinkScopeNameRef,
+		Selector:     mg.Spec.ForProvider.PrivateLinkScopeNameSelector,
+		To:           reference.To{Managed: &AzureMonitorPrivateLinkScope{}, List: &AzureMonitorPrivateLinkScopeList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.privateLinkScopeName")
+	}
+	mg.Spec.ForProvider.PrivateLinkScopeName = rsp.ResolvedValue
+	mg.Spec.ForProvider.PrivateLinkScopeNameRef = rsp.ResolvedReference
+
+	return nil
+}
+
+// ResolveReferences of this AzureMonitorWorkspace.
+func (mg *AzureMonitorWorkspace) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.forProvider.resourceGroupName
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.ResourceGroupName,
+		Reference:    mg.Spec.ForProvider.ResourceGroupNameRef,
+		Selector:     mg.Spec.ForProvider.ResourceGroupNameSelector,
+		To:           reference.To{Managed: &v1alpha3.ResourceGroup{}, List: &v1alpha3.ResourceGroupList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.resourceGroupName")
+	}
+	mg.Spec.ForProvider.ResourceGroupName = rsp.ResolvedValue
+	mg.Spec.ForProvider.ResourceGroupNameRef = rsp.ResolvedReference
+
+	return nil
+}
+
+// ResolveReferences of this DataCollectionEndpoint.
+func (mg *DataCollectionEndpoint) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.forProvider.resourceGroupName
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.ResourceGroupName,
+		Reference:    mg.Spec.ForProvider.ResourceGroupNameRef,
+		Selector:     mg.Spec.ForProvider.ResourceGroupNameSelector,
+		To:           reference.To{Managed: &v1alpha3.ResourceGroup{}, List: &v1alpha3.ResourceGroupList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.resourceGroupName")
+	}
+	mg.Spec.ForProvider.ResourceGroupName = rsp.ResolvedValue
+	mg.Spec.ForProvider.ResourceGroupNameRef = rsp.ResolvedReference
+
+	return nil
+}
+
+// ResolveReferences of this DataCollectionRule.
+func (mg *DataCollectionRule) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.forProvider.resourceGroupName
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.ResourceGroupName,
+		Reference:    mg.Spec.ForProvider.ResourceGroupNameRef,
+		Selector:     mg.Spec.ForProvider.ResourceGroupNameSelector,
+		To:           reference.To{Managed: &v1alpha3.ResourceGroup{}, List: &v1alpha3.ResourceGroupList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.resourceGroupName")
+	}
+	mg.Spec.ForProvider.ResourceGroupName = rsp.ResolvedValue
+	mg.Spec.ForProvider.ResourceGroupNameRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.dataCollectionEndpointID
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.DataCollectionEndpointID),
+		Reference:    mg.Spec.ForProvider.DataCollectionEndpointIDRef,
+		Selector:     mg.Spec.ForProvider.DataCollectionEndpointIDSelector,
+		To:           reference.To{Managed: &DataCollectionEndpoint{}, List: &DataCollectionEndpointList{}},
+		Extract:      DataCollectionEndpointID(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.dataCollectionEndpointID")
+	}
+	mg.Spec.ForProvider.DataCollectionEndpointID = reference.ToPtrValue(rsp.ResolvedValue)
+	mg.Spec.ForProvider.DataCollectionEndpointIDRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.destinations.monitoringAccounts[*].accountResourceID
+	for i := range mg.Spec.ForProvider.Destinations.MonitoringAccounts {
+		d := &mg.Spec.ForProvider.Destinations.MonitoringAccounts[i]
+		rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+			CurrentValue: d.AccountResourceID,
+			Reference:    d.AccountResourceIDRef,
+			Selector:     d.AccountResourceIDSelector,
+			To:           reference.To{Managed: &AzureMonitorWorkspace{}, List: &AzureMonitorWorkspaceList{}},
+			Extract:      AzureMonitorWorkspaceID(),
+		})
+		if err != nil {
+			return errors.Wrapf(err, "spec.forProvider.destinations.monitoringAccounts[%d].accountResourceID", i)
+		}
+		d.AccountResourceID = rsp.ResolvedValue
+		d.AccountResourceIDRef = rsp.ResolvedReference
+	}
+
+	return nil
+}
+
+// ResolveReferences of this DataCollectionRuleAssociation.
+func (mg *DataCollectionRuleAssociation) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.forProvider.targetResourceID
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.TargetResourceID,
+		Reference:    mg.Spec.ForProvider.TargetResourceIDRef,
+		Selector:     mg.Spec.ForProvider.TargetResourceIDSelector,
+		To:           reference.To{Managed: &computev1alpha3.AKSCluster{}, List: &computev1alpha3.AKSClusterList{}},
+		Extract:      computev1alpha3.AKSClusterID(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.targetResourceID")
+	}
+	mg.Spec.ForProvider.TargetResourceID = rsp.ResolvedValue
+	mg.Spec.ForProvider.TargetResourceIDRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.dataCollectionRuleID
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.DataCollectionRuleID),
+		Reference:    mg.Spec.ForProvider.DataCollectionRuleIDRef,
+		Selector:     mg.Spec.ForProvider.DataCollectionRuleIDSelector,
+		To:           reference.To{Managed: &DataCollectionRule{}, List: &DataCollectionRuleList{}},
+		Extract:      DataCollectionRuleID(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.dataCollectionRuleID")
+	}
+	mg.Spec.ForProvider.DataCollectionRuleID = reference.ToPtrValue(rsp.ResolvedValue)
+	mg.Spec.ForProvider.DataCollectionRuleIDRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.dataCollectionEndpointID
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.DataCollectionEndpointID),
+		Reference:    mg.Spec.ForProvider.DataCollectionEndpointIDRef,
+		Selector:     mg.Spec.ForProvider.DataCollectionEndpointIDSelector,
+		To:           reference.To{Managed: &DataCollectionEndpoint{}, List: &DataCollectionEndpointList{}},
+		Extract:      DataCollectionEndpointID(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.dataCollectionEndpointID")
+	}
+	mg.Spec.ForProvider.DataCollectionEndpointID = reference.ToPtrValue(rsp.ResolvedValue)
+	mg.Spec.ForProvider.DataCollectionEndpointIDRef = rsp.ResolvedReference
+
+	return nil
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/scheme"
+)
+
+// Package type metadata.
+const (
+	Group   = "monitor.azure.crossplane.io"
+	Version = "v1alpha1"
+)
+
+var (
+	// SchemeGroupVersion is group version used to register these objects
+	SchemeGroupVersion = schema.GroupVersion{Group: Group, Version: Version}
+
+	// SchemeBuilder is used to add go types to the GroupVersionKind scheme
+	SchemeBuilder = &scheme.Builder{GroupVersion: SchemeGroupVersion}
+)
+
+// AzureMonitorPrivateLinkScope type metadata.
+var (
+	AzureMonitorPrivateLinkScopeKind             = reflect.TypeOf(AzureMonitorPrivateLinkScope{}).Name()
+	AzureMonitorPrivateLinkScopeGroupKind        = schema.GroupKind{Group: Group, Kind: AzureMonitorPrivateLinkScopeKind}.String()
+	AzureMonitorPrivateLinkScopeKindAPIVersion   = AzureMonitorPrivateLinkScopeKind + "." + SchemeGroupVersion.String()
+	AzureMonitorPrivateLinkScopeGroupVersionKind = SchemeGroupVersion.WithKind(AzureMonitorPrivateLinkScopeKind)
+)
+
+// AzureMonitorPrivateLinkScopedResource type metadata.
+var (
+	AzureMonitorPrivateLinkScopedResourceKind             = reflect.TypeOf(AzureMonitorPrivateLinkScopedResource{}).Name()
+	AzureMonitorPrivateLinkScopedResourceGroupKind        = schema.GroupKind{Group: Group, Kind: AzureMonitorPrivateLinkScopedResourceKind}.String()
+	AzureMonitorPrivateLinkScopedResourceKindAPIVersion   = AzureMonitorPrivateLinkScopedResourceKind + "." + SchemeGroupVersion.String()
+	AzureMonitorPrivateLinkScopedResourceGroupVersionKind = SchemeGroupVersion.WithKind(AzureMonitorPrivateLinkScopedResourceKind)
+)
+
+// AzureMonitorWorkspace type metadata.
+var (
+	AzureMonitorWorkspaceKind             = reflect.TypeOf(AzureMonitorWorkspace{}).Name()
+	AzureMonitorWorkspaceGroupKind        = schema.GroupKind{Group: Group, Kind: AzureMonitorWorkspaceKind}.String()
+	AzureMonitorWorkspaceKindAPIVersion   = AzureMonitorWorkspaceKind + "." + SchemeGroupVersion.String()
+	AzureMonitorWorkspaceGroupVersionKind = SchemeGroupVersion.WithKind(AzureMonitorWorkspaceKind)
+)
+
+// DataCollectionEndpoint type metadata.
+var (
+	DataCollectionEndpointKind             = reflect.TypeOf(DataCollectionEndpoint{}).Name()
+	DataCollectionEndpointGroupKind        = schema.GroupKind{Group: Group, Kind: DataCollectionEndpointKind}.String()
+	DataCollectionEndpointKindAPIVersion   = DataCollectionEndpointKind + "." + SchemeGroupVersion.String()
+	DataCollectionEndpointGroupVersionKind = SchemeGroupVersion.WithKind(DataCollectionEndpointKind)
+)
+
+// DataCollectionRule type metadata.
+var (
+	DataCollectionRuleKind             = reflect.TypeOf(DataCollectionRule{}).Name()
+	DataCollectionRuleGroupKind        = schema.GroupKind{Group: Group, Kind: DataCollectionRuleKind}.String()
+	DataCollectionRuleKindAPIVersion   = DataCollectionRuleKind + "." + SchemeGroupVersion.String()
+	DataCollectionRuleGroupVersionKind = SchemeGroupVersion.WithKind(DataCollectionRuleKind)
+)
+
+// DataCollectionRuleAssociation type metadata.
+var (
+	DataCollectionRuleAssociationKind             = reflect.TypeOf(DataCollectionRuleAssociation{}).Name()
+	DataCollectionRuleAssociationGroupKind        = schema.GroupKind{Group: Group, Kind: DataCollectionRuleAssociationKind}.String()
+	DataCollectionRuleAssociationKindAPIVersion   = DataCollectionRuleAssociationKind + "." + SchemeGroupVersion.String()
+	DataCollectionRuleAssociationGroupVersionKind = SchemeGroupVersion.WithKind(DataCollectionRuleAssociationKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&AzureMonitorPrivateLinkScope{}, &AzureMonitorPrivateLinkScopeList{})
+	SchemeBuilder.Register(&AzureMonitorPrivateLinkScopedResource{}, &AzureMonitorPrivateLinkScopedResourceList{})
+	SchemeBuilder.Register(&AzureMonitorWorkspace{}, &AzureMonitorWorkspaceList{})
+	SchemeBuilder.Register(&DataCollectionEndpoint{}, &DataCollectionEndpointList{})
+	SchemeBuilder.Register(&DataCollectionRule{}, &DataCollectionRuleList{})
+	SchemeBuilder.Register(&DataCollectionRuleAssociation{}, &DataCollectionRuleAssociationList{})
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// An AccessMode determines which networks may send monitoring data to, or
+// query, the resources of an Azure Monitor private link scope.
+// +kubebuilder:validation:Enum=Open;PrivateOnly
+type AccessMode string
+
+// Access modes.
+const (
+	// AccessModeOpen allows all networks.
+	AccessModeOpen AccessMode = "Open"
+
+	// AccessModePrivateOnly only allows the private endpoints of the private
+	// link scope.
+	AccessModePrivateOnly AccessMode = "PrivateOnly"
+)
+
+// AzureMonitorPrivateLinkScopeParameters define the desired state of an
+// Azure Monitor private link scope.
+// https://docs.microsoft.com/en-us/rest/api/monitor/private-link-scopes-api/create-or-update
+type AzureMonitorPrivateLinkScopeParameters struct {
+	// ResourceGroupName in which to create this resource.
+	// +immutable
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// ResourceGroupNameRef to fetch resource group name.
+	// +immutable
+	ResourceGroupNameRef *xpv1.Reference `json:"resourceGroupNameRef,omitempty"`
+
+	// ResourceGroupNameSelector to select a reference to a resource group.
+	// +immutable
+	ResourceGroupNameSelector *xpv1.Selector `json:"resourceGroupNameSelector,omitempty"`
+
+	// SubscriptionID of the subscription the private link scope is in.
+	// Defaults to the subscription of the credentials of the provider.
+	// +immutable
+	// +optional
+	SubscriptionID *string `json:"subscriptionID,omitempty"`
+
+	// IngestionAccessMode determines which networks may send monitoring data
+	// to the resources of the private link scope. PrivateOnly keeps the
+	// monitoring traffic of networks connected to the scope on its private
+	// endpoints. Defaults to Open.
+	// +optional
+	IngestionAccessMode *AccessMode `json:"ingestionAccessMode,omitempty"`
+
+	// QueryAccessMode determines which networks may query the resources of
+	// the private link scope. Defaults to Open.
+	// +optional
+	QueryAccessMode *AccessMode `json:"queryAccessMode,omitempty"`
+
+	// Tags - Resource tags.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// An AzureMonitorPrivateLinkScopeSpec defines the desired state of an
+// AzureMonitorPrivateLinkScope.
+type AzureMonitorPrivateLinkScopeSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       AzureMonitorPrivateLinkScopeParameters `json:"forProvider"`
+}
+
+// An AzureMonitorPrivateLinkScopeObservation represents the observed state
+// of an Azure Monitor private link scope in Azure.
+type AzureMonitorPrivateLinkScopeObservation struct {
+	// ID - Resource ID.
+	ID string `json:"id,omitempty"`
+
+	// ProvisioningState - Private link scope provisioning state.
+	ProvisioningState string `json:"provisioningState,omitempty"`
+
+	// PrivateEndpointConnections - Resource IDs of the private endpoint
+	// connections of the private link scope.
+	PrivateEndpointConnections []string `json:"privateEndpointConnections,omitempty"`
+}
+
+// An AzureMonitorPrivateLinkScopeStatus represents the observed state of an
+// AzureMonitorPrivateLinkScope.
+type AzureMonitorPrivateLinkScopeStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          AzureMonitorPrivateLinkScopeObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An AzureMonitorPrivateLinkScope is a managed resource that represents an
+// Azure Monitor private link scope, which connects private endpoints to the
+// Log Analytics workspaces and Application Insights components it scopes.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.provisioningState"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,azure}
+type AzureMonitorPrivateLinkScope struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AzureMonitorPrivateLinkScopeSpec   `json:"spec"`
+	Status AzureMonitorPrivateLinkScopeStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AzureMonitorPrivateLinkScopeList contains a list of
+// AzureMonitorPrivateLinkScope.
+type AzureMonitorPrivateLinkScopeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AzureMonitorPrivateLinkScope `json:"items"`
+}
+
+// AzureMonitorPrivateLinkScopedResourceParameters define the desired state
+// of the association of a resource with an Azure Monitor private link scope.
+// https://docs.microsoft.com/en-us/rest/api/monitor/private-link-scoped-resources/create-or-update
+type AzureMonitorPrivateLinkScopedResourceParameters struct {
+	// ResourceGroupName of the private link scope.
+	// +immutable
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// ResourceGroupNameRef to fetch resource group name.
+	// +immutable
+	ResourceGroupNameRef *xpv1.Reference `json:"resourceGroupNameRef,omitempty"`
+
+	// ResourceGroupNameSelector to select a reference to a resource group.
+	// +immutable
+	ResourceGroupNameSelector *xpv1.Selector `json:"resourceGroupNameSelector,omitempty"`
+
+	// SubscriptionID of the subscription the private link scope is in.
+	// Defaults to the subscription of the credentials of the provider.
+	// +immutable
+	// +optional
+	SubscriptionID *string `json:"subscriptionID,omitempty"`
+
+	// PrivateLinkScopeName is the name of the private link scope the
+	// resource is associated with.
+	// +immutable
+	PrivateLinkScopeName string `json:"privateLinkScopeName,omitempty"`
+
+	// PrivateLinkScopeNameRef to fetch the name of the private link scope.
+	// +immutable
+	PrivateLinkScopeNameRef *xpv1.Reference `json:"privateLinkScopeNameRef,omitempty"`
+
+	// PrivateLinkScopeNameSelector to select a reference to a private link
+	// scope.
+	// +immutable
+	PrivateLinkScopeNameSelector *xpv1.Selector `json:"privateLinkScopeNameSelector,omitempty"`
+
+	// LinkedResourceID is the resource ID of the Log Analytics workspace or
+	// Application Insights component that is associated with the private
+	// link scope.
+	// +immutable
+	LinkedResourceID string `json:"linkedResourceID"`
+}
+
+// An AzureMonitorPrivateLinkScopedResourceSpec defines the desired state of
+// an AzureMonitorPrivateLinkScopedResource.
+type AzureMonitorPrivateLinkScopedResourceSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       AzureMonitorPrivateLinkScopedResourceParameters `json:"forProvider"`
+}
+
+// An AzureMonitorPrivateLinkScopedResourceObservation represents the
+// observed state of the association of a resource with an Azure Monitor
+// private link scope in Azure.
+type AzureMonitorPrivateLinkScopedResourceObservation struct {
+	// ID - Resource ID.
+	ID string `json:"id,omitempty"`
+
+	// ProvisioningState - Scoped resource provisioning state.
+	ProvisioningState string `json:"provisioningState,omitempty"`
+}
+
+// An AzureMonitorPrivateLinkScopedResourceStatus represents the observed
+// state of an AzureMonitorPrivateLinkScopedResource.
+type AzureMonitorPrivateLinkScopedResourceStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          AzureMonitorPrivateLinkScopedResourceObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An AzureMonitorPrivateLinkScopedResource is a managed resource that
+// represents the association of a Log Analytics workspace or Application
+// Insights component with an Azure Monitor private link scope.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.provisioningState"
+// +kubebuilder:printcolumn:name="SCOPE",type="string",JSONPath=".spec.forProvider.privateLinkScopeName"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,azure}
+type AzureMonitorPrivateLinkScopedResource struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AzureMonitorPrivateLinkScopedResourceSpec   `json:"spec"`
+	Status AzureMonitorPrivateLinkScopedResourceStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AzureMonitorPrivateLinkScopedResourceList contains a list of
+// AzureMonitorPrivateLinkScopedResource.
+type AzureMonitorPrivateLinkScopedResourceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AzureMonitorPrivateLinkScopedResource `json:"items"`
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// A PublicNetworkAccess determines whether an Azure Monitor resource may be
+// reached from public networks.
+// +kubebuilder:validation:Enum=Enabled;Disabled
+type PublicNetworkAccess string
+
+// Public network access settings.
+const (
+	PublicNetworkAccessEnabled  PublicNetworkAccess = "Enabled"
+	PublicNetworkAccessDisabled PublicNetworkAccess = "Disabled"
+)
+
+// AzureMonitorWorkspaceParameters define the desired state of an Azure
+// Monitor workspace.
+// https://learn.microsoft.com/en-us/rest/api/monitor/azure-monitor-workspaces/create
+type AzureMonitorWorkspaceParameters struct {
+	// ResourceGroupName in which to create this resource.
+	// +immutable
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// ResourceGroupNameRef to fetch resource group name.
+	// +immutable
+	ResourceGroupNameRef *xpv1.Reference `json:"resourceGroupNameRef,omitempty"`
+
+	// ResourceGroupNameSelector to select a reference to a resource group.
+	// +immutable
+	ResourceGroupNameSelector *xpv1.Selector `json:"resourceGroupNameSelector,omitempty"`
+
+	// SubscriptionID of the subscription the workspace is in. Defaults to
+	// the subscription of the credentials of the provider.
+	// +immutable
+	// +optional
+	SubscriptionID *string `json:"subscriptionID,omitempty"`
+
+	// Location of the workspace. Defaults to the default location of the
+	// ProviderConfig.
+	// +immutable
+	// +optional
+	Location string `json:"location,omitempty"`
+
+	// PublicNetworkAccess determines whether the workspace may be queried
+	// and ingested into from public networks. Defaults to Enabled.
+	// +optional
+	PublicNetworkAccess *PublicNetworkAccess `json:"publicNetworkAccess,omitempty"`
+
+	// Tags - Resource tags.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// An AzureMonitorWorkspaceSpec defines the desired state of an
+// AzureMonitorWorkspace.
+type AzureMonitorWorkspaceSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       AzureMonitorWorkspaceParameters `json:"forProvider"`
+}
+
+// An AzureMonitorWorkspaceObservation represents the observed state of an
+// Azure Monitor workspace in Azure.
+type AzureMonitorWorkspaceObservation struct {
+	// ID - Resource ID.
+	ID string `json:"id,omitempty"`
+
+	// ProvisioningState - Workspace provisioning state.
+	ProvisioningState string `json:"provisioningState,omitempty"`
+
+	// AccountID is the immutable ID of the workspace.
+	AccountID string `json:"accountID,omitempty"`
+
+	// PrometheusQueryEndpoint is the endpoint Grafana and other Prometheus
+	// clients query the metrics of the workspace at.
+	PrometheusQueryEndpoint string `json:"prometheusQueryEndpoint,omitempty"`
+
+	// DefaultDataCollectionEndpointID is the resource ID of the data
+	// collection endpoint Azure created with the workspace.
+	DefaultDataCollectionEndpointID string `json:"defaultDataCollectionEndpointID,omitempty"`
+
+	// DefaultDataCollectionRuleID is the resource ID of the data collection
+	// rule Azure created with the workspace.
+	DefaultDataCollectionRuleID string `json:"defaultDataCollectionRuleID,omitempty"`
+}
+
+// An AzureMonitorWorkspaceStatus represents the observed state of an
+// AzureMonitorWorkspace.
+type AzureMonitorWorkspaceStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          AzureMonitorWorkspaceObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An AzureMonitorWorkspace is a managed resource that represents an Azure
+// Monitor workspace, which stores the metrics collected by the managed
+// service for Prometheus.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.provisioningState"
+// +kubebuilder:printcolumn:name="LOCATION",type="string",JSONPath=".spec.forProvider.location"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,azure}
+type AzureMonitorWorkspace struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AzureMonitorWorkspaceSpec   `json:"spec"`
+	Status AzureMonitorWorkspaceStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AzureMonitorWorkspaceList contains a list of AzureMonitorWorkspace.
+type AzureMonitorWorkspaceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AzureMonitorWorkspace `json:"items"`
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+import (
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/crossplane/provider-azure/apis/network/v1beta1"
+)
+
+// Error strings.
+const (
+	errNotV1Beta1VirtualNetwork = "hub is not a v1beta1 VirtualNetwork"
+	errNotV1Beta1Subnet         = "hub is not a v1beta1 Subnet"
+)
+
+// driftPaths maps the paths of the spec fields this version reports in its
+// status.drift to those of the same fields in v1beta1.
+var driftPaths = map[string]string{
+	"spec.properties.addressSpace.addressPrefixes": "spec.forProvider.addressPrefixes",
+	"spec.properties.enableDdosProtection":         "spec.forProvider.enableDdosProtection",
+	"spec.properties.enableVmProtection":           "spec.forProvider.enableVmProtection",
+	"spec.properties.addressPrefix":                "spec.forProvider.addressPrefix",
+	"spec.tags":                                    "spec.forProvider.tags",
+}
+
+func convertDrift(drift []string, toHub bool) []string {
+	if drift == nil {
+		return nil
+	}
+	paths := driftPaths
+	if !toHub {
+		paths = make(map[string]string, len(driftPaths))
+		for from, to := range driftPaths {
+			paths[to] = from
+		}
+	}
+	out := make([]string, len(drift))
+	for i, p := range drift {
+		out[i] = p
+		if c, ok := paths[p]; ok {
+			out[i] = c
+		}
+	}
+	return out
+}
+
+func firstNonEmpty(a, b string) string {
+	if a != "" {
+		return a
+	}
+	return b
+}
+
+// ConvertTo converts this VirtualNetwork to the v1beta1 hub version.
+func (mg *VirtualNetwork) ConvertTo(h conversion.Hub) error {
+	dst, ok := h.(*v1beta1.VirtualNetwork)
+	if !ok {
+		return errors.New(errNotV1Beta1VirtualNetwork)
+	}
+	s := mg.Spec.DeepCopy()
+	dst.ObjectMeta = *mg.ObjectMeta.DeepCopy()
+	dst.Spec = v1beta1.VirtualNetworkSpec{
+		ResourceSpec: s.ResourceSpec,
+		ForProvider: v1beta1.VirtualNetworkParameters{
+			ResourceGroupName:         s.ResourceGroupName,
+			ResourceGroupNameRef:      s.ResourceGroupNameRef,
+			ResourceGroupNameSelector: s.ResourceGroupNameSelector,
+			SubscriptionID:            s.SubscriptionID,
+			AddressPrefixes:           s.AddressSpace.AddressPrefixes,
+			EnableDDOSProtection:      s.EnableDDOSProtection,
+			EnableVMProtection:        s.EnableVMProtection,
+			Location:                  s.Location,
+			Tags:                      s.Tags,
+		},
+	}
+	st := mg.Status.DeepCopy()
+	// Objects stored before this version had status.atProvider only record
+	// the observed state in the fields of status itself.
+	o := st.AtProvider
+	o.ProvisioningState = firstNonEmpty(o.ProvisioningState, st.State)
+	o.ID = firstNonEmpty(o.ID, st.ID)
+	o.Etag = firstNonEmpty(o.Etag, st.Etag)
+	o.ResourceGUID = firstNonEmpty(o.ResourceGUID, st.ResourceGUID)
+	o.Type = firstNonEmpty(o.Type, st.Type)
+	dst.Status = v1beta1.VirtualNetworkStatus{
+		ResourceStatus: st.ResourceStatus,
+		Drift:          convertDrift(st.Drift, true),
+		AtProvider:     v1beta1.VirtualNetworkObservation(o),
+	}
+	return nil
+}
+
+// ConvertFrom converts the v1beta1 hub version to this VirtualNetwork.
+func (mg *VirtualNetwork) ConvertFrom(h conversion.Hub) error {
+	src, ok := h.(*v1beta1.VirtualNetwork)
+	if !ok {
+		return errors.New(errNotV1Beta1VirtualNetwork)
+	}
+	p := src.Spec.ForProvider.DeepCopy()
+	mg.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	mg.Spec = VirtualNetworkSpec{
+		ResourceSpec:              *src.Spec.ResourceSpec.DeepCopy(),
+		ResourceGroupName:         p.ResourceGroupName,
+		ResourceGroupNameRef:      p.ResourceGroupNameRef,
+		ResourceGroupNameSelector: p.ResourceGroupNameSelector,
+		SubscriptionID:            p.SubscriptionID,
+		VirtualNetworkPropertiesFormat: VirtualNetworkPropertiesFormat{
+			AddressSpace:         AddressSpace{AddressPrefixes: p.AddressPrefixes},
+			EnableDDOSProtection: p.EnableDDOSProtection,
+			EnableVMProtection:   p.EnableVMProtection,
+		},
+		Location: p.Location,
+		Tags:     p.Tags,
+	}
+	st := src.Status.DeepCopy()
+	mg.Status = VirtualNetworkStatus{
+		ResourceStatus: st.ResourceStatus,
+		State:          st.AtProvider.ProvisioningState,
+		ID:             st.AtProvider.ID,
+		Etag:           st.AtProvider.Etag,
+		ResourceGUID:   st.AtProvider.ResourceGUID,
+		Type:           st.AtProvider.Type,
+		Drift:          convertDrift(st.Drift, false),
+		AtProvider:     VirtualNetworkObservation(st.AtProvider),
+	}
+	return nil
+}
+
+// ConvertTo converts this Subnet to the v1beta1 hub version.
+func (mg *Subnet) ConvertTo(h conversion.Hub) error {
+	dst, ok := h.(*v1beta1.Subnet)
+	if !ok {
+		return errors.New(errNotV1Beta1Subnet)
+	}
+	s := mg.Spec.DeepCopy()
+	dst.ObjectMeta = *mg.ObjectMeta.DeepCopy()
+	dst.Spec = v1beta1.SubnetSpec{
+		ResourceSpec: s.ResourceSpec,
+		ForProvider: v1beta1.SubnetParameters{
+			VirtualNetworkName:         s.VirtualNetworkName,
+			VirtualNetworkNameRef:      s.VirtualNetworkNameRef,
+			VirtualNetworkNameSelector: s.VirtualNetworkNameSelector,
+			ResourceGroupName:          s.ResourceGroupName,
+			ResourceGroupNameRef:       s.ResourceGroupNameRef,
+			ResourceGroupNameSelector:  s.ResourceGroupNameSelector,
+			SubscriptionID:             s.SubscriptionID,
+			AddressPrefix:              s.AddressPrefix,
+		},
+	}
+	for _, e := range s.ServiceEndpoints {
+		dst.Spec.ForProvider.ServiceEndpoints = append(dst.Spec.ForProvider.ServiceEndpoints, v1beta1.ServiceEndpoint{
+			Service:   e.Service,
+			Locations: e.Locations,
+		})
+	}
+	st := mg.Status.DeepCopy()
+	o := st.AtProvider
+	o.ProvisioningState = firstNonEmpty(o.ProvisioningState, st.State)
+	o.Etag = firstNonEmpty(o.Etag, st.Etag)
+	o.ID = firstNonEmpty(o.ID, st.ID)
+	o.Purpose = firstNonEmpty(o.Purpose, st.Purpose)
+	dst.Status = v1beta1.SubnetStatus{
+		ResourceStatus: st.ResourceStatus,
+		Drift:          convertDrift(st.Drift, true),
+		AtProvider:     v1beta1.SubnetObservation(o),
+	}
+	return nil
+}
+
+// ConvertFrom converts the v1beta1 hub version to this Subnet.
+func (mg *Subnet) ConvertFrom(h conversion.Hub) error {
+	src, ok := h.(*v1beta1.Subnet)
+	if !ok {
+		return errors.New(errNotV1Beta1Subnet)
+	}
+	p := src.Spec.ForProvider.DeepCopy()
+	mg.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	mg.Spec = SubnetSpec{
+		ResourceSpec:               *src.Spec.ResourceSpec.DeepCopy(),
+		VirtualNetworkName:         p.VirtualNetworkName,
+		VirtualNetworkNameRef:      p.VirtualNetworkNameRef,
+		VirtualNetworkNameSelector: p.VirtualNetworkNameSelector,
+		ResourceGroupName:          p.ResourceGroupName,
+		ResourceGroupNameRef:       p.ResourceGroupNameRef,
+		ResourceGroupNameSelector:  p.ResourceGroupNameSelector,
+		SubscriptionID:             p.SubscriptionID,
+		SubnetPropertiesFormat:     SubnetPropertiesFormat{AddressPrefix: p.AddressPrefix},
+	}
+	for _, e := range p.ServiceEndpoints {
+		mg.Spec.ServiceEndpoints = append(mg.Spec.ServiceEndpoints, ServiceEndpointPropertiesFormat{
+			Service:   e.Service,
+			Locations: e.Locations,
+		})
+	}
+	st := src.Status.DeepCopy()
+	mg.Status = SubnetStatus{
+		ResourceStatus: st.ResourceStatus,
+		State:          st.AtProvider.ProvisioningState,
+		Etag:           st.AtProvider.Etag,
+		ID:             st.AtProvider.ID,
+		Purpose:        st.AtProvider.Purpose,
+		Drift:          convertDrift(st.Drift, false),
+		AtProvider:     SubnetObservation(st.AtProvider),
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane/provider-azure/apis/network/v1beta1"
+)
+
+func TestVirtualNetworkConversion(t *testing.T) {
+	vnet := &VirtualNetwork{
+		ObjectMeta: metav1.ObjectMeta{Name: "cool-vnet"},
+		Spec: VirtualNetworkSpec{
+			ResourceSpec:      xpv1.ResourceSpec{DeletionPolicy: xpv1.DeletionOrphan},
+			ResourceGroupName: "cool-rg",
+			VirtualNetworkPropertiesFormat: VirtualNetworkPropertiesFormat{
+				AddressSpace:         AddressSpace{AddressPrefixes: []string{"10.0.0.0/16"}},
+				EnableDDOSProtection: true,
+			},
+			Location: "westeurope",
+			Tags:     map[string]string{"team": "cool"},
+		},
+		Status: VirtualNetworkStatus{
+			State:      "Succeeded",
+			ID:         "cool-id",
+			Drift:      []string{"spec.properties.addressSpace.addressPrefixes", "spec.tags"},
+			AtProvider: VirtualNetworkObservation{ProvisioningState: "Succeeded", ID: "cool-id"},
+		},
+	}
+
+	hub := &v1beta1.VirtualNetwork{}
+	if err := vnet.ConvertTo(hub); err != nil {
+		t.Fatalf("ConvertTo(...): %s", err)
+	}
+	wantDrift := []string{"spec.forProvider.addressPrefixes", "spec.forProvider.tags"}
+	if diff := cmp.Diff(wantDrift, hub.Status.Drift); diff != "" {
+		t.Errorf("ConvertTo(...): -want drift, +got drift:\n%s", diff)
+	}
+
+	got := &VirtualNetwork{}
+	if err := got.ConvertFrom(hub); err != nil {
+		t.Fatalf("ConvertFrom(...): %s", err)
+	}
+	if diff := cmp.Diff(vnet, got); diff != "" {
+		t.Errorf("ConvertFrom(ConvertTo(...)): -want, +got:\n%s", diff)
+	}
+}
+
+func TestSubnetConversion(t *testing.T) {
+	s := &Subnet{
+		ObjectMeta: metav1.ObjectMeta{Name: "cool-subnet"},
+		Spec: SubnetSpec{
+			VirtualNetworkName: "cool-vnet",
+			ResourceGroupName:  "cool-rg",
+			SubnetPropertiesFormat: SubnetPropertiesFormat{
+				AddressPrefix:    "10.0.0.0/24",
+				ServiceEndpoints: []ServiceEndpointPropertiesFormat{{Service: "Microsoft.Sql", Locations: []string{"westeurope"}}},
+			},
+		},
+		Status: SubnetStatus{
+			State:      "Succeeded",
+			Purpose:    "cool-purpose",
+			Drift:      []string{"spec.properties.addressPrefix"},
+			AtProvider: SubnetObservation{ProvisioningState: "Succeeded", Purpose: "cool-purpose"},
+		},
+	}
+
+	hub := &v1beta1.Subnet{}
+	if err := s.ConvertTo(hub); err != nil {
+		t.Fatalf("ConvertTo(...): %s", err)
+	}
+	if diff := cmp.Diff([]string{"spec.forProvider.addressPrefix"}, hub.Status.Drift); diff != "" {
+		t.Errorf("ConvertTo(...): -want drift, +got drift:\n%s", diff)
+	}
+
+	got := &Subnet{}
+	if err := got.ConvertFrom(hub); err != nil {
+		t.Fatalf("ConvertFrom(...): %s", err)
+	}
+	if diff := cmp.Diff(s, got); diff != "" {
+		t.Errorf("ConvertFrom(ConvertTo(...)): -want, +got:\n%s", diff)
+	}
+}
+
+func TestStoredVirtualNetworkRoundTrip(t *testing.T) {
+	// A VirtualNetwork as stored by a provider that predates status.atProvider.
+	stored := []byte(`{
+		"apiVersion": "network.azure.crossplane.io/v1alpha3",
+		"kind": "VirtualNetwork",
+		"metadata": {"name": "cool-vnet"},
+		"spec": {
+			"resourceGroupName": "cool-rg",
+			"location": "westeurope",
+			"properties": {"addressSpace": {"addressPrefixes": ["10.0.0.0/16"]}}
+		},
+		"status": {"state": "Succeeded", "id": "cool-id", "etag": "cool-etag", "resourceGuid": "cool-guid", "type": "cool-type"}
+	}`)
+	vnet := &VirtualNetwork{}
+	if err := json.Unmarshal(stored, vnet); err != nil {
+		t.Fatalf("json.Unmarshal(...): %s", err)
+	}
+
+	hub := &v1beta1.VirtualNetwork{}
+	if err := vnet.ConvertTo(hub); err != nil {
+		t.Fatalf("ConvertTo(...): %s", err)
+	}
+	wantObs := v1beta1.VirtualNetworkObservation{ProvisioningState: "Succeeded", ID: "cool-id", Etag: "cool-etag", ResourceGUID: "cool-guid", Type: "cool-type"}
+	if diff := cmp.Diff(wantObs, hub.Status.AtProvider); diff != "" {
+		t.Errorf("ConvertTo(...): -want atProvider, +got atProvider:\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"10.0.0.0/16"}, hub.Spec.ForProvider.AddressPrefixes); diff != "" {
+		t.Errorf("ConvertTo(...): -want addressPrefixes, +got addressPrefixes:\n%s", diff)
+	}
+
+	got := &VirtualNetwork{}
+	if err := got.ConvertFrom(hub); err != nil {
+		t.Fatalf("ConvertFrom(...): %s", err)
+	}
+	want := vnet.DeepCopy()
+	want.TypeMeta = metav1.TypeMeta{}
+	want.Status.AtProvider = VirtualNetworkObservation(wantObs)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ConvertFrom(ConvertTo(...)): -want, +got:\n%s", diff)
+	}
+}
+
+func TestStoredSubnetRoundTrip(t *testing.T) {
+	// A Subnet as stored by a provider that predates status.atProvider.
+	stored := []byte(`{
+		"apiVersion": "network.azure.crossplane.io/v1alpha3",
+		"kind": "Subnet",
+		"metadata": {"name": "cool-subnet"},
+		"spec": {
+			"virtualNetworkName": "cool-vnet",
+			"resourceGroupName": "cool-rg",
+			"properties": {"addressPrefix": "10.0.0.0/24"}
+		},
+		"status": {"state": "Succeeded", "id": "cool-id", "etag": "cool-etag", "purpose": "cool-purpose"}
+	}`)
+	s := &Subnet{}
+	if err := json.Unmarshal(stored, s); err != nil {
+		t.Fatalf("json.Unmarshal(...): %s", err)
+	}
+
+	hub := &v1beta1.Subnet{}
+	if err := s.ConvertTo(hub); err != nil {
+		t.Fatalf("ConvertTo(...): %s", err)
+	}
+	wantObs := v1beta1.SubnetObservation{ProvisioningState: "Succeeded", ID: "cool-id", Etag: "cool-etag", Purpose: "cool-purpose"}
+	if diff := cmp.Diff(wantObs, hub.Status.AtProvider); diff != "" {
+		t.Errorf("ConvertTo(...): -want atProvider, +got atProvider:\n%s", diff)
+	}
+
+	got := &Subnet{}
+	if err := got.ConvertFrom(hub); err != nil {
+		t.Fatalf("ConvertFrom(...): %s", err)
+	}
+	want := s.DeepCopy()
+	want.TypeMeta = metav1.TypeMeta{}
+	want.Status.AtProvider = SubnetObservation(wantObs)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ConvertFrom(ConvertTo(...)): -want, +got:\n%s", diff)
+	}
+}
@@ -64,17 +64,61 @@ type VirtualNetworkSpec struct {
 	// Network's resource group.
 	ResourceGroupNameSelector *xpv1.Selector `json:"resourceGroupNameSelector,omitempty"`
 
+	// SubscriptionID of the subscription the Virtual Network is in. Defaults to the
+	// subscription of the credentials of the provider.
+	// +immutable
+	// +optional
+	SubscriptionID *string `json:"subscriptionID,omitempty"`
+
 	// VirtualNetworkPropertiesFormat - Properties of the virtual network.
 	VirtualNetworkPropertiesFormat `json:"properties"`
 
-	// Location - Resource location.
-	Location string `json:"location"`
+	// Location - Resource location. Defaults to the defaultLocation of the
+	// ProviderConfig.
+	// +optional
+	Location string `json:"location,omitempty"`
 
 	// Tags - Resource tags.
 	// +optional
 	Tags map[string]string `json:"tags,omitempty"`
 }
 
+// A VirtualNetworkObservation represents the observed state of a
+// VirtualNetwork in Azure.
+type VirtualNetworkObservation struct {
+	// ProvisioningState of the VirtualNetwork.
+	ProvisioningState string `json:"provisioningState,omitempty"`
+
+	// Etag - A unique read-only string that changes whenever the resource is
+	// updated.
+	Etag string `json:"etag,omitempty"`
+
+	// ID of the VirtualNetwork.
+	ID string `json:"id,omitempty"`
+
+	// ResourceGUID of the VirtualNetwork.
+	ResourceGUID string `json:"resourceGuid,omitempty"`
+
+	// Type of the VirtualNetwork.
+	Type string `json:"type,omitempty"`
+
+	// AddressPrefixes of the address space of the VirtualNetwork.
+	AddressPrefixes []string `json:"addressPrefixes,omitempty"`
+
+	// DNSServers available to VMs deployed in the VirtualNetwork.
+	DNSServers []string `json:"dnsServers,omitempty"`
+
+	// SubnetIDs of the subnets of the VirtualNetwork.
+	SubnetIDs []string `json:"subnetIds,omitempty"`
+
+	// PeeringIDs of the peerings of the VirtualNetwork.
+	PeeringIDs []string `json:"peeringIds,omitempty"`
+
+	// DDOSProtectionPlanID of the DDoS protection plan associated with the
+	// VirtualNetwork.
+	DDOSProtectionPlanID string `json:"ddosProtectionPlanId,omitempty"`
+}
+
 // A VirtualNetworkStatus represents the observed state of a VirtualNetwork.
 type VirtualNetworkStatus struct {
 	xpv1.ResourceStatus `json:",inline"`
@@ -98,6 +142,15 @@ type VirtualNetworkStatus struct {
 
 	// Type of this VirtualNetwork.
 	Type string `json:"type,omitempty"`
+
+	// Drift lists the paths of the spec fields whose value differs from that
+	// of the Azure VirtualNetwork, e.g. because it was changed in the Azure
+	// portal. The controller will overwrite these changes with the spec.
+	// +optional
+	Drift []string `json:"drift,omitempty"`
+
+	// AtProvider is the observed state of the VirtualNetwork in Azure.
+	AtProvider VirtualNetworkObservation `json:"atProvider,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -110,6 +163,7 @@ type VirtualNetworkStatus struct {
 // +kubebuilder:printcolumn:name="LOCATION",type="string",JSONPath=".spec.location"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 // +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,azure}
 type VirtualNetwork struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -176,10 +230,55 @@ type SubnetSpec struct {
 	// resource group.
 	ResourceGroupNameSelector *xpv1.Selector `json:"resourceGroupNameSelector,omitempty"`
 
+	// SubscriptionID of the subscription the Subnet is in. Defaults to the
+	// subscription of the credentials of the provider.
+	// +immutable
+	// +optional
+	SubscriptionID *string `json:"subscriptionID,omitempty"`
+
 	// SubnetPropertiesFormat - Properties of the subnet.
 	SubnetPropertiesFormat `json:"properties"`
 }
 
+// A SubnetObservation represents the observed state of a Subnet in Azure.
+type SubnetObservation struct {
+	// ProvisioningState of the Subnet.
+	ProvisioningState string `json:"provisioningState,omitempty"`
+
+	// Etag - A unique string that changes whenever the resource is updated.
+	Etag string `json:"etag,omitempty"`
+
+	// ID of the Subnet.
+	ID string `json:"id,omitempty"`
+
+	// Purpose - A string identifying the intention of use for this subnet based
+	// on delegations and other user-defined properties.
+	Purpose string `json:"purpose,omitempty"`
+
+	// AddressPrefixes of the Subnet.
+	AddressPrefixes []string `json:"addressPrefixes,omitempty"`
+
+	// NetworkSecurityGroupID of the network security group associated with
+	// the Subnet.
+	NetworkSecurityGroupID string `json:"networkSecurityGroupId,omitempty"`
+
+	// RouteTableID of the route table associated with the Subnet.
+	RouteTableID string `json:"routeTableId,omitempty"`
+
+	// NATGatewayID of the NAT gateway associated with the Subnet.
+	NATGatewayID string `json:"natGatewayId,omitempty"`
+
+	// IPConfigurationIDs of the network interface IP configurations that use
+	// the Subnet.
+	IPConfigurationIDs []string `json:"ipConfigurationIds,omitempty"`
+
+	// PrivateEndpointIDs of the private endpoints in the Subnet.
+	PrivateEndpointIDs []string `json:"privateEndpointIds,omitempty"`
+
+	// Delegations lists the services the Subnet is delegated to.
+	Delegations []string `json:"delegations,omitempty"`
+}
+
 // A SubnetStatus represents the observed state of a Subnet.
 type SubnetStatus struct {
 	xpv1.ResourceStatus `json:",inline"`
@@ -199,6 +298,15 @@ type SubnetStatus struct {
 	// Purpose - A string identifying the intention of use for this subnet based
 	// on delegations and other user-defined properties.
 	Purpose string `json:"purpose,omitempty"`
+
+	// Drift lists the paths of the spec fields whose value differs from that
+	// of the Azure Subnet, e.g. because it was changed in the Azure portal.
+	// The controller will overwrite these changes with the spec.
+	// +optional
+	Drift []string `json:"drift,omitempty"`
+
+	// AtProvider is the observed state of the Subnet in Azure.
+	AtProvider SubnetObservation `json:"atProvider,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -209,6 +317,7 @@ type SubnetStatus struct {
 // +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.state"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 // +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,azure}
 type Subnet struct {
 	metav1.TypeMeta   `json:",inline"`
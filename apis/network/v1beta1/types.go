@@ -0,0 +1,295 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// VirtualNetworkParameters define the desired state of an Azure Virtual
+// Network.
+// https://docs.microsoft.com/en-us/rest/api/virtualnetwork/virtualnetworks/createorupdate
+type VirtualNetworkParameters struct {
+	// ResourceGroupName - Name of the Virtual Network's resource group.
+	// +immutable
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// ResourceGroupNameRef - A reference to the the Virtual Network's resource
+	// group.
+	// +immutable
+	ResourceGroupNameRef *xpv1.Reference `json:"resourceGroupNameRef,omitempty"`
+
+	// ResourceGroupNameSelector - Select a reference to the the Virtual
+	// Network's resource group.
+	// +immutable
+	ResourceGroupNameSelector *xpv1.Selector `json:"resourceGroupNameSelector,omitempty"`
+
+	// SubscriptionID of the subscription the Virtual Network is in. Defaults to the
+	// subscription of the credentials of the provider.
+	// +immutable
+	// +optional
+	SubscriptionID *string `json:"subscriptionID,omitempty"`
+
+	// AddressPrefixes - A list of address blocks reserved for this virtual
+	// network in CIDR notation.
+	AddressPrefixes []string `json:"addressPrefixes"`
+
+	// EnableDDOSProtection - Indicates if DDoS protection is enabled for all
+	// the protected resources in the virtual network. It requires a DDoS
+	// protection plan associated with the resource.
+	// +optional
+	EnableDDOSProtection bool `json:"enableDdosProtection,omitempty"`
+
+	// EnableVMProtection - Indicates if VM protection is enabled for all the
+	// subnets in the virtual network.
+	// +optional
+	EnableVMProtection bool `json:"enableVmProtection,omitempty"`
+
+	// Location - Resource location. Defaults to the defaultLocation of the
+	// ProviderConfig.
+	// +immutable
+	// +optional
+	Location string `json:"location,omitempty"`
+
+	// Tags - Resource tags.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// A VirtualNetworkSpec defines the desired state of a VirtualNetwork.
+type VirtualNetworkSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       VirtualNetworkParameters `json:"forProvider"`
+}
+
+// A VirtualNetworkObservation represents the observed state of a
+// VirtualNetwork in Azure.
+type VirtualNetworkObservation struct {
+	// ProvisioningState of the VirtualNetwork.
+	ProvisioningState string `json:"provisioningState,omitempty"`
+
+	// Etag - A unique read-only string that changes whenever the resource is
+	// updated.
+	Etag string `json:"etag,omitempty"`
+
+	// ID of the VirtualNetwork.
+	ID string `json:"id,omitempty"`
+
+	// ResourceGUID of the VirtualNetwork.
+	ResourceGUID string `json:"resourceGuid,omitempty"`
+
+	// Type of the VirtualNetwork.
+	Type string `json:"type,omitempty"`
+
+	// AddressPrefixes of the address space of the VirtualNetwork.
+	AddressPrefixes []string `json:"addressPrefixes,omitempty"`
+
+	// DNSServers available to VMs deployed in the VirtualNetwork.
+	DNSServers []string `json:"dnsServers,omitempty"`
+
+	// SubnetIDs of the subnets of the VirtualNetwork.
+	SubnetIDs []string `json:"subnetIds,omitempty"`
+
+	// PeeringIDs of the peerings of the VirtualNetwork.
+	PeeringIDs []string `json:"peeringIds,omitempty"`
+
+	// DDOSProtectionPlanID of the DDoS protection plan associated with the
+	// VirtualNetwork.
+	DDOSProtectionPlanID string `json:"ddosProtectionPlanId,omitempty"`
+}
+
+// A VirtualNetworkStatus represents the observed state of a VirtualNetwork.
+type VirtualNetworkStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+
+	// Drift lists the paths of the spec fields whose value differs from that
+	// of the Azure VirtualNetwork, e.g. because it was changed in the Azure
+	// portal. The controller will overwrite these changes with the spec.
+	// +optional
+	Drift []string `json:"drift,omitempty"`
+
+	AtProvider VirtualNetworkObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A VirtualNetwork is a managed resource that represents an Azure Virtual
+// Network.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.provisioningState"
+// +kubebuilder:printcolumn:name="LOCATION",type="string",JSONPath=".spec.forProvider.location"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,azure}
+type VirtualNetwork struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VirtualNetworkSpec   `json:"spec"`
+	Status VirtualNetworkStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VirtualNetworkList contains a list of VirtualNetwork items
+type VirtualNetworkList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualNetwork `json:"items"`
+}
+
+// A ServiceEndpoint enables a subnet to reach an Azure service over the Azure
+// backbone network.
+type ServiceEndpoint struct {
+	// Service - The type of the endpoint service.
+	Service string `json:"service"`
+
+	// Locations - A list of locations. Defaults to the locations Azure chose
+	// for the service.
+	// +optional
+	Locations []string `json:"locations,omitempty"`
+}
+
+// SubnetParameters define the desired state of an Azure Subnet.
+// https://docs.microsoft.com/en-us/rest/api/virtualnetwork/subnets/createorupdate
+type SubnetParameters struct {
+	// VirtualNetworkName - Name of the Subnet's virtual network.
+	// +immutable
+	VirtualNetworkName string `json:"virtualNetworkName,omitempty"`
+
+	// VirtualNetworkNameRef references to a VirtualNetwork to retrieve its name
+	// +immutable
+	VirtualNetworkNameRef *xpv1.Reference `json:"virtualNetworkNameRef,omitempty"`
+
+	// VirtualNetworkNameSelector selects a reference to a VirtualNetwork to
+	// retrieve its name
+	// +immutable
+	VirtualNetworkNameSelector *xpv1.Selector `json:"virtualNetworkNameSelector,omitempty"`
+
+	// ResourceGroupName - Name of the Subnet's resource group.
+	// +immutable
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// ResourceGroupNameRef - A reference to the the Subnets's resource group.
+	// +immutable
+	ResourceGroupNameRef *xpv1.Reference `json:"resourceGroupNameRef,omitempty"`
+
+	// ResourceGroupNameSelector - Selects a reference to the the Subnets's
+	// resource group.
+	// +immutable
+	ResourceGroupNameSelector *xpv1.Selector `json:"resourceGroupNameSelector,omitempty"`
+
+	// SubscriptionID of the subscription the Subnet is in. Defaults to the
+	// subscription of the credentials of the provider.
+	// +immutable
+	// +optional
+	SubscriptionID *string `json:"subscriptionID,omitempty"`
+
+	// AddressPrefix - The address prefix for the subnet.
+	AddressPrefix string `json:"addressPrefix"`
+
+	// ServiceEndpoints - An array of service endpoints.
+	// +optional
+	ServiceEndpoints []ServiceEndpoint `json:"serviceEndpoints,omitempty"`
+}
+
+// A SubnetSpec defines the desired state of a Subnet.
+type SubnetSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       SubnetParameters `json:"forProvider"`
+}
+
+// A SubnetObservation represents the observed state of a Subnet in Azure.
+type SubnetObservation struct {
+	// ProvisioningState of the Subnet.
+	ProvisioningState string `json:"provisioningState,omitempty"`
+
+	// Etag - A unique string that changes whenever the resource is updated.
+	Etag string `json:"etag,omitempty"`
+
+	// ID of the Subnet.
+	ID string `json:"id,omitempty"`
+
+	// Purpose - A string identifying the intention of use for this subnet based
+	// on delegations and other user-defined properties.
+	Purpose string `json:"purpose,omitempty"`
+
+	// AddressPrefixes of the Subnet.
+	AddressPrefixes []string `json:"addressPrefixes,omitempty"`
+
+	// NetworkSecurityGroupID of the network security group associated with
+	// the Subnet.
+	NetworkSecurityGroupID string `json:"networkSecurityGroupId,omitempty"`
+
+	// RouteTableID of the route table associated with the Subnet.
+	RouteTableID string `json:"routeTableId,omitempty"`
+
+	// NATGatewayID of the NAT gateway associated with the Subnet.
+	NATGatewayID string `json:"natGatewayId,omitempty"`
+
+	// IPConfigurationIDs of the network interface IP configurations that use
+	// the Subnet.
+	IPConfigurationIDs []string `json:"ipConfigurationIds,omitempty"`
+
+	// PrivateEndpointIDs of the private endpoints in the Subnet.
+	PrivateEndpointIDs []string `json:"privateEndpointIds,omitempty"`
+
+	// Delegations lists the services the Subnet is delegated to.
+	Delegations []string `json:"delegations,omitempty"`
+}
+
+// A SubnetStatus represents the observed state of a Subnet.
+type SubnetStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+
+	// Drift lists the paths of the spec fields whose value differs from that
+	// of the Azure Subnet, e.g. because it was changed in the Azure portal.
+	// The controller will overwrite these changes with the spec.
+	// +optional
+	Drift []string `json:"drift,omitempty"`
+
+	AtProvider SubnetObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Subnet is a managed resource that represents an Azure Subnet.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.provisioningState"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,azure}
+type Subnet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SubnetSpec   `json:"spec"`
+	Status SubnetStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SubnetList contains a list of Subnet items
+type SubnetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Subnet `json:"items"`
+}
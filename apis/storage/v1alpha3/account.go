@@ -505,6 +505,13 @@ type StorageAccountSpecProperties struct {
 
 	// NetworkRuleSet - Network rule set
 	NetworkRuleSet *NetworkRuleSet `json:"networkAcls,omitempty"`
+
+	// MinimumTLSVersion - The minimum version of TLS requests to the storage
+	// account must use. Azure's default is used if it is unspecified.
+	// Possible values include: 'TLS1_0', 'TLS1_1', 'TLS1_2'
+	// +kubebuilder:validation:Enum=TLS1_0;TLS1_1;TLS1_2
+	// +optional
+	MinimumTLSVersion string `json:"minimumTlsVersion,omitempty"`
 }
 
 // newStorageAccountSpecProperties from the storage equivalent
@@ -634,8 +641,10 @@ type StorageAccountSpec struct {
 
 	// Location - The location of the resource. This will be one of the
 	// supported and registered Azure Geo Regions (e.g. West US, East US,
-	// Southeast Asia, etc.).
-	Location string `json:"location"`
+	// Southeast Asia, etc.). Defaults to the defaultLocation of the
+	// ProviderConfig.
+	// +optional
+	Location string `json:"location,omitempty"`
 
 	// Sku of the storage account.
 	Sku *Sku `json:"sku"`
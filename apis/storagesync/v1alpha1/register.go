@@ -0,0 +1,68 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/scheme"
+)
+
+// Package type metadata.
+const (
+	Group   = "storagesync.azure.crossplane.io"
+	Version = "v1alpha1"
+)
+
+var (
+	// SchemeGroupVersion is group version used to register these objects
+	SchemeGroupVersion = schema.GroupVersion{Group: Group, Version: Version}
+
+	// SchemeBuilder is used to add go types to the GroupVersionKind scheme
+	SchemeBuilder = &scheme.Builder{GroupVersion: SchemeGroupVersion}
+)
+
+// StorageSyncService type metadata.
+var (
+	StorageSyncServiceKind             = reflect.TypeOf(StorageSyncService{}).Name()
+	StorageSyncServiceGroupKind        = schema.GroupKind{Group: Group, Kind: StorageSyncServiceKind}.String()
+	StorageSyncServiceKindAPIVersion   = StorageSyncServiceKind + "." + SchemeGroupVersion.String()
+	StorageSyncServiceGroupVersionKind = SchemeGroupVersion.WithKind(StorageSyncServiceKind)
+)
+
+// SyncGroup type metadata.
+var (
+	SyncGroupKind             = reflect.TypeOf(SyncGroup{}).Name()
+	SyncGroupGroupKind        = schema.GroupKind{Group: Group, Kind: SyncGroupKind}.String()
+	SyncGroupKindAPIVersion   = SyncGroupKind + "." + SchemeGroupVersion.String()
+	SyncGroupGroupVersionKind = SchemeGroupVersion.WithKind(SyncGroupKind)
+)
+
+// CloudEndpoint type metadata.
+var (
+	CloudEndpointKind             = reflect.TypeOf(CloudEndpoint{}).Name()
+	CloudEndpointGroupKind        = schema.GroupKind{Group: Group, Kind: CloudEndpointKind}.String()
+	CloudEndpointKindAPIVersion   = CloudEndpointKind + "." + SchemeGroupVersion.String()
+	CloudEndpointGroupVersionKind = SchemeGroupVersion.WithKind(CloudEndpointKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&StorageSyncService{}, &StorageSyncServiceList{})
+	SchemeBuilder.Register(&SyncGroup{}, &SyncGroupList{})
+	SchemeBuilder.Register(&CloudEndpoint{}, &CloudEndpointList{})
+}
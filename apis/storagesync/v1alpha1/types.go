@@ -0,0 +1,340 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// StorageSyncServiceParameters define the desired state of an Azure Storage
+// Sync Service.
+// https://docs.microsoft.com/en-us/rest/api/storagesync/storage-sync-services/create
+type StorageSyncServiceParameters struct {
+	// ResourceGroupName in which to create this resource.
+	// +immutable
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// ResourceGroupNameRef to fetch resource group name.
+	// +immutable
+	ResourceGroupNameRef *xpv1.Reference `json:"resourceGroupNameRef,omitempty"`
+
+	// ResourceGroupNameSelector to select a reference to a resource group.
+	// +immutable
+	ResourceGroupNameSelector *xpv1.Selector `json:"resourceGroupNameSelector,omitempty"`
+
+	// SubscriptionID of the subscription the Storage Sync Service is in.
+	// Defaults to the subscription of the credentials of the provider.
+	// +immutable
+	// +optional
+	SubscriptionID *string `json:"subscriptionID,omitempty"`
+
+	// Location in which to create this resource. Defaults to the
+	// defaultLocation of the ProviderConfig.
+	// +immutable
+	// +optional
+	Location string `json:"location,omitempty"`
+
+	// Tags - Resource tags.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// A StorageSyncServiceSpec defines the desired state of a StorageSyncService.
+type StorageSyncServiceSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       StorageSyncServiceParameters `json:"forProvider"`
+}
+
+// A StorageSyncServiceObservation represents the observed state of a Storage
+// Sync Service in Azure.
+type StorageSyncServiceObservation struct {
+	// ID - Resource ID.
+	ID string `json:"id,omitempty"`
+
+	// StorageSyncServiceStatus - Storage Sync Service status.
+	StorageSyncServiceStatus int `json:"storageSyncServiceStatus,omitempty"`
+
+	// StorageSyncServiceUID - Storage Sync Service UID.
+	StorageSyncServiceUID string `json:"storageSyncServiceUid,omitempty"`
+}
+
+// A StorageSyncServiceStatus represents the observed state of a
+// StorageSyncService.
+type StorageSyncServiceStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          StorageSyncServiceObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A StorageSyncService is a managed resource that represents an Azure Storage
+// Sync Service, the top-level resource of Azure File Sync. Its sync groups
+// define the file shares and servers it syncs.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="LOCATION",type="string",JSONPath=".spec.forProvider.location"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,azure}
+type StorageSyncService struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   StorageSyncServiceSpec   `json:"spec"`
+	Status StorageSyncServiceStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// StorageSyncServiceList contains a list of StorageSyncService.
+type StorageSyncServiceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []StorageSyncService `json:"items"`
+}
+
+// SyncGroupParameters define the desired state of an Azure File Sync group.
+// https://docs.microsoft.com/en-us/rest/api/storagesync/sync-groups/create
+type SyncGroupParameters struct {
+	// ResourceGroupName of the Storage Sync Service of the sync group.
+	// +immutable
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// ResourceGroupNameRef to fetch resource group name.
+	// +immutable
+	ResourceGroupNameRef *xpv1.Reference `json:"resourceGroupNameRef,omitempty"`
+
+	// ResourceGroupNameSelector to select a reference to a resource group.
+	// +immutable
+	ResourceGroupNameSelector *xpv1.Selector `json:"resourceGroupNameSelector,omitempty"`
+
+	// SubscriptionID of the subscription the sync group is in. Defaults to
+	// the subscription of the credentials of the provider.
+	// +immutable
+	// +optional
+	SubscriptionID *string `json:"subscriptionID,omitempty"`
+
+	// StorageSyncServiceName is the name of the Storage Sync Service of the
+	// sync group.
+	// +immutable
+	StorageSyncServiceName string `json:"storageSyncServiceName,omitempty"`
+
+	// StorageSyncServiceNameRef to fetch the name of the Storage Sync Service.
+	// +immutable
+	StorageSyncServiceNameRef *xpv1.Reference `json:"storageSyncServiceNameRef,omitempty"`
+
+	// StorageSyncServiceNameSelector to select a reference to a Storage Sync
+	// Service.
+	// +immutable
+	StorageSyncServiceNameSelector *xpv1.Selector `json:"storageSyncServiceNameSelector,omitempty"`
+}
+
+// A SyncGroupSpec defines the desired state of a SyncGroup.
+type SyncGroupSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       SyncGroupParameters `json:"forProvider"`
+}
+
+// A SyncGroupObservation represents the observed state of a sync group in
+// Azure.
+type SyncGroupObservation struct {
+	// ID - Resource ID.
+	ID string `json:"id,omitempty"`
+
+	// UniqueID - Unique ID of the sync group.
+	UniqueID string `json:"uniqueId,omitempty"`
+
+	// SyncGroupStatus - Sync group status.
+	SyncGroupStatus string `json:"syncGroupStatus,omitempty"`
+}
+
+// A SyncGroupStatus represents the observed state of a SyncGroup.
+type SyncGroupStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          SyncGroupObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A SyncGroup is a managed resource that represents an Azure File Sync group,
+// which syncs the file share of its cloud endpoint with the folders of its
+// server endpoints.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="SERVICE",type="string",JSONPath=".spec.forProvider.storageSyncServiceName"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,azure}
+type SyncGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SyncGroupSpec   `json:"spec"`
+	Status SyncGroupStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SyncGroupList contains a list of SyncGroup.
+type SyncGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SyncGroup `json:"items"`
+}
+
+// CloudEndpointParameters define the desired state of an Azure File Sync cloud
+// endpoint. Cloud endpoints cannot be changed once they are created.
+// https://docs.microsoft.com/en-us/rest/api/storagesync/cloud-endpoints/create
+type CloudEndpointParameters struct {
+	// ResourceGroupName of the Storage Sync Service of the cloud endpoint.
+	// +immutable
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// ResourceGroupNameRef to fetch resource group name.
+	// +immutable
+	ResourceGroupNameRef *xpv1.Reference `json:"resourceGroupNameRef,omitempty"`
+
+	// ResourceGroupNameSelector to select a reference to a resource group.
+	// +immutable
+	ResourceGroupNameSelector *xpv1.Selector `json:"resourceGroupNameSelector,omitempty"`
+
+	// SubscriptionID of the subscription the cloud endpoint is in. Defaults
+	// to the subscription of the credentials of the provider.
+	// +immutable
+	// +optional
+	SubscriptionID *string `json:"subscriptionID,omitempty"`
+
+	// StorageSyncServiceName is the name of the Storage Sync Service of the
+	// cloud endpoint.
+	// +immutable
+	StorageSyncServiceName string `json:"storageSyncServiceName,omitempty"`
+
+	// StorageSyncServiceNameRef to fetch the name of the Storage Sync Service.
+	// +immutable
+	StorageSyncServiceNameRef *xpv1.Reference `json:"storageSyncServiceNameRef,omitempty"`
+
+	// StorageSyncServiceNameSelector to select a reference to a Storage Sync
+	// Service.
+	// +immutable
+	StorageSyncServiceNameSelector *xpv1.Selector `json:"storageSyncServiceNameSelector,omitempty"`
+
+	// SyncGroupName is the name of the sync group of the cloud endpoint. A
+	// sync group has exactly one cloud endpoint.
+	// +immutable
+	SyncGroupName string `json:"syncGroupName,omitempty"`
+
+	// SyncGroupNameRef to fetch the name of the sync group.
+	// +immutable
+	SyncGroupNameRef *xpv1.Reference `json:"syncGroupNameRef,omitempty"`
+
+	// SyncGroupNameSelector to select a reference to a sync group.
+	// +immutable
+	SyncGroupNameSelector *xpv1.Selector `json:"syncGroupNameSelector,omitempty"`
+
+	// StorageAccountID is the resource ID of the storage account of the file
+	// share to sync.
+	// +immutable
+	StorageAccountID string `json:"storageAccountId,omitempty"`
+
+	// StorageAccountIDRef to fetch the resource ID of the storage account.
+	// +immutable
+	StorageAccountIDRef *xpv1.Reference `json:"storageAccountIdRef,omitempty"`
+
+	// StorageAccountIDSelector to select a reference to a storage account.
+	// +immutable
+	StorageAccountIDSelector *xpv1.Selector `json:"storageAccountIdSelector,omitempty"`
+
+	// StorageAccountTenantID is the ID of the tenant of the storage account.
+	// Defaults to the tenant of the credentials of the provider.
+	// +immutable
+	// +optional
+	StorageAccountTenantID *string `json:"storageAccountTenantId,omitempty"`
+
+	// AzureFileShareName is the name of the Azure file share to sync.
+	// +immutable
+	AzureFileShareName string `json:"azureFileShareName"`
+
+	// FriendlyName of the cloud endpoint.
+	// +immutable
+	// +optional
+	FriendlyName *string `json:"friendlyName,omitempty"`
+}
+
+// A CloudEndpointSpec defines the desired state of a CloudEndpoint.
+type CloudEndpointSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       CloudEndpointParameters `json:"forProvider"`
+}
+
+// A CloudEndpointObservation represents the observed state of a cloud endpoint
+// in Azure.
+type CloudEndpointObservation struct {
+	// ID - Resource ID.
+	ID string `json:"id,omitempty"`
+
+	// ProvisioningState - Cloud endpoint provisioning state.
+	ProvisioningState string `json:"provisioningState,omitempty"`
+
+	// PartnershipID - Partnership ID.
+	PartnershipID string `json:"partnershipId,omitempty"`
+
+	// BackupEnabled - Whether the file share of the cloud endpoint is
+	// backed up.
+	BackupEnabled string `json:"backupEnabled,omitempty"`
+
+	// LastWorkflowID - ID of the last workflow of the cloud endpoint.
+	LastWorkflowID string `json:"lastWorkflowId,omitempty"`
+
+	// LastOperationName - Name of the last operation of the cloud endpoint.
+	LastOperationName string `json:"lastOperationName,omitempty"`
+}
+
+// A CloudEndpointStatus represents the observed state of a CloudEndpoint.
+type CloudEndpointStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          CloudEndpointObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A CloudEndpoint is a managed resource that represents an Azure File Sync
+// cloud endpoint, the Azure file share a sync group syncs.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.provisioningState"
+// +kubebuilder:printcolumn:name="SHARE",type="string",JSONPath=".spec.forProvider.azureFileShareName"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,azure}
+type CloudEndpoint struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CloudEndpointSpec   `json:"spec"`
+	Status CloudEndpointStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CloudEndpointList contains a list of CloudEndpoint.
+type CloudEndpointList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CloudEndpoint `json:"items"`
+}
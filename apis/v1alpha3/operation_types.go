@@ -0,0 +1,76 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// An AzureOperationSpec records an operation on an Azure resource.
+type AzureOperationSpec struct {
+	// Operation performed on the Azure resource.
+	// +kubebuilder:validation:Enum=Create;Update;Delete
+	Operation string `json:"operation"`
+
+	// ResourceReference to the managed resource on whose behalf the operation
+	// was performed.
+	ResourceReference xpv1.TypedReference `json:"resourceRef"`
+
+	// ExternalName of the Azure resource.
+	// +optional
+	ExternalName string `json:"externalName,omitempty"`
+
+	// CorrelationID of the operation in the Azure activity log.
+	CorrelationID string `json:"correlationId"`
+
+	// Timestamp at which the operation was performed.
+	Timestamp metav1.Time `json:"timestamp"`
+
+	// Error returned by Azure if the operation failed.
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An AzureOperation records a Create, Update or Delete the provider performed
+// on an Azure resource, forming an in-cluster audit trail of the changes made
+// to Azure. AzureOperations are never changed once they are recorded.
+// +kubebuilder:printcolumn:name="OPERATION",type="string",JSONPath=".spec.operation"
+// +kubebuilder:printcolumn:name="RESOURCE-KIND",type="string",JSONPath=".spec.resourceRef.kind"
+// +kubebuilder:printcolumn:name="RESOURCE-NAME",type="string",JSONPath=".spec.resourceRef.name"
+// +kubebuilder:printcolumn:name="CORRELATION-ID",type="string",JSONPath=".spec.correlationId"
+// +kubebuilder:printcolumn:name="ERROR",type="string",JSONPath=".spec.error",priority=1
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,azure}
+type AzureOperation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec AzureOperationSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// AzureOperationList contains a list of AzureOperation.
+type AzureOperationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AzureOperation `json:"items"`
+}
@@ -34,8 +34,124 @@ const (
 // A ProviderSpec defines the desired state of a Provider.
 type ProviderSpec struct {
 	// CredentialsSecretRef references a specific secret's key that contains
-	// the credentials that are used to connect to the Azure API.
-	CredentialsSecretRef xpv1.SecretKeySelector `json:"credentialsSecretRef"`
+	// the credentials that are used to connect to the Azure API. Required
+	// unless UseManagedIdentity, UseWorkloadIdentity or UseAzureCLI is set,
+	// in which case it may be omitted, or left with an empty name.
+	// +optional
+	CredentialsSecretRef xpv1.SecretKeySelector `json:"credentialsSecretRef,omitempty"`
+
+	// UseManagedIdentity authenticates to the Azure API as a managed identity
+	// rather than with the credentials of CredentialsSecretRef.
+	// +optional
+	UseManagedIdentity *ManagedIdentity `json:"useManagedIdentity,omitempty"`
+
+	// UseWorkloadIdentity authenticates to the Azure API by exchanging the
+	// Kubernetes service account token of the provider pod for Azure access
+	// tokens, rather than with the credentials of CredentialsSecretRef.
+	// +optional
+	UseWorkloadIdentity *WorkloadIdentity `json:"useWorkloadIdentity,omitempty"`
+
+	// UseAzureCLI authenticates to the Azure API with the access tokens of
+	// the Azure CLI, rather than with the credentials of CredentialsSecretRef.
+	// +optional
+	UseAzureCLI *AzureCLI `json:"useAzureCLI,omitempty"`
+
+	// Endpoint of the Azure Resource Manager API to manage resources
+	// through, e.g. that of an Azure Stack Hub. Defaults to the endpoint of
+	// the public Azure cloud, or to the resourceManagerEndpointUrl of the
+	// credentials if they have one.
+	// +optional
+	Endpoint *Endpoint `json:"endpoint,omitempty"`
+
+	// TenantID of the Azure AD tenant to authenticate to, overriding the
+	// tenantId of the credentials, e.g. to manage resources in a tenant the
+	// service principal of the credentials was consented to. Only honored for
+	// service principal credentials.
+	// +optional
+	TenantID *string `json:"tenantID,omitempty"`
+
+	// AuxiliaryTenantIDs of further Azure AD tenants to authenticate to with
+	// the service principal of the credentials. Their access tokens are sent
+	// along with every request, as Azure Resource Manager requires for
+	// operations that span tenants, e.g. peering virtual networks of
+	// different tenants. Only honored for service principal credentials.
+	// +kubebuilder:validation:MaxItems=3
+	// +optional
+	AuxiliaryTenantIDs []string `json:"auxiliaryTenantIDs,omitempty"`
+
+	// Proxy that requests to the Azure API, including those that acquire
+	// access tokens, are sent through. Defaults to the proxy of the
+	// HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables of the
+	// provider.
+	// +optional
+	Proxy *Proxy `json:"proxy,omitempty"`
+}
+
+// An AzureCLI authenticates as the user logged in to the Azure CLI on the
+// host the provider runs on, e.g. when running it out of cluster during
+// development.
+type AzureCLI struct {
+	// SubscriptionID of the subscription managed resources are in. Defaults
+	// to the default subscription of the Azure CLI.
+	// +optional
+	SubscriptionID *string `json:"subscriptionId,omitempty"`
+}
+
+// An Endpoint of the Azure Resource Manager API.
+type Endpoint struct {
+	// ResourceManagerURL is the URL of the API, e.g.
+	// https://management.local.azurestack.external/.
+	ResourceManagerURL string `json:"resourceManagerUrl"`
+
+	// Audience of the access tokens the API accepts. Defaults to
+	// ResourceManagerURL.
+	// +optional
+	Audience *string `json:"audience,omitempty"`
+}
+
+// A Proxy is an HTTP or HTTPS proxy.
+type Proxy struct {
+	// URL of the proxy, e.g. http://proxy.example.com:3128.
+	URL string `json:"url"`
+
+	// NoProxy lists hosts, domains such as .example.com, and CIDR ranges
+	// whose requests bypass the proxy, like the NO_PROXY environment
+	// variable. The Azure instance metadata service always bypasses the
+	// proxy.
+	// +optional
+	NoProxy []string `json:"noProxy,omitempty"`
+}
+
+// A ManagedIdentity is an Azure managed identity assigned to the node or pod
+// the provider runs on, e.g. on AKS.
+type ManagedIdentity struct {
+	// SubscriptionID of the subscription managed resources are in.
+	SubscriptionID string `json:"subscriptionId"`
+
+	// ClientID of the user-assigned managed identity to authenticate as. The
+	// system-assigned managed identity is used if unset.
+	// +optional
+	ClientID *string `json:"clientId,omitempty"`
+}
+
+// A WorkloadIdentity is an Azure AD application or user-assigned managed
+// identity with a federated identity credential that trusts the Kubernetes
+// service account of the provider pod.
+type WorkloadIdentity struct {
+	// SubscriptionID of the subscription managed resources are in.
+	SubscriptionID string `json:"subscriptionId"`
+
+	// TenantID of the Azure AD tenant of the identity.
+	TenantID string `json:"tenantId"`
+
+	// ClientID of the identity to authenticate as.
+	ClientID string `json:"clientId"`
+
+	// TokenFile is the path of the projected service account token that is
+	// exchanged for Azure access tokens. Defaults to the path the Azure
+	// workload identity webhook projects it to.
+	// +optional
+	TokenFile *string `json:"tokenFile,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -66,7 +182,13 @@ type ResourceGroupSpec struct {
 
 	// Location of the resource group. See the  official list of valid regions -
 	// https://azure.microsoft.com/en-us/global-infrastructure/regions/
-	Location string `json:"location"`
+	// Defaults to the defaultLocation of the ProviderConfig.
+	// +optional
+	Location string `json:"location,omitempty"`
+
+	// Tags of the resource group.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
 }
 
 // A ResourceGroupStatus represents the observed status of a ResourceGroup.
@@ -114,4 +236,15 @@ type AsyncOperation struct {
 
 	// ErrorMessage represents the error that occurred during the operation.
 	ErrorMessage string `json:"errorMessage,omitempty"`
+
+	// ClientRequestID is the x-ms-client-request-id header value the initial
+	// request is made with.
+	ClientRequestID string `json:"clientRequestId,omitempty"`
+
+	// StartTime is the time the initial request is made.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// Generation is the metadata.generation of the managed resource the
+	// initial request is made for.
+	Generation int64 `json:"generation,omitempty"`
 }
@@ -53,7 +53,34 @@ var (
 	ResourceGroupGroupVersionKind = SchemeGroupVersion.WithKind(ResourceGroupKind)
 )
 
+// AzureSKUCatalog type metadata.
+var (
+	AzureSKUCatalogKind             = reflect.TypeOf(AzureSKUCatalog{}).Name()
+	AzureSKUCatalogGroupKind        = schema.GroupKind{Group: Group, Kind: AzureSKUCatalogKind}.String()
+	AzureSKUCatalogKindAPIVersion   = AzureSKUCatalogKind + "." + SchemeGroupVersion.String()
+	AzureSKUCatalogGroupVersionKind = SchemeGroupVersion.WithKind(AzureSKUCatalogKind)
+)
+
+// AzureResourceObservation type metadata.
+var (
+	AzureResourceObservationKind             = reflect.TypeOf(AzureResourceObservation{}).Name()
+	AzureResourceObservationGroupKind        = schema.GroupKind{Group: Group, Kind: AzureResourceObservationKind}.String()
+	AzureResourceObservationKindAPIVersion   = AzureResourceObservationKind + "." + SchemeGroupVersion.String()
+	AzureResourceObservationGroupVersionKind = SchemeGroupVersion.WithKind(AzureResourceObservationKind)
+)
+
+// AzureOperation type metadata.
+var (
+	AzureOperationKind             = reflect.TypeOf(AzureOperation{}).Name()
+	AzureOperationGroupKind        = schema.GroupKind{Group: Group, Kind: AzureOperationKind}.String()
+	AzureOperationKindAPIVersion   = AzureOperationKind + "." + SchemeGroupVersion.String()
+	AzureOperationGroupVersionKind = SchemeGroupVersion.WithKind(AzureOperationKind)
+)
+
 func init() {
 	SchemeBuilder.Register(&Provider{}, &ProviderList{})
 	SchemeBuilder.Register(&ResourceGroup{}, &ResourceGroupList{})
+	SchemeBuilder.Register(&AzureSKUCatalog{}, &AzureSKUCatalogList{})
+	SchemeBuilder.Register(&AzureResourceObservation{}, &AzureResourceObservationList{})
+	SchemeBuilder.Register(&AzureOperation{}, &AzureOperationList{})
 }
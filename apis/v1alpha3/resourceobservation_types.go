@@ -0,0 +1,114 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// An AzureResourceObservationSpec defines the Azure resource that is observed.
+type AzureResourceObservationSpec struct {
+	// ProviderConfigReference specifies the ProviderConfig whose credentials
+	// are used to read the resource.
+	ProviderConfigReference xpv1.Reference `json:"providerConfigRef"`
+
+	// ResourceID of the observed resource, e.g.
+	// /subscriptions/<id>/resourceGroups/<group>/providers/Microsoft.Network/virtualNetworks/<name>.
+	ResourceID string `json:"resourceId"`
+
+	// APIVersion of the resource provider used to read the resource, e.g.
+	// 2020-05-01. Defaults to the latest non-preview API version of the type
+	// of the resource.
+	// +optional
+	APIVersion *string `json:"apiVersion,omitempty"`
+}
+
+// An ObservedAzureResource mirrors the state of an Azure resource.
+type ObservedAzureResource struct {
+	// ID of the resource.
+	ID string `json:"id"`
+
+	// Name of the resource.
+	Name string `json:"name"`
+
+	// Type of the resource, e.g. Microsoft.Network/virtualNetworks.
+	Type string `json:"type"`
+
+	// APIVersion the resource was read with.
+	APIVersion string `json:"apiVersion"`
+
+	// Location of the resource.
+	// +optional
+	Location string `json:"location,omitempty"`
+
+	// Kind of the resource, for types that have kinds.
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// Tags of the resource.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// Properties of the resource, as returned by its resource provider.
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Properties *runtime.RawExtension `json:"properties,omitempty"`
+}
+
+// An AzureResourceObservationStatus mirrors the observed Azure resource.
+type AzureResourceObservationStatus struct {
+	xpv1.ConditionedStatus `json:",inline"`
+
+	// LastSyncTime is the last time the resource was read.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// AtProvider is the last observed state of the resource.
+	// +optional
+	AtProvider *ObservedAzureResource `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An AzureResourceObservation mirrors the state of an arbitrary Azure
+// resource into its status, so that it can be referenced without being
+// managed. It is observe-only; the resource is never changed or deleted.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="TYPE",type="string",JSONPath=".status.atProvider.type"
+// +kubebuilder:printcolumn:name="LAST-SYNC",type="date",JSONPath=".status.lastSyncTime"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,azure}
+// +kubebuilder:subresource:status
+type AzureResourceObservation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AzureResourceObservationSpec   `json:"spec"`
+	Status AzureResourceObservationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AzureResourceObservationList contains a list of AzureResourceObservation.
+type AzureResourceObservationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AzureResourceObservation `json:"items"`
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// An AzureSKUCatalogSpec defines the locations whose SKUs are listed.
+type AzureSKUCatalogSpec struct {
+	// ProviderConfigReference specifies the ProviderConfig whose credentials
+	// are used to list SKUs.
+	ProviderConfigReference xpv1.Reference `json:"providerConfigRef"`
+
+	// Locations whose SKUs are listed, e.g. westus2.
+	Locations []string `json:"locations"`
+}
+
+// A SKUOffering lists the SKUs of a resource type offered in a location.
+type SKUOffering struct {
+	// Location the SKUs are offered in.
+	Location string `json:"location"`
+
+	// ResourceType the SKUs apply to, e.g. Microsoft.Compute/virtualMachines.
+	ResourceType string `json:"resourceType"`
+
+	// Names of the SKUs, e.g. Standard_DS2_v2.
+	Names []string `json:"names"`
+}
+
+// An AzureSKUCatalogStatus lists the SKUs offered to the subscription.
+type AzureSKUCatalogStatus struct {
+	xpv1.ConditionedStatus `json:",inline"`
+
+	// LastSyncTime is the last time the SKUs were listed.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// SKUs offered to the subscription, per location and resource type.
+	// +optional
+	SKUs []SKUOffering `json:"skus,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An AzureSKUCatalog lists the compute and database SKUs that Azure offers to
+// a subscription in a set of locations. It is observe-only; the catalog is
+// refreshed periodically from the Azure SKU APIs.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="LAST-SYNC",type="date",JSONPath=".status.lastSyncTime"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,azure}
+// +kubebuilder:subresource:status
+type AzureSKUCatalog struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AzureSKUCatalogSpec   `json:"spec"`
+	Status AzureSKUCatalogStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AzureSKUCatalogList contains a list of AzureSKUCatalog.
+type AzureSKUCatalogList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AzureSKUCatalog `json:"items"`
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
@@ -24,8 +25,264 @@ import (
 
 // A ProviderConfigSpec defines the desired state of a ProviderConfig.
 type ProviderConfigSpec struct {
-	// Credentials required to authenticate to this provider.
+	// Credentials required to authenticate to this provider. Ignored if
+	// UseManagedIdentity, UseWorkloadIdentity or UseAzureCLI is set and
+	// available, unless CredentialsOrder says otherwise. The source should be
+	// None if Credentials are not used.
 	Credentials ProviderCredentials `json:"credentials"`
+
+	// UseManagedIdentity authenticates to the Azure API as a managed identity
+	// rather than with Credentials.
+	// +optional
+	UseManagedIdentity *ManagedIdentity `json:"useManagedIdentity,omitempty"`
+
+	// UseWorkloadIdentity authenticates to the Azure API by exchanging the
+	// Kubernetes service account token of the provider pod for Azure access
+	// tokens, rather than with Credentials.
+	// +optional
+	UseWorkloadIdentity *WorkloadIdentity `json:"useWorkloadIdentity,omitempty"`
+
+	// UseAzureCLI authenticates to the Azure API with the access tokens of
+	// the Azure CLI, rather than with Credentials.
+	// +optional
+	UseAzureCLI *AzureCLI `json:"useAzureCLI,omitempty"`
+
+	// CredentialsOrder in which the configured credentials methods are
+	// tried when more than one is configured. The first method that is
+	// available, e.g. ManagedIdentity if the Azure instance metadata service
+	// is reachable, is used. Methods that are not listed are never used.
+	// Defaults to ManagedIdentity, WorkloadIdentity, AzureCLI, Credentials.
+	// +optional
+	CredentialsOrder []CredentialsMethod `json:"credentialsOrder,omitempty"`
+
+	// Endpoint of the Azure Resource Manager API to manage resources
+	// through, e.g. that of an Azure Stack Hub. Defaults to the endpoint of
+	// the public Azure cloud, or to the resourceManagerEndpointUrl of the
+	// credentials if they have one.
+	// +optional
+	Endpoint *Endpoint `json:"endpoint,omitempty"`
+
+	// TenantID of the Azure AD tenant to authenticate to, overriding the
+	// tenantId of the credentials, e.g. to manage resources in a tenant the
+	// service principal of the credentials was consented to. Only honored for
+	// service principal credentials.
+	// +optional
+	TenantID *string `json:"tenantID,omitempty"`
+
+	// AuxiliaryTenantIDs of further Azure AD tenants to authenticate to with
+	// the service principal of the credentials. Their access tokens are sent
+	// along with every request, as Azure Resource Manager requires for
+	// operations that span tenants, e.g. peering virtual networks of
+	// different tenants. Only honored for service principal credentials.
+	// +kubebuilder:validation:MaxItems=3
+	// +optional
+	AuxiliaryTenantIDs []string `json:"auxiliaryTenantIDs,omitempty"`
+
+	// Proxy that requests to the Azure API, including those that acquire
+	// access tokens, are sent through. Defaults to the proxy of the
+	// HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables of the
+	// provider.
+	// +optional
+	Proxy *Proxy `json:"proxy,omitempty"`
+
+	// QuotaWatch configures the compute and network quotas of the
+	// subscription whose usage is recorded in the status of this
+	// ProviderConfig.
+	// +optional
+	QuotaWatch *QuotaWatch `json:"quotaWatch,omitempty"`
+
+	// ApprovalPolicy requires the deletion of managed resources that use this
+	// ProviderConfig, and updates that may destroy data or connectivity, to
+	// be approved before they are executed.
+	// +optional
+	ApprovalPolicy *ApprovalPolicy `json:"approvalPolicy,omitempty"`
+
+	// DefaultLocation of managed resources that use this ProviderConfig and
+	// do not specify a location, e.g. westus2.
+	// +optional
+	DefaultLocation *string `json:"defaultLocation,omitempty"`
+
+	// AllowedLocations of managed resources that use this ProviderConfig.
+	// Managed resources in any other location are neither created nor
+	// updated. All locations are allowed if unset.
+	// +optional
+	AllowedLocations []string `json:"allowedLocations,omitempty"`
+
+	// DefaultResourceGroupName of managed resources that use this
+	// ProviderConfig and neither specify, reference nor select a resource
+	// group.
+	// +optional
+	DefaultResourceGroupName *string `json:"defaultResourceGroupName,omitempty"`
+
+	// DefaultTags of managed resources that use this ProviderConfig. Tags a
+	// managed resource specifies take precedence over default tags with the
+	// same key.
+	// +optional
+	DefaultTags map[string]string `json:"defaultTags,omitempty"`
+
+	// ExternalNamePrefix is prepended to the name of managed resources that
+	// use this ProviderConfig to name their Azure resource when they are
+	// created without an external name, e.g. prod- names the Azure resource
+	// of a managed resource named db prod-db. It is applied by the defaulting
+	// webhook, so it has no effect if the webhooks are disabled.
+	// +optional
+	ExternalNamePrefix *string `json:"externalNamePrefix,omitempty"`
+
+	// TagPolicy determines what happens to the tags of the Azure resources of
+	// managed resources that use this ProviderConfig when they were added
+	// outside of Crossplane, e.g. by Azure Policy. Replace removes them, so
+	// that Azure resources have exactly the tags of their managed resource.
+	// Merge adds them to the tags of the managed resource instead. Defaults
+	// to Replace.
+	// +optional
+	TagPolicy *TagPolicy `json:"tagPolicy,omitempty"`
+
+	// ConnectionSecretKeyVault is an Azure Key Vault the connection details
+	// of managed resources that use this ProviderConfig are written to
+	// instead of Kubernetes secrets, so that they are never stored in etcd.
+	// +optional
+	ConnectionSecretKeyVault *KeyVault `json:"connectionSecretKeyVault,omitempty"`
+}
+
+// A TagPolicy determines what happens to tags that were added to an Azure
+// resource outside of Crossplane.
+// +kubebuilder:validation:Enum=Replace;Merge
+type TagPolicy string
+
+// Tag policies.
+const (
+	TagPolicyReplace TagPolicy = "Replace"
+	TagPolicyMerge   TagPolicy = "Merge"
+)
+
+// A CredentialsMethod is a method of authenticating to the Azure API.
+// +kubebuilder:validation:Enum=ManagedIdentity;WorkloadIdentity;AzureCLI;Credentials
+type CredentialsMethod string
+
+// Credentials methods.
+const (
+	CredentialsMethodManagedIdentity  CredentialsMethod = "ManagedIdentity"
+	CredentialsMethodWorkloadIdentity CredentialsMethod = "WorkloadIdentity"
+	CredentialsMethodAzureCLI         CredentialsMethod = "AzureCLI"
+	CredentialsMethodCredentials      CredentialsMethod = "Credentials"
+)
+
+// An AzureCLI authenticates as the user logged in to the Azure CLI on the
+// host the provider runs on, e.g. when running it out of cluster during
+// development.
+type AzureCLI struct {
+	// SubscriptionID of the subscription managed resources are in. Defaults
+	// to the default subscription of the Azure CLI.
+	// +optional
+	SubscriptionID *string `json:"subscriptionId,omitempty"`
+}
+
+// An Endpoint of the Azure Resource Manager API.
+type Endpoint struct {
+	// ResourceManagerURL is the URL of the API, e.g.
+	// https://management.local.azurestack.external/.
+	ResourceManagerURL string `json:"resourceManagerUrl"`
+
+	// Audience of the access tokens the API accepts. Defaults to
+	// ResourceManagerURL.
+	// +optional
+	Audience *string `json:"audience,omitempty"`
+
+	// SupportedKinds of managed resources the API supports, as kind.group,
+	// e.g. Subnet.network.azure.crossplane.io. Azure Stack Hub and Azure
+	// Stack Edge support only some of the resource types of the public Azure
+	// cloud. Managed resources of other kinds that use this ProviderConfig
+	// are rejected when they are created. It is enforced by a validating
+	// webhook, so it has no effect if the webhooks are disabled. All kinds
+	// are supported if unset.
+	// +optional
+	SupportedKinds []string `json:"supportedKinds,omitempty"`
+}
+
+// A KeyVault is an Azure Key Vault.
+type KeyVault struct {
+	// VaultURL of the Key Vault, e.g. https://example.vault.azure.net.
+	VaultURL string `json:"vaultURL"`
+
+	// Audience of the access tokens the Key Vault accepts. Defaults to that
+	// of the public Azure cloud, https://vault.azure.net.
+	// +optional
+	Audience *string `json:"audience,omitempty"`
+}
+
+// A Proxy is an HTTP or HTTPS proxy.
+type Proxy struct {
+	// URL of the proxy, e.g. http://proxy.example.com:3128.
+	URL string `json:"url"`
+
+	// NoProxy lists hosts, domains such as .example.com, and CIDR ranges
+	// whose requests bypass the proxy, like the NO_PROXY environment
+	// variable. The Azure instance metadata service always bypasses the
+	// proxy.
+	// +optional
+	NoProxy []string `json:"noProxy,omitempty"`
+}
+
+// A ManagedIdentity is an Azure managed identity assigned to the node or pod
+// the provider runs on, e.g. on AKS.
+type ManagedIdentity struct {
+	// SubscriptionID of the subscription managed resources are in.
+	SubscriptionID string `json:"subscriptionId"`
+
+	// ClientID of the user-assigned managed identity to authenticate as. The
+	// system-assigned managed identity is used if unset.
+	// +optional
+	ClientID *string `json:"clientId,omitempty"`
+}
+
+// A WorkloadIdentity is an Azure AD application or user-assigned managed
+// identity with a federated identity credential that trusts the Kubernetes
+// service account of the provider pod.
+type WorkloadIdentity struct {
+	// SubscriptionID of the subscription managed resources are in.
+	SubscriptionID string `json:"subscriptionId"`
+
+	// TenantID of the Azure AD tenant of the identity.
+	TenantID string `json:"tenantId"`
+
+	// ClientID of the identity to authenticate as.
+	ClientID string `json:"clientId"`
+
+	// TokenFile is the path of the projected service account token that is
+	// exchanged for Azure access tokens. Defaults to the path the Azure
+	// workload identity webhook projects it to.
+	// +optional
+	TokenFile *string `json:"tokenFile,omitempty"`
+}
+
+// An ApprovalPolicy configures how destructive operations are approved. An
+// operation is approved if any of the configured methods approves it. Until
+// then the managed resource is held with a PendingApproval condition.
+type ApprovalPolicy struct {
+	// WebhookURL is sent a POST request describing each destructive
+	// operation. The operation is approved if it responds with 200 OK.
+	// +optional
+	WebhookURL *string `json:"webhookURL,omitempty"`
+
+	// SigningKeySecretRef references the HMAC key of approval annotations.
+	// An operation is approved if the managed resource carries an
+	// azure.crossplane.io/approval annotation holding the hex encoded
+	// HMAC-SHA256 of the operation, signed with this key.
+	// +optional
+	SigningKeySecretRef *xpv1.SecretKeySelector `json:"signingKeySecretRef,omitempty"`
+}
+
+// A QuotaWatch configures the quotas of a subscription that are watched.
+type QuotaWatch struct {
+	// Locations whose quotas are watched, e.g. westus2.
+	Locations []string `json:"locations"`
+
+	// ThresholdPercent is the percentage of the limit of a quota whose
+	// crossing fires a warning event. Defaults to 80.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	ThresholdPercent *int `json:"thresholdPercent,omitempty"`
 }
 
 // ProviderCredentials required to authenticate.
@@ -40,6 +297,92 @@ type ProviderCredentials struct {
 // A ProviderConfigStatus represents the status of a ProviderConfig.
 type ProviderConfigStatus struct {
 	xpv1.ProviderConfigStatus `json:",inline"`
+
+	// Quotas whose usage is not zero in the locations watched by QuotaWatch.
+	// +optional
+	Quotas []QuotaUsage `json:"quotas,omitempty"`
+}
+
+// TypeCredentialsValid indicates whether the credentials of a ProviderConfig
+// can authenticate to the Azure API.
+const TypeCredentialsValid xpv1.ConditionType = "CredentialsValid"
+
+// Reasons a ProviderConfig's credentials are or are not valid.
+const (
+	ReasonAuthenticated      xpv1.ConditionReason = "Authenticated"
+	ReasonAuthenticateFailed xpv1.ConditionReason = "AuthenticateFailed"
+)
+
+// CredentialsValid returns a condition that indicates the credentials of a
+// ProviderConfig authenticated to the Azure API.
+func CredentialsValid() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeCredentialsValid,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonAuthenticated,
+	}
+}
+
+// CredentialsInvalid returns a condition that indicates the credentials of a
+// ProviderConfig could not authenticate to the Azure API, with the supplied
+// error as its message.
+func CredentialsInvalid(err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeCredentialsValid,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonAuthenticateFailed,
+		Message:            err.Error(),
+	}
+}
+
+// TypeCredentialsMethod indicates which credentials method of a ProviderConfig
+// is used to authenticate to the Azure API. Its reason is the method.
+const TypeCredentialsMethod xpv1.ConditionType = "CredentialsMethod"
+
+// ReasonNoCredentialsMethod indicates that none of the credentials methods of
+// a ProviderConfig is available.
+const ReasonNoCredentialsMethod xpv1.ConditionReason = "NoCredentialsMethod"
+
+// CredentialsMethodActive returns a condition that indicates the supplied
+// credentials method of a ProviderConfig is used to authenticate to the Azure
+// API.
+func CredentialsMethodActive(m CredentialsMethod) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeCredentialsMethod,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             xpv1.ConditionReason(m),
+	}
+}
+
+// CredentialsMethodUnavailable returns a condition that indicates none of the
+// credentials methods of a ProviderConfig is available, with the supplied
+// error as its message.
+func CredentialsMethodUnavailable(err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeCredentialsMethod,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNoCredentialsMethod,
+		Message:            err.Error(),
+	}
+}
+
+// A QuotaUsage is the usage of an Azure quota.
+type QuotaUsage struct {
+	// Location of the quota.
+	Location string `json:"location"`
+
+	// Name of the quota, e.g. cores.
+	Name string `json:"name"`
+
+	// CurrentValue is the current usage of the quota.
+	CurrentValue int64 `json:"currentValue"`
+
+	// Limit is the maximum permitted usage of the quota.
+	Limit int64 `json:"limit"`
 }
 
 // +kubebuilder:object:root=true
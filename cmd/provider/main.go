@@ -17,28 +17,100 @@ limitations under the License.
 package main
 
 import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 
 	"gopkg.in/alecthomas/kingpin.v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 
 	"github.com/crossplane/provider-azure/apis"
+	"github.com/crossplane/provider-azure/pkg/audit"
+	"github.com/crossplane/provider-azure/pkg/clients/fault"
+	"github.com/crossplane/provider-azure/pkg/clients/fips"
+	"github.com/crossplane/provider-azure/pkg/clients/proxy"
+	"github.com/crossplane/provider-azure/pkg/concurrency"
+	"github.com/crossplane/provider-azure/pkg/connection"
 	"github.com/crossplane/provider-azure/pkg/controller"
+	"github.com/crossplane/provider-azure/pkg/controller/backup"
+	"github.com/crossplane/provider-azure/pkg/controller/cost"
+	"github.com/crossplane/provider-azure/pkg/controller/monitor"
+	"github.com/crossplane/provider-azure/pkg/feature"
+	"github.com/crossplane/provider-azure/pkg/metrics"
+	"github.com/crossplane/provider-azure/pkg/pause"
+	"github.com/crossplane/provider-azure/pkg/resync"
+	"github.com/crossplane/provider-azure/pkg/telemetry"
+	"github.com/crossplane/provider-azure/pkg/terraform"
 )
 
 func main() {
 	var (
 		app            = kingpin.New(filepath.Base(os.Args[0]), "Azure support for Crossplane.").DefaultEnvars()
 		debug          = app.Flag("debug", "Run with debug logging.").Short('d').Bool()
-		syncPeriod     = app.Flag("sync", "Controller manager sync period duration such as 300ms, 1.5h or 2h45m").Short('s').Default("1h").Duration()
+		syncPeriod     = app.Flag("sync", "Interval at which the controller manager resyncs its cache of Kubernetes objects, such as 300ms, 1.5h or 2h45m. It does not set how often Azure resources are observed; see --sync-period.").Short('s').Default("1h").Duration()
 		leaderElection = app.Flag("leader-election", "Use leader election for the conroller manager.").Short('l').Default("false").OverrideDefaultFromEnvar("LEADER_ELECTION").Bool()
+		estimateCosts  = app.Flag("estimate-costs", "Annotate managed resources with their estimated monthly cost using the Azure Retail Prices API.").Default("false").Bool()
+		teamLabel      = app.Flag("chargeback-team-label", "Label of managed resources that identifies the team that requested them, exported by the managed resource info metric.").Default(metrics.DefaultTeamLabel).String()
+		backupSecret   = app.Flag("backup-secret", "Connection secret, as namespace/name, of the storage account to periodically snapshot all managed resources to. Snapshots are disabled if unset.").String()
+		backupCont     = app.Flag("backup-container", "Blob container of the backup storage account to write snapshots to.").Default("crossplane-backup").String()
+		backupInterval = app.Flag("backup-interval", "Interval between snapshots of all managed resources such as 1h or 24h.").Default("24h").Duration()
+		monitorSecret  = app.Flag("monitor-secret", "Secret, as namespace/name, with the workspaceId and sharedKey of a Log Analytics workspace to export the Events and condition transitions of managed resources to. Exports are disabled if unset.").String()
+		monitorLogType = app.Flag("monitor-log-type", "Log type of exported records; Log Analytics stores them in a custom log table of this name with a _CL suffix.").Default("CrossplaneAzure").String()
+		monitorInt     = app.Flag("monitor-interval", "Interval between exports of buffered records to Log Analytics such as 30s or 5m.").Default("30s").Duration()
+		serveDashboard = app.Flag("serve-dashboard", "Serve a Grafana dashboard of work queue depth, reconcile durations and Azure API errors at /dashboard.json of the metrics server.").Default("false").Bool()
+		injectFaults   = app.Flag("inject-faults", "Inject faults into Azure API requests for resilience testing, e.g. throttle=0.1,server-error=0.05,not-found=0.05,slow-operation=0.2. Never use in production.").Hidden().String()
+		cacheSecrets   = app.Flag("cache-secrets", "Cache secrets in memory. Use --no-cache-secrets to read credentials and connection secrets from the API server instead, which reduces the memory footprint of the provider in clusters with many or large secrets at the cost of more API server requests.").Default("true").Bool()
+		watchSelector  = app.Flag("watch-label-selector", "Label selector of the managed resources to reconcile, e.g. instance=prod, so that several deployments of the provider can share a cluster. All managed resources are reconciled if unset.").String()
+		secretNS       = app.Flag("connection-secret-namespace", "Namespace to write the connection secrets of managed resources that omit writeConnectionSecretToRef to. Their connection details are not written if unset.").String()
+		allowedNS      = app.Flag("allowed-connection-secret-namespaces", "Comma separated namespaces managed resources may write connection secrets to, in addition to the one of --connection-secret-namespace. All namespaces are allowed if unset.").String()
+		webhookCerts   = app.Flag("webhook-tls-cert-dir", "Directory of the tls.crt and tls.key the webhooks serve with. They convert between the API versions of kinds that are served at several, e.g. network v1alpha3 and v1beta1, apply the defaults of ProviderConfigs to new managed resources, reject managed resources of kinds the endpoint of their ProviderConfig does not support, reject changes to immutable fields, and warn about fields that are ignored. Crossplane sets it when it installs the provider package. The webhooks are disabled if unset.").Envar("WEBHOOK_TLS_CERT_DIR").String()
+		resyncDepth    = app.Flag("resync-saturation-depth", "Work queue depth at which a controller is saturated. The poll interval of a controller whose work queue is consistently saturated is doubled, up to --resync-max-factor times, until its queue drains. Poll intervals are never lengthened if 0.").Default("100").Int()
+		resyncFactor   = app.Flag("resync-max-factor", "Maximum factor the poll interval of a controller with a saturated work queue is lengthened by.").Default(strconv.Itoa(resync.DefaultMaxFactor)).Int()
+		pollInterval   = app.Flag("sync-period", "Interval at which managed resources are polled, i.e. their Azure resource is observed, such as 1m, 10m or 1h. Unlike --sync, which resyncs the cache of the controller manager. Overridden for a managed resource by its "+resync.AnnotationKeySyncPeriod+" annotation. Longer intervals make Azure Resource Manager throttle the provider less, but take longer to notice changes made outside Crossplane.").Default("1m").Duration()
+		maxReconciles  = app.Flag("max-reconcile-rate", "Maximum number of managed resources of a kind reconciled concurrently. Higher rates let large fleets of managed resources reconcile in parallel, at the cost of more concurrent Azure API requests.").Default("1").Int()
+		groupReconcile = app.Flag("max-reconcile-rate-per-group", "Comma separated overrides of --max-reconcile-rate for the kinds of an API group, with or without its .azure.crossplane.io suffix, e.g. compute=4,database=2.").String()
+		auditHistory   = app.Flag("audit-history", "Number of AzureOperations to keep per managed resource. Every create, update and delete of an Azure resource is recorded as an AzureOperation with the correlation ID of the operation in the Azure activity log, and the oldest are deleted once there are more. Operations are not recorded if 0.").Default("0").Int()
+		disableFeature = app.Flag("disable-features", "Comma separated optional features to disable, e.g. quota-usage,sku-catalog, in subscriptions where the Azure APIs they call are blocked. Either of management-locks, quota-usage and sku-catalog. Features are also disabled for --feature-backoff whenever Azure forbids one of their requests.").String()
+		featureBackoff = app.Flag("feature-backoff", "Duration for which an optional feature is disabled once Azure forbids one of its requests, such as 30m or 6h.").Default(feature.DefaultBackoff.String()).Duration()
+		fipsMode       = app.Flag("fips", "Restrict TLS connections to Azure to FIPS 140-2 approved protocol versions, cipher suites and curves. Always enabled in builds with the fips build tag, which use a FIPS 140-2 validated cryptographic module.").Default("false").Bool()
+		otlpEndpoint   = app.Flag("otlp-endpoint", "Endpoint, as host:port, of an OTLP gRPC receiver such as an OpenTelemetry collector to export traces to. Every reconcile of a managed resource is traced as a span, with child spans for the operations on its Azure resource and the Azure API requests they send, which record the Azure resource ID and the correlation ID of their request. Tracing is disabled if unset.").String()
+		otlpInsecure   = app.Flag("otlp-insecure", "Export traces to --otlp-endpoint without TLS.").Default("false").Bool()
+		otlpRatio      = app.Flag("otlp-sample-ratio", "Fraction of reconciles to trace, between 0 and 1.").Default("1").Float64()
+
+		_         = app.Command("start", "Start the Azure provider controllers.").Default()
+		exportCmd = app.Command("export-terraform", "Write a Terraform import block for every ready managed resource.")
+		exportOut = exportCmd.Flag("output", "File to write the import blocks to. Defaults to stdout.").Short('o').String()
+		pauseCmd  = app.Command("pause", "Pause the reconciliation of managed resources matching a label selector.")
+		pauseSel  = pauseCmd.Flag("selector", "Label selector of the managed resources to pause, e.g. region=westeurope.").Required().String()
+		resumeCmd = app.Command("resume", "Resume the reconciliation of managed resources matching a label selector.")
+		resumeSel = resumeCmd.Flag("selector", "Label selector of the managed resources to resume, e.g. region=westeurope.").Required().String()
 	)
-	kingpin.MustParse(app.Parse(os.Args[1:]))
+	switch kingpin.MustParse(app.Parse(os.Args[1:])) {
+	case exportCmd.FullCommand():
+		exportTerraform(*exportOut)
+		return
+	case pauseCmd.FullCommand():
+		setPaused(*pauseSel, true)
+		return
+	case resumeCmd.FullCommand():
+		setPaused(*resumeSel, false)
+		return
+	}
 
 	zl := zap.New(zap.UseDevMode(*debug))
 	log := logging.NewLogrLogger(zl.WithName("provider-azure"))
@@ -49,20 +121,163 @@ func main() {
 		ctrl.SetLogger(zl)
 	}
 
-	log.Debug("Starting", "sync-period", syncPeriod.String())
+	log.Debug("Starting", "sync", syncPeriod.String(), "sync-period", pollInterval.String())
+
+	// Azure SDK clients must pick up the proxy of their ProviderConfig before
+	// any of them sends a request.
+	proxy.Enable()
+
+	// Faults are injected beneath the metrics of Azure API requests, so that
+	// injected errors are counted as any other.
+	var wrap []func(http.RoundTripper) http.RoundTripper
+	if *fipsMode || fips.Build {
+		// The TLS config of Azure SDK clients must be restricted before any
+		// of them connects, and before their transport is wrapped.
+		fips.Enable()
+		wrap = append(wrap, fips.NewTransport)
+		log.Info("Restricting TLS connections to FIPS 140-2 approved algorithms", "fips-build", fips.Build)
+	}
+	if *injectFaults != "" {
+		fc, err := fault.Parse(*injectFaults)
+		kingpin.FatalIfError(err, "Cannot parse faults to inject")
+		wrap = append(wrap, func(rt http.RoundTripper) http.RoundTripper { return fault.NewTransport(rt, fc) })
+		log.Info("Injecting faults into Azure API requests", "faults", *injectFaults)
+	}
+	am := metrics.NewAzureAPIMetrics()
+	crmetrics.Registry.MustRegister(am)
+	if *otlpEndpoint == "" {
+		am.Enable(wrap...)
+	} else {
+		shutdown, err := telemetry.Setup(context.Background(), telemetry.Options{Endpoint: *otlpEndpoint, Insecure: *otlpInsecure, SampleRatio: *otlpRatio})
+		kingpin.FatalIfError(err, "Cannot setup tracing")
+		defer shutdown(context.Background()) // nolint:errcheck
+
+		// Only one go-autorest tracer can be registered, so requests are
+		// traced above their metrics.
+		telemetry.Enable(append(wrap, am.NewTransport)...)
+		log.Info("Exporting traces", "endpoint", *otlpEndpoint, "sample-ratio", *otlpRatio)
+	}
+	crmetrics.Registry.MustRegister(metrics.DefaultExternalMetrics)
 
 	cfg, err := ctrl.GetConfig()
 	kingpin.FatalIfError(err, "Cannot get API server rest config")
 
-	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+	sel, err := labels.Parse(*watchSelector)
+	kingpin.FatalIfError(err, "Cannot parse watch label selector")
+	leaderElectionID := "crossplane-leader-election-provider-azure"
+	if !sel.Empty() {
+		// Deployments that reconcile different managed resources must not
+		// compete for leadership.
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(sel.String()))
+		leaderElectionID = fmt.Sprintf("%s-%08x", leaderElectionID, h.Sum32())
+	}
+
+	o := ctrl.Options{
 		LeaderElection:   *leaderElection,
-		LeaderElectionID: "crossplane-leader-election-provider-azure",
+		LeaderElectionID: leaderElectionID,
 		SyncPeriod:       syncPeriod,
-	})
+	}
+	if *webhookCerts != "" {
+		o.CertDir = *webhookCerts
+	}
+	if !*cacheSecrets {
+		// Controllers watch secrets as metadata only, so no informer holds
+		// their data once reads bypass the cache.
+		o.ClientDisableCacheFor = []client.Object{&corev1.Secret{}}
+	}
+	mgr, err := ctrl.NewManager(cfg, o)
 	kingpin.FatalIfError(err, "Cannot create controller manager")
 
 	kingpin.FatalIfError(apis.AddToScheme(mgr.GetScheme()), "Cannot add Azure APIs to scheme")
-	kingpin.FatalIfError(controller.Setup(mgr, log, ratelimiter.NewDefaultProviderRateLimiter(ratelimiter.DefaultProviderRPS)), "Cannot setup Azure controllers")
+	connection.DefaultNamespacePolicy.Default = *secretNS
+	connection.DefaultNamespacePolicy.Allowed = connection.ParseNamespaces(*allowedNS)
+	disabled, err := feature.Parse(*disableFeature)
+	kingpin.FatalIfError(err, "Cannot parse features to disable")
+	feature.DefaultGate.Disable(disabled...)
+	feature.DefaultGate.Backoff = *featureBackoff
+	feature.DefaultGate.Log = log.WithValues("controller", "feature")
+	audit.DefaultTrail.History = *auditHistory
+	audit.DefaultTrail.Log = log.WithValues("controller", "audit")
+	resync.DefaultMonitor.Log = log.WithValues("controller", "resync")
+	resync.DefaultMonitor.Depth = *resyncDepth
+	resync.DefaultMonitor.MaxFactor = *resyncFactor
+	resync.DefaultMonitor.SyncPeriod = *pollInterval
+	crmetrics.Registry.MustRegister(resync.DefaultMonitor)
+	kingpin.FatalIfError(mgr.Add(resync.DefaultMonitor), "Cannot setup adaptive resync")
+	groupLimits, err := concurrency.Parse(*groupReconcile)
+	kingpin.FatalIfError(err, "Cannot parse maximum reconcile rates of API groups")
+	concurrency.DefaultLimits.Default = *maxReconciles
+	concurrency.DefaultLimits.Groups = groupLimits
+	rl := ratelimiter.NewDefaultProviderRateLimiter(ratelimiter.DefaultProviderRPS)
+	kingpin.FatalIfError(controller.Setup(mgr, log, rl, sel), "Cannot setup Azure controllers")
+	if *webhookCerts != "" {
+		kingpin.FatalIfError(controller.SetupWebhooks(mgr), "Cannot setup webhooks")
+	}
+	crmetrics.Registry.MustRegister(metrics.NewChargebackCollector(mgr.GetClient(), log, metrics.WithTeamLabel(*teamLabel)))
+	if *serveDashboard {
+		h, err := metrics.NewDashboardHandler(metrics.NewDashboard())
+		kingpin.FatalIfError(err, "Cannot generate Grafana dashboard")
+		kingpin.FatalIfError(mgr.AddMetricsExtraHandler(metrics.DashboardPath, h), "Cannot serve Grafana dashboard")
+	}
+	if *estimateCosts {
+		kingpin.FatalIfError(cost.Setup(mgr, log, rl), "Cannot setup cost estimation controllers")
+	}
+	if *backupSecret != "" {
+		ref := strings.SplitN(*backupSecret, "/", 2)
+		if len(ref) != 2 {
+			kingpin.Fatalf("Backup secret %q must be of the form namespace/name", *backupSecret)
+		}
+		kingpin.FatalIfError(backup.Setup(mgr, log, backup.Options{
+			SecretRef: types.NamespacedName{Namespace: ref[0], Name: ref[1]},
+			Container: *backupCont,
+			Interval:  *backupInterval,
+		}), "Cannot setup managed resource backups")
+	}
+	if *monitorSecret != "" {
+		ref := strings.SplitN(*monitorSecret, "/", 2)
+		if len(ref) != 2 {
+			kingpin.Fatalf("Monitor secret %q must be of the form namespace/name", *monitorSecret)
+		}
+		kingpin.FatalIfError(monitor.Setup(mgr, log, monitor.Options{
+			SecretRef: types.NamespacedName{Namespace: ref[0], Name: ref[1]},
+			LogType:   *monitorLogType,
+			Interval:  *monitorInt,
+		}), "Cannot setup Azure Monitor exports")
+	}
 	kingpin.FatalIfError(mgr.Start(ctrl.SetupSignalHandler()), "Cannot start controller manager")
+}
 
+func newClient() client.Client {
+	cfg, err := ctrl.GetConfig()
+	kingpin.FatalIfError(err, "Cannot get API server rest config")
+
+	kingpin.FatalIfError(apis.AddToScheme(scheme.Scheme), "Cannot add Azure APIs to scheme")
+	kube, err := client.New(cfg, client.Options{Scheme: scheme.Scheme})
+	kingpin.FatalIfError(err, "Cannot create Kubernetes client")
+	return kube
+}
+
+func exportTerraform(output string) {
+	blocks, err := terraform.NewExporter(newClient()).Export(context.Background())
+	kingpin.FatalIfError(err, "Cannot export managed resources")
+
+	w := os.Stdout
+	if output != "" {
+		w, err = os.Create(filepath.Clean(output))
+		kingpin.FatalIfError(err, "Cannot create output file")
+		defer w.Close() // nolint:errcheck
+	}
+	kingpin.FatalIfError(terraform.Write(w, blocks), "Cannot write import blocks")
+}
+
+func setPaused(selector string, paused bool) {
+	sel, err := labels.Parse(selector)
+	kingpin.FatalIfError(err, "Cannot parse label selector")
+
+	changed, err := pause.SetPaused(context.Background(), newClient(), scheme.Scheme, sel, paused)
+	for _, n := range changed {
+		fmt.Println(n)
+	}
+	kingpin.FatalIfError(err, "Cannot update managed resources")
 }
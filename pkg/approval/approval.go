@@ -0,0 +1,270 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package approval holds destructive operations on managed resources until
+// they are approved, as required by the ApprovalPolicy of their
+// ProviderConfig.
+package approval
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+)
+
+// AnnotationKeyApproval is the annotation that approves a destructive
+// operation on a managed resource. It holds the hex encoded HMAC-SHA256 of the
+// Payload of the operation.
+const AnnotationKeyApproval = "azure.crossplane.io/approval"
+
+// TypeApproved managed resources have had their last destructive operation
+// approved.
+const TypeApproved xpv1.ConditionType = "Approved"
+
+// Reasons a destructive operation is or is not approved.
+const (
+	ReasonPendingApproval xpv1.ConditionReason = "PendingApproval"
+	ReasonApproved        xpv1.ConditionReason = "Approved"
+)
+
+const webhookTimeout = 10 * time.Second
+
+// Error strings.
+const (
+	errGetProviderConfig = "cannot get referenced ProviderConfig"
+	errGetSigningKey     = "cannot get approval signing key"
+	errCallWebhook       = "cannot call approval webhook"
+	errPendingApproval   = "%s is pending approval"
+)
+
+// An Operation on a managed resource that must be approved.
+type Operation string
+
+// Operations that must be approved.
+const (
+	OperationDelete Operation = "Delete"
+	OperationUpdate Operation = "Update"
+)
+
+// PendingApproval returns a condition that indicates the supplied operation
+// is waiting to be approved.
+func PendingApproval(op Operation) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeApproved,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPendingApproval,
+		Message:            fmt.Sprintf(errPendingApproval, op),
+	}
+}
+
+// Approved returns a condition that indicates the last destructive operation
+// was approved.
+func Approved() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeApproved,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonApproved,
+	}
+}
+
+// Payload returns the payload whose signature approves the supplied operation
+// on the supplied managed resource. Updates are approved for the current
+// generation of the resource only.
+func Payload(mg resource.Managed, op Operation) string {
+	if op == OperationDelete {
+		return fmt.Sprintf("%s/%s", op, mg.GetUID())
+	}
+	return fmt.Sprintf("%s/%s/%d", op, mg.GetUID(), mg.GetGeneration())
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of the supplied payload.
+func Sign(key []byte, payload string) string {
+	h := hmac.New(sha256.New, key)
+	_, _ = h.Write([]byte(payload))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// IsSigned returns true if the supplied managed resource carries an approval
+// annotation for the supplied operation, signed with the supplied key.
+func IsSigned(mg resource.Managed, op Operation, key []byte) bool {
+	sig, err := hex.DecodeString(mg.GetAnnotations()[AnnotationKeyApproval])
+	if err != nil || len(sig) == 0 {
+		return false
+	}
+	want, _ := hex.DecodeString(Sign(key, Payload(mg, op)))
+	return hmac.Equal(sig, want)
+}
+
+// A Request is sent to the approval webhook for each destructive operation.
+type Request struct {
+	Operation  Operation `json:"operation"`
+	APIVersion string    `json:"apiVersion"`
+	Kind       string    `json:"kind"`
+	Name       string    `json:"name"`
+	UID        string    `json:"uid"`
+	Generation int64     `json:"generation"`
+}
+
+// A Gate decides whether a destructive operation is approved.
+type Gate interface {
+	Approve(ctx context.Context, mg resource.Managed, op Operation) (bool, error)
+}
+
+// A ProviderConfigGate approves operations as required by the ApprovalPolicy
+// of the ProviderConfig a managed resource uses. Operations on resources
+// whose ProviderConfig has no ApprovalPolicy are always approved.
+type ProviderConfigGate struct {
+	kube  client.Client
+	typer runtime.ObjectTyper
+	http  *http.Client
+}
+
+// NewProviderConfigGate returns a new ProviderConfigGate.
+func NewProviderConfigGate(c client.Client, ot runtime.ObjectTyper) *ProviderConfigGate {
+	return &ProviderConfigGate{kube: c, typer: ot, http: &http.Client{Timeout: webhookTimeout}}
+}
+
+// Approve returns true if the supplied operation on the supplied managed
+// resource is approved by its signed approval annotation or by the webhook.
+func (g *ProviderConfigGate) Approve(ctx context.Context, mg resource.Managed, op Operation) (bool, error) {
+	ref := mg.GetProviderConfigReference()
+	if ref == nil {
+		return true, nil
+	}
+	pc := &v1beta1.ProviderConfig{}
+	if err := g.kube.Get(ctx, types.NamespacedName{Name: ref.Name}, pc); err != nil {
+		return false, errors.Wrap(err, errGetProviderConfig)
+	}
+	p := pc.Spec.ApprovalPolicy
+	if p == nil {
+		return true, nil
+	}
+
+	if p.SigningKeySecretRef != nil {
+		s := &corev1.Secret{}
+		if err := g.kube.Get(ctx, types.NamespacedName{Namespace: p.SigningKeySecretRef.Namespace, Name: p.SigningKeySecretRef.Name}, s); err != nil {
+			return false, errors.Wrap(err, errGetSigningKey)
+		}
+		if IsSigned(mg, op, s.Data[p.SigningKeySecretRef.Key]) {
+			return true, nil
+		}
+	}
+
+	if p.WebhookURL != nil {
+		ok, err := g.callWebhook(ctx, *p.WebhookURL, mg, op)
+		return ok, errors.Wrap(err, errCallWebhook)
+	}
+	return false, nil
+}
+
+func (g *ProviderConfigGate) callWebhook(ctx context.Context, url string, mg resource.Managed, op Operation) (bool, error) {
+	gvk := resource.MustGetKind(mg, g.typer)
+	body, err := json.Marshal(Request{
+		Operation:  op,
+		APIVersion: gvk.GroupVersion().String(),
+		Kind:       gvk.Kind,
+		Name:       mg.GetName(),
+		UID:        string(mg.GetUID()),
+		Generation: mg.GetGeneration(),
+	})
+	if err != nil {
+		return false, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := g.http.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close() // nolint:errcheck
+	return resp.StatusCode == http.StatusOK, nil
+}
+
+// Hold returns an error, and sets the PendingApproval condition of the
+// supplied managed resource, unless the supplied operation is approved by the
+// supplied gate. A nil gate approves every operation.
+func Hold(ctx context.Context, g Gate, mg resource.Managed, op Operation) error {
+	if g == nil {
+		return nil
+	}
+	ok, err := g.Approve(ctx, mg, op)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		mg.SetConditions(PendingApproval(op))
+		return errors.Errorf(errPendingApproval, op)
+	}
+	if mg.GetCondition(TypeApproved).Reason == ReasonPendingApproval {
+		mg.SetConditions(Approved())
+	}
+	return nil
+}
+
+// NewConnecter returns an ExternalConnecter whose clients hold the deletion
+// of managed resources until it is approved by the supplied gate.
+func NewConnecter(c managed.ExternalConnecter, g Gate) managed.ExternalConnecter {
+	return &connecter{ExternalConnecter: c, gate: g}
+}
+
+type connecter struct {
+	managed.ExternalConnecter
+	gate Gate
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	e, err := c.ExternalConnecter.Connect(ctx, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &external{ExternalClient: e, gate: c.gate}, nil
+}
+
+type external struct {
+	managed.ExternalClient
+	gate Gate
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	if err := Hold(ctx, e.gate, mg, OperationDelete); err != nil {
+		return err
+	}
+	return e.ExternalClient.Delete(ctx, mg)
+}
@@ -0,0 +1,221 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approval
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+)
+
+var key = []byte("cool-key")
+
+type gateFn func(ctx context.Context, mg resource.Managed, op Operation) (bool, error)
+
+func (fn gateFn) Approve(ctx context.Context, mg resource.Managed, op Operation) (bool, error) {
+	return fn(ctx, mg, op)
+}
+
+func managedResource(annotations map[string]string) *fake.Managed {
+	return &fake.Managed{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "cool-managed",
+			UID:         "cool-uid",
+			Generation:  2,
+			Annotations: annotations,
+		},
+		ProviderConfigReferencer: fake.ProviderConfigReferencer{Ref: &xpv1.Reference{Name: "cool-pc"}},
+	}
+}
+
+func TestIsSigned(t *testing.T) {
+	cases := map[string]struct {
+		mg   resource.Managed
+		op   Operation
+		want bool
+	}{
+		"Signed": {
+			mg:   managedResource(map[string]string{AnnotationKeyApproval: Sign(key, "Delete/cool-uid")}),
+			op:   OperationDelete,
+			want: true,
+		},
+		"SignedForAnotherOperation": {
+			mg:   managedResource(map[string]string{AnnotationKeyApproval: Sign(key, "Delete/cool-uid")}),
+			op:   OperationUpdate,
+			want: false,
+		},
+		"SignedForAnotherGeneration": {
+			mg:   managedResource(map[string]string{AnnotationKeyApproval: Sign(key, "Update/cool-uid/1")}),
+			op:   OperationUpdate,
+			want: false,
+		},
+		"NotSigned": {
+			mg:   managedResource(nil),
+			op:   OperationDelete,
+			want: false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsSigned(tc.mg, tc.op, key)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("IsSigned(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHold(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	cases := map[string]struct {
+		reason string
+		gate   Gate
+		want   error
+		cond   xpv1.Condition
+	}{
+		"NoGate": {
+			reason: "A nil gate should approve every operation",
+			cond:   xpv1.Condition{Type: TypeApproved, Status: corev1.ConditionUnknown},
+		},
+		"Error": {
+			reason: "Errors approving the operation should be returned",
+			gate:   gateFn(func(_ context.Context, _ resource.Managed, _ Operation) (bool, error) { return false, errBoom }),
+			want:   errBoom,
+			cond:   xpv1.Condition{Type: TypeApproved, Status: corev1.ConditionUnknown},
+		},
+		"Pending": {
+			reason: "Operations that are not approved should be held with a PendingApproval condition",
+			gate:   gateFn(func(_ context.Context, _ resource.Managed, _ Operation) (bool, error) { return false, nil }),
+			want:   errors.Errorf(errPendingApproval, OperationDelete),
+			cond:   PendingApproval(OperationDelete),
+		},
+		"Approved": {
+			reason: "Approved operations should not be held",
+			gate:   gateFn(func(_ context.Context, _ resource.Managed, _ Operation) (bool, error) { return true, nil }),
+			cond:   xpv1.Condition{Type: TypeApproved, Status: corev1.ConditionUnknown},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := managedResource(nil)
+			err := Hold(context.Background(), tc.gate, mg, OperationDelete)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nHold(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.cond, mg.GetCondition(TypeApproved), test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nHold(...): -want condition, +got condition:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestProviderConfigGate(t *testing.T) {
+	var got Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		if got.Name == "approved" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	kube := func(p *v1beta1.ApprovalPolicy) client.Client {
+		return &test.MockClient{MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			switch o := obj.(type) {
+			case *v1beta1.ProviderConfig:
+				o.Spec.ApprovalPolicy = p
+			case *corev1.Secret:
+				o.Data = map[string][]byte{"key": key}
+			}
+			return nil
+		}}
+	}
+	url := srv.URL
+	secretRef := &xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Name: "cool-secret"}, Key: "key"}
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Client
+		mg     resource.Managed
+		want   bool
+	}{
+		"NoPolicy": {
+			reason: "Operations should be approved if the ProviderConfig has no ApprovalPolicy",
+			kube:   kube(nil),
+			mg:     managedResource(nil),
+			want:   true,
+		},
+		"SignedAnnotation": {
+			reason: "Operations should be approved by a correctly signed approval annotation",
+			kube:   kube(&v1beta1.ApprovalPolicy{SigningKeySecretRef: secretRef}),
+			mg:     managedResource(map[string]string{AnnotationKeyApproval: Sign(key, "Delete/cool-uid")}),
+			want:   true,
+		},
+		"UnsignedAnnotation": {
+			reason: "Operations should not be approved without a signed approval annotation",
+			kube:   kube(&v1beta1.ApprovalPolicy{SigningKeySecretRef: secretRef}),
+			mg:     managedResource(map[string]string{AnnotationKeyApproval: "c0ffee"}),
+			want:   false,
+		},
+		"WebhookApproves": {
+			reason: "Operations should be approved if the webhook responds with 200 OK",
+			kube:   kube(&v1beta1.ApprovalPolicy{WebhookURL: &url}),
+			mg: func() resource.Managed {
+				mg := managedResource(nil)
+				mg.SetName("approved")
+				return mg
+			}(),
+			want: true,
+		},
+		"WebhookRejects": {
+			reason: "Operations should not be approved if the webhook responds with anything but 200 OK",
+			kube:   kube(&v1beta1.ApprovalPolicy{WebhookURL: &url}),
+			mg:     managedResource(nil),
+			want:   false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			g := NewProviderConfigGate(tc.kube, fake.SchemeWith(&fake.Managed{}))
+			ok, err := g.Approve(context.Background(), tc.mg, OperationDelete)
+			if err != nil {
+				t.Fatalf("\n%s\nApprove(...): %s", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, ok); diff != "" {
+				t.Errorf("\n%s\nApprove(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
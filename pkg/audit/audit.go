@@ -0,0 +1,199 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit records the operations the provider performs on Azure
+// resources as AzureOperations, forming an in-cluster audit trail of the
+// changes Crossplane made to Azure.
+package audit
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-azure/apis/v1alpha3"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/redact"
+)
+
+// Labels of AzureOperations.
+const (
+	// LabelKeyResourceUID is the UID of the managed resource on whose behalf
+	// the operation was performed.
+	LabelKeyResourceUID = "azure.crossplane.io/resource-uid"
+
+	// LabelKeyResourceKind is the kind of the managed resource on whose
+	// behalf the operation was performed.
+	LabelKeyResourceKind = "azure.crossplane.io/resource-kind"
+)
+
+// Operations on Azure resources.
+const (
+	OperationCreate = "Create"
+	OperationUpdate = "Update"
+	OperationDelete = "Delete"
+)
+
+// A Trail of the operations performed on Azure resources.
+type Trail struct {
+	// History is the number of AzureOperations kept per managed resource.
+	// The oldest are deleted once there are more. Operations are not
+	// recorded if it is zero.
+	History int
+
+	// Log of the operations that could not be recorded.
+	Log logging.Logger
+}
+
+// DefaultTrail records no operations until its History is set, e.g. from the
+// flags of the provider.
+var DefaultTrail = &Trail{Log: logging.NewNopLogger()}
+
+// NewConnecter returns an ExternalConnecter whose clients record every
+// Create, Update and Delete of an external resource in the supplied Trail.
+// Each operation is sent to Azure with a new correlation ID, by which it can
+// be found in the Azure activity log.
+func NewConnecter(c managed.ExternalConnecter, kube client.Client, s *runtime.Scheme, t *Trail) managed.ExternalConnecter {
+	return &connecter{ExternalConnecter: c, kube: kube, scheme: s, trail: t}
+}
+
+type connecter struct {
+	managed.ExternalConnecter
+	kube   client.Client
+	scheme *runtime.Scheme
+	trail  *Trail
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	e, err := c.ExternalConnecter.Connect(ctx, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &external{ExternalClient: e, kube: c.kube, scheme: c.scheme, trail: c.trail, now: time.Now, newID: func() string { return uuid.New().String() }}, nil
+}
+
+type external struct {
+	managed.ExternalClient
+	kube   client.Client
+	scheme *runtime.Scheme
+	trail  *Trail
+	now    func() time.Time
+	newID  func() string
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	if e.trail.History <= 0 {
+		return e.ExternalClient.Create(ctx, mg)
+	}
+	id := e.newID()
+	c, err := e.ExternalClient.Create(azure.WithCorrelationID(ctx, id), mg)
+	e.record(ctx, mg, OperationCreate, id, err)
+	return c, err
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	if e.trail.History <= 0 {
+		return e.ExternalClient.Update(ctx, mg)
+	}
+	id := e.newID()
+	u, err := e.ExternalClient.Update(azure.WithCorrelationID(ctx, id), mg)
+	e.record(ctx, mg, OperationUpdate, id, err)
+	return u, err
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	if e.trail.History <= 0 {
+		return e.ExternalClient.Delete(ctx, mg)
+	}
+	id := e.newID()
+	err := e.ExternalClient.Delete(azure.WithCorrelationID(ctx, id), mg)
+	e.record(ctx, mg, OperationDelete, id, err)
+	return err
+}
+
+// record the supplied operation, and delete the oldest AzureOperations of the
+// supplied managed resource beyond the history of the trail. Failing to do so
+// is only logged; an operation that was performed must not be retried just
+// because it could not be recorded.
+func (e *external) record(ctx context.Context, mg resource.Managed, op, id string, opErr error) {
+	log := e.trail.Log.WithValues("uid", mg.GetUID(), "name", mg.GetName(), "operation", op, "correlation-id", id)
+	gvk, err := apiutil.GVKForObject(mg, e.scheme)
+	if err != nil {
+		log.Info("Cannot record operation", "error", err)
+		return
+	}
+	o := &v1alpha3.AzureOperation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: strings.ToLower(op) + "-" + id,
+			Labels: map[string]string{
+				LabelKeyResourceUID:  string(mg.GetUID()),
+				LabelKeyResourceKind: gvk.Kind,
+			},
+		},
+		Spec: v1alpha3.AzureOperationSpec{
+			Operation:         op,
+			ResourceReference: *meta.TypedReferenceTo(mg, gvk),
+			ExternalName:      meta.GetExternalName(mg),
+			CorrelationID:     id,
+			Timestamp:         metav1.NewTime(e.now()),
+		},
+	}
+	if opErr != nil {
+		o.Spec.Error = redact.String(opErr.Error())
+	}
+	if err := e.kube.Create(ctx, o); err != nil {
+		log.Info("Cannot record operation", "error", err)
+		return
+	}
+
+	l := &v1alpha3.AzureOperationList{}
+	if err := e.kube.List(ctx, l, client.MatchingLabels{LabelKeyResourceUID: string(mg.GetUID())}); err != nil {
+		log.Info("Cannot list recorded operations", "error", err)
+		return
+	}
+	for _, old := range Expired(l.Items, e.trail.History) {
+		old := old
+		if err := e.kube.Delete(ctx, &old); resource.IgnoreNotFound(err) != nil {
+			log.Info("Cannot delete expired operation", "expired", old.GetName(), "error", err)
+		}
+	}
+}
+
+// Expired returns the oldest of the supplied AzureOperations beyond the
+// supplied history.
+func Expired(ops []v1alpha3.AzureOperation, history int) []v1alpha3.AzureOperation {
+	if len(ops) <= history {
+		return nil
+	}
+	sorted := make([]v1alpha3.AzureOperation, len(ops))
+	copy(sorted, ops)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Spec.Timestamp.Before(&sorted[j].Spec.Timestamp)
+	})
+	return sorted[:len(sorted)-history]
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/network/v1beta1"
+	"github.com/crossplane/provider-azure/apis/v1alpha3"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+var errBoom = errors.New("boom")
+
+var now = time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func subnet() *v1beta1.Subnet {
+	s := &v1beta1.Subnet{ObjectMeta: metav1.ObjectMeta{Name: "cool-subnet", UID: "cool-uid"}}
+	meta.SetExternalName(s, "cool-external")
+	return s
+}
+
+func operation(name string, at time.Time) v1alpha3.AzureOperation {
+	return v1alpha3.AzureOperation{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       v1alpha3.AzureOperationSpec{Timestamp: metav1.NewTime(at)},
+	}
+}
+
+func TestCreate(t *testing.T) {
+	s := runtime.NewScheme()
+	_ = v1beta1.SchemeBuilder.AddToScheme(s)
+
+	type want struct {
+		correlationID string
+		recorded      *v1alpha3.AzureOperation
+		deleted       []string
+		err           error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		history int
+		create  error
+		listed  []v1alpha3.AzureOperation
+		want    want
+	}{
+		"Disabled": {
+			reason: "Operations should not be recorded if the trail keeps no history",
+		},
+		"Recorded": {
+			reason:  "Operations should be sent with a correlation ID and recorded",
+			history: 2,
+			want: want{
+				correlationID: "cool-id",
+				recorded: &v1alpha3.AzureOperation{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "create-cool-id",
+						Labels: map[string]string{LabelKeyResourceUID: "cool-uid", LabelKeyResourceKind: v1beta1.SubnetKind},
+					},
+					Spec: v1alpha3.AzureOperationSpec{
+						Operation:         OperationCreate,
+						ResourceReference: xpv1.TypedReference{APIVersion: v1beta1.SchemeGroupVersion.String(), Kind: v1beta1.SubnetKind, Name: "cool-subnet", UID: "cool-uid"},
+						ExternalName:      "cool-external",
+						CorrelationID:     "cool-id",
+						Timestamp:         metav1.NewTime(now),
+					},
+				},
+			},
+		},
+		"Failed": {
+			reason:  "Failed operations should be recorded with their error",
+			history: 2,
+			create:  errBoom,
+			want: want{
+				correlationID: "cool-id",
+				recorded: &v1alpha3.AzureOperation{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "create-cool-id",
+						Labels: map[string]string{LabelKeyResourceUID: "cool-uid", LabelKeyResourceKind: v1beta1.SubnetKind},
+					},
+					Spec: v1alpha3.AzureOperationSpec{
+						Operation:         OperationCreate,
+						ResourceReference: xpv1.TypedReference{APIVersion: v1beta1.SchemeGroupVersion.String(), Kind: v1beta1.SubnetKind, Name: "cool-subnet", UID: "cool-uid"},
+						ExternalName:      "cool-external",
+						CorrelationID:     "cool-id",
+						Timestamp:         metav1.NewTime(now),
+						Error:             errBoom.Error(),
+					},
+				},
+				err: errBoom,
+			},
+		},
+		"Expired": {
+			reason:  "The oldest operations beyond the history of the trail should be deleted",
+			history: 2,
+			listed: []v1alpha3.AzureOperation{
+				operation("update-b", now.Add(-1*time.Minute)),
+				operation("create-a", now.Add(-2*time.Minute)),
+				operation("create-cool-id", now),
+			},
+			want: want{
+				correlationID: "cool-id",
+				deleted:       []string{"create-a"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var gotID string
+			var recorded *v1alpha3.AzureOperation
+			var deleted []string
+			kube := &test.MockClient{
+				MockCreate: func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+					recorded = obj.(*v1alpha3.AzureOperation)
+					return nil
+				},
+				MockList: func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+					obj.(*v1alpha3.AzureOperationList).Items = tc.listed
+					return nil
+				},
+				MockDelete: func(_ context.Context, obj client.Object, _ ...client.DeleteOption) error {
+					deleted = append(deleted, obj.GetName())
+					return nil
+				},
+			}
+			e := &external{
+				ExternalClient: &managed.ExternalClientFns{
+					CreateFn: func(ctx context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+						gotID = azure.CorrelationID(ctx)
+						return managed.ExternalCreation{}, tc.create
+					},
+				},
+				kube:   kube,
+				scheme: s,
+				trail:  &Trail{History: tc.history, Log: logging.NewNopLogger()},
+				now:    func() time.Time { return now },
+				newID:  func() string { return "cool-id" },
+			}
+			_, err := e.Create(context.Background(), subnet())
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.correlationID, gotID); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want correlation ID, +got correlation ID:\n%s", tc.reason, diff)
+			}
+			if tc.want.recorded != nil {
+				if diff := cmp.Diff(tc.want.recorded, recorded); diff != "" {
+					t.Errorf("\n%s\nCreate(...): -want recorded, +got recorded:\n%s", tc.reason, diff)
+				}
+			}
+			if tc.history == 0 && recorded != nil {
+				t.Errorf("\n%s\nCreate(...): recorded an operation although the trail keeps no history", tc.reason)
+			}
+			if diff := cmp.Diff(tc.want.deleted, deleted); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want deleted, +got deleted:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestExpired(t *testing.T) {
+	a := operation("a", now.Add(-3*time.Minute))
+	b := operation("b", now.Add(-2*time.Minute))
+	c := operation("c", now.Add(-1*time.Minute))
+
+	cases := map[string]struct {
+		reason  string
+		ops     []v1alpha3.AzureOperation
+		history int
+		want    []v1alpha3.AzureOperation
+	}{
+		"WithinHistory": {
+			reason:  "No operations should expire while there are no more than the history",
+			ops:     []v1alpha3.AzureOperation{a, b},
+			history: 2,
+		},
+		"BeyondHistory": {
+			reason:  "The oldest operations beyond the history should expire, regardless of their order",
+			ops:     []v1alpha3.AzureOperation{c, a, b},
+			history: 1,
+			want:    []v1alpha3.AzureOperation{a, b},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := Expired(tc.ops, tc.history)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nExpired(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package chain wraps the external connecters and reconcilers of managed
+// resource controllers with the behaviour every controller of this provider
+// shares, so that each controller's Setup need not repeat it.
+package chain
+
+import (
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-azure/pkg/approval"
+	"github.com/crossplane/provider-azure/pkg/audit"
+	"github.com/crossplane/provider-azure/pkg/clients/redact"
+	"github.com/crossplane/provider-azure/pkg/inuse"
+	"github.com/crossplane/provider-azure/pkg/lock"
+	"github.com/crossplane/provider-azure/pkg/management"
+	"github.com/crossplane/provider-azure/pkg/metrics"
+	"github.com/crossplane/provider-azure/pkg/operation"
+	"github.com/crossplane/provider-azure/pkg/pause"
+	"github.com/crossplane/provider-azure/pkg/protection"
+	"github.com/crossplane/provider-azure/pkg/replacement"
+	"github.com/crossplane/provider-azure/pkg/resync"
+	"github.com/crossplane/provider-azure/pkg/telemetry"
+)
+
+type connecterOptions struct {
+	users       inuse.UsersFn
+	replacement bool
+}
+
+// A ConnecterOption configures the connecter returned by NewConnecter.
+type ConnecterOption func(*connecterOptions)
+
+// WithUsers refuses to delete the Azure resources of managed resources that
+// are used by the managed resources the supplied function returns.
+func WithUsers(fn inuse.UsersFn) ConnecterOption {
+	return func(o *connecterOptions) {
+		o.users = fn
+	}
+}
+
+// WithReplacement deletes the Azure resource a managed resource is replacing
+// once its replacement is available.
+func WithReplacement() ConnecterOption {
+	return func(o *connecterOptions) {
+		o.replacement = true
+	}
+}
+
+// NewConnecter returns an ExternalConnecter that wraps the supplied connecter
+// of managed resources of the supplied kind. From the outside in, it traces,
+// records metrics of and records events about external operations, redacts
+// secrets from their errors, honours management policies, locks and deletion
+// protection, holds destructive operations for approval and audits them.
+func NewConnecter(mgr ctrl.Manager, kind string, c managed.ExternalConnecter, o ...ConnecterOption) managed.ExternalConnecter {
+	opts := &connecterOptions{}
+	for _, fn := range o {
+		fn(opts)
+	}
+
+	c = audit.NewConnecter(c, mgr.GetClient(), mgr.GetScheme(), audit.DefaultTrail)
+	if opts.replacement {
+		c = replacement.NewConnecter(c)
+	}
+	c = approval.NewConnecter(c, approval.NewProviderConfigGate(mgr.GetClient(), mgr.GetScheme()))
+	if opts.users != nil {
+		c = inuse.NewConnecter(c, mgr.GetClient(), opts.users)
+	}
+	c = protection.NewConnecter(c)
+	c = lock.NewConnecter(c, lock.NewAPIListerFn(mgr.GetClient()))
+	c = management.NewConnecter(c)
+	c = redact.NewConnecter(c)
+	c = operation.NewConnecter(c, event.NewAPIRecorder(mgr.GetEventRecorderFor(managed.ControllerName(kind))), kind)
+	c = metrics.NewConnecter(c, metrics.DefaultExternalMetrics, kind)
+	return telemetry.NewConnecter(c, kind)
+}
+
+// NewReconciler returns a Reconciler that wraps the supplied reconciler of
+// managed resources of the supplied kind. It traces reconciles, resyncs
+// managed resources periodically and skips those that are paused or that do
+// not match the supplied label selector.
+func NewReconciler(mgr ctrl.Manager, of resource.ManagedKind, r reconcile.Reconciler, sel labels.Selector) reconcile.Reconciler {
+	kind := schema.GroupVersionKind(of).GroupKind().String()
+	return telemetry.NewReconciler(kind, resync.NewReconciler(resync.DefaultMonitor, managed.ControllerName(kind),
+		pause.NewReconciler(mgr, of, r, pause.WithSelector(sel)),
+		resync.WithSyncPeriod(mgr, of)))
+}
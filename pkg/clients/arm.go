@@ -0,0 +1,89 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+)
+
+// An ARMClient reads and writes Azure resources by sending requests to the
+// Azure Resource Manager API itself, rather than through a client of the
+// Azure SDK. The SDK this provider uses predates some of the resources it
+// manages, e.g. image templates and Kusto scripts, and some of the properties
+// of others. Their clients embed an ARMClient that sends requests to an API
+// version that supports them.
+type ARMClient struct {
+	autorest.Client
+	BaseURI        string
+	SubscriptionID string
+
+	// APIVersion of the resource provider requests are sent to.
+	APIVersion string
+
+	// name of the client in the errors it returns, e.g.
+	// compute.ImageTemplatesClient.
+	name string
+}
+
+// NewARMClientWithBaseURI returns an ARMClient with the supplied name that
+// sends requests for the supplied subscription to the supplied version of the
+// Azure Resource Manager API at the supplied base URI.
+func NewARMClientWithBaseURI(name, baseURI, subscriptionID, apiVersion string) ARMClient {
+	return ARMClient{
+		Client:         autorest.NewClientWithUserAgent(UserAgent),
+		BaseURI:        baseURI,
+		SubscriptionID: subscriptionID,
+		APIVersion:     apiVersion,
+		name:           name,
+	}
+}
+
+// Do sends a request with the supplied method, path and body, if any, and
+// unmarshals the response into out, if any. Like the SDK clients it returns
+// an autorest.DetailedError if Azure does not respond with one of the
+// supplied status codes.
+func (c ARMClient) Do(ctx context.Context, op string, method, path autorest.PrepareDecorator, in, out interface{}, codes ...int) error {
+	decorators := []autorest.PrepareDecorator{
+		method,
+		autorest.WithBaseURL(c.BaseURI),
+		path,
+		autorest.WithQueryParameters(map[string]interface{}{"api-version": c.APIVersion}),
+	}
+	if in != nil {
+		decorators = append(decorators, autorest.AsContentType("application/json; charset=utf-8"), autorest.WithJSON(in))
+	}
+	req, err := autorest.Prepare((&http.Request{}).WithContext(ctx), decorators...)
+	if err != nil {
+		return autorest.NewErrorWithError(err, c.name, op, nil, "Failure preparing request")
+	}
+	resp, err := c.Send(req, azure.DoRetryWithRegistration(c.Client))
+	if err != nil {
+		return autorest.NewErrorWithError(err, c.name, op, resp, "Failure sending request")
+	}
+	responders := []autorest.RespondDecorator{c.ByInspecting(), azure.WithErrorUnlessStatusCode(codes...)}
+	if out != nil {
+		responders = append(responders, autorest.ByUnmarshallingJSON(out))
+	}
+	if err := autorest.Respond(resp, append(responders, autorest.ByClosing())...); err != nil {
+		return autorest.NewErrorWithError(err, c.name, op, resp, "Failure responding to request")
+	}
+	return nil
+}
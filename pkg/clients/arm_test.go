@@ -0,0 +1,90 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestARMClientDo(t *testing.T) {
+	type thing struct {
+		Name string `json:"name"`
+	}
+	path := "/subscriptions/{subscriptionId}/things/{name}"
+
+	cases := map[string]struct {
+		reason   string
+		status   int
+		codes    []int
+		want     thing
+		notFound bool
+	}{
+		"Success": {
+			reason: "The response should be unmarshalled if Azure responds with one of the supplied status codes.",
+			status: http.StatusOK,
+			codes:  []int{http.StatusOK},
+			want:   thing{Name: "cool"},
+		},
+		"UnexpectedStatus": {
+			reason:   "An autorest.DetailedError should be returned if Azure responds with another status code.",
+			status:   http.StatusNotFound,
+			codes:    []int{http.StatusOK},
+			notFound: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got, want := r.URL.Path, "/subscriptions/sub/things/cool"; got != want {
+					t.Errorf("path: want %q, got %q", want, got)
+				}
+				if got, want := r.URL.Query().Get("api-version"), "2021-01-01"; got != want {
+					t.Errorf("api-version: want %q, got %q", want, got)
+				}
+				in := thing{}
+				_ = json.NewDecoder(r.Body).Decode(&in)
+				w.WriteHeader(tc.status)
+				_ = json.NewEncoder(w).Encode(in)
+			}))
+			defer srv.Close()
+
+			c := NewARMClientWithBaseURI("test.ThingsClient", srv.URL, "sub", "2021-01-01")
+			p := autorest.WithPathParameters(path, map[string]interface{}{
+				"subscriptionId": autorest.Encode("path", c.SubscriptionID),
+				"name":           autorest.Encode("path", "cool"),
+			})
+			got := thing{}
+			err := c.Do(context.Background(), "Put", autorest.AsPut(), p, thing{Name: "cool"}, &got, tc.codes...)
+			if diff := cmp.Diff(tc.notFound, IsNotFound(err)); diff != "" {
+				t.Errorf("\n%s\nDo(...): -want not found, +got not found:\n%s", tc.reason, diff)
+			}
+			if !tc.notFound && err != nil {
+				t.Errorf("\n%s\nDo(...): %s", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nDo(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+// DefaultAuthorizerIdleTimeout is the time after which an authorizer that was
+// not used is evicted from the DefaultAuthorizerCache, e.g. once the
+// credentials it was built from were rotated.
+const DefaultAuthorizerIdleTimeout = 1 * time.Hour
+
+// DefaultAuthorizerCache is the process-wide cache of authorizers. It lets all
+// managed resources whose credentials are the same share an authorizer, and
+// thus its access token, which it refreshes only shortly before it expires.
+var DefaultAuthorizerCache = NewAuthorizerCache(DefaultAuthorizerIdleTimeout)
+
+// Fingerprint returns a key of an authorizer that identifies the supplied
+// parts of its credentials, without revealing them.
+func Fingerprint(parts ...string) string {
+	h := sha256.New()
+	for _, p := range parts {
+		_, _ = h.Write([]byte(p))
+		// Separate the parts, so that e.g. ("ab", "c") and ("a", "bc") are
+		// different credentials.
+		_, _ = h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+type cachedAuthorizer struct {
+	authorizer autorest.Authorizer
+	used       time.Time
+}
+
+// An AuthorizerCache shares authorizers by the fingerprint of their
+// credentials. Every Connect of a managed resource gets an authorizer, and an
+// authorizer that is not shared acquires an access token for its first
+// request. Sharing authorizers means an access token is acquired once per set
+// of credentials rather than once per reconcile.
+type AuthorizerCache struct {
+	idle time.Duration
+	now  func() time.Time
+
+	mu          sync.Mutex
+	authorizers map[string]*cachedAuthorizer
+}
+
+// NewAuthorizerCache returns an AuthorizerCache that evicts authorizers that
+// were not used for the supplied duration.
+func NewAuthorizerCache(idle time.Duration) *AuthorizerCache {
+	return &AuthorizerCache{idle: idle, now: time.Now, authorizers: map[string]*cachedAuthorizer{}}
+}
+
+// Get the authorizer with the supplied fingerprint. It is built with the
+// supplied function if there is none. Authorizers that could not be built are
+// not cached.
+func (c *AuthorizerCache) Get(fingerprint string, build func() (autorest.Authorizer, error)) (autorest.Authorizer, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for k, ca := range c.authorizers {
+		if now.Sub(ca.used) > c.idle {
+			delete(c.authorizers, k)
+		}
+	}
+
+	if ca, ok := c.authorizers[fingerprint]; ok {
+		ca.used = now
+		return ca.authorizer, nil
+	}
+	a, err := build()
+	if err != nil {
+		return nil, err
+	}
+	c.authorizers[fingerprint] = &cachedAuthorizer{authorizer: a, used: now}
+	return a, nil
+}
+
+// Len returns the number of cached authorizers.
+func (c *AuthorizerCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.authorizers)
+}
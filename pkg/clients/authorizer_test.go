@@ -0,0 +1,82 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestFingerprint(t *testing.T) {
+	if Fingerprint("ab", "c") == Fingerprint("a", "bc") {
+		t.Errorf("Fingerprint(...): want different fingerprints of different parts")
+	}
+	if Fingerprint("a", "b") != Fingerprint("a", "b") {
+		t.Errorf("Fingerprint(...): want equal fingerprints of equal parts")
+	}
+}
+
+func TestAuthorizerCache(t *testing.T) {
+	errBoom := errors.New("boom")
+	now := time.Now()
+
+	c := NewAuthorizerCache(time.Hour)
+	c.now = func() time.Time { return now }
+
+	builds := 0
+	build := func() (autorest.Authorizer, error) {
+		builds++
+		return autorest.NewBearerAuthorizer(nil), nil
+	}
+
+	a, _ := c.Get("cool", build)
+	b, _ := c.Get("cool", build)
+	if a != b || builds != 1 {
+		t.Errorf("Get(...): want one shared authorizer of the same credentials, got %d builds", builds)
+	}
+
+	if _, err := c.Get("other", build); err != nil || builds != 2 {
+		t.Errorf("Get(...): want a new authorizer of other credentials, got %d builds", builds)
+	}
+
+	_, err := c.Get("broken", func() (autorest.Authorizer, error) { return nil, errBoom })
+	if diff := cmp.Diff(errBoom, err, test.EquateErrors()); diff != "" {
+		t.Errorf("Get(...): -want error, +got error:\n%s", diff)
+	}
+	if diff := cmp.Diff(2, c.Len()); diff != "" {
+		t.Errorf("Len(): authorizers that cannot be built must not be cached: -want, +got:\n%s", diff)
+	}
+
+	// Using cool keeps it cached, while other is evicted once it was idle for
+	// longer than the idle timeout.
+	now = now.Add(45 * time.Minute)
+	_, _ = c.Get("cool", build)
+	now = now.Add(30 * time.Minute)
+	_, _ = c.Get("cool", build)
+	if diff := cmp.Diff(1, c.Len()); diff != "" {
+		t.Errorf("Len(): idle authorizers must be evicted: -want, +got:\n%s", diff)
+	}
+	if builds != 2 {
+		t.Errorf("Get(...): want used authorizers to stay cached, got %d builds", builds)
+	}
+}
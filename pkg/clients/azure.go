@@ -20,9 +20,11 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2018-05-01/resources"
 	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/adal"
 	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/Azure/go-autorest/autorest/azure/auth"
 	"github.com/Azure/go-autorest/autorest/to"
@@ -35,6 +37,7 @@ import (
 
 	"github.com/crossplane/provider-azure/apis/v1alpha3"
 	"github.com/crossplane/provider-azure/apis/v1beta1"
+	"github.com/crossplane/provider-azure/pkg/clients/proxy"
 )
 
 const (
@@ -43,7 +46,13 @@ const (
 	// AsyncOperationStatusInProgress is the status value for AsyncOperation type
 	// that indicates the operation is still ongoing.
 	AsyncOperationStatusInProgress = "InProgress"
-	asyncOperationPollingMethod    = "AsyncOperation"
+	// AsyncOperationStatusFailed is the status value for AsyncOperation type
+	// that indicates the operation failed.
+	AsyncOperationStatusFailed = "Failed"
+	// AsyncOperationStatusCanceled is the status value for AsyncOperation
+	// type that indicates the operation was canceled.
+	AsyncOperationStatusCanceled = "Canceled"
+	asyncOperationPollingMethod  = "AsyncOperation"
 )
 
 // Error strings.
@@ -54,6 +63,10 @@ const (
 	errNeitherPCNorPGiven        = "neither providerConfigRef nor providerRef was supplied"
 	errUnmarshalCredentialSecret = "cannot unmarshal the data in credentials secret"
 	errGetAuthorizer             = "cannot get authorizer from client credentials config"
+	errGetMSIAuthorizer          = "cannot get authorizer from managed identity config"
+	errGetMultiTenantAuthorizer  = "cannot get multi-tenant authorizer from client credentials config"
+	errNoCredentialsSecretRef    = "none of credentialsSecretRef, useManagedIdentity, useWorkloadIdentity and useAzureCLI was supplied"
+	errGetProxy                  = "cannot get proxy config"
 )
 
 // A FieldOption determines how common Go types are translated to the types
@@ -85,15 +98,21 @@ const (
 
 // GetAuthInfo figures out how to connect to Azure API and returns the necessary
 // information to be used for controllers to construct their specific clients.
+// Requests authorized by the returned authorizer are sent with the correlation
+// ID of their context, if any.
 func GetAuthInfo(ctx context.Context, c client.Client, mg resource.Managed) (content map[string]string, authorizer autorest.Authorizer, err error) {
 	switch {
 	case mg.GetProviderConfigReference() != nil:
-		return UseProviderConfig(ctx, c, mg)
+		content, authorizer, err = UseProviderConfig(ctx, c, mg)
 	case mg.GetProviderReference() != nil:
-		return UseProvider(ctx, c, mg)
+		content, authorizer, err = UseProvider(ctx, c, mg)
 	default:
 		return nil, nil, errors.New(errNeitherPCNorPGiven)
 	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return content, NewCorrelatingAuthorizer(authorizer), nil
 }
 
 // UseProvider to return the necessary information to construct an Azure client.
@@ -103,22 +122,42 @@ func UseProvider(ctx context.Context, c client.Client, mg resource.Managed) (con
 	if err := c.Get(ctx, types.NamespacedName{Name: mg.GetProviderReference().Name}, p); err != nil {
 		return nil, nil, errors.Wrap(err, errGetProvider)
 	}
+	content, authorizer, err = providerAuthInfo(ctx, c, p)
+	if err != nil || p.Spec.Proxy == nil {
+		return content, authorizer, err
+	}
+	return withProxy(content, authorizer, p.Spec.Proxy.URL, p.Spec.Proxy.NoProxy)
+}
+
+func providerAuthInfo(ctx context.Context, c client.Client, p *v1alpha3.Provider) (content map[string]string, authorizer autorest.Authorizer, err error) {
+	var e *Endpoint
+	if o := p.Spec.Endpoint; o != nil {
+		ne := NewEndpoint(o.ResourceManagerURL, o.Audience)
+		e = &ne
+	}
+	if mi := p.Spec.UseManagedIdentity; mi != nil {
+		return UseManagedIdentity(mi.SubscriptionID, mi.ClientID, e)
+	}
+	if wi := p.Spec.UseWorkloadIdentity; wi != nil {
+		return UseWorkloadIdentity(wi.SubscriptionID, wi.TenantID, wi.ClientID, wi.TokenFile, e)
+	}
+	if c := p.Spec.UseAzureCLI; c != nil {
+		return UseAzureCLI(c.SubscriptionID, e)
+	}
 
 	ref := p.Spec.CredentialsSecretRef
+	if ref.Name == "" {
+		return nil, nil, errors.New(errNoCredentialsSecretRef)
+	}
 	s := &corev1.Secret{}
 	if err := c.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}, s); err != nil {
 		return nil, nil, err
 	}
-	m := map[string]string{}
-	if err := json.Unmarshal(s.Data[ref.Key], &m); err != nil {
-		return nil, nil, errors.Wrap(err, errUnmarshalCredentialSecret)
+	data, err := SecretCredentials(s, ref.Key)
+	if err != nil {
+		return nil, nil, err
 	}
-	cfg := auth.NewClientCredentialsConfig(m[CredentialsKeyClientID], m[CredentialsKeyClientSecret], m[CredentialsKeyTenantID])
-	cfg.AADEndpoint = m[CredentialsKeyActiveDirectoryEndpointURL]
-	cfg.Resource = m[CredentialsKeyResourceManagerEndpointURL]
-
-	a, err := cfg.Authorizer()
-	return m, a, errors.Wrap(err, errGetAuthorizer)
+	return UseClientCredentials(data, e, p.Spec.TenantID, p.Spec.AuxiliaryTenantIDs)
 }
 
 // UseProviderConfig to return the necessary information to construct an Azure
@@ -132,23 +171,137 @@ func UseProviderConfig(ctx context.Context, c client.Client, mg resource.Managed
 	if err := c.Get(ctx, types.NamespacedName{Name: mg.GetProviderConfigReference().Name}, pc); err != nil {
 		return nil, nil, errors.Wrap(err, errGetProviderConfig)
 	}
+	return GetProviderConfigAuthInfo(ctx, c, pc)
+}
+
+// GetProviderConfigAuthInfo returns the necessary information to construct an
+// Azure client using the credentials of the supplied ProviderConfig.
+func GetProviderConfigAuthInfo(ctx context.Context, c client.Client, pc *v1beta1.ProviderConfig) (content map[string]string, authorizer autorest.Authorizer, err error) {
+	content, authorizer, err = providerConfigAuthInfo(ctx, c, pc)
+	if err != nil || pc.Spec.Proxy == nil {
+		return content, authorizer, err
+	}
+	return withProxy(content, authorizer, pc.Spec.Proxy.URL, pc.Spec.Proxy.NoProxy)
+}
 
-	data, err := resource.CommonCredentialExtractor(ctx, pc.Spec.Credentials.Source, c, pc.Spec.Credentials.CommonCredentialSelectors)
+func providerConfigAuthInfo(ctx context.Context, c client.Client, pc *v1beta1.ProviderConfig) (content map[string]string, authorizer autorest.Authorizer, err error) {
+	var e *Endpoint
+	if o := pc.Spec.Endpoint; o != nil {
+		ne := NewEndpoint(o.ResourceManagerURL, o.Audience)
+		e = &ne
+	}
+	m, err := DefaultMethodResolver.Resolve(ctx, c, pc)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch m {
+	case v1beta1.CredentialsMethodManagedIdentity:
+		mi := pc.Spec.UseManagedIdentity
+		return UseManagedIdentity(mi.SubscriptionID, mi.ClientID, e)
+	case v1beta1.CredentialsMethodWorkloadIdentity:
+		wi := pc.Spec.UseWorkloadIdentity
+		return UseWorkloadIdentity(wi.SubscriptionID, wi.TenantID, wi.ClientID, wi.TokenFile, e)
+	case v1beta1.CredentialsMethodAzureCLI:
+		return UseAzureCLI(pc.Spec.UseAzureCLI.SubscriptionID, e)
+	}
+	data, err := ExtractCredentials(ctx, pc.Spec.Credentials.Source, c, pc.Spec.Credentials.CommonCredentialSelectors)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "cannot get credentials")
 	}
-	m := map[string]string{}
-	if err := json.Unmarshal(data, &m); err != nil {
-		return nil, nil, errors.Wrap(err, errUnmarshalCredentialSecret)
+	return UseClientCredentials(data, e, pc.Spec.TenantID, pc.Spec.AuxiliaryTenantIDs)
+}
+
+// withProxy returns an authorizer that sends the requests the supplied
+// authorizer authorizes, including those that acquire its access tokens,
+// through the supplied proxy.
+func withProxy(content map[string]string, a autorest.Authorizer, url string, noProxy []string) (map[string]string, autorest.Authorizer, error) {
+	cfg, err := proxy.NewConfig(url, noProxy)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, errGetProxy)
+	}
+	return content, proxy.NewAuthorizer(a, cfg), nil
+}
+
+// UseClientCredentials returns the necessary information to construct an
+// Azure client that authenticates as the service principal of the supplied
+// JSON credentials. The resource manager endpoint and tenant of the
+// credentials are replaced by the supplied Endpoint and tenant ID, if any.
+// Access tokens of the supplied auxiliary tenants are sent along with every
+// request, as Azure requires for operations that span tenants.
+func UseClientCredentials(data []byte, e *Endpoint, tenantID *string, auxiliaryTenantIDs []string) (content map[string]string, authorizer autorest.Authorizer, err error) {
+	m, err := ParseCredentials(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	if tenantID != nil && *tenantID != "" {
+		m[CredentialsKeyTenantID] = *tenantID
 	}
 	cfg := auth.NewClientCredentialsConfig(m[CredentialsKeyClientID], m[CredentialsKeyClientSecret], m[CredentialsKeyTenantID])
 	cfg.AADEndpoint = m[CredentialsKeyActiveDirectoryEndpointURL]
 	cfg.Resource = m[CredentialsKeyResourceManagerEndpointURL]
+	if e != nil {
+		m[CredentialsKeyResourceManagerEndpointURL] = e.URL
+		cfg.Resource = e.Audience
+	}
+	fp := Fingerprint("client-credentials", cfg.AADEndpoint, cfg.TenantID, cfg.ClientID, cfg.ClientSecret, cfg.Resource, strings.Join(auxiliaryTenantIDs, ","))
+	if len(auxiliaryTenantIDs) > 0 {
+		a, err := DefaultAuthorizerCache.Get(fp, func() (autorest.Authorizer, error) {
+			return multiTenantAuthorizer(cfg, auxiliaryTenantIDs)
+		})
+		return m, a, errors.Wrap(err, errGetMultiTenantAuthorizer)
+	}
 
-	a, err := cfg.Authorizer()
+	a, err := DefaultAuthorizerCache.Get(fp, cfg.Authorizer)
 	return m, a, errors.Wrap(err, errGetAuthorizer)
 }
 
+// multiTenantAuthorizer returns an authorizer that authenticates as the
+// service principal of the supplied config to its tenant and to the supplied
+// auxiliary tenants.
+func multiTenantAuthorizer(cfg auth.ClientCredentialsConfig, auxiliaryTenantIDs []string) (autorest.Authorizer, error) {
+	aad := cfg.AADEndpoint
+	if aad == "" {
+		aad = azure.PublicCloud.ActiveDirectoryEndpoint
+	}
+	oc, err := adal.NewMultiTenantOAuthConfig(aad, cfg.TenantID, auxiliaryTenantIDs, adal.OAuthOptions{})
+	if err != nil {
+		return nil, err
+	}
+	t, err := adal.NewMultiTenantServicePrincipalToken(oc, cfg.ClientID, cfg.ClientSecret, cfg.Resource)
+	if err != nil {
+		return nil, err
+	}
+	return autorest.NewMultiTenantServicePrincipalTokenAuthorizer(t), nil
+}
+
+// UseManagedIdentity returns the necessary information to construct an Azure
+// client that authenticates through the MSI endpoint as the user-assigned
+// managed identity with the supplied client ID, or as the system-assigned
+// managed identity if it is nil. Access tokens are requested for the supplied
+// Endpoint, or for that of the public Azure cloud if it is nil.
+func UseManagedIdentity(subscriptionID string, clientID *string, e *Endpoint) (content map[string]string, authorizer autorest.Authorizer, err error) {
+	if e == nil {
+		e = &PublicEndpoint
+	}
+	cfg := auth.NewMSIConfig()
+	cfg.Resource = e.Audience
+	if clientID != nil {
+		cfg.ClientID = *clientID
+	}
+	a, err := DefaultAuthorizerCache.Get(Fingerprint("managed-identity", cfg.ClientID, cfg.Resource), cfg.Authorizer)
+	return e.content(subscriptionID), a, errors.Wrap(err, errGetMSIAuthorizer)
+}
+
+// SubscriptionID returns the ID of the subscription a managed resource is in.
+// It is the supplied override, if any, and the subscription of the supplied
+// credentials otherwise.
+func SubscriptionID(creds map[string]string, override *string) string {
+	if override != nil && *override != "" {
+		return *override
+	}
+	return creds[CredentialsKeySubscriptionID]
+}
+
 // Client struct that represents the information needed to connect to the Azure services as a client
 type Client struct {
 	autorest.Authorizer
@@ -194,6 +347,7 @@ func NewClient(credentials []byte) (*Client, error) {
 			ClientSecret:                   creds.ClientSecret,
 			TenantID:                       creds.TenantID,
 			ActiveDirectoryEndpointURL:     creds.ActiveDirectoryEndpointURL,
+			ResourceManagerEndpointURL:     creds.ResourceManagerEndpointURL,
 			ActiveDirectoryGraphResourceID: creds.ActiveDirectoryGraphResourceID,
 		},
 	}, nil
@@ -202,7 +356,7 @@ func NewClient(credentials []byte) (*Client, error) {
 // ValidateClient verifies if the given client is valid by testing if it can make an Azure service API call
 // TODO: is there a better way to validate the Azure client?
 func ValidateClient(client *Client) error {
-	groupsClient := resources.NewGroupsClient(client.SubscriptionID)
+	groupsClient := resources.NewGroupsClientWithBaseURI(client.BaseURI(), client.SubscriptionID)
 	groupsClient.Authorizer = client.Authorizer
 	groupsClient.AddToUserAgent(UserAgent)
 
@@ -210,6 +364,21 @@ func ValidateClient(client *Client) error {
 	return err
 }
 
+// ValidateCredentials returns an error if the supplied credentials and
+// authorizer cannot list the resource groups of their subscription. Listing a
+// single resource group is a cheap call that any role in the subscription is
+// authorized to make, so the error is that of Azure AD or of the subscription
+// rather than of a particular resource.
+func ValidateCredentials(ctx context.Context, creds map[string]string, auth autorest.Authorizer) error {
+	c := resources.NewGroupsClientWithBaseURI(BaseURI(creds), creds[CredentialsKeySubscriptionID])
+	c.Authorizer = auth
+	_ = c.AddToUserAgent(UserAgent)
+
+	top := int32(1)
+	_, err := c.List(ctx, "", &top)
+	return err
+}
+
 // FetchAsyncOperation updates the given operation object with the most up-to-date
 // status retrieved from Azure API.
 func FetchAsyncOperation(ctx context.Context, client autorest.Sender, as *v1alpha3.AsyncOperation) error {
@@ -262,6 +431,42 @@ func IsNotFound(err error) bool {
 	return statusCode == http.StatusNotFound
 }
 
+// IsForbidden returns true if the supplied error, or the error it wraps, is
+// the response of Azure to a request the caller is not authorized to send,
+// e.g. because a role assignment or an Azure Policy does not allow it.
+func IsForbidden(err error) bool {
+	de, ok := errors.Cause(err).(autorest.DetailedError)
+	if !ok {
+		return false
+	}
+	sc, ok := de.StatusCode.(int)
+	return ok && sc == http.StatusForbidden
+}
+
+// ErrorCode returns the code and the message of the error Azure responded
+// with, e.g. NetcfgInvalidSubnet, if the supplied error or the error it wraps
+// is the response of Azure to a request or to the poll of a long running
+// operation. The returned code is empty if it is not.
+func ErrorCode(err error) (code, message string) {
+	cause := errors.Cause(err)
+	if de, ok := cause.(autorest.DetailedError); ok {
+		cause = de.Original
+	}
+	var se *azure.ServiceError
+	switch e := cause.(type) {
+	case *azure.RequestError:
+		se = e.ServiceError
+	case azure.RequestError:
+		se = e.ServiceError
+	case *azure.ServiceError:
+		se = e
+	}
+	if se == nil {
+		return "", ""
+	}
+	return se.Code, se.Message
+}
+
 // ToStringPtr converts the supplied string for use with the Azure Go SDK.
 func ToStringPtr(s string, o ...FieldOption) *string {
 	for _, fo := range o {
@@ -395,6 +600,23 @@ func LateInitializeStringMap(in map[string]string, from map[string]*string) map[
 	return to.StringMap(from)
 }
 
+// LateInitializeStringMapKeys late-inits the keys of map[string]string that
+// are missing from it, e.g. the settings a service adds to those that were
+// supplied. Unlike LateInitializeStringMap it also adds keys to maps that are
+// not nil.
+func LateInitializeStringMapKeys(in map[string]string, from map[string]*string) map[string]string {
+	for k, v := range from {
+		if _, ok := in[k]; ok || v == nil {
+			continue
+		}
+		if in == nil {
+			in = map[string]string{}
+		}
+		in[k] = *v
+	}
+	return in
+}
+
 // LateInitializeBoolPtrFromPtr late-inits *bool
 func LateInitializeBoolPtrFromPtr(in, from *bool) *bool {
 	if in != nil {
@@ -26,12 +26,21 @@ import (
 	"testing"
 
 	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/google/go-cmp/cmp"
 	"github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 
 	"github.com/crossplane/provider-azure/apis/v1alpha3"
+	"github.com/crossplane/provider-azure/apis/v1beta1"
 )
 
 const (
@@ -57,6 +66,156 @@ func TestNewClient(t *testing.T) {
 	g.Expect(client.SubscriptionID).To(gomega.Equal("bf1b0e59-93da-42e0-82c6-5a1d94227911"))
 }
 
+func TestUseClientCredentials(t *testing.T) {
+	tenant := "cool-tenant"
+
+	cases := map[string]struct {
+		tenantID   *string
+		aux        []string
+		wantTenant string
+		wantSingle bool
+	}{
+		"CredentialsTenant": {
+			wantTenant: "302de427-dba9-4452-8583-a4268e46de6b",
+			wantSingle: true,
+		},
+		"TenantOverride": {
+			tenantID:   &tenant,
+			wantTenant: tenant,
+			wantSingle: true,
+		},
+		"AuxiliaryTenants": {
+			aux:        []string{"other-tenant"},
+			wantTenant: "302de427-dba9-4452-8583-a4268e46de6b",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m, a, err := UseClientCredentials([]byte(authData), nil, tc.tenantID, tc.aux)
+			if err != nil {
+				t.Fatalf("UseClientCredentials(...): %s", err)
+			}
+			if got := m[CredentialsKeyTenantID]; got != tc.wantTenant {
+				t.Errorf("UseClientCredentials(...): want tenant %q, got %q", tc.wantTenant, got)
+			}
+			// Only a single tenant authorizer is a plain bearer authorizer.
+			if _, got := a.(*autorest.BearerAuthorizer); got != tc.wantSingle {
+				t.Errorf("UseClientCredentials(...): want single tenant authorizer %t, got %t", tc.wantSingle, got)
+			}
+		})
+	}
+}
+
+func TestGetAuthInfo(t *testing.T) {
+	errBoom := errors.New("boom")
+	subscription := "bf1b0e59-93da-42e0-82c6-5a1d94227911"
+	ref := xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Namespace: "crossplane-system", Name: "azure-creds"}, Key: "credentials"}
+	mi := v1alpha3.ManagedIdentity{SubscriptionID: "managed-subscription"}
+
+	withProviderConfig := &fake.Managed{ProviderConfigReferencer: fake.ProviderConfigReferencer{Ref: &xpv1.Reference{Name: "default"}}}
+	withProvider := &fake.Managed{ProviderReferencer: fake.ProviderReferencer{Ref: &xpv1.Reference{Name: "default"}}}
+
+	// get returns the supplied Provider or ProviderConfig spec, and a secret
+	// that contains service principal credentials.
+	get := func(p v1alpha3.ProviderSpec, pc v1beta1.ProviderConfigSpec, secretErr error) test.MockGetFn {
+		return func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			switch o := obj.(type) {
+			case *v1alpha3.Provider:
+				o.Spec = p
+			case *v1beta1.ProviderConfig:
+				o.Spec = pc
+			case *v1beta1.ProviderConfigUsage:
+				return kerrors.NewNotFound(schema.GroupResource{}, "")
+			case *corev1.Secret:
+				o.Data = map[string][]byte{ref.Key: []byte(authData)}
+				return secretErr
+			}
+			return nil
+		}
+	}
+	secretSource := v1beta1.ProviderCredentials{
+		Source:                    xpv1.CredentialsSourceSecret,
+		CommonCredentialSelectors: xpv1.CommonCredentialSelectors{SecretRef: &ref},
+	}
+
+	type want struct {
+		subscription string
+		err          error
+	}
+	cases := map[string]struct {
+		reason string
+		c      client.Client
+		mg     *fake.Managed
+		want   want
+	}{
+		"NoReference": {
+			reason: "A managed resource that references neither a ProviderConfig nor a Provider cannot be authenticated.",
+			c:      &test.MockClient{},
+			mg:     &fake.Managed{},
+			want:   want{err: errors.New(errNeitherPCNorPGiven)},
+		},
+		"ProviderConfigSecret": {
+			reason: "The service principal credentials of a ProviderConfig should be read from its secret.",
+			c: &test.MockClient{
+				MockGet:    get(v1alpha3.ProviderSpec{}, v1beta1.ProviderConfigSpec{Credentials: secretSource}, nil),
+				MockCreate: test.NewMockCreateFn(nil),
+			},
+			mg:   withProviderConfig,
+			want: want{subscription: subscription},
+		},
+		"ProviderConfigSecretError": {
+			reason: "Errors getting the secret of a ProviderConfig should be returned.",
+			c: &test.MockClient{
+				MockGet:    get(v1alpha3.ProviderSpec{}, v1beta1.ProviderConfigSpec{Credentials: secretSource}, errBoom),
+				MockCreate: test.NewMockCreateFn(nil),
+			},
+			mg:   withProviderConfig,
+			want: want{err: errors.Wrap(errors.Wrap(errBoom, errGetCredentialsSecret), "cannot get credentials")},
+		},
+		"ProviderConfigManagedIdentity": {
+			reason: "A ProviderConfig that uses a managed identity should manage its subscription without a secret.",
+			c: &test.MockClient{
+				MockGet:    get(v1alpha3.ProviderSpec{}, v1beta1.ProviderConfigSpec{UseManagedIdentity: &v1beta1.ManagedIdentity{SubscriptionID: mi.SubscriptionID}}, errBoom),
+				MockCreate: test.NewMockCreateFn(nil),
+			},
+			mg:   withProviderConfig,
+			want: want{subscription: mi.SubscriptionID},
+		},
+		"ProviderSecret": {
+			reason: "The service principal credentials of a Provider should be read from its secret.",
+			c:      &test.MockClient{MockGet: get(v1alpha3.ProviderSpec{CredentialsSecretRef: ref}, v1beta1.ProviderConfigSpec{}, nil)},
+			mg:     withProvider,
+			want:   want{subscription: subscription},
+		},
+		"ProviderManagedIdentity": {
+			reason: "A Provider that uses a managed identity should manage its subscription without a secret.",
+			c:      &test.MockClient{MockGet: get(v1alpha3.ProviderSpec{UseManagedIdentity: &mi}, v1beta1.ProviderConfigSpec{}, errBoom)},
+			mg:     withProvider,
+			want:   want{subscription: mi.SubscriptionID},
+		},
+		"ProviderNoCredentials": {
+			reason: "A Provider that references no secret and uses no identity cannot be authenticated.",
+			c:      &test.MockClient{MockGet: get(v1alpha3.ProviderSpec{}, v1beta1.ProviderConfigSpec{}, nil)},
+			mg:     withProvider,
+			want:   want{err: errors.New(errNoCredentialsSecretRef)},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			creds, a, err := GetAuthInfo(context.Background(), tc.c, tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nGetAuthInfo(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.subscription, creds[CredentialsKeySubscriptionID]); diff != "" {
+				t.Errorf("\n%s\nGetAuthInfo(...): -want subscription, +got subscription:\n%s", tc.reason, diff)
+			}
+			if got := a != nil; got != (tc.want.err == nil) {
+				t.Errorf("\n%s\nGetAuthInfo(...): want authorizer %t, got %t", tc.reason, tc.want.err == nil, got)
+			}
+		})
+	}
+}
+
 func TestFetchAsyncOperation(t *testing.T) {
 	inprogressStatus := "inprogress"
 	inProgressResponse := fmt.Sprintf(`{"status": "%s"}`, inprogressStatus)
@@ -163,6 +322,100 @@ func TestIsNotFound(t *testing.T) {
 	}
 }
 
+func TestIsForbidden(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	cases := []struct {
+		err      error
+		expected bool
+	}{
+		{nil, false},
+		{autorest.DetailedError{StatusCode: http.StatusNotFound}, false},
+		{autorest.DetailedError{StatusCode: http.StatusForbidden}, true},
+		{errors.Wrap(autorest.DetailedError{StatusCode: http.StatusForbidden}, "cannot list"), true},
+	}
+
+	for _, tt := range cases {
+		actual := IsForbidden(tt.err)
+		g.Expect(actual).To(gomega.Equal(tt.expected))
+	}
+}
+
+func TestErrorCode(t *testing.T) {
+	se := &azure.ServiceError{Code: "NetcfgInvalidSubnet", Message: "Subnet is not valid."}
+
+	cases := map[string]struct {
+		err         error
+		wantCode    string
+		wantMessage string
+	}{
+		"NoError": {},
+		"NotAzure": {
+			err: errors.New("boom"),
+		},
+		"NoServiceError": {
+			err: autorest.DetailedError{StatusCode: http.StatusInternalServerError},
+		},
+		"RequestError": {
+			err:         autorest.NewErrorWithError(&azure.RequestError{ServiceError: se}, "network.SubnetsClient", "CreateOrUpdate", nil, "Failure sending request"),
+			wantCode:    se.Code,
+			wantMessage: se.Message,
+		},
+		"WrappedRequestError": {
+			err:         errors.Wrap(autorest.DetailedError{Original: azure.RequestError{ServiceError: se}}, "cannot create subnet"),
+			wantCode:    se.Code,
+			wantMessage: se.Message,
+		},
+		"LongRunningOperation": {
+			err:         autorest.NewErrorWithError(se, "network.SubnetsCreateOrUpdateFuture", "Result", nil, "Polling failure"),
+			wantCode:    se.Code,
+			wantMessage: se.Message,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			code, message := ErrorCode(tc.err)
+			if diff := cmp.Diff(tc.wantCode, code); diff != "" {
+				t.Errorf("ErrorCode(...): -want code, +got code:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantMessage, message); diff != "" {
+				t.Errorf("ErrorCode(...): -want message, +got message:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSubscriptionID(t *testing.T) {
+	creds := map[string]string{CredentialsKeySubscriptionID: "cool-sub"}
+	other := "other-sub"
+	empty := ""
+
+	cases := map[string]struct {
+		override *string
+		want     string
+	}{
+		"NoOverride": {
+			want: "cool-sub",
+		},
+		"EmptyOverride": {
+			override: &empty,
+			want:     "cool-sub",
+		},
+		"Override": {
+			override: &other,
+			want:     "other-sub",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := SubscriptionID(creds, tc.override); got != tc.want {
+				t.Errorf("SubscriptionID(...): want %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
 func TestStringHelpers(t *testing.T) {
 	t.Run("ToStringMap", func(t *testing.T) {
 		original := make(map[string]*string)
@@ -226,4 +479,18 @@ func TestStringHelpers(t *testing.T) {
 			t.Errorf("ToStringPtr(...): -want error, +got error:\n%s", diff)
 		}
 	})
+
+	t.Run("LateInitializeStringMapKeys", func(t *testing.T) {
+		from := map[string]*string{"a": ToStringPtr("azure"), "b": ToStringPtr("azure"), "c": nil}
+
+		if diff := cmp.Diff(map[string]string{"a": "azure", "b": "azure"}, LateInitializeStringMapKeys(nil, from)); diff != "" {
+			t.Errorf("LateInitializeStringMapKeys(...): -want, +got:\n%s", diff)
+		}
+		if diff := cmp.Diff(map[string]string{"a": "spec", "b": "azure"}, LateInitializeStringMapKeys(map[string]string{"a": "spec"}, from)); diff != "" {
+			t.Errorf("LateInitializeStringMapKeys(...): -want, +got:\n%s", diff)
+		}
+		if diff := cmp.Diff(map[string]string(nil), LateInitializeStringMapKeys(nil, nil)); diff != "" {
+			t.Errorf("LateInitializeStringMapKeys(...): -want, +got:\n%s", diff)
+		}
+	})
 }
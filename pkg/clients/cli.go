@@ -0,0 +1,76 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure/auth"
+	"github.com/Azure/go-autorest/autorest/azure/cli"
+	"github.com/pkg/errors"
+)
+
+// Error strings.
+const (
+	errLoadCLIProfile        = "cannot load Azure CLI profile"
+	errNoCLISubscription     = "no default subscription is set in the Azure CLI"
+	errGetCLIAuthorizer      = "cannot get authorizer from Azure CLI"
+	errCLIProfilePathUnknown = "cannot determine Azure CLI profile path"
+)
+
+// UseAzureCLI returns the necessary information to construct an Azure client
+// that authenticates with an access token of the user logged in to the Azure
+// CLI. Managed resources are assumed to be in the subscription with the
+// supplied ID or, if it is nil, in the default subscription of the CLI. A
+// new token is requested from the CLI on every call, since it refreshes its
+// own tokens. Access tokens are requested for the supplied Endpoint, or for
+// that of the public Azure cloud if it is nil.
+func UseAzureCLI(subscriptionID *string, e *Endpoint) (content map[string]string, authorizer autorest.Authorizer, err error) {
+	if e == nil {
+		e = &PublicEndpoint
+	}
+	sub := ""
+	if subscriptionID != nil {
+		sub = *subscriptionID
+	}
+	if sub == "" {
+		p, err := cli.ProfilePath()
+		if err != nil {
+			return nil, nil, errors.Wrap(err, errCLIProfilePathUnknown)
+		}
+		prof, err := cli.LoadProfile(p)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, errLoadCLIProfile)
+		}
+		sub = DefaultCLISubscription(prof)
+	}
+	if sub == "" {
+		return nil, nil, errors.New(errNoCLISubscription)
+	}
+	a, err := auth.NewAuthorizerFromCLIWithResource(e.Audience)
+	return e.content(sub), a, errors.Wrap(err, errGetCLIAuthorizer)
+}
+
+// DefaultCLISubscription returns the ID of the default subscription of the
+// supplied Azure CLI profile, or an empty string if it has none.
+func DefaultCLISubscription(p cli.Profile) string {
+	for _, s := range p.Subscriptions {
+		if s.IsDefault {
+			return s.ID
+		}
+	}
+	return ""
+}
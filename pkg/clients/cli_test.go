@@ -0,0 +1,46 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest/azure/cli"
+)
+
+func TestDefaultCLISubscription(t *testing.T) {
+	cases := map[string]struct {
+		p    cli.Profile
+		want string
+	}{
+		"NoSubscriptions": {},
+		"NoDefault": {
+			p: cli.Profile{Subscriptions: []cli.Subscription{{ID: "a"}}},
+		},
+		"Default": {
+			p:    cli.Profile{Subscriptions: []cli.Subscription{{ID: "a"}, {ID: "b", IsDefault: true}}},
+			want: "b",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := DefaultCLISubscription(tc.p); got != tc.want {
+				t.Errorf("DefaultCLISubscription(...): want %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,254 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cognitiveservices contains clients of Azure Cognitive Services,
+// which deploy models to Azure OpenAI accounts.
+package cognitiveservices
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Azure/go-autorest/autorest"
+
+	"github.com/crossplane/provider-azure/apis/cognitiveservices/v1alpha1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+// APIVersion is the version of the Cognitive Services API that deployments
+// are managed with.
+const APIVersion = "2023-05-01"
+
+// ModelFormatOpenAI is the format of the models of Azure OpenAI.
+const ModelFormatOpenAI = "OpenAI"
+
+// Provisioning states of deployments.
+const (
+	ProvisioningStateSucceeded = "Succeeded"
+	ProvisioningStateFailed    = "Failed"
+	ProvisioningStateDeleting  = "Deleting"
+)
+
+// Keys of the connection details of an OpenAIDeployment.
+const (
+	ConnectionKeyAPIKey         = "apiKey"
+	ConnectionKeyDeploymentName = "deploymentName"
+)
+
+const (
+	accountPath    = "/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/Microsoft.CognitiveServices/accounts/{accountName}"
+	deploymentPath = accountPath + "/deployments/{deploymentName}"
+	listKeysPath   = accountPath + "/listKeys"
+)
+
+// A DeploymentModel is the model a deployment serves.
+type DeploymentModel struct {
+	Format  *string `json:"format,omitempty"`
+	Name    *string `json:"name,omitempty"`
+	Version *string `json:"version,omitempty"`
+}
+
+// DeploymentProperties are the properties of a deployment.
+type DeploymentProperties struct {
+	Model                *DeploymentModel `json:"model,omitempty"`
+	ProvisioningState    *string          `json:"provisioningState,omitempty"`
+	VersionUpgradeOption *string          `json:"versionUpgradeOption,omitempty"`
+	RaiPolicyName        *string          `json:"raiPolicyName,omitempty"`
+}
+
+// A SKU determines the kind and capacity of a deployment.
+type SKU struct {
+	Name     *string `json:"name,omitempty"`
+	Capacity *int32  `json:"capacity,omitempty"`
+}
+
+// A Deployment of a model to a Cognitive Services account.
+type Deployment struct {
+	ID         *string               `json:"id,omitempty"`
+	Name       *string               `json:"name,omitempty"`
+	SKU        *SKU                  `json:"sku,omitempty"`
+	Properties *DeploymentProperties `json:"properties,omitempty"`
+}
+
+// AccountProperties are the properties of a Cognitive Services account.
+type AccountProperties struct {
+	Endpoint *string `json:"endpoint,omitempty"`
+}
+
+// An Account is a Cognitive Services account.
+type Account struct {
+	ID         *string            `json:"id,omitempty"`
+	Name       *string            `json:"name,omitempty"`
+	Kind       *string            `json:"kind,omitempty"`
+	Properties *AccountProperties `json:"properties,omitempty"`
+}
+
+// AccountKeys are the API keys of a Cognitive Services account.
+type AccountKeys struct {
+	Key1 *string `json:"key1,omitempty"`
+	Key2 *string `json:"key2,omitempty"`
+}
+
+// A DeploymentsAPI reads and writes the deployments of Cognitive Services
+// accounts, and reads the endpoints and keys of the accounts.
+type DeploymentsAPI interface {
+	Get(ctx context.Context, resourceGroupName, accountName, name string) (Deployment, error)
+	CreateOrUpdate(ctx context.Context, resourceGroupName, accountName, name string, d Deployment) error
+	Delete(ctx context.Context, resourceGroupName, accountName, name string) error
+	GetAccount(ctx context.Context, resourceGroupName, accountName string) (Account, error)
+	ListKeys(ctx context.Context, resourceGroupName, accountName string) (AccountKeys, error)
+}
+
+// A DeploymentsClient reads and writes deployments through the Azure Resource
+// Manager API.
+type DeploymentsClient struct {
+	azure.ARMClient
+}
+
+// NewDeploymentsClientWithBaseURI returns a DeploymentsClient for the
+// supplied subscription of the Azure Resource Manager API at the supplied base
+// URI.
+func NewDeploymentsClientWithBaseURI(baseURI, subscriptionID string) DeploymentsClient {
+	return DeploymentsClient{ARMClient: azure.NewARMClientWithBaseURI("cognitiveservices.DeploymentsClient", baseURI, subscriptionID, APIVersion)}
+}
+
+// Get returns the supplied deployment.
+func (c DeploymentsClient) Get(ctx context.Context, resourceGroupName, accountName, name string) (Deployment, error) {
+	d := Deployment{}
+	err := c.Do(ctx, "Get", autorest.AsGet(), c.deploymentPath(resourceGroupName, accountName, name), nil, &d, http.StatusOK)
+	return d, err
+}
+
+// CreateOrUpdate starts to create or update the supplied deployment. Azure
+// provisions the deployment asynchronously; its provisioning state reports
+// when it is done.
+func (c DeploymentsClient) CreateOrUpdate(ctx context.Context, resourceGroupName, accountName, name string, d Deployment) error {
+	return c.Do(ctx, "CreateOrUpdate", autorest.AsPut(), c.deploymentPath(resourceGroupName, accountName, name), d, nil, http.StatusOK, http.StatusCreated, http.StatusAccepted)
+}
+
+// Delete starts to delete the supplied deployment.
+func (c DeploymentsClient) Delete(ctx context.Context, resourceGroupName, accountName, name string) error {
+	return c.Do(ctx, "Delete", autorest.AsDelete(), c.deploymentPath(resourceGroupName, accountName, name), nil, nil, http.StatusOK, http.StatusAccepted, http.StatusNoContent)
+}
+
+// GetAccount returns the supplied account.
+func (c DeploymentsClient) GetAccount(ctx context.Context, resourceGroupName, accountName string) (Account, error) {
+	a := Account{}
+	err := c.Do(ctx, "GetAccount", autorest.AsGet(), c.accountPath(accountPath, resourceGroupName, accountName), nil, &a, http.StatusOK)
+	return a, err
+}
+
+// ListKeys returns the API keys of the supplied account.
+func (c DeploymentsClient) ListKeys(ctx context.Context, resourceGroupName, accountName string) (AccountKeys, error) {
+	k := AccountKeys{}
+	err := c.Do(ctx, "ListKeys", autorest.AsPost(), c.accountPath(listKeysPath, resourceGroupName, accountName), nil, &k, http.StatusOK)
+	return k, err
+}
+
+func (c DeploymentsClient) accountPath(path, resourceGroupName, accountName string) autorest.PrepareDecorator {
+	return autorest.WithPathParameters(path, map[string]interface{}{
+		"subscriptionId":    autorest.Encode("path", c.SubscriptionID),
+		"resourceGroupName": autorest.Encode("path", resourceGroupName),
+		"accountName":       autorest.Encode("path", accountName),
+	})
+}
+
+func (c DeploymentsClient) deploymentPath(resourceGroupName, accountName, name string) autorest.PrepareDecorator {
+	return autorest.WithPathParameters(deploymentPath, map[string]interface{}{
+		"subscriptionId":    autorest.Encode("path", c.SubscriptionID),
+		"resourceGroupName": autorest.Encode("path", resourceGroupName),
+		"accountName":       autorest.Encode("path", accountName),
+		"deploymentName":    autorest.Encode("path", name),
+	})
+}
+
+// modelFormat returns the supplied model format, or OpenAI if it is nil.
+func modelFormat(f *string) *string {
+	if f == nil {
+		return azure.ToStringPtr(ModelFormatOpenAI)
+	}
+	return f
+}
+
+// NewDeployment returns the deployment Azure creates or updates for the
+// supplied OpenAIDeployment.
+func NewDeployment(p v1alpha1.OpenAIDeploymentParameters) Deployment {
+	capacity := p.SKU.Capacity
+	d := Deployment{
+		SKU: &SKU{Name: azure.ToStringPtr(p.SKU.Name), Capacity: &capacity},
+		Properties: &DeploymentProperties{
+			Model: &DeploymentModel{
+				Format:  modelFormat(p.Model.Format),
+				Name:    azure.ToStringPtr(p.Model.Name),
+				Version: p.Model.Version,
+			},
+			RaiPolicyName: p.RAIPolicyName,
+		},
+	}
+	if p.VersionUpgradeOption != nil {
+		d.Properties.VersionUpgradeOption = azure.ToStringPtr(string(*p.VersionUpgradeOption))
+	}
+	return d
+}
+
+// DeploymentNeedsUpdate returns true if the supplied parameters differ from
+// the supplied deployment.
+func DeploymentNeedsUpdate(p v1alpha1.OpenAIDeploymentParameters, az Deployment) bool {
+	if az.SKU == nil || az.Properties == nil || az.Properties.Model == nil {
+		return true
+	}
+	if p.SKU.Name != azure.ToString(az.SKU.Name) || az.SKU.Capacity == nil || p.SKU.Capacity != *az.SKU.Capacity {
+		return true
+	}
+	m := az.Properties.Model
+	if p.Model.Version != nil && *p.Model.Version != azure.ToString(m.Version) {
+		return true
+	}
+	if p.VersionUpgradeOption != nil && string(*p.VersionUpgradeOption) != azure.ToString(az.Properties.VersionUpgradeOption) {
+		return true
+	}
+	return p.RAIPolicyName != nil && *p.RAIPolicyName != azure.ToString(az.Properties.RaiPolicyName)
+}
+
+// LateInitializeDeployment fills the empty fields of the supplied parameters
+// with the values of the supplied deployment.
+func LateInitializeDeployment(p *v1alpha1.OpenAIDeploymentParameters, az Deployment) {
+	if az.Properties == nil {
+		return
+	}
+	if m := az.Properties.Model; m != nil {
+		p.Model.Format = azure.LateInitializeStringPtrFromPtr(p.Model.Format, m.Format)
+		p.Model.Version = azure.LateInitializeStringPtrFromPtr(p.Model.Version, m.Version)
+	}
+	if p.VersionUpgradeOption == nil && az.Properties.VersionUpgradeOption != nil {
+		o := v1alpha1.VersionUpgradeOption(*az.Properties.VersionUpgradeOption)
+		p.VersionUpgradeOption = &o
+	}
+	p.RAIPolicyName = azure.LateInitializeStringPtrFromPtr(p.RAIPolicyName, az.Properties.RaiPolicyName)
+}
+
+// GenerateDeploymentObservation returns the observation of the supplied
+// deployment of the supplied account.
+func GenerateDeploymentObservation(az Deployment, a Account) v1alpha1.OpenAIDeploymentObservation {
+	o := v1alpha1.OpenAIDeploymentObservation{ID: azure.ToString(az.ID)}
+	if az.Properties != nil {
+		o.ProvisioningState = azure.ToString(az.Properties.ProvisioningState)
+	}
+	if a.Properties != nil {
+		o.Endpoint = azure.ToString(a.Properties.Endpoint)
+	}
+	return o
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cognitiveservices
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/provider-azure/apis/cognitiveservices/v1alpha1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+func deployment(version string, capacity int32) Deployment {
+	return Deployment{
+		SKU: &SKU{Name: azure.ToStringPtr("Standard"), Capacity: &capacity},
+		Properties: &DeploymentProperties{
+			Model: &DeploymentModel{
+				Format:  azure.ToStringPtr(ModelFormatOpenAI),
+				Name:    azure.ToStringPtr("gpt-4o"),
+				Version: azure.ToStringPtr(version),
+			},
+		},
+	}
+}
+
+func TestNewDeployment(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		p      v1alpha1.OpenAIDeploymentParameters
+		want   Deployment
+	}{
+		"DefaultFormat": {
+			reason: "The model of a deployment should default to the OpenAI format",
+			p: v1alpha1.OpenAIDeploymentParameters{
+				Model: v1alpha1.OpenAIModel{Name: "gpt-4o", Version: azure.ToStringPtr("2024-05-13")},
+				SKU:   v1alpha1.OpenAISKU{Name: "Standard", Capacity: 10},
+			},
+			want: deployment("2024-05-13", 10),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := NewDeployment(tc.p)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nNewDeployment(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDeploymentNeedsUpdate(t *testing.T) {
+	p := func(version string, capacity int32) v1alpha1.OpenAIDeploymentParameters {
+		return v1alpha1.OpenAIDeploymentParameters{
+			Model: v1alpha1.OpenAIModel{Name: "gpt-4o", Version: azure.ToStringPtr(version)},
+			SKU:   v1alpha1.OpenAISKU{Name: "Standard", Capacity: capacity},
+		}
+	}
+
+	cases := map[string]struct {
+		reason string
+		p      v1alpha1.OpenAIDeploymentParameters
+		az     Deployment
+		want   bool
+	}{
+		"UpToDate": {
+			reason: "A deployment whose model version and capacity match should not need an update",
+			p:      p("2024-05-13", 10),
+			az:     deployment("2024-05-13", 10),
+			want:   false,
+		},
+		"CapacityChanged": {
+			reason: "A deployment whose capacity differs should need an update",
+			p:      p("2024-05-13", 20),
+			az:     deployment("2024-05-13", 10),
+			want:   true,
+		},
+		"VersionChanged": {
+			reason: "A deployment whose model version differs should need an update",
+			p:      p("2024-08-06", 10),
+			az:     deployment("2024-05-13", 10),
+			want:   true,
+		},
+		"NoProperties": {
+			reason: "A deployment without properties should need an update",
+			p:      p("2024-05-13", 10),
+			az:     Deployment{},
+			want:   true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := DeploymentNeedsUpdate(tc.p, tc.az)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nDeploymentNeedsUpdate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGenerateDeploymentObservation(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		az     Deployment
+		a      Account
+		want   v1alpha1.OpenAIDeploymentObservation
+	}{
+		"NoProperties": {
+			reason: "Only the ID of a deployment without properties should be observed",
+			az:     Deployment{ID: azure.ToStringPtr("id")},
+			want:   v1alpha1.OpenAIDeploymentObservation{ID: "id"},
+		},
+		"Properties": {
+			reason: "The provisioning state of a deployment and the endpoint of its account should be observed",
+			az: Deployment{
+				ID:         azure.ToStringPtr("id"),
+				Properties: &DeploymentProperties{ProvisioningState: azure.ToStringPtr(ProvisioningStateSucceeded)},
+			},
+			a: Account{Properties: &AccountProperties{Endpoint: azure.ToStringPtr("https://cool.openai.azure.com/")}},
+			want: v1alpha1.OpenAIDeploymentObservation{
+				ID:                "id",
+				ProvisioningState: ProvisioningStateSucceeded,
+				Endpoint:          "https://cool.openai.azure.com/",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GenerateDeploymentObservation(tc.az, tc.a)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGenerateDeploymentObservation(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
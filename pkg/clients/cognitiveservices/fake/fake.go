@@ -0,0 +1,60 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	"github.com/crossplane/provider-azure/pkg/clients/cognitiveservices"
+)
+
+var _ cognitiveservices.DeploymentsAPI = &MockDeploymentsClient{}
+
+// MockDeploymentsClient is a fake implementation of
+// cognitiveservices.DeploymentsClient.
+type MockDeploymentsClient struct {
+	MockGet            func(ctx context.Context, resourceGroupName, accountName, name string) (cognitiveservices.Deployment, error)
+	MockCreateOrUpdate func(ctx context.Context, resourceGroupName, accountName, name string, d cognitiveservices.Deployment) error
+	MockDelete         func(ctx context.Context, resourceGroupName, accountName, name string) error
+	MockGetAccount     func(ctx context.Context, resourceGroupName, accountName string) (cognitiveservices.Account, error)
+	MockListKeys       func(ctx context.Context, resourceGroupName, accountName string) (cognitiveservices.AccountKeys, error)
+}
+
+// Get calls the MockDeploymentsClient's MockGet method.
+func (c *MockDeploymentsClient) Get(ctx context.Context, resourceGroupName, accountName, name string) (cognitiveservices.Deployment, error) {
+	return c.MockGet(ctx, resourceGroupName, accountName, name)
+}
+
+// CreateOrUpdate calls the MockDeploymentsClient's MockCreateOrUpdate method.
+func (c *MockDeploymentsClient) CreateOrUpdate(ctx context.Context, resourceGroupName, accountName, name string, d cognitiveservices.Deployment) error {
+	return c.MockCreateOrUpdate(ctx, resourceGroupName, accountName, name, d)
+}
+
+// Delete calls the MockDeploymentsClient's MockDelete method.
+func (c *MockDeploymentsClient) Delete(ctx context.Context, resourceGroupName, accountName, name string) error {
+	return c.MockDelete(ctx, resourceGroupName, accountName, name)
+}
+
+// GetAccount calls the MockDeploymentsClient's MockGetAccount method.
+func (c *MockDeploymentsClient) GetAccount(ctx context.Context, resourceGroupName, accountName string) (cognitiveservices.Account, error) {
+	return c.MockGetAccount(ctx, resourceGroupName, accountName)
+}
+
+// ListKeys calls the MockDeploymentsClient's MockListKeys method.
+func (c *MockDeploymentsClient) ListKeys(ctx context.Context, resourceGroupName, accountName string) (cognitiveservices.AccountKeys, error) {
+	return c.MockListKeys(ctx, resourceGroupName, accountName)
+}
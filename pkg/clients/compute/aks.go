@@ -23,7 +23,7 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/services/authorization/mgmt/2015-07-01/authorization"
 	authorizationmgmt "github.com/Azure/azure-sdk-for-go/services/authorization/mgmt/2015-07-01/authorization"
-	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2018-03-31/containerservice"
+	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2019-02-01/containerservice"
 	"github.com/Azure/azure-sdk-for-go/services/graphrbac/1.6/graphrbac"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/adal"
@@ -36,6 +36,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/crossplane/provider-azure/apis/compute/v1alpha3"
+	"github.com/crossplane/provider-azure/apis/v1beta1"
 	azure "github.com/crossplane/provider-azure/pkg/clients"
 )
 
@@ -49,6 +50,8 @@ const (
 	NetworkContributorRoleID = "/providers/Microsoft.Authorization/roleDefinitions/4d97b98b-1d4f-4787-a291-c67834d212e7"
 
 	appCredsValidYears = 5
+
+	errRollBack = "cannot clean up after failed creation: %s"
 )
 
 // An AKSClient can create, read, and delete AKS clusters and the various other
@@ -58,6 +61,7 @@ type AKSClient interface {
 	EnsureManagedCluster(ctx context.Context, ac *v1alpha3.AKSCluster, secret string) error
 	DeleteManagedCluster(ctx context.Context, ac *v1alpha3.AKSCluster) error
 	GetKubeConfig(ctx context.Context, ac *v1alpha3.AKSCluster) ([]byte, error)
+	UpdateManagedClusterTags(ctx context.Context, ac *v1alpha3.AKSCluster) error
 }
 
 // An AggregateClient aggregates the various clients used by the AKS controller.
@@ -70,11 +74,11 @@ type AggregateClient struct {
 
 // NewAggregateClient produces the various clients used by the AKS controller.
 func NewAggregateClient(creds map[string]string, auth autorest.Authorizer) (AKSClient, error) {
-	mcc := containerservice.NewManagedClustersClient(creds[azure.CredentialsKeySubscriptionID])
+	mcc := containerservice.NewManagedClustersClientWithBaseURI(azure.BaseURI(creds), creds[azure.CredentialsKeySubscriptionID])
 	mcc.Authorizer = auth
 	_ = mcc.AddToUserAgent(azure.UserAgent)
 
-	rac := authorization.NewRoleAssignmentsClient(creds[azure.CredentialsKeySubscriptionID])
+	rac := authorization.NewRoleAssignmentsClientWithBaseURI(azure.BaseURI(creds), creds[azure.CredentialsKeySubscriptionID])
 	rac.Authorizer = auth
 	_ = rac.AddToUserAgent(azure.UserAgent)
 
@@ -127,28 +131,37 @@ func (c AggregateClient) EnsureManagedCluster(ctx context.Context, ac *v1alpha3.
 
 	sp, err := c.ensureServicePrincipal(ctx, to.String(app.AppID))
 	if err != nil {
-		return err
+		return c.rollBack(ctx, ac, err)
 	}
 
 	if err := c.ensureRoleAssignment(ctx, to.String(sp.ObjectID), NetworkContributorRoleID, ac.Spec.VnetSubnetID); err != nil {
-		return err
+		return c.rollBack(ctx, ac, err)
 	}
 
 	mc := newManagedCluster(ac, to.String(app.AppID), secret)
 	_, err = c.ManagedClusters.CreateOrUpdate(ctx, ac.Spec.ResourceGroupName, meta.GetExternalName(ac), mc)
-	return err
+	return c.rollBack(ctx, ac, err)
 }
 
 // DeleteManagedCluster deletes the supplied AKS cluster, including its service
-// principals and any role assignments.
+// principals and any role assignments. Azure deletes the node resource group
+// of the cluster, and thus its VMs, NICs and disks, with it.
 func (c AggregateClient) DeleteManagedCluster(ctx context.Context, ac *v1alpha3.AKSCluster) error {
-	if err := c.deleteApplication(ctx, meta.GetExternalName(ac)); err != nil {
+	if err := c.deleteDependents(ctx, ac); err != nil {
 		return err
 	}
 	_, err := c.ManagedClusters.Delete(ctx, ac.Spec.ResourceGroupName, meta.GetExternalName(ac))
 	return err
 }
 
+// UpdateManagedClusterTags replaces the tags of the supplied AKS cluster with
+// those of its spec.
+func (c AggregateClient) UpdateManagedClusterTags(ctx context.Context, ac *v1alpha3.AKSCluster) error {
+	t := containerservice.TagsObject{Tags: azure.ToStringPtrMap(ac.Spec.Tags)}
+	_, err := c.ManagedClusters.UpdateTags(ctx, ac.Spec.ResourceGroupName, meta.GetExternalName(ac), t)
+	return err
+}
+
 // GetKubeConfig produces a kubeconfig file that configures access to the
 // supplied AKS cluster.
 func (c AggregateClient) GetKubeConfig(ctx context.Context, ac *v1alpha3.AKSCluster) ([]byte, error) {
@@ -249,21 +262,100 @@ func (c AggregateClient) ensureRoleAssignment(ctx context.Context, principalID,
 	return err
 }
 
-func (c AggregateClient) deleteApplication(ctx context.Context, name string) error {
-	filter := fmt.Sprintf("displayName eq '%s'", name)
+// rollBack cleans up the dependents created for the supplied AKS cluster if
+// Azure rejected a step of its creation, so that they don't linger if the
+// AKSCluster is deleted before the cluster is ever created. Any other error
+// may mean the step succeeded, e.g. that the cluster is being created using
+// the application, so we leave the dependents for the next reconcile to
+// adopt. The supplied error is returned in either case.
+func (c AggregateClient) rollBack(ctx context.Context, ac *v1alpha3.AKSCluster, err error) error {
+	if !azure.IsRejected(err) {
+		return err
+	}
+	if derr := c.deleteDependents(ctx, ac); derr != nil {
+		return errors.Wrapf(err, errRollBack, derr)
+	}
+	return err
+}
+
+// deleteDependents deletes the role assignment of the service principal of
+// the supplied AKS cluster, and its application, which also deletes the
+// service principal.
+func (c AggregateClient) deleteDependents(ctx context.Context, ac *v1alpha3.AKSCluster) error {
+	filter := fmt.Sprintf("displayName eq '%s'", meta.GetExternalName(ac))
 	for l, err := c.Applications.ListComplete(ctx, filter); l.NotDone(); err = l.NextWithContext(ctx) {
 		if err != nil {
 			return err
 		}
 
 		// We really do want to delete the first matching application we find.
-		_, err := c.Applications.Delete(ctx, to.String(l.Value().ObjectID))
+		app := l.Value()
+		if err := c.deleteRoleAssignments(ctx, to.String(app.AppID), ac.Spec.VnetSubnetID); err != nil {
+			return err
+		}
+		_, err := c.Applications.Delete(ctx, to.String(app.ObjectID))
 		return resource.Ignore(azure.IsNotFound, err) // nolint:staticcheck
 	}
 
 	return nil
 }
 
+// deleteRoleAssignments deletes the role assignments of the service principal
+// of the supplied application at the supplied scope.
+func (c AggregateClient) deleteRoleAssignments(ctx context.Context, appID, scope string) error {
+	if scope == "" {
+		return nil
+	}
+
+	r, err := c.Applications.GetServicePrincipalsIDByAppID(ctx, appID)
+	if azure.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	filter := fmt.Sprintf("principalId eq '%s'", to.String(r.Value))
+	for l, err := c.RoleAssignments.ListForScopeComplete(ctx, scope, filter); l.NotDone(); err = l.NextWithContext(ctx) {
+		if err != nil {
+			return err
+		}
+		if _, err := c.RoleAssignments.DeleteByID(ctx, to.String(l.Value().ID)); resource.Ignore(azure.IsNotFound, err) != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// LateInitialize fills the empty fields of the supplied AKSCluster parameters
+// with their values in the supplied Azure managed cluster, e.g. the DNS name
+// prefix Azure chose or the size of its agent pool.
+func LateInitialize(p *v1alpha3.AKSClusterParameters, c containerservice.ManagedCluster, tp v1beta1.TagPolicy) {
+	if p.Location == "" {
+		p.Location = to.String(c.Location)
+	}
+	p.Tags = azure.LateInitializeTags(p.Tags, c.Tags, tp)
+	if c.ManagedClusterProperties == nil {
+		return
+	}
+	if p.DNSNamePrefix == "" {
+		p.DNSNamePrefix = to.String(c.DNSPrefix)
+	}
+	if c.AgentPoolProfiles == nil {
+		return
+	}
+	for _, ap := range *c.AgentPoolProfiles {
+		if to.String(ap.Name) != AgentPoolProfileName {
+			continue
+		}
+		if p.NodeVMSize == "" {
+			p.NodeVMSize = string(ap.VMSize)
+		}
+		p.NodeCount = azure.LateInitializeIntPtrFromInt32Ptr(p.NodeCount, ap.Count)
+	}
+}
+
 func newManagedCluster(c *v1alpha3.AKSCluster, appID, secret string) containerservice.ManagedCluster {
 	nodeCount := int32(v1alpha3.DefaultNodeCount)
 	if c.Spec.NodeCount != nil {
@@ -273,6 +365,7 @@ func newManagedCluster(c *v1alpha3.AKSCluster, appID, secret string) containerse
 	p := containerservice.ManagedCluster{
 		Name:     to.StringPtr(meta.GetExternalName(c)),
 		Location: to.StringPtr(c.Spec.Location),
+		Tags:     azure.ToStringPtrMap(c.Spec.Tags),
 		ManagedClusterProperties: &containerservice.ManagedClusterProperties{
 			KubernetesVersion: to.StringPtr(c.Spec.Version),
 			DNSPrefix:         to.StringPtr(c.Spec.DNSNamePrefix),
@@ -292,7 +385,7 @@ func newManagedCluster(c *v1alpha3.AKSCluster, appID, secret string) containerse
 	}
 
 	if c.Spec.VnetSubnetID != "" {
-		p.ManagedClusterProperties.NetworkProfile = &containerservice.NetworkProfile{NetworkPlugin: containerservice.Azure}
+		p.ManagedClusterProperties.NetworkProfile = &containerservice.NetworkProfileType{NetworkPlugin: containerservice.Azure}
 		p.ManagedClusterProperties.AgentPoolProfiles = &[]containerservice.ManagedClusterAgentPoolProfile{
 			{
 				Name:         to.StringPtr(AgentPoolProfileName),
@@ -303,6 +396,13 @@ func newManagedCluster(c *v1alpha3.AKSCluster, appID, secret string) containerse
 		}
 	}
 
+	// Availability zones are only supported by scale set backed agent pools.
+	if len(c.Spec.AvailabilityZones) > 0 {
+		ap := &(*p.ManagedClusterProperties.AgentPoolProfiles)[0]
+		ap.Type = containerservice.VirtualMachineScaleSets
+		ap.AvailabilityZones = &c.Spec.AvailabilityZones
+	}
+
 	return p
 }
 
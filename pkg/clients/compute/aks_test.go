@@ -0,0 +1,308 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/authorization/mgmt/2015-07-01/authorization"
+	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2019-02-01/containerservice"
+	"github.com/Azure/azure-sdk-for-go/services/graphrbac/1.6/graphrbac"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	"github.com/crossplane/provider-azure/apis/compute/v1alpha3"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+// Operations of the fake Azure API used by TestEnsureManagedCluster.
+const (
+	opListApplications       = "ListApplications"
+	opCreateApplication      = "CreateApplication"
+	opDeleteApplication      = "DeleteApplication"
+	opGetServicePrincipalID  = "GetServicePrincipalID"
+	opCreateServicePrincipal = "CreateServicePrincipal"
+	opListRoleAssignments    = "ListRoleAssignments"
+	opCreateRoleAssignment   = "CreateRoleAssignment"
+	opDeleteRoleAssignment   = "DeleteRoleAssignment"
+	opCreateManagedCluster   = "CreateManagedCluster"
+)
+
+// fakeAzure is a fake of the Graph and Resource Manager APIs an
+// AggregateClient uses. It records the operations it is called with and
+// fails the configured operation.
+type fakeAzure struct {
+	ops []string
+
+	// fail maps operations to the status code they fail with, or to 0 if
+	// they fail without a response.
+	fail map[string]int
+
+	apps        []interface{}
+	sp          string
+	assignments []interface{}
+}
+
+func (f *fakeAzure) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	op := operation(r)
+	f.ops = append(f.ops, op)
+	var body interface{}
+	code, fail := f.fail[op]
+	switch {
+	case fail && code == 0:
+		// Fail without a response.
+		conn, _, _ := w.(http.Hijacker).Hijack()
+		_ = conn.Close()
+		return
+	case fail:
+		body = map[string]interface{}{"error": map[string]string{"code": "Rejected", "message": "boom"}}
+	default:
+		code, body = f.do(op, r.URL.Path)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// operation returns the operation the supplied request calls.
+func operation(r *http.Request) string {
+	p := r.URL.Path
+	switch {
+	case r.Method == http.MethodGet && strings.HasSuffix(p, "/applications"):
+		return opListApplications
+	case r.Method == http.MethodPost && strings.HasSuffix(p, "/applications"):
+		return opCreateApplication
+	case r.Method == http.MethodDelete && strings.Contains(p, "/applications/"):
+		return opDeleteApplication
+	case r.Method == http.MethodGet && strings.Contains(p, "/servicePrincipalsByAppId/"):
+		return opGetServicePrincipalID
+	case r.Method == http.MethodPost && strings.HasSuffix(p, "/servicePrincipals"):
+		return opCreateServicePrincipal
+	case r.Method == http.MethodGet && strings.HasSuffix(p, "/roleAssignments"):
+		return opListRoleAssignments
+	case r.Method == http.MethodPut && strings.Contains(p, "/roleAssignments/"):
+		return opCreateRoleAssignment
+	case r.Method == http.MethodDelete && strings.Contains(p, "/roleAssignments/"):
+		return opDeleteRoleAssignment
+	case r.Method == http.MethodPut && strings.Contains(p, "/managedClusters/"):
+		return opCreateManagedCluster
+	}
+	return r.Method + " " + p
+}
+
+// do the supplied operation, returning the status code and body of its
+// response.
+func (f *fakeAzure) do(op, path string) (int, interface{}) {
+	app := map[string]string{"objectId": "app-object", "appId": "app-id"}
+	switch op {
+	case opListApplications:
+		return http.StatusOK, map[string]interface{}{"value": f.apps}
+	case opCreateApplication:
+		f.apps = append(f.apps, app)
+		return http.StatusCreated, app
+	case opDeleteApplication:
+		return http.StatusNoContent, nil
+	case opGetServicePrincipalID:
+		if f.sp == "" {
+			return http.StatusNotFound, graphError("Request_ResourceNotFound", "no service principal for app-id")
+		}
+		return http.StatusOK, map[string]string{"value": f.sp}
+	case opCreateServicePrincipal:
+		f.sp = "sp-object"
+		return http.StatusCreated, map[string]string{"objectId": f.sp}
+	case opListRoleAssignments:
+		return http.StatusOK, map[string]interface{}{"value": f.assignments}
+	case opCreateRoleAssignment:
+		ra := map[string]string{"id": path}
+		f.assignments = append(f.assignments, ra)
+		return http.StatusCreated, ra
+	case opDeleteRoleAssignment:
+		return http.StatusOK, map[string]string{"id": path}
+	case opCreateManagedCluster:
+		return http.StatusCreated, map[string]interface{}{"properties": map[string]string{"provisioningState": "Creating"}}
+	}
+	return http.StatusNotFound, graphError("Request_ResourceNotFound", "no such resource")
+}
+
+// graphError returns the body of an error response of the Graph API.
+func graphError(code, message string) interface{} {
+	return map[string]interface{}{
+		"odata.error": map[string]interface{}{
+			"code":    code,
+			"message": map[string]string{"lang": "en", "value": message},
+		},
+	}
+}
+
+func TestEnsureManagedCluster(t *testing.T) {
+	created := []string{
+		opListApplications,
+		opCreateApplication,
+		opGetServicePrincipalID,
+		opCreateServicePrincipal,
+		opListRoleAssignments,
+		opCreateRoleAssignment,
+		opCreateManagedCluster,
+	}
+
+	cases := map[string]struct {
+		reason   string
+		fail     map[string]int
+		wantErr  bool
+		wantOps  []string
+		rejected bool
+	}{
+		"Success": {
+			reason:  "Nothing should be cleaned up if the cluster is created.",
+			wantOps: created,
+		},
+		"ClusterRejected": {
+			reason:   "The role assignment and the application should be deleted if Azure rejects the cluster.",
+			fail:     map[string]int{opCreateManagedCluster: http.StatusBadRequest},
+			wantErr:  true,
+			rejected: true,
+			wantOps: append(append([]string{}, created...),
+				opListApplications,
+				opGetServicePrincipalID,
+				opListRoleAssignments,
+				opDeleteRoleAssignment,
+				opDeleteApplication,
+			),
+		},
+		"RoleAssignmentRejected": {
+			reason:   "The application should be deleted if Azure rejects the role assignment.",
+			fail:     map[string]int{opCreateRoleAssignment: http.StatusForbidden},
+			wantErr:  true,
+			rejected: true,
+			wantOps: append(append([]string{}, created[:6]...),
+				opListApplications,
+				opGetServicePrincipalID,
+				opListRoleAssignments,
+				opDeleteApplication,
+			),
+		},
+		"ClusterNoResponse": {
+			reason:  "Nothing should be cleaned up if the cluster may be being created, since Azure did not respond.",
+			fail:    map[string]int{opCreateManagedCluster: 0},
+			wantErr: true,
+			// Requests that get no response are retried three times.
+			wantOps: append(append([]string{}, created...), opCreateManagedCluster, opCreateManagedCluster, opCreateManagedCluster),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			f := &fakeAzure{fail: tc.fail}
+			srv := httptest.NewServer(f)
+			defer srv.Close()
+
+			c := AggregateClient{
+				ManagedClusters:   containerservice.NewManagedClustersClientWithBaseURI(srv.URL, "sub"),
+				Applications:      graphrbac.NewApplicationsClientWithBaseURI(srv.URL, "tenant"),
+				ServicePrincipals: graphrbac.NewServicePrincipalsClientWithBaseURI(srv.URL, "tenant"),
+				RoleAssignments:   authorization.NewRoleAssignmentsClientWithBaseURI(srv.URL, "sub"),
+			}
+			// Don't wait between the retries of requests that get no response.
+			c.ManagedClusters.RetryDuration = time.Millisecond
+			c.Applications.RetryDuration = time.Millisecond
+			c.ServicePrincipals.RetryDuration = time.Millisecond
+			c.RoleAssignments.RetryDuration = time.Millisecond
+
+			ac := &v1alpha3.AKSCluster{ObjectMeta: metav1.ObjectMeta{Name: "cool"}}
+			meta.SetExternalName(ac, "cool")
+			ac.Spec.ResourceGroupName = "rg"
+			ac.Spec.VnetSubnetID = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet/subnets/subnet"
+
+			err := c.EnsureManagedCluster(context.Background(), ac, "secret")
+			if diff := cmp.Diff(tc.wantErr, err != nil); diff != "" {
+				t.Errorf("\n%s\nEnsureManagedCluster(...): -want error, +got error:\n%s\n%v", tc.reason, diff, err)
+			}
+			if diff := cmp.Diff(tc.rejected, azure.IsRejected(err)); diff != "" {
+				t.Errorf("\n%s\nEnsureManagedCluster(...): -want rejected, +got rejected:\n%s\n%v", tc.reason, diff, err)
+			}
+			if diff := cmp.Diff(tc.wantOps, f.ops); diff != "" {
+				t.Errorf("\n%s\nEnsureManagedCluster(...): -want operations, +got operations:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestNewManagedCluster(t *testing.T) {
+	nodes := int32(v1alpha3.DefaultNodeCount)
+	subnet := "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet/subnets/subnet"
+
+	cases := map[string]struct {
+		reason string
+		p      v1alpha3.AKSClusterParameters
+		want   []containerservice.ManagedClusterAgentPoolProfile
+		net    *containerservice.NetworkProfileType
+	}{
+		"Default": {
+			reason: "The agent pool should be backed by an availability set if no zones are requested.",
+			p:      v1alpha3.AKSClusterParameters{NodeVMSize: "Standard_B2s"},
+			want: []containerservice.ManagedClusterAgentPoolProfile{{
+				Name:   to.StringPtr(AgentPoolProfileName),
+				Count:  &nodes,
+				VMSize: containerservice.VMSizeTypes("Standard_B2s"),
+			}},
+		},
+		"Subnet": {
+			reason: "The agent pool should use the Azure network plugin in the requested subnet.",
+			p:      v1alpha3.AKSClusterParameters{NodeVMSize: "Standard_B2s", VnetSubnetID: subnet},
+			want: []containerservice.ManagedClusterAgentPoolProfile{{
+				Name:         to.StringPtr(AgentPoolProfileName),
+				Count:        &nodes,
+				VMSize:       containerservice.VMSizeTypes("Standard_B2s"),
+				VnetSubnetID: to.StringPtr(subnet),
+			}},
+			net: &containerservice.NetworkProfileType{NetworkPlugin: containerservice.Azure},
+		},
+		"Zones": {
+			reason: "The agent pool should be backed by a scale set spread across the requested zones.",
+			p:      v1alpha3.AKSClusterParameters{NodeVMSize: "Standard_B2s", AvailabilityZones: []string{"1", "2"}},
+			want: []containerservice.ManagedClusterAgentPoolProfile{{
+				Name:              to.StringPtr(AgentPoolProfileName),
+				Count:             &nodes,
+				VMSize:            containerservice.VMSizeTypes("Standard_B2s"),
+				Type:              containerservice.VirtualMachineScaleSets,
+				AvailabilityZones: &[]string{"1", "2"},
+			}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ac := &v1alpha3.AKSCluster{Spec: v1alpha3.AKSClusterSpec{AKSClusterParameters: tc.p}}
+			got := newManagedCluster(ac, "app-id", "secret")
+			if diff := cmp.Diff(tc.want, *got.AgentPoolProfiles); diff != "" {
+				t.Errorf("\n%s\nnewManagedCluster(...): -want agent pools, +got agent pools:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.net, got.NetworkProfile); diff != "" {
+				t.Errorf("\n%s\nnewManagedCluster(...): -want network profile, +got network profile:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,273 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/Azure/go-autorest/autorest/to"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/provider-azure/apis/compute/v1alpha3"
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+// CapacityReservationAPIVersion is the version of the compute API that
+// capacity reservations are managed with.
+const CapacityReservationAPIVersion = "2021-04-01"
+
+const (
+	capacityReservationGroupPath = "/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/Microsoft.Compute/capacityReservationGroups/{capacityReservationGroupName}"
+	capacityReservationPath      = capacityReservationGroupPath + "/capacityReservations/{capacityReservationName}"
+)
+
+// A SubResource references another Azure resource by its ID.
+type SubResource struct {
+	ID *string `json:"id,omitempty"`
+}
+
+// A CapacityReservationSku is the VM size and quantity of a capacity
+// reservation.
+type CapacityReservationSku struct {
+	Name     *string `json:"name,omitempty"`
+	Capacity *int64  `json:"capacity,omitempty"`
+}
+
+// CapacityReservationGroupProperties are the properties of a capacity
+// reservation group.
+type CapacityReservationGroupProperties struct {
+	CapacityReservations      *[]SubResource `json:"capacityReservations,omitempty"`
+	VirtualMachinesAssociated *[]SubResource `json:"virtualMachinesAssociated,omitempty"`
+}
+
+// A CapacityReservationGroup is an Azure capacity reservation group.
+type CapacityReservationGroup struct {
+	ID         *string                             `json:"id,omitempty"`
+	Name       *string                             `json:"name,omitempty"`
+	Location   *string                             `json:"location,omitempty"`
+	Zones      *[]string                           `json:"zones,omitempty"`
+	Tags       map[string]*string                  `json:"tags,omitempty"`
+	Properties *CapacityReservationGroupProperties `json:"properties,omitempty"`
+}
+
+// CapacityReservationProperties are the properties of a capacity
+// reservation.
+type CapacityReservationProperties struct {
+	ReservationID             *string        `json:"reservationId,omitempty"`
+	ProvisioningState         *string        `json:"provisioningState,omitempty"`
+	ProvisioningTime          *date.Time     `json:"provisioningTime,omitempty"`
+	VirtualMachinesAssociated *[]SubResource `json:"virtualMachinesAssociated,omitempty"`
+}
+
+// A CapacityReservation is an Azure capacity reservation.
+type CapacityReservation struct {
+	ID         *string                        `json:"id,omitempty"`
+	Name       *string                        `json:"name,omitempty"`
+	Location   *string                        `json:"location,omitempty"`
+	Sku        *CapacityReservationSku        `json:"sku,omitempty"`
+	Zones      *[]string                      `json:"zones,omitempty"`
+	Tags       map[string]*string             `json:"tags,omitempty"`
+	Properties *CapacityReservationProperties `json:"properties,omitempty"`
+}
+
+// A CapacityReservationsAPI reads and writes capacity reservation groups and
+// their capacity reservations.
+type CapacityReservationsAPI interface {
+	GetGroup(ctx context.Context, resourceGroupName, groupName string) (CapacityReservationGroup, error)
+	CreateOrUpdateGroup(ctx context.Context, resourceGroupName, groupName string, g CapacityReservationGroup) error
+	DeleteGroup(ctx context.Context, resourceGroupName, groupName string) error
+	Get(ctx context.Context, resourceGroupName, groupName, name string) (CapacityReservation, error)
+	CreateOrUpdate(ctx context.Context, resourceGroupName, groupName, name string, r CapacityReservation) error
+	Delete(ctx context.Context, resourceGroupName, groupName, name string) error
+}
+
+// A CapacityReservationsClient reads and writes capacity reservation groups
+// and capacity reservations through the Azure Resource Manager API.
+type CapacityReservationsClient struct {
+	azure.ARMClient
+}
+
+// NewCapacityReservationsClientWithBaseURI returns a
+// CapacityReservationsClient for the supplied subscription of the Azure
+// Resource Manager API at the supplied base URI.
+func NewCapacityReservationsClientWithBaseURI(baseURI, subscriptionID string) CapacityReservationsClient {
+	return CapacityReservationsClient{ARMClient: azure.NewARMClientWithBaseURI("compute.CapacityReservationsClient", baseURI, subscriptionID, CapacityReservationAPIVersion)}
+}
+
+// GetGroup returns the supplied capacity reservation group.
+func (c CapacityReservationsClient) GetGroup(ctx context.Context, resourceGroupName, groupName string) (CapacityReservationGroup, error) {
+	g := CapacityReservationGroup{}
+	err := c.Do(ctx, "GetGroup", autorest.AsGet(), c.groupPath(resourceGroupName, groupName), nil, &g, http.StatusOK)
+	return g, err
+}
+
+// CreateOrUpdateGroup creates or updates the supplied capacity reservation
+// group.
+func (c CapacityReservationsClient) CreateOrUpdateGroup(ctx context.Context, resourceGroupName, groupName string, g CapacityReservationGroup) error {
+	return c.Do(ctx, "CreateOrUpdateGroup", autorest.AsPut(), c.groupPath(resourceGroupName, groupName), g, nil, http.StatusOK, http.StatusCreated)
+}
+
+// DeleteGroup deletes the supplied capacity reservation group. Azure only
+// deletes groups without capacity reservations.
+func (c CapacityReservationsClient) DeleteGroup(ctx context.Context, resourceGroupName, groupName string) error {
+	return c.Do(ctx, "DeleteGroup", autorest.AsDelete(), c.groupPath(resourceGroupName, groupName), nil, nil, http.StatusOK, http.StatusNoContent)
+}
+
+// Get returns the supplied capacity reservation.
+func (c CapacityReservationsClient) Get(ctx context.Context, resourceGroupName, groupName, name string) (CapacityReservation, error) {
+	r := CapacityReservation{}
+	err := c.Do(ctx, "Get", autorest.AsGet(), c.path(resourceGroupName, groupName, name), nil, &r, http.StatusOK)
+	return r, err
+}
+
+// CreateOrUpdate starts to create or update the supplied capacity
+// reservation. Azure provisions the reservation asynchronously; its
+// provisioning state reports when it is done.
+func (c CapacityReservationsClient) CreateOrUpdate(ctx context.Context, resourceGroupName, groupName, name string, r CapacityReservation) error {
+	return c.Do(ctx, "CreateOrUpdate", autorest.AsPut(), c.path(resourceGroupName, groupName, name), r, nil, http.StatusOK, http.StatusCreated)
+}
+
+// Delete starts to delete the supplied capacity reservation.
+func (c CapacityReservationsClient) Delete(ctx context.Context, resourceGroupName, groupName, name string) error {
+	return c.Do(ctx, "Delete", autorest.AsDelete(), c.path(resourceGroupName, groupName, name), nil, nil, http.StatusOK, http.StatusAccepted, http.StatusNoContent)
+}
+
+func (c CapacityReservationsClient) groupPath(resourceGroupName, groupName string) autorest.PrepareDecorator {
+	return autorest.WithPathParameters(capacityReservationGroupPath, map[string]interface{}{
+		"subscriptionId":               autorest.Encode("path", c.SubscriptionID),
+		"resourceGroupName":            autorest.Encode("path", resourceGroupName),
+		"capacityReservationGroupName": autorest.Encode("path", groupName),
+	})
+}
+
+func (c CapacityReservationsClient) path(resourceGroupName, groupName, name string) autorest.PrepareDecorator {
+	return autorest.WithPathParameters(capacityReservationPath, map[string]interface{}{
+		"subscriptionId":               autorest.Encode("path", c.SubscriptionID),
+		"resourceGroupName":            autorest.Encode("path", resourceGroupName),
+		"capacityReservationGroupName": autorest.Encode("path", groupName),
+		"capacityReservationName":      autorest.Encode("path", name),
+	})
+}
+
+// NewCapacityReservationGroup returns the capacity reservation group Azure
+// creates for the supplied CapacityReservationGroup.
+func NewCapacityReservationGroup(p v1alpha3.CapacityReservationGroupParameters) CapacityReservationGroup {
+	return CapacityReservationGroup{
+		Location: azure.ToStringPtr(p.Location),
+		Zones:    azure.ToStringArrayPtr(p.Zones),
+		Tags:     azure.ToStringPtrMap(p.Tags),
+	}
+}
+
+// CapacityReservationGroupNeedsUpdate returns true if the supplied parameters
+// differ from the supplied capacity reservation group. Only its tags can be
+// updated.
+func CapacityReservationGroupNeedsUpdate(p v1alpha3.CapacityReservationGroupParameters, az CapacityReservationGroup) bool {
+	return azure.TagsNeedUpdate(p.Tags, az.Tags)
+}
+
+// LateInitializeCapacityReservationGroup fills the empty fields of the
+// supplied parameters with the values of the supplied capacity reservation
+// group.
+func LateInitializeCapacityReservationGroup(p *v1alpha3.CapacityReservationGroupParameters, az CapacityReservationGroup, tp v1beta1.TagPolicy) {
+	if p.Location == "" {
+		p.Location = azure.ToString(az.Location)
+	}
+	if len(p.Zones) == 0 && az.Zones != nil {
+		p.Zones = *az.Zones
+	}
+	p.Tags = azure.LateInitializeTags(p.Tags, az.Tags, tp)
+}
+
+// GenerateCapacityReservationGroupObservation returns the observation of the
+// supplied capacity reservation group.
+func GenerateCapacityReservationGroupObservation(az CapacityReservationGroup) v1alpha3.CapacityReservationGroupObservation {
+	o := v1alpha3.CapacityReservationGroupObservation{ID: azure.ToString(az.ID)}
+	if az.Properties == nil {
+		return o
+	}
+	o.CapacityReservations = ids(az.Properties.CapacityReservations)
+	o.VirtualMachinesAssociated = ids(az.Properties.VirtualMachinesAssociated)
+	return o
+}
+
+// NewCapacityReservation returns the capacity reservation Azure creates or
+// updates for the supplied CapacityReservation.
+func NewCapacityReservation(p v1alpha3.CapacityReservationParameters) CapacityReservation {
+	return CapacityReservation{
+		Location: azure.ToStringPtr(p.Location),
+		Sku: &CapacityReservationSku{
+			Name:     azure.ToStringPtr(p.SKU),
+			Capacity: to.Int64Ptr(int64(p.Capacity)),
+		},
+		Zones: azure.ToStringArrayPtr(p.Zones),
+		Tags:  azure.ToStringPtrMap(p.Tags),
+	}
+}
+
+// CapacityReservationNeedsUpdate returns true if the supplied parameters
+// differ from the supplied capacity reservation.
+func CapacityReservationNeedsUpdate(p v1alpha3.CapacityReservationParameters, az CapacityReservation) bool {
+	if azure.TagsNeedUpdate(p.Tags, az.Tags) {
+		return true
+	}
+	return az.Sku == nil || int64(p.Capacity) != to.Int64(az.Sku.Capacity)
+}
+
+// LateInitializeCapacityReservation fills the empty fields of the supplied
+// parameters with the values of the supplied capacity reservation.
+func LateInitializeCapacityReservation(p *v1alpha3.CapacityReservationParameters, az CapacityReservation, tp v1beta1.TagPolicy) {
+	if p.Location == "" {
+		p.Location = azure.ToString(az.Location)
+	}
+	if len(p.Zones) == 0 && az.Zones != nil {
+		p.Zones = *az.Zones
+	}
+	p.Tags = azure.LateInitializeTags(p.Tags, az.Tags, tp)
+}
+
+// GenerateCapacityReservationObservation returns the observation of the
+// supplied capacity reservation.
+func GenerateCapacityReservationObservation(az CapacityReservation) v1alpha3.CapacityReservationObservation {
+	o := v1alpha3.CapacityReservationObservation{ID: azure.ToString(az.ID)}
+	if az.Properties == nil {
+		return o
+	}
+	o.ReservationID = azure.ToString(az.Properties.ReservationID)
+	o.ProvisioningState = azure.ToString(az.Properties.ProvisioningState)
+	if az.Properties.ProvisioningTime != nil {
+		t := metav1.NewTime(az.Properties.ProvisioningTime.Time)
+		o.ProvisioningTime = &t
+	}
+	o.VirtualMachinesAssociated = ids(az.Properties.VirtualMachinesAssociated)
+	return o
+}
+
+func ids(s *[]SubResource) []string {
+	if s == nil {
+		return nil
+	}
+	out := make([]string, 0, len(*s))
+	for _, r := range *s {
+		out = append(out, azure.ToString(r.ID))
+	}
+	return out
+}
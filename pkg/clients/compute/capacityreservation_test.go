@@ -0,0 +1,115 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/provider-azure/apis/compute/v1alpha3"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+func TestCapacityReservationNeedsUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		p      v1alpha3.CapacityReservationParameters
+		az     CapacityReservation
+		want   bool
+	}{
+		"UpToDate": {
+			reason: "A reservation whose capacity and tags match should not need an update",
+			p:      v1alpha3.CapacityReservationParameters{Capacity: 2, Tags: map[string]string{"team": "data"}},
+			az: CapacityReservation{
+				Sku:  &CapacityReservationSku{Capacity: to.Int64Ptr(2)},
+				Tags: map[string]*string{"team": azure.ToStringPtr("data")},
+			},
+			want: false,
+		},
+		"CapacityChanged": {
+			reason: "A reservation whose capacity differs should need an update",
+			p:      v1alpha3.CapacityReservationParameters{Capacity: 4},
+			az:     CapacityReservation{Sku: &CapacityReservationSku{Capacity: to.Int64Ptr(2)}},
+			want:   true,
+		},
+		"TagsChanged": {
+			reason: "A reservation whose tags differ should need an update",
+			p:      v1alpha3.CapacityReservationParameters{Capacity: 2, Tags: map[string]string{"team": "data"}},
+			az:     CapacityReservation{Sku: &CapacityReservationSku{Capacity: to.Int64Ptr(2)}},
+			want:   true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := CapacityReservationNeedsUpdate(tc.p, tc.az)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nCapacityReservationNeedsUpdate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGenerateCapacityReservationObservation(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	mt := metav1.NewTime(now)
+
+	cases := map[string]struct {
+		reason string
+		az     CapacityReservation
+		want   v1alpha3.CapacityReservationObservation
+	}{
+		"NoProperties": {
+			reason: "Only the ID of a reservation without properties should be observed",
+			az:     CapacityReservation{ID: azure.ToStringPtr("id")},
+			want:   v1alpha3.CapacityReservationObservation{ID: "id"},
+		},
+		"Properties": {
+			reason: "The properties of a reservation should be observed",
+			az: CapacityReservation{
+				ID: azure.ToStringPtr("id"),
+				Properties: &CapacityReservationProperties{
+					ReservationID:             azure.ToStringPtr("rid"),
+					ProvisioningState:         azure.ToStringPtr(ProvisioningStateSucceeded),
+					ProvisioningTime:          &date.Time{Time: now},
+					VirtualMachinesAssociated: &[]SubResource{{ID: azure.ToStringPtr("vm")}},
+				},
+			},
+			want: v1alpha3.CapacityReservationObservation{
+				ID:                        "id",
+				ReservationID:             "rid",
+				ProvisioningState:         ProvisioningStateSucceeded,
+				ProvisioningTime:          &mt,
+				VirtualMachinesAssociated: []string{"vm"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GenerateCapacityReservationObservation(tc.az)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGenerateCapacityReservationObservation(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
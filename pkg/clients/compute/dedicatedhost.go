@@ -0,0 +1,162 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-07-01/compute"
+
+	"github.com/crossplane/provider-azure/apis/compute/v1alpha3"
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+// Provisioning states of AKS clusters, dedicated hosts, capacity reservations
+// and image templates.
+const (
+	ProvisioningStateSucceeded = "Succeeded"
+	ProvisioningStateFailed    = "Failed"
+)
+
+// NewDedicatedHostGroup returns the dedicated host group Azure creates for
+// the supplied DedicatedHostGroup.
+func NewDedicatedHostGroup(p v1alpha3.DedicatedHostGroupParameters) compute.DedicatedHostGroup {
+	return compute.DedicatedHostGroup{
+		Location: azure.ToStringPtr(p.Location),
+		Zones:    azure.ToStringArrayPtr(p.Zones),
+		Tags:     azure.ToStringPtrMap(p.Tags),
+		DedicatedHostGroupProperties: &compute.DedicatedHostGroupProperties{
+			PlatformFaultDomainCount: azure.ToInt32Ptr(p.PlatformFaultDomainCount),
+		},
+	}
+}
+
+// NewDedicatedHostGroupUpdate returns the update Azure applies to the
+// dedicated host group of the supplied DedicatedHostGroup. Only its tags can
+// be updated.
+func NewDedicatedHostGroupUpdate(p v1alpha3.DedicatedHostGroupParameters) compute.DedicatedHostGroupUpdate {
+	return compute.DedicatedHostGroupUpdate{Tags: azure.ToStringPtrMap(p.Tags)}
+}
+
+// DedicatedHostGroupNeedsUpdate returns true if the supplied parameters
+// differ from the supplied dedicated host group.
+func DedicatedHostGroupNeedsUpdate(p v1alpha3.DedicatedHostGroupParameters, az compute.DedicatedHostGroup) bool {
+	return azure.TagsNeedUpdate(p.Tags, az.Tags)
+}
+
+// LateInitializeDedicatedHostGroup fills the empty fields of the supplied
+// parameters with the values of the supplied dedicated host group.
+func LateInitializeDedicatedHostGroup(p *v1alpha3.DedicatedHostGroupParameters, az compute.DedicatedHostGroup, tp v1beta1.TagPolicy) {
+	if p.Location == "" {
+		p.Location = azure.ToString(az.Location)
+	}
+	if len(p.Zones) == 0 && az.Zones != nil {
+		p.Zones = *az.Zones
+	}
+	p.Tags = azure.LateInitializeTags(p.Tags, az.Tags, tp)
+}
+
+// GenerateDedicatedHostGroupObservation returns the observation of the
+// supplied dedicated host group.
+func GenerateDedicatedHostGroupObservation(az compute.DedicatedHostGroup) v1alpha3.DedicatedHostGroupObservation {
+	o := v1alpha3.DedicatedHostGroupObservation{ID: azure.ToString(az.ID)}
+	if az.DedicatedHostGroupProperties == nil || az.Hosts == nil {
+		return o
+	}
+	for _, h := range *az.Hosts {
+		o.Hosts = append(o.Hosts, azure.ToString(h.ID))
+	}
+	return o
+}
+
+// NewDedicatedHost returns the dedicated host Azure creates for the supplied
+// DedicatedHost.
+func NewDedicatedHost(p v1alpha3.DedicatedHostParameters) compute.DedicatedHost {
+	return compute.DedicatedHost{
+		Location: azure.ToStringPtr(p.Location),
+		Sku:      &compute.Sku{Name: azure.ToStringPtr(p.SKU)},
+		Tags:     azure.ToStringPtrMap(p.Tags),
+		DedicatedHostProperties: &compute.DedicatedHostProperties{
+			PlatformFaultDomain:  azure.ToInt32PtrFromIntPtr(p.PlatformFaultDomain),
+			AutoReplaceOnFailure: p.AutoReplaceOnFailure,
+			LicenseType:          compute.DedicatedHostLicenseTypes(azure.ToString(p.LicenseType)),
+		},
+	}
+}
+
+// NewDedicatedHostUpdate returns the update Azure applies to the dedicated
+// host of the supplied DedicatedHost.
+func NewDedicatedHostUpdate(p v1alpha3.DedicatedHostParameters) compute.DedicatedHostUpdate {
+	return compute.DedicatedHostUpdate{
+		Tags: azure.ToStringPtrMap(p.Tags),
+		DedicatedHostProperties: &compute.DedicatedHostProperties{
+			AutoReplaceOnFailure: p.AutoReplaceOnFailure,
+			LicenseType:          compute.DedicatedHostLicenseTypes(azure.ToString(p.LicenseType)),
+		},
+	}
+}
+
+// DedicatedHostNeedsUpdate returns true if the supplied parameters differ
+// from the supplied dedicated host.
+func DedicatedHostNeedsUpdate(p v1alpha3.DedicatedHostParameters, az compute.DedicatedHost) bool {
+	if azure.TagsNeedUpdate(p.Tags, az.Tags) {
+		return true
+	}
+	if az.DedicatedHostProperties == nil {
+		return false
+	}
+	switch {
+	case p.AutoReplaceOnFailure != nil && *p.AutoReplaceOnFailure != azure.ToBool(az.AutoReplaceOnFailure):
+		return true
+	case p.LicenseType != nil && *p.LicenseType != string(az.LicenseType):
+		return true
+	}
+	return false
+}
+
+// LateInitializeDedicatedHost fills the empty fields of the supplied
+// parameters with the values of the supplied dedicated host.
+func LateInitializeDedicatedHost(p *v1alpha3.DedicatedHostParameters, az compute.DedicatedHost, tp v1beta1.TagPolicy) {
+	if p.Location == "" {
+		p.Location = azure.ToString(az.Location)
+	}
+	p.Tags = azure.LateInitializeTags(p.Tags, az.Tags, tp)
+	if az.DedicatedHostProperties == nil {
+		return
+	}
+	p.PlatformFaultDomain = azure.LateInitializeIntPtrFromInt32Ptr(p.PlatformFaultDomain, az.PlatformFaultDomain)
+	p.AutoReplaceOnFailure = azure.LateInitializeBoolPtrFromPtr(p.AutoReplaceOnFailure, az.AutoReplaceOnFailure)
+	if p.LicenseType == nil && az.LicenseType != "" {
+		p.LicenseType = azure.ToStringPtr(string(az.LicenseType))
+	}
+}
+
+// GenerateDedicatedHostObservation returns the observation of the supplied
+// dedicated host.
+func GenerateDedicatedHostObservation(az compute.DedicatedHost) v1alpha3.DedicatedHostObservation {
+	o := v1alpha3.DedicatedHostObservation{ID: azure.ToString(az.ID)}
+	if az.DedicatedHostProperties == nil {
+		return o
+	}
+	o.HostID = azure.ToString(az.HostID)
+	o.ProvisioningState = azure.ToString(az.ProvisioningState)
+	if az.VirtualMachines != nil {
+		for _, vm := range *az.VirtualMachines {
+			o.VirtualMachines = append(o.VirtualMachines, azure.ToString(vm.ID))
+		}
+	}
+	return o
+}
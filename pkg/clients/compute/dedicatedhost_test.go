@@ -0,0 +1,126 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-07-01/compute"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/provider-azure/apis/compute/v1alpha3"
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+func TestDedicatedHostNeedsUpdate(t *testing.T) {
+	hybrid := string(compute.DedicatedHostLicenseTypesWindowsServerHybrid)
+	off := false
+
+	cases := map[string]struct {
+		reason string
+		p      v1alpha3.DedicatedHostParameters
+		az     compute.DedicatedHost
+		want   bool
+	}{
+		"UpToDate": {
+			reason: "A host whose properties are not specified should not need an update",
+			p:      v1alpha3.DedicatedHostParameters{SKU: "DSv3-Type1"},
+			az: compute.DedicatedHost{DedicatedHostProperties: &compute.DedicatedHostProperties{
+				AutoReplaceOnFailure: azure.ToBoolPtr(true),
+				LicenseType:          compute.DedicatedHostLicenseTypesNone,
+			}},
+			want: false,
+		},
+		"TagsDiffer": {
+			reason: "A host whose tags differ should need an update",
+			p:      v1alpha3.DedicatedHostParameters{Tags: map[string]string{"team": "data"}},
+			az:     compute.DedicatedHost{},
+			want:   true,
+		},
+		"AutoReplaceDiffers": {
+			reason: "A host that is replaced on failure when it should not be should need an update",
+			p:      v1alpha3.DedicatedHostParameters{AutoReplaceOnFailure: &off},
+			az: compute.DedicatedHost{DedicatedHostProperties: &compute.DedicatedHostProperties{
+				AutoReplaceOnFailure: azure.ToBoolPtr(true),
+			}},
+			want: true,
+		},
+		"LicenseTypeDiffers": {
+			reason: "A host whose license type differs should need an update",
+			p:      v1alpha3.DedicatedHostParameters{LicenseType: &hybrid},
+			az: compute.DedicatedHost{DedicatedHostProperties: &compute.DedicatedHostProperties{
+				LicenseType: compute.DedicatedHostLicenseTypesNone,
+			}},
+			want: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := DedicatedHostNeedsUpdate(tc.p, tc.az)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nDedicatedHostNeedsUpdate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLateInitializeDedicatedHost(t *testing.T) {
+	none := string(compute.DedicatedHostLicenseTypesNone)
+	on := true
+	fd := 1
+
+	cases := map[string]struct {
+		reason string
+		p      v1alpha3.DedicatedHostParameters
+		az     compute.DedicatedHost
+		want   v1alpha3.DedicatedHostParameters
+	}{
+		"NoProperties": {
+			reason: "Only the location should be late initialized from a host without properties",
+			p:      v1alpha3.DedicatedHostParameters{SKU: "DSv3-Type1"},
+			az:     compute.DedicatedHost{Location: azure.ToStringPtr("westus2")},
+			want:   v1alpha3.DedicatedHostParameters{SKU: "DSv3-Type1", Location: "westus2"},
+		},
+		"EmptyFields": {
+			reason: "The fault domain, auto replacement and license type should be late initialized if they are empty",
+			p:      v1alpha3.DedicatedHostParameters{SKU: "DSv3-Type1", Location: "westus2"},
+			az: compute.DedicatedHost{DedicatedHostProperties: &compute.DedicatedHostProperties{
+				PlatformFaultDomain:  azure.ToInt32Ptr(fd),
+				AutoReplaceOnFailure: &on,
+				LicenseType:          compute.DedicatedHostLicenseTypesNone,
+			}},
+			want: v1alpha3.DedicatedHostParameters{
+				SKU:                  "DSv3-Type1",
+				Location:             "westus2",
+				PlatformFaultDomain:  &fd,
+				AutoReplaceOnFailure: &on,
+				LicenseType:          &none,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializeDedicatedHost(&tc.p, tc.az, v1beta1.TagPolicyReplace)
+			if diff := cmp.Diff(tc.want, tc.p); diff != "" {
+				t.Errorf("\n%s\nLateInitializeDedicatedHost(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
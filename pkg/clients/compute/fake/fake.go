@@ -19,17 +19,22 @@ package fake
 import (
 	"context"
 
-	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2018-03-31/containerservice"
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-07-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-07-01/compute/computeapi"
+	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2019-02-01/containerservice"
+	"github.com/Azure/go-autorest/autorest"
 
 	"github.com/crossplane/provider-azure/apis/compute/v1alpha3"
+	azurecompute "github.com/crossplane/provider-azure/pkg/clients/compute"
 )
 
 // AKSClient is a fake AKS client.
 type AKSClient struct {
-	MockGetManagedCluster    func(ctx context.Context, ac *v1alpha3.AKSCluster) (containerservice.ManagedCluster, error)
-	MockEnsureManagedCluster func(ctx context.Context, ac *v1alpha3.AKSCluster, secret string) error
-	MockDeleteManagedCluster func(ctx context.Context, ac *v1alpha3.AKSCluster) error
-	MockGetKubeConfig        func(ctx context.Context, ac *v1alpha3.AKSCluster) ([]byte, error)
+	MockGetManagedCluster        func(ctx context.Context, ac *v1alpha3.AKSCluster) (containerservice.ManagedCluster, error)
+	MockEnsureManagedCluster     func(ctx context.Context, ac *v1alpha3.AKSCluster, secret string) error
+	MockDeleteManagedCluster     func(ctx context.Context, ac *v1alpha3.AKSCluster) error
+	MockGetKubeConfig            func(ctx context.Context, ac *v1alpha3.AKSCluster) ([]byte, error)
+	MockUpdateManagedClusterTags func(ctx context.Context, ac *v1alpha3.AKSCluster) error
 }
 
 // GetManagedCluster calls MockGetManagedCluster.
@@ -51,3 +56,167 @@ func (c AKSClient) DeleteManagedCluster(ctx context.Context, ac *v1alpha3.AKSClu
 func (c AKSClient) GetKubeConfig(ctx context.Context, ac *v1alpha3.AKSCluster) ([]byte, error) {
 	return c.MockGetKubeConfig(ctx, ac)
 }
+
+// UpdateManagedClusterTags calls MockUpdateManagedClusterTags.
+func (c AKSClient) UpdateManagedClusterTags(ctx context.Context, ac *v1alpha3.AKSCluster) error {
+	return c.MockUpdateManagedClusterTags(ctx, ac)
+}
+
+var _ computeapi.DedicatedHostGroupsClientAPI = &MockDedicatedHostGroupsClient{}
+
+// MockDedicatedHostGroupsClient is a fake implementation of
+// compute.DedicatedHostGroupsClient.
+type MockDedicatedHostGroupsClient struct {
+	computeapi.DedicatedHostGroupsClientAPI
+
+	MockCreateOrUpdate func(ctx context.Context, resourceGroupName string, hostGroupName string, parameters compute.DedicatedHostGroup) (result compute.DedicatedHostGroup, err error)
+	MockDelete         func(ctx context.Context, resourceGroupName string, hostGroupName string) (result autorest.Response, err error)
+	MockGet            func(ctx context.Context, resourceGroupName string, hostGroupName string) (result compute.DedicatedHostGroup, err error)
+	MockUpdate         func(ctx context.Context, resourceGroupName string, hostGroupName string, parameters compute.DedicatedHostGroupUpdate) (result compute.DedicatedHostGroup, err error)
+}
+
+// CreateOrUpdate calls the MockDedicatedHostGroupsClient's MockCreateOrUpdate
+// method.
+func (c *MockDedicatedHostGroupsClient) CreateOrUpdate(ctx context.Context, resourceGroupName string, hostGroupName string, parameters compute.DedicatedHostGroup) (result compute.DedicatedHostGroup, err error) {
+	return c.MockCreateOrUpdate(ctx, resourceGroupName, hostGroupName, parameters)
+}
+
+// Delete calls the MockDedicatedHostGroupsClient's MockDelete method.
+func (c *MockDedicatedHostGroupsClient) Delete(ctx context.Context, resourceGroupName string, hostGroupName string) (result autorest.Response, err error) {
+	return c.MockDelete(ctx, resourceGroupName, hostGroupName)
+}
+
+// Get calls the MockDedicatedHostGroupsClient's MockGet method.
+func (c *MockDedicatedHostGroupsClient) Get(ctx context.Context, resourceGroupName string, hostGroupName string) (result compute.DedicatedHostGroup, err error) {
+	return c.MockGet(ctx, resourceGroupName, hostGroupName)
+}
+
+// Update calls the MockDedicatedHostGroupsClient's MockUpdate method.
+func (c *MockDedicatedHostGroupsClient) Update(ctx context.Context, resourceGroupName string, hostGroupName string, parameters compute.DedicatedHostGroupUpdate) (result compute.DedicatedHostGroup, err error) {
+	return c.MockUpdate(ctx, resourceGroupName, hostGroupName, parameters)
+}
+
+var _ computeapi.DedicatedHostsClientAPI = &MockDedicatedHostsClient{}
+
+// MockDedicatedHostsClient is a fake implementation of
+// compute.DedicatedHostsClient.
+type MockDedicatedHostsClient struct {
+	computeapi.DedicatedHostsClientAPI
+
+	MockCreateOrUpdate func(ctx context.Context, resourceGroupName string, hostGroupName string, hostName string, parameters compute.DedicatedHost) (result compute.DedicatedHostsCreateOrUpdateFuture, err error)
+	MockDelete         func(ctx context.Context, resourceGroupName string, hostGroupName string, hostName string) (result compute.DedicatedHostsDeleteFuture, err error)
+	MockGet            func(ctx context.Context, resourceGroupName string, hostGroupName string, hostName string, expand compute.InstanceViewTypes) (result compute.DedicatedHost, err error)
+	MockUpdate         func(ctx context.Context, resourceGroupName string, hostGroupName string, hostName string, parameters compute.DedicatedHostUpdate) (result compute.DedicatedHostsUpdateFuture, err error)
+}
+
+// CreateOrUpdate calls the MockDedicatedHostsClient's MockCreateOrUpdate
+// method.
+func (c *MockDedicatedHostsClient) CreateOrUpdate(ctx context.Context, resourceGroupName string, hostGroupName string, hostName string, parameters compute.DedicatedHost) (result compute.DedicatedHostsCreateOrUpdateFuture, err error) {
+	return c.MockCreateOrUpdate(ctx, resourceGroupName, hostGroupName, hostName, parameters)
+}
+
+// Delete calls the MockDedicatedHostsClient's MockDelete method.
+func (c *MockDedicatedHostsClient) Delete(ctx context.Context, resourceGroupName string, hostGroupName string, hostName string) (result compute.DedicatedHostsDeleteFuture, err error) {
+	return c.MockDelete(ctx, resourceGroupName, hostGroupName, hostName)
+}
+
+// Get calls the MockDedicatedHostsClient's MockGet method.
+func (c *MockDedicatedHostsClient) Get(ctx context.Context, resourceGroupName string, hostGroupName string, hostName string, expand compute.InstanceViewTypes) (result compute.DedicatedHost, err error) {
+	return c.MockGet(ctx, resourceGroupName, hostGroupName, hostName, expand)
+}
+
+// Update calls the MockDedicatedHostsClient's MockUpdate method.
+func (c *MockDedicatedHostsClient) Update(ctx context.Context, resourceGroupName string, hostGroupName string, hostName string, parameters compute.DedicatedHostUpdate) (result compute.DedicatedHostsUpdateFuture, err error) {
+	return c.MockUpdate(ctx, resourceGroupName, hostGroupName, hostName, parameters)
+}
+
+var _ azurecompute.CapacityReservationsAPI = &MockCapacityReservationsClient{}
+
+// MockCapacityReservationsClient is a fake implementation of
+// compute.CapacityReservationsClient.
+type MockCapacityReservationsClient struct {
+	MockGetGroup            func(ctx context.Context, resourceGroupName, groupName string) (azurecompute.CapacityReservationGroup, error)
+	MockCreateOrUpdateGroup func(ctx context.Context, resourceGroupName, groupName string, g azurecompute.CapacityReservationGroup) error
+	MockDeleteGroup         func(ctx context.Context, resourceGroupName, groupName string) error
+	MockGet                 func(ctx context.Context, resourceGroupName, groupName, name string) (azurecompute.CapacityReservation, error)
+	MockCreateOrUpdate      func(ctx context.Context, resourceGroupName, groupName, name string, r azurecompute.CapacityReservation) error
+	MockDelete              func(ctx context.Context, resourceGroupName, groupName, name string) error
+}
+
+// GetGroup calls the MockCapacityReservationsClient's MockGetGroup method.
+func (c *MockCapacityReservationsClient) GetGroup(ctx context.Context, resourceGroupName, groupName string) (azurecompute.CapacityReservationGroup, error) {
+	return c.MockGetGroup(ctx, resourceGroupName, groupName)
+}
+
+// CreateOrUpdateGroup calls the MockCapacityReservationsClient's
+// MockCreateOrUpdateGroup method.
+func (c *MockCapacityReservationsClient) CreateOrUpdateGroup(ctx context.Context, resourceGroupName, groupName string, g azurecompute.CapacityReservationGroup) error {
+	return c.MockCreateOrUpdateGroup(ctx, resourceGroupName, groupName, g)
+}
+
+// DeleteGroup calls the MockCapacityReservationsClient's MockDeleteGroup
+// method.
+func (c *MockCapacityReservationsClient) DeleteGroup(ctx context.Context, resourceGroupName, groupName string) error {
+	return c.MockDeleteGroup(ctx, resourceGroupName, groupName)
+}
+
+// Get calls the MockCapacityReservationsClient's MockGet method.
+func (c *MockCapacityReservationsClient) Get(ctx context.Context, resourceGroupName, groupName, name string) (azurecompute.CapacityReservation, error) {
+	return c.MockGet(ctx, resourceGroupName, groupName, name)
+}
+
+// CreateOrUpdate calls the MockCapacityReservationsClient's
+// MockCreateOrUpdate method.
+func (c *MockCapacityReservationsClient) CreateOrUpdate(ctx context.Context, resourceGroupName, groupName, name string, r azurecompute.CapacityReservation) error {
+	return c.MockCreateOrUpdate(ctx, resourceGroupName, groupName, name, r)
+}
+
+// Delete calls the MockCapacityReservationsClient's MockDelete method.
+func (c *MockCapacityReservationsClient) Delete(ctx context.Context, resourceGroupName, groupName, name string) error {
+	return c.MockDelete(ctx, resourceGroupName, groupName, name)
+}
+
+var _ azurecompute.ImageTemplatesAPI = &MockImageTemplatesClient{}
+
+// MockImageTemplatesClient is a fake implementation of
+// compute.ImageTemplatesClient.
+type MockImageTemplatesClient struct {
+	MockGet            func(ctx context.Context, resourceGroupName, name string) (azurecompute.ImageTemplate, error)
+	MockCreateOrUpdate func(ctx context.Context, resourceGroupName, name string, t azurecompute.ImageTemplate) error
+	MockUpdateTags     func(ctx context.Context, resourceGroupName, name string, tags map[string]*string) error
+	MockDelete         func(ctx context.Context, resourceGroupName, name string) error
+	MockRun            func(ctx context.Context, resourceGroupName, name string) error
+	MockListRunOutputs func(ctx context.Context, resourceGroupName, name string) ([]azurecompute.RunOutput, error)
+}
+
+// Get calls the MockImageTemplatesClient's MockGet method.
+func (c *MockImageTemplatesClient) Get(ctx context.Context, resourceGroupName, name string) (azurecompute.ImageTemplate, error) {
+	return c.MockGet(ctx, resourceGroupName, name)
+}
+
+// CreateOrUpdate calls the MockImageTemplatesClient's MockCreateOrUpdate
+// method.
+func (c *MockImageTemplatesClient) CreateOrUpdate(ctx context.Context, resourceGroupName, name string, t azurecompute.ImageTemplate) error {
+	return c.MockCreateOrUpdate(ctx, resourceGroupName, name, t)
+}
+
+// UpdateTags calls the MockImageTemplatesClient's MockUpdateTags method.
+func (c *MockImageTemplatesClient) UpdateTags(ctx context.Context, resourceGroupName, name string, tags map[string]*string) error {
+	return c.MockUpdateTags(ctx, resourceGroupName, name, tags)
+}
+
+// Delete calls the MockImageTemplatesClient's MockDelete method.
+func (c *MockImageTemplatesClient) Delete(ctx context.Context, resourceGroupName, name string) error {
+	return c.MockDelete(ctx, resourceGroupName, name)
+}
+
+// Run calls the MockImageTemplatesClient's MockRun method.
+func (c *MockImageTemplatesClient) Run(ctx context.Context, resourceGroupName, name string) error {
+	return c.MockRun(ctx, resourceGroupName, name)
+}
+
+// ListRunOutputs calls the MockImageTemplatesClient's MockListRunOutputs
+// method.
+func (c *MockImageTemplatesClient) ListRunOutputs(ctx context.Context, resourceGroupName, name string) ([]azurecompute.RunOutput, error) {
+	return c.MockListRunOutputs(ctx, resourceGroupName, name)
+}
@@ -0,0 +1,358 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/date"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/provider-azure/apis/compute/v1alpha3"
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+// ImageTemplateAPIVersion is the version of the Azure VM Image Builder API
+// that image templates are managed with.
+const ImageTemplateAPIVersion = "2022-07-01"
+
+// AnnotationKeyBuild is the annotation of an ImageTemplate whose value is
+// changed, e.g. to a timestamp or a version, to start a build of its image.
+const AnnotationKeyBuild = "azure.crossplane.io/build"
+
+// RunStateRunning is the run state of an image template that is building
+// its image.
+const RunStateRunning = "Running"
+
+const (
+	imageTemplatePath = "/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/Microsoft.VirtualMachineImages/imageTemplates/{imageTemplateName}"
+
+	identityTypeUserAssigned = "UserAssigned"
+)
+
+// An ImageTemplateIdentity is the managed identity of an image template.
+type ImageTemplateIdentity struct {
+	Type                   *string                `json:"type,omitempty"`
+	UserAssignedIdentities map[string]interface{} `json:"userAssignedIdentities,omitempty"`
+}
+
+// An ImageTemplateSource is the source image of an image template.
+type ImageTemplateSource struct {
+	Type           *string `json:"type,omitempty"`
+	Publisher      *string `json:"publisher,omitempty"`
+	Offer          *string `json:"offer,omitempty"`
+	Sku            *string `json:"sku,omitempty"`
+	Version        *string `json:"version,omitempty"`
+	ImageID        *string `json:"imageId,omitempty"`
+	ImageVersionID *string `json:"imageVersionId,omitempty"`
+}
+
+// An ImageTemplateCustomizer customizes the source image of an image
+// template.
+type ImageTemplateCustomizer struct {
+	Type           *string   `json:"type,omitempty"`
+	Name           *string   `json:"name,omitempty"`
+	ScriptURI      *string   `json:"scriptUri,omitempty"`
+	Sha256Checksum *string   `json:"sha256Checksum,omitempty"`
+	Inline         *[]string `json:"inline,omitempty"`
+	RunElevated    *bool     `json:"runElevated,omitempty"`
+	SourceURI      *string   `json:"sourceUri,omitempty"`
+	Destination    *string   `json:"destination,omitempty"`
+}
+
+// An ImageTemplateDistributor distributes the image of an image template.
+type ImageTemplateDistributor struct {
+	Type               *string            `json:"type,omitempty"`
+	RunOutputName      *string            `json:"runOutputName,omitempty"`
+	GalleryImageID     *string            `json:"galleryImageId,omitempty"`
+	ReplicationRegions *[]string          `json:"replicationRegions,omitempty"`
+	StorageAccountType *string            `json:"storageAccountType,omitempty"`
+	ExcludeFromLatest  *bool              `json:"excludeFromLatest,omitempty"`
+	ImageID            *string            `json:"imageId,omitempty"`
+	Location           *string            `json:"location,omitempty"`
+	ArtifactTags       map[string]*string `json:"artifactTags,omitempty"`
+}
+
+// An ImageTemplateVMProfile describes the VM that builds the image of an
+// image template.
+type ImageTemplateVMProfile struct {
+	VMSize       *string `json:"vmSize,omitempty"`
+	OsDiskSizeGB *int32  `json:"osDiskSizeGB,omitempty"`
+}
+
+// An ImageTemplateProvisioningError is why an image template failed to
+// provision.
+type ImageTemplateProvisioningError struct {
+	ProvisioningErrorCode *string `json:"provisioningErrorCode,omitempty"`
+	Message               *string `json:"message,omitempty"`
+}
+
+// An ImageTemplateLastRunStatus is the status of the last build of an image
+// template.
+type ImageTemplateLastRunStatus struct {
+	StartTime   *date.Time `json:"startTime,omitempty"`
+	EndTime     *date.Time `json:"endTime,omitempty"`
+	RunState    *string    `json:"runState,omitempty"`
+	RunSubState *string    `json:"runSubState,omitempty"`
+	Message     *string    `json:"message,omitempty"`
+}
+
+// ImageTemplateProperties are the properties of an image template.
+type ImageTemplateProperties struct {
+	Source                *ImageTemplateSource            `json:"source,omitempty"`
+	Customize             *[]ImageTemplateCustomizer      `json:"customize,omitempty"`
+	Distribute            *[]ImageTemplateDistributor     `json:"distribute,omitempty"`
+	BuildTimeoutInMinutes *int32                          `json:"buildTimeoutInMinutes,omitempty"`
+	VMProfile             *ImageTemplateVMProfile         `json:"vmProfile,omitempty"`
+	ProvisioningState     *string                         `json:"provisioningState,omitempty"`
+	ProvisioningError     *ImageTemplateProvisioningError `json:"provisioningError,omitempty"`
+	LastRunStatus         *ImageTemplateLastRunStatus     `json:"lastRunStatus,omitempty"`
+}
+
+// An ImageTemplate is an Azure VM Image Builder image template.
+type ImageTemplate struct {
+	ID         *string                  `json:"id,omitempty"`
+	Name       *string                  `json:"name,omitempty"`
+	Location   *string                  `json:"location,omitempty"`
+	Tags       map[string]*string       `json:"tags,omitempty"`
+	Identity   *ImageTemplateIdentity   `json:"identity,omitempty"`
+	Properties *ImageTemplateProperties `json:"properties,omitempty"`
+}
+
+// RunOutputProperties are the properties of a run output.
+type RunOutputProperties struct {
+	ArtifactID        *string `json:"artifactId,omitempty"`
+	ArtifactURI       *string `json:"artifactUri,omitempty"`
+	ProvisioningState *string `json:"provisioningState,omitempty"`
+}
+
+// A RunOutput is an image a distributor of an image template distributed.
+type RunOutput struct {
+	ID         *string              `json:"id,omitempty"`
+	Name       *string              `json:"name,omitempty"`
+	Properties *RunOutputProperties `json:"properties,omitempty"`
+}
+
+type runOutputList struct {
+	Value []RunOutput `json:"value,omitempty"`
+}
+
+// An ImageTemplatesAPI reads and writes image templates and starts their
+// builds.
+type ImageTemplatesAPI interface {
+	Get(ctx context.Context, resourceGroupName, name string) (ImageTemplate, error)
+	CreateOrUpdate(ctx context.Context, resourceGroupName, name string, t ImageTemplate) error
+	UpdateTags(ctx context.Context, resourceGroupName, name string, tags map[string]*string) error
+	Delete(ctx context.Context, resourceGroupName, name string) error
+	Run(ctx context.Context, resourceGroupName, name string) error
+	ListRunOutputs(ctx context.Context, resourceGroupName, name string) ([]RunOutput, error)
+}
+
+// An ImageTemplatesClient reads and writes image templates through the
+// Azure Resource Manager API.
+type ImageTemplatesClient struct {
+	azure.ARMClient
+}
+
+// NewImageTemplatesClientWithBaseURI returns an ImageTemplatesClient for the
+// supplied subscription of the Azure Resource Manager API at the supplied
+// base URI.
+func NewImageTemplatesClientWithBaseURI(baseURI, subscriptionID string) ImageTemplatesClient {
+	return ImageTemplatesClient{ARMClient: azure.NewARMClientWithBaseURI("compute.ImageTemplatesClient", baseURI, subscriptionID, ImageTemplateAPIVersion)}
+}
+
+// Get returns the supplied image template.
+func (c ImageTemplatesClient) Get(ctx context.Context, resourceGroupName, name string) (ImageTemplate, error) {
+	t := ImageTemplate{}
+	err := c.Do(ctx, "Get", autorest.AsGet(), c.path(resourceGroupName, name, ""), nil, &t, http.StatusOK)
+	return t, err
+}
+
+// CreateOrUpdate starts to create the supplied image template. Azure
+// validates the template asynchronously; its provisioning state reports when
+// it is done. Azure rejects changes to the properties of existing templates.
+func (c ImageTemplatesClient) CreateOrUpdate(ctx context.Context, resourceGroupName, name string, t ImageTemplate) error {
+	return c.Do(ctx, "CreateOrUpdate", autorest.AsPut(), c.path(resourceGroupName, name, ""), t, nil, http.StatusOK, http.StatusCreated)
+}
+
+// UpdateTags starts to update the tags of the supplied image template.
+func (c ImageTemplatesClient) UpdateTags(ctx context.Context, resourceGroupName, name string, tags map[string]*string) error {
+	body := map[string]interface{}{"tags": tags}
+	return c.Do(ctx, "UpdateTags", autorest.AsPatch(), c.path(resourceGroupName, name, ""), body, nil, http.StatusOK, http.StatusAccepted)
+}
+
+// Delete starts to delete the supplied image template, including the staging
+// resource group Image Builder created for it.
+func (c ImageTemplatesClient) Delete(ctx context.Context, resourceGroupName, name string) error {
+	return c.Do(ctx, "Delete", autorest.AsDelete(), c.path(resourceGroupName, name, ""), nil, nil, http.StatusOK, http.StatusAccepted, http.StatusNoContent)
+}
+
+// Run starts a build of the supplied image template. The last run status of
+// the template reports when it is done.
+func (c ImageTemplatesClient) Run(ctx context.Context, resourceGroupName, name string) error {
+	return c.Do(ctx, "Run", autorest.AsPost(), c.path(resourceGroupName, name, "/run"), nil, nil, http.StatusOK, http.StatusAccepted)
+}
+
+// ListRunOutputs returns the run outputs of the supplied image template.
+func (c ImageTemplatesClient) ListRunOutputs(ctx context.Context, resourceGroupName, name string) ([]RunOutput, error) {
+	l := runOutputList{}
+	err := c.Do(ctx, "ListRunOutputs", autorest.AsGet(), c.path(resourceGroupName, name, "/runOutputs"), nil, &l, http.StatusOK)
+	return l.Value, err
+}
+
+func (c ImageTemplatesClient) path(resourceGroupName, name, suffix string) autorest.PrepareDecorator {
+	return autorest.WithPathParameters(imageTemplatePath+suffix, map[string]interface{}{
+		"subscriptionId":    autorest.Encode("path", c.SubscriptionID),
+		"resourceGroupName": autorest.Encode("path", resourceGroupName),
+		"imageTemplateName": autorest.Encode("path", name),
+	})
+}
+
+// NewImageTemplate returns the image template Azure creates for the supplied
+// ImageTemplate. The inline commands of customizers that select a ConfigMap
+// must already have been read from it.
+func NewImageTemplate(p v1alpha3.ImageTemplateParameters) ImageTemplate {
+	t := ImageTemplate{
+		Location: azure.ToStringPtr(p.Location),
+		Tags:     azure.ToStringPtrMap(p.Tags),
+		Identity: &ImageTemplateIdentity{
+			Type:                   azure.ToStringPtr(identityTypeUserAssigned),
+			UserAssignedIdentities: map[string]interface{}{p.IdentityID: struct{}{}},
+		},
+		Properties: &ImageTemplateProperties{
+			Source: &ImageTemplateSource{
+				Type:           azure.ToStringPtr(p.Source.Type),
+				Publisher:      p.Source.Publisher,
+				Offer:          p.Source.Offer,
+				Sku:            p.Source.SKU,
+				Version:        p.Source.Version,
+				ImageID:        p.Source.ImageID,
+				ImageVersionID: p.Source.ImageVersionID,
+			},
+			BuildTimeoutInMinutes: p.BuildTimeoutInMinutes,
+		},
+	}
+	if p.VMSize != nil || p.OSDiskSizeGB != nil {
+		t.Properties.VMProfile = &ImageTemplateVMProfile{VMSize: p.VMSize, OsDiskSizeGB: p.OSDiskSizeGB}
+	}
+	if len(p.Customizers) > 0 {
+		c := make([]ImageTemplateCustomizer, len(p.Customizers))
+		for i, pc := range p.Customizers {
+			c[i] = ImageTemplateCustomizer{
+				Type:           azure.ToStringPtr(pc.Type),
+				Name:           pc.Name,
+				ScriptURI:      pc.ScriptURI,
+				Sha256Checksum: pc.SHA256Checksum,
+				Inline:         azure.ToStringArrayPtr(pc.Inline),
+				RunElevated:    pc.RunElevated,
+				SourceURI:      pc.SourceURI,
+				Destination:    pc.Destination,
+			}
+		}
+		t.Properties.Customize = &c
+	}
+	d := make([]ImageTemplateDistributor, len(p.Distributors))
+	for i, pd := range p.Distributors {
+		d[i] = ImageTemplateDistributor{
+			Type:               azure.ToStringPtr(pd.Type),
+			RunOutputName:      azure.ToStringPtr(pd.RunOutputName),
+			GalleryImageID:     pd.GalleryImageID,
+			ReplicationRegions: azure.ToStringArrayPtr(pd.ReplicationRegions),
+			StorageAccountType: pd.StorageAccountType,
+			ExcludeFromLatest:  pd.ExcludeFromLatest,
+			ImageID:            pd.ImageID,
+			Location:           pd.Location,
+			ArtifactTags:       azure.ToStringPtrMap(pd.ArtifactTags),
+		}
+	}
+	t.Properties.Distribute = &d
+	return t
+}
+
+// ImageTemplateNeedsUpdate returns true if the supplied parameters differ
+// from the supplied image template. Only its tags can be updated.
+func ImageTemplateNeedsUpdate(p v1alpha3.ImageTemplateParameters, az ImageTemplate) bool {
+	return azure.TagsNeedUpdate(p.Tags, az.Tags)
+}
+
+// ImageTemplateNeedsBuild returns true if the build annotation of the
+// supplied ImageTemplate changed since its last build was started, its image
+// template is provisioned and it is not building an image.
+func ImageTemplateNeedsBuild(cr *v1alpha3.ImageTemplate) bool {
+	b := cr.GetAnnotations()[AnnotationKeyBuild]
+	o := cr.Status.AtProvider
+	if b == "" || b == o.LastBuild || o.ProvisioningState != ProvisioningStateSucceeded {
+		return false
+	}
+	return o.LastRunStatus == nil || o.LastRunStatus.RunState != RunStateRunning
+}
+
+// LateInitializeImageTemplate fills the empty fields of the supplied
+// parameters with the values of the supplied image template.
+func LateInitializeImageTemplate(p *v1alpha3.ImageTemplateParameters, az ImageTemplate, tp v1beta1.TagPolicy) {
+	if p.Location == "" {
+		p.Location = azure.ToString(az.Location)
+	}
+	if az.Properties != nil && p.BuildTimeoutInMinutes == nil {
+		p.BuildTimeoutInMinutes = az.Properties.BuildTimeoutInMinutes
+	}
+	p.Tags = azure.LateInitializeTags(p.Tags, az.Tags, tp)
+}
+
+// GenerateImageTemplateObservation returns the observation of the supplied
+// image template and its run outputs. It does not report the last build that
+// was started, which only the ImageTemplate records.
+func GenerateImageTemplateObservation(az ImageTemplate, outputs []RunOutput) v1alpha3.ImageTemplateObservation {
+	o := v1alpha3.ImageTemplateObservation{ID: azure.ToString(az.ID)}
+	for _, r := range outputs {
+		ro := v1alpha3.ImageTemplateRunOutput{Name: azure.ToString(r.Name)}
+		if r.Properties != nil {
+			ro.ArtifactID = azure.ToString(r.Properties.ArtifactID)
+			ro.ArtifactURI = azure.ToString(r.Properties.ArtifactURI)
+			ro.ProvisioningState = azure.ToString(r.Properties.ProvisioningState)
+		}
+		o.RunOutputs = append(o.RunOutputs, ro)
+	}
+	if az.Properties == nil {
+		return o
+	}
+	o.ProvisioningState = azure.ToString(az.Properties.ProvisioningState)
+	if e := az.Properties.ProvisioningError; e != nil {
+		o.ProvisioningError = azure.ToString(e.Message)
+	}
+	if s := az.Properties.LastRunStatus; s != nil {
+		o.LastRunStatus = &v1alpha3.ImageTemplateRunStatus{
+			RunState:    azure.ToString(s.RunState),
+			RunSubState: azure.ToString(s.RunSubState),
+			Message:     azure.ToString(s.Message),
+			StartTime:   toTime(s.StartTime),
+			EndTime:     toTime(s.EndTime),
+		}
+	}
+	return o
+}
+
+func toTime(t *date.Time) *metav1.Time {
+	if t == nil {
+		return nil
+	}
+	mt := metav1.NewTime(t.Time)
+	return &mt
+}
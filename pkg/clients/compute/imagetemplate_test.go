@@ -0,0 +1,208 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/provider-azure/apis/compute/v1alpha3"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+func TestNewImageTemplate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		p      v1alpha3.ImageTemplateParameters
+		want   ImageTemplate
+	}{
+		"SharedImage": {
+			reason: "An image template should build the source image with its customizers and distribute it to its gallery image",
+			p: v1alpha3.ImageTemplateParameters{
+				Location:   "westeurope",
+				IdentityID: "identity",
+				Source: v1alpha3.ImageTemplateSource{
+					Type:      v1alpha3.ImageSourcePlatformImage,
+					Publisher: azure.ToStringPtr("Canonical"),
+					Offer:     azure.ToStringPtr("0001-com-ubuntu-server-jammy"),
+					SKU:       azure.ToStringPtr("22_04-lts-gen2"),
+					Version:   azure.ToStringPtr("latest"),
+				},
+				Customizers: []v1alpha3.ImageTemplateCustomizer{{
+					Type:   v1alpha3.ImageCustomizerShell,
+					Inline: []string{"apt-get update"},
+				}},
+				Distributors: []v1alpha3.ImageTemplateDistributor{{
+					Type:               v1alpha3.ImageDistributorSharedImage,
+					RunOutputName:      "ubuntu",
+					GalleryImageID:     azure.ToStringPtr("gallery-image"),
+					ReplicationRegions: []string{"westeurope"},
+				}},
+				VMSize: azure.ToStringPtr("Standard_D2s_v3"),
+			},
+			want: ImageTemplate{
+				Location: azure.ToStringPtr("westeurope"),
+				Identity: &ImageTemplateIdentity{
+					Type:                   azure.ToStringPtr(identityTypeUserAssigned),
+					UserAssignedIdentities: map[string]interface{}{"identity": struct{}{}},
+				},
+				Properties: &ImageTemplateProperties{
+					Source: &ImageTemplateSource{
+						Type:      azure.ToStringPtr(v1alpha3.ImageSourcePlatformImage),
+						Publisher: azure.ToStringPtr("Canonical"),
+						Offer:     azure.ToStringPtr("0001-com-ubuntu-server-jammy"),
+						Sku:       azure.ToStringPtr("22_04-lts-gen2"),
+						Version:   azure.ToStringPtr("latest"),
+					},
+					Customize: &[]ImageTemplateCustomizer{{
+						Type:   azure.ToStringPtr(v1alpha3.ImageCustomizerShell),
+						Inline: &[]string{"apt-get update"},
+					}},
+					Distribute: &[]ImageTemplateDistributor{{
+						Type:               azure.ToStringPtr(v1alpha3.ImageDistributorSharedImage),
+						RunOutputName:      azure.ToStringPtr("ubuntu"),
+						GalleryImageID:     azure.ToStringPtr("gallery-image"),
+						ReplicationRegions: &[]string{"westeurope"},
+					}},
+					VMProfile: &ImageTemplateVMProfile{VMSize: azure.ToStringPtr("Standard_D2s_v3")},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := NewImageTemplate(tc.p)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nNewImageTemplate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestImageTemplateNeedsBuild(t *testing.T) {
+	template := func(build string, o v1alpha3.ImageTemplateObservation) *v1alpha3.ImageTemplate {
+		cr := &v1alpha3.ImageTemplate{Status: v1alpha3.ImageTemplateStatus{AtProvider: o}}
+		if build != "" {
+			cr.SetAnnotations(map[string]string{AnnotationKeyBuild: build})
+		}
+		return cr
+	}
+
+	cases := map[string]struct {
+		reason string
+		cr     *v1alpha3.ImageTemplate
+		want   bool
+	}{
+		"NoAnnotation": {
+			reason: "A template without a build annotation should not need a build",
+			cr:     template("", v1alpha3.ImageTemplateObservation{ProvisioningState: ProvisioningStateSucceeded}),
+			want:   false,
+		},
+		"AlreadyBuilt": {
+			reason: "A template whose build annotation did not change since its last build should not need a build",
+			cr:     template("v1", v1alpha3.ImageTemplateObservation{ProvisioningState: ProvisioningStateSucceeded, LastBuild: "v1"}),
+			want:   false,
+		},
+		"NotProvisioned": {
+			reason: "A template that is not provisioned yet should not need a build",
+			cr:     template("v1", v1alpha3.ImageTemplateObservation{ProvisioningState: "Creating"}),
+			want:   false,
+		},
+		"Building": {
+			reason: "A template that is building an image should not need another build",
+			cr: template("v2", v1alpha3.ImageTemplateObservation{
+				ProvisioningState: ProvisioningStateSucceeded,
+				LastBuild:         "v1",
+				LastRunStatus:     &v1alpha3.ImageTemplateRunStatus{RunState: RunStateRunning},
+			}),
+			want: false,
+		},
+		"AnnotationChanged": {
+			reason: "A template whose build annotation changed since its last build should need a build",
+			cr: template("v2", v1alpha3.ImageTemplateObservation{
+				ProvisioningState: ProvisioningStateSucceeded,
+				LastBuild:         "v1",
+				LastRunStatus:     &v1alpha3.ImageTemplateRunStatus{RunState: "Succeeded"},
+			}),
+			want: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ImageTemplateNeedsBuild(tc.cr)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nImageTemplateNeedsBuild(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGenerateImageTemplateObservation(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	mt := metav1.NewTime(now)
+
+	cases := map[string]struct {
+		reason  string
+		az      ImageTemplate
+		outputs []RunOutput
+		want    v1alpha3.ImageTemplateObservation
+	}{
+		"NoProperties": {
+			reason: "Only the ID of a template without properties should be observed",
+			az:     ImageTemplate{ID: azure.ToStringPtr("id")},
+			want:   v1alpha3.ImageTemplateObservation{ID: "id"},
+		},
+		"Built": {
+			reason: "The last run status and run outputs of a template should be observed",
+			az: ImageTemplate{
+				ID: azure.ToStringPtr("id"),
+				Properties: &ImageTemplateProperties{
+					ProvisioningState: azure.ToStringPtr(ProvisioningStateSucceeded),
+					LastRunStatus: &ImageTemplateLastRunStatus{
+						StartTime: &date.Time{Time: now},
+						RunState:  azure.ToStringPtr("Succeeded"),
+					},
+				},
+			},
+			outputs: []RunOutput{{
+				Name:       azure.ToStringPtr("ubuntu"),
+				Properties: &RunOutputProperties{ArtifactID: azure.ToStringPtr("image-version")},
+			}},
+			want: v1alpha3.ImageTemplateObservation{
+				ID:                "id",
+				ProvisioningState: ProvisioningStateSucceeded,
+				LastRunStatus:     &v1alpha3.ImageTemplateRunStatus{RunState: "Succeeded", StartTime: &mt},
+				RunOutputs:        []v1alpha3.ImageTemplateRunOutput{{Name: "ubuntu", ArtifactID: "image-version"}},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GenerateImageTemplateObservation(tc.az, tc.outputs)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGenerateImageTemplateObservation(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
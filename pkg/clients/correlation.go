@@ -0,0 +1,73 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+// HeaderCorrelationID is the header of the ID by which the Azure activity log
+// correlates the requests of an operation. Azure Resource Manager generates
+// one unless the client supplies it.
+const HeaderCorrelationID = "x-ms-correlation-request-id"
+
+type correlationKey struct{}
+
+// WithCorrelationID returns a copy of the supplied context whose requests are
+// sent with the supplied correlation ID.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationKey{}, id)
+}
+
+// CorrelationID returns the correlation ID requests with the supplied context
+// are sent with, if any.
+func CorrelationID(ctx context.Context) string {
+	id, _ := ctx.Value(correlationKey{}).(string)
+	return id
+}
+
+// A CorrelatingAuthorizer sends the requests it authorizes with the
+// correlation ID of their context, if any.
+type CorrelatingAuthorizer struct {
+	autorest.Authorizer
+}
+
+// NewCorrelatingAuthorizer returns a CorrelatingAuthorizer that authorizes
+// requests with the supplied authorizer.
+func NewCorrelatingAuthorizer(a autorest.Authorizer) *CorrelatingAuthorizer {
+	return &CorrelatingAuthorizer{Authorizer: a}
+}
+
+// WithAuthorization returns a PrepareDecorator that adds the correlation ID
+// of the context of a request to its headers before it is authorized.
+func (a *CorrelatingAuthorizer) WithAuthorization() autorest.PrepareDecorator {
+	return func(p autorest.Preparer) autorest.Preparer {
+		return autorest.PreparerFunc(func(r *http.Request) (*http.Request, error) {
+			r, err := p.Prepare(r)
+			if err != nil {
+				return r, err
+			}
+			if id := CorrelationID(r.Context()); id != "" {
+				r.Header.Set(HeaderCorrelationID, id)
+			}
+			return autorest.CreatePreparer(a.Authorizer.WithAuthorization()).Prepare(r)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+var _ autorest.Authorizer = &CorrelatingAuthorizer{}
+
+type authorizerFn func(r *http.Request)
+
+func (fn authorizerFn) WithAuthorization() autorest.PrepareDecorator {
+	return func(p autorest.Preparer) autorest.Preparer {
+		return autorest.PreparerFunc(func(r *http.Request) (*http.Request, error) {
+			fn(r)
+			return p.Prepare(r)
+		})
+	}
+}
+
+func TestCorrelatingAuthorizer(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		ctx    context.Context
+		want   string
+	}{
+		"NoCorrelationID": {
+			reason: "Requests whose context has no correlation ID should be sent without one",
+			ctx:    context.Background(),
+		},
+		"CorrelationID": {
+			reason: "Requests should be sent with the correlation ID of their context",
+			ctx:    WithCorrelationID(context.Background(), "cool-id"),
+			want:   "cool-id",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			authorized := false
+			inner := authorizerFn(func(_ *http.Request) { authorized = true })
+			req, _ := http.NewRequest(http.MethodPut, "https://management.azure.com/subscriptions/cool", nil)
+			req, err := autorest.Prepare(req.WithContext(tc.ctx), NewCorrelatingAuthorizer(inner).WithAuthorization())
+			if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nWithAuthorization(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want, req.Header.Get(HeaderCorrelationID)); diff != "" {
+				t.Errorf("\n%s\nWithAuthorization(...): -want correlation ID, +got correlation ID:\n%s", tc.reason, diff)
+			}
+			if !authorized {
+				t.Errorf("\n%s\nWithAuthorization(...): request was not authorized by the wrapped authorizer", tc.reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cost estimates the cost of Azure resources using the Azure Retail
+// Prices API.
+// https://docs.microsoft.com/en-us/rest/api/cost-management/retail-prices/azure-retail-prices
+package cost
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	cachev1beta1 "github.com/crossplane/provider-azure/apis/cache/v1beta1"
+	computev1alpha3 "github.com/crossplane/provider-azure/apis/compute/v1alpha3"
+	databasev1beta1 "github.com/crossplane/provider-azure/apis/database/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+const (
+	// RetailPricesURL is the endpoint of the Azure Retail Prices API.
+	RetailPricesURL = "https://prices.azure.com/api/retail/prices"
+
+	// HoursPerMonth is the number of hours Azure uses to estimate monthly
+	// costs.
+	HoursPerMonth = 730
+
+	// DefaultCacheTTL is how long retail prices are cached for.
+	DefaultCacheTTL = 24 * time.Hour
+)
+
+// Error strings.
+const (
+	errNewRequest   = "cannot create retail prices request"
+	errGetPrices    = "cannot get retail prices"
+	errDecodePrices = "cannot decode retail prices"
+	errStatusFmt    = "retail prices API returned status %d"
+	errNoPrice      = "no retail price matches filter %q"
+)
+
+// A Query identifies the hourly retail price of a resource.
+type Query struct {
+	// Filter is the OData filter sent to the Retail Prices API.
+	Filter string
+
+	// Exclude prices whose product or SKU name contains any of these strings,
+	// e.g. Windows or Spot variants of a VM size.
+	Exclude []string
+
+	// Quantity the hourly price is multiplied by, e.g. nodes or vCores.
+	Quantity int
+}
+
+// An Estimate of the monthly cost of a resource.
+type Estimate struct {
+	Monthly  float64
+	Currency string
+}
+
+// String returns the estimate formatted as e.g. "73.00 USD".
+func (e Estimate) String() string {
+	return fmt.Sprintf("%.2f %s", e.Monthly, e.Currency)
+}
+
+// An Estimator estimates the monthly cost of a resource.
+type Estimator interface {
+	Estimate(ctx context.Context, q Query) (Estimate, error)
+}
+
+type price struct {
+	CurrencyCode  string  `json:"currencyCode"`
+	RetailPrice   float64 `json:"retailPrice"`
+	ProductName   string  `json:"productName"`
+	SKUName       string  `json:"skuName"`
+	UnitOfMeasure string  `json:"unitOfMeasure"`
+}
+
+type prices struct {
+	Items        []price `json:"Items"`
+	NextPageLink string  `json:"NextPageLink"`
+}
+
+type cached struct {
+	price   price
+	found   bool
+	expires time.Time
+}
+
+// A RetailPricesClient is an Estimator backed by the Azure Retail Prices API.
+// Prices, and queries that match no price, are cached in memory for
+// DefaultCacheTTL.
+type RetailPricesClient struct {
+	http *http.Client
+	url  string
+
+	mu    sync.Mutex
+	cache map[string]cached
+}
+
+// NewRetailPricesClient returns a new RetailPricesClient.
+func NewRetailPricesClient() *RetailPricesClient {
+	return &RetailPricesClient{
+		http:  &http.Client{Timeout: 30 * time.Second},
+		url:   RetailPricesURL,
+		cache: map[string]cached{},
+	}
+}
+
+// Estimate the monthly cost of the resource identified by the supplied query.
+func (c *RetailPricesClient) Estimate(ctx context.Context, q Query) (Estimate, error) {
+	p, err := c.price(ctx, q)
+	if err != nil {
+		return Estimate{}, err
+	}
+	return Estimate{
+		Monthly:  p.RetailPrice * HoursPerMonth * float64(q.Quantity),
+		Currency: p.CurrencyCode,
+	}, nil
+}
+
+func (c *RetailPricesClient) price(ctx context.Context, q Query) (price, error) {
+	key := q.Filter + "|" + strings.Join(q.Exclude, ",")
+	c.mu.Lock()
+	if p, ok := c.cache[key]; ok && time.Now().Before(p.expires) {
+		c.mu.Unlock()
+		if !p.found {
+			return price{}, errors.Errorf(errNoPrice, q.Filter)
+		}
+		return p.price, nil
+	}
+	c.mu.Unlock()
+
+	// Results are paged; follow NextPageLink until a price matches.
+	for next := c.url + "?$filter=" + url.QueryEscape(q.Filter); next != ""; {
+		ps, err := c.page(ctx, next)
+		if err != nil {
+			return price{}, err
+		}
+		for _, p := range ps.Items {
+			if p.UnitOfMeasure != "1 Hour" || excluded(p, q.Exclude) {
+				continue
+			}
+			c.store(key, cached{price: p, found: true})
+			return p, nil
+		}
+		next = ps.NextPageLink
+	}
+	c.store(key, cached{})
+	return price{}, errors.Errorf(errNoPrice, q.Filter)
+}
+
+func (c *RetailPricesClient) page(ctx context.Context, u string) (prices, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return prices{}, errors.Wrap(err, errNewRequest)
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return prices{}, errors.Wrap(err, errGetPrices)
+	}
+	defer resp.Body.Close() // nolint:errcheck
+	if resp.StatusCode != http.StatusOK {
+		return prices{}, errors.Errorf(errStatusFmt, resp.StatusCode)
+	}
+	ps := prices{}
+	return ps, errors.Wrap(json.NewDecoder(resp.Body).Decode(&ps), errDecodePrices)
+}
+
+func (c *RetailPricesClient) store(key string, p cached) {
+	p.expires = time.Now().Add(DefaultCacheTTL)
+	c.mu.Lock()
+	c.cache[key] = p
+	c.mu.Unlock()
+}
+
+func excluded(p price, exclude []string) bool {
+	for _, e := range exclude {
+		if strings.Contains(p.ProductName, e) || strings.Contains(p.SKUName, e) {
+			return true
+		}
+	}
+	return false
+}
+
+// literal returns the supplied value as an OData string literal, in which
+// single quotes are escaped by doubling them.
+func literal(v string) string {
+	return "'" + strings.ReplaceAll(v, "'", "''") + "'"
+}
+
+// ForRedis returns the query for the price of the supplied Redis cache.
+func ForRedis(p cachev1beta1.RedisParameters) Query {
+	return Query{
+		Filter: fmt.Sprintf("serviceName eq 'Redis Cache' and priceType eq 'Consumption' and armRegionName eq %s and productName eq %s and skuName eq %s",
+			literal(azure.NormalizeLocation(p.Location)), literal("Azure Redis Cache "+p.SKU.Name), literal(fmt.Sprintf("%s%d", p.SKU.Family, p.SKU.Capacity))),
+		Quantity: 1,
+	}
+}
+
+// ForAKSCluster returns the query for the price of the nodes of the supplied
+// AKS cluster. The control plane of AKS clusters is free.
+func ForAKSCluster(p computev1alpha3.AKSClusterParameters) Query {
+	nodes := computev1alpha3.DefaultNodeCount
+	if p.NodeCount != nil {
+		nodes = *p.NodeCount
+	}
+	return Query{
+		Filter: fmt.Sprintf("serviceName eq 'Virtual Machines' and priceType eq 'Consumption' and armRegionName eq %s and armSkuName eq %s",
+			literal(azure.NormalizeLocation(p.Location)), literal(p.NodeVMSize)),
+		Exclude:  []string{"Windows", "Spot", "Low Priority"},
+		Quantity: nodes,
+	}
+}
+
+// ForMySQLServer returns the query for the compute price of the supplied
+// MySQL server. Storage and backups are not included.
+func ForMySQLServer(p databasev1beta1.SQLServerParameters) Query {
+	return forSQLServer("MySQL", p)
+}
+
+// ForPostgreSQLServer returns the query for the compute price of the supplied
+// PostgreSQL server. Storage and backups are not included.
+func ForPostgreSQLServer(p databasev1beta1.SQLServerParameters) Query {
+	return forSQLServer("PostgreSQL", p)
+}
+
+func forSQLServer(engine string, p databasev1beta1.SQLServerParameters) Query {
+	tiers := map[string]string{
+		"Basic":           "Basic",
+		"GeneralPurpose":  "General Purpose",
+		"MemoryOptimized": "Memory Optimized",
+	}
+	return Query{
+		Filter: fmt.Sprintf("serviceName eq %s and priceType eq 'Consumption' and armRegionName eq %s and productName eq %s and meterName eq 'vCore'",
+			literal("Azure Database for "+engine), literal(azure.NormalizeLocation(p.Location)),
+			literal(fmt.Sprintf("Azure Database for %s Single Server %s - Compute %s", engine, tiers[p.SKU.Tier], p.SKU.Family))),
+		Quantity: p.SKU.Capacity,
+	}
+}
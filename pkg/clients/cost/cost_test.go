@@ -0,0 +1,176 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cost
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	computev1alpha3 "github.com/crossplane/provider-azure/apis/compute/v1alpha3"
+)
+
+func TestEstimate(t *testing.T) {
+	filter := "armSkuName eq 'Standard_DS2_v2'"
+	items := []price{
+		{CurrencyCode: "USD", RetailPrice: 0.2, ProductName: "Virtual Machines DSv2 Series Windows", UnitOfMeasure: "1 Hour"},
+		{CurrencyCode: "USD", RetailPrice: 0.1, ProductName: "Virtual Machines DSv2 Series", UnitOfMeasure: "1 Hour"},
+	}
+
+	type want struct {
+		e   Estimate
+		err error
+	}
+	cases := map[string]struct {
+		status int
+		items  []price
+		q      Query
+		want   want
+	}{
+		"Success": {
+			status: http.StatusOK,
+			items:  items,
+			q:      Query{Filter: filter, Exclude: []string{"Windows"}, Quantity: 3},
+			want:   want{e: Estimate{Monthly: 0.1 * HoursPerMonth * 3, Currency: "USD"}},
+		},
+		"NoPrice": {
+			status: http.StatusOK,
+			q:      Query{Filter: filter, Quantity: 1},
+			want:   want{err: errors.Errorf(errNoPrice, filter)},
+		},
+		"BadStatus": {
+			status: http.StatusBadRequest,
+			q:      Query{Filter: filter, Quantity: 1},
+			want:   want{err: errors.Errorf(errStatusFmt, http.StatusBadRequest)},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.URL.Query().Get("$filter"); got != tc.q.Filter {
+					t.Errorf("$filter: want %q, got %q", tc.q.Filter, got)
+				}
+				w.WriteHeader(tc.status)
+				_ = json.NewEncoder(w).Encode(prices{Items: tc.items})
+			}))
+			defer srv.Close()
+
+			c := NewRetailPricesClient()
+			c.url = srv.URL
+			e, err := c.Estimate(context.Background(), tc.q)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Estimate(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.e, e); diff != "" {
+				t.Errorf("Estimate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEstimatePaged(t *testing.T) {
+	pages := []prices{
+		{Items: []price{{CurrencyCode: "USD", RetailPrice: 0.2, ProductName: "Virtual Machines DSv2 Series Windows", UnitOfMeasure: "1 Hour"}}},
+		{Items: []price{{CurrencyCode: "USD", RetailPrice: 0.1, ProductName: "Virtual Machines DSv2 Series", UnitOfMeasure: "1 Hour"}}},
+	}
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		i, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		p := pages[i]
+		if i+1 < len(pages) {
+			p.NextPageLink = srv.URL + "?page=" + strconv.Itoa(i+1)
+		}
+		_ = json.NewEncoder(w).Encode(p)
+	}))
+	defer srv.Close()
+
+	c := NewRetailPricesClient()
+	c.url = srv.URL
+	e, err := c.Estimate(context.Background(), Query{Filter: "armSkuName eq 'Standard_DS2_v2'", Exclude: []string{"Windows"}, Quantity: 1})
+	if err != nil {
+		t.Fatalf("Estimate(...): %s", err)
+	}
+	if diff := cmp.Diff(Estimate{Monthly: 0.1 * HoursPerMonth, Currency: "USD"}, e); diff != "" {
+		t.Errorf("Estimate(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestEstimateCachesMisses(t *testing.T) {
+	filter := "armSkuName eq 'Standard_DS2_v2'"
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_ = json.NewEncoder(w).Encode(prices{})
+	}))
+	defer srv.Close()
+
+	c := NewRetailPricesClient()
+	c.url = srv.URL
+	for i := 0; i < 2; i++ {
+		_, err := c.Estimate(context.Background(), Query{Filter: filter, Quantity: 1})
+		if diff := cmp.Diff(errors.Errorf(errNoPrice, filter), err, test.EquateErrors()); diff != "" {
+			t.Errorf("Estimate(...): -want error, +got error:\n%s", diff)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("Estimate(...): want 1 request, got %d", requests)
+	}
+}
+
+func TestForAKSCluster(t *testing.T) {
+	nodes := 3
+	cases := map[string]struct {
+		reason string
+		p      computev1alpha3.AKSClusterParameters
+		want   Query
+	}{
+		"Success": {
+			reason: "The query should match the VM size of the nodes in the cluster's region.",
+			p:      computev1alpha3.AKSClusterParameters{Location: "West US 2", NodeVMSize: "Standard_DS2_v2", NodeCount: &nodes},
+			want: Query{
+				Filter:   "serviceName eq 'Virtual Machines' and priceType eq 'Consumption' and armRegionName eq 'westus2' and armSkuName eq 'Standard_DS2_v2'",
+				Exclude:  []string{"Windows", "Spot", "Low Priority"},
+				Quantity: 3,
+			},
+		},
+		"Escaped": {
+			reason: "Single quotes in values should be escaped so they cannot end the OData string literal.",
+			p:      computev1alpha3.AKSClusterParameters{Location: "westus2", NodeVMSize: "Standard_D2' or '1"},
+			want: Query{
+				Filter:   "serviceName eq 'Virtual Machines' and priceType eq 'Consumption' and armRegionName eq 'westus2' and armSkuName eq 'Standard_D2'' or ''1'",
+				Exclude:  []string{"Windows", "Spot", "Low Priority"},
+				Quantity: computev1alpha3.DefaultNodeCount,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ForAKSCluster(tc.p)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nForAKSCluster(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// Environment variables of service principal credentials, as read by the
+// Azure SDKs and the Azure CLI.
+const (
+	EnvClientID       = "AZURE_CLIENT_ID"
+	EnvClientSecret   = "AZURE_CLIENT_SECRET"
+	EnvTenantID       = "AZURE_TENANT_ID"
+	EnvSubscriptionID = "AZURE_SUBSCRIPTION_ID"
+)
+
+// Keys of the output of az ad sp create-for-rbac without --sdk-auth.
+const (
+	rbacKeyAppID    = "appId"
+	rbacKeyPassword = "password"
+	rbacKeyTenant   = "tenant"
+)
+
+// Error strings.
+const (
+	errUnknownCredentialsFormat = "cannot detect format of credentials; must be the JSON output of az ad sp create-for-rbac, with or without --sdk-auth, or AZURE_CLIENT_ID=... lines"
+	errGetCredentialsSecret     = "cannot get credentials secret"
+	errNoCredentialsInSecret    = "credentials secret has neither key %q nor the keys clientId, clientSecret, tenantId and subscriptionId"
+	errNoCredentialsInEnv       = "environment variables AZURE_CLIENT_ID, AZURE_CLIENT_SECRET and AZURE_TENANT_ID are not set"
+)
+
+// envKeys maps the environment variables of service principal credentials to
+// credentials keys.
+var envKeys = map[string]string{
+	EnvClientID:       CredentialsKeyClientID,
+	EnvClientSecret:   CredentialsKeyClientSecret,
+	EnvTenantID:       CredentialsKeyTenantID,
+	EnvSubscriptionID: CredentialsKeySubscriptionID,
+}
+
+// ParseCredentials returns the credentials keys of the supplied service
+// principal credentials. It detects their format, which is one of:
+//
+// The JSON output of az ad sp create-for-rbac --sdk-auth.
+//
+// The JSON output of az ad sp create-for-rbac without --sdk-auth, i.e. with
+// appId, password and tenant keys, optionally with a subscriptionId key.
+//
+// Lines of environment variable assignments, e.g. AZURE_CLIENT_ID=...
+func ParseCredentials(data []byte) (map[string]string, error) {
+	data = bytes.TrimSpace(data)
+	if bytes.HasPrefix(data, []byte("{")) {
+		m := map[string]string{}
+		if err := json.Unmarshal(data, &m); err != nil {
+			return nil, errors.Wrap(err, errUnmarshalCredentialSecret)
+		}
+		if _, ok := m[CredentialsKeyClientID]; ok {
+			return m, nil
+		}
+		if _, ok := m[rbacKeyAppID]; ok {
+			c := map[string]string{
+				CredentialsKeyClientID:     m[rbacKeyAppID],
+				CredentialsKeyClientSecret: m[rbacKeyPassword],
+				CredentialsKeyTenantID:     m[rbacKeyTenant],
+			}
+			if id, ok := m[CredentialsKeySubscriptionID]; ok {
+				c[CredentialsKeySubscriptionID] = id
+			}
+			return c, nil
+		}
+		return nil, errors.New(errUnknownCredentialsFormat)
+	}
+
+	m := map[string]string{}
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		line := strings.TrimPrefix(strings.TrimSpace(s.Text()), "export ")
+		kv := strings.SplitN(line, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		if k, ok := envKeys[strings.TrimSpace(kv[0])]; ok {
+			m[k] = strings.Trim(strings.TrimSpace(kv[1]), `"'`)
+		}
+	}
+	if m[CredentialsKeyClientID] == "" {
+		return nil, errors.New(errUnknownCredentialsFormat)
+	}
+	return m, nil
+}
+
+// SecretCredentials returns the service principal credentials of the supplied
+// secret. They are the data of the supplied key if the secret has it, or else
+// the JSON encoding of the discrete clientId, clientSecret, tenantId and
+// subscriptionId keys of the secret.
+func SecretCredentials(s *corev1.Secret, key string) ([]byte, error) {
+	if d, ok := s.Data[key]; ok {
+		return d, nil
+	}
+	m := map[string]string{}
+	for _, k := range []string{CredentialsKeyClientID, CredentialsKeyClientSecret, CredentialsKeyTenantID, CredentialsKeySubscriptionID} {
+		v, ok := s.Data[k]
+		if !ok {
+			return nil, errors.Errorf(errNoCredentialsInSecret, key)
+		}
+		m[k] = string(v)
+	}
+	return json.Marshal(m)
+}
+
+// EnvironmentCredentials returns the JSON encoding of the service principal
+// credentials of the AZURE_CLIENT_ID, AZURE_CLIENT_SECRET, AZURE_TENANT_ID and
+// AZURE_SUBSCRIPTION_ID environment variables.
+func EnvironmentCredentials(getenv func(string) string) ([]byte, error) {
+	m := map[string]string{}
+	for env, k := range envKeys {
+		if v := getenv(env); v != "" {
+			m[k] = v
+		}
+	}
+	if m[CredentialsKeyClientID] == "" || m[CredentialsKeyClientSecret] == "" || m[CredentialsKeyTenantID] == "" {
+		return nil, errors.New(errNoCredentialsInEnv)
+	}
+	return json.Marshal(m)
+}
+
+// ExtractCredentials extracts the service principal credentials of a
+// ProviderConfig from the supplied source. Unlike the common credentials
+// extractor it accepts secrets with discrete credentials keys, and falls back
+// to the AZURE_CLIENT_ID, AZURE_CLIENT_SECRET, AZURE_TENANT_ID and
+// AZURE_SUBSCRIPTION_ID environment variables if the Environment source names
+// no environment variable.
+func ExtractCredentials(ctx context.Context, source xpv1.CredentialsSource, c client.Client, sel xpv1.CommonCredentialSelectors) ([]byte, error) {
+	switch {
+	case source == xpv1.CredentialsSourceSecret && sel.SecretRef != nil:
+		s := &corev1.Secret{}
+		if err := c.Get(ctx, types.NamespacedName{Namespace: sel.SecretRef.Namespace, Name: sel.SecretRef.Name}, s); err != nil {
+			return nil, errors.Wrap(err, errGetCredentialsSecret)
+		}
+		return SecretCredentials(s, sel.SecretRef.Key)
+	case source == xpv1.CredentialsSourceEnvironment && sel.Env == nil:
+		return EnvironmentCredentials(os.Getenv)
+	}
+	return resource.CommonCredentialExtractor(ctx, source, c, sel)
+}
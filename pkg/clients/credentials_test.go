@@ -0,0 +1,286 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+const (
+	credClientID       = "0f32e96b-b9a4-49ce-a857-243a33b20e5c"
+	credClientSecret   = "49d8cab5-d47a-4d1a-9133-5c5db29c345d"
+	credTenantID       = "302de427-dba9-4452-8583-a4268e46de6b"
+	credSubscriptionID = "bf1b0e59-93da-42e0-82c6-5a1d94227911"
+)
+
+var credentials = map[string]string{
+	CredentialsKeyClientID:       credClientID,
+	CredentialsKeyClientSecret:   credClientSecret,
+	CredentialsKeyTenantID:       credTenantID,
+	CredentialsKeySubscriptionID: credSubscriptionID,
+}
+
+func TestParseCredentials(t *testing.T) {
+	type want struct {
+		m   map[string]string
+		err error
+	}
+
+	cases := map[string]struct {
+		data string
+		want want
+	}{
+		"SDKAuth": {
+			data: authData,
+			want: want{m: map[string]string{
+				CredentialsKeyClientID:                       credClientID,
+				CredentialsKeyClientSecret:                   credClientSecret,
+				CredentialsKeyTenantID:                       credTenantID,
+				CredentialsKeySubscriptionID:                 credSubscriptionID,
+				CredentialsKeyActiveDirectoryEndpointURL:     "https://login.microsoftonline.com",
+				CredentialsKeyResourceManagerEndpointURL:     "https://management.azure.com/",
+				CredentialsKeyActiveDirectoryGraphResourceID: "https://graph.windows.net/",
+				CredentialsKeySQLManagementEndpointURL:       "https://management.core.windows.net:8443/",
+				CredentialsKeyGalleryEndpointURL:             "https://gallery.azure.com/",
+				CredentialsManagementEndpointURL:             "https://management.core.windows.net/",
+			}},
+		},
+		"CreateForRBAC": {
+			data: `{
+				"appId": "0f32e96b-b9a4-49ce-a857-243a33b20e5c",
+				"displayName": "crossplane",
+				"password": "49d8cab5-d47a-4d1a-9133-5c5db29c345d",
+				"tenant": "302de427-dba9-4452-8583-a4268e46de6b",
+				"subscriptionId": "bf1b0e59-93da-42e0-82c6-5a1d94227911"
+			}`,
+			want: want{m: credentials},
+		},
+		"Environment": {
+			data: `
+				AZURE_CLIENT_ID=0f32e96b-b9a4-49ce-a857-243a33b20e5c
+				export AZURE_CLIENT_SECRET="49d8cab5-d47a-4d1a-9133-5c5db29c345d"
+				AZURE_TENANT_ID='302de427-dba9-4452-8583-a4268e46de6b'
+				# A comment.
+				AZURE_SUBSCRIPTION_ID = bf1b0e59-93da-42e0-82c6-5a1d94227911
+				OTHER=value
+			`,
+			want: want{m: credentials},
+		},
+		"UnknownJSON": {
+			data: `{"cool": "credentials"}`,
+			want: want{err: errors.New(errUnknownCredentialsFormat)},
+		},
+		"Unknown": {
+			data: "cool credentials",
+			want: want{err: errors.New(errUnknownCredentialsFormat)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m, err := ParseCredentials([]byte(tc.data))
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("ParseCredentials(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.m, m); diff != "" {
+				t.Errorf("ParseCredentials(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSecretCredentials(t *testing.T) {
+	key := "credentials"
+
+	type want struct {
+		m   map[string]string
+		err error
+	}
+
+	cases := map[string]struct {
+		data map[string][]byte
+		want want
+	}{
+		"Key": {
+			data: map[string][]byte{key: []byte(`{"clientId": "0f32e96b-b9a4-49ce-a857-243a33b20e5c"}`)},
+			want: want{m: map[string]string{CredentialsKeyClientID: credClientID}},
+		},
+		"DiscreteKeys": {
+			data: map[string][]byte{
+				CredentialsKeyClientID:       []byte(credClientID),
+				CredentialsKeyClientSecret:   []byte(credClientSecret),
+				CredentialsKeyTenantID:       []byte(credTenantID),
+				CredentialsKeySubscriptionID: []byte(credSubscriptionID),
+			},
+			want: want{m: credentials},
+		},
+		"MissingKeys": {
+			data: map[string][]byte{
+				CredentialsKeyClientID: []byte(credClientID),
+			},
+			want: want{err: errors.Errorf(errNoCredentialsInSecret, key)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			d, err := SecretCredentials(&corev1.Secret{Data: tc.data}, key)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("SecretCredentials(...): -want error, +got error:\n%s", diff)
+			}
+			if err != nil {
+				return
+			}
+			m, err := ParseCredentials(d)
+			if err != nil {
+				t.Fatalf("ParseCredentials(...): %s", err)
+			}
+			if diff := cmp.Diff(tc.want.m, m); diff != "" {
+				t.Errorf("SecretCredentials(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEnvironmentCredentials(t *testing.T) {
+	type want struct {
+		m   map[string]string
+		err error
+	}
+
+	cases := map[string]struct {
+		env  map[string]string
+		want want
+	}{
+		"Set": {
+			env: map[string]string{
+				EnvClientID:       credClientID,
+				EnvClientSecret:   credClientSecret,
+				EnvTenantID:       credTenantID,
+				EnvSubscriptionID: credSubscriptionID,
+			},
+			want: want{m: credentials},
+		},
+		"NoSubscription": {
+			env: map[string]string{
+				EnvClientID:     credClientID,
+				EnvClientSecret: credClientSecret,
+				EnvTenantID:     credTenantID,
+			},
+			want: want{m: map[string]string{
+				CredentialsKeyClientID:     credClientID,
+				CredentialsKeyClientSecret: credClientSecret,
+				CredentialsKeyTenantID:     credTenantID,
+			}},
+		},
+		"NotSet": {
+			env: map[string]string{
+				EnvClientID: credClientID,
+			},
+			want: want{err: errors.New(errNoCredentialsInEnv)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			d, err := EnvironmentCredentials(func(k string) string { return tc.env[k] })
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("EnvironmentCredentials(...): -want error, +got error:\n%s", diff)
+			}
+			if err != nil {
+				return
+			}
+			m := map[string]string{}
+			if err := json.Unmarshal(d, &m); err != nil {
+				t.Fatalf("json.Unmarshal(...): %s", err)
+			}
+			if diff := cmp.Diff(tc.want.m, m); diff != "" {
+				t.Errorf("EnvironmentCredentials(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestExtractCredentials(t *testing.T) {
+	errBoom := errors.New("boom")
+	ref := &xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Name: "cool", Namespace: "default"}, Key: "credentials"}
+
+	type args struct {
+		source xpv1.CredentialsSource
+		c      client.Client
+		sel    xpv1.CommonCredentialSelectors
+	}
+	type want struct {
+		data []byte
+		err  error
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Secret": {
+			args: args{
+				source: xpv1.CredentialsSourceSecret,
+				c: &test.MockClient{MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+					o.(*corev1.Secret).Data = map[string][]byte{ref.Key: []byte(authData)}
+					return nil
+				})},
+				sel: xpv1.CommonCredentialSelectors{SecretRef: ref},
+			},
+			want: want{data: []byte(authData)},
+		},
+		"GetSecretError": {
+			args: args{
+				source: xpv1.CredentialsSourceSecret,
+				c:      &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+				sel:    xpv1.CommonCredentialSelectors{SecretRef: ref},
+			},
+			want: want{err: errors.Wrap(errBoom, errGetCredentialsSecret)},
+		},
+		"NoSecretKey": {
+			args: args{
+				source: xpv1.CredentialsSourceSecret,
+				c:      &test.MockClient{MockGet: test.NewMockGetFn(nil)},
+				sel:    xpv1.CommonCredentialSelectors{SecretRef: ref},
+			},
+			want: want{err: errors.Errorf(errNoCredentialsInSecret, ref.Key)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			data, err := ExtractCredentials(context.Background(), tc.args.source, tc.args.c, tc.args.sel)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("ExtractCredentials(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.data, data); diff != "" {
+				t.Errorf("ExtractCredentials(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
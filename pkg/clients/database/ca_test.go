@@ -0,0 +1,41 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"encoding/pem"
+	"testing"
+)
+
+func TestServerCACertificates(t *testing.T) {
+	rest := []byte(ServerCACertificates)
+	n := 0
+	for {
+		var b *pem.Block
+		b, rest = pem.Decode(rest)
+		if b == nil {
+			break
+		}
+		if b.Type != "CERTIFICATE" {
+			t.Errorf("pem.Decode(...): want block of type CERTIFICATE, got %q", b.Type)
+		}
+		n++
+	}
+	if n != 3 {
+		t.Errorf("pem.Decode(...): want 3 certificates, got %d", n)
+	}
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/crossplane/provider-azure/apis/database/v1alpha3"
+	"github.com/crossplane/provider-azure/apis/v1beta1"
 	azure "github.com/crossplane/provider-azure/pkg/clients"
 )
 
@@ -51,7 +52,7 @@ func NewDatabaseAccountClient(credentials []byte) (AccountClient, error) {
 		return nil, errors.Wrap(err, "failed to get authorizer from config")
 	}
 
-	client := documentdb.NewDatabaseAccountsClient(creds.SubscriptionID)
+	client := documentdb.NewDatabaseAccountsClientWithBaseURI(creds.BaseURI(), creds.SubscriptionID)
 	client.Authorizer = authorizer
 
 	if err := client.AddToUserAgent(azure.UserAgent); err != nil {
@@ -84,6 +85,26 @@ func UpdateCosmosDBAccountObservation(o *v1alpha3.CosmosDBAccountStatus, in docu
 	}
 }
 
+// LateInitialize fills the empty fields of the supplied CosmosDBAccount
+// parameters with their values in the supplied Azure account, e.g. the
+// consistency policy Azure defaults to.
+func LateInitialize(p *v1alpha3.CosmosDBAccountParameters, in documentdb.DatabaseAccount, tp v1beta1.TagPolicy) {
+	if p.Location == "" {
+		p.Location = azure.ToString(in.Location)
+	}
+	p.Tags = azure.LateInitializeTags(p.Tags, in.Tags, tp)
+	if in.DatabaseAccountProperties == nil {
+		return
+	}
+	if p.Properties.ConsistencyPolicy == nil {
+		p.Properties.ConsistencyPolicy = fromDatabaseConsistencyPolicy(in.ConsistencyPolicy)
+	}
+	p.Properties.IPRangeFilter = azure.LateInitializeStringPtrFromPtr(p.Properties.IPRangeFilter, in.IPRangeFilter)
+	p.Properties.EnableAutomaticFailover = azure.LateInitializeBoolPtrFromPtr(p.Properties.EnableAutomaticFailover, in.EnableAutomaticFailover)
+	p.Properties.EnableMultipleWriteLocations = azure.LateInitializeBoolPtrFromPtr(p.Properties.EnableMultipleWriteLocations, in.EnableMultipleWriteLocations)
+	p.Properties.EnableCassandraConnector = azure.LateInitializeBoolPtrFromPtr(p.Properties.EnableCassandraConnector, in.EnableCassandraConnector)
+}
+
 func toDatabaseProperties(a *v1alpha3.CosmosDBAccountProperties) *documentdb.DatabaseAccountCreateUpdateProperties {
 	if a == nil {
 		return nil
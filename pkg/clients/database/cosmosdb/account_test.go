@@ -26,6 +26,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 
 	"github.com/crossplane/provider-azure/apis/database/v1alpha3"
+	"github.com/crossplane/provider-azure/apis/v1beta1"
 	azure "github.com/crossplane/provider-azure/pkg/clients"
 )
 
@@ -207,3 +208,69 @@ func TestCheckEqualDatabaseProperties(t *testing.T) {
 		}
 	})
 }
+
+func TestLateInitialize(t *testing.T) {
+	location := "uswest"
+	tags := map[string]string{"cool": "tag"}
+	policy := &documentdb.ConsistencyPolicy{DefaultConsistencyLevel: documentdb.Session}
+
+	cases := map[string]struct {
+		p    *v1alpha3.CosmosDBAccountParameters
+		in   documentdb.DatabaseAccount
+		want *v1alpha3.CosmosDBAccountParameters
+	}{
+		"Empty": {
+			p: &v1alpha3.CosmosDBAccountParameters{},
+			in: documentdb.DatabaseAccount{
+				Location: azure.ToStringPtr(location),
+				Tags:     azure.ToStringPtrMap(tags),
+				DatabaseAccountProperties: &documentdb.DatabaseAccountProperties{
+					ConsistencyPolicy:            policy,
+					EnableAutomaticFailover:      azure.ToBoolPtr(false, azure.FieldRequired),
+					EnableMultipleWriteLocations: azure.ToBoolPtr(true),
+				},
+			},
+			want: &v1alpha3.CosmosDBAccountParameters{
+				Location: location,
+				Tags:     tags,
+				Properties: v1alpha3.CosmosDBAccountProperties{
+					ConsistencyPolicy:            &v1alpha3.CosmosDBAccountConsistencyPolicy{DefaultConsistencyLevel: string(documentdb.Session)},
+					EnableAutomaticFailover:      azure.ToBoolPtr(false, azure.FieldRequired),
+					EnableMultipleWriteLocations: azure.ToBoolPtr(true),
+				},
+			},
+		},
+		"Set": {
+			p: &v1alpha3.CosmosDBAccountParameters{
+				Location: location,
+				Properties: v1alpha3.CosmosDBAccountProperties{
+					ConsistencyPolicy:       &v1alpha3.CosmosDBAccountConsistencyPolicy{DefaultConsistencyLevel: string(documentdb.Strong)},
+					EnableAutomaticFailover: azure.ToBoolPtr(true),
+				},
+			},
+			in: documentdb.DatabaseAccount{
+				Location: azure.ToStringPtr("useast"),
+				DatabaseAccountProperties: &documentdb.DatabaseAccountProperties{
+					ConsistencyPolicy:       policy,
+					EnableAutomaticFailover: azure.ToBoolPtr(false, azure.FieldRequired),
+				},
+			},
+			want: &v1alpha3.CosmosDBAccountParameters{
+				Location: location,
+				Properties: v1alpha3.CosmosDBAccountProperties{
+					ConsistencyPolicy:       &v1alpha3.CosmosDBAccountConsistencyPolicy{DefaultConsistencyLevel: string(documentdb.Strong)},
+					EnableAutomaticFailover: azure.ToBoolPtr(true),
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitialize(tc.p, tc.in, v1beta1.TagPolicyReplace)
+			if diff := cmp.Diff(tc.want, tc.p); diff != "" {
+				t.Errorf("LateInitialize(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/crossplane/provider-azure/apis/database/v1beta1"
 	azuredbv1beta1 "github.com/crossplane/provider-azure/apis/database/v1beta1"
 	"github.com/crossplane/provider-azure/apis/v1alpha3"
+	apisv1beta1 "github.com/crossplane/provider-azure/apis/v1beta1"
 	azure "github.com/crossplane/provider-azure/pkg/clients"
 )
 
@@ -89,12 +90,13 @@ func toMySQLProperties(s v1beta1.SQLServerParameters, adminPassword string) mysq
 	switch createMode {
 	case azuredbv1beta1.CreateModePointInTimeRestore:
 		return &mysql.ServerPropertiesForRestore{
-			MinimalTLSVersion:  mysql.MinimalTLSVersionEnum(s.MinimalTLSVersion),
-			Version:            mysql.ServerVersion(s.Version),
-			SslEnforcement:     mysql.SslEnforcementEnum(s.SSLEnforcement),
-			CreateMode:         mysql.CreateModePointInTimeRestore,
-			RestorePointInTime: safeDate(s.RestorePointInTime),
-			SourceServerID:     s.SourceServerID,
+			MinimalTLSVersion:   mysql.MinimalTLSVersionEnum(s.MinimalTLSVersion),
+			PublicNetworkAccess: mysql.PublicNetworkAccessEnum(azure.ToString(s.PublicNetworkAccess)),
+			Version:             mysql.ServerVersion(s.Version),
+			SslEnforcement:      mysql.SslEnforcementEnum(s.SSLEnforcement),
+			CreateMode:          mysql.CreateModePointInTimeRestore,
+			RestorePointInTime:  safeDate(s.RestorePointInTime),
+			SourceServerID:      s.SourceServerID,
 			StorageProfile: &mysql.StorageProfile{
 				BackupRetentionDays: azure.ToInt32PtrFromIntPtr(s.StorageProfile.BackupRetentionDays),
 				GeoRedundantBackup:  mysql.GeoRedundantBackup(azure.ToString(s.StorageProfile.GeoRedundantBackup)),
@@ -104,11 +106,12 @@ func toMySQLProperties(s v1beta1.SQLServerParameters, adminPassword string) mysq
 		}
 	case azuredbv1beta1.CreateModeGeoRestore:
 		return &mysql.ServerPropertiesForGeoRestore{
-			MinimalTLSVersion: mysql.MinimalTLSVersionEnum(s.MinimalTLSVersion),
-			Version:           mysql.ServerVersion(s.Version),
-			SslEnforcement:    mysql.SslEnforcementEnum(s.SSLEnforcement),
-			CreateMode:        mysql.CreateModeGeoRestore,
-			SourceServerID:    s.SourceServerID,
+			MinimalTLSVersion:   mysql.MinimalTLSVersionEnum(s.MinimalTLSVersion),
+			PublicNetworkAccess: mysql.PublicNetworkAccessEnum(azure.ToString(s.PublicNetworkAccess)),
+			Version:             mysql.ServerVersion(s.Version),
+			SslEnforcement:      mysql.SslEnforcementEnum(s.SSLEnforcement),
+			CreateMode:          mysql.CreateModeGeoRestore,
+			SourceServerID:      s.SourceServerID,
 			StorageProfile: &mysql.StorageProfile{
 				BackupRetentionDays: azure.ToInt32PtrFromIntPtr(s.StorageProfile.BackupRetentionDays),
 				GeoRedundantBackup:  mysql.GeoRedundantBackup(azure.ToString(s.StorageProfile.GeoRedundantBackup)),
@@ -118,11 +121,12 @@ func toMySQLProperties(s v1beta1.SQLServerParameters, adminPassword string) mysq
 		}
 	case azuredbv1beta1.CreateModeReplica:
 		return &mysql.ServerPropertiesForReplica{
-			MinimalTLSVersion: mysql.MinimalTLSVersionEnum(s.MinimalTLSVersion),
-			Version:           mysql.ServerVersion(s.Version),
-			SslEnforcement:    mysql.SslEnforcementEnum(s.SSLEnforcement),
-			CreateMode:        mysql.CreateModeReplica,
-			SourceServerID:    s.SourceServerID,
+			MinimalTLSVersion:   mysql.MinimalTLSVersionEnum(s.MinimalTLSVersion),
+			PublicNetworkAccess: mysql.PublicNetworkAccessEnum(azure.ToString(s.PublicNetworkAccess)),
+			Version:             mysql.ServerVersion(s.Version),
+			SslEnforcement:      mysql.SslEnforcementEnum(s.SSLEnforcement),
+			CreateMode:          mysql.CreateModeReplica,
+			SourceServerID:      s.SourceServerID,
 			StorageProfile: &mysql.StorageProfile{
 				BackupRetentionDays: azure.ToInt32PtrFromIntPtr(s.StorageProfile.BackupRetentionDays),
 				GeoRedundantBackup:  mysql.GeoRedundantBackup(azure.ToString(s.StorageProfile.GeoRedundantBackup)),
@@ -135,6 +139,7 @@ func toMySQLProperties(s v1beta1.SQLServerParameters, adminPassword string) mysq
 	default:
 		return &mysql.ServerPropertiesForDefaultCreate{
 			MinimalTLSVersion:          mysql.MinimalTLSVersionEnum(s.MinimalTLSVersion),
+			PublicNetworkAccess:        mysql.PublicNetworkAccessEnum(azure.ToString(s.PublicNetworkAccess)),
 			AdministratorLogin:         azure.ToStringPtr(s.AdministratorLogin),
 			AdministratorLoginPassword: &adminPassword,
 			Version:                    mysql.ServerVersion(s.Version),
@@ -163,14 +168,19 @@ func (c *MySQLServerClient) CreateServer(ctx context.Context, cr *azuredbv1beta1
 		Location:   &s.Location,
 		Tags:       azure.ToStringPtrMap(s.Tags),
 	}
-	op, err := c.Create(ctx, s.ResourceGroupName, meta.GetExternalName(cr), createParams)
+	// The operation is recorded before the request is made so
+	// that a create whose request times out is not repeated while it may be
+	// in progress.
+	cr.Status.AtProvider.LastOperation = azure.NewAsyncOperation(http.MethodPut)
+	cr.Status.AtProvider.LastOperation.Generation = cr.GetGeneration()
+	op, err := c.Create(azure.WithClientRequestID(ctx, cr.Status.AtProvider.LastOperation.ClientRequestID), s.ResourceGroupName, meta.GetExternalName(cr), createParams)
 	if err != nil {
+		if azure.IsRejected(err) {
+			cr.Status.AtProvider.LastOperation = v1alpha3.AsyncOperation{}
+		}
 		return err
 	}
-	cr.Status.AtProvider.LastOperation = v1alpha3.AsyncOperation{
-		PollingURL: op.PollingURL(),
-		Method:     http.MethodPut,
-	}
+	cr.Status.AtProvider.LastOperation.PollingURL = op.PollingURL()
 	return nil
 }
 
@@ -180,9 +190,10 @@ func (c *MySQLServerClient) UpdateServer(ctx context.Context, cr *azuredbv1beta1
 	// we don't support that.
 	s := cr.Spec.ForProvider
 	properties := &mysql.ServerUpdateParametersProperties{
-		Version:           mysql.ServerVersion(s.Version),
-		MinimalTLSVersion: mysql.MinimalTLSVersionEnum(s.MinimalTLSVersion),
-		SslEnforcement:    mysql.SslEnforcementEnum(s.SSLEnforcement),
+		Version:             mysql.ServerVersion(s.Version),
+		MinimalTLSVersion:   mysql.MinimalTLSVersionEnum(s.MinimalTLSVersion),
+		PublicNetworkAccess: mysql.PublicNetworkAccessEnum(azure.ToString(s.PublicNetworkAccess)),
+		SslEnforcement:      mysql.SslEnforcementEnum(s.SSLEnforcement),
 		StorageProfile: &mysql.StorageProfile{
 			BackupRetentionDays: azure.ToInt32PtrFromIntPtr(s.StorageProfile.BackupRetentionDays),
 			GeoRedundantBackup:  mysql.GeoRedundantBackup(azure.ToString(s.StorageProfile.GeoRedundantBackup)),
@@ -301,15 +312,19 @@ func UpdateMySQLObservation(o *azuredbv1beta1.SQLServerObservation, in mysql.Ser
 	o.UserVisibleState = string(in.UserVisibleState)
 	o.FullyQualifiedDomainName = azure.ToString(in.FullyQualifiedDomainName)
 	o.MasterServerID = azure.ToString(in.MasterServerID)
+	o.SecondaryLocation = ""
+	if in.StorageProfile != nil && in.StorageProfile.GeoRedundantBackup == mysql.Enabled {
+		o.SecondaryLocation = azure.PairedLocation(azure.ToString(in.Location))
+	}
 }
 
 // LateInitializeMySQL fills the empty values of SQLServerParameters with the
 // ones that are retrieved from the Azure API.
-func LateInitializeMySQL(p *azuredbv1beta1.SQLServerParameters, in mysql.Server) {
+func LateInitializeMySQL(p *azuredbv1beta1.SQLServerParameters, in mysql.Server, tp apisv1beta1.TagPolicy) {
 	if in.Sku != nil {
 		p.SKU.Size = azure.LateInitializeStringPtrFromPtr(p.SKU.Size, in.Sku.Size)
 	}
-	p.Tags = azure.LateInitializeStringMap(p.Tags, in.Tags)
+	p.Tags = azure.LateInitializeTags(p.Tags, in.Tags, tp)
 	if in.StorageProfile != nil {
 		p.StorageProfile.BackupRetentionDays = azure.LateInitializeIntPtrFromInt32Ptr(p.StorageProfile.BackupRetentionDays, in.StorageProfile.BackupRetentionDays)
 		p.StorageProfile.GeoRedundantBackup = azure.LateInitializeStringPtrFromVal(p.StorageProfile.GeoRedundantBackup, string(in.StorageProfile.GeoRedundantBackup))
@@ -318,6 +333,7 @@ func LateInitializeMySQL(p *azuredbv1beta1.SQLServerParameters, in mysql.Server)
 	if p.MinimalTLSVersion == "" {
 		p.MinimalTLSVersion = string(in.MinimalTLSVersion)
 	}
+	p.PublicNetworkAccess = azure.LateInitializeStringPtrFromPtr(p.PublicNetworkAccess, azure.ToStringPtr(string(in.PublicNetworkAccess)))
 	if p.SSLEnforcement == "" {
 		p.SSLEnforcement = string(in.SslEnforcement)
 	}
@@ -332,11 +348,13 @@ func IsMySQLUpToDate(p azuredbv1beta1.SQLServerParameters, in mysql.Server) bool
 	switch {
 	case p.MinimalTLSVersion != string(in.MinimalTLSVersion):
 		return false
+	case azure.ToString(p.PublicNetworkAccess) != string(in.PublicNetworkAccess):
+		return false
 	case p.SSLEnforcement != string(in.SslEnforcement):
 		return false
 	case p.Version != string(in.Version):
 		return false
-	case !reflect.DeepEqual(azure.ToStringPtrMap(p.Tags), in.Tags):
+	case azure.TagsNeedUpdate(p.Tags, in.Tags):
 		return false
 	case p.SKU.Tier != string(in.Sku.Tier):
 		return false
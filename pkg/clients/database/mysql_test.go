@@ -146,6 +146,19 @@ func TestToMySQLProperties(t *testing.T) {
 			fp:   mySQLServerParameters(nil),
 			want: mySQLServerPropertiesForDefaultCreate(),
 		},
+		{
+			name: "SecurityParameters",
+			fp: v1beta1.SQLServerParameters{
+				MinimalTLSVersion:   "TLS1_2",
+				PublicNetworkAccess: azure.ToStringPtr("Disabled"),
+			},
+			want: func() mysql.BasicServerPropertiesForCreate {
+				p := mySQLServerPropertiesForDefaultCreate().(*mysql.ServerPropertiesForDefaultCreate)
+				p.MinimalTLSVersion = mysql.TLS12
+				p.PublicNetworkAccess = mysql.PublicNetworkAccessEnumDisabled
+				return p
+			}(),
+		},
 	}
 
 	for _, tc := range cases {
@@ -443,3 +456,53 @@ func TestMySQLServerFirewallRuleIsUpToDate(t *testing.T) {
 		})
 	}
 }
+
+func TestLateInitializeMySQL(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		p      v1beta1.SQLServerParameters
+		in     mysql.Server
+		want   v1beta1.SQLServerParameters
+	}{
+		"Unset": {
+			reason: "The minimum TLS version and public network access of the server should be late initialized.",
+			in: mysql.Server{ServerProperties: &mysql.ServerProperties{
+				MinimalTLSVersion:   mysql.TLS12,
+				PublicNetworkAccess: mysql.PublicNetworkAccessEnumEnabled,
+			}},
+			want: v1beta1.SQLServerParameters{
+				MinimalTLSVersion:   "TLS1_2",
+				PublicNetworkAccess: azure.ToStringPtr("Enabled"),
+			},
+		},
+		"Set": {
+			reason: "The minimum TLS version and public network access that are set should not be late initialized.",
+			p: v1beta1.SQLServerParameters{
+				MinimalTLSVersion:   "TLS1_0",
+				PublicNetworkAccess: azure.ToStringPtr("Disabled"),
+			},
+			in: mysql.Server{ServerProperties: &mysql.ServerProperties{
+				MinimalTLSVersion:   mysql.TLS12,
+				PublicNetworkAccess: mysql.PublicNetworkAccessEnumEnabled,
+			}},
+			want: v1beta1.SQLServerParameters{
+				MinimalTLSVersion:   "TLS1_0",
+				PublicNetworkAccess: azure.ToStringPtr("Disabled"),
+			},
+		},
+		"Empty": {
+			reason: "Public network access should not be late initialized to an empty string Azure does not accept.",
+			in:     mysql.Server{ServerProperties: &mysql.ServerProperties{}},
+			want:   v1beta1.SQLServerParameters{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializeMySQL(&tc.p, tc.in, "")
+			if diff := cmp.Diff(tc.want, tc.p); diff != "" {
+				t.Errorf("\n%s\nLateInitializeMySQL(...): -want, +got\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -34,6 +34,7 @@ import (
 	"github.com/crossplane/provider-azure/apis/database/v1beta1"
 	azuredbv1beta1 "github.com/crossplane/provider-azure/apis/database/v1beta1"
 	"github.com/crossplane/provider-azure/apis/v1alpha3"
+	apisv1beta1 "github.com/crossplane/provider-azure/apis/v1beta1"
 	azure "github.com/crossplane/provider-azure/pkg/clients"
 )
 
@@ -81,12 +82,13 @@ func toPGSQLProperties(s v1beta1.SQLServerParameters, adminPassword string) post
 	switch createMode {
 	case azuredbv1beta1.CreateModePointInTimeRestore:
 		return &postgresql.ServerPropertiesForRestore{
-			MinimalTLSVersion:  postgresql.MinimalTLSVersionEnum(s.MinimalTLSVersion),
-			Version:            postgresql.ServerVersion(s.Version),
-			SslEnforcement:     postgresql.SslEnforcementEnum(s.SSLEnforcement),
-			CreateMode:         postgresql.CreateModePointInTimeRestore,
-			RestorePointInTime: safeDate(s.RestorePointInTime),
-			SourceServerID:     s.SourceServerID,
+			MinimalTLSVersion:   postgresql.MinimalTLSVersionEnum(s.MinimalTLSVersion),
+			PublicNetworkAccess: postgresql.PublicNetworkAccessEnum(azure.ToString(s.PublicNetworkAccess)),
+			Version:             postgresql.ServerVersion(s.Version),
+			SslEnforcement:      postgresql.SslEnforcementEnum(s.SSLEnforcement),
+			CreateMode:          postgresql.CreateModePointInTimeRestore,
+			RestorePointInTime:  safeDate(s.RestorePointInTime),
+			SourceServerID:      s.SourceServerID,
 			StorageProfile: &postgresql.StorageProfile{
 				BackupRetentionDays: azure.ToInt32PtrFromIntPtr(s.StorageProfile.BackupRetentionDays),
 				GeoRedundantBackup:  postgresql.GeoRedundantBackup(azure.ToString(s.StorageProfile.GeoRedundantBackup)),
@@ -96,11 +98,12 @@ func toPGSQLProperties(s v1beta1.SQLServerParameters, adminPassword string) post
 		}
 	case azuredbv1beta1.CreateModeGeoRestore:
 		return &postgresql.ServerPropertiesForGeoRestore{
-			MinimalTLSVersion: postgresql.MinimalTLSVersionEnum(s.MinimalTLSVersion),
-			Version:           postgresql.ServerVersion(s.Version),
-			SslEnforcement:    postgresql.SslEnforcementEnum(s.SSLEnforcement),
-			SourceServerID:    s.SourceServerID,
-			CreateMode:        postgresql.CreateModeGeoRestore,
+			MinimalTLSVersion:   postgresql.MinimalTLSVersionEnum(s.MinimalTLSVersion),
+			PublicNetworkAccess: postgresql.PublicNetworkAccessEnum(azure.ToString(s.PublicNetworkAccess)),
+			Version:             postgresql.ServerVersion(s.Version),
+			SslEnforcement:      postgresql.SslEnforcementEnum(s.SSLEnforcement),
+			SourceServerID:      s.SourceServerID,
+			CreateMode:          postgresql.CreateModeGeoRestore,
 			StorageProfile: &postgresql.StorageProfile{
 				BackupRetentionDays: azure.ToInt32PtrFromIntPtr(s.StorageProfile.BackupRetentionDays),
 				GeoRedundantBackup:  postgresql.GeoRedundantBackup(azure.ToString(s.StorageProfile.GeoRedundantBackup)),
@@ -110,11 +113,12 @@ func toPGSQLProperties(s v1beta1.SQLServerParameters, adminPassword string) post
 		}
 	case azuredbv1beta1.CreateModeReplica:
 		return &postgresql.ServerPropertiesForReplica{
-			MinimalTLSVersion: postgresql.MinimalTLSVersionEnum(s.MinimalTLSVersion),
-			Version:           postgresql.ServerVersion(s.Version),
-			SslEnforcement:    postgresql.SslEnforcementEnum(s.SSLEnforcement),
-			CreateMode:        postgresql.CreateModeReplica,
-			SourceServerID:    s.SourceServerID,
+			MinimalTLSVersion:   postgresql.MinimalTLSVersionEnum(s.MinimalTLSVersion),
+			PublicNetworkAccess: postgresql.PublicNetworkAccessEnum(azure.ToString(s.PublicNetworkAccess)),
+			Version:             postgresql.ServerVersion(s.Version),
+			SslEnforcement:      postgresql.SslEnforcementEnum(s.SSLEnforcement),
+			CreateMode:          postgresql.CreateModeReplica,
+			SourceServerID:      s.SourceServerID,
 			StorageProfile: &postgresql.StorageProfile{
 				BackupRetentionDays: azure.ToInt32PtrFromIntPtr(s.StorageProfile.BackupRetentionDays),
 				GeoRedundantBackup:  postgresql.GeoRedundantBackup(azure.ToString(s.StorageProfile.GeoRedundantBackup)),
@@ -127,6 +131,7 @@ func toPGSQLProperties(s v1beta1.SQLServerParameters, adminPassword string) post
 	default:
 		return &postgresql.ServerPropertiesForDefaultCreate{
 			MinimalTLSVersion:          postgresql.MinimalTLSVersionEnum(s.MinimalTLSVersion),
+			PublicNetworkAccess:        postgresql.PublicNetworkAccessEnum(azure.ToString(s.PublicNetworkAccess)),
 			AdministratorLogin:         azure.ToStringPtr(s.AdministratorLogin),
 			AdministratorLoginPassword: &adminPassword,
 			Version:                    postgresql.ServerVersion(s.Version),
@@ -155,14 +160,19 @@ func (c *PostgreSQLServerClient) CreateServer(ctx context.Context, cr *azuredbv1
 		Location:   &s.Location,
 		Tags:       azure.ToStringPtrMap(s.Tags),
 	}
-	op, err := c.Create(ctx, s.ResourceGroupName, meta.GetExternalName(cr), createParams)
+	// The operation is recorded before the request is made so
+	// that a create whose request times out is not repeated while it may be
+	// in progress.
+	cr.Status.AtProvider.LastOperation = azure.NewAsyncOperation(http.MethodPut)
+	cr.Status.AtProvider.LastOperation.Generation = cr.GetGeneration()
+	op, err := c.Create(azure.WithClientRequestID(ctx, cr.Status.AtProvider.LastOperation.ClientRequestID), s.ResourceGroupName, meta.GetExternalName(cr), createParams)
 	if err != nil {
+		if azure.IsRejected(err) {
+			cr.Status.AtProvider.LastOperation = v1alpha3.AsyncOperation{}
+		}
 		return err
 	}
-	cr.Status.AtProvider.LastOperation = v1alpha3.AsyncOperation{
-		PollingURL: op.PollingURL(),
-		Method:     http.MethodPut,
-	}
+	cr.Status.AtProvider.LastOperation.PollingURL = op.PollingURL()
 	return nil
 }
 
@@ -172,9 +182,10 @@ func (c *PostgreSQLServerClient) UpdateServer(ctx context.Context, cr *azuredbv1
 	// we don't support that.
 	s := cr.Spec.ForProvider
 	properties := &postgresql.ServerUpdateParametersProperties{
-		Version:           postgresql.ServerVersion(s.Version),
-		MinimalTLSVersion: postgresql.MinimalTLSVersionEnum(s.MinimalTLSVersion),
-		SslEnforcement:    postgresql.SslEnforcementEnum(s.SSLEnforcement),
+		Version:             postgresql.ServerVersion(s.Version),
+		MinimalTLSVersion:   postgresql.MinimalTLSVersionEnum(s.MinimalTLSVersion),
+		PublicNetworkAccess: postgresql.PublicNetworkAccessEnum(azure.ToString(s.PublicNetworkAccess)),
+		SslEnforcement:      postgresql.SslEnforcementEnum(s.SSLEnforcement),
 		StorageProfile: &postgresql.StorageProfile{
 			BackupRetentionDays: azure.ToInt32PtrFromIntPtr(s.StorageProfile.BackupRetentionDays),
 			GeoRedundantBackup:  postgresql.GeoRedundantBackup(azure.ToString(s.StorageProfile.GeoRedundantBackup)),
@@ -293,15 +304,19 @@ func UpdatePostgreSQLObservation(o *azuredbv1beta1.SQLServerObservation, in post
 	o.UserVisibleState = string(in.UserVisibleState)
 	o.FullyQualifiedDomainName = azure.ToString(in.FullyQualifiedDomainName)
 	o.MasterServerID = azure.ToString(in.MasterServerID)
+	o.SecondaryLocation = ""
+	if in.StorageProfile != nil && in.StorageProfile.GeoRedundantBackup == postgresql.Enabled {
+		o.SecondaryLocation = azure.PairedLocation(azure.ToString(in.Location))
+	}
 }
 
 // LateInitializePostgreSQL fills the empty values of SQLServerParameters with the
 // ones that are retrieved from the Azure API.
-func LateInitializePostgreSQL(p *azuredbv1beta1.SQLServerParameters, in postgresql.Server) {
+func LateInitializePostgreSQL(p *azuredbv1beta1.SQLServerParameters, in postgresql.Server, tp apisv1beta1.TagPolicy) {
 	if in.Sku != nil {
 		p.SKU.Size = azure.LateInitializeStringPtrFromPtr(p.SKU.Size, in.Sku.Size)
 	}
-	p.Tags = azure.LateInitializeStringMap(p.Tags, in.Tags)
+	p.Tags = azure.LateInitializeTags(p.Tags, in.Tags, tp)
 	if in.StorageProfile != nil {
 		p.StorageProfile.BackupRetentionDays = azure.LateInitializeIntPtrFromInt32Ptr(p.StorageProfile.BackupRetentionDays, in.StorageProfile.BackupRetentionDays)
 		p.StorageProfile.GeoRedundantBackup = azure.LateInitializeStringPtrFromVal(p.StorageProfile.GeoRedundantBackup, string(in.StorageProfile.GeoRedundantBackup))
@@ -310,6 +325,7 @@ func LateInitializePostgreSQL(p *azuredbv1beta1.SQLServerParameters, in postgres
 	if p.MinimalTLSVersion == "" {
 		p.MinimalTLSVersion = string(in.MinimalTLSVersion)
 	}
+	p.PublicNetworkAccess = azure.LateInitializeStringPtrFromPtr(p.PublicNetworkAccess, azure.ToStringPtr(string(in.PublicNetworkAccess)))
 	if p.SSLEnforcement == "" {
 		p.SSLEnforcement = string(in.SslEnforcement)
 	}
@@ -324,11 +340,13 @@ func IsPostgreSQLUpToDate(p azuredbv1beta1.SQLServerParameters, in postgresql.Se
 	switch {
 	case p.MinimalTLSVersion != string(in.MinimalTLSVersion):
 		return false
+	case azure.ToString(p.PublicNetworkAccess) != string(in.PublicNetworkAccess):
+		return false
 	case p.SSLEnforcement != string(in.SslEnforcement):
 		return false
 	case p.Version != string(in.Version):
 		return false
-	case !reflect.DeepEqual(azure.ToStringPtrMap(p.Tags), in.Tags):
+	case azure.TagsNeedUpdate(p.Tags, in.Tags):
 		return false
 	case p.SKU.Tier != string(in.Sku.Tier):
 		return false
@@ -135,6 +135,19 @@ func TestTopostgresqlProperties(t *testing.T) {
 			fp:   postgresqlServerParameters(nil),
 			want: postgresqlServerPropertiesForDefaultCreate(),
 		},
+		{
+			name: "SecurityParameters",
+			fp: v1beta1.SQLServerParameters{
+				MinimalTLSVersion:   "TLS1_2",
+				PublicNetworkAccess: azure.ToStringPtr("Disabled"),
+			},
+			want: func() postgresql.BasicServerPropertiesForCreate {
+				p := postgresqlServerPropertiesForDefaultCreate().(*postgresql.ServerPropertiesForDefaultCreate)
+				p.MinimalTLSVersion = postgresql.TLS12
+				p.PublicNetworkAccess = postgresql.PublicNetworkAccessEnumDisabled
+				return p
+			}(),
+		},
 	}
 
 	for _, tc := range cases {
@@ -431,3 +444,53 @@ func TestPostgreSQLServerFirewallRuleIsUpToDate(t *testing.T) {
 		})
 	}
 }
+
+func TestLateInitializePostgreSQL(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		p      v1beta1.SQLServerParameters
+		in     postgresql.Server
+		want   v1beta1.SQLServerParameters
+	}{
+		"Unset": {
+			reason: "The minimum TLS version and public network access of the server should be late initialized.",
+			in: postgresql.Server{ServerProperties: &postgresql.ServerProperties{
+				MinimalTLSVersion:   postgresql.TLS12,
+				PublicNetworkAccess: postgresql.PublicNetworkAccessEnumEnabled,
+			}},
+			want: v1beta1.SQLServerParameters{
+				MinimalTLSVersion:   "TLS1_2",
+				PublicNetworkAccess: azure.ToStringPtr("Enabled"),
+			},
+		},
+		"Set": {
+			reason: "The minimum TLS version and public network access that are set should not be late initialized.",
+			p: v1beta1.SQLServerParameters{
+				MinimalTLSVersion:   "TLS1_0",
+				PublicNetworkAccess: azure.ToStringPtr("Disabled"),
+			},
+			in: postgresql.Server{ServerProperties: &postgresql.ServerProperties{
+				MinimalTLSVersion:   postgresql.TLS12,
+				PublicNetworkAccess: postgresql.PublicNetworkAccessEnumEnabled,
+			}},
+			want: v1beta1.SQLServerParameters{
+				MinimalTLSVersion:   "TLS1_0",
+				PublicNetworkAccess: azure.ToStringPtr("Disabled"),
+			},
+		},
+		"Empty": {
+			reason: "Public network access should not be late initialized to an empty string Azure does not accept.",
+			in:     postgresql.Server{ServerProperties: &postgresql.ServerProperties{}},
+			want:   v1beta1.SQLServerParameters{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializePostgreSQL(&tc.p, tc.in, "")
+			if diff := cmp.Diff(tc.want, tc.p); diff != "" {
+				t.Errorf("\n%s\nLateInitializePostgreSQL(...): -want, +got\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
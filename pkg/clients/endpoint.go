@@ -0,0 +1,80 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest/azure"
+)
+
+// An Endpoint of the Azure Resource Manager API, e.g. of an Azure Stack Hub.
+type Endpoint struct {
+	// URL of the API.
+	URL string
+
+	// Audience of the access tokens the API accepts.
+	Audience string
+}
+
+// PublicEndpoint is the Endpoint of the Azure Resource Manager API of the
+// public Azure cloud.
+var PublicEndpoint = Endpoint{
+	URL:      azure.PublicCloud.ResourceManagerEndpoint,
+	Audience: azure.PublicCloud.ResourceManagerEndpoint,
+}
+
+// NewEndpoint returns the Endpoint with the supplied URL and token audience.
+// The audience defaults to the URL.
+func NewEndpoint(url string, audience *string) Endpoint {
+	e := Endpoint{URL: url, Audience: url}
+	if audience != nil && *audience != "" {
+		e.Audience = *audience
+	}
+	return e
+}
+
+// BaseURI returns the base URI Azure SDK clients should send requests to,
+// given the supplied credentials. It is the resource manager endpoint of the
+// credentials, if any, and that of the public Azure cloud otherwise.
+func BaseURI(creds map[string]string) string {
+	return baseURI(creds[CredentialsKeyResourceManagerEndpointURL])
+}
+
+// BaseURI returns the base URI Azure SDK clients should send requests to. It
+// is the ResourceManagerEndpointURL of the credentials, if any, and the
+// resource manager endpoint of the public Azure cloud otherwise.
+func (c Credentials) BaseURI() string {
+	return baseURI(c.ResourceManagerEndpointURL)
+}
+
+func baseURI(url string) string {
+	if url == "" {
+		url = azure.PublicCloud.ResourceManagerEndpoint
+	}
+	// Azure SDK clients append paths that start with a slash to the base URI.
+	return strings.TrimSuffix(url, "/")
+}
+
+// content returns the content of the credentials of a client that manages
+// resources in the subscription with the supplied ID through the Endpoint.
+func (e *Endpoint) content(subscriptionID string) map[string]string {
+	return map[string]string{
+		CredentialsKeySubscriptionID:             subscriptionID,
+		CredentialsKeyResourceManagerEndpointURL: e.URL,
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewEndpoint(t *testing.T) {
+	url := "https://management.local.azurestack.external/"
+
+	cases := map[string]struct {
+		audience *string
+		want     Endpoint
+	}{
+		"NoAudience": {
+			want: Endpoint{URL: url, Audience: url},
+		},
+		"EmptyAudience": {
+			audience: to.StringPtr(""),
+			want:     Endpoint{URL: url, Audience: url},
+		},
+		"Audience": {
+			audience: to.StringPtr("https://management.adfs.azurestack.local/cool-id"),
+			want:     Endpoint{URL: url, Audience: "https://management.adfs.azurestack.local/cool-id"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := NewEndpoint(url, tc.audience)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("NewEndpoint(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBaseURI(t *testing.T) {
+	cases := map[string]struct {
+		creds map[string]string
+		want  string
+	}{
+		"NoEndpoint": {
+			creds: map[string]string{CredentialsKeySubscriptionID: "cool-sub"},
+			want:  "https://management.azure.com",
+		},
+		"Endpoint": {
+			creds: map[string]string{CredentialsKeyResourceManagerEndpointURL: "https://management.local.azurestack.external/"},
+			want:  "https://management.local.azurestack.external",
+		},
+		"EndpointWithoutTrailingSlash": {
+			creds: map[string]string{CredentialsKeyResourceManagerEndpointURL: "https://management.local.azurestack.external"},
+			want:  "https://management.local.azurestack.external",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := BaseURI(tc.creds); got != tc.want {
+				t.Errorf("BaseURI(...): want %q, got %q", tc.want, got)
+			}
+			c := Credentials{ResourceManagerEndpointURL: tc.creds[CredentialsKeyResourceManagerEndpointURL]}
+			if got := c.BaseURI(); got != tc.want {
+				t.Errorf("c.BaseURI(): want %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
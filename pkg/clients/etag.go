@@ -0,0 +1,84 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/pkg/errors"
+)
+
+// HeaderIfMatch is the header that makes Azure reject a write with
+// http.StatusPreconditionFailed unless the resource still has the given etag.
+const HeaderIfMatch = "If-Match"
+
+const errChangedSinceObserved = "the resource was changed in Azure since it was observed; the update will be retried once it is observed again"
+
+type ifMatchKey struct{}
+
+// WithIfMatch returns a copy of the supplied context that makes requests
+// prepared by WithIfMatchFromContext conditional on the supplied etag. An empty
+// etag makes requests unconditional.
+func WithIfMatch(ctx context.Context, etag string) context.Context {
+	return context.WithValue(ctx, ifMatchKey{}, etag)
+}
+
+// WithIfMatchFromContext returns a PrepareDecorator that sets the
+// HeaderIfMatch header of a request to the etag stored in its context by
+// WithIfMatch, if any. It is meant to be used as the RequestInspector of Azure
+// SDK clients.
+func WithIfMatchFromContext() autorest.PrepareDecorator {
+	return func(p autorest.Preparer) autorest.Preparer {
+		return autorest.PreparerFunc(func(r *http.Request) (*http.Request, error) {
+			r, err := p.Prepare(r)
+			if err != nil {
+				return r, err
+			}
+			if etag, ok := r.Context().Value(ifMatchKey{}).(string); ok && etag != "" {
+				if r.Header == nil {
+					r.Header = make(http.Header)
+				}
+				r.Header.Set(HeaderIfMatch, etag)
+			}
+			return r, nil
+		})
+	}
+}
+
+// IsPreconditionFailed returns true if the supplied error is the response of
+// Azure to a request whose HeaderIfMatch etag no longer matched.
+func IsPreconditionFailed(err error) bool {
+	de, ok := err.(autorest.DetailedError)
+	if !ok {
+		return false
+	}
+	sc, ok := de.StatusCode.(int)
+	return ok && sc == http.StatusPreconditionFailed
+}
+
+// ExplainPreconditionFailed wraps the supplied error with an explanation if
+// IsPreconditionFailed, and returns it unchanged otherwise. Returning the
+// error makes the managed reconciler requeue the resource, so that it is
+// observed again and the update is computed against the new state.
+func ExplainPreconditionFailed(err error) error {
+	if !IsPreconditionFailed(err) {
+		return err
+	}
+	return errors.Wrap(err, errChangedSinceObserved)
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestWithIfMatchFromContext(t *testing.T) {
+	cases := map[string]struct {
+		ctx  context.Context
+		want string
+	}{
+		"NoEtag": {
+			ctx: context.Background(),
+		},
+		"EmptyEtag": {
+			ctx: WithIfMatch(context.Background(), ""),
+		},
+		"Etag": {
+			ctx:  WithIfMatch(context.Background(), `W/"cool-etag"`),
+			want: `W/"cool-etag"`,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r, err := autorest.Prepare((&http.Request{}).WithContext(tc.ctx), WithIfMatchFromContext())
+			if err != nil {
+				t.Fatalf("Prepare(...): %s", err)
+			}
+			if got := r.Header.Get(HeaderIfMatch); got != tc.want {
+				t.Errorf("Header.Get(...): want %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestExplainPreconditionFailed(t *testing.T) {
+	errBoom := errors.New("boom")
+	errFailed := autorest.DetailedError{StatusCode: http.StatusPreconditionFailed}
+	errConflict := autorest.DetailedError{StatusCode: http.StatusConflict}
+
+	cases := map[string]struct {
+		err  error
+		want error
+	}{
+		"NoError": {},
+		"OtherError": {
+			err:  errBoom,
+			want: errBoom,
+		},
+		"OtherStatus": {
+			err:  errConflict,
+			want: errConflict,
+		},
+		"PreconditionFailed": {
+			err:  errFailed,
+			want: errors.Wrap(errFailed, errChangedSinceObserved),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ExplainPreconditionFailed(tc.err)
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors()); diff != "" {
+				t.Errorf("ExplainPreconditionFailed(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	"github.com/crossplane/provider-azure/pkg/clients/eventhub"
+)
+
+var _ eventhub.SchemaGroupsAPI = &MockSchemaGroupsClient{}
+
+// MockSchemaGroupsClient is a fake implementation of
+// eventhub.SchemaGroupsClient.
+type MockSchemaGroupsClient struct {
+	MockGet            func(ctx context.Context, resourceGroupName, namespaceName, name string) (eventhub.SchemaGroup, error)
+	MockCreateOrUpdate func(ctx context.Context, resourceGroupName, namespaceName, name string, g eventhub.SchemaGroup) error
+	MockDelete         func(ctx context.Context, resourceGroupName, namespaceName, name string) error
+}
+
+// Get calls the MockSchemaGroupsClient's MockGet method.
+func (c *MockSchemaGroupsClient) Get(ctx context.Context, resourceGroupName, namespaceName, name string) (eventhub.SchemaGroup, error) {
+	return c.MockGet(ctx, resourceGroupName, namespaceName, name)
+}
+
+// CreateOrUpdate calls the MockSchemaGroupsClient's MockCreateOrUpdate
+// method.
+func (c *MockSchemaGroupsClient) CreateOrUpdate(ctx context.Context, resourceGroupName, namespaceName, name string, g eventhub.SchemaGroup) error {
+	return c.MockCreateOrUpdate(ctx, resourceGroupName, namespaceName, name, g)
+}
+
+// Delete calls the MockSchemaGroupsClient's MockDelete method.
+func (c *MockSchemaGroupsClient) Delete(ctx context.Context, resourceGroupName, namespaceName, name string) error {
+	return c.MockDelete(ctx, resourceGroupName, namespaceName, name)
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package eventhub contains clients of Azure Event Hubs.
+package eventhub
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Azure/go-autorest/autorest"
+
+	"github.com/crossplane/provider-azure/apis/eventhub/v1alpha1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+// SchemaGroupAPIVersion is the version of the Event Hubs API that schema
+// registry groups are managed with.
+const SchemaGroupAPIVersion = "2021-11-01"
+
+const schemaGroupPath = "/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/Microsoft.EventHub/namespaces/{namespaceName}/schemagroups/{schemaGroupName}"
+
+// SchemaGroupProperties are the properties of a schema registry group.
+type SchemaGroupProperties struct {
+	GroupProperties     map[string]*string `json:"groupProperties,omitempty"`
+	SchemaCompatibility *string            `json:"schemaCompatibility,omitempty"`
+	SchemaType          *string            `json:"schemaType,omitempty"`
+	ETag                *string            `json:"eTag,omitempty"`
+	CreatedAtUtc        *string            `json:"createdAtUtc,omitempty"`
+	UpdatedAtUtc        *string            `json:"updatedAtUtc,omitempty"`
+}
+
+// A SchemaGroup is an Event Hubs schema registry group.
+type SchemaGroup struct {
+	ID         *string                `json:"id,omitempty"`
+	Name       *string                `json:"name,omitempty"`
+	Properties *SchemaGroupProperties `json:"properties,omitempty"`
+}
+
+// A SchemaGroupsAPI reads and writes the schema registry groups of Event Hubs
+// namespaces.
+type SchemaGroupsAPI interface {
+	Get(ctx context.Context, resourceGroupName, namespaceName, name string) (SchemaGroup, error)
+	CreateOrUpdate(ctx context.Context, resourceGroupName, namespaceName, name string, g SchemaGroup) error
+	Delete(ctx context.Context, resourceGroupName, namespaceName, name string) error
+}
+
+// A SchemaGroupsClient reads and writes schema registry groups through the
+// Azure Resource Manager API.
+type SchemaGroupsClient struct {
+	azure.ARMClient
+}
+
+// NewSchemaGroupsClientWithBaseURI returns a SchemaGroupsClient for the
+// supplied subscription of the Azure Resource Manager API at the supplied
+// base URI.
+func NewSchemaGroupsClientWithBaseURI(baseURI, subscriptionID string) SchemaGroupsClient {
+	return SchemaGroupsClient{ARMClient: azure.NewARMClientWithBaseURI("eventhub.SchemaGroupsClient", baseURI, subscriptionID, SchemaGroupAPIVersion)}
+}
+
+// Get returns the supplied schema registry group.
+func (c SchemaGroupsClient) Get(ctx context.Context, resourceGroupName, namespaceName, name string) (SchemaGroup, error) {
+	g := SchemaGroup{}
+	err := c.Do(ctx, "Get", autorest.AsGet(), c.path(resourceGroupName, namespaceName, name), nil, &g, http.StatusOK)
+	return g, err
+}
+
+// CreateOrUpdate creates or updates the supplied schema registry group.
+func (c SchemaGroupsClient) CreateOrUpdate(ctx context.Context, resourceGroupName, namespaceName, name string, g SchemaGroup) error {
+	return c.Do(ctx, "CreateOrUpdate", autorest.AsPut(), c.path(resourceGroupName, namespaceName, name), g, nil, http.StatusOK, http.StatusCreated)
+}
+
+// Delete deletes the supplied schema registry group.
+func (c SchemaGroupsClient) Delete(ctx context.Context, resourceGroupName, namespaceName, name string) error {
+	return c.Do(ctx, "Delete", autorest.AsDelete(), c.path(resourceGroupName, namespaceName, name), nil, nil, http.StatusOK, http.StatusNoContent)
+}
+
+func (c SchemaGroupsClient) path(resourceGroupName, namespaceName, name string) autorest.PrepareDecorator {
+	return autorest.WithPathParameters(schemaGroupPath, map[string]interface{}{
+		"subscriptionId":    autorest.Encode("path", c.SubscriptionID),
+		"resourceGroupName": autorest.Encode("path", resourceGroupName),
+		"namespaceName":     autorest.Encode("path", namespaceName),
+		"schemaGroupName":   autorest.Encode("path", name),
+	})
+}
+
+// schemaCompatibility returns the supplied compatibility, or None if it is
+// nil.
+func schemaCompatibility(c *v1alpha1.SchemaCompatibility) *string {
+	if c == nil {
+		return azure.ToStringPtr(string(v1alpha1.SchemaCompatibilityNone))
+	}
+	return azure.ToStringPtr(string(*c))
+}
+
+// schemaType returns the supplied schema type, or Avro if it is nil.
+func schemaType(t *v1alpha1.SchemaType) *string {
+	if t == nil {
+		return azure.ToStringPtr(string(v1alpha1.SchemaTypeAvro))
+	}
+	return azure.ToStringPtr(string(*t))
+}
+
+// NewSchemaGroup returns the schema registry group Azure creates or updates
+// for the supplied SchemaRegistryGroup.
+func NewSchemaGroup(p v1alpha1.SchemaRegistryGroupParameters) SchemaGroup {
+	return SchemaGroup{
+		Properties: &SchemaGroupProperties{
+			GroupProperties:     azure.ToStringPtrMap(p.GroupProperties),
+			SchemaCompatibility: schemaCompatibility(p.SchemaCompatibility),
+			SchemaType:          schemaType(p.SchemaType),
+		},
+	}
+}
+
+// SchemaGroupNeedsUpdate returns true if the supplied parameters differ from
+// the supplied schema registry group.
+func SchemaGroupNeedsUpdate(p v1alpha1.SchemaRegistryGroupParameters, az SchemaGroup) bool {
+	if az.Properties == nil {
+		return true
+	}
+	return *schemaCompatibility(p.SchemaCompatibility) != azure.ToString(az.Properties.SchemaCompatibility) ||
+		azure.TagsNeedUpdate(p.GroupProperties, az.Properties.GroupProperties)
+}
+
+// LateInitializeSchemaGroup fills the empty fields of the supplied parameters
+// with the values of the supplied schema registry group.
+func LateInitializeSchemaGroup(p *v1alpha1.SchemaRegistryGroupParameters, az SchemaGroup) {
+	if az.Properties == nil {
+		return
+	}
+	if p.SchemaCompatibility == nil && az.Properties.SchemaCompatibility != nil {
+		c := v1alpha1.SchemaCompatibility(*az.Properties.SchemaCompatibility)
+		p.SchemaCompatibility = &c
+	}
+	if p.SchemaType == nil && az.Properties.SchemaType != nil {
+		t := v1alpha1.SchemaType(*az.Properties.SchemaType)
+		p.SchemaType = &t
+	}
+}
+
+// GenerateSchemaGroupObservation returns the observation of the supplied
+// schema registry group.
+func GenerateSchemaGroupObservation(az SchemaGroup) v1alpha1.SchemaRegistryGroupObservation {
+	o := v1alpha1.SchemaRegistryGroupObservation{ID: azure.ToString(az.ID)}
+	if az.Properties == nil {
+		return o
+	}
+	o.ETag = azure.ToString(az.Properties.ETag)
+	o.CreatedAtUTC = azure.ToString(az.Properties.CreatedAtUtc)
+	o.UpdatedAtUTC = azure.ToString(az.Properties.UpdatedAtUtc)
+	return o
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventhub
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/provider-azure/apis/eventhub/v1alpha1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+func TestSchemaGroupNeedsUpdate(t *testing.T) {
+	backward := v1alpha1.SchemaCompatibilityBackward
+
+	cases := map[string]struct {
+		reason string
+		p      v1alpha1.SchemaRegistryGroupParameters
+		az     SchemaGroup
+		want   bool
+	}{
+		"UpToDate": {
+			reason: "A group whose compatibility and properties match should not need an update",
+			p:      v1alpha1.SchemaRegistryGroupParameters{GroupProperties: map[string]string{"owner": "data"}},
+			az: SchemaGroup{Properties: &SchemaGroupProperties{
+				SchemaCompatibility: azure.ToStringPtr(string(v1alpha1.SchemaCompatibilityNone)),
+				GroupProperties:     map[string]*string{"owner": azure.ToStringPtr("data")},
+			}},
+			want: false,
+		},
+		"CompatibilityChanged": {
+			reason: "A group whose compatibility differs should need an update",
+			p:      v1alpha1.SchemaRegistryGroupParameters{SchemaCompatibility: &backward},
+			az: SchemaGroup{Properties: &SchemaGroupProperties{
+				SchemaCompatibility: azure.ToStringPtr(string(v1alpha1.SchemaCompatibilityNone)),
+			}},
+			want: true,
+		},
+		"PropertiesChanged": {
+			reason: "A group whose properties differ should need an update",
+			p:      v1alpha1.SchemaRegistryGroupParameters{GroupProperties: map[string]string{"owner": "data"}},
+			az: SchemaGroup{Properties: &SchemaGroupProperties{
+				SchemaCompatibility: azure.ToStringPtr(string(v1alpha1.SchemaCompatibilityNone)),
+			}},
+			want: true,
+		},
+		"NoProperties": {
+			reason: "A group without properties should need an update",
+			p:      v1alpha1.SchemaRegistryGroupParameters{},
+			az:     SchemaGroup{},
+			want:   true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := SchemaGroupNeedsUpdate(tc.p, tc.az)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nSchemaGroupNeedsUpdate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLateInitializeSchemaGroup(t *testing.T) {
+	backward := v1alpha1.SchemaCompatibilityBackward
+	forward := v1alpha1.SchemaCompatibilityForward
+	avro := v1alpha1.SchemaTypeAvro
+
+	cases := map[string]struct {
+		reason string
+		p      v1alpha1.SchemaRegistryGroupParameters
+		az     SchemaGroup
+		want   v1alpha1.SchemaRegistryGroupParameters
+	}{
+		"NoProperties": {
+			reason: "Nothing should be late initialized from a group without properties",
+			az:     SchemaGroup{},
+		},
+		"Empty": {
+			reason: "Empty fields should be late initialized",
+			az: SchemaGroup{Properties: &SchemaGroupProperties{
+				SchemaCompatibility: azure.ToStringPtr(string(backward)),
+				SchemaType:          azure.ToStringPtr(string(avro)),
+			}},
+			want: v1alpha1.SchemaRegistryGroupParameters{SchemaCompatibility: &backward, SchemaType: &avro},
+		},
+		"Set": {
+			reason: "Fields that are set should not be late initialized",
+			p:      v1alpha1.SchemaRegistryGroupParameters{SchemaCompatibility: &forward},
+			az: SchemaGroup{Properties: &SchemaGroupProperties{
+				SchemaCompatibility: azure.ToStringPtr(string(backward)),
+			}},
+			want: v1alpha1.SchemaRegistryGroupParameters{SchemaCompatibility: &forward},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializeSchemaGroup(&tc.p, tc.az)
+			if diff := cmp.Diff(tc.want, tc.p); diff != "" {
+				t.Errorf("\n%s\nLateInitializeSchemaGroup(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
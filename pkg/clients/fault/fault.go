@@ -0,0 +1,227 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fault injects faults into the requests Azure SDK clients send to
+// the Azure Resource Manager API, so that the resilience of the controllers
+// to throttling, server errors, slow operations and eventual consistency can
+// be tested without real Azure outages. It must never be enabled in
+// production.
+package fault
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/tracing"
+	"github.com/pkg/errors"
+)
+
+// Keys of a fault specification.
+const (
+	KeyThrottle      = "throttle"
+	KeyServerError   = "server-error"
+	KeyNotFound      = "not-found"
+	KeySlowOperation = "slow-operation"
+)
+
+// Error strings.
+const (
+	errParseFmt = "cannot parse fault %q; must be of the form key=rate"
+	errKeyFmt   = "unknown fault %q; must be one of throttle, server-error, not-found or slow-operation"
+	errRateFmt  = "rate of fault %q must be between 0 and 1"
+	errTotal    = "rates of all faults must add up to at most 1"
+)
+
+// RetryAfter is the Retry-After header of injected throttling responses.
+const RetryAfter = "1"
+
+// Config of the faults to inject. Each rate is the fraction of eligible
+// requests that fail with the fault.
+type Config struct {
+	// Throttle is the rate of requests that fail with 429 Too Many Requests.
+	Throttle float64
+
+	// ServerError is the rate of requests that fail with 500 Internal Server
+	// Error.
+	ServerError float64
+
+	// NotFound is the rate of GET requests that fail with 404 Not Found, as
+	// when a resource that was just created is read from a replica that has
+	// not seen it yet.
+	NotFound float64
+
+	// SlowOperation is the rate of long-running operation status requests
+	// that report the operation to still be in progress.
+	SlowOperation float64
+}
+
+// Parse a fault specification such as "throttle=0.1,not-found=0.05".
+func Parse(spec string) (Config, error) {
+	c := Config{}
+	rates := map[string]*float64{
+		KeyThrottle:      &c.Throttle,
+		KeyServerError:   &c.ServerError,
+		KeyNotFound:      &c.NotFound,
+		KeySlowOperation: &c.SlowOperation,
+	}
+	total := 0.0
+	for _, f := range strings.Split(spec, ",") {
+		if strings.TrimSpace(f) == "" {
+			continue
+		}
+		kv := strings.SplitN(f, "=", 2)
+		if len(kv) != 2 {
+			return Config{}, errors.Errorf(errParseFmt, f)
+		}
+		k := strings.TrimSpace(kv[0])
+		r, ok := rates[k]
+		if !ok {
+			return Config{}, errors.Errorf(errKeyFmt, k)
+		}
+		v, err := strconv.ParseFloat(strings.TrimSpace(kv[1]), 64)
+		if err != nil {
+			return Config{}, errors.Wrapf(err, errParseFmt, f)
+		}
+		if v < 0 || v > 1 {
+			return Config{}, errors.Errorf(errRateFmt, k)
+		}
+		*r = v
+		total += v
+	}
+	if total > 1 {
+		return Config{}, errors.New(errTotal)
+	}
+	return c, nil
+}
+
+// Enable injects the configured faults into the requests of every Azure SDK
+// client that does not have its own Sender. It registers a go-autorest
+// tracer, whose transport the clients send their requests through.
+func Enable(c Config) {
+	tracing.Register(&tracer{config: c})
+}
+
+type tracer struct {
+	config Config
+}
+
+func (t *tracer) NewTransport(base *http.Transport) http.RoundTripper {
+	return NewTransport(base, t.config)
+}
+
+func (t *tracer) StartSpan(ctx context.Context, _ string) context.Context { return ctx }
+
+func (t *tracer) EndSpan(_ context.Context, _ int, _ error) {}
+
+var (
+	mu  sync.Mutex
+	rnd = rand.New(rand.NewSource(time.Now().UnixNano())) // nolint:gosec
+)
+
+func random() float64 {
+	mu.Lock()
+	defer mu.Unlock()
+	return rnd.Float64()
+}
+
+// A Transport injects faults into the requests it sends to the Azure Resource
+// Manager API, and sends the others through the transport it wraps. Requests
+// to other APIs, e.g. for access tokens, are never faulted.
+type Transport struct {
+	base   http.RoundTripper
+	config Config
+	random func() float64
+}
+
+// NewTransport returns a Transport that injects the supplied faults into the
+// requests it sends through the supplied transport.
+func NewTransport(base http.RoundTripper, c Config) *Transport {
+	return &Transport{base: base, config: c, random: random}
+}
+
+type fault struct {
+	rate     float64
+	eligible bool
+	inject   func(*http.Request) *http.Response
+}
+
+// RoundTrip sends the supplied request, or returns an injected fault.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !strings.Contains(strings.ToLower(req.URL.Path), "/subscriptions/") {
+		return t.base.RoundTrip(req)
+	}
+	get := req.Method == http.MethodGet
+	faults := []fault{
+		{rate: t.config.Throttle, eligible: true, inject: func(r *http.Request) *http.Response {
+			rsp := errorResponse(r, http.StatusTooManyRequests, "TooManyRequests")
+			rsp.Header.Set("Retry-After", RetryAfter)
+			return rsp
+		}},
+		{rate: t.config.ServerError, eligible: true, inject: func(r *http.Request) *http.Response {
+			return errorResponse(r, http.StatusInternalServerError, "InternalServerError")
+		}},
+		{rate: t.config.NotFound, eligible: get && !isOperation(req), inject: func(r *http.Request) *http.Response {
+			return errorResponse(r, http.StatusNotFound, "ResourceNotFound")
+		}},
+		{rate: t.config.SlowOperation, eligible: get && isOperation(req), inject: func(r *http.Request) *http.Response {
+			return response(r, http.StatusOK, `{"status":"InProgress"}`)
+		}},
+	}
+	n := t.random()
+	for _, f := range faults {
+		if !f.eligible {
+			continue
+		}
+		if n < f.rate {
+			return f.inject(req), nil
+		}
+		n -= f.rate
+	}
+	return t.base.RoundTrip(req)
+}
+
+// isOperation returns true if the supplied request polls the status of a
+// long-running operation.
+func isOperation(req *http.Request) bool {
+	p := strings.ToLower(req.URL.Path)
+	return strings.Contains(p, "/operations/") || strings.Contains(p, "/operationresults/") || strings.Contains(p, "asyncoperation")
+}
+
+func errorResponse(req *http.Request, status int, code string) *http.Response {
+	return response(req, status, fmt.Sprintf(`{"error":{"code":%q,"message":"Injected fault."}}`, code))
+}
+
+func response(req *http.Request, status int, body string) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          ioutil.NopCloser(bytes.NewBufferString(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fault
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestParse(t *testing.T) {
+	type want struct {
+		c   Config
+		err error
+	}
+	cases := map[string]struct {
+		spec string
+		want want
+	}{
+		"Empty": {
+			spec: "",
+		},
+		"Valid": {
+			spec: "throttle=0.1, not-found=0.05,slow-operation=0.5",
+			want: want{c: Config{Throttle: 0.1, NotFound: 0.05, SlowOperation: 0.5}},
+		},
+		"UnknownKey": {
+			spec: "outage=1",
+			want: want{err: errors.Errorf(errKeyFmt, "outage")},
+		},
+		"NoRate": {
+			spec: "throttle",
+			want: want{err: errors.Errorf(errParseFmt, "throttle")},
+		},
+		"RateOutOfRange": {
+			spec: "server-error=2",
+			want: want{err: errors.Errorf(errRateFmt, KeyServerError)},
+		},
+		"TotalOutOfRange": {
+			spec: "throttle=0.6,server-error=0.6",
+			want: want{err: errors.New(errTotal)},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c, err := Parse(tc.spec)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Parse(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.c, c); diff != "" {
+				t.Errorf("Parse(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+type roundTripFn func(*http.Request) (*http.Response, error)
+
+func (fn roundTripFn) RoundTrip(r *http.Request) (*http.Response, error) { return fn(r) }
+
+func TestRoundTrip(t *testing.T) {
+	resource := "https://management.azure.com/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Cache/Redis/cool?api-version=2018-03-01"
+	operation := "https://management.azure.com/subscriptions/sub/providers/Microsoft.Cache/locations/westus/asyncOperations/op?api-version=2018-03-01"
+	c := Config{Throttle: 0.1, ServerError: 0.1, NotFound: 0.1, SlowOperation: 0.1}
+
+	cases := map[string]struct {
+		method string
+		url    string
+		random float64
+		want   int
+	}{
+		"NotARMRequest": {
+			method: http.MethodPost,
+			url:    "https://login.microsoftonline.com/tenant/oauth2/token",
+			random: 0,
+			want:   http.StatusTeapot,
+		},
+		"Throttled": {
+			method: http.MethodPut,
+			url:    resource,
+			random: 0.05,
+			want:   http.StatusTooManyRequests,
+		},
+		"ServerError": {
+			method: http.MethodPut,
+			url:    resource,
+			random: 0.15,
+			want:   http.StatusInternalServerError,
+		},
+		"NotFound": {
+			method: http.MethodGet,
+			url:    resource,
+			random: 0.25,
+			want:   http.StatusNotFound,
+		},
+		"NotFoundIsOnlyInjectedIntoGets": {
+			method: http.MethodPut,
+			url:    resource,
+			random: 0.25,
+			want:   http.StatusTeapot,
+		},
+		"SlowOperation": {
+			method: http.MethodGet,
+			url:    operation,
+			random: 0.25,
+			want:   http.StatusOK,
+		},
+		"NoFault": {
+			method: http.MethodGet,
+			url:    resource,
+			random: 0.35,
+			want:   http.StatusTeapot,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			base := roundTripFn(func(r *http.Request) (*http.Response, error) {
+				rec := httptest.NewRecorder()
+				rec.WriteHeader(http.StatusTeapot)
+				return rec.Result(), nil
+			})
+			tr := NewTransport(base, c)
+			tr.random = func() float64 { return tc.random }
+			rsp, err := tr.RoundTrip(httptest.NewRequest(tc.method, tc.url, nil))
+			if err != nil {
+				t.Fatalf("RoundTrip(...): %s", err)
+			}
+			if diff := cmp.Diff(tc.want, rsp.StatusCode); diff != "" {
+				t.Errorf("RoundTrip(...): -want status, +got status:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+// +build fips
+
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fips
+
+// Restrict all TLS connections of the provider to FIPS 140-2 approved
+// settings. This package is only available to Go toolchains with BoringCrypto.
+import _ "crypto/tls/fipsonly"
+
+// Build is true if the provider was built with the fips build tag.
+const Build = true
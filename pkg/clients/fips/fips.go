@@ -0,0 +1,82 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fips restricts the TLS connections of the provider to FIPS 140-2
+// approved protocol versions, cipher suites and curves, as required by users
+// of the Azure US Government cloud. Only a provider built with the fips build
+// tag by a Go toolchain with BoringCrypto, e.g. with make build.fips, uses a
+// FIPS 140-2 validated cryptographic module. Enabling FIPS mode in any other
+// build only restricts the algorithms its TLS connections negotiate.
+package fips
+
+import (
+	"crypto/tls"
+	"net/http"
+	"sync/atomic"
+)
+
+var enabled int32
+
+// Enable FIPS mode. The TLS connections of Azure SDK clients that are created
+// afterwards, and of all clients that use the default HTTP transport, only
+// negotiate FIPS 140-2 approved algorithms.
+func Enable() {
+	atomic.StoreInt32(&enabled, 1)
+	if t, ok := http.DefaultTransport.(*http.Transport); ok {
+		t.TLSClientConfig = Config(t.TLSClientConfig)
+	}
+}
+
+// Enabled returns true if FIPS mode is enabled, either by Enable or because
+// the provider was built with the fips build tag.
+func Enabled() bool {
+	return Build || atomic.LoadInt32(&enabled) == 1
+}
+
+// Config returns a copy of the supplied TLS config, which may be nil, that
+// only negotiates FIPS 140-2 approved protocol versions, cipher suites and
+// curves. TLS 1.3 is not negotiated, because its cipher suites cannot be
+// restricted.
+func Config(c *tls.Config) *tls.Config {
+	if c == nil {
+		c = &tls.Config{}
+	}
+	c = c.Clone()
+	c.MinVersion = tls.VersionTLS12
+	c.MaxVersion = tls.VersionTLS12
+	c.CipherSuites = []uint16{
+		tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+		tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+		tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+		tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+	}
+	c.CurvePreferences = []tls.CurveID{tls.CurveP256, tls.CurveP384}
+	return c
+}
+
+// NewTransport returns a copy of the supplied transport whose TLS connections
+// only negotiate FIPS 140-2 approved algorithms. Transports other than an
+// *http.Transport are returned unchanged, since their TLS connections cannot
+// be configured.
+func NewTransport(rt http.RoundTripper) http.RoundTripper {
+	t, ok := rt.(*http.Transport)
+	if !ok {
+		return rt
+	}
+	t = t.Clone()
+	t.TLSClientConfig = Config(t.TLSClientConfig)
+	return t
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fips
+
+import (
+	"crypto/tls"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestConfig(t *testing.T) {
+	cases := map[string]*tls.Config{
+		"Nil":      nil,
+		"Existing": {ServerName: "cool.example.org", MinVersion: tls.VersionTLS10},
+	}
+
+	for name, in := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := Config(in)
+			if got == in {
+				t.Errorf("Config(...): want a copy of the supplied config")
+			}
+			if got.MinVersion != tls.VersionTLS12 || got.MaxVersion != tls.VersionTLS12 {
+				t.Errorf("Config(...): want TLS 1.2 only, got versions %x-%x", got.MinVersion, got.MaxVersion)
+			}
+			for _, cs := range got.CipherSuites {
+				if n := tls.CipherSuiteName(cs); !strings.Contains(n, "_AES_") || !strings.Contains(n, "_GCM_") {
+					t.Errorf("Config(...): cipher suite %s is not approved", n)
+				}
+			}
+			if in != nil && got.ServerName != in.ServerName {
+				t.Errorf("Config(...): want server name %q, got %q", in.ServerName, got.ServerName)
+			}
+		})
+	}
+}
+
+type roundTripper struct{}
+
+func (roundTripper) RoundTrip(*http.Request) (*http.Response, error) { return nil, nil }
+
+func TestNewTransport(t *testing.T) {
+	base := &http.Transport{}
+	got, ok := NewTransport(base).(*http.Transport)
+	if !ok || got == base {
+		t.Fatalf("NewTransport(...): want a copy of the supplied transport")
+	}
+	if got.TLSClientConfig == nil || got.TLSClientConfig.MinVersion != tls.VersionTLS12 {
+		t.Errorf("NewTransport(...): want a FIPS TLS config, got %+v", got.TLSClientConfig)
+	}
+	if base.TLSClientConfig != nil && base.TLSClientConfig.MinVersion == tls.VersionTLS12 {
+		t.Errorf("NewTransport(...): want the TLS config of the supplied transport unchanged")
+	}
+
+	rt := roundTripper{}
+	if NewTransport(rt) != rt {
+		t.Errorf("NewTransport(...): want other transports unchanged")
+	}
+}
@@ -0,0 +1,23 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package golden tests that the parameters the Azure clients send for a
+// library of sample managed resources do not change unnoticed. Each sample in
+// testdata is converted to the Azure SDK parameters its controller sends, and
+// their JSON is compared to the golden file of the sample. After an intended
+// change, run the tests with the -update flag to rewrite the golden files, and
+// review their diff.
+package golden
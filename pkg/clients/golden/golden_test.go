@@ -0,0 +1,110 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package golden
+
+import (
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"sigs.k8s.io/yaml"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-azure/apis/cache/v1beta1"
+	databasev1alpha3 "github.com/crossplane/provider-azure/apis/database/v1alpha3"
+	networkv1beta1 "github.com/crossplane/provider-azure/apis/network/v1beta1"
+	"github.com/crossplane/provider-azure/apis/v1alpha3"
+	"github.com/crossplane/provider-azure/pkg/clients/database"
+	"github.com/crossplane/provider-azure/pkg/clients/network"
+	"github.com/crossplane/provider-azure/pkg/clients/redis"
+	"github.com/crossplane/provider-azure/pkg/clients/resourcegroup"
+)
+
+var update = flag.Bool("update", false, "Rewrite the golden files with the current parameters.")
+
+func TestParameters(t *testing.T) {
+	cases := map[string]struct {
+		mg     resource.Managed
+		params func(mg resource.Managed) interface{}
+	}{
+		"virtualnetwork": {
+			mg: &networkv1beta1.VirtualNetwork{},
+			params: func(mg resource.Managed) interface{} {
+				return network.NewVirtualNetworkParameters(mg.(*networkv1beta1.VirtualNetwork))
+			},
+		},
+		"subnet": {
+			mg: &networkv1beta1.Subnet{},
+			params: func(mg resource.Managed) interface{} {
+				return network.NewSubnetParameters(mg.(*networkv1beta1.Subnet))
+			},
+		},
+		"redis": {
+			mg: &v1beta1.Redis{},
+			params: func(mg resource.Managed) interface{} {
+				return redis.NewCreateParameters(mg.(*v1beta1.Redis))
+			},
+		},
+		"resourcegroup": {
+			mg: &v1alpha3.ResourceGroup{},
+			params: func(mg resource.Managed) interface{} {
+				return resourcegroup.NewParameters(mg.(*v1alpha3.ResourceGroup))
+			},
+		},
+		"mysqlserverfirewallrule": {
+			mg: &databasev1alpha3.MySQLServerFirewallRule{},
+			params: func(mg resource.Managed) interface{} {
+				return database.NewMySQLFirewallRuleParameters(mg.(*databasev1alpha3.MySQLServerFirewallRule))
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			spec, err := ioutil.ReadFile(filepath.Join("testdata", name+".yaml"))
+			if err != nil {
+				t.Fatalf("cannot read sample: %s", err)
+			}
+			if err := yaml.Unmarshal(spec, tc.mg); err != nil {
+				t.Fatalf("cannot unmarshal sample: %s", err)
+			}
+			got, err := json.MarshalIndent(tc.params(tc.mg), "", "  ")
+			if err != nil {
+				t.Fatalf("cannot marshal parameters: %s", err)
+			}
+			got = append(got, '\n')
+
+			file := filepath.Join("testdata", name+".golden.json")
+			if *update {
+				if err := ioutil.WriteFile(file, got, 0600); err != nil {
+					t.Fatalf("cannot write golden file: %s", err)
+				}
+				return
+			}
+			want, err := ioutil.ReadFile(file)
+			if err != nil {
+				t.Fatalf("cannot read golden file: %s", err)
+			}
+			if diff := cmp.Diff(string(want), string(got)); diff != "" {
+				t.Errorf("%s parameters: -want, +got:\n%s\nRun with -update if the change is intended.", name, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/v7.0/keyvault"
+
+	kv "github.com/crossplane/provider-azure/pkg/clients/keyvault"
+)
+
+var _ kv.SecretsAPI = &MockSecretsClient{}
+
+// MockSecretsClient is a fake implementation of kv.SecretsAPI.
+type MockSecretsClient struct {
+	MockGetSecret    func(ctx context.Context, vaultBaseURL string, secretName string, secretVersion string) (keyvault.SecretBundle, error)
+	MockSetSecret    func(ctx context.Context, vaultBaseURL string, secretName string, parameters keyvault.SecretSetParameters) (keyvault.SecretBundle, error)
+	MockDeleteSecret func(ctx context.Context, vaultBaseURL string, secretName string) (keyvault.DeletedSecretBundle, error)
+}
+
+// GetSecret calls the MockSecretsClient's MockGetSecret method.
+func (c *MockSecretsClient) GetSecret(ctx context.Context, vaultBaseURL string, secretName string, secretVersion string) (keyvault.SecretBundle, error) {
+	return c.MockGetSecret(ctx, vaultBaseURL, secretName, secretVersion)
+}
+
+// SetSecret calls the MockSecretsClient's MockSetSecret method.
+func (c *MockSecretsClient) SetSecret(ctx context.Context, vaultBaseURL string, secretName string, parameters keyvault.SecretSetParameters) (keyvault.SecretBundle, error) {
+	return c.MockSetSecret(ctx, vaultBaseURL, secretName, parameters)
+}
+
+// DeleteSecret calls the MockSecretsClient's MockDeleteSecret method.
+func (c *MockSecretsClient) DeleteSecret(ctx context.Context, vaultBaseURL string, secretName string) (keyvault.DeletedSecretBundle, error) {
+	return c.MockDeleteSecret(ctx, vaultBaseURL, secretName)
+}
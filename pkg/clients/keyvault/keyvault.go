@@ -0,0 +1,128 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package keyvault stores the connection details of managed resources as
+// secrets of an Azure Key Vault.
+package keyvault
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"strings"
+
+	azurekeyvault "github.com/Azure/azure-sdk-for-go/services/keyvault/v7.0/keyvault"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+// DefaultAudience of the access tokens of Key Vaults in the public Azure
+// cloud.
+const DefaultAudience = "https://vault.azure.net"
+
+// ContentType of the Key Vault secrets of connection details.
+const ContentType = "application/json"
+
+// maxNameLength of Key Vault secrets.
+const maxNameLength = 127
+
+// Error strings.
+const (
+	errUnmarshal = "cannot unmarshal connection details"
+)
+
+// invalidName matches the characters Key Vault secret names may not contain.
+var invalidName = regexp.MustCompile(`[^0-9A-Za-z-]`)
+
+// A SecretsAPI reads and writes the secrets of Key Vaults.
+type SecretsAPI interface {
+	GetSecret(ctx context.Context, vaultBaseURL string, secretName string, secretVersion string) (azurekeyvault.SecretBundle, error)
+	SetSecret(ctx context.Context, vaultBaseURL string, secretName string, parameters azurekeyvault.SecretSetParameters) (azurekeyvault.SecretBundle, error)
+	DeleteSecret(ctx context.Context, vaultBaseURL string, secretName string) (azurekeyvault.DeletedSecretBundle, error)
+}
+
+// NewSecretsClient returns a client of the ConnectionSecretKeyVault of the
+// supplied ProviderConfig that authenticates with its credentials.
+func NewSecretsClient(ctx context.Context, c client.Client, pc *v1beta1.ProviderConfig) (SecretsAPI, error) {
+	aud := DefaultAudience
+	if kv := pc.Spec.ConnectionSecretKeyVault; kv != nil && kv.Audience != nil {
+		aud = *kv.Audience
+	}
+
+	// The credentials of the ProviderConfig must acquire access tokens for
+	// the Key Vault rather than for Azure Resource Manager.
+	pc = pc.DeepCopy()
+	if pc.Spec.Endpoint == nil {
+		pc.Spec.Endpoint = &v1beta1.Endpoint{ResourceManagerURL: azure.PublicEndpoint.URL}
+	}
+	pc.Spec.Endpoint.Audience = &aud
+	_, auth, err := azure.GetProviderConfigAuthInfo(ctx, c, pc)
+	if err != nil {
+		return nil, err
+	}
+	cl := azurekeyvault.New()
+	cl.Authorizer = auth
+	return cl, nil
+}
+
+// SecretName returns the name of the Key Vault secret of the connection
+// details of the named managed resource of the supplied kind, e.g.
+// redis-example. Characters Key Vault secret names may not contain are
+// replaced by dashes, and names that are too long are shortened and suffixed
+// with a hash of the full name.
+func SecretName(kind, name string) string {
+	n := invalidName.ReplaceAllString(strings.ToLower(kind)+"-"+name, "-")
+	if len(n) <= maxNameLength {
+		return n
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(n))
+	suffix := fmt.Sprintf("-%08x", h.Sum32())
+	return n[:maxNameLength-len(suffix)] + suffix
+}
+
+// Marshal returns the supplied connection details as the JSON object a Key
+// Vault secret holds.
+func Marshal(c managed.ConnectionDetails) string {
+	m := make(map[string]string, len(c))
+	for k, v := range c {
+		m[k] = string(v)
+	}
+	// A map of strings always marshals.
+	b, _ := json.Marshal(m)
+	return string(b)
+}
+
+// Unmarshal returns the connection details of the supplied JSON object a Key
+// Vault secret holds.
+func Unmarshal(v string) (managed.ConnectionDetails, error) {
+	m := map[string]string{}
+	if err := json.Unmarshal([]byte(v), &m); err != nil {
+		return nil, errors.Wrap(err, errUnmarshal)
+	}
+	c := make(managed.ConnectionDetails, len(m))
+	for k, v := range m {
+		c[k] = []byte(v)
+	}
+	return c, nil
+}
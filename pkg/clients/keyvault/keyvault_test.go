@@ -0,0 +1,83 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyvault
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+)
+
+func TestSecretName(t *testing.T) {
+	long := strings.Repeat("a", 200)
+
+	cases := map[string]struct {
+		kind string
+		name string
+		want string
+	}{
+		"Valid": {
+			kind: "Redis",
+			name: "example",
+			want: "redis-example",
+		},
+		"InvalidCharacters": {
+			kind: "MySQLServer",
+			name: "team-a.db_1",
+			want: "mysqlserver-team-a-db-1",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := SecretName(tc.kind, tc.name)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("SecretName(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+
+	// Names that are too long are shortened, but still unique.
+	a, b := SecretName("Redis", long+"a"), SecretName("Redis", long+"b")
+	if len(a) != maxNameLength || !strings.HasPrefix(a, "redis-aaa") {
+		t.Errorf("SecretName(...): want %d characters starting with redis-aaa, got %q", maxNameLength, a)
+	}
+	if a == b {
+		t.Errorf("SecretName(...): want different names for different managed resources, got %q", a)
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	c := managed.ConnectionDetails{
+		"endpoint": []byte("cool.redis.cache.windows.net"),
+		"password": []byte(`s3cr"t`),
+	}
+	got, err := Unmarshal(Marshal(c))
+	if err != nil {
+		t.Fatalf("Unmarshal(Marshal(...)): %s", err)
+	}
+	if diff := cmp.Diff(c, got); diff != "" {
+		t.Errorf("Unmarshal(Marshal(...)): -want, +got:\n%s", diff)
+	}
+
+	if _, err := Unmarshal("not-json"); err == nil {
+		t.Errorf("Unmarshal(...): want error, got nil")
+	}
+}
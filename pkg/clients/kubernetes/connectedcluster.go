@@ -0,0 +1,150 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package kubernetes contains helpers for Azure Arc-enabled Kubernetes
+// clients.
+package kubernetes
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/hybridkubernetes/mgmt/2020-01-01-preview/hybridkubernetes"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane/provider-azure/apis/kubernetes/v1alpha1"
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+// agentKeyBits is the size of the agent key pairs that are generated, which
+// is the size the Azure CLI generates.
+const agentKeyBits = 4096
+
+// Keys of the connection details of a ConnectedCluster. They are the values
+// of the same name below global of the Azure Arc agents Helm chart, e.g.
+// global.onboardingPrivateKey, that are needed to connect the cluster.
+const (
+	ConnectionKeySubscriptionID       = "subscriptionId"
+	ConnectionKeyTenantID             = "tenantId"
+	ConnectionKeyResourceGroupName    = "resourceGroupName"
+	ConnectionKeyResourceName         = "resourceName"
+	ConnectionKeyLocation             = "location"
+	ConnectionKeyOnboardingPrivateKey = "onboardingPrivateKey"
+)
+
+// Error strings.
+const (
+	errGenerateKey = "cannot generate agent key pair"
+	errMarshalKey  = "cannot marshal agent public key"
+)
+
+// NewAgentKeyPair returns a new key pair for the Azure Arc agents of a
+// connected cluster: the base64 encoded DER of its public key, which Azure
+// expects as the agent public key certificate, and its PEM encoded private
+// key, which the agents are installed with.
+func NewAgentKeyPair() (string, []byte, error) {
+	k, err := rsa.GenerateKey(rand.Reader, agentKeyBits)
+	if err != nil {
+		return "", nil, errors.Wrap(err, errGenerateKey)
+	}
+	pub, err := x509.MarshalPKIXPublicKey(&k.PublicKey)
+	if err != nil {
+		return "", nil, errors.Wrap(err, errMarshalKey)
+	}
+	priv := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(k)})
+	return base64.StdEncoding.EncodeToString(pub), priv, nil
+}
+
+// NewConnectedCluster returns the connected cluster Azure creates for the
+// supplied ConnectedCluster, whose agents use the supplied public key.
+func NewConnectedCluster(p v1alpha1.ConnectedClusterParameters, publicKey string) hybridkubernetes.ConnectedCluster {
+	return hybridkubernetes.ConnectedCluster{
+		Location: azure.ToStringPtr(p.Location),
+		Tags:     azure.ToStringPtrMap(p.Tags),
+		Identity: &hybridkubernetes.ConnectedClusterIdentity{Type: hybridkubernetes.SystemAssigned},
+		ConnectedClusterProperties: &hybridkubernetes.ConnectedClusterProperties{
+			AgentPublicKeyCertificate: azure.ToStringPtr(publicKey),
+			// Azure requires an AAD profile, but only uses it for clusters
+			// whose API servers authenticate with AAD.
+			AadProfile: &hybridkubernetes.ConnectedClusterAADProfile{
+				TenantID:    azure.ToStringPtr(""),
+				ClientAppID: azure.ToStringPtr(""),
+				ServerAppID: azure.ToStringPtr(""),
+			},
+		},
+	}
+}
+
+// NewConnectedClusterPatch returns the update Azure applies to the connected
+// cluster of the supplied ConnectedCluster. Only its tags can be updated.
+func NewConnectedClusterPatch(p v1alpha1.ConnectedClusterParameters) hybridkubernetes.ConnectedClusterPatch {
+	return hybridkubernetes.ConnectedClusterPatch{Tags: azure.ToStringPtrMap(p.Tags)}
+}
+
+// ConnectedClusterNeedsUpdate returns true if the supplied parameters differ
+// from the supplied connected cluster.
+func ConnectedClusterNeedsUpdate(p v1alpha1.ConnectedClusterParameters, az hybridkubernetes.ConnectedCluster) bool {
+	return azure.TagsNeedUpdate(p.Tags, az.Tags)
+}
+
+// LateInitializeConnectedCluster fills the empty fields of the supplied
+// parameters with the values of the supplied connected cluster.
+func LateInitializeConnectedCluster(p *v1alpha1.ConnectedClusterParameters, az hybridkubernetes.ConnectedCluster, tp v1beta1.TagPolicy) {
+	if p.Location == "" {
+		p.Location = azure.ToString(az.Location)
+	}
+	if p.AgentPublicKeyCertificate == nil && az.ConnectedClusterProperties != nil {
+		p.AgentPublicKeyCertificate = az.AgentPublicKeyCertificate
+	}
+	p.Tags = azure.LateInitializeTags(p.Tags, az.Tags, tp)
+}
+
+// GenerateConnectedClusterObservation returns the observation of the
+// supplied connected cluster.
+func GenerateConnectedClusterObservation(az hybridkubernetes.ConnectedCluster) v1alpha1.ConnectedClusterObservation {
+	o := v1alpha1.ConnectedClusterObservation{ID: azure.ToString(az.ID)}
+	if az.Identity != nil {
+		o.IdentityPrincipalID = azure.ToString(az.Identity.PrincipalID)
+	}
+	if az.ConnectedClusterProperties == nil {
+		return o
+	}
+	o.ProvisioningState = string(az.ProvisioningState)
+	o.KubernetesVersion = azure.ToString(az.KubernetesVersion)
+	o.TotalNodeCount = azure.ToInt(az.TotalNodeCount)
+	o.AgentVersion = azure.ToString(az.AgentVersion)
+	return o
+}
+
+// ConnectionDetails returns the values the Azure Arc agents of the supplied
+// ConnectedCluster are installed with to connect its cluster, other than its
+// private key, for the supplied Azure credentials.
+func ConnectionDetails(cr *v1alpha1.ConnectedCluster, creds map[string]string) managed.ConnectionDetails {
+	return managed.ConnectionDetails{
+		ConnectionKeySubscriptionID:    []byte(azure.SubscriptionID(creds, cr.Spec.ForProvider.SubscriptionID)),
+		ConnectionKeyTenantID:          []byte(creds[azure.CredentialsKeyTenantID]),
+		ConnectionKeyResourceGroupName: []byte(cr.Spec.ForProvider.ResourceGroupName),
+		ConnectionKeyResourceName:      []byte(meta.GetExternalName(cr)),
+		ConnectionKeyLocation:          []byte(azure.NormalizeLocation(cr.Spec.ForProvider.Location)),
+	}
+}
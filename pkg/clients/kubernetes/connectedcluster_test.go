@@ -0,0 +1,135 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/hybridkubernetes/mgmt/2020-01-01-preview/hybridkubernetes"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/provider-azure/apis/kubernetes/v1alpha1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+func TestNewAgentKeyPair(t *testing.T) {
+	pub, priv, err := NewAgentKeyPair()
+	if err != nil {
+		t.Fatalf("NewAgentKeyPair(): %s", err)
+	}
+
+	der, err := base64.StdEncoding.DecodeString(pub)
+	if err != nil {
+		t.Fatalf("NewAgentKeyPair(): public key is not base64 encoded: %s", err)
+	}
+	k, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		t.Fatalf("NewAgentKeyPair(): cannot parse public key: %s", err)
+	}
+
+	b, _ := pem.Decode(priv)
+	if b == nil {
+		t.Fatalf("NewAgentKeyPair(): private key is not PEM encoded")
+	}
+	pk, err := x509.ParsePKCS1PrivateKey(b.Bytes)
+	if err != nil {
+		t.Fatalf("NewAgentKeyPair(): cannot parse private key: %s", err)
+	}
+
+	if got, ok := k.(*rsa.PublicKey); !ok || got.N.Cmp(pk.N) != 0 || got.E != pk.E {
+		t.Errorf("NewAgentKeyPair(): public key does not match private key")
+	}
+}
+
+func TestConnectedClusterNeedsUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		p      v1alpha1.ConnectedClusterParameters
+		az     hybridkubernetes.ConnectedCluster
+		want   bool
+	}{
+		"UpToDate": {
+			reason: "A connected cluster with the same tags should not need an update",
+			p:      v1alpha1.ConnectedClusterParameters{Tags: map[string]string{"team": "platform"}},
+			az:     hybridkubernetes.ConnectedCluster{Tags: map[string]*string{"team": azure.ToStringPtr("platform")}},
+			want:   false,
+		},
+		"TagsDiffer": {
+			reason: "A connected cluster with different tags should need an update",
+			p:      v1alpha1.ConnectedClusterParameters{Tags: map[string]string{"team": "data"}},
+			az:     hybridkubernetes.ConnectedCluster{Tags: map[string]*string{"team": azure.ToStringPtr("platform")}},
+			want:   true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ConnectedClusterNeedsUpdate(tc.p, tc.az)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nConnectedClusterNeedsUpdate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGenerateConnectedClusterObservation(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		az     hybridkubernetes.ConnectedCluster
+		want   v1alpha1.ConnectedClusterObservation
+	}{
+		"Empty": {
+			reason: "A connected cluster without properties should only be observed to have its ID",
+			az:     hybridkubernetes.ConnectedCluster{ID: azure.ToStringPtr("id")},
+			want:   v1alpha1.ConnectedClusterObservation{ID: "id"},
+		},
+		"Full": {
+			reason: "All observable fields of a connected cluster should be observed",
+			az: hybridkubernetes.ConnectedCluster{
+				ID:       azure.ToStringPtr("id"),
+				Identity: &hybridkubernetes.ConnectedClusterIdentity{PrincipalID: azure.ToStringPtr("principal")},
+				ConnectedClusterProperties: &hybridkubernetes.ConnectedClusterProperties{
+					ProvisioningState: hybridkubernetes.Succeeded,
+					KubernetesVersion: azure.ToStringPtr("1.19.7"),
+					TotalNodeCount:    azure.ToInt32Ptr(3),
+					AgentVersion:      azure.ToStringPtr("1.0.0"),
+				},
+			},
+			want: v1alpha1.ConnectedClusterObservation{
+				ID:                  "id",
+				ProvisioningState:   "Succeeded",
+				KubernetesVersion:   "1.19.7",
+				TotalNodeCount:      3,
+				AgentVersion:        "1.0.0",
+				IdentityPrincipalID: "principal",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GenerateConnectedClusterObservation(tc.az)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGenerateConnectedClusterObservation(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
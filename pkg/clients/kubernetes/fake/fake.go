@@ -0,0 +1,57 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/hybridkubernetes/mgmt/2020-01-01-preview/hybridkubernetes"
+	"github.com/Azure/azure-sdk-for-go/services/preview/hybridkubernetes/mgmt/2020-01-01-preview/hybridkubernetes/hybridkubernetesapi"
+)
+
+var _ hybridkubernetesapi.ConnectedClusterClientAPI = &MockConnectedClusterClient{}
+
+// MockConnectedClusterClient is a fake implementation of
+// hybridkubernetes.ConnectedClusterClient.
+type MockConnectedClusterClient struct {
+	hybridkubernetesapi.ConnectedClusterClientAPI
+
+	MockCreate func(ctx context.Context, resourceGroupName string, clusterName string, connectedCluster hybridkubernetes.ConnectedCluster) (result hybridkubernetes.ConnectedClusterCreateFuture, err error)
+	MockDelete func(ctx context.Context, resourceGroupName string, clusterName string) (result hybridkubernetes.ConnectedClusterDeleteFuture, err error)
+	MockGet    func(ctx context.Context, resourceGroupName string, clusterName string) (result hybridkubernetes.ConnectedCluster, err error)
+	MockUpdate func(ctx context.Context, resourceGroupName string, clusterName string, connectedClusterPatch hybridkubernetes.ConnectedClusterPatch) (result hybridkubernetes.ConnectedCluster, err error)
+}
+
+// Create calls the MockConnectedClusterClient's MockCreate method.
+func (c *MockConnectedClusterClient) Create(ctx context.Context, resourceGroupName string, clusterName string, connectedCluster hybridkubernetes.ConnectedCluster) (result hybridkubernetes.ConnectedClusterCreateFuture, err error) {
+	return c.MockCreate(ctx, resourceGroupName, clusterName, connectedCluster)
+}
+
+// Delete calls the MockConnectedClusterClient's MockDelete method.
+func (c *MockConnectedClusterClient) Delete(ctx context.Context, resourceGroupName string, clusterName string) (result hybridkubernetes.ConnectedClusterDeleteFuture, err error) {
+	return c.MockDelete(ctx, resourceGroupName, clusterName)
+}
+
+// Get calls the MockConnectedClusterClient's MockGet method.
+func (c *MockConnectedClusterClient) Get(ctx context.Context, resourceGroupName string, clusterName string) (result hybridkubernetes.ConnectedCluster, err error) {
+	return c.MockGet(ctx, resourceGroupName, clusterName)
+}
+
+// Update calls the MockConnectedClusterClient's MockUpdate method.
+func (c *MockConnectedClusterClient) Update(ctx context.Context, resourceGroupName string, clusterName string, connectedClusterPatch hybridkubernetes.ConnectedClusterPatch) (result hybridkubernetes.ConnectedCluster, err error) {
+	return c.MockUpdate(ctx, resourceGroupName, clusterName, connectedClusterPatch)
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	"github.com/crossplane/provider-azure/pkg/clients/kusto"
+)
+
+var _ kusto.ClustersAPI = &MockClustersClient{}
+
+// MockClustersClient is a fake implementation of kusto.ClustersClient.
+type MockClustersClient struct {
+	MockGetCluster             func(ctx context.Context, resourceGroupName, clusterName string) (kusto.Cluster, error)
+	MockCreateOrUpdateCluster  func(ctx context.Context, resourceGroupName, clusterName string, cl kusto.Cluster) error
+	MockDeleteCluster          func(ctx context.Context, resourceGroupName, clusterName string) error
+	MockGetDatabase            func(ctx context.Context, resourceGroupName, clusterName, databaseName string) (kusto.Database, error)
+	MockCreateOrUpdateDatabase func(ctx context.Context, resourceGroupName, clusterName, databaseName string, d kusto.Database) error
+	MockDeleteDatabase         func(ctx context.Context, resourceGroupName, clusterName, databaseName string) error
+	MockGetScript              func(ctx context.Context, resourceGroupName, clusterName, databaseName, scriptName string) (kusto.Script, error)
+	MockCreateOrUpdateScript   func(ctx context.Context, resourceGroupName, clusterName, databaseName, scriptName string, s kusto.Script) error
+	MockDeleteScript           func(ctx context.Context, resourceGroupName, clusterName, databaseName, scriptName string) error
+}
+
+// GetCluster calls the MockClustersClient's MockGetCluster method.
+func (c *MockClustersClient) GetCluster(ctx context.Context, resourceGroupName, clusterName string) (kusto.Cluster, error) {
+	return c.MockGetCluster(ctx, resourceGroupName, clusterName)
+}
+
+// CreateOrUpdateCluster calls the MockClustersClient's MockCreateOrUpdateCluster method.
+func (c *MockClustersClient) CreateOrUpdateCluster(ctx context.Context, resourceGroupName, clusterName string, cl kusto.Cluster) error {
+	return c.MockCreateOrUpdateCluster(ctx, resourceGroupName, clusterName, cl)
+}
+
+// DeleteCluster calls the MockClustersClient's MockDeleteCluster method.
+func (c *MockClustersClient) DeleteCluster(ctx context.Context, resourceGroupName, clusterName string) error {
+	return c.MockDeleteCluster(ctx, resourceGroupName, clusterName)
+}
+
+// GetDatabase calls the MockClustersClient's MockGetDatabase method.
+func (c *MockClustersClient) GetDatabase(ctx context.Context, resourceGroupName, clusterName, databaseName string) (kusto.Database, error) {
+	return c.MockGetDatabase(ctx, resourceGroupName, clusterName, databaseName)
+}
+
+// CreateOrUpdateDatabase calls the MockClustersClient's MockCreateOrUpdateDatabase method.
+func (c *MockClustersClient) CreateOrUpdateDatabase(ctx context.Context, resourceGroupName, clusterName, databaseName string, d kusto.Database) error {
+	return c.MockCreateOrUpdateDatabase(ctx, resourceGroupName, clusterName, databaseName, d)
+}
+
+// DeleteDatabase calls the MockClustersClient's MockDeleteDatabase method.
+func (c *MockClustersClient) DeleteDatabase(ctx context.Context, resourceGroupName, clusterName, databaseName string) error {
+	return c.MockDeleteDatabase(ctx, resourceGroupName, clusterName, databaseName)
+}
+
+// GetScript calls the MockClustersClient's MockGetScript method.
+func (c *MockClustersClient) GetScript(ctx context.Context, resourceGroupName, clusterName, databaseName, scriptName string) (kusto.Script, error) {
+	return c.MockGetScript(ctx, resourceGroupName, clusterName, databaseName, scriptName)
+}
+
+// CreateOrUpdateScript calls the MockClustersClient's MockCreateOrUpdateScript method.
+func (c *MockClustersClient) CreateOrUpdateScript(ctx context.Context, resourceGroupName, clusterName, databaseName, scriptName string, s kusto.Script) error {
+	return c.MockCreateOrUpdateScript(ctx, resourceGroupName, clusterName, databaseName, scriptName, s)
+}
+
+// DeleteScript calls the MockClustersClient's MockDeleteScript method.
+func (c *MockClustersClient) DeleteScript(ctx context.Context, resourceGroupName, clusterName, databaseName, scriptName string) error {
+	return c.MockDeleteScript(ctx, resourceGroupName, clusterName, databaseName, scriptName)
+}
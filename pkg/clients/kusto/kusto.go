@@ -0,0 +1,421 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package kusto contains clients of Azure Data Explorer, also known as Kusto,
+// which manage clusters, their databases and the scripts that define the
+// schema of the databases.
+package kusto
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	"github.com/crossplane/provider-azure/apis/kusto/v1alpha1"
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+// APIVersion is the version of the Azure Data Explorer API that clusters,
+// databases and scripts are managed with.
+const APIVersion = "2022-12-29"
+
+// Provisioning states of clusters, databases and scripts.
+const (
+	ProvisioningStateSucceeded = "Succeeded"
+	ProvisioningStateFailed    = "Failed"
+)
+
+// StateStopped is the state of a cluster that was stopped, e.g. to save
+// costs.
+const StateStopped = "Stopped"
+
+// Keys of the connection details of a KustoCluster.
+const (
+	ConnectionKeyURI              = "uri"
+	ConnectionKeyDataIngestionURI = "dataIngestionUri"
+)
+
+const (
+	clusterPath  = "/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/Microsoft.Kusto/clusters/{clusterName}"
+	databasePath = clusterPath + "/databases/{databaseName}"
+	scriptPath   = databasePath + "/scripts/{scriptName}"
+
+	databaseKindReadWrite = "ReadWrite"
+	autoscaleVersion      = 1
+)
+
+// A ClusterSku is the VM size, tier and number of instances of a cluster.
+type ClusterSku struct {
+	Name     *string `json:"name,omitempty"`
+	Tier     *string `json:"tier,omitempty"`
+	Capacity *int32  `json:"capacity,omitempty"`
+}
+
+// An OptimizedAutoscale scales a cluster automatically.
+type OptimizedAutoscale struct {
+	Version   *int32 `json:"version,omitempty"`
+	IsEnabled *bool  `json:"isEnabled,omitempty"`
+	Minimum   *int32 `json:"minimum,omitempty"`
+	Maximum   *int32 `json:"maximum,omitempty"`
+}
+
+// ClusterProperties are the properties of a cluster.
+type ClusterProperties struct {
+	State                 *string             `json:"state,omitempty"`
+	StateReason           *string             `json:"stateReason,omitempty"`
+	ProvisioningState     *string             `json:"provisioningState,omitempty"`
+	URI                   *string             `json:"uri,omitempty"`
+	DataIngestionURI      *string             `json:"dataIngestionUri,omitempty"`
+	OptimizedAutoscale    *OptimizedAutoscale `json:"optimizedAutoscale,omitempty"`
+	EnableStreamingIngest *bool               `json:"enableStreamingIngest,omitempty"`
+	EnablePurge           *bool               `json:"enablePurge,omitempty"`
+	EnableDiskEncryption  *bool               `json:"enableDiskEncryption,omitempty"`
+	PublicNetworkAccess   *string             `json:"publicNetworkAccess,omitempty"`
+}
+
+// A Cluster is an Azure Data Explorer cluster.
+type Cluster struct {
+	ID         *string            `json:"id,omitempty"`
+	Name       *string            `json:"name,omitempty"`
+	Location   *string            `json:"location,omitempty"`
+	Sku        *ClusterSku        `json:"sku,omitempty"`
+	Zones      *[]string          `json:"zones,omitempty"`
+	Tags       map[string]*string `json:"tags,omitempty"`
+	Properties *ClusterProperties `json:"properties,omitempty"`
+}
+
+// DatabaseProperties are the properties of a read-write database.
+type DatabaseProperties struct {
+	ProvisioningState *string `json:"provisioningState,omitempty"`
+	SoftDeletePeriod  *string `json:"softDeletePeriod,omitempty"`
+	HotCachePeriod    *string `json:"hotCachePeriod,omitempty"`
+}
+
+// A Database is a database of an Azure Data Explorer cluster.
+type Database struct {
+	ID         *string             `json:"id,omitempty"`
+	Name       *string             `json:"name,omitempty"`
+	Kind       *string             `json:"kind,omitempty"`
+	Location   *string             `json:"location,omitempty"`
+	Properties *DatabaseProperties `json:"properties,omitempty"`
+}
+
+// ScriptProperties are the properties of a script. Azure never returns the
+// content of a script.
+type ScriptProperties struct {
+	ScriptContent     *string `json:"scriptContent,omitempty"`
+	ForceUpdateTag    *string `json:"forceUpdateTag,omitempty"`
+	ContinueOnErrors  *bool   `json:"continueOnErrors,omitempty"`
+	ProvisioningState *string `json:"provisioningState,omitempty"`
+}
+
+// A Script is a script of a database of an Azure Data Explorer cluster.
+type Script struct {
+	ID         *string           `json:"id,omitempty"`
+	Name       *string           `json:"name,omitempty"`
+	Properties *ScriptProperties `json:"properties,omitempty"`
+}
+
+// A ClustersAPI reads and writes clusters, their databases and the scripts
+// of their databases.
+type ClustersAPI interface {
+	GetCluster(ctx context.Context, resourceGroupName, clusterName string) (Cluster, error)
+	CreateOrUpdateCluster(ctx context.Context, resourceGroupName, clusterName string, c Cluster) error
+	DeleteCluster(ctx context.Context, resourceGroupName, clusterName string) error
+	GetDatabase(ctx context.Context, resourceGroupName, clusterName, databaseName string) (Database, error)
+	CreateOrUpdateDatabase(ctx context.Context, resourceGroupName, clusterName, databaseName string, d Database) error
+	DeleteDatabase(ctx context.Context, resourceGroupName, clusterName, databaseName string) error
+	GetScript(ctx context.Context, resourceGroupName, clusterName, databaseName, scriptName string) (Script, error)
+	CreateOrUpdateScript(ctx context.Context, resourceGroupName, clusterName, databaseName, scriptName string, s Script) error
+	DeleteScript(ctx context.Context, resourceGroupName, clusterName, databaseName, scriptName string) error
+}
+
+// A ClustersClient reads and writes clusters, databases and scripts through
+// the Azure Resource Manager API.
+type ClustersClient struct {
+	azure.ARMClient
+}
+
+// NewClustersClientWithBaseURI returns a ClustersClient for the supplied
+// subscription of the Azure Resource Manager API at the supplied base URI.
+func NewClustersClientWithBaseURI(baseURI, subscriptionID string) ClustersClient {
+	return ClustersClient{ARMClient: azure.NewARMClientWithBaseURI("kusto.ClustersClient", baseURI, subscriptionID, APIVersion)}
+}
+
+// GetCluster returns the supplied cluster.
+func (c ClustersClient) GetCluster(ctx context.Context, resourceGroupName, clusterName string) (Cluster, error) {
+	cl := Cluster{}
+	err := c.Do(ctx, "GetCluster", autorest.AsGet(), c.path(clusterPath, resourceGroupName, clusterName, "", ""), nil, &cl, http.StatusOK)
+	return cl, err
+}
+
+// CreateOrUpdateCluster starts to create or update the supplied cluster. Its
+// provisioning state reports when it is done.
+func (c ClustersClient) CreateOrUpdateCluster(ctx context.Context, resourceGroupName, clusterName string, cl Cluster) error {
+	return c.Do(ctx, "CreateOrUpdateCluster", autorest.AsPut(), c.path(clusterPath, resourceGroupName, clusterName, "", ""), cl, nil, http.StatusOK, http.StatusCreated)
+}
+
+// DeleteCluster starts to delete the supplied cluster and its databases.
+func (c ClustersClient) DeleteCluster(ctx context.Context, resourceGroupName, clusterName string) error {
+	return c.Do(ctx, "DeleteCluster", autorest.AsDelete(), c.path(clusterPath, resourceGroupName, clusterName, "", ""), nil, nil, http.StatusOK, http.StatusAccepted, http.StatusNoContent)
+}
+
+// GetDatabase returns the supplied database.
+func (c ClustersClient) GetDatabase(ctx context.Context, resourceGroupName, clusterName, databaseName string) (Database, error) {
+	d := Database{}
+	err := c.Do(ctx, "GetDatabase", autorest.AsGet(), c.path(databasePath, resourceGroupName, clusterName, databaseName, ""), nil, &d, http.StatusOK)
+	return d, err
+}
+
+// CreateOrUpdateDatabase starts to create or update the supplied database.
+func (c ClustersClient) CreateOrUpdateDatabase(ctx context.Context, resourceGroupName, clusterName, databaseName string, d Database) error {
+	return c.Do(ctx, "CreateOrUpdateDatabase", autorest.AsPut(), c.path(databasePath, resourceGroupName, clusterName, databaseName, ""), d, nil, http.StatusOK, http.StatusCreated, http.StatusAccepted)
+}
+
+// DeleteDatabase starts to delete the supplied database and its data.
+func (c ClustersClient) DeleteDatabase(ctx context.Context, resourceGroupName, clusterName, databaseName string) error {
+	return c.Do(ctx, "DeleteDatabase", autorest.AsDelete(), c.path(databasePath, resourceGroupName, clusterName, databaseName, ""), nil, nil, http.StatusOK, http.StatusAccepted, http.StatusNoContent)
+}
+
+// GetScript returns the supplied script.
+func (c ClustersClient) GetScript(ctx context.Context, resourceGroupName, clusterName, databaseName, scriptName string) (Script, error) {
+	s := Script{}
+	err := c.Do(ctx, "GetScript", autorest.AsGet(), c.path(scriptPath, resourceGroupName, clusterName, databaseName, scriptName), nil, &s, http.StatusOK)
+	return s, err
+}
+
+// CreateOrUpdateScript starts to create or update the supplied script, which
+// runs its commands if its force update tag changed.
+func (c ClustersClient) CreateOrUpdateScript(ctx context.Context, resourceGroupName, clusterName, databaseName, scriptName string, s Script) error {
+	return c.Do(ctx, "CreateOrUpdateScript", autorest.AsPut(), c.path(scriptPath, resourceGroupName, clusterName, databaseName, scriptName), s, nil, http.StatusOK, http.StatusCreated, http.StatusAccepted)
+}
+
+// DeleteScript starts to delete the supplied script. The commands it ran are
+// not undone.
+func (c ClustersClient) DeleteScript(ctx context.Context, resourceGroupName, clusterName, databaseName, scriptName string) error {
+	return c.Do(ctx, "DeleteScript", autorest.AsDelete(), c.path(scriptPath, resourceGroupName, clusterName, databaseName, scriptName), nil, nil, http.StatusOK, http.StatusAccepted, http.StatusNoContent)
+}
+
+func (c ClustersClient) path(p, resourceGroupName, clusterName, databaseName, scriptName string) autorest.PrepareDecorator {
+	return autorest.WithPathParameters(p, map[string]interface{}{
+		"subscriptionId":    autorest.Encode("path", c.SubscriptionID),
+		"resourceGroupName": autorest.Encode("path", resourceGroupName),
+		"clusterName":       autorest.Encode("path", clusterName),
+		"databaseName":      autorest.Encode("path", databaseName),
+		"scriptName":        autorest.Encode("path", scriptName),
+	})
+}
+
+// NewCluster returns the cluster Azure creates or updates for the supplied
+// KustoCluster.
+func NewCluster(p v1alpha1.KustoClusterParameters) Cluster {
+	c := Cluster{
+		Location: azure.ToStringPtr(p.Location),
+		Sku: &ClusterSku{
+			Name:     azure.ToStringPtr(p.SKU.Name),
+			Tier:     azure.ToStringPtr(p.SKU.Tier),
+			Capacity: p.SKU.Capacity,
+		},
+		Zones: azure.ToStringArrayPtr(p.Zones),
+		Tags:  azure.ToStringPtrMap(p.Tags),
+		Properties: &ClusterProperties{
+			EnableStreamingIngest: p.EnableStreamingIngest,
+			EnablePurge:           p.EnablePurge,
+			EnableDiskEncryption:  p.EnableDiskEncryption,
+			PublicNetworkAccess:   p.PublicNetworkAccess,
+		},
+	}
+	if a := p.OptimizedAutoscale; a != nil {
+		c.Properties.OptimizedAutoscale = &OptimizedAutoscale{
+			Version:   to.Int32Ptr(autoscaleVersion),
+			IsEnabled: to.BoolPtr(a.Enabled),
+			Minimum:   to.Int32Ptr(a.Minimum),
+			Maximum:   to.Int32Ptr(a.Maximum),
+		}
+	}
+	return c
+}
+
+// ClusterNeedsUpdate returns true if the supplied parameters differ from the
+// supplied cluster. The capacity of a cluster that scales automatically is
+// managed by Azure.
+func ClusterNeedsUpdate(p v1alpha1.KustoClusterParameters, az Cluster) bool { // nolint:gocyclo
+	if azure.TagsNeedUpdate(p.Tags, az.Tags) {
+		return true
+	}
+	if az.Sku == nil || az.Properties == nil {
+		return true
+	}
+	if !strings.EqualFold(p.SKU.Name, azure.ToString(az.Sku.Name)) || !strings.EqualFold(p.SKU.Tier, azure.ToString(az.Sku.Tier)) {
+		return true
+	}
+	autoscale := p.OptimizedAutoscale != nil && p.OptimizedAutoscale.Enabled
+	if !autoscale && p.SKU.Capacity != nil && to.Int32(p.SKU.Capacity) != to.Int32(az.Sku.Capacity) {
+		return true
+	}
+	if a := p.OptimizedAutoscale; a != nil {
+		o := az.Properties.OptimizedAutoscale
+		if o == nil || a.Enabled != to.Bool(o.IsEnabled) || a.Minimum != to.Int32(o.Minimum) || a.Maximum != to.Int32(o.Maximum) {
+			return true
+		}
+	}
+	switch {
+	case p.EnableStreamingIngest != nil && *p.EnableStreamingIngest != to.Bool(az.Properties.EnableStreamingIngest):
+		return true
+	case p.EnablePurge != nil && *p.EnablePurge != to.Bool(az.Properties.EnablePurge):
+		return true
+	case p.EnableDiskEncryption != nil && *p.EnableDiskEncryption != to.Bool(az.Properties.EnableDiskEncryption):
+		return true
+	case p.PublicNetworkAccess != nil && !strings.EqualFold(*p.PublicNetworkAccess, azure.ToString(az.Properties.PublicNetworkAccess)):
+		return true
+	}
+	return false
+}
+
+// LateInitializeCluster fills the empty fields of the supplied parameters
+// with the values of the supplied cluster.
+func LateInitializeCluster(p *v1alpha1.KustoClusterParameters, az Cluster, tp v1beta1.TagPolicy) {
+	if p.Location == "" {
+		p.Location = azure.ToString(az.Location)
+	}
+	if len(p.Zones) == 0 && az.Zones != nil {
+		p.Zones = *az.Zones
+	}
+	if az.Sku != nil && p.SKU.Capacity == nil {
+		p.SKU.Capacity = az.Sku.Capacity
+	}
+	if az.Properties != nil {
+		p.EnableStreamingIngest = azure.LateInitializeBoolPtrFromPtr(p.EnableStreamingIngest, az.Properties.EnableStreamingIngest)
+		p.EnablePurge = azure.LateInitializeBoolPtrFromPtr(p.EnablePurge, az.Properties.EnablePurge)
+		p.EnableDiskEncryption = azure.LateInitializeBoolPtrFromPtr(p.EnableDiskEncryption, az.Properties.EnableDiskEncryption)
+		p.PublicNetworkAccess = azure.LateInitializeStringPtrFromPtr(p.PublicNetworkAccess, az.Properties.PublicNetworkAccess)
+	}
+	p.Tags = azure.LateInitializeTags(p.Tags, az.Tags, tp)
+}
+
+// GenerateClusterObservation returns the observation of the supplied
+// cluster.
+func GenerateClusterObservation(az Cluster) v1alpha1.KustoClusterObservation {
+	o := v1alpha1.KustoClusterObservation{ID: azure.ToString(az.ID)}
+	if az.Properties == nil {
+		return o
+	}
+	o.State = azure.ToString(az.Properties.State)
+	o.StateReason = azure.ToString(az.Properties.StateReason)
+	o.ProvisioningState = azure.ToString(az.Properties.ProvisioningState)
+	o.URI = azure.ToString(az.Properties.URI)
+	o.DataIngestionURI = azure.ToString(az.Properties.DataIngestionURI)
+	return o
+}
+
+// NewDatabase returns the database Azure creates or updates for the supplied
+// KustoDatabase.
+func NewDatabase(p v1alpha1.KustoDatabaseParameters) Database {
+	return Database{
+		Kind:     azure.ToStringPtr(databaseKindReadWrite),
+		Location: azure.ToStringPtr(p.Location),
+		Properties: &DatabaseProperties{
+			SoftDeletePeriod: p.SoftDeletePeriod,
+			HotCachePeriod:   p.HotCachePeriod,
+		},
+	}
+}
+
+// DatabaseNeedsUpdate returns true if the supplied parameters differ from the
+// supplied database.
+func DatabaseNeedsUpdate(p v1alpha1.KustoDatabaseParameters, az Database) bool {
+	if az.Properties == nil {
+		return true
+	}
+	if p.SoftDeletePeriod != nil && !strings.EqualFold(*p.SoftDeletePeriod, azure.ToString(az.Properties.SoftDeletePeriod)) {
+		return true
+	}
+	return p.HotCachePeriod != nil && !strings.EqualFold(*p.HotCachePeriod, azure.ToString(az.Properties.HotCachePeriod))
+}
+
+// LateInitializeDatabase fills the empty fields of the supplied parameters
+// with the values of the supplied database.
+func LateInitializeDatabase(p *v1alpha1.KustoDatabaseParameters, az Database) {
+	if p.Location == "" {
+		p.Location = azure.ToString(az.Location)
+	}
+	if az.Properties != nil {
+		p.SoftDeletePeriod = azure.LateInitializeStringPtrFromPtr(p.SoftDeletePeriod, az.Properties.SoftDeletePeriod)
+		p.HotCachePeriod = azure.LateInitializeStringPtrFromPtr(p.HotCachePeriod, az.Properties.HotCachePeriod)
+	}
+}
+
+// GenerateDatabaseObservation returns the observation of the supplied
+// database.
+func GenerateDatabaseObservation(az Database) v1alpha1.KustoDatabaseObservation {
+	o := v1alpha1.KustoDatabaseObservation{ID: azure.ToString(az.ID)}
+	if az.Properties != nil {
+		o.ProvisioningState = azure.ToString(az.Properties.ProvisioningState)
+	}
+	return o
+}
+
+// ForceUpdateTag returns the force update tag of the supplied script, or a
+// hash of its content if it has none, so that the script runs again whenever
+// its content changes.
+func ForceUpdateTag(p v1alpha1.KustoScriptParameters) string {
+	if p.ForceUpdateTag != nil {
+		return *p.ForceUpdateTag
+	}
+	h := sha256.Sum256([]byte(p.Content))
+	return hex.EncodeToString(h[:])
+}
+
+// NewScript returns the script Azure creates or updates for the supplied
+// KustoScript.
+func NewScript(p v1alpha1.KustoScriptParameters) Script {
+	return Script{
+		Properties: &ScriptProperties{
+			ScriptContent:    azure.ToStringPtr(p.Content),
+			ForceUpdateTag:   azure.ToStringPtr(ForceUpdateTag(p)),
+			ContinueOnErrors: p.ContinueOnErrors,
+		},
+	}
+}
+
+// ScriptNeedsUpdate returns true if the supplied parameters differ from the
+// supplied script. Azure does not return the content of scripts, so their
+// force update tags are compared instead.
+func ScriptNeedsUpdate(p v1alpha1.KustoScriptParameters, az Script) bool {
+	if az.Properties == nil {
+		return true
+	}
+	if ForceUpdateTag(p) != azure.ToString(az.Properties.ForceUpdateTag) {
+		return true
+	}
+	return p.ContinueOnErrors != nil && *p.ContinueOnErrors != to.Bool(az.Properties.ContinueOnErrors)
+}
+
+// GenerateScriptObservation returns the observation of the supplied script.
+func GenerateScriptObservation(az Script) v1alpha1.KustoScriptObservation {
+	o := v1alpha1.KustoScriptObservation{ID: azure.ToString(az.ID)}
+	if az.Properties != nil {
+		o.ProvisioningState = azure.ToString(az.Properties.ProvisioningState)
+		o.ForceUpdateTag = azure.ToString(az.Properties.ForceUpdateTag)
+	}
+	return o
+}
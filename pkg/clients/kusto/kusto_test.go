@@ -0,0 +1,157 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kusto
+
+import (
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/provider-azure/apis/kusto/v1alpha1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+func cluster(capacity int32, autoscale bool) Cluster {
+	return Cluster{
+		Sku: &ClusterSku{Name: azure.ToStringPtr("Standard_E8ads_v5"), Tier: azure.ToStringPtr("Standard"), Capacity: &capacity},
+		Properties: &ClusterProperties{
+			OptimizedAutoscale: &OptimizedAutoscale{Version: to.Int32Ptr(1), IsEnabled: &autoscale, Minimum: to.Int32Ptr(2), Maximum: to.Int32Ptr(4)},
+		},
+	}
+}
+
+func TestClusterNeedsUpdate(t *testing.T) {
+	p := func(capacity int32, autoscale bool) v1alpha1.KustoClusterParameters {
+		return v1alpha1.KustoClusterParameters{
+			SKU:                v1alpha1.KustoClusterSKU{Name: "Standard_E8ads_v5", Tier: "Standard", Capacity: &capacity},
+			OptimizedAutoscale: &v1alpha1.OptimizedAutoscale{Enabled: autoscale, Minimum: 2, Maximum: 4},
+		}
+	}
+
+	cases := map[string]struct {
+		reason string
+		p      v1alpha1.KustoClusterParameters
+		az     Cluster
+		want   bool
+	}{
+		"UpToDate": {
+			reason: "A cluster that matches its parameters should not need an update",
+			p:      p(2, false),
+			az:     cluster(2, false),
+			want:   false,
+		},
+		"CapacityChanged": {
+			reason: "A cluster whose capacity differs should need an update",
+			p:      p(2, false),
+			az:     cluster(3, false),
+			want:   true,
+		},
+		"CapacityAutoscaled": {
+			reason: "The capacity of a cluster that scales automatically should be ignored",
+			p:      p(2, true),
+			az:     cluster(3, true),
+			want:   false,
+		},
+		"AutoscaleChanged": {
+			reason: "A cluster whose autoscale differs should need an update",
+			p:      p(2, true),
+			az:     cluster(2, false),
+			want:   true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ClusterNeedsUpdate(tc.p, tc.az)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nClusterNeedsUpdate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDatabaseNeedsUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		p      v1alpha1.KustoDatabaseParameters
+		az     Database
+		want   bool
+	}{
+		"SameHotCachePeriod": {
+			reason: "Periods should be compared regardless of their case",
+			p:      v1alpha1.KustoDatabaseParameters{HotCachePeriod: azure.ToStringPtr("P7D")},
+			az:     Database{Properties: &DatabaseProperties{HotCachePeriod: azure.ToStringPtr("p7d")}},
+			want:   false,
+		},
+		"HotCachePeriodChanged": {
+			reason: "A database whose hot cache period differs should need an update",
+			p:      v1alpha1.KustoDatabaseParameters{HotCachePeriod: azure.ToStringPtr("P31D")},
+			az:     Database{Properties: &DatabaseProperties{HotCachePeriod: azure.ToStringPtr("P7D")}},
+			want:   true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := DatabaseNeedsUpdate(tc.p, tc.az)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nDatabaseNeedsUpdate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestScriptNeedsUpdate(t *testing.T) {
+	content := ".create table Events (Timestamp: datetime, Name: string)"
+	tag := ForceUpdateTag(v1alpha1.KustoScriptParameters{Content: content})
+
+	cases := map[string]struct {
+		reason string
+		p      v1alpha1.KustoScriptParameters
+		az     Script
+		want   bool
+	}{
+		"SameContent": {
+			reason: "A script whose content did not change should not run again",
+			p:      v1alpha1.KustoScriptParameters{Content: content},
+			az:     Script{Properties: &ScriptProperties{ForceUpdateTag: &tag}},
+			want:   false,
+		},
+		"ContentChanged": {
+			reason: "A script whose content changed should run again",
+			p:      v1alpha1.KustoScriptParameters{Content: content + "\n.create table Errors (Timestamp: datetime)"},
+			az:     Script{Properties: &ScriptProperties{ForceUpdateTag: &tag}},
+			want:   true,
+		},
+		"ForceUpdateTag": {
+			reason: "A script should run again when its force update tag changes",
+			p:      v1alpha1.KustoScriptParameters{Content: content, ForceUpdateTag: azure.ToStringPtr("2")},
+			az:     Script{Properties: &ScriptProperties{ForceUpdateTag: azure.ToStringPtr("1")}},
+			want:   true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ScriptNeedsUpdate(tc.p, tc.az)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nScriptNeedsUpdate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Error strings.
+const (
+	errZonesNotSupported = "location %q does not support availability zones"
+	errInvalidZone       = "availability zone %q is not one of 1, 2 or 3"
+	errNoPairedLocation  = "location %q has no paired location for geo-redundancy"
+)
+
+// nonZonalLocations are Azure locations that are known not to support
+// availability zones. Locations gain zones over time, and new locations are
+// usually zonal, so locations are assumed to support zones unless listed here.
+// Azure rejects zones in locations that don't support them in any case.
+// https://docs.microsoft.com/en-us/azure/availability-zones/az-region
+var nonZonalLocations = map[string]bool{
+	"australiacentral2":  true,
+	"australiasoutheast": true,
+	"brazilsoutheast":    true,
+	"canadaeast":         true,
+	"francesouth":        true,
+	"germanynorth":       true,
+	"koreasouth":         true,
+	"northcentralus":     true,
+	"norwaywest":         true,
+	"southafricawest":    true,
+	"southindia":         true,
+	"switzerlandwest":    true,
+	"uaecentral":         true,
+	"ukwest":             true,
+	"usgovarizona":       true,
+	"usgovtexas":         true,
+	"westcentralus":      true,
+	"westindia":          true,
+	"westus":             true,
+}
+
+// pairedLocations maps Azure locations to the location they are paired with
+// for geo-redundancy. Some pairings are not symmetric, e.g. Brazil South.
+// https://docs.microsoft.com/en-us/azure/best-practices-availability-paired-regions
+var pairedLocations = map[string]string{
+	"australiacentral":   "australiacentral2",
+	"australiacentral2":  "australiacentral",
+	"australiaeast":      "australiasoutheast",
+	"australiasoutheast": "australiaeast",
+	"brazilsouth":        "southcentralus",
+	"canadacentral":      "canadaeast",
+	"canadaeast":         "canadacentral",
+	"centralindia":       "southindia",
+	"centralus":          "eastus2",
+	"eastasia":           "southeastasia",
+	"eastus":             "westus",
+	"eastus2":            "centralus",
+	"francecentral":      "francesouth",
+	"francesouth":        "francecentral",
+	"germanynorth":       "germanywestcentral",
+	"germanywestcentral": "germanynorth",
+	"japaneast":          "japanwest",
+	"japanwest":          "japaneast",
+	"koreacentral":       "koreasouth",
+	"koreasouth":         "koreacentral",
+	"northcentralus":     "southcentralus",
+	"northeurope":        "westeurope",
+	"norwayeast":         "norwaywest",
+	"norwaywest":         "norwayeast",
+	"southafricanorth":   "southafricawest",
+	"southafricawest":    "southafricanorth",
+	"southcentralus":     "northcentralus",
+	"southeastasia":      "eastasia",
+	"southindia":         "centralindia",
+	"swedencentral":      "swedensouth",
+	"swedensouth":        "swedencentral",
+	"switzerlandnorth":   "switzerlandwest",
+	"switzerlandwest":    "switzerlandnorth",
+	"uaecentral":         "uaenorth",
+	"uaenorth":           "uaecentral",
+	"uksouth":            "ukwest",
+	"ukwest":             "uksouth",
+	"westcentralus":      "westus2",
+	"westeurope":         "northeurope",
+	"westindia":          "southindia",
+	"westus":             "eastus",
+	"westus2":            "westcentralus",
+	"westus3":            "eastus",
+}
+
+// NormalizeLocation converts the display name of an Azure location, e.g.
+// "West US 2", to its programmatic name, e.g. "westus2".
+func NormalizeLocation(l string) string {
+	return strings.ToLower(strings.ReplaceAll(l, " ", ""))
+}
+
+// SupportsZones returns false if the supplied Azure location is known not to
+// support availability zones.
+func SupportsZones(location string) bool {
+	return !nonZonalLocations[NormalizeLocation(location)]
+}
+
+// ValidateZones returns an error if availability zones are requested in a
+// location that is known not to support them, or if any of the zones is
+// invalid.
+func ValidateZones(location string, zones []string) error {
+	if len(zones) == 0 {
+		return nil
+	}
+	if !SupportsZones(location) {
+		return errors.Errorf(errZonesNotSupported, location)
+	}
+	for _, z := range zones {
+		if z != "1" && z != "2" && z != "3" {
+			return errors.Errorf(errInvalidZone, z)
+		}
+	}
+	return nil
+}
+
+// PairedLocation returns the programmatic name of the location the supplied
+// Azure location is paired with for geo-redundancy, or an empty string if it
+// has none.
+func PairedLocation(location string) string {
+	return pairedLocations[NormalizeLocation(location)]
+}
+
+// ValidateGeoRedundancy returns an error if the supplied Azure location has no
+// paired location to replicate to.
+func ValidateGeoRedundancy(location string) error {
+	if PairedLocation(location) == "" {
+		return errors.Errorf(errNoPairedLocation, location)
+	}
+	return nil
+}
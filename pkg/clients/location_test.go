@@ -0,0 +1,81 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestValidateZones(t *testing.T) {
+	type args struct {
+		location string
+		zones    []string
+	}
+	cases := map[string]struct {
+		args args
+		want error
+	}{
+		"NoZones": {
+			args: args{location: "West Central US"},
+		},
+		"ZonalLocation": {
+			args: args{location: "West US 2", zones: []string{"1", "3"}},
+		},
+		"UnknownLocation": {
+			args: args{location: "polandcentral", zones: []string{"1"}},
+		},
+		"NonZonalLocation": {
+			args: args{location: "West Central US", zones: []string{"1"}},
+			want: errors.Errorf(errZonesNotSupported, "West Central US"),
+		},
+		"InvalidZone": {
+			args: args{location: "westeurope", zones: []string{"4"}},
+			want: errors.Errorf(errInvalidZone, "4"),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateZones(tc.args.location, tc.args.zones)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("ValidateZones(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPairedLocation(t *testing.T) {
+	cases := map[string]struct {
+		location string
+		want     string
+	}{
+		"DisplayName": {location: "West Europe", want: "northeurope"},
+		"Asymmetric":  {location: "brazilsouth", want: "southcentralus"},
+		"Unpaired":    {location: "qatarcentral"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := PairedLocation(tc.location); got != tc.want {
+				t.Errorf("PairedLocation(%q): want %q, got %q", tc.location, tc.want, got)
+			}
+		})
+	}
+}
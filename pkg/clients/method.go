@@ -0,0 +1,224 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+	"github.com/crossplane/provider-azure/pkg/clients/proxy"
+)
+
+// Error strings.
+const (
+	errNoCredentialsMethod    = "none of the credentials methods of the ProviderConfig is available"
+	errIMDSUnreachable        = "cannot reach the Azure instance metadata service"
+	errFederatedTokenNotFound = "federated token file does not exist"
+	errAzureCLINotFound       = "az is not in PATH"
+)
+
+// DefaultCredentialsOrder is the order in which the configured credentials
+// methods of a ProviderConfig that has no CredentialsOrder are tried.
+var DefaultCredentialsOrder = []v1beta1.CredentialsMethod{
+	v1beta1.CredentialsMethodManagedIdentity,
+	v1beta1.CredentialsMethodWorkloadIdentity,
+	v1beta1.CredentialsMethodAzureCLI,
+	v1beta1.CredentialsMethodCredentials,
+}
+
+// A ProbeFn returns an error if the supplied credentials method of the
+// supplied ProviderConfig is not available, e.g. because the Azure instance
+// metadata service cannot be reached.
+type ProbeFn func(ctx context.Context, c client.Client, pc *v1beta1.ProviderConfig) error
+
+// A MethodResolver determines the credentials method of a ProviderConfig.
+type MethodResolver struct {
+	probes map[v1beta1.CredentialsMethod]ProbeFn
+}
+
+// NewMethodResolver returns a MethodResolver that probes credentials methods
+// with the supplied functions. Methods without a probe are always available.
+func NewMethodResolver(probes map[v1beta1.CredentialsMethod]ProbeFn) *MethodResolver {
+	return &MethodResolver{probes: probes}
+}
+
+// DefaultMethodResolver probes the Azure instance metadata service, the
+// federated token file, the Azure CLI and the credentials of ProviderConfigs.
+var DefaultMethodResolver = NewMethodResolver(map[v1beta1.CredentialsMethod]ProbeFn{
+	v1beta1.CredentialsMethodManagedIdentity:  NewIMDSProbe(imdsProbeTimeout, imdsProbeInterval).Probe,
+	v1beta1.CredentialsMethodWorkloadIdentity: ProbeFederatedTokenFile,
+	v1beta1.CredentialsMethodAzureCLI:         ProbeAzureCLI,
+	v1beta1.CredentialsMethodCredentials:      ProbeCredentials,
+})
+
+// Resolve the credentials method of the supplied ProviderConfig. It is the
+// first of its configured methods, in its CredentialsOrder, whose probe
+// succeeds. A method that is the only configured one is not probed, since
+// there is nothing to fall back to and its own error is more telling.
+func (r *MethodResolver) Resolve(ctx context.Context, c client.Client, pc *v1beta1.ProviderConfig) (v1beta1.CredentialsMethod, error) {
+	order := pc.Spec.CredentialsOrder
+	if len(order) == 0 {
+		order = DefaultCredentialsOrder
+	}
+	configured := make([]v1beta1.CredentialsMethod, 0, len(order))
+	for _, m := range order {
+		if Configured(pc, m) {
+			configured = append(configured, m)
+		}
+	}
+	switch len(configured) {
+	case 0:
+		return "", errors.New(errNoCredentialsSecretRef)
+	case 1:
+		return configured[0], nil
+	}
+
+	reasons := make([]string, 0, len(configured))
+	for _, m := range configured {
+		probe, ok := r.probes[m]
+		if !ok {
+			return m, nil
+		}
+		err := probe(ctx, c, pc)
+		if err == nil {
+			return m, nil
+		}
+		reasons = append(reasons, errors.Wrap(err, string(m)).Error())
+	}
+	return "", errors.Wrap(errors.New(strings.Join(reasons, "; ")), errNoCredentialsMethod)
+}
+
+// Configured returns true if the supplied credentials method is configured by
+// the supplied ProviderConfig.
+func Configured(pc *v1beta1.ProviderConfig, m v1beta1.CredentialsMethod) bool {
+	switch m {
+	case v1beta1.CredentialsMethodManagedIdentity:
+		return pc.Spec.UseManagedIdentity != nil
+	case v1beta1.CredentialsMethodWorkloadIdentity:
+		return pc.Spec.UseWorkloadIdentity != nil
+	case v1beta1.CredentialsMethodAzureCLI:
+		return pc.Spec.UseAzureCLI != nil
+	case v1beta1.CredentialsMethodCredentials:
+		s := pc.Spec.Credentials.Source
+		return s != "" && s != xpv1.CredentialsSourceNone
+	}
+	return false
+}
+
+const (
+	imdsProbeTimeout  = 2 * time.Second
+	imdsProbeInterval = 1 * time.Minute
+
+	// imdsProbeURL is the IMDS endpoint that issues managed identity access
+	// tokens. It answers requests without a resource with 400 Bad Request,
+	// which suffices to tell that it is reachable.
+	imdsProbeURL = "http://" + proxy.IMDSHost + "/metadata/identity/oauth2/token?api-version=2018-02-01"
+)
+
+// An IMDSProbe probes whether the Azure instance metadata service, which
+// issues the access tokens of managed identities, can be reached. Its result
+// is cached, since it is not reachable from most hosts outside of Azure and a
+// probe that times out would otherwise delay every reconcile.
+type IMDSProbe struct {
+	client   *http.Client
+	url      string
+	interval time.Duration
+	now      func() time.Time
+
+	mu     sync.Mutex
+	probed time.Time
+	err    error
+}
+
+// NewIMDSProbe returns an IMDSProbe whose requests time out after the supplied
+// duration, and which probes again once its result is older than the supplied
+// interval.
+func NewIMDSProbe(timeout, interval time.Duration) *IMDSProbe {
+	return &IMDSProbe{
+		// The IMDS must be reached directly, never through a proxy.
+		client:   &http.Client{Timeout: timeout, Transport: &http.Transport{}},
+		url:      imdsProbeURL,
+		interval: interval,
+		now:      time.Now,
+	}
+}
+
+// Probe returns an error if the Azure instance metadata service cannot be
+// reached.
+func (p *IMDSProbe) Probe(ctx context.Context, _ client.Client, _ *v1beta1.ProviderConfig) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	if !p.probed.IsZero() && now.Sub(p.probed) < p.interval {
+		return p.err
+	}
+	p.probed, p.err = now, p.probe(ctx)
+	return p.err
+}
+
+func (p *IMDSProbe) probe(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url, nil)
+	if err != nil {
+		return errors.Wrap(err, errIMDSUnreachable)
+	}
+	req.Header.Set("Metadata", "true")
+	rsp, err := p.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, errIMDSUnreachable)
+	}
+	return rsp.Body.Close()
+}
+
+// ProbeFederatedTokenFile returns an error if the federated token file of the
+// workload identity of the supplied ProviderConfig does not exist, e.g.
+// because the Azure workload identity webhook did not project it.
+func ProbeFederatedTokenFile(_ context.Context, _ client.Client, pc *v1beta1.ProviderConfig) error {
+	path := DefaultFederatedTokenFile
+	if f := pc.Spec.UseWorkloadIdentity.TokenFile; f != nil {
+		path = *f
+	}
+	if _, err := os.Stat(path); err != nil {
+		return errors.Wrap(err, errFederatedTokenNotFound)
+	}
+	return nil
+}
+
+// ProbeAzureCLI returns an error if the Azure CLI is not installed.
+func ProbeAzureCLI(_ context.Context, _ client.Client, _ *v1beta1.ProviderConfig) error {
+	_, err := exec.LookPath("az")
+	return errors.Wrap(err, errAzureCLINotFound)
+}
+
+// ProbeCredentials returns an error if the credentials of the supplied
+// ProviderConfig cannot be extracted, e.g. because their secret does not
+// exist.
+func ProbeCredentials(ctx context.Context, c client.Client, pc *v1beta1.ProviderConfig) error {
+	_, err := ExtractCredentials(ctx, pc.Spec.Credentials.Source, c, pc.Spec.Credentials.CommonCredentialSelectors)
+	return err
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+)
+
+func TestMethodResolverResolve(t *testing.T) {
+	errBoom := errors.New("boom")
+	available := func(_ context.Context, _ client.Client, _ *v1beta1.ProviderConfig) error { return nil }
+	unavailable := func(_ context.Context, _ client.Client, _ *v1beta1.ProviderConfig) error { return errBoom }
+
+	all := v1beta1.ProviderConfigSpec{
+		Credentials:         v1beta1.ProviderCredentials{Source: xpv1.CredentialsSourceSecret},
+		UseManagedIdentity:  &v1beta1.ManagedIdentity{},
+		UseWorkloadIdentity: &v1beta1.WorkloadIdentity{},
+	}
+	ordered := all
+	ordered.CredentialsOrder = []v1beta1.CredentialsMethod{v1beta1.CredentialsMethodCredentials, v1beta1.CredentialsMethodManagedIdentity}
+
+	type want struct {
+		m   v1beta1.CredentialsMethod
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		probes map[v1beta1.CredentialsMethod]ProbeFn
+		spec   v1beta1.ProviderConfigSpec
+		want   want
+	}{
+		"NoneConfigured": {
+			reason: "A ProviderConfig without any credentials method should return an error",
+			spec:   v1beta1.ProviderConfigSpec{Credentials: v1beta1.ProviderCredentials{Source: xpv1.CredentialsSourceNone}},
+			want:   want{err: errors.New(errNoCredentialsSecretRef)},
+		},
+		"OnlyOneConfigured": {
+			reason: "The only configured credentials method should be used without probing it",
+			probes: map[v1beta1.CredentialsMethod]ProbeFn{v1beta1.CredentialsMethodManagedIdentity: unavailable},
+			spec:   v1beta1.ProviderConfigSpec{UseManagedIdentity: &v1beta1.ManagedIdentity{}},
+			want:   want{m: v1beta1.CredentialsMethodManagedIdentity},
+		},
+		"DefaultOrder": {
+			reason: "The first available method in the default order should be used",
+			probes: map[v1beta1.CredentialsMethod]ProbeFn{
+				v1beta1.CredentialsMethodManagedIdentity:  available,
+				v1beta1.CredentialsMethodWorkloadIdentity: available,
+				v1beta1.CredentialsMethodCredentials:      available,
+			},
+			spec: all,
+			want: want{m: v1beta1.CredentialsMethodManagedIdentity},
+		},
+		"FallBack": {
+			reason: "A method should be skipped if its probe fails",
+			probes: map[v1beta1.CredentialsMethod]ProbeFn{
+				v1beta1.CredentialsMethodManagedIdentity:  unavailable,
+				v1beta1.CredentialsMethodWorkloadIdentity: available,
+				v1beta1.CredentialsMethodCredentials:      available,
+			},
+			spec: all,
+			want: want{m: v1beta1.CredentialsMethodWorkloadIdentity},
+		},
+		"ExplicitOrder": {
+			reason: "The first available method in the CredentialsOrder should be used",
+			probes: map[v1beta1.CredentialsMethod]ProbeFn{
+				v1beta1.CredentialsMethodManagedIdentity: available,
+				v1beta1.CredentialsMethodCredentials:     available,
+			},
+			spec: ordered,
+			want: want{m: v1beta1.CredentialsMethodCredentials},
+		},
+		"NoneAvailable": {
+			reason: "An error listing why each method is unavailable should be returned if none is available",
+			probes: map[v1beta1.CredentialsMethod]ProbeFn{
+				v1beta1.CredentialsMethodManagedIdentity: unavailable,
+				v1beta1.CredentialsMethodCredentials:     unavailable,
+			},
+			spec: ordered,
+			want: want{err: errors.Wrap(errors.New("Credentials: boom; ManagedIdentity: boom"), errNoCredentialsMethod)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m, err := NewMethodResolver(tc.probes).Resolve(context.Background(), nil, &v1beta1.ProviderConfig{Spec: tc.spec})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nResolve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.m, m); diff != "" {
+				t.Errorf("\n%s\nResolve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestIMDSProbe(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("Metadata") != "true" {
+			t.Errorf("Probe(...): want Metadata header, got %q", r.Header.Get("Metadata"))
+		}
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	now := time.Now()
+	p := NewIMDSProbe(time.Second, time.Minute)
+	p.url = srv.URL
+	p.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if err := p.Probe(context.Background(), nil, nil); err != nil {
+			t.Errorf("Probe(...): %s", err)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("Probe(...): want a cached result, got %d requests", requests)
+	}
+
+	srv.Close()
+	now = now.Add(2 * time.Minute)
+	if err := p.Probe(context.Background(), nil, nil); err == nil {
+		t.Errorf("Probe(...): want an error once the IMDS is unreachable")
+	}
+}
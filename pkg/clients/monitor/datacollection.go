@@ -0,0 +1,523 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package monitor
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/crossplane/provider-azure/apis/monitor/v1alpha1"
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+// DataCollectionAPIVersion is the version of the Azure Monitor API that data
+// collection endpoints, rules and their associations are managed with.
+const DataCollectionAPIVersion = "2022-06-01"
+
+// AssociationNameConfigurationAccessEndpoint is the name every association of
+// a data collection endpoint with a resource must have.
+const AssociationNameConfigurationAccessEndpoint = "configurationAccessEndpoint"
+
+const (
+	dataCollectionEndpointPath        = "/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/Microsoft.Insights/dataCollectionEndpoints/{name}"
+	dataCollectionRulePath            = "/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/Microsoft.Insights/dataCollectionRules/{name}"
+	dataCollectionRuleAssociationPath = "/{resourceUri}/providers/Microsoft.Insights/dataCollectionRuleAssociations/{name}"
+)
+
+// An Endpoint is the address of a data collection endpoint that agents
+// connect to.
+type Endpoint struct {
+	Endpoint *string `json:"endpoint,omitempty"`
+}
+
+// NetworkAcls are the network access rules of a data collection endpoint.
+type NetworkAcls struct {
+	PublicNetworkAccess *string `json:"publicNetworkAccess,omitempty"`
+}
+
+// DataCollectionEndpointProperties are the properties of a data collection
+// endpoint.
+type DataCollectionEndpointProperties struct {
+	Description         *string      `json:"description,omitempty"`
+	ConfigurationAccess *Endpoint    `json:"configurationAccess,omitempty"`
+	LogsIngestion       *Endpoint    `json:"logsIngestion,omitempty"`
+	MetricsIngestion    *Endpoint    `json:"metricsIngestion,omitempty"`
+	NetworkAcls         *NetworkAcls `json:"networkAcls,omitempty"`
+	ProvisioningState   *string      `json:"provisioningState,omitempty"`
+}
+
+// A DataCollectionEndpoint is an Azure Monitor data collection endpoint.
+type DataCollectionEndpoint struct {
+	ID         *string                           `json:"id,omitempty"`
+	Name       *string                           `json:"name,omitempty"`
+	Kind       *string                           `json:"kind,omitempty"`
+	Location   *string                           `json:"location,omitempty"`
+	Tags       map[string]*string                `json:"tags,omitempty"`
+	Properties *DataCollectionEndpointProperties `json:"properties,omitempty"`
+}
+
+// A PrometheusForwarderDataSource forwards the metrics scraped by the managed
+// service for Prometheus.
+type PrometheusForwarderDataSource struct {
+	Name               *string           `json:"name,omitempty"`
+	Streams            []string          `json:"streams,omitempty"`
+	LabelIncludeFilter map[string]string `json:"labelIncludeFilter,omitempty"`
+}
+
+// A PerformanceCounterDataSource collects the performance counters of the
+// machines the Azure Monitor agent runs on.
+type PerformanceCounterDataSource struct {
+	Name                       *string  `json:"name,omitempty"`
+	Streams                    []string `json:"streams,omitempty"`
+	SamplingFrequencyInSeconds *int32   `json:"samplingFrequencyInSeconds,omitempty"`
+	CounterSpecifiers          []string `json:"counterSpecifiers,omitempty"`
+}
+
+// A SyslogDataSource collects the syslog events of Linux machines.
+type SyslogDataSource struct {
+	Name          *string  `json:"name,omitempty"`
+	Streams       []string `json:"streams,omitempty"`
+	FacilityNames []string `json:"facilityNames,omitempty"`
+	LogLevels     []string `json:"logLevels,omitempty"`
+}
+
+// A WindowsEventLogDataSource collects the Windows event logs of Windows
+// machines.
+type WindowsEventLogDataSource struct {
+	Name         *string  `json:"name,omitempty"`
+	Streams      []string `json:"streams,omitempty"`
+	XPathQueries []string `json:"xPathQueries,omitempty"`
+}
+
+// DataSources are the sources of a data collection rule.
+type DataSources struct {
+	PrometheusForwarder []PrometheusForwarderDataSource `json:"prometheusForwarder,omitempty"`
+	PerformanceCounters []PerformanceCounterDataSource  `json:"performanceCounters,omitempty"`
+	Syslog              []SyslogDataSource              `json:"syslog,omitempty"`
+	WindowsEventLogs    []WindowsEventLogDataSource     `json:"windowsEventLogs,omitempty"`
+}
+
+// A MonitoringAccountDestination is an Azure Monitor workspace a data
+// collection rule sends metrics to.
+type MonitoringAccountDestination struct {
+	Name              *string `json:"name,omitempty"`
+	AccountResourceID *string `json:"accountResourceId,omitempty"`
+}
+
+// A LogAnalyticsDestination is a Log Analytics workspace a data collection
+// rule sends data to.
+type LogAnalyticsDestination struct {
+	Name                *string `json:"name,omitempty"`
+	WorkspaceResourceID *string `json:"workspaceResourceId,omitempty"`
+}
+
+// Destinations are the destinations of a data collection rule.
+type Destinations struct {
+	MonitoringAccounts []MonitoringAccountDestination `json:"monitoringAccounts,omitempty"`
+	LogAnalytics       []LogAnalyticsDestination      `json:"logAnalytics,omitempty"`
+}
+
+// A DataFlow sends the data of some streams to some destinations.
+type DataFlow struct {
+	Streams      []string `json:"streams,omitempty"`
+	Destinations []string `json:"destinations,omitempty"`
+}
+
+// DataCollectionRuleProperties are the properties of a data collection rule.
+type DataCollectionRuleProperties struct {
+	Description              *string       `json:"description,omitempty"`
+	ImmutableID              *string       `json:"immutableId,omitempty"`
+	DataCollectionEndpointID *string       `json:"dataCollectionEndpointId,omitempty"`
+	DataSources              *DataSources  `json:"dataSources,omitempty"`
+	Destinations             *Destinations `json:"destinations,omitempty"`
+	DataFlows                []DataFlow    `json:"dataFlows,omitempty"`
+	ProvisioningState        *string       `json:"provisioningState,omitempty"`
+}
+
+// A DataCollectionRule is an Azure Monitor data collection rule.
+type DataCollectionRule struct {
+	ID         *string                       `json:"id,omitempty"`
+	Name       *string                       `json:"name,omitempty"`
+	Kind       *string                       `json:"kind,omitempty"`
+	Location   *string                       `json:"location,omitempty"`
+	Tags       map[string]*string            `json:"tags,omitempty"`
+	Properties *DataCollectionRuleProperties `json:"properties,omitempty"`
+}
+
+// DataCollectionRuleAssociationProperties are the properties of the
+// association of a data collection rule or endpoint with a resource.
+type DataCollectionRuleAssociationProperties struct {
+	Description              *string `json:"description,omitempty"`
+	DataCollectionRuleID     *string `json:"dataCollectionRuleId,omitempty"`
+	DataCollectionEndpointID *string `json:"dataCollectionEndpointId,omitempty"`
+	ProvisioningState        *string `json:"provisioningState,omitempty"`
+}
+
+// A DataCollectionRuleAssociation associates a data collection rule or
+// endpoint with a resource.
+type DataCollectionRuleAssociation struct {
+	ID         *string                                  `json:"id,omitempty"`
+	Name       *string                                  `json:"name,omitempty"`
+	Properties *DataCollectionRuleAssociationProperties `json:"properties,omitempty"`
+}
+
+// A DataCollectionAPI reads and writes Azure Monitor data collection
+// endpoints, rules and their associations.
+type DataCollectionAPI interface {
+	GetEndpoint(ctx context.Context, resourceGroupName, name string) (DataCollectionEndpoint, error)
+	CreateOrUpdateEndpoint(ctx context.Context, resourceGroupName, name string, e DataCollectionEndpoint) error
+	DeleteEndpoint(ctx context.Context, resourceGroupName, name string) error
+	GetRule(ctx context.Context, resourceGroupName, name string) (DataCollectionRule, error)
+	CreateOrUpdateRule(ctx context.Context, resourceGroupName, name string, r DataCollectionRule) error
+	DeleteRule(ctx context.Context, resourceGroupName, name string) error
+	GetAssociation(ctx context.Context, resourceURI, name string) (DataCollectionRuleAssociation, error)
+	CreateOrUpdateAssociation(ctx context.Context, resourceURI, name string, a DataCollectionRuleAssociation) error
+	DeleteAssociation(ctx context.Context, resourceURI, name string) error
+}
+
+// A DataCollectionClient reads and writes Azure Monitor data collection
+// endpoints, rules and their associations through the Azure Resource Manager
+// API.
+type DataCollectionClient struct {
+	azure.ARMClient
+}
+
+// NewDataCollectionClientWithBaseURI returns a DataCollectionClient for the
+// supplied subscription of the Azure Resource Manager API at the supplied base
+// URI.
+func NewDataCollectionClientWithBaseURI(baseURI, subscriptionID string) DataCollectionClient {
+	return DataCollectionClient{ARMClient: azure.NewARMClientWithBaseURI("monitor.DataCollectionClient", baseURI, subscriptionID, DataCollectionAPIVersion)}
+}
+
+// GetEndpoint returns the supplied data collection endpoint.
+func (c DataCollectionClient) GetEndpoint(ctx context.Context, resourceGroupName, name string) (DataCollectionEndpoint, error) {
+	e := DataCollectionEndpoint{}
+	err := c.Do(ctx, "GetEndpoint", autorest.AsGet(), c.path(dataCollectionEndpointPath, resourceGroupName, name), nil, &e, http.StatusOK)
+	return e, err
+}
+
+// CreateOrUpdateEndpoint creates or updates the supplied data collection
+// endpoint.
+func (c DataCollectionClient) CreateOrUpdateEndpoint(ctx context.Context, resourceGroupName, name string, e DataCollectionEndpoint) error {
+	return c.Do(ctx, "CreateOrUpdateEndpoint", autorest.AsPut(), c.path(dataCollectionEndpointPath, resourceGroupName, name), e, nil, http.StatusOK, http.StatusCreated)
+}
+
+// DeleteEndpoint deletes the supplied data collection endpoint.
+func (c DataCollectionClient) DeleteEndpoint(ctx context.Context, resourceGroupName, name string) error {
+	return c.Do(ctx, "DeleteEndpoint", autorest.AsDelete(), c.path(dataCollectionEndpointPath, resourceGroupName, name), nil, nil, http.StatusOK, http.StatusNoContent)
+}
+
+// GetRule returns the supplied data collection rule.
+func (c DataCollectionClient) GetRule(ctx context.Context, resourceGroupName, name string) (DataCollectionRule, error) {
+	r := DataCollectionRule{}
+	err := c.Do(ctx, "GetRule", autorest.AsGet(), c.path(dataCollectionRulePath, resourceGroupName, name), nil, &r, http.StatusOK)
+	return r, err
+}
+
+// CreateOrUpdateRule creates or updates the supplied data collection rule.
+func (c DataCollectionClient) CreateOrUpdateRule(ctx context.Context, resourceGroupName, name string, r DataCollectionRule) error {
+	return c.Do(ctx, "CreateOrUpdateRule", autorest.AsPut(), c.path(dataCollectionRulePath, resourceGroupName, name), r, nil, http.StatusOK, http.StatusCreated)
+}
+
+// DeleteRule deletes the supplied data collection rule.
+func (c DataCollectionClient) DeleteRule(ctx context.Context, resourceGroupName, name string) error {
+	return c.Do(ctx, "DeleteRule", autorest.AsDelete(), c.path(dataCollectionRulePath, resourceGroupName, name), nil, nil, http.StatusOK, http.StatusNoContent)
+}
+
+// GetAssociation returns the supplied association of the resource with the
+// supplied ID.
+func (c DataCollectionClient) GetAssociation(ctx context.Context, resourceURI, name string) (DataCollectionRuleAssociation, error) {
+	a := DataCollectionRuleAssociation{}
+	err := c.Do(ctx, "GetAssociation", autorest.AsGet(), associationPath(resourceURI, name), nil, &a, http.StatusOK)
+	return a, err
+}
+
+// CreateOrUpdateAssociation creates or updates the supplied association of
+// the resource with the supplied ID.
+func (c DataCollectionClient) CreateOrUpdateAssociation(ctx context.Context, resourceURI, name string, a DataCollectionRuleAssociation) error {
+	return c.Do(ctx, "CreateOrUpdateAssociation", autorest.AsPut(), associationPath(resourceURI, name), a, nil, http.StatusOK, http.StatusCreated)
+}
+
+// DeleteAssociation deletes the supplied association of the resource with
+// the supplied ID.
+func (c DataCollectionClient) DeleteAssociation(ctx context.Context, resourceURI, name string) error {
+	return c.Do(ctx, "DeleteAssociation", autorest.AsDelete(), associationPath(resourceURI, name), nil, nil, http.StatusOK, http.StatusNoContent)
+}
+
+func (c DataCollectionClient) path(path, resourceGroupName, name string) autorest.PrepareDecorator {
+	return autorest.WithPathParameters(path, map[string]interface{}{
+		"subscriptionId":    autorest.Encode("path", c.SubscriptionID),
+		"resourceGroupName": autorest.Encode("path", resourceGroupName),
+		"name":              autorest.Encode("path", name),
+	})
+}
+
+// associationPath returns the path of an association. The ID of the
+// associated resource is not encoded, since it is a path itself.
+func associationPath(resourceURI, name string) autorest.PrepareDecorator {
+	return autorest.WithPathParameters(dataCollectionRuleAssociationPath, map[string]interface{}{
+		"resourceUri": strings.TrimPrefix(resourceURI, "/"),
+		"name":        autorest.Encode("path", name),
+	})
+}
+
+func kind(k *v1alpha1.DataCollectionKind) *string {
+	if k == nil {
+		return nil
+	}
+	return azure.ToStringPtr(string(*k))
+}
+
+// NewDataCollectionEndpoint returns the data collection endpoint Azure
+// creates or updates for the supplied DataCollectionEndpoint.
+func NewDataCollectionEndpoint(p v1alpha1.DataCollectionEndpointParameters) DataCollectionEndpoint {
+	return DataCollectionEndpoint{
+		Kind:     kind(p.Kind),
+		Location: azure.ToStringPtr(p.Location),
+		Tags:     azure.ToStringPtrMap(p.Tags),
+		Properties: &DataCollectionEndpointProperties{
+			Description: p.Description,
+			NetworkAcls: &NetworkAcls{PublicNetworkAccess: publicNetworkAccess(p.PublicNetworkAccess)},
+		},
+	}
+}
+
+// DataCollectionEndpointNeedsUpdate returns true if the supplied parameters
+// differ from the supplied data collection endpoint.
+func DataCollectionEndpointNeedsUpdate(p v1alpha1.DataCollectionEndpointParameters, az DataCollectionEndpoint) bool {
+	if azure.TagsNeedUpdate(p.Tags, az.Tags) {
+		return true
+	}
+	if az.Properties == nil || az.Properties.NetworkAcls == nil {
+		return true
+	}
+	return azure.ToString(p.Description) != azure.ToString(az.Properties.Description) ||
+		*publicNetworkAccess(p.PublicNetworkAccess) != azure.ToString(az.Properties.NetworkAcls.PublicNetworkAccess)
+}
+
+// LateInitializeDataCollectionEndpoint fills the empty fields of the supplied
+// parameters with the values of the supplied data collection endpoint.
+func LateInitializeDataCollectionEndpoint(p *v1alpha1.DataCollectionEndpointParameters, az DataCollectionEndpoint, tp v1beta1.TagPolicy) {
+	if p.Kind == nil && az.Kind != nil {
+		k := v1alpha1.DataCollectionKind(*az.Kind)
+		p.Kind = &k
+	}
+	if az.Properties != nil {
+		p.Description = azure.LateInitializeStringPtrFromPtr(p.Description, az.Properties.Description)
+		if p.PublicNetworkAccess == nil && az.Properties.NetworkAcls != nil && az.Properties.NetworkAcls.PublicNetworkAccess != nil {
+			a := v1alpha1.PublicNetworkAccess(*az.Properties.NetworkAcls.PublicNetworkAccess)
+			p.PublicNetworkAccess = &a
+		}
+	}
+	p.Tags = azure.LateInitializeTags(p.Tags, az.Tags, tp)
+}
+
+func endpoint(e *Endpoint) string {
+	if e == nil {
+		return ""
+	}
+	return azure.ToString(e.Endpoint)
+}
+
+// GenerateDataCollectionEndpointObservation returns the observation of the
+// supplied data collection endpoint.
+func GenerateDataCollectionEndpointObservation(az DataCollectionEndpoint) v1alpha1.DataCollectionEndpointObservation {
+	o := v1alpha1.DataCollectionEndpointObservation{ID: azure.ToString(az.ID)}
+	if az.Properties == nil {
+		return o
+	}
+	o.ProvisioningState = azure.ToString(az.Properties.ProvisioningState)
+	o.ConfigurationAccessEndpoint = endpoint(az.Properties.ConfigurationAccess)
+	o.LogsIngestionEndpoint = endpoint(az.Properties.LogsIngestion)
+	o.MetricsIngestionEndpoint = endpoint(az.Properties.MetricsIngestion)
+	return o
+}
+
+// NewDataCollectionRule returns the data collection rule Azure creates or
+// updates for the supplied DataCollectionRule.
+func NewDataCollectionRule(p v1alpha1.DataCollectionRuleParameters) DataCollectionRule {
+	r := DataCollectionRule{
+		Kind:     kind(p.Kind),
+		Location: azure.ToStringPtr(p.Location),
+		Tags:     azure.ToStringPtrMap(p.Tags),
+		Properties: &DataCollectionRuleProperties{
+			Description:              p.Description,
+			DataCollectionEndpointID: p.DataCollectionEndpointID,
+			Destinations:             &Destinations{},
+		},
+	}
+	if p.DataSources != nil {
+		r.Properties.DataSources = &DataSources{}
+		for _, s := range p.DataSources.PrometheusForwarder {
+			r.Properties.DataSources.PrometheusForwarder = append(r.Properties.DataSources.PrometheusForwarder, PrometheusForwarderDataSource{
+				Name:               azure.ToStringPtr(s.Name),
+				Streams:            s.Streams,
+				LabelIncludeFilter: s.LabelIncludeFilter,
+			})
+		}
+		for _, s := range p.DataSources.PerformanceCounters {
+			r.Properties.DataSources.PerformanceCounters = append(r.Properties.DataSources.PerformanceCounters, PerformanceCounterDataSource{
+				Name:                       azure.ToStringPtr(s.Name),
+				Streams:                    s.Streams,
+				SamplingFrequencyInSeconds: azure.ToInt32Ptr(int(s.SamplingFrequencyInSeconds)),
+				CounterSpecifiers:          s.CounterSpecifiers,
+			})
+		}
+		for _, s := range p.DataSources.Syslog {
+			r.Properties.DataSources.Syslog = append(r.Properties.DataSources.Syslog, SyslogDataSource{
+				Name:          azure.ToStringPtr(s.Name),
+				Streams:       s.Streams,
+				FacilityNames: s.FacilityNames,
+				LogLevels:     s.LogLevels,
+			})
+		}
+		for _, s := range p.DataSources.WindowsEventLogs {
+			r.Properties.DataSources.WindowsEventLogs = append(r.Properties.DataSources.WindowsEventLogs, WindowsEventLogDataSource{
+				Name:         azure.ToStringPtr(s.Name),
+				Streams:      s.Streams,
+				XPathQueries: s.XPathQueries,
+			})
+		}
+	}
+	for _, d := range p.Destinations.MonitoringAccounts {
+		r.Properties.Destinations.MonitoringAccounts = append(r.Properties.Destinations.MonitoringAccounts, MonitoringAccountDestination{
+			Name:              azure.ToStringPtr(d.Name),
+			AccountResourceID: azure.ToStringPtr(d.AccountResourceID),
+		})
+	}
+	for _, d := range p.Destinations.LogAnalytics {
+		r.Properties.Destinations.LogAnalytics = append(r.Properties.Destinations.LogAnalytics, LogAnalyticsDestination{
+			Name:                azure.ToStringPtr(d.Name),
+			WorkspaceResourceID: azure.ToStringPtr(d.WorkspaceResourceID),
+		})
+	}
+	for _, f := range p.DataFlows {
+		r.Properties.DataFlows = append(r.Properties.DataFlows, DataFlow{Streams: f.Streams, Destinations: f.Destinations})
+	}
+	return r
+}
+
+// DataCollectionRuleNeedsUpdate returns true if the supplied parameters
+// differ from the supplied data collection rule. Resource IDs are compared
+// case insensitively, since Azure may return them in a different case.
+func DataCollectionRuleNeedsUpdate(p v1alpha1.DataCollectionRuleParameters, az DataCollectionRule) bool {
+	if azure.TagsNeedUpdate(p.Tags, az.Tags) {
+		return true
+	}
+	if az.Properties == nil {
+		return true
+	}
+	want := NewDataCollectionRule(p).Properties
+	if azure.ToString(want.Description) != azure.ToString(az.Properties.Description) ||
+		!strings.EqualFold(azure.ToString(want.DataCollectionEndpointID), azure.ToString(az.Properties.DataCollectionEndpointID)) {
+		return true
+	}
+	opts := []cmp.Option{
+		cmpopts.EquateEmpty(),
+		cmp.Comparer(func(a, b MonitoringAccountDestination) bool {
+			return azure.ToString(a.Name) == azure.ToString(b.Name) &&
+				strings.EqualFold(azure.ToString(a.AccountResourceID), azure.ToString(b.AccountResourceID))
+		}),
+		cmp.Comparer(func(a, b LogAnalyticsDestination) bool {
+			return azure.ToString(a.Name) == azure.ToString(b.Name) &&
+				strings.EqualFold(azure.ToString(a.WorkspaceResourceID), azure.ToString(b.WorkspaceResourceID))
+		}),
+	}
+	return !cmp.Equal(dataSources(want.DataSources), dataSources(az.Properties.DataSources), opts...) ||
+		!cmp.Equal(destinations(want.Destinations), destinations(az.Properties.Destinations), opts...) ||
+		!cmp.Equal(want.DataFlows, az.Properties.DataFlows, opts...)
+}
+
+// dataSources returns the supplied data sources, or none if they are nil.
+func dataSources(s *DataSources) DataSources {
+	if s == nil {
+		return DataSources{}
+	}
+	return *s
+}
+
+// destinations returns the supplied destinations, or none if they are nil.
+func destinations(d *Destinations) Destinations {
+	if d == nil {
+		return Destinations{}
+	}
+	return *d
+}
+
+// LateInitializeDataCollectionRule fills the empty fields of the supplied
+// parameters with the values of the supplied data collection rule.
+func LateInitializeDataCollectionRule(p *v1alpha1.DataCollectionRuleParameters, az DataCollectionRule, tp v1beta1.TagPolicy) {
+	if p.Kind == nil && az.Kind != nil {
+		k := v1alpha1.DataCollectionKind(*az.Kind)
+		p.Kind = &k
+	}
+	if az.Properties != nil {
+		p.Description = azure.LateInitializeStringPtrFromPtr(p.Description, az.Properties.Description)
+	}
+	p.Tags = azure.LateInitializeTags(p.Tags, az.Tags, tp)
+}
+
+// GenerateDataCollectionRuleObservation returns the observation of the
+// supplied data collection rule.
+func GenerateDataCollectionRuleObservation(az DataCollectionRule) v1alpha1.DataCollectionRuleObservation {
+	o := v1alpha1.DataCollectionRuleObservation{ID: azure.ToString(az.ID)}
+	if az.Properties == nil {
+		return o
+	}
+	o.ProvisioningState = azure.ToString(az.Properties.ProvisioningState)
+	o.ImmutableID = azure.ToString(az.Properties.ImmutableID)
+	return o
+}
+
+// NewDataCollectionRuleAssociation returns the association Azure creates or
+// updates for the supplied DataCollectionRuleAssociation.
+func NewDataCollectionRuleAssociation(p v1alpha1.DataCollectionRuleAssociationParameters) DataCollectionRuleAssociation {
+	return DataCollectionRuleAssociation{
+		Properties: &DataCollectionRuleAssociationProperties{
+			Description:              p.Description,
+			DataCollectionRuleID:     p.DataCollectionRuleID,
+			DataCollectionEndpointID: p.DataCollectionEndpointID,
+		},
+	}
+}
+
+// DataCollectionRuleAssociationNeedsUpdate returns true if the supplied
+// parameters differ from the supplied association.
+func DataCollectionRuleAssociationNeedsUpdate(p v1alpha1.DataCollectionRuleAssociationParameters, az DataCollectionRuleAssociation) bool {
+	if az.Properties == nil {
+		return true
+	}
+	return azure.ToString(p.Description) != azure.ToString(az.Properties.Description) ||
+		!strings.EqualFold(azure.ToString(p.DataCollectionRuleID), azure.ToString(az.Properties.DataCollectionRuleID)) ||
+		!strings.EqualFold(azure.ToString(p.DataCollectionEndpointID), azure.ToString(az.Properties.DataCollectionEndpointID))
+}
+
+// GenerateDataCollectionRuleAssociationObservation returns the observation
+// of the supplied association.
+func GenerateDataCollectionRuleAssociationObservation(az DataCollectionRuleAssociation) v1alpha1.DataCollectionRuleAssociationObservation {
+	o := v1alpha1.DataCollectionRuleAssociationObservation{ID: azure.ToString(az.ID)}
+	if az.Properties != nil {
+		o.ProvisioningState = azure.ToString(az.Properties.ProvisioningState)
+	}
+	return o
+}
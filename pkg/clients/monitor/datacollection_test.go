@@ -0,0 +1,183 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package monitor
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/provider-azure/apis/monitor/v1alpha1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+func TestDataCollectionRuleNeedsUpdate(t *testing.T) {
+	params := func() v1alpha1.DataCollectionRuleParameters {
+		return v1alpha1.DataCollectionRuleParameters{
+			DataCollectionEndpointID: azure.ToStringPtr("/subscriptions/s/resourceGroups/rg/providers/Microsoft.Insights/dataCollectionEndpoints/dce"),
+			DataSources: &v1alpha1.DataCollectionRuleDataSources{
+				PrometheusForwarder: []v1alpha1.PrometheusForwarderDataSource{{Name: "prometheus", Streams: []string{v1alpha1.StreamPrometheusMetrics}}},
+			},
+			Destinations: v1alpha1.DataCollectionRuleDestinations{
+				MonitoringAccounts: []v1alpha1.MonitoringAccountDestination{{Name: "workspace", AccountResourceID: "/subscriptions/s/resourceGroups/rg/providers/Microsoft.Monitor/accounts/amw"}},
+			},
+			DataFlows: []v1alpha1.DataFlow{{Streams: []string{v1alpha1.StreamPrometheusMetrics}, Destinations: []string{"workspace"}}},
+		}
+	}
+	rule := func() DataCollectionRule {
+		return DataCollectionRule{
+			Properties: &DataCollectionRuleProperties{
+				DataCollectionEndpointID: azure.ToStringPtr("/subscriptions/s/resourcegroups/rg/providers/Microsoft.Insights/dataCollectionEndpoints/dce"),
+				DataSources: &DataSources{
+					PrometheusForwarder: []PrometheusForwarderDataSource{{Name: azure.ToStringPtr("prometheus"), Streams: []string{v1alpha1.StreamPrometheusMetrics}}},
+				},
+				Destinations: &Destinations{
+					MonitoringAccounts: []MonitoringAccountDestination{{Name: azure.ToStringPtr("workspace"), AccountResourceID: azure.ToStringPtr("/subscriptions/s/resourcegroups/rg/providers/microsoft.monitor/accounts/amw")}},
+				},
+				DataFlows: []DataFlow{{Streams: []string{v1alpha1.StreamPrometheusMetrics}, Destinations: []string{"workspace"}}},
+			},
+		}
+	}
+
+	cases := map[string]struct {
+		reason string
+		p      v1alpha1.DataCollectionRuleParameters
+		az     DataCollectionRule
+		want   bool
+	}{
+		"UpToDate": {
+			reason: "A rule whose resource IDs only differ in case should not need an update",
+			p:      params(),
+			az:     rule(),
+			want:   false,
+		},
+		"DataFlowChanged": {
+			reason: "A rule whose data flows differ should need an update",
+			p: func() v1alpha1.DataCollectionRuleParameters {
+				p := params()
+				p.DataFlows[0].Destinations = []string{"other"}
+				return p
+			}(),
+			az:   rule(),
+			want: true,
+		},
+		"DestinationChanged": {
+			reason: "A rule whose Azure Monitor workspace differs should need an update",
+			p: func() v1alpha1.DataCollectionRuleParameters {
+				p := params()
+				p.Destinations.MonitoringAccounts[0].AccountResourceID = "/subscriptions/s/resourceGroups/rg/providers/Microsoft.Monitor/accounts/other"
+				return p
+			}(),
+			az:   rule(),
+			want: true,
+		},
+		"AgentUpToDate": {
+			reason: "A rule whose Azure Monitor agent data sources and Log Analytics destinations match should not need an update",
+			p: v1alpha1.DataCollectionRuleParameters{
+				DataSources: &v1alpha1.DataCollectionRuleDataSources{
+					Syslog: []v1alpha1.SyslogDataSource{{Name: "syslog", Streams: []string{v1alpha1.StreamSyslog}, FacilityNames: []string{"auth"}, LogLevels: []string{"Error"}}},
+				},
+				Destinations: v1alpha1.DataCollectionRuleDestinations{
+					LogAnalytics: []v1alpha1.LogAnalyticsDestination{{Name: "logs", WorkspaceResourceID: "/subscriptions/s/resourceGroups/rg/providers/Microsoft.OperationalInsights/workspaces/law"}},
+				},
+				DataFlows: []v1alpha1.DataFlow{{Streams: []string{v1alpha1.StreamSyslog}, Destinations: []string{"logs"}}},
+			},
+			az: DataCollectionRule{
+				Properties: &DataCollectionRuleProperties{
+					DataSources: &DataSources{
+						Syslog: []SyslogDataSource{{Name: azure.ToStringPtr("syslog"), Streams: []string{v1alpha1.StreamSyslog}, FacilityNames: []string{"auth"}, LogLevels: []string{"Error"}}},
+					},
+					Destinations: &Destinations{
+						LogAnalytics: []LogAnalyticsDestination{{Name: azure.ToStringPtr("logs"), WorkspaceResourceID: azure.ToStringPtr("/subscriptions/s/resourcegroups/rg/providers/microsoft.operationalinsights/workspaces/law")}},
+					},
+					DataFlows: []DataFlow{{Streams: []string{v1alpha1.StreamSyslog}, Destinations: []string{"logs"}}},
+				},
+			},
+			want: false,
+		},
+		"PerformanceCounterAdded": {
+			reason: "A rule that lacks a performance counter data source should need an update",
+			p: func() v1alpha1.DataCollectionRuleParameters {
+				p := params()
+				p.DataSources.PerformanceCounters = []v1alpha1.PerformanceCounterDataSource{{
+					Name:                       "perf",
+					Streams:                    []string{v1alpha1.StreamPerf},
+					SamplingFrequencyInSeconds: 60,
+					CounterSpecifiers:          []string{"\\Processor(_Total)\\% Processor Time"},
+				}}
+				return p
+			}(),
+			az:   rule(),
+			want: true,
+		},
+		"NoProperties": {
+			reason: "A rule without properties should need an update",
+			p:      params(),
+			az:     DataCollectionRule{},
+			want:   true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := DataCollectionRuleNeedsUpdate(tc.p, tc.az)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nDataCollectionRuleNeedsUpdate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGenerateDataCollectionEndpointObservation(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		az     DataCollectionEndpoint
+		want   v1alpha1.DataCollectionEndpointObservation
+	}{
+		"NoProperties": {
+			reason: "Only the ID of an endpoint without properties should be observed",
+			az:     DataCollectionEndpoint{ID: azure.ToStringPtr("id")},
+			want:   v1alpha1.DataCollectionEndpointObservation{ID: "id"},
+		},
+		"Properties": {
+			reason: "The endpoints of a data collection endpoint should be observed",
+			az: DataCollectionEndpoint{
+				ID: azure.ToStringPtr("id"),
+				Properties: &DataCollectionEndpointProperties{
+					ProvisioningState:   azure.ToStringPtr(ProvisioningStateSucceeded),
+					ConfigurationAccess: &Endpoint{Endpoint: azure.ToStringPtr("https://config")},
+					MetricsIngestion:    &Endpoint{Endpoint: azure.ToStringPtr("https://metrics")},
+				},
+			},
+			want: v1alpha1.DataCollectionEndpointObservation{
+				ID:                          "id",
+				ProvisioningState:           ProvisioningStateSucceeded,
+				ConfigurationAccessEndpoint: "https://config",
+				MetricsIngestionEndpoint:    "https://metrics",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GenerateDataCollectionEndpointObservation(tc.az)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGenerateDataCollectionEndpointObservation(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	"github.com/crossplane/provider-azure/pkg/clients/monitor"
+)
+
+var _ monitor.PrivateLinkScopesAPI = &MockPrivateLinkScopesClient{}
+var _ monitor.WorkspacesAPI = &MockWorkspacesClient{}
+var _ monitor.DataCollectionAPI = &MockDataCollectionClient{}
+
+// MockPrivateLinkScopesClient is a fake implementation of
+// monitor.PrivateLinkScopesClient.
+type MockPrivateLinkScopesClient struct {
+	MockGet                          func(ctx context.Context, resourceGroupName, scopeName string) (monitor.PrivateLinkScope, error)
+	MockCreateOrUpdate               func(ctx context.Context, resourceGroupName, scopeName string, s monitor.PrivateLinkScope) error
+	MockDelete                       func(ctx context.Context, resourceGroupName, scopeName string) error
+	MockGetScopedResource            func(ctx context.Context, resourceGroupName, scopeName, name string) (monitor.ScopedResource, error)
+	MockCreateOrUpdateScopedResource func(ctx context.Context, resourceGroupName, scopeName, name string, r monitor.ScopedResource) error
+	MockDeleteScopedResource         func(ctx context.Context, resourceGroupName, scopeName, name string) error
+}
+
+// Get calls the MockPrivateLinkScopesClient's MockGet method.
+func (c *MockPrivateLinkScopesClient) Get(ctx context.Context, resourceGroupName, scopeName string) (monitor.PrivateLinkScope, error) {
+	return c.MockGet(ctx, resourceGroupName, scopeName)
+}
+
+// CreateOrUpdate calls the MockPrivateLinkScopesClient's MockCreateOrUpdate
+// method.
+func (c *MockPrivateLinkScopesClient) CreateOrUpdate(ctx context.Context, resourceGroupName, scopeName string, s monitor.PrivateLinkScope) error {
+	return c.MockCreateOrUpdate(ctx, resourceGroupName, scopeName, s)
+}
+
+// Delete calls the MockPrivateLinkScopesClient's MockDelete method.
+func (c *MockPrivateLinkScopesClient) Delete(ctx context.Context, resourceGroupName, scopeName string) error {
+	return c.MockDelete(ctx, resourceGroupName, scopeName)
+}
+
+// GetScopedResource calls the MockPrivateLinkScopesClient's
+// MockGetScopedResource method.
+func (c *MockPrivateLinkScopesClient) GetScopedResource(ctx context.Context, resourceGroupName, scopeName, name string) (monitor.ScopedResource, error) {
+	return c.MockGetScopedResource(ctx, resourceGroupName, scopeName, name)
+}
+
+// CreateOrUpdateScopedResource calls the MockPrivateLinkScopesClient's
+// MockCreateOrUpdateScopedResource method.
+func (c *MockPrivateLinkScopesClient) CreateOrUpdateScopedResource(ctx context.Context, resourceGroupName, scopeName, name string, r monitor.ScopedResource) error {
+	return c.MockCreateOrUpdateScopedResource(ctx, resourceGroupName, scopeName, name, r)
+}
+
+// DeleteScopedResource calls the MockPrivateLinkScopesClient's
+// MockDeleteScopedResource method.
+func (c *MockPrivateLinkScopesClient) DeleteScopedResource(ctx context.Context, resourceGroupName, scopeName, name string) error {
+	return c.MockDeleteScopedResource(ctx, resourceGroupName, scopeName, name)
+}
+
+// MockWorkspacesClient is a fake implementation of monitor.WorkspacesClient.
+type MockWorkspacesClient struct {
+	MockGet            func(ctx context.Context, resourceGroupName, name string) (monitor.Workspace, error)
+	MockCreateOrUpdate func(ctx context.Context, resourceGroupName, name string, w monitor.Workspace) error
+	MockDelete         func(ctx context.Context, resourceGroupName, name string) error
+}
+
+// Get calls the MockWorkspacesClient's MockGet method.
+func (c *MockWorkspacesClient) Get(ctx context.Context, resourceGroupName, name string) (monitor.Workspace, error) {
+	return c.MockGet(ctx, resourceGroupName, name)
+}
+
+// CreateOrUpdate calls the MockWorkspacesClient's MockCreateOrUpdate method.
+func (c *MockWorkspacesClient) CreateOrUpdate(ctx context.Context, resourceGroupName, name string, w monitor.Workspace) error {
+	return c.MockCreateOrUpdate(ctx, resourceGroupName, name, w)
+}
+
+// Delete calls the MockWorkspacesClient's MockDelete method.
+func (c *MockWorkspacesClient) Delete(ctx context.Context, resourceGroupName, name string) error {
+	return c.MockDelete(ctx, resourceGroupName, name)
+}
+
+// MockDataCollectionClient is a fake implementation of
+// monitor.DataCollectionClient.
+type MockDataCollectionClient struct {
+	MockGetEndpoint               func(ctx context.Context, resourceGroupName, name string) (monitor.DataCollectionEndpoint, error)
+	MockCreateOrUpdateEndpoint    func(ctx context.Context, resourceGroupName, name string, e monitor.DataCollectionEndpoint) error
+	MockDeleteEndpoint            func(ctx context.Context, resourceGroupName, name string) error
+	MockGetRule                   func(ctx context.Context, resourceGroupName, name string) (monitor.DataCollectionRule, error)
+	MockCreateOrUpdateRule        func(ctx context.Context, resourceGroupName, name string, r monitor.DataCollectionRule) error
+	MockDeleteRule                func(ctx context.Context, resourceGroupName, name string) error
+	MockGetAssociation            func(ctx context.Context, resourceURI, name string) (monitor.DataCollectionRuleAssociation, error)
+	MockCreateOrUpdateAssociation func(ctx context.Context, resourceURI, name string, a monitor.DataCollectionRuleAssociation) error
+	MockDeleteAssociation         func(ctx context.Context, resourceURI, name string) error
+}
+
+// GetEndpoint calls the MockDataCollectionClient's MockGetEndpoint method.
+func (c *MockDataCollectionClient) GetEndpoint(ctx context.Context, resourceGroupName, name string) (monitor.DataCollectionEndpoint, error) {
+	return c.MockGetEndpoint(ctx, resourceGroupName, name)
+}
+
+// CreateOrUpdateEndpoint calls the MockDataCollectionClient's
+// MockCreateOrUpdateEndpoint method.
+func (c *MockDataCollectionClient) CreateOrUpdateEndpoint(ctx context.Context, resourceGroupName, name string, e monitor.DataCollectionEndpoint) error {
+	return c.MockCreateOrUpdateEndpoint(ctx, resourceGroupName, name, e)
+}
+
+// DeleteEndpoint calls the MockDataCollectionClient's MockDeleteEndpoint
+// method.
+func (c *MockDataCollectionClient) DeleteEndpoint(ctx context.Context, resourceGroupName, name string) error {
+	return c.MockDeleteEndpoint(ctx, resourceGroupName, name)
+}
+
+// GetRule calls the MockDataCollectionClient's MockGetRule method.
+func (c *MockDataCollectionClient) GetRule(ctx context.Context, resourceGroupName, name string) (monitor.DataCollectionRule, error) {
+	return c.MockGetRule(ctx, resourceGroupName, name)
+}
+
+// CreateOrUpdateRule calls the MockDataCollectionClient's
+// MockCreateOrUpdateRule method.
+func (c *MockDataCollectionClient) CreateOrUpdateRule(ctx context.Context, resourceGroupName, name string, r monitor.DataCollectionRule) error {
+	return c.MockCreateOrUpdateRule(ctx, resourceGroupName, name, r)
+}
+
+// DeleteRule calls the MockDataCollectionClient's MockDeleteRule method.
+func (c *MockDataCollectionClient) DeleteRule(ctx context.Context, resourceGroupName, name string) error {
+	return c.MockDeleteRule(ctx, resourceGroupName, name)
+}
+
+// GetAssociation calls the MockDataCollectionClient's MockGetAssociation
+// method.
+func (c *MockDataCollectionClient) GetAssociation(ctx context.Context, resourceURI, name string) (monitor.DataCollectionRuleAssociation, error) {
+	return c.MockGetAssociation(ctx, resourceURI, name)
+}
+
+// CreateOrUpdateAssociation calls the MockDataCollectionClient's
+// MockCreateOrUpdateAssociation method.
+func (c *MockDataCollectionClient) CreateOrUpdateAssociation(ctx context.Context, resourceURI, name string, a monitor.DataCollectionRuleAssociation) error {
+	return c.MockCreateOrUpdateAssociation(ctx, resourceURI, name, a)
+}
+
+// DeleteAssociation calls the MockDataCollectionClient's
+// MockDeleteAssociation method.
+func (c *MockDataCollectionClient) DeleteAssociation(ctx context.Context, resourceURI, name string) error {
+	return c.MockDeleteAssociation(ctx, resourceURI, name)
+}
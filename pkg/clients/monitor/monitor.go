@@ -0,0 +1,130 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package monitor contains clients of Azure Monitor. It sends records to a
+// Log Analytics workspace using the HTTP Data Collector API, and manages the
+// private link scopes that keep monitoring traffic on private endpoints, and
+// the Azure Monitor workspaces and data collection rules of the managed
+// service for Prometheus.
+// https://docs.microsoft.com/en-us/azure/azure-monitor/logs/data-collector-api
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// DataCollectorURLFormat is the endpoint of the HTTP Data Collector API of
+	// the workspace with the supplied ID.
+	DataCollectorURLFormat = "https://%s.ods.opinsights.azure.com/api/logs?api-version=2016-04-01"
+
+	// TimeGeneratedField is the field of records that Log Analytics uses as
+	// their TimeGenerated column.
+	TimeGeneratedField = "TimeGenerated"
+
+	resource    = "/api/logs"
+	contentType = "application/json"
+)
+
+// Error strings.
+const (
+	errDecodeKey  = "cannot decode workspace shared key"
+	errMarshal    = "cannot marshal records"
+	errNewRequest = "cannot create data collector request"
+	errSend       = "cannot send records"
+	errStatusFmt  = "data collector API returned status %d"
+)
+
+// A Sender sends records of the supplied log type to a workspace. Log
+// Analytics stores them in the custom table named after the log type with a
+// _CL suffix.
+type Sender interface {
+	Send(ctx context.Context, logType string, records interface{}) error
+}
+
+// A DataCollectorClient is a Sender backed by the HTTP Data Collector API.
+type DataCollectorClient struct {
+	http        *http.Client
+	url         string
+	workspaceID string
+	key         []byte
+	now         func() time.Time
+}
+
+// NewDataCollectorClient returns a DataCollectorClient that sends records to
+// the workspace with the supplied ID, authenticating with its base64 encoded
+// primary or secondary shared key.
+func NewDataCollectorClient(workspaceID, sharedKey string) (*DataCollectorClient, error) {
+	key, err := base64.StdEncoding.DecodeString(sharedKey)
+	if err != nil {
+		return nil, errors.Wrap(err, errDecodeKey)
+	}
+	return &DataCollectorClient{
+		http:        &http.Client{Timeout: 30 * time.Second},
+		url:         fmt.Sprintf(DataCollectorURLFormat, workspaceID),
+		workspaceID: workspaceID,
+		key:         key,
+		now:         time.Now,
+	}, nil
+}
+
+// Send the supplied records, which must marshal to a JSON array of objects.
+func (c *DataCollectorClient) Send(ctx context.Context, logType string, records interface{}) error {
+	body, err := json.Marshal(records)
+	if err != nil {
+		return errors.Wrap(err, errMarshal)
+	}
+	date := c.now().UTC().Format(http.TimeFormat)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, errNewRequest)
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Log-Type", logType)
+	req.Header.Set("x-ms-date", date)
+	req.Header.Set("time-generated-field", TimeGeneratedField)
+	req.Header.Set("Authorization", fmt.Sprintf("SharedKey %s:%s", c.workspaceID, Signature(c.key, len(body), date)))
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return errors.Wrap(err, errSend)
+	}
+	defer resp.Body.Close() // nolint:errcheck
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf(errStatusFmt, resp.StatusCode)
+	}
+	return nil
+}
+
+// Signature returns the signature of a request with a body of the supplied
+// length sent at the supplied date, in http.TimeFormat.
+func Signature(key []byte, contentLength int, date string) string {
+	s := fmt.Sprintf("POST\n%d\n%s\nx-ms-date:%s\n%s", contentLength, contentType, date, resource)
+	h := hmac.New(sha256.New, key)
+	_, _ = h.Write([]byte(s))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package monitor
+
+import (
+	"context"
+	"encoding/base64"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestSend(t *testing.T) {
+	key := base64.StdEncoding.EncodeToString([]byte("cool-key"))
+	now := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	records := []map[string]string{{"Reason": "CreatedExternalResource"}}
+	body := `[{"Reason":"CreatedExternalResource"}]`
+
+	cases := map[string]struct {
+		status int
+		want   error
+	}{
+		"Success": {
+			status: http.StatusOK,
+		},
+		"BadStatus": {
+			status: http.StatusForbidden,
+			want:   errors.Errorf(errStatusFmt, http.StatusForbidden),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := ioutil.ReadAll(r.Body)
+				if diff := cmp.Diff(body, string(b)); diff != "" {
+					t.Errorf("body: -want, +got:\n%s", diff)
+				}
+				if got := r.Header.Get("Log-Type"); got != "CrossplaneAzure" {
+					t.Errorf("Log-Type: want %q, got %q", "CrossplaneAzure", got)
+				}
+				date := now.Format(http.TimeFormat)
+				want := "SharedKey cool-workspace:" + Signature([]byte("cool-key"), len(body), date)
+				if got := r.Header.Get("Authorization"); got != want {
+					t.Errorf("Authorization: want %q, got %q", want, got)
+				}
+				w.WriteHeader(tc.status)
+			}))
+			defer srv.Close()
+
+			c, err := NewDataCollectorClient("cool-workspace", key)
+			if err != nil {
+				t.Fatalf("NewDataCollectorClient(...): %s", err)
+			}
+			c.url = srv.URL
+			c.now = func() time.Time { return now }
+			err = c.Send(context.Background(), "CrossplaneAzure", records)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Send(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
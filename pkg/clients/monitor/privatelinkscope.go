@@ -0,0 +1,252 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package monitor
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Azure/go-autorest/autorest"
+
+	"github.com/crossplane/provider-azure/apis/monitor/v1alpha1"
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+// PrivateLinkScopeAPIVersion is the version of the Azure Monitor API that
+// private link scopes are managed with.
+const PrivateLinkScopeAPIVersion = "2021-07-01-preview"
+
+// PrivateLinkScopeLocation is the location of every private link scope.
+const PrivateLinkScopeLocation = "global"
+
+// Provisioning states of private link scopes and scoped resources.
+const (
+	ProvisioningStateSucceeded = "Succeeded"
+	ProvisioningStateFailed    = "Failed"
+)
+
+const (
+	privateLinkScopePath = "/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/microsoft.insights/privateLinkScopes/{scopeName}"
+	scopedResourcePath   = privateLinkScopePath + "/scopedResources/{name}"
+)
+
+// A SubResource references another Azure resource by its ID.
+type SubResource struct {
+	ID *string `json:"id,omitempty"`
+}
+
+// AccessModeSettings determine which networks may use the resources of a
+// private link scope.
+type AccessModeSettings struct {
+	QueryAccessMode     *string `json:"queryAccessMode,omitempty"`
+	IngestionAccessMode *string `json:"ingestionAccessMode,omitempty"`
+}
+
+// PrivateLinkScopeProperties are the properties of a private link scope.
+type PrivateLinkScopeProperties struct {
+	ProvisioningState          *string             `json:"provisioningState,omitempty"`
+	PrivateEndpointConnections *[]SubResource      `json:"privateEndpointConnections,omitempty"`
+	AccessModeSettings         *AccessModeSettings `json:"accessModeSettings,omitempty"`
+}
+
+// A PrivateLinkScope is an Azure Monitor private link scope.
+type PrivateLinkScope struct {
+	ID         *string                     `json:"id,omitempty"`
+	Name       *string                     `json:"name,omitempty"`
+	Location   *string                     `json:"location,omitempty"`
+	Tags       map[string]*string          `json:"tags,omitempty"`
+	Properties *PrivateLinkScopeProperties `json:"properties,omitempty"`
+}
+
+// ScopedResourceProperties are the properties of a scoped resource.
+type ScopedResourceProperties struct {
+	LinkedResourceID  *string `json:"linkedResourceId,omitempty"`
+	ProvisioningState *string `json:"provisioningState,omitempty"`
+}
+
+// A ScopedResource associates a resource with a private link scope.
+type ScopedResource struct {
+	ID         *string                   `json:"id,omitempty"`
+	Name       *string                   `json:"name,omitempty"`
+	Properties *ScopedResourceProperties `json:"properties,omitempty"`
+}
+
+// A PrivateLinkScopesAPI reads and writes Azure Monitor private link scopes
+// and their scoped resources.
+type PrivateLinkScopesAPI interface {
+	Get(ctx context.Context, resourceGroupName, scopeName string) (PrivateLinkScope, error)
+	CreateOrUpdate(ctx context.Context, resourceGroupName, scopeName string, s PrivateLinkScope) error
+	Delete(ctx context.Context, resourceGroupName, scopeName string) error
+	GetScopedResource(ctx context.Context, resourceGroupName, scopeName, name string) (ScopedResource, error)
+	CreateOrUpdateScopedResource(ctx context.Context, resourceGroupName, scopeName, name string, r ScopedResource) error
+	DeleteScopedResource(ctx context.Context, resourceGroupName, scopeName, name string) error
+}
+
+// A PrivateLinkScopesClient reads and writes private link scopes and scoped
+// resources through the Azure Resource Manager API.
+type PrivateLinkScopesClient struct {
+	azure.ARMClient
+}
+
+// NewPrivateLinkScopesClientWithBaseURI returns a PrivateLinkScopesClient for
+// the supplied subscription of the Azure Resource Manager API at the supplied
+// base URI.
+func NewPrivateLinkScopesClientWithBaseURI(baseURI, subscriptionID string) PrivateLinkScopesClient {
+	return PrivateLinkScopesClient{ARMClient: azure.NewARMClientWithBaseURI("monitor.PrivateLinkScopesClient", baseURI, subscriptionID, PrivateLinkScopeAPIVersion)}
+}
+
+// Get returns the supplied private link scope.
+func (c PrivateLinkScopesClient) Get(ctx context.Context, resourceGroupName, scopeName string) (PrivateLinkScope, error) {
+	s := PrivateLinkScope{}
+	err := c.Do(ctx, "Get", autorest.AsGet(), c.scopePath(resourceGroupName, scopeName), nil, &s, http.StatusOK)
+	return s, err
+}
+
+// CreateOrUpdate creates or updates the supplied private link scope.
+func (c PrivateLinkScopesClient) CreateOrUpdate(ctx context.Context, resourceGroupName, scopeName string, s PrivateLinkScope) error {
+	return c.Do(ctx, "CreateOrUpdate", autorest.AsPut(), c.scopePath(resourceGroupName, scopeName), s, nil, http.StatusOK, http.StatusCreated)
+}
+
+// Delete starts to delete the supplied private link scope.
+func (c PrivateLinkScopesClient) Delete(ctx context.Context, resourceGroupName, scopeName string) error {
+	return c.Do(ctx, "Delete", autorest.AsDelete(), c.scopePath(resourceGroupName, scopeName), nil, nil, http.StatusOK, http.StatusAccepted, http.StatusNoContent)
+}
+
+// GetScopedResource returns the supplied scoped resource.
+func (c PrivateLinkScopesClient) GetScopedResource(ctx context.Context, resourceGroupName, scopeName, name string) (ScopedResource, error) {
+	r := ScopedResource{}
+	err := c.Do(ctx, "GetScopedResource", autorest.AsGet(), c.scopedResourcePath(resourceGroupName, scopeName, name), nil, &r, http.StatusOK)
+	return r, err
+}
+
+// CreateOrUpdateScopedResource starts to create or update the supplied
+// scoped resource. Azure associates the resource with the scope
+// asynchronously; its provisioning state reports when it is done.
+func (c PrivateLinkScopesClient) CreateOrUpdateScopedResource(ctx context.Context, resourceGroupName, scopeName, name string, r ScopedResource) error {
+	return c.Do(ctx, "CreateOrUpdateScopedResource", autorest.AsPut(), c.scopedResourcePath(resourceGroupName, scopeName, name), r, nil, http.StatusOK, http.StatusCreated, http.StatusAccepted)
+}
+
+// DeleteScopedResource starts to delete the supplied scoped resource.
+func (c PrivateLinkScopesClient) DeleteScopedResource(ctx context.Context, resourceGroupName, scopeName, name string) error {
+	return c.Do(ctx, "DeleteScopedResource", autorest.AsDelete(), c.scopedResourcePath(resourceGroupName, scopeName, name), nil, nil, http.StatusOK, http.StatusAccepted, http.StatusNoContent)
+}
+
+func (c PrivateLinkScopesClient) scopePath(resourceGroupName, scopeName string) autorest.PrepareDecorator {
+	return autorest.WithPathParameters(privateLinkScopePath, map[string]interface{}{
+		"subscriptionId":    autorest.Encode("path", c.SubscriptionID),
+		"resourceGroupName": autorest.Encode("path", resourceGroupName),
+		"scopeName":         autorest.Encode("path", scopeName),
+	})
+}
+
+func (c PrivateLinkScopesClient) scopedResourcePath(resourceGroupName, scopeName, name string) autorest.PrepareDecorator {
+	return autorest.WithPathParameters(scopedResourcePath, map[string]interface{}{
+		"subscriptionId":    autorest.Encode("path", c.SubscriptionID),
+		"resourceGroupName": autorest.Encode("path", resourceGroupName),
+		"scopeName":         autorest.Encode("path", scopeName),
+		"name":              autorest.Encode("path", name),
+	})
+}
+
+// accessMode returns the supplied access mode, or Open if it is nil.
+func accessMode(m *v1alpha1.AccessMode) *string {
+	if m == nil {
+		return azure.ToStringPtr(string(v1alpha1.AccessModeOpen))
+	}
+	return azure.ToStringPtr(string(*m))
+}
+
+// NewPrivateLinkScope returns the private link scope Azure creates or
+// updates for the supplied AzureMonitorPrivateLinkScope.
+func NewPrivateLinkScope(p v1alpha1.AzureMonitorPrivateLinkScopeParameters) PrivateLinkScope {
+	return PrivateLinkScope{
+		Location: azure.ToStringPtr(PrivateLinkScopeLocation),
+		Tags:     azure.ToStringPtrMap(p.Tags),
+		Properties: &PrivateLinkScopeProperties{
+			AccessModeSettings: &AccessModeSettings{
+				QueryAccessMode:     accessMode(p.QueryAccessMode),
+				IngestionAccessMode: accessMode(p.IngestionAccessMode),
+			},
+		},
+	}
+}
+
+// PrivateLinkScopeNeedsUpdate returns true if the supplied parameters differ
+// from the supplied private link scope.
+func PrivateLinkScopeNeedsUpdate(p v1alpha1.AzureMonitorPrivateLinkScopeParameters, az PrivateLinkScope) bool {
+	if azure.TagsNeedUpdate(p.Tags, az.Tags) {
+		return true
+	}
+	if az.Properties == nil || az.Properties.AccessModeSettings == nil {
+		return true
+	}
+	s := az.Properties.AccessModeSettings
+	return *accessMode(p.QueryAccessMode) != azure.ToString(s.QueryAccessMode) ||
+		*accessMode(p.IngestionAccessMode) != azure.ToString(s.IngestionAccessMode)
+}
+
+// LateInitializePrivateLinkScope fills the empty fields of the supplied
+// parameters with the values of the supplied private link scope.
+func LateInitializePrivateLinkScope(p *v1alpha1.AzureMonitorPrivateLinkScopeParameters, az PrivateLinkScope, tp v1beta1.TagPolicy) {
+	if az.Properties != nil && az.Properties.AccessModeSettings != nil {
+		s := az.Properties.AccessModeSettings
+		if p.QueryAccessMode == nil && s.QueryAccessMode != nil {
+			m := v1alpha1.AccessMode(*s.QueryAccessMode)
+			p.QueryAccessMode = &m
+		}
+		if p.IngestionAccessMode == nil && s.IngestionAccessMode != nil {
+			m := v1alpha1.AccessMode(*s.IngestionAccessMode)
+			p.IngestionAccessMode = &m
+		}
+	}
+	p.Tags = azure.LateInitializeTags(p.Tags, az.Tags, tp)
+}
+
+// GeneratePrivateLinkScopeObservation returns the observation of the
+// supplied private link scope.
+func GeneratePrivateLinkScopeObservation(az PrivateLinkScope) v1alpha1.AzureMonitorPrivateLinkScopeObservation {
+	o := v1alpha1.AzureMonitorPrivateLinkScopeObservation{ID: azure.ToString(az.ID)}
+	if az.Properties == nil {
+		return o
+	}
+	o.ProvisioningState = azure.ToString(az.Properties.ProvisioningState)
+	if az.Properties.PrivateEndpointConnections != nil {
+		for _, c := range *az.Properties.PrivateEndpointConnections {
+			o.PrivateEndpointConnections = append(o.PrivateEndpointConnections, azure.ToString(c.ID))
+		}
+	}
+	return o
+}
+
+// NewScopedResource returns the scoped resource Azure creates for the
+// supplied AzureMonitorPrivateLinkScopedResource.
+func NewScopedResource(p v1alpha1.AzureMonitorPrivateLinkScopedResourceParameters) ScopedResource {
+	return ScopedResource{
+		Properties: &ScopedResourceProperties{LinkedResourceID: azure.ToStringPtr(p.LinkedResourceID)},
+	}
+}
+
+// GenerateScopedResourceObservation returns the observation of the supplied
+// scoped resource.
+func GenerateScopedResourceObservation(az ScopedResource) v1alpha1.AzureMonitorPrivateLinkScopedResourceObservation {
+	o := v1alpha1.AzureMonitorPrivateLinkScopedResourceObservation{ID: azure.ToString(az.ID)}
+	if az.Properties != nil {
+		o.ProvisioningState = azure.ToString(az.Properties.ProvisioningState)
+	}
+	return o
+}
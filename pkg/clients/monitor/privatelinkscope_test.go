@@ -0,0 +1,114 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package monitor
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/provider-azure/apis/monitor/v1alpha1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+func TestPrivateLinkScopeNeedsUpdate(t *testing.T) {
+	privateOnly := v1alpha1.AccessModePrivateOnly
+
+	cases := map[string]struct {
+		reason string
+		p      v1alpha1.AzureMonitorPrivateLinkScopeParameters
+		az     PrivateLinkScope
+		want   bool
+	}{
+		"UpToDate": {
+			reason: "A scope whose access modes and tags match should not need an update",
+			p:      v1alpha1.AzureMonitorPrivateLinkScopeParameters{Tags: map[string]string{"team": "data"}},
+			az: PrivateLinkScope{
+				Tags: map[string]*string{"team": azure.ToStringPtr("data")},
+				Properties: &PrivateLinkScopeProperties{AccessModeSettings: &AccessModeSettings{
+					QueryAccessMode:     azure.ToStringPtr(string(v1alpha1.AccessModeOpen)),
+					IngestionAccessMode: azure.ToStringPtr(string(v1alpha1.AccessModeOpen)),
+				}},
+			},
+			want: false,
+		},
+		"AccessModeChanged": {
+			reason: "A scope whose ingestion access mode differs should need an update",
+			p:      v1alpha1.AzureMonitorPrivateLinkScopeParameters{IngestionAccessMode: &privateOnly},
+			az: PrivateLinkScope{
+				Properties: &PrivateLinkScopeProperties{AccessModeSettings: &AccessModeSettings{
+					QueryAccessMode:     azure.ToStringPtr(string(v1alpha1.AccessModeOpen)),
+					IngestionAccessMode: azure.ToStringPtr(string(v1alpha1.AccessModeOpen)),
+				}},
+			},
+			want: true,
+		},
+		"NoAccessModeSettings": {
+			reason: "A scope without access mode settings should need an update",
+			p:      v1alpha1.AzureMonitorPrivateLinkScopeParameters{},
+			az:     PrivateLinkScope{},
+			want:   true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := PrivateLinkScopeNeedsUpdate(tc.p, tc.az)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nPrivateLinkScopeNeedsUpdate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGeneratePrivateLinkScopeObservation(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		az     PrivateLinkScope
+		want   v1alpha1.AzureMonitorPrivateLinkScopeObservation
+	}{
+		"NoProperties": {
+			reason: "Only the ID of a scope without properties should be observed",
+			az:     PrivateLinkScope{ID: azure.ToStringPtr("id")},
+			want:   v1alpha1.AzureMonitorPrivateLinkScopeObservation{ID: "id"},
+		},
+		"Properties": {
+			reason: "The properties of a scope should be observed",
+			az: PrivateLinkScope{
+				ID: azure.ToStringPtr("id"),
+				Properties: &PrivateLinkScopeProperties{
+					ProvisioningState:          azure.ToStringPtr(ProvisioningStateSucceeded),
+					PrivateEndpointConnections: &[]SubResource{{ID: azure.ToStringPtr("pe")}},
+				},
+			},
+			want: v1alpha1.AzureMonitorPrivateLinkScopeObservation{
+				ID:                         "id",
+				ProvisioningState:          ProvisioningStateSucceeded,
+				PrivateEndpointConnections: []string{"pe"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GeneratePrivateLinkScopeObservation(tc.az)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGeneratePrivateLinkScopeObservation(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package monitor
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Azure/go-autorest/autorest"
+
+	"github.com/crossplane/provider-azure/apis/monitor/v1alpha1"
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+// WorkspaceAPIVersion is the version of the Azure Monitor API that Azure
+// Monitor workspaces are managed with.
+const WorkspaceAPIVersion = "2023-04-03"
+
+// ConnectionKeyPrometheusQueryEndpoint is the connection detail of an
+// AzureMonitorWorkspace that Grafana and other Prometheus clients query its
+// metrics at.
+const ConnectionKeyPrometheusQueryEndpoint = "prometheusQueryEndpoint"
+
+const workspacePath = "/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/Microsoft.Monitor/accounts/{name}"
+
+// WorkspaceMetrics are the properties of the metrics store of an Azure
+// Monitor workspace.
+type WorkspaceMetrics struct {
+	PrometheusQueryEndpoint *string `json:"prometheusQueryEndpoint,omitempty"`
+	InternalID              *string `json:"internalId,omitempty"`
+}
+
+// WorkspaceIngestionSettings are the data collection endpoint and rule Azure
+// creates with an Azure Monitor workspace.
+type WorkspaceIngestionSettings struct {
+	DataCollectionRuleResourceID     *string `json:"dataCollectionRuleResourceId,omitempty"`
+	DataCollectionEndpointResourceID *string `json:"dataCollectionEndpointResourceId,omitempty"`
+}
+
+// WorkspaceProperties are the properties of an Azure Monitor workspace.
+type WorkspaceProperties struct {
+	AccountID                *string                     `json:"accountId,omitempty"`
+	Metrics                  *WorkspaceMetrics           `json:"metrics,omitempty"`
+	ProvisioningState        *string                     `json:"provisioningState,omitempty"`
+	DefaultIngestionSettings *WorkspaceIngestionSettings `json:"defaultIngestionSettings,omitempty"`
+	PublicNetworkAccess      *string                     `json:"publicNetworkAccess,omitempty"`
+}
+
+// A Workspace is an Azure Monitor workspace.
+type Workspace struct {
+	ID         *string              `json:"id,omitempty"`
+	Name       *string              `json:"name,omitempty"`
+	Location   *string              `json:"location,omitempty"`
+	Tags       map[string]*string   `json:"tags,omitempty"`
+	Properties *WorkspaceProperties `json:"properties,omitempty"`
+}
+
+// A WorkspacesAPI reads and writes Azure Monitor workspaces.
+type WorkspacesAPI interface {
+	Get(ctx context.Context, resourceGroupName, name string) (Workspace, error)
+	CreateOrUpdate(ctx context.Context, resourceGroupName, name string, w Workspace) error
+	Delete(ctx context.Context, resourceGroupName, name string) error
+}
+
+// A WorkspacesClient reads and writes Azure Monitor workspaces through the
+// Azure Resource Manager API.
+type WorkspacesClient struct {
+	azure.ARMClient
+}
+
+// NewWorkspacesClientWithBaseURI returns a WorkspacesClient for the supplied
+// subscription of the Azure Resource Manager API at the supplied base URI.
+func NewWorkspacesClientWithBaseURI(baseURI, subscriptionID string) WorkspacesClient {
+	return WorkspacesClient{ARMClient: azure.NewARMClientWithBaseURI("monitor.WorkspacesClient", baseURI, subscriptionID, WorkspaceAPIVersion)}
+}
+
+// Get returns the supplied workspace.
+func (c WorkspacesClient) Get(ctx context.Context, resourceGroupName, name string) (Workspace, error) {
+	w := Workspace{}
+	err := c.Do(ctx, "Get", autorest.AsGet(), c.path(resourceGroupName, name), nil, &w, http.StatusOK)
+	return w, err
+}
+
+// CreateOrUpdate starts to create or update the supplied workspace. Its
+// provisioning state reports when it is done.
+func (c WorkspacesClient) CreateOrUpdate(ctx context.Context, resourceGroupName, name string, w Workspace) error {
+	return c.Do(ctx, "CreateOrUpdate", autorest.AsPut(), c.path(resourceGroupName, name), w, nil, http.StatusOK, http.StatusCreated)
+}
+
+// Delete starts to delete the supplied workspace.
+func (c WorkspacesClient) Delete(ctx context.Context, resourceGroupName, name string) error {
+	return c.Do(ctx, "Delete", autorest.AsDelete(), c.path(resourceGroupName, name), nil, nil, http.StatusOK, http.StatusAccepted, http.StatusNoContent)
+}
+
+func (c WorkspacesClient) path(resourceGroupName, name string) autorest.PrepareDecorator {
+	return autorest.WithPathParameters(workspacePath, map[string]interface{}{
+		"subscriptionId":    autorest.Encode("path", c.SubscriptionID),
+		"resourceGroupName": autorest.Encode("path", resourceGroupName),
+		"name":              autorest.Encode("path", name),
+	})
+}
+
+// publicNetworkAccess returns the supplied public network access setting, or
+// Enabled if it is nil.
+func publicNetworkAccess(a *v1alpha1.PublicNetworkAccess) *string {
+	if a == nil {
+		return azure.ToStringPtr(string(v1alpha1.PublicNetworkAccessEnabled))
+	}
+	return azure.ToStringPtr(string(*a))
+}
+
+// NewWorkspace returns the workspace Azure creates or updates for the
+// supplied AzureMonitorWorkspace.
+func NewWorkspace(p v1alpha1.AzureMonitorWorkspaceParameters) Workspace {
+	return Workspace{
+		Location:   azure.ToStringPtr(p.Location),
+		Tags:       azure.ToStringPtrMap(p.Tags),
+		Properties: &WorkspaceProperties{PublicNetworkAccess: publicNetworkAccess(p.PublicNetworkAccess)},
+	}
+}
+
+// WorkspaceNeedsUpdate returns true if the supplied parameters differ from
+// the supplied workspace.
+func WorkspaceNeedsUpdate(p v1alpha1.AzureMonitorWorkspaceParameters, az Workspace) bool {
+	if azure.TagsNeedUpdate(p.Tags, az.Tags) {
+		return true
+	}
+	if az.Properties == nil {
+		return true
+	}
+	return *publicNetworkAccess(p.PublicNetworkAccess) != azure.ToString(az.Properties.PublicNetworkAccess)
+}
+
+// LateInitializeWorkspace fills the empty fields of the supplied parameters
+// with the values of the supplied workspace.
+func LateInitializeWorkspace(p *v1alpha1.AzureMonitorWorkspaceParameters, az Workspace, tp v1beta1.TagPolicy) {
+	if p.PublicNetworkAccess == nil && az.Properties != nil && az.Properties.PublicNetworkAccess != nil {
+		a := v1alpha1.PublicNetworkAccess(*az.Properties.PublicNetworkAccess)
+		p.PublicNetworkAccess = &a
+	}
+	p.Tags = azure.LateInitializeTags(p.Tags, az.Tags, tp)
+}
+
+// GenerateWorkspaceObservation returns the observation of the supplied
+// workspace.
+func GenerateWorkspaceObservation(az Workspace) v1alpha1.AzureMonitorWorkspaceObservation {
+	o := v1alpha1.AzureMonitorWorkspaceObservation{ID: azure.ToString(az.ID)}
+	if az.Properties == nil {
+		return o
+	}
+	o.ProvisioningState = azure.ToString(az.Properties.ProvisioningState)
+	o.AccountID = azure.ToString(az.Properties.AccountID)
+	if m := az.Properties.Metrics; m != nil {
+		o.PrometheusQueryEndpoint = azure.ToString(m.PrometheusQueryEndpoint)
+	}
+	if s := az.Properties.DefaultIngestionSettings; s != nil {
+		o.DefaultDataCollectionEndpointID = azure.ToString(s.DataCollectionEndpointResourceID)
+		o.DefaultDataCollectionRuleID = azure.ToString(s.DataCollectionRuleResourceID)
+	}
+	return o
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package monitor
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/provider-azure/apis/monitor/v1alpha1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+func TestWorkspaceNeedsUpdate(t *testing.T) {
+	disabled := v1alpha1.PublicNetworkAccessDisabled
+
+	cases := map[string]struct {
+		reason string
+		p      v1alpha1.AzureMonitorWorkspaceParameters
+		az     Workspace
+		want   bool
+	}{
+		"UpToDate": {
+			reason: "A workspace whose public network access and tags match should not need an update",
+			p:      v1alpha1.AzureMonitorWorkspaceParameters{Tags: map[string]string{"team": "data"}},
+			az: Workspace{
+				Tags:       map[string]*string{"team": azure.ToStringPtr("data")},
+				Properties: &WorkspaceProperties{PublicNetworkAccess: azure.ToStringPtr(string(v1alpha1.PublicNetworkAccessEnabled))},
+			},
+			want: false,
+		},
+		"PublicNetworkAccessChanged": {
+			reason: "A workspace whose public network access differs should need an update",
+			p:      v1alpha1.AzureMonitorWorkspaceParameters{PublicNetworkAccess: &disabled},
+			az: Workspace{
+				Properties: &WorkspaceProperties{PublicNetworkAccess: azure.ToStringPtr(string(v1alpha1.PublicNetworkAccessEnabled))},
+			},
+			want: true,
+		},
+		"NoProperties": {
+			reason: "A workspace without properties should need an update",
+			p:      v1alpha1.AzureMonitorWorkspaceParameters{},
+			az:     Workspace{},
+			want:   true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := WorkspaceNeedsUpdate(tc.p, tc.az)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nWorkspaceNeedsUpdate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGenerateWorkspaceObservation(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		az     Workspace
+		want   v1alpha1.AzureMonitorWorkspaceObservation
+	}{
+		"NoProperties": {
+			reason: "Only the ID of a workspace without properties should be observed",
+			az:     Workspace{ID: azure.ToStringPtr("id")},
+			want:   v1alpha1.AzureMonitorWorkspaceObservation{ID: "id"},
+		},
+		"Properties": {
+			reason: "The properties of a workspace should be observed",
+			az: Workspace{
+				ID: azure.ToStringPtr("id"),
+				Properties: &WorkspaceProperties{
+					ProvisioningState: azure.ToStringPtr(ProvisioningStateSucceeded),
+					AccountID:         azure.ToStringPtr("account"),
+					Metrics:           &WorkspaceMetrics{PrometheusQueryEndpoint: azure.ToStringPtr("https://query")},
+					DefaultIngestionSettings: &WorkspaceIngestionSettings{
+						DataCollectionEndpointResourceID: azure.ToStringPtr("dce"),
+						DataCollectionRuleResourceID:     azure.ToStringPtr("dcr"),
+					},
+				},
+			},
+			want: v1alpha1.AzureMonitorWorkspaceObservation{
+				ID:                              "id",
+				ProvisioningState:               ProvisioningStateSucceeded,
+				AccountID:                       "account",
+				PrometheusQueryEndpoint:         "https://query",
+				DefaultDataCollectionEndpointID: "dce",
+				DefaultDataCollectionRuleID:     "dcr",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GenerateWorkspaceObservation(tc.az)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGenerateWorkspaceObservation(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
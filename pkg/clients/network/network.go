@@ -18,73 +18,178 @@ package network
 
 import (
 	"reflect"
+	"strings"
+	"time"
 
 	networkmgmt "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
 
-	"github.com/crossplane/provider-azure/apis/network/v1alpha3"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane/provider-azure/apis/network/v1beta1"
+	apisv1beta1 "github.com/crossplane/provider-azure/apis/v1beta1"
 	azure "github.com/crossplane/provider-azure/pkg/clients"
 )
 
+// Keys of the connection details of virtual networks and subnets.
+const (
+	ConnectionKeyID               = "id"
+	ConnectionKeyAddressPrefix    = "addressPrefix"
+	ConnectionKeyAddressPrefixes  = "addressPrefixes"
+	ConnectionKeyVirtualNetworkID = "virtualNetworkId"
+)
+
+// VirtualNetworkWrites limits the writes in progress to each virtual network
+// and its subnets to one, since Azure rejects any that arrives while another
+// is in progress with AnotherOperationInProgress. Writes hold their slot for
+// at most ten minutes.
+var VirtualNetworkWrites = azure.NewKeyedSemaphore(1, 10*time.Minute)
+
+// VirtualNetworkKey returns the key of the writes to the supplied virtual
+// network, and to its subnets, in VirtualNetworkWrites.
+func VirtualNetworkKey(resourceGroup, virtualNetwork string) string {
+	return resourceGroup + "/" + virtualNetwork
+}
+
 // NewVirtualNetworkParameters returns an Azure VirtualNetwork object from a virtual network spec
-func NewVirtualNetworkParameters(v *v1alpha3.VirtualNetwork) networkmgmt.VirtualNetwork {
+func NewVirtualNetworkParameters(v *v1beta1.VirtualNetwork) networkmgmt.VirtualNetwork {
 	return networkmgmt.VirtualNetwork{
-		Location: azure.ToStringPtr(v.Spec.Location),
-		Tags:     azure.ToStringPtrMap(v.Spec.Tags),
+		Location: azure.ToStringPtr(v.Spec.ForProvider.Location),
+		Tags:     azure.ToStringPtrMap(v.Spec.ForProvider.Tags),
 		VirtualNetworkPropertiesFormat: &networkmgmt.VirtualNetworkPropertiesFormat{
-			EnableDdosProtection: azure.ToBoolPtr(v.Spec.VirtualNetworkPropertiesFormat.EnableDDOSProtection, azure.FieldRequired),
-			EnableVMProtection:   azure.ToBoolPtr(v.Spec.VirtualNetworkPropertiesFormat.EnableVMProtection, azure.FieldRequired),
+			EnableDdosProtection: azure.ToBoolPtr(v.Spec.ForProvider.EnableDDOSProtection, azure.FieldRequired),
+			EnableVMProtection:   azure.ToBoolPtr(v.Spec.ForProvider.EnableVMProtection, azure.FieldRequired),
 			AddressSpace: &networkmgmt.AddressSpace{
-				AddressPrefixes: &v.Spec.VirtualNetworkPropertiesFormat.AddressSpace.AddressPrefixes,
+				AddressPrefixes: &v.Spec.ForProvider.AddressPrefixes,
 			},
 		},
 	}
 }
 
 // VirtualNetworkNeedsUpdate determines if a virtual network need to be updated
-func VirtualNetworkNeedsUpdate(kube *v1alpha3.VirtualNetwork, az networkmgmt.VirtualNetwork) bool {
+func VirtualNetworkNeedsUpdate(kube *v1beta1.VirtualNetwork, az networkmgmt.VirtualNetwork) bool {
+	return len(VirtualNetworkDrift(kube, az)) > 0
+}
+
+// VirtualNetworkDrift returns the paths of the fields of the supplied virtual
+// network spec whose value differs from that of the supplied Azure virtual
+// network, e.g. because it was changed in the Azure portal.
+func VirtualNetworkDrift(kube *v1beta1.VirtualNetwork, az networkmgmt.VirtualNetwork) []string {
 	up := NewVirtualNetworkParameters(kube)
+	if az.VirtualNetworkPropertiesFormat == nil {
+		az.VirtualNetworkPropertiesFormat = &networkmgmt.VirtualNetworkPropertiesFormat{}
+	}
 
-	switch {
-	case !reflect.DeepEqual(up.VirtualNetworkPropertiesFormat.AddressSpace, az.VirtualNetworkPropertiesFormat.AddressSpace):
-		return true
-	case !reflect.DeepEqual(up.VirtualNetworkPropertiesFormat.EnableDdosProtection, az.VirtualNetworkPropertiesFormat.EnableDdosProtection):
-		return true
-	case !reflect.DeepEqual(up.VirtualNetworkPropertiesFormat.EnableVMProtection, az.VirtualNetworkPropertiesFormat.EnableVMProtection):
-		return true
-	case !reflect.DeepEqual(up.Tags, az.Tags):
-		return true
+	var drift []string
+	if !reflect.DeepEqual(up.VirtualNetworkPropertiesFormat.AddressSpace, az.VirtualNetworkPropertiesFormat.AddressSpace) {
+		drift = append(drift, "spec.forProvider.addressPrefixes")
+	}
+	if !reflect.DeepEqual(up.VirtualNetworkPropertiesFormat.EnableDdosProtection, az.VirtualNetworkPropertiesFormat.EnableDdosProtection) {
+		drift = append(drift, "spec.forProvider.enableDdosProtection")
+	}
+	if !reflect.DeepEqual(up.VirtualNetworkPropertiesFormat.EnableVMProtection, az.VirtualNetworkPropertiesFormat.EnableVMProtection) {
+		drift = append(drift, "spec.forProvider.enableVmProtection")
 	}
+	if azure.TagsNeedUpdate(kube.Spec.ForProvider.Tags, az.Tags) {
+		drift = append(drift, "spec.forProvider.tags")
+	}
+	return drift
+}
 
+// AddressSpaceShrinks returns true if an address prefix of the supplied Azure
+// virtual network is missing from the supplied spec. Removing a prefix breaks
+// the connectivity of anything addressed from it.
+func AddressSpaceShrinks(kube *v1beta1.VirtualNetwork, az networkmgmt.VirtualNetwork) bool {
+	if az.VirtualNetworkPropertiesFormat == nil || az.AddressSpace == nil || az.AddressSpace.AddressPrefixes == nil {
+		return false
+	}
+	want := map[string]bool{}
+	for _, p := range kube.Spec.ForProvider.AddressPrefixes {
+		want[p] = true
+	}
+	for _, p := range *az.AddressSpace.AddressPrefixes {
+		if !want[p] {
+			return true
+		}
+	}
 	return false
 }
 
-// UpdateVirtualNetworkStatusFromAzure updates the status related to the external
-// Azure virtual network in the VirtualNetworkStatus
-func UpdateVirtualNetworkStatusFromAzure(v *v1alpha3.VirtualNetwork, az networkmgmt.VirtualNetwork) {
-	v.Status.State = azure.ToString(az.ProvisioningState)
-	v.Status.ID = azure.ToString(az.ID)
-	v.Status.Etag = azure.ToString(az.Etag)
-	v.Status.ResourceGUID = azure.ToString(az.ResourceGUID)
-	v.Status.Type = azure.ToString(az.Type)
+// GenerateVirtualNetworkObservation produces a VirtualNetworkObservation from
+// the supplied Azure virtual network.
+func GenerateVirtualNetworkObservation(az networkmgmt.VirtualNetwork) v1beta1.VirtualNetworkObservation {
+	o := v1beta1.VirtualNetworkObservation{
+		Etag: azure.ToString(az.Etag),
+		ID:   azure.ToString(az.ID),
+		Type: azure.ToString(az.Type),
+	}
+	p := az.VirtualNetworkPropertiesFormat
+	if p == nil {
+		return o
+	}
+	o.ProvisioningState = azure.ToString(p.ProvisioningState)
+	o.ResourceGUID = azure.ToString(p.ResourceGUID)
+	if p.AddressSpace != nil && p.AddressSpace.AddressPrefixes != nil {
+		o.AddressPrefixes = *p.AddressSpace.AddressPrefixes
+	}
+	if p.DhcpOptions != nil && p.DhcpOptions.DNSServers != nil {
+		o.DNSServers = *p.DhcpOptions.DNSServers
+	}
+	if p.Subnets != nil {
+		o.SubnetIDs = make([]string, len(*p.Subnets))
+		for i, sn := range *p.Subnets {
+			o.SubnetIDs[i] = azure.ToString(sn.ID)
+		}
+	}
+	if p.VirtualNetworkPeerings != nil {
+		o.PeeringIDs = make([]string, len(*p.VirtualNetworkPeerings))
+		for i, pr := range *p.VirtualNetworkPeerings {
+			o.PeeringIDs[i] = azure.ToString(pr.ID)
+		}
+	}
+	if p.DdosProtectionPlan != nil {
+		o.DDOSProtectionPlanID = azure.ToString(p.DdosProtectionPlan.ID)
+	}
+	return o
+}
+
+// VirtualNetworkConnectionDetails returns the connection details of the
+// supplied virtual network, i.e. its ID and the comma separated address
+// prefixes of its address space.
+func VirtualNetworkConnectionDetails(o v1beta1.VirtualNetworkObservation) managed.ConnectionDetails {
+	return managed.ConnectionDetails{
+		ConnectionKeyID:              []byte(o.ID),
+		ConnectionKeyAddressPrefixes: []byte(strings.Join(o.AddressPrefixes, ",")),
+	}
+}
+
+// LateInitializeVirtualNetwork fills the empty fields of the supplied virtual
+// network spec with their values in the supplied Azure virtual network.
+func LateInitializeVirtualNetwork(v *v1beta1.VirtualNetwork, az networkmgmt.VirtualNetwork, tp apisv1beta1.TagPolicy) {
+	p := &v.Spec.ForProvider
+	if p.Location == "" {
+		p.Location = azure.ToString(az.Location)
+	}
+	p.Tags = azure.LateInitializeTags(p.Tags, az.Tags, tp)
 }
 
 // NewSubnetParameters returns an Azure Subnet object from a subnet spec
-func NewSubnetParameters(s *v1alpha3.Subnet) networkmgmt.Subnet {
+func NewSubnetParameters(s *v1beta1.Subnet) networkmgmt.Subnet {
 	return networkmgmt.Subnet{
 		SubnetPropertiesFormat: &networkmgmt.SubnetPropertiesFormat{
-			AddressPrefix:    azure.ToStringPtr(s.Spec.SubnetPropertiesFormat.AddressPrefix),
-			ServiceEndpoints: NewServiceEndpoints(s.Spec.SubnetPropertiesFormat.ServiceEndpoints),
+			AddressPrefix:    azure.ToStringPtr(s.Spec.ForProvider.AddressPrefix),
+			ServiceEndpoints: NewServiceEndpoints(s.Spec.ForProvider.ServiceEndpoints),
 		},
 	}
 }
 
 // NewServiceEndpoints converts to Azure ServiceEndpointPropertiesFormat
-func NewServiceEndpoints(e []v1alpha3.ServiceEndpointPropertiesFormat) *[]networkmgmt.ServiceEndpointPropertiesFormat {
+func NewServiceEndpoints(e []v1beta1.ServiceEndpoint) *[]networkmgmt.ServiceEndpointPropertiesFormat {
 	endpoints := make([]networkmgmt.ServiceEndpointPropertiesFormat, len(e))
 
 	for i, end := range e {
 		endpoints[i] = networkmgmt.ServiceEndpointPropertiesFormat{
-			Service: azure.ToStringPtr(end.Service),
+			Service:   azure.ToStringPtr(end.Service),
+			Locations: azure.ToStringArrayPtr(end.Locations),
 		}
 	}
 
@@ -92,17 +197,107 @@ func NewServiceEndpoints(e []v1alpha3.ServiceEndpointPropertiesFormat) *[]networ
 }
 
 // SubnetNeedsUpdate determines if a virtual network need to be updated
-func SubnetNeedsUpdate(kube *v1alpha3.Subnet, az networkmgmt.Subnet) bool {
+func SubnetNeedsUpdate(kube *v1beta1.Subnet, az networkmgmt.Subnet) bool {
+	return len(SubnetDrift(kube, az)) > 0
+}
+
+// SubnetDrift returns the paths of the fields of the supplied subnet spec
+// whose value differs from that of the supplied Azure subnet.
+func SubnetDrift(kube *v1beta1.Subnet, az networkmgmt.Subnet) []string {
 	up := NewSubnetParameters(kube)
+	if az.SubnetPropertiesFormat == nil {
+		az.SubnetPropertiesFormat = &networkmgmt.SubnetPropertiesFormat{}
+	}
 
-	return !reflect.DeepEqual(up.SubnetPropertiesFormat.AddressPrefix, az.SubnetPropertiesFormat.AddressPrefix)
+	var drift []string
+	if !reflect.DeepEqual(up.SubnetPropertiesFormat.AddressPrefix, az.SubnetPropertiesFormat.AddressPrefix) {
+		drift = append(drift, "spec.forProvider.addressPrefix")
+	}
+	return drift
+}
+
+// LateInitializeSubnet fills the empty locations of the service endpoints of
+// the supplied subnet spec with those Azure chose for the same service, which
+// are the location of the subnet and its paired location for most services.
+func LateInitializeSubnet(s *v1beta1.Subnet, az networkmgmt.Subnet) {
+	if az.SubnetPropertiesFormat == nil || az.ServiceEndpoints == nil {
+		return
+	}
+	for i, e := range s.Spec.ForProvider.ServiceEndpoints {
+		for _, aze := range *az.ServiceEndpoints {
+			if azure.ToString(aze.Service) == e.Service {
+				s.Spec.ForProvider.ServiceEndpoints[i].Locations = azure.LateInitializeStringValArrFromArrPtr(e.Locations, aze.Locations)
+			}
+		}
+	}
+}
+
+// GenerateSubnetObservation produces a SubnetObservation from the supplied
+// Azure subnet.
+func GenerateSubnetObservation(az networkmgmt.Subnet) v1beta1.SubnetObservation {
+	o := v1beta1.SubnetObservation{
+		Etag: azure.ToString(az.Etag),
+		ID:   azure.ToString(az.ID),
+	}
+	p := az.SubnetPropertiesFormat
+	if p == nil {
+		return o
+	}
+	o.ProvisioningState = azure.ToString(p.ProvisioningState)
+	o.Purpose = azure.ToString(p.Purpose)
+	switch {
+	case p.AddressPrefixes != nil:
+		o.AddressPrefixes = *p.AddressPrefixes
+	case p.AddressPrefix != nil:
+		o.AddressPrefixes = []string{*p.AddressPrefix}
+	}
+	if p.NetworkSecurityGroup != nil {
+		o.NetworkSecurityGroupID = azure.ToString(p.NetworkSecurityGroup.ID)
+	}
+	if p.RouteTable != nil {
+		o.RouteTableID = azure.ToString(p.RouteTable.ID)
+	}
+	if p.NatGateway != nil {
+		o.NATGatewayID = azure.ToString(p.NatGateway.ID)
+	}
+	if p.IPConfigurations != nil {
+		o.IPConfigurationIDs = make([]string, len(*p.IPConfigurations))
+		for i, c := range *p.IPConfigurations {
+			o.IPConfigurationIDs[i] = azure.ToString(c.ID)
+		}
+	}
+	if p.PrivateEndpoints != nil {
+		o.PrivateEndpointIDs = make([]string, len(*p.PrivateEndpoints))
+		for i, e := range *p.PrivateEndpoints {
+			o.PrivateEndpointIDs[i] = azure.ToString(e.ID)
+		}
+	}
+	if p.Delegations != nil {
+		o.Delegations = make([]string, 0, len(*p.Delegations))
+		for _, d := range *p.Delegations {
+			if d.ServiceDelegationPropertiesFormat != nil {
+				o.Delegations = append(o.Delegations, azure.ToString(d.ServiceName))
+			}
+		}
+	}
+	return o
 }
 
-// UpdateSubnetStatusFromAzure updates the status related to the external
-// Azure subnet in the SubnetStatus
-func UpdateSubnetStatusFromAzure(v *v1alpha3.Subnet, az networkmgmt.Subnet) {
-	v.Status.State = azure.ToString(az.ProvisioningState)
-	v.Status.Etag = azure.ToString(az.Etag)
-	v.Status.ID = azure.ToString(az.ID)
-	v.Status.Purpose = azure.ToString(az.Purpose)
+// SubnetConnectionDetails returns the connection details of the supplied
+// subnet, i.e. its ID, its first and all of its comma separated address
+// prefixes, and the ID of its virtual network.
+func SubnetConnectionDetails(o v1beta1.SubnetObservation) managed.ConnectionDetails {
+	cd := managed.ConnectionDetails{
+		ConnectionKeyID:              []byte(o.ID),
+		ConnectionKeyAddressPrefixes: []byte(strings.Join(o.AddressPrefixes, ",")),
+	}
+	if len(o.AddressPrefixes) > 0 {
+		cd[ConnectionKeyAddressPrefix] = []byte(o.AddressPrefixes[0])
+	}
+	// The ID of a subnet is that of its virtual network followed by
+	// /subnets/<name>.
+	if i := strings.LastIndex(strings.ToLower(o.ID), "/subnets/"); i > 0 {
+		cd[ConnectionKeyVirtualNetworkID] = []byte(o.ID[:i])
+	}
+	return cd
 }
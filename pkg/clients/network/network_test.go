@@ -25,9 +25,10 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
-	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 
-	"github.com/crossplane/provider-azure/apis/network/v1alpha3"
+	"github.com/crossplane/provider-azure/apis/network/v1beta1"
+	apisv1beta1 "github.com/crossplane/provider-azure/apis/v1beta1"
 	azure "github.com/crossplane/provider-azure/pkg/clients"
 )
 
@@ -50,19 +51,17 @@ var (
 func TestNewVirtualNetworkParameters(t *testing.T) {
 	cases := []struct {
 		name string
-		r    *v1alpha3.VirtualNetwork
+		r    *v1beta1.VirtualNetwork
 		want networkmgmt.VirtualNetwork
 	}{
 		{
 			name: "SuccessfulFull",
-			r: &v1alpha3.VirtualNetwork{
+			r: &v1beta1.VirtualNetwork{
 				ObjectMeta: metav1.ObjectMeta{UID: uid},
-				Spec: v1alpha3.VirtualNetworkSpec{
-					Location: location,
-					VirtualNetworkPropertiesFormat: v1alpha3.VirtualNetworkPropertiesFormat{
-						AddressSpace: v1alpha3.AddressSpace{
-							AddressPrefixes: addressPrefixes,
-						},
+				Spec: v1beta1.VirtualNetworkSpec{
+					ForProvider: v1beta1.VirtualNetworkParameters{
+						Location:             location,
+						AddressPrefixes:      addressPrefixes,
 						EnableDDOSProtection: enableDDOSProtection,
 						EnableVMProtection:   enableVMProtection,
 					},
@@ -82,14 +81,12 @@ func TestNewVirtualNetworkParameters(t *testing.T) {
 		},
 		{
 			name: "SuccessfulPartial",
-			r: &v1alpha3.VirtualNetwork{
+			r: &v1beta1.VirtualNetwork{
 				ObjectMeta: metav1.ObjectMeta{UID: uid},
-				Spec: v1alpha3.VirtualNetworkSpec{
-					Location: location,
-					VirtualNetworkPropertiesFormat: v1alpha3.VirtualNetworkPropertiesFormat{
-						AddressSpace: v1alpha3.AddressSpace{
-							AddressPrefixes: addressPrefixes,
-						},
+				Spec: v1beta1.VirtualNetworkSpec{
+					ForProvider: v1beta1.VirtualNetworkParameters{
+						Location:             location,
+						AddressPrefixes:      addressPrefixes,
 						EnableDDOSProtection: enableDDOSProtection,
 					},
 				},
@@ -121,22 +118,20 @@ func TestNewVirtualNetworkParameters(t *testing.T) {
 func TestVirtualNetworkNeedsUpdate(t *testing.T) {
 	cases := []struct {
 		name string
-		kube *v1alpha3.VirtualNetwork
+		kube *v1beta1.VirtualNetwork
 		az   networkmgmt.VirtualNetwork
 		want bool
 	}{
 		{
 			name: "NeedsUpdateAddressSpace",
-			kube: &v1alpha3.VirtualNetwork{
-				Spec: v1alpha3.VirtualNetworkSpec{
-					VirtualNetworkPropertiesFormat: v1alpha3.VirtualNetworkPropertiesFormat{
-						AddressSpace: v1alpha3.AddressSpace{
-							AddressPrefixes: []string{"10.3.0.0/16"},
-						},
+			kube: &v1beta1.VirtualNetwork{
+				Spec: v1beta1.VirtualNetworkSpec{
+					ForProvider: v1beta1.VirtualNetworkParameters{
+						AddressPrefixes:      []string{"10.3.0.0/16"},
 						EnableDDOSProtection: enableDDOSProtection,
 						EnableVMProtection:   enableVMProtection,
+						Tags:                 tags,
 					},
-					Tags: tags,
 				},
 			},
 			az: networkmgmt.VirtualNetwork{
@@ -153,16 +148,14 @@ func TestVirtualNetworkNeedsUpdate(t *testing.T) {
 		},
 		{
 			name: "NeedsUpdateDdosProtection",
-			kube: &v1alpha3.VirtualNetwork{
-				Spec: v1alpha3.VirtualNetworkSpec{
-					VirtualNetworkPropertiesFormat: v1alpha3.VirtualNetworkPropertiesFormat{
-						AddressSpace: v1alpha3.AddressSpace{
-							AddressPrefixes: addressPrefixes,
-						},
+			kube: &v1beta1.VirtualNetwork{
+				Spec: v1beta1.VirtualNetworkSpec{
+					ForProvider: v1beta1.VirtualNetworkParameters{
+						AddressPrefixes:      addressPrefixes,
 						EnableDDOSProtection: !enableDDOSProtection,
 						EnableVMProtection:   enableVMProtection,
+						Tags:                 tags,
 					},
-					Tags: tags,
 				},
 			},
 			az: networkmgmt.VirtualNetwork{
@@ -179,16 +172,14 @@ func TestVirtualNetworkNeedsUpdate(t *testing.T) {
 		},
 		{
 			name: "NeedsUpdateVMProtection",
-			kube: &v1alpha3.VirtualNetwork{
-				Spec: v1alpha3.VirtualNetworkSpec{
-					VirtualNetworkPropertiesFormat: v1alpha3.VirtualNetworkPropertiesFormat{
-						AddressSpace: v1alpha3.AddressSpace{
-							AddressPrefixes: addressPrefixes,
-						},
+			kube: &v1beta1.VirtualNetwork{
+				Spec: v1beta1.VirtualNetworkSpec{
+					ForProvider: v1beta1.VirtualNetworkParameters{
+						AddressPrefixes:      addressPrefixes,
 						EnableDDOSProtection: enableDDOSProtection,
 						EnableVMProtection:   !enableVMProtection,
+						Tags:                 tags,
 					},
-					Tags: tags,
 				},
 			},
 			az: networkmgmt.VirtualNetwork{
@@ -205,16 +196,14 @@ func TestVirtualNetworkNeedsUpdate(t *testing.T) {
 		},
 		{
 			name: "NeedsUpdateTags",
-			kube: &v1alpha3.VirtualNetwork{
-				Spec: v1alpha3.VirtualNetworkSpec{
-					VirtualNetworkPropertiesFormat: v1alpha3.VirtualNetworkPropertiesFormat{
-						AddressSpace: v1alpha3.AddressSpace{
-							AddressPrefixes: addressPrefixes,
-						},
+			kube: &v1beta1.VirtualNetwork{
+				Spec: v1beta1.VirtualNetworkSpec{
+					ForProvider: v1beta1.VirtualNetworkParameters{
+						AddressPrefixes:      addressPrefixes,
 						EnableDDOSProtection: enableDDOSProtection,
 						EnableVMProtection:   enableVMProtection,
+						Tags:                 map[string]string{"three": "test"},
 					},
-					Tags: map[string]string{"three": "test"},
 				},
 			},
 			az: networkmgmt.VirtualNetwork{
@@ -231,16 +220,14 @@ func TestVirtualNetworkNeedsUpdate(t *testing.T) {
 		},
 		{
 			name: "NoUpdate",
-			kube: &v1alpha3.VirtualNetwork{
-				Spec: v1alpha3.VirtualNetworkSpec{
-					VirtualNetworkPropertiesFormat: v1alpha3.VirtualNetworkPropertiesFormat{
-						AddressSpace: v1alpha3.AddressSpace{
-							AddressPrefixes: addressPrefixes,
-						},
+			kube: &v1beta1.VirtualNetwork{
+				Spec: v1beta1.VirtualNetworkSpec{
+					ForProvider: v1beta1.VirtualNetworkParameters{
+						AddressPrefixes:      addressPrefixes,
 						EnableDDOSProtection: enableDDOSProtection,
 						EnableVMProtection:   enableVMProtection,
+						Tags:                 tags,
 					},
-					Tags: tags,
 				},
 			},
 			az: networkmgmt.VirtualNetwork{
@@ -267,89 +254,218 @@ func TestVirtualNetworkNeedsUpdate(t *testing.T) {
 	}
 }
 
-func TestUpdateVirtualNetworkStatusFromAzure(t *testing.T) {
-	mockCondition := xpv1.Condition{Message: "mockMessage"}
-	resourceStatus := xpv1.ResourceStatus{
-		ConditionedStatus: xpv1.ConditionedStatus{
-			Conditions: []xpv1.Condition{mockCondition},
-		},
-	}
-
+func TestVirtualNetworkDrift(t *testing.T) {
 	cases := []struct {
 		name string
-		r    networkmgmt.VirtualNetwork
-		want v1alpha3.VirtualNetworkStatus
+		kube *v1beta1.VirtualNetwork
+		az   networkmgmt.VirtualNetwork
+		want []string
 	}{
 		{
-			name: "SuccessfulFull",
-			r: networkmgmt.VirtualNetwork{
-				Location: azure.ToStringPtr(location),
-				Etag:     azure.ToStringPtr(etag),
-				ID:       azure.ToStringPtr(id),
-				Type:     azure.ToStringPtr(resourceType),
-				Tags:     azure.ToStringPtrMap(nil),
+			name: "Drifted",
+			kube: &v1beta1.VirtualNetwork{
+				Spec: v1beta1.VirtualNetworkSpec{
+					ForProvider: v1beta1.VirtualNetworkParameters{
+						AddressPrefixes:      addressPrefixes,
+						EnableDDOSProtection: enableDDOSProtection,
+						EnableVMProtection:   enableVMProtection,
+						Tags:                 tags,
+					},
+				},
+			},
+			az: networkmgmt.VirtualNetwork{
 				VirtualNetworkPropertiesFormat: &networkmgmt.VirtualNetworkPropertiesFormat{
-					EnableDdosProtection: azure.ToBoolPtr(enableDDOSProtection),
-					EnableVMProtection:   azure.ToBoolPtr(enableVMProtection),
 					AddressSpace: &networkmgmt.AddressSpace{
-						AddressPrefixes: &addressPrefixes,
+						AddressPrefixes: &[]string{"10.3.0.0/16"},
 					},
-					ProvisioningState: azure.ToStringPtr("Succeeded"),
-					ResourceGUID:      azure.ToStringPtr(string(uid)),
+					EnableDdosProtection: to.BoolPtr(enableDDOSProtection),
+					EnableVMProtection:   to.BoolPtr(enableVMProtection),
 				},
+				Tags: azure.ToStringPtrMap(map[string]string{"one": "test"}),
 			},
-			want: v1alpha3.VirtualNetworkStatus{
-				State:        string(networkmgmt.Succeeded),
-				ID:           id,
-				Etag:         etag,
-				Type:         resourceType,
-				ResourceGUID: string(uid),
-			},
+			want: []string{"spec.forProvider.addressPrefixes", "spec.forProvider.tags"},
 		},
 		{
-			name: "SuccessfulPartial",
-			r: networkmgmt.VirtualNetwork{
-				Location: azure.ToStringPtr(location),
-				Type:     azure.ToStringPtr(resourceType),
-				Tags:     azure.ToStringPtrMap(nil),
+			name: "NotDrifted",
+			kube: &v1beta1.VirtualNetwork{
+				Spec: v1beta1.VirtualNetworkSpec{
+					ForProvider: v1beta1.VirtualNetworkParameters{
+						AddressPrefixes:      addressPrefixes,
+						EnableDDOSProtection: enableDDOSProtection,
+						EnableVMProtection:   enableVMProtection,
+						Tags:                 tags,
+					},
+				},
+			},
+			az: networkmgmt.VirtualNetwork{
 				VirtualNetworkPropertiesFormat: &networkmgmt.VirtualNetworkPropertiesFormat{
-					EnableDdosProtection: azure.ToBoolPtr(enableDDOSProtection),
-					EnableVMProtection:   azure.ToBoolPtr(enableVMProtection),
 					AddressSpace: &networkmgmt.AddressSpace{
 						AddressPrefixes: &addressPrefixes,
 					},
-					ProvisioningState: azure.ToStringPtr("Succeeded"),
-					ResourceGUID:      azure.ToStringPtr(string(uid)),
+					EnableDdosProtection: to.BoolPtr(enableDDOSProtection),
+					EnableVMProtection:   to.BoolPtr(enableVMProtection),
 				},
-			},
-			want: v1alpha3.VirtualNetworkStatus{
-				State:        string(networkmgmt.Succeeded),
-				ResourceGUID: string(uid),
-				Type:         resourceType,
+				Tags: azure.ToStringPtrMap(tags),
 			},
 		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
+			got := VirtualNetworkDrift(tc.kube, tc.az)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("VirtualNetworkDrift(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
 
-			v := &v1alpha3.VirtualNetwork{
-				Status: v1alpha3.VirtualNetworkStatus{
-					ResourceStatus: resourceStatus,
+func TestGenerateVirtualNetworkObservation(t *testing.T) {
+	cases := []struct {
+		name string
+		az   networkmgmt.VirtualNetwork
+		want v1beta1.VirtualNetworkObservation
+	}{
+		{
+			name: "Full",
+			az: networkmgmt.VirtualNetwork{
+				Etag: azure.ToStringPtr(etag),
+				ID:   azure.ToStringPtr(id),
+				Type: azure.ToStringPtr(resourceType),
+				VirtualNetworkPropertiesFormat: &networkmgmt.VirtualNetworkPropertiesFormat{
+					AddressSpace: &networkmgmt.AddressSpace{
+						AddressPrefixes: &addressPrefixes,
+					},
+					DhcpOptions: &networkmgmt.DhcpOptions{
+						DNSServers: &[]string{"10.0.0.4"},
+					},
+					Subnets: &[]networkmgmt.Subnet{
+						{ID: azure.ToStringPtr("subnet-id")},
+					},
+					VirtualNetworkPeerings: &[]networkmgmt.VirtualNetworkPeering{
+						{ID: azure.ToStringPtr("peering-id")},
+					},
+					DdosProtectionPlan: &networkmgmt.SubResource{ID: azure.ToStringPtr("plan-id")},
+					ProvisioningState:  azure.ToStringPtr("Succeeded"),
+					ResourceGUID:       azure.ToStringPtr(string(uid)),
 				},
+			},
+			want: v1beta1.VirtualNetworkObservation{
+				ProvisioningState:    string(networkmgmt.Succeeded),
+				Etag:                 etag,
+				ID:                   id,
+				ResourceGUID:         string(uid),
+				Type:                 resourceType,
+				AddressPrefixes:      addressPrefixes,
+				DNSServers:           []string{"10.0.0.4"},
+				SubnetIDs:            []string{"subnet-id"},
+				PeeringIDs:           []string{"peering-id"},
+				DDOSProtectionPlanID: "plan-id",
+			},
+		},
+		{
+			name: "NoProperties",
+			az: networkmgmt.VirtualNetwork{
+				ID: azure.ToStringPtr(id),
+			},
+			want: v1beta1.VirtualNetworkObservation{
+				ID: id,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := GenerateVirtualNetworkObservation(tc.az)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GenerateVirtualNetworkObservation(...): -want, +got\n%s", diff)
 			}
+		})
+	}
+}
 
-			UpdateVirtualNetworkStatusFromAzure(v, tc.r)
+func TestVirtualNetworkConnectionDetails(t *testing.T) {
+	cases := []struct {
+		name string
+		o    v1beta1.VirtualNetworkObservation
+		want managed.ConnectionDetails
+	}{
+		{
+			name: "Full",
+			o: v1beta1.VirtualNetworkObservation{
+				ID:              id,
+				AddressPrefixes: []string{"10.0.0.0/16", "10.1.0.0/16"},
+			},
+			want: managed.ConnectionDetails{
+				ConnectionKeyID:              []byte(id),
+				ConnectionKeyAddressPrefixes: []byte("10.0.0.0/16,10.1.0.0/16"),
+			},
+		},
+	}
 
-			// make sure that internal resource status hasn't changed
-			if diff := cmp.Diff(mockCondition, v.Status.ResourceStatus.Conditions[0]); diff != "" {
-				t.Errorf("UpdateVirtualNetworkStatusFromAzure(...): -want, +got\n%s", diff)
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := VirtualNetworkConnectionDetails(tc.o)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("VirtualNetworkConnectionDetails(...): -want, +got\n%s", diff)
 			}
+		})
+	}
+}
 
-			// make sure that other resource parameters are updated
-			tc.want.ResourceStatus = resourceStatus
-			if diff := cmp.Diff(tc.want, v.Status); diff != "" {
-				t.Errorf("UpdateVirtualNetworkStatusFromAzure(...): -want, +got\n%s", diff)
+func TestLateInitializeVirtualNetwork(t *testing.T) {
+	cases := []struct {
+		name string
+		r    *v1beta1.VirtualNetwork
+		az   networkmgmt.VirtualNetwork
+		want *v1beta1.VirtualNetwork
+	}{
+		{
+			name: "Empty",
+			r:    &v1beta1.VirtualNetwork{},
+			az: networkmgmt.VirtualNetwork{
+				Location: azure.ToStringPtr(location),
+				Tags:     azure.ToStringPtrMap(tags),
+			},
+			want: &v1beta1.VirtualNetwork{
+				Spec: v1beta1.VirtualNetworkSpec{
+					ForProvider: v1beta1.VirtualNetworkParameters{
+						Location: location,
+						Tags:     tags,
+					},
+				},
+			},
+		},
+		{
+			name: "Set",
+			r: &v1beta1.VirtualNetwork{
+				Spec: v1beta1.VirtualNetworkSpec{
+					ForProvider: v1beta1.VirtualNetworkParameters{
+						Location: location,
+						Tags:     map[string]string{"three": "test"},
+					},
+				},
+			},
+			az: networkmgmt.VirtualNetwork{
+				Location: azure.ToStringPtr("other-location"),
+				Tags:     azure.ToStringPtrMap(tags),
+			},
+			want: &v1beta1.VirtualNetwork{
+				Spec: v1beta1.VirtualNetworkSpec{
+					ForProvider: v1beta1.VirtualNetworkParameters{
+						Location: location,
+						Tags:     map[string]string{"three": "test"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			LateInitializeVirtualNetwork(tc.r, tc.az, apisv1beta1.TagPolicyReplace)
+			if diff := cmp.Diff(tc.want, tc.r); diff != "" {
+				t.Errorf("LateInitializeVirtualNetwork(...): -want, +got\n%s", diff)
 			}
 		})
 	}
@@ -358,15 +474,15 @@ func TestUpdateVirtualNetworkStatusFromAzure(t *testing.T) {
 func TestNewSubnetParameters(t *testing.T) {
 	cases := []struct {
 		name string
-		r    *v1alpha3.Subnet
+		r    *v1beta1.Subnet
 		want networkmgmt.Subnet
 	}{
 		{
 			name: "Successful",
-			r: &v1alpha3.Subnet{
+			r: &v1beta1.Subnet{
 				ObjectMeta: metav1.ObjectMeta{UID: uid},
-				Spec: v1alpha3.SubnetSpec{
-					SubnetPropertiesFormat: v1alpha3.SubnetPropertiesFormat{
+				Spec: v1beta1.SubnetSpec{
+					ForProvider: v1beta1.SubnetParameters{
 						AddressPrefix: addressPrefix,
 					},
 				},
@@ -393,23 +509,32 @@ func TestNewSubnetParameters(t *testing.T) {
 func TestNewServiceEndpoints(t *testing.T) {
 	cases := []struct {
 		name string
-		r    []v1alpha3.ServiceEndpointPropertiesFormat
+		r    []v1beta1.ServiceEndpoint
 		want *[]networkmgmt.ServiceEndpointPropertiesFormat
 	}{
 		{
 			name: "SuccessfulNotSet",
-			r:    []v1alpha3.ServiceEndpointPropertiesFormat{},
+			r:    []v1beta1.ServiceEndpoint{},
 			want: &[]networkmgmt.ServiceEndpointPropertiesFormat{},
 		},
 		{
 			name: "SuccessfulSet",
-			r: []v1alpha3.ServiceEndpointPropertiesFormat{
+			r: []v1beta1.ServiceEndpoint{
 				{Service: serviceEndpoint},
 			},
 			want: &[]networkmgmt.ServiceEndpointPropertiesFormat{
 				{Service: &serviceEndpoint},
 			},
 		},
+		{
+			name: "SuccessfulSetLocations",
+			r: []v1beta1.ServiceEndpoint{
+				{Service: serviceEndpoint, Locations: []string{location}},
+			},
+			want: &[]networkmgmt.ServiceEndpointPropertiesFormat{
+				{Service: &serviceEndpoint, Locations: &[]string{location}},
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -425,15 +550,15 @@ func TestNewServiceEndpoints(t *testing.T) {
 func TestSubnetNeedsUpdate(t *testing.T) {
 	cases := []struct {
 		name string
-		kube *v1alpha3.Subnet
+		kube *v1beta1.Subnet
 		az   networkmgmt.Subnet
 		want bool
 	}{
 		{
 			name: "NeedsUpdate",
-			kube: &v1alpha3.Subnet{
-				Spec: v1alpha3.SubnetSpec{
-					SubnetPropertiesFormat: v1alpha3.SubnetPropertiesFormat{
+			kube: &v1beta1.Subnet{
+				Spec: v1beta1.SubnetSpec{
+					ForProvider: v1beta1.SubnetParameters{
 						AddressPrefix: "10.1.0.0/16",
 					},
 				},
@@ -447,9 +572,9 @@ func TestSubnetNeedsUpdate(t *testing.T) {
 		},
 		{
 			name: "NoUpdate",
-			kube: &v1alpha3.Subnet{
-				Spec: v1alpha3.SubnetSpec{
-					SubnetPropertiesFormat: v1alpha3.SubnetPropertiesFormat{
+			kube: &v1beta1.Subnet{
+				Spec: v1beta1.SubnetSpec{
+					ForProvider: v1beta1.SubnetParameters{
 						AddressPrefix: addressPrefix,
 					},
 				},
@@ -473,71 +598,266 @@ func TestSubnetNeedsUpdate(t *testing.T) {
 	}
 }
 
-func TestUpdateSubnetStatusFromAzure(t *testing.T) {
-	mockCondition := xpv1.Condition{Message: "mockMessage"}
-	resourceStatus := xpv1.ResourceStatus{
-		ConditionedStatus: xpv1.ConditionedStatus{
-			Conditions: []xpv1.Condition{mockCondition},
+func TestSubnetDrift(t *testing.T) {
+	cases := []struct {
+		name string
+		kube *v1beta1.Subnet
+		az   networkmgmt.Subnet
+		want []string
+	}{
+		{
+			name: "Drifted",
+			kube: &v1beta1.Subnet{
+				Spec: v1beta1.SubnetSpec{
+					ForProvider: v1beta1.SubnetParameters{
+						AddressPrefix: "10.1.0.0/16",
+					},
+				},
+			},
+			az: networkmgmt.Subnet{
+				SubnetPropertiesFormat: &networkmgmt.SubnetPropertiesFormat{
+					AddressPrefix: &addressPrefix,
+				},
+			},
+			want: []string{"spec.forProvider.addressPrefix"},
+		},
+		{
+			name: "NotDrifted",
+			kube: &v1beta1.Subnet{
+				Spec: v1beta1.SubnetSpec{
+					ForProvider: v1beta1.SubnetParameters{
+						AddressPrefix: addressPrefix,
+					},
+				},
+			},
+			az: networkmgmt.Subnet{
+				SubnetPropertiesFormat: &networkmgmt.SubnetPropertiesFormat{
+					AddressPrefix: &addressPrefix,
+				},
+			},
 		},
 	}
 
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := SubnetDrift(tc.kube, tc.az)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("SubnetDrift(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLateInitializeSubnet(t *testing.T) {
+	locations := []string{"westus2", "westcentralus"}
+
 	cases := []struct {
 		name string
-		r    networkmgmt.Subnet
-		want v1alpha3.SubnetStatus
+		r    *v1beta1.Subnet
+		az   networkmgmt.Subnet
+		want *v1beta1.Subnet
 	}{
 		{
-			name: "SuccessfulFull",
-			r: networkmgmt.Subnet{
+			name: "NoProperties",
+			r:    &v1beta1.Subnet{},
+			az:   networkmgmt.Subnet{},
+			want: &v1beta1.Subnet{},
+		},
+		{
+			name: "ServiceEndpointLocations",
+			r: &v1beta1.Subnet{
+				Spec: v1beta1.SubnetSpec{
+					ForProvider: v1beta1.SubnetParameters{
+						ServiceEndpoints: []v1beta1.ServiceEndpoint{
+							{Service: serviceEndpoint},
+							{Service: "Microsoft.Storage", Locations: []string{location}},
+						},
+					},
+				},
+			},
+			az: networkmgmt.Subnet{
+				SubnetPropertiesFormat: &networkmgmt.SubnetPropertiesFormat{
+					ServiceEndpoints: &[]networkmgmt.ServiceEndpointPropertiesFormat{
+						{Service: azure.ToStringPtr(serviceEndpoint), Locations: &locations},
+						{Service: azure.ToStringPtr("Microsoft.Storage"), Locations: &locations},
+					},
+				},
+			},
+			want: &v1beta1.Subnet{
+				Spec: v1beta1.SubnetSpec{
+					ForProvider: v1beta1.SubnetParameters{
+						ServiceEndpoints: []v1beta1.ServiceEndpoint{
+							{Service: serviceEndpoint, Locations: locations},
+							{Service: "Microsoft.Storage", Locations: []string{location}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			LateInitializeSubnet(tc.r, tc.az)
+			if diff := cmp.Diff(tc.want, tc.r); diff != "" {
+				t.Errorf("LateInitializeSubnet(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAddressSpaceShrinks(t *testing.T) {
+	az := networkmgmt.VirtualNetwork{
+		VirtualNetworkPropertiesFormat: &networkmgmt.VirtualNetworkPropertiesFormat{
+			AddressSpace: &networkmgmt.AddressSpace{AddressPrefixes: &addressPrefixes},
+		},
+	}
+	cases := map[string]struct {
+		prefixes []string
+		az       networkmgmt.VirtualNetwork
+		want     bool
+	}{
+		"PrefixRemoved": {
+			prefixes: []string{"10.1.0.0/16"},
+			az:       az,
+			want:     true,
+		},
+		"PrefixAdded": {
+			prefixes: []string{addressPrefix, "10.1.0.0/16"},
+			az:       az,
+			want:     false,
+		},
+		"NoAddressSpace": {
+			prefixes: []string{"10.1.0.0/16"},
+			az:       networkmgmt.VirtualNetwork{},
+			want:     false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			v := &v1beta1.VirtualNetwork{}
+			v.Spec.ForProvider.AddressPrefixes = tc.prefixes
+			got := AddressSpaceShrinks(v, tc.az)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("AddressSpaceShrinks(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateSubnetObservation(t *testing.T) {
+	cases := []struct {
+		name string
+		az   networkmgmt.Subnet
+		want v1beta1.SubnetObservation
+	}{
+		{
+			name: "Full",
+			az: networkmgmt.Subnet{
 				Etag: azure.ToStringPtr(etag),
 				ID:   azure.ToStringPtr(id),
 				SubnetPropertiesFormat: &networkmgmt.SubnetPropertiesFormat{
+					AddressPrefix:        azure.ToStringPtr(addressPrefix),
+					NetworkSecurityGroup: &networkmgmt.SecurityGroup{ID: azure.ToStringPtr("nsg-id")},
+					RouteTable:           &networkmgmt.RouteTable{ID: azure.ToStringPtr("rt-id")},
+					NatGateway:           &networkmgmt.SubResource{ID: azure.ToStringPtr("nat-id")},
+					IPConfigurations: &[]networkmgmt.IPConfiguration{
+						{ID: azure.ToStringPtr("ipconfig-id")},
+					},
+					PrivateEndpoints: &[]networkmgmt.PrivateEndpoint{
+						{ID: azure.ToStringPtr("pe-id")},
+					},
+					Delegations: &[]networkmgmt.Delegation{
+						{ServiceDelegationPropertiesFormat: &networkmgmt.ServiceDelegationPropertiesFormat{ServiceName: azure.ToStringPtr("Microsoft.Sql/managedInstances")}},
+					},
 					Purpose:           azure.ToStringPtr(purpose),
 					ProvisioningState: azure.ToStringPtr("Succeeded"),
 				},
 			},
-			want: v1alpha3.SubnetStatus{
-				State:   string(networkmgmt.Succeeded),
-				ID:      id,
-				Etag:    etag,
-				Purpose: purpose,
+			want: v1beta1.SubnetObservation{
+				ProvisioningState:      string(networkmgmt.Succeeded),
+				Etag:                   etag,
+				ID:                     id,
+				Purpose:                purpose,
+				AddressPrefixes:        []string{addressPrefix},
+				NetworkSecurityGroupID: "nsg-id",
+				RouteTableID:           "rt-id",
+				NATGatewayID:           "nat-id",
+				IPConfigurationIDs:     []string{"ipconfig-id"},
+				PrivateEndpointIDs:     []string{"pe-id"},
+				Delegations:            []string{"Microsoft.Sql/managedInstances"},
 			},
 		},
 		{
-			name: "SuccessfulPartial",
-			r: networkmgmt.Subnet{
+			name: "NoProperties",
+			az: networkmgmt.Subnet{
 				ID: azure.ToStringPtr(id),
-				SubnetPropertiesFormat: &networkmgmt.SubnetPropertiesFormat{
-					ProvisioningState: azure.ToStringPtr("Succeeded"),
-				},
 			},
-			want: v1alpha3.SubnetStatus{
-				State: string(networkmgmt.Succeeded),
-				ID:    id,
+			want: v1beta1.SubnetObservation{
+				ID: id,
 			},
 		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-
-			v := &v1alpha3.Subnet{
-				Status: v1alpha3.SubnetStatus{
-					ResourceStatus: resourceStatus,
-				},
+			got := GenerateSubnetObservation(tc.az)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GenerateSubnetObservation(...): -want, +got\n%s", diff)
 			}
+		})
+	}
+}
 
-			UpdateSubnetStatusFromAzure(v, tc.r)
+func TestSubnetConnectionDetails(t *testing.T) {
+	vnetID := "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet"
 
-			// make sure that internal resource status hasn't changed
-			if diff := cmp.Diff(mockCondition, v.Status.ResourceStatus.Conditions[0]); diff != "" {
-				t.Errorf("UpdateSubnetStatusFromAzure(...): -want, +got\n%s", diff)
-			}
+	cases := []struct {
+		name string
+		o    v1beta1.SubnetObservation
+		want managed.ConnectionDetails
+	}{
+		{
+			name: "Full",
+			o: v1beta1.SubnetObservation{
+				ID:              vnetID + "/subnets/subnet",
+				AddressPrefixes: []string{"10.0.0.0/24", "10.0.1.0/24"},
+			},
+			want: managed.ConnectionDetails{
+				ConnectionKeyID:               []byte(vnetID + "/subnets/subnet"),
+				ConnectionKeyAddressPrefix:    []byte("10.0.0.0/24"),
+				ConnectionKeyAddressPrefixes:  []byte("10.0.0.0/24,10.0.1.0/24"),
+				ConnectionKeyVirtualNetworkID: []byte(vnetID),
+			},
+		},
+		{
+			name: "NoAddressPrefixes",
+			o: v1beta1.SubnetObservation{
+				ID: vnetID + "/Subnets/subnet",
+			},
+			want: managed.ConnectionDetails{
+				ConnectionKeyID:               []byte(vnetID + "/Subnets/subnet"),
+				ConnectionKeyAddressPrefixes:  []byte(""),
+				ConnectionKeyVirtualNetworkID: []byte(vnetID),
+			},
+		},
+		{
+			name: "NotASubnetID",
+			o: v1beta1.SubnetObservation{
+				ID: id,
+			},
+			want: managed.ConnectionDetails{
+				ConnectionKeyID:              []byte(id),
+				ConnectionKeyAddressPrefixes: []byte(""),
+			},
+		},
+	}
 
-			// make sure that other resource parameters are updated
-			tc.want.ResourceStatus = resourceStatus
-			if diff := cmp.Diff(tc.want, v.Status); diff != "" {
-				t.Errorf("UpdateSubnetStatusFromAzure(...): -want, +got\n%s", diff)
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := SubnetConnectionDetails(tc.o)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("SubnetConnectionDetails(...): -want, +got\n%s", diff)
 			}
 		})
 	}
@@ -0,0 +1,177 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package observation
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2018-05-01/resources"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/crossplane/provider-azure/apis/v1alpha3"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+// previewSuffix is the suffix of the API versions of previews.
+const previewSuffix = "-preview"
+
+// Error strings.
+const (
+	errParseID         = "cannot determine the resource type of resource ID %q"
+	errGetProvider     = "cannot get resource provider %q"
+	errNoAPIVersion    = "cannot find an API version of resource type %q"
+	errGetResource     = "cannot get resource"
+	errMarshalResource = "cannot marshal resource properties"
+)
+
+// An Observer reads arbitrary Azure resources.
+type Observer interface {
+	Observe(ctx context.Context, id, apiVersion string) (v1alpha3.ObservedAzureResource, error)
+}
+
+// A Client reads arbitrary Azure resources through the generic resources API.
+type Client struct {
+	resources resources.Client
+	providers resources.ProvidersClient
+}
+
+// NewClient returns a Client for the subscription of the supplied credentials.
+func NewClient(creds map[string]string, auth autorest.Authorizer) *Client {
+	rc := resources.NewClientWithBaseURI(azure.BaseURI(creds), creds[azure.CredentialsKeySubscriptionID])
+	rc.Authorizer = auth
+	_ = rc.AddToUserAgent(azure.UserAgent)
+
+	pc := resources.NewProvidersClientWithBaseURI(azure.BaseURI(creds), creds[azure.CredentialsKeySubscriptionID])
+	pc.Authorizer = auth
+	_ = pc.AddToUserAgent(azure.UserAgent)
+
+	return &Client{resources: rc, providers: pc}
+}
+
+// Observe the resource with the supplied ID. The latest non-preview API
+// version of its type is used if no API version is supplied.
+func (c *Client) Observe(ctx context.Context, id, apiVersion string) (v1alpha3.ObservedAzureResource, error) {
+	if apiVersion == "" {
+		v, err := c.latestAPIVersion(ctx, id)
+		if err != nil {
+			return v1alpha3.ObservedAzureResource{}, err
+		}
+		apiVersion = v
+	}
+	r, err := c.resources.GetByID(ctx, id, apiVersion)
+	if err != nil {
+		return v1alpha3.ObservedAzureResource{}, errors.Wrap(err, errGetResource)
+	}
+	return GenerateObservation(r, apiVersion)
+}
+
+func (c *Client) latestAPIVersion(ctx context.Context, id string) (string, error) {
+	ns, rt, err := ParseResourceType(id)
+	if err != nil {
+		return "", err
+	}
+	p, err := c.providers.Get(ctx, ns, "")
+	if err != nil {
+		return "", errors.Wrapf(err, errGetProvider, ns)
+	}
+	if p.ResourceTypes != nil {
+		for _, t := range *p.ResourceTypes {
+			if !strings.EqualFold(azure.ToString(t.ResourceType), rt) || t.APIVersions == nil {
+				continue
+			}
+			if v := LatestAPIVersion(*t.APIVersions); v != "" {
+				return v, nil
+			}
+		}
+	}
+	return "", errors.Errorf(errNoAPIVersion, ns+"/"+rt)
+}
+
+// ParseResourceType returns the resource provider namespace and the resource
+// type of the supplied resource ID, e.g. Microsoft.Network and
+// virtualNetworks/subnets for the ID of a subnet.
+func ParseResourceType(id string) (namespace, resourceType string, err error) {
+	segs := strings.Split(strings.Trim(id, "/"), "/")
+	p := -1
+	for i, s := range segs {
+		if strings.EqualFold(s, "providers") {
+			p = i
+		}
+	}
+	if p == -1 {
+		// Resource groups are the only resources whose IDs have no provider.
+		if len(segs) == 4 && strings.EqualFold(segs[2], "resourceGroups") {
+			return "Microsoft.Resources", "resourceGroups", nil
+		}
+		return "", "", errors.Errorf(errParseID, id)
+	}
+	// The provider namespace is followed by pairs of type and name segments.
+	rest := segs[p+1:]
+	if len(rest) < 3 || len(rest)%2 == 0 {
+		return "", "", errors.Errorf(errParseID, id)
+	}
+	types := make([]string, 0, len(rest)/2)
+	for i := 1; i < len(rest); i += 2 {
+		types = append(types, rest[i])
+	}
+	return rest[0], strings.Join(types, "/"), nil
+}
+
+// LatestAPIVersion returns the latest of the supplied API versions, preferring
+// versions that are not previews. API versions are dates, so they are ordered
+// lexically.
+func LatestAPIVersion(versions []string) string {
+	if len(versions) == 0 {
+		return ""
+	}
+	v := make([]string, len(versions))
+	copy(v, versions)
+	sort.Sort(sort.Reverse(sort.StringSlice(v)))
+	for _, s := range v {
+		if !strings.HasSuffix(s, previewSuffix) {
+			return s
+		}
+	}
+	return v[0]
+}
+
+// GenerateObservation returns the observation of the supplied resource, read
+// with the supplied API version.
+func GenerateObservation(r resources.GenericResource, apiVersion string) (v1alpha3.ObservedAzureResource, error) {
+	o := v1alpha3.ObservedAzureResource{
+		ID:         azure.ToString(r.ID),
+		Name:       azure.ToString(r.Name),
+		Type:       azure.ToString(r.Type),
+		APIVersion: apiVersion,
+		Location:   azure.ToString(r.Location),
+		Kind:       azure.ToString(r.Kind),
+		Tags:       azure.ToStringMap(r.Tags),
+	}
+	if r.Properties != nil {
+		b, err := json.Marshal(r.Properties)
+		if err != nil {
+			return v1alpha3.ObservedAzureResource{}, errors.Wrap(err, errMarshalResource)
+		}
+		o.Properties = &runtime.RawExtension{Raw: b}
+	}
+	return o, nil
+}
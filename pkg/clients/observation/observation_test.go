@@ -0,0 +1,138 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package observation
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2018-05-01/resources"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/v1alpha3"
+)
+
+func TestParseResourceType(t *testing.T) {
+	type want struct {
+		namespace    string
+		resourceType string
+		err          error
+	}
+
+	cases := map[string]struct {
+		id   string
+		want want
+	}{
+		"TopLevel": {
+			id:   "/subscriptions/sub/resourceGroups/group/providers/Microsoft.Network/virtualNetworks/vnet",
+			want: want{namespace: "Microsoft.Network", resourceType: "virtualNetworks"},
+		},
+		"Nested": {
+			id:   "/subscriptions/sub/resourceGroups/group/providers/Microsoft.Network/virtualNetworks/vnet/subnets/subnet",
+			want: want{namespace: "Microsoft.Network", resourceType: "virtualNetworks/subnets"},
+		},
+		"Extension": {
+			id:   "/subscriptions/sub/resourceGroups/group/providers/Microsoft.Storage/storageAccounts/acct/providers/Microsoft.Authorization/locks/lock",
+			want: want{namespace: "Microsoft.Authorization", resourceType: "locks"},
+		},
+		"ResourceGroup": {
+			id:   "/subscriptions/sub/resourceGroups/group",
+			want: want{namespace: "Microsoft.Resources", resourceType: "resourceGroups"},
+		},
+		"MissingName": {
+			id:   "/subscriptions/sub/resourceGroups/group/providers/Microsoft.Network/virtualNetworks",
+			want: want{err: errors.Errorf(errParseID, "/subscriptions/sub/resourceGroups/group/providers/Microsoft.Network/virtualNetworks")},
+		},
+		"NotAnID": {
+			id:   "vnet",
+			want: want{err: errors.Errorf(errParseID, "vnet")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ns, rt, err := ParseResourceType(tc.id)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("ParseResourceType(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.namespace, ns); diff != "" {
+				t.Errorf("ParseResourceType(...): -want namespace, +got namespace:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.resourceType, rt); diff != "" {
+				t.Errorf("ParseResourceType(...): -want type, +got type:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLatestAPIVersion(t *testing.T) {
+	cases := map[string]struct {
+		versions []string
+		want     string
+	}{
+		"None": {},
+		"PreferStable": {
+			versions: []string{"2019-11-01", "2020-08-01-preview", "2020-05-01"},
+			want:     "2020-05-01",
+		},
+		"OnlyPreviews": {
+			versions: []string{"2020-01-01-preview", "2020-08-01-preview"},
+			want:     "2020-08-01-preview",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, LatestAPIVersion(tc.versions)); diff != "" {
+				t.Errorf("LatestAPIVersion(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateObservation(t *testing.T) {
+	r := resources.GenericResource{
+		ID:       to.StringPtr("/subscriptions/sub/resourceGroups/group/providers/Microsoft.Network/virtualNetworks/vnet"),
+		Name:     to.StringPtr("vnet"),
+		Type:     to.StringPtr("Microsoft.Network/virtualNetworks"),
+		Location: to.StringPtr("westus2"),
+		Tags:     map[string]*string{"team": to.StringPtr("network")},
+		Properties: map[string]interface{}{
+			"addressSpace": map[string]interface{}{"addressPrefixes": []string{"10.0.0.0/16"}},
+		},
+	}
+	want := v1alpha3.ObservedAzureResource{
+		ID:         "/subscriptions/sub/resourceGroups/group/providers/Microsoft.Network/virtualNetworks/vnet",
+		Name:       "vnet",
+		Type:       "Microsoft.Network/virtualNetworks",
+		APIVersion: "2020-05-01",
+		Location:   "westus2",
+		Tags:       map[string]string{"team": "network"},
+		Properties: &runtime.RawExtension{Raw: []byte(`{"addressSpace":{"addressPrefixes":["10.0.0.0/16"]}}`)},
+	}
+	got, err := GenerateObservation(r, "2020-05-01")
+	if err != nil {
+		t.Fatalf("GenerateObservation(...): %s", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GenerateObservation(...): -want, +got:\n%s", diff)
+	}
+}
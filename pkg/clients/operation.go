@@ -0,0 +1,172 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane/provider-azure/apis/v1alpha3"
+)
+
+const (
+	// HeaderClientRequestID is the header Azure uses to correlate the requests
+	// of a client with its own logs.
+	HeaderClientRequestID = "x-ms-client-request-id"
+
+	// AsyncOperationAcceptTimeout is how long an operation whose initial
+	// request went unanswered, e.g. because it timed out, is considered to be
+	// in flight.
+	AsyncOperationAcceptTimeout = 10 * time.Minute
+)
+
+// TypeLastOperation is the type of the condition that reports the state of
+// the last asynchronous operation started for a managed resource.
+const TypeLastOperation xpv1.ConditionType = "LastOperation"
+
+// Reasons a last operation condition may be reported for.
+const (
+	ReasonOperationInProgress xpv1.ConditionReason = "OperationInProgress"
+	ReasonOperationSucceeded  xpv1.ConditionReason = "OperationSucceeded"
+	ReasonOperationFailed     xpv1.ConditionReason = "OperationFailed"
+	ReasonQuotaExceeded       xpv1.ConditionReason = "QuotaExceeded"
+	ReasonSKUNotAvailable     xpv1.ConditionReason = "SKUNotAvailable"
+)
+
+type clientRequestIDKey struct{}
+
+// WithClientRequestID returns a copy of the supplied context that makes
+// requests prepared by WithClientRequestIDFromContext carry the supplied ID.
+func WithClientRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, clientRequestIDKey{}, id)
+}
+
+// WithClientRequestIDFromContext returns a PrepareDecorator that sets the
+// HeaderClientRequestID header of a request to the ID stored in its context
+// by WithClientRequestID, if any. It is meant to be used as the
+// RequestInspector of Azure SDK clients.
+func WithClientRequestIDFromContext() autorest.PrepareDecorator {
+	return func(p autorest.Preparer) autorest.Preparer {
+		return autorest.PreparerFunc(func(r *http.Request) (*http.Request, error) {
+			r, err := p.Prepare(r)
+			if err != nil {
+				return r, err
+			}
+			if id, ok := r.Context().Value(clientRequestIDKey{}).(string); ok && id != "" {
+				if r.Header == nil {
+					r.Header = make(http.Header)
+				}
+				r.Header.Set(HeaderClientRequestID, id)
+			}
+			return r, nil
+		})
+	}
+}
+
+// NewAsyncOperation returns an in progress AsyncOperation for the supplied
+// HTTP method with a new client request ID. It is meant to be recorded in the
+// status of a resource before the initial request is made, so that the
+// operation is known even if the request times out.
+func NewAsyncOperation(method string) v1alpha3.AsyncOperation {
+	now := metav1.Now()
+	return v1alpha3.AsyncOperation{
+		Method:          method,
+		Status:          AsyncOperationStatusInProgress,
+		ClientRequestID: uuid.New().String(),
+		StartTime:       &now,
+	}
+}
+
+// IsAsyncOperationInFlight returns true if the supplied operation is known to
+// be in progress, or if its initial request went unanswered less than
+// AsyncOperationAcceptTimeout ago.
+func IsAsyncOperationInFlight(op v1alpha3.AsyncOperation) bool {
+	if op.Status != AsyncOperationStatusInProgress {
+		return false
+	}
+	if op.PollingURL != "" || op.StartTime == nil {
+		return true
+	}
+	return time.Since(op.StartTime.Time) < AsyncOperationAcceptTimeout
+}
+
+// IsAsyncOperationFailed returns true if the supplied operation failed or was
+// canceled.
+func IsAsyncOperationFailed(op v1alpha3.AsyncOperation) bool {
+	return op.Status == AsyncOperationStatusFailed || op.Status == AsyncOperationStatusCanceled
+}
+
+// LastOperationCondition returns the condition that reports the state of the
+// supplied operation. The reason of a failed operation tells the failures
+// that no retry can fix, like an exhausted quota or a SKU that is not
+// available in the region, apart from the others.
+func LastOperationCondition(op v1alpha3.AsyncOperation) xpv1.Condition {
+	c := xpv1.Condition{
+		Type:               TypeLastOperation,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonOperationSucceeded,
+	}
+	switch {
+	case op.Status == AsyncOperationStatusInProgress:
+		c.Status = corev1.ConditionUnknown
+		c.Reason = ReasonOperationInProgress
+	case IsAsyncOperationFailed(op):
+		c.Status = corev1.ConditionFalse
+		c.Reason = failureReason(op.ErrorMessage)
+		c.Message = op.ErrorMessage
+		if c.Message == "" {
+			c.Message = op.Status
+		}
+	}
+	return c
+}
+
+// failureReason returns the reason of an operation that failed with the
+// supplied error message. Azure reports these failures with an error code,
+// e.g. QuotaExceeded or SkuNotAvailable, that the message includes.
+func failureReason(msg string) xpv1.ConditionReason {
+	m := strings.ToLower(msg)
+	switch {
+	case strings.Contains(m, "quota"):
+		return ReasonQuotaExceeded
+	case strings.Contains(m, "skunotavailable"), strings.Contains(m, "notavailableforsubscription"), strings.Contains(m, "not available in"):
+		return ReasonSKUNotAvailable
+	}
+	return ReasonOperationFailed
+}
+
+// IsRejected returns true if the supplied error is the response of Azure to a
+// request, as opposed to an error that occurred before a response was
+// received.
+func IsRejected(err error) bool {
+	de, ok := err.(autorest.DetailedError)
+	if !ok {
+		return false
+	}
+	sc, ok := de.StatusCode.(int)
+	return ok && sc != 0
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/v1alpha3"
+)
+
+func TestWithClientRequestIDFromContext(t *testing.T) {
+	cases := map[string]struct {
+		ctx  context.Context
+		want string
+	}{
+		"NoID": {
+			ctx: context.Background(),
+		},
+		"ID": {
+			ctx:  WithClientRequestID(context.Background(), "cool-id"),
+			want: "cool-id",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r, err := autorest.Prepare((&http.Request{}).WithContext(tc.ctx), WithClientRequestIDFromContext())
+			if err != nil {
+				t.Fatalf("Prepare(...): %s", err)
+			}
+			if got := r.Header.Get(HeaderClientRequestID); got != tc.want {
+				t.Errorf("Header.Get(...): want %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestIsAsyncOperationInFlight(t *testing.T) {
+	recent := metav1.Now()
+	old := metav1.NewTime(time.Now().Add(-2 * AsyncOperationAcceptTimeout))
+	cases := map[string]struct {
+		op   v1alpha3.AsyncOperation
+		want bool
+	}{
+		"Done": {
+			op: v1alpha3.AsyncOperation{PollingURL: "crossplane.io", Status: "Succeeded"},
+		},
+		"Accepted": {
+			op:   v1alpha3.AsyncOperation{PollingURL: "crossplane.io", Status: AsyncOperationStatusInProgress, StartTime: &old},
+			want: true,
+		},
+		"UnansweredRecently": {
+			op:   v1alpha3.AsyncOperation{Status: AsyncOperationStatusInProgress, StartTime: &recent},
+			want: true,
+		},
+		"UnansweredLongAgo": {
+			op: v1alpha3.AsyncOperation{Status: AsyncOperationStatusInProgress, StartTime: &old},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := IsAsyncOperationInFlight(tc.op); got != tc.want {
+				t.Errorf("IsAsyncOperationInFlight(...): want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestIsRejected(t *testing.T) {
+	cases := map[string]struct {
+		err  error
+		want bool
+	}{
+		"NotDetailed": {
+			err: errors.New("boom"),
+		},
+		"NoResponse": {
+			err: autorest.DetailedError{StatusCode: autorest.UndefinedStatusCode},
+		},
+		"Response": {
+			err:  autorest.DetailedError{StatusCode: http.StatusBadRequest},
+			want: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := IsRejected(tc.err); got != tc.want {
+				t.Errorf("IsRejected(...): want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestLastOperationCondition(t *testing.T) {
+	cases := map[string]struct {
+		op   v1alpha3.AsyncOperation
+		want xpv1.Condition
+	}{
+		"InProgress": {
+			op:   v1alpha3.AsyncOperation{Status: AsyncOperationStatusInProgress},
+			want: xpv1.Condition{Type: TypeLastOperation, Status: corev1.ConditionUnknown, Reason: ReasonOperationInProgress},
+		},
+		"Succeeded": {
+			op:   v1alpha3.AsyncOperation{Status: "Succeeded"},
+			want: xpv1.Condition{Type: TypeLastOperation, Status: corev1.ConditionTrue, Reason: ReasonOperationSucceeded},
+		},
+		"Canceled": {
+			op:   v1alpha3.AsyncOperation{Status: AsyncOperationStatusCanceled},
+			want: xpv1.Condition{Type: TypeLastOperation, Status: corev1.ConditionFalse, Reason: ReasonOperationFailed, Message: AsyncOperationStatusCanceled},
+		},
+		"QuotaExceeded": {
+			op:   v1alpha3.AsyncOperation{Status: AsyncOperationStatusFailed, ErrorMessage: `Code="QuotaExceeded" Message="Operation results in exceeding quota limits of Core."`},
+			want: xpv1.Condition{Type: TypeLastOperation, Status: corev1.ConditionFalse, Reason: ReasonQuotaExceeded, Message: `Code="QuotaExceeded" Message="Operation results in exceeding quota limits of Core."`},
+		},
+		"SKUNotAvailable": {
+			op:   v1alpha3.AsyncOperation{Status: AsyncOperationStatusFailed, ErrorMessage: `Code="SkuNotAvailable"`},
+			want: xpv1.Condition{Type: TypeLastOperation, Status: corev1.ConditionFalse, Reason: ReasonSKUNotAvailable, Message: `Code="SkuNotAvailable"`},
+		},
+		"Failed": {
+			op:   v1alpha3.AsyncOperation{Status: AsyncOperationStatusFailed, ErrorMessage: "boom"},
+			want: xpv1.Condition{Type: TypeLastOperation, Status: corev1.ConditionFalse, Reason: ReasonOperationFailed, Message: "boom"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := LastOperationCondition(tc.op)
+			if diff := cmp.Diff(tc.want, got, test.EquateConditions()); diff != "" {
+				t.Errorf("LastOperationCondition(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// Provisioning states Azure reports while it changes a resource. Azure
+// rejects writes to a resource in either state with a conflict.
+const (
+	ProvisioningStateUpdating = "Updating"
+	ProvisioningStateDeleting = "Deleting"
+)
+
+// ReasonUpdating indicates that Azure is updating a resource.
+const ReasonUpdating xpv1.ConditionReason = "Updating"
+
+// Updating returns a condition that indicates Azure is updating a resource.
+func Updating() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonUpdating,
+	}
+}
+
+// IsTransitioning returns true if a resource in the supplied provisioning
+// state is being updated or deleted. Writes to such a resource should wait
+// until it is observed in another state.
+func IsTransitioning(state string) bool {
+	return state == ProvisioningStateUpdating || state == ProvisioningStateDeleting
+}
+
+// ProvisioningCondition returns the Ready condition of an existing resource in
+// the supplied provisioning state.
+func ProvisioningCondition(state string) xpv1.Condition {
+	switch state {
+	case ProvisioningStateUpdating:
+		return Updating()
+	case ProvisioningStateDeleting:
+		return xpv1.Deleting()
+	default:
+		return xpv1.Available()
+	}
+}
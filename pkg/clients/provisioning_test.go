@@ -0,0 +1,60 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestProvisioningCondition(t *testing.T) {
+	cases := map[string]struct {
+		state            string
+		wantCondition    xpv1.Condition
+		wantTransitional bool
+	}{
+		"Succeeded": {
+			state:         "Succeeded",
+			wantCondition: xpv1.Available(),
+		},
+		"Updating": {
+			state:            ProvisioningStateUpdating,
+			wantCondition:    Updating(),
+			wantTransitional: true,
+		},
+		"Deleting": {
+			state:            ProvisioningStateDeleting,
+			wantCondition:    xpv1.Deleting(),
+			wantTransitional: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ProvisioningCondition(tc.state)
+			if diff := cmp.Diff(tc.wantCondition, got, test.EquateConditions()); diff != "" {
+				t.Errorf("ProvisioningCondition(...): -want, +got:\n%s", diff)
+			}
+			if got := IsTransitioning(tc.state); got != tc.wantTransitional {
+				t.Errorf("IsTransitioning(...): want %t, got %t", tc.wantTransitional, got)
+			}
+		})
+	}
+}
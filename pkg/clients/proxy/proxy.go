@@ -0,0 +1,168 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package proxy sends the requests of Azure SDK clients, including those that
+// acquire access tokens, through an HTTP or HTTPS proxy. Azure SDK clients
+// honor the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables of the
+// provider by default. The proxy of a ProviderConfig takes precedence over
+// them for requests authorized with its credentials.
+package proxy
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/pkg/errors"
+)
+
+// Error strings.
+const (
+	errParseURL = "cannot parse proxy URL"
+	errScheme   = "proxy URL scheme must be http or https"
+)
+
+// IMDSHost is the host of the Azure instance metadata service, which issues
+// the access tokens of managed identities. It is never proxied.
+const IMDSHost = "169.254.169.254"
+
+// A Config of a proxy.
+type Config struct {
+	// URL of the proxy.
+	URL *url.URL
+
+	// NoProxy lists hosts, domains and CIDR ranges that bypass the proxy, in
+	// the format of the NO_PROXY environment variable.
+	NoProxy []string
+}
+
+// NewConfig returns the Config of the proxy with the supplied URL and
+// exceptions.
+func NewConfig(rawurl string, noProxy []string) (*Config, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, errors.Wrap(err, errParseURL)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, errors.New(errScheme)
+	}
+	return &Config{URL: u, NoProxy: noProxy}, nil
+}
+
+// Bypass returns true if requests to the supplied host, optionally with a
+// port, bypass the proxy.
+func (c *Config) Bypass(host string) bool {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.ToLower(host)
+	if host == IMDSHost || host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	for _, np := range c.NoProxy {
+		np = strings.ToLower(strings.TrimSpace(np))
+		switch {
+		case np == "":
+			continue
+		case np == "*":
+			return true
+		case ip != nil:
+			if _, cidr, err := net.ParseCIDR(np); err == nil && cidr.Contains(ip) {
+				return true
+			}
+			if ip.Equal(net.ParseIP(np)) {
+				return true
+			}
+		case host == strings.TrimPrefix(np, "."):
+			return true
+		case strings.HasSuffix(host, "."+strings.TrimPrefix(np, ".")):
+			return true
+		}
+	}
+	return false
+}
+
+type contextKey struct{}
+
+// WithConfig returns a copy of the supplied context whose requests are sent
+// through the proxy of the supplied Config.
+func WithConfig(ctx context.Context, c *Config) context.Context {
+	return context.WithValue(ctx, contextKey{}, c)
+}
+
+// FromContext returns the Config of the proxy requests with the supplied
+// context are sent through, if any.
+func FromContext(ctx context.Context) (*Config, bool) {
+	c, ok := ctx.Value(contextKey{}).(*Config)
+	return c, ok
+}
+
+// FromRequest returns the URL of the proxy the supplied request is sent
+// through, or nil if it is not proxied. Requests whose context has a proxy
+// Config are sent through its proxy, and others through the proxy of the
+// environment variables.
+func FromRequest(req *http.Request) (*url.URL, error) {
+	c, ok := FromContext(req.Context())
+	if !ok {
+		return http.ProxyFromEnvironment(req)
+	}
+	if c.Bypass(req.URL.Host) {
+		return nil, nil
+	}
+	return c.URL, nil
+}
+
+// Enable proxying by context. It makes every Azure SDK client, and every other
+// user of the default HTTP transport, choose the proxy of each request with
+// FromRequest. It must be called before any client sends a request.
+func Enable() {
+	if t, ok := http.DefaultTransport.(*http.Transport); ok {
+		t.Proxy = FromRequest
+	}
+}
+
+// An Authorizer sends the requests it authorizes, including those that
+// acquire or refresh its access tokens, through a proxy.
+type Authorizer struct {
+	autorest.Authorizer
+	config *Config
+}
+
+// NewAuthorizer returns an Authorizer that sends the requests the supplied
+// authorizer authorizes through the proxy of the supplied Config.
+func NewAuthorizer(a autorest.Authorizer, c *Config) *Authorizer {
+	return &Authorizer{Authorizer: a, config: c}
+}
+
+// WithAuthorization returns a PrepareDecorator that adds the proxy Config to
+// the context of a request before it is authorized, so that access tokens are
+// acquired through the proxy too.
+func (a *Authorizer) WithAuthorization() autorest.PrepareDecorator {
+	return func(p autorest.Preparer) autorest.Preparer {
+		return autorest.PreparerFunc(func(r *http.Request) (*http.Request, error) {
+			r, err := p.Prepare(r)
+			if err != nil {
+				return r, err
+			}
+			r = r.WithContext(WithConfig(r.Context(), a.config))
+			return autorest.CreatePreparer(a.Authorizer.WithAuthorization()).Prepare(r)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-07-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+// DefaultThresholdPercent is the percentage of the limit of a quota whose
+// crossing fires a warning event, unless configured otherwise.
+const DefaultThresholdPercent = 80
+
+// Error strings.
+const (
+	errListCompute = "cannot list compute usage in location %q"
+	errListNetwork = "cannot list network usage in location %q"
+)
+
+// A Lister lists the usage of the quotas of a subscription in a location.
+type Lister interface {
+	List(ctx context.Context, location string) ([]v1beta1.QuotaUsage, error)
+}
+
+// A Client lists compute and network quota usage.
+type Client struct {
+	compute compute.UsageClient
+	network network.UsagesClient
+}
+
+// NewClient returns a Client for the subscription of the supplied credentials.
+func NewClient(creds map[string]string, auth autorest.Authorizer) *Client {
+	cc := compute.NewUsageClientWithBaseURI(azure.BaseURI(creds), creds[azure.CredentialsKeySubscriptionID])
+	cc.Authorizer = auth
+	_ = cc.AddToUserAgent(azure.UserAgent)
+
+	nc := network.NewUsagesClientWithBaseURI(azure.BaseURI(creds), creds[azure.CredentialsKeySubscriptionID])
+	nc.Authorizer = auth
+	_ = nc.AddToUserAgent(azure.UserAgent)
+
+	return &Client{compute: cc, network: nc}
+}
+
+// List the compute and network quotas whose usage is not zero in the supplied
+// location.
+func (c *Client) List(ctx context.Context, location string) ([]v1beta1.QuotaUsage, error) {
+	l := azure.NormalizeLocation(location)
+	q := make([]v1beta1.QuotaUsage, 0)
+
+	for it, err := c.compute.ListComplete(ctx, l); it.NotDone(); err = it.NextWithContext(ctx) {
+		if err != nil {
+			return nil, errors.Wrapf(err, errListCompute, l)
+		}
+		u := it.Value()
+		if u.Name == nil || u.CurrentValue == nil || *u.CurrentValue == 0 {
+			continue
+		}
+		q = append(q, v1beta1.QuotaUsage{Location: l, Name: azure.ToString(u.Name.Value), CurrentValue: int64(*u.CurrentValue), Limit: toInt64(u.Limit)})
+	}
+
+	for it, err := c.network.ListComplete(ctx, l); it.NotDone(); err = it.NextWithContext(ctx) {
+		if err != nil {
+			return nil, errors.Wrapf(err, errListNetwork, l)
+		}
+		u := it.Value()
+		if u.Name == nil || u.CurrentValue == nil || *u.CurrentValue == 0 {
+			continue
+		}
+		q = append(q, v1beta1.QuotaUsage{Location: l, Name: azure.ToString(u.Name.Value), CurrentValue: *u.CurrentValue, Limit: toInt64(u.Limit)})
+	}
+
+	return q, nil
+}
+
+func toInt64(i *int64) int64 {
+	if i == nil {
+		return 0
+	}
+	return *i
+}
+
+// Exceeds returns true if the usage of the supplied quota is at or above the
+// supplied percentage of its limit.
+func Exceeds(u v1beta1.QuotaUsage, percent int) bool {
+	if u.Limit <= 0 {
+		return false
+	}
+	return u.CurrentValue*100 >= u.Limit*int64(percent)
+}
+
+// Crossed returns the current quotas that exceed the supplied percentage of
+// their limit but did not previously.
+func Crossed(previous, current []v1beta1.QuotaUsage, percent int) []v1beta1.QuotaUsage {
+	exceeded := map[string]bool{}
+	for _, u := range previous {
+		exceeded[u.Location+"/"+u.Name] = Exceeds(u, percent)
+	}
+	crossed := make([]v1beta1.QuotaUsage, 0)
+	for _, u := range current {
+		if Exceeds(u, percent) && !exceeded[u.Location+"/"+u.Name] {
+			crossed = append(crossed, u)
+		}
+	}
+	return crossed
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+)
+
+func TestCrossed(t *testing.T) {
+	below := v1beta1.QuotaUsage{Location: "westus2", Name: "cores", CurrentValue: 7, Limit: 10}
+	above := v1beta1.QuotaUsage{Location: "westus2", Name: "cores", CurrentValue: 9, Limit: 10}
+	unlimited := v1beta1.QuotaUsage{Location: "westus2", Name: "virtualMachines", CurrentValue: 9}
+
+	cases := map[string]struct {
+		previous []v1beta1.QuotaUsage
+		current  []v1beta1.QuotaUsage
+		want     []v1beta1.QuotaUsage
+	}{
+		"NewlyCrossed": {
+			previous: []v1beta1.QuotaUsage{below},
+			current:  []v1beta1.QuotaUsage{above},
+			want:     []v1beta1.QuotaUsage{above},
+		},
+		"FirstObservation": {
+			current: []v1beta1.QuotaUsage{above},
+			want:    []v1beta1.QuotaUsage{above},
+		},
+		"AlreadyCrossed": {
+			previous: []v1beta1.QuotaUsage{above},
+			current:  []v1beta1.QuotaUsage{above},
+			want:     []v1beta1.QuotaUsage{},
+		},
+		"BelowThreshold": {
+			current: []v1beta1.QuotaUsage{below},
+			want:    []v1beta1.QuotaUsage{},
+		},
+		"NoLimit": {
+			current: []v1beta1.QuotaUsage{unlimited},
+			want:    []v1beta1.QuotaUsage{},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := Crossed(tc.previous, tc.current, DefaultThresholdPercent)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Crossed(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package redact removes secret data, such as passwords, access keys,
+// connection strings and kubeconfigs, from the errors of Azure SDK clients.
+// Errors of Azure API requests may include the request or response payload,
+// and the errors of managed resources are logged, recorded as events and
+// written to their status.
+package redact
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// Redacted replaces secret data.
+const Redacted = "REDACTED"
+
+// secretKey matches the keys of secret values, e.g. adminPassword,
+// primaryKey, AccountKey, connectionString, client-key-data, kubeconfig, or
+// the sig of a SAS URL.
+const secretKey = `[\w.-]*(?i:password|secret|token|key|connectionstring|kubeconfig|credential)[\w.-]*|(?i:sig)`
+
+// notSecret are suffixes of keys that match secretKey, but whose values are
+// not secret, e.g. keyVaultName or tokenType.
+var notSecret = []string{"name", "id", "uri", "url", "type", "source", "version", "vault"}
+
+var (
+	// Quoted values of secret keys in JSON, e.g. "adminPassword": "cool".
+	quoted = regexp.MustCompile(`("(` + secretKey + `)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+	// Quoted values of secret keys in JSON that is itself quoted, as in the
+	// messages of Azure API errors, e.g. \"adminPassword\": \"cool\".
+	escaped = regexp.MustCompile(`(\\"(` + secretKey + `)\\"\s*:\s*)\\"(?:[^\\]|\\[^"])*?\\"`)
+
+	// Unquoted values of secret keys, e.g. AccountKey=cool; in connection
+	// strings, sig=cool& in SAS URLs, or client-key-data: cool in kubeconfigs.
+	unquoted = regexp.MustCompile(`(\b(` + secretKey + `)\s*[:=]\s*)[^\s"',;&}]+`)
+
+	// Private keys in PEM format.
+	pem = regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`)
+
+	// Bearer tokens of Authorization headers.
+	bearer = regexp.MustCompile(`(?i)(bearer\s+)[\w.~+/-]+=*`)
+)
+
+// String returns the supplied string with all secret data it contains
+// replaced by Redacted.
+func String(s string) string {
+	s = pem.ReplaceAllString(s, Redacted)
+	s = bearer.ReplaceAllString(s, "${1}"+Redacted)
+	s = replace(quoted, s, `"`+Redacted+`"`)
+	s = replace(escaped, s, `\"`+Redacted+`\"`)
+	return replace(unquoted, s, Redacted)
+}
+
+// replace the values matched by the supplied expression with the supplied
+// replacement, unless their key is not secret. The expression must capture
+// the key and its separator as its first group, and the key as its second.
+func replace(re *regexp.Regexp, s, replacement string) string {
+	return re.ReplaceAllStringFunc(s, func(m string) string {
+		sm := re.FindStringSubmatch(m)
+		key := strings.ToLower(sm[2])
+		for _, suffix := range notSecret {
+			if strings.HasSuffix(key, suffix) {
+				return m
+			}
+		}
+		return sm[1] + replacement
+	})
+}
+
+// A redactedError is an error whose message has its secret data redacted.
+type redactedError struct {
+	cause error
+	msg   string
+}
+
+func (e *redactedError) Error() string { return e.msg }
+
+// Cause returns the original error, so that its type can still be inspected.
+func (e *redactedError) Cause() error { return e.cause }
+
+// Unwrap returns the original error.
+func (e *redactedError) Unwrap() error { return e.cause }
+
+// Error returns an error whose message is that of the supplied error with all
+// secret data it contains replaced by Redacted. The supplied error is returned
+// if its message contains no secret data.
+func Error(err error) error {
+	if err == nil {
+		return nil
+	}
+	msg := String(err.Error())
+	if msg == err.Error() {
+		return err
+	}
+	return &redactedError{cause: err, msg: msg}
+}
+
+// NewConnecter returns an ExternalConnecter that redacts the errors of its
+// clients, which are logged, recorded as events and written to the status of
+// managed resources.
+func NewConnecter(c managed.ExternalConnecter) managed.ExternalConnecter {
+	return &connecter{ExternalConnecter: c}
+}
+
+type connecter struct {
+	managed.ExternalConnecter
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	e, err := c.ExternalConnecter.Connect(ctx, mg)
+	if err != nil {
+		return nil, Error(err)
+	}
+	return &external{ExternalClient: e}, nil
+}
+
+type external struct {
+	managed.ExternalClient
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	o, err := e.ExternalClient.Observe(ctx, mg)
+	return o, Error(err)
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, err := e.ExternalClient.Create(ctx, mg)
+	return cr, Error(err)
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	u, err := e.ExternalClient.Update(ctx, mg)
+	return u, Error(err)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	return Error(e.ExternalClient.Delete(ctx, mg))
+}
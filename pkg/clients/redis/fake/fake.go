@@ -29,11 +29,12 @@ var _ redisapi.ClientAPI = &MockClient{}
 type MockClient struct {
 	redisapi.ClientAPI
 
-	MockCreate   func(ctx context.Context, resourceGroupName string, name string, parameters redis.CreateParameters) (result redis.CreateFuture, err error)
-	MockDelete   func(ctx context.Context, resourceGroupName string, name string) (result redis.DeleteFuture, err error)
-	MockGet      func(ctx context.Context, resourceGroupName string, name string) (result redis.ResourceType, err error)
-	MockListKeys func(ctx context.Context, resourceGroupName string, name string) (result redis.AccessKeys, err error)
-	MockUpdate   func(ctx context.Context, resourceGroupName string, name string, parameters redis.UpdateParameters) (result redis.ResourceType, err error)
+	MockCreate     func(ctx context.Context, resourceGroupName string, name string, parameters redis.CreateParameters) (result redis.CreateFuture, err error)
+	MockDelete     func(ctx context.Context, resourceGroupName string, name string) (result redis.DeleteFuture, err error)
+	MockGet        func(ctx context.Context, resourceGroupName string, name string) (result redis.ResourceType, err error)
+	MockImportData func(ctx context.Context, resourceGroupName string, name string, parameters redis.ImportRDBParameters) (result redis.ImportDataFuture, err error)
+	MockListKeys   func(ctx context.Context, resourceGroupName string, name string) (result redis.AccessKeys, err error)
+	MockUpdate     func(ctx context.Context, resourceGroupName string, name string, parameters redis.UpdateParameters) (result redis.ResourceType, err error)
 }
 
 // Create calls the MockClient's MockCreate method.
@@ -51,6 +52,11 @@ func (c *MockClient) Get(ctx context.Context, resourceGroupName string, name str
 	return c.MockGet(ctx, resourceGroupName, name)
 }
 
+// ImportData calls the MockClient's MockImportData method.
+func (c *MockClient) ImportData(ctx context.Context, resourceGroupName string, name string, parameters redis.ImportRDBParameters) (result redis.ImportDataFuture, err error) {
+	return c.MockImportData(ctx, resourceGroupName, name, parameters)
+}
+
 // ListKeys calls the MockClient's MockListKeys method.
 func (c *MockClient) ListKeys(ctx context.Context, resourceGroupName string, name string) (result redis.AccessKeys, err error) {
 	return c.MockListKeys(ctx, resourceGroupName, name)
@@ -18,10 +18,14 @@ package redis
 
 import (
 	"reflect"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/redis/mgmt/2018-03-01/redis"
 
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
 	"github.com/crossplane/provider-azure/apis/cache/v1beta1"
+	apisv1beta1 "github.com/crossplane/provider-azure/apis/v1beta1"
 	azure "github.com/crossplane/provider-azure/pkg/clients"
 )
 
@@ -33,6 +37,16 @@ const (
 	ProvisioningStateSucceeded = string(redis.Succeeded)
 )
 
+// ImportStatusSucceeded is the status of an import operation that succeeded.
+const ImportStatusSucceeded = "Succeeded"
+
+// Condition messages.
+const (
+	msgImportPending    = "waiting for the data import to start"
+	msgImportInProgress = "importing data"
+	msgImportFailed     = "cannot import data: "
+)
+
 // NewCreateParameters returns Redis resource creation parameters suitable for
 // use with the Azure API.
 func NewCreateParameters(cr *v1beta1.Redis) redis.CreateParameters {
@@ -78,12 +92,9 @@ func NewUpdateParameters(spec v1beta1.RedisParameters, state redis.ResourceType)
 	// ResourceType and extract a JSON patch. But since the number of fields
 	// are not that many, I wanted to go with if statements. Hopefully, we'll
 	// generate this code in the future.
-	for k, v := range state.Tags {
-		if patch.Tags[k] == v {
-			delete(patch.Tags, k)
-		}
-	}
-	if len(patch.Tags) == 0 {
+	// Azure replaces all tags of a cache with those of an update, so they are
+	// either updated in full or not at all.
+	if !azure.TagsNeedUpdate(spec.Tags, state.Tags) {
 		patch.Tags = nil
 	}
 	if state.Properties == nil {
@@ -129,6 +140,24 @@ func NewSKU(s v1beta1.SKU) *redis.Sku {
 	}
 }
 
+// skuTiers ranks the SKU names of Redis caches from the least to the most
+// capable.
+var skuTiers = map[redis.SkuName]int{redis.Basic: 0, redis.Standard: 1, redis.Premium: 2}
+
+// IsSKUDowngrade returns true if the supplied SKU is of a lower tier, or of a
+// smaller capacity in the same tier, than the SKU of the supplied Azure
+// resource. Downgrades may lose data.
+func IsSKUDowngrade(s v1beta1.SKU, az redis.ResourceType) bool {
+	if az.Properties == nil || az.Properties.Sku == nil {
+		return false
+	}
+	current := az.Properties.Sku
+	if t := skuTiers[redis.SkuName(s.Name)]; t != skuTiers[current.Name] {
+		return t < skuTiers[current.Name]
+	}
+	return s.Capacity < azure.ToInt(current.Capacity)
+}
+
 // NeedsUpdate returns true if the supplied spec object differs from the
 // supplied Azure resource. It considers only fields that can be modified in
 // place without deleting and recreating the instance.
@@ -167,18 +196,74 @@ func GenerateObservation(az redis.ResourceType) v1beta1.RedisObservation {
 
 // LateInitialize fills the spec values that user did not fill with their
 // corresponding value in the Azure, if there is any.
-func LateInitialize(spec *v1beta1.RedisParameters, az redis.ResourceType) {
+func LateInitialize(spec *v1beta1.RedisParameters, az redis.ResourceType, tp apisv1beta1.TagPolicy) {
 	spec.Zones = azure.LateInitializeStringValArrFromArrPtr(spec.Zones, az.Zones)
-	spec.Tags = azure.LateInitializeStringMap(spec.Tags, az.Tags)
+	spec.Tags = azure.LateInitializeTags(spec.Tags, az.Tags, tp)
 	if az.Properties == nil {
 		return
 	}
 	spec.SubnetID = azure.LateInitializeStringPtrFromPtr(spec.SubnetID, az.Properties.SubnetID)
 	spec.StaticIP = azure.LateInitializeStringPtrFromPtr(spec.StaticIP, az.Properties.StaticIP)
-	spec.RedisConfiguration = azure.LateInitializeStringMap(spec.RedisConfiguration, az.Properties.RedisConfiguration)
+	// Azure adds the settings that were not supplied, e.g. maxclients, to
+	// those that were.
+	spec.RedisConfiguration = azure.LateInitializeStringMapKeys(spec.RedisConfiguration, az.Properties.RedisConfiguration)
 	spec.EnableNonSSLPort = azure.LateInitializeBoolPtrFromPtr(spec.EnableNonSSLPort, az.Properties.EnableNonSslPort)
 	spec.TenantSettings = azure.LateInitializeStringMap(spec.TenantSettings, az.Properties.TenantSettings)
 	spec.ShardCount = azure.LateInitializeIntPtrFromInt32Ptr(spec.ShardCount, az.Properties.ShardCount)
 	minTLS := string(az.Properties.MinimumTLSVersion)
 	spec.MinimumTLSVersion = azure.LateInitializeStringPtrFromPtr(spec.MinimumTLSVersion, &minTLS)
 }
+
+// NewImportParameters returns the parameters of an import of the supplied
+// files, which are the SAS URLs of their blobs listed one per line.
+func NewImportParameters(i v1beta1.RedisImport, files string) redis.ImportRDBParameters {
+	urls := []string{}
+	for _, f := range strings.Split(files, "\n") {
+		if f = strings.TrimSpace(f); f != "" {
+			urls = append(urls, f)
+		}
+	}
+	return redis.ImportRDBParameters{
+		Format: i.Format,
+		Files:  &urls,
+	}
+}
+
+// ImportPending returns true if the files of the supplied Redis are yet to be
+// imported, i.e. it has files to import and either no import was started or
+// the initial request of the import went unanswered too long ago to still be
+// in flight.
+func ImportPending(cr *v1beta1.Redis) bool {
+	if cr.Spec.ForProvider.ImportFrom == nil {
+		return false
+	}
+	op := cr.Status.AtProvider.Import
+	if op == nil {
+		return true
+	}
+	return op.Status == azure.AsyncOperationStatusInProgress && !azure.IsAsyncOperationInFlight(*op)
+}
+
+// ImportCondition returns the condition of the supplied Redis while the
+// import of its files did not succeed, and false once it did or if it has no
+// files to import. A failed import is not retried.
+func ImportCondition(cr *v1beta1.Redis) (xpv1.Condition, bool) {
+	if cr.Spec.ForProvider.ImportFrom == nil {
+		return xpv1.Condition{}, false
+	}
+	if ImportPending(cr) {
+		return xpv1.Unavailable().WithMessage(msgImportPending), true
+	}
+	switch op := cr.Status.AtProvider.Import; op.Status {
+	case ImportStatusSucceeded:
+		return xpv1.Condition{}, false
+	case azure.AsyncOperationStatusInProgress:
+		return xpv1.Unavailable().WithMessage(msgImportInProgress), true
+	default:
+		msg := op.ErrorMessage
+		if msg == "" {
+			msg = op.Status
+		}
+		return xpv1.Unavailable().WithMessage(msgImportFailed + msg), true
+	}
+}
@@ -18,11 +18,17 @@ package redis
 
 import (
 	"testing"
+	"time"
 
 	redismgmt "github.com/Azure/azure-sdk-for-go/services/redis/mgmt/2018-03-01/redis"
 	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 
 	"github.com/crossplane/provider-azure/apis/cache/v1beta1"
+	"github.com/crossplane/provider-azure/apis/v1alpha3"
+	apisv1beta1 "github.com/crossplane/provider-azure/apis/v1beta1"
 	azure "github.com/crossplane/provider-azure/pkg/clients"
 )
 
@@ -190,6 +196,31 @@ func TestNewUpdateParameters(t *testing.T) {
 				Tags:             azure.ToStringPtrMap(tags),
 			},
 		},
+		{
+			name: "PatchAllTags",
+			spec: v1beta1.RedisParameters{
+				Tags: tags2,
+				SKU: v1beta1.SKU{
+					Name:     skuName,
+					Family:   skuFamily,
+					Capacity: skuCapacity,
+				},
+			},
+			current: redismgmt.ResourceType{
+				Tags: azure.ToStringPtrMap(tags),
+				Properties: &redismgmt.Properties{
+					Sku: &redismgmt.Sku{
+						Name:     redismgmt.SkuName(skuName),
+						Family:   redismgmt.SkuFamily(skuFamily),
+						Capacity: azure.ToInt32Ptr(skuCapacity),
+					},
+				},
+			},
+			want: redismgmt.UpdateParameters{
+				UpdateProperties: &redismgmt.UpdateProperties{},
+				Tags:             azure.ToStringPtrMap(tags2),
+			},
+		},
 		{
 			name: "PatchRedisConfig",
 			spec: v1beta1.RedisParameters{
@@ -439,13 +470,158 @@ func TestLateInitialize(t *testing.T) {
 				},
 			},
 		},
+		"LateInitializeRedisConfigurationKeys": {
+			args: args{
+				az: redismgmt.ResourceType{
+					Properties: &redismgmt.Properties{
+						RedisConfiguration: map[string]*string{
+							"cool":       azure.ToStringPtr("notcool"),
+							"maxclients": azure.ToStringPtr("1000"),
+						},
+					},
+				},
+				spec: &v1beta1.RedisParameters{
+					RedisConfiguration: map[string]string{"cool": "socool"},
+					MinimumTLSVersion:  &minTLSVersion,
+				},
+			},
+			want: want{
+				spec: &v1beta1.RedisParameters{
+					RedisConfiguration: map[string]string{"cool": "socool", "maxclients": "1000"},
+					MinimumTLSVersion:  &minTLSVersion,
+				},
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			LateInitialize(tc.args.spec, tc.args.az)
+			LateInitialize(tc.args.spec, tc.args.az, apisv1beta1.TagPolicyReplace)
 			if diff := cmp.Diff(tc.want.spec, tc.args.spec); diff != "" {
 				t.Errorf("LateInitialize(...): -want, +got\n%s", diff)
 			}
 		})
 	}
 }
+
+func TestIsSKUDowngrade(t *testing.T) {
+	az := redismgmt.ResourceType{
+		Properties: &redismgmt.Properties{
+			Sku: &redismgmt.Sku{
+				Name:     redismgmt.Standard,
+				Family:   redismgmt.C,
+				Capacity: azure.ToInt32Ptr(2),
+			},
+		},
+	}
+	cases := map[string]struct {
+		sku  v1beta1.SKU
+		az   redismgmt.ResourceType
+		want bool
+	}{
+		"LowerTier": {
+			sku:  v1beta1.SKU{Name: "Basic", Family: "C", Capacity: 2},
+			az:   az,
+			want: true,
+		},
+		"HigherTier": {
+			sku:  v1beta1.SKU{Name: "Premium", Family: "P", Capacity: 1},
+			az:   az,
+			want: false,
+		},
+		"SmallerCapacity": {
+			sku:  v1beta1.SKU{Name: "Standard", Family: "C", Capacity: 1},
+			az:   az,
+			want: true,
+		},
+		"SameSKU": {
+			sku:  v1beta1.SKU{Name: "Standard", Family: "C", Capacity: 2},
+			az:   az,
+			want: false,
+		},
+		"NoProperties": {
+			sku:  v1beta1.SKU{Name: "Basic", Family: "C", Capacity: 0},
+			az:   redismgmt.ResourceType{},
+			want: false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsSKUDowngrade(tc.sku, tc.az)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("IsSKUDowngrade(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewImportParameters(t *testing.T) {
+	format := "RDB"
+	i := v1beta1.RedisImport{Format: &format}
+	files := "https://cool.blob.core.windows.net/c/a.rdb?sig=a\n\n  https://cool.blob.core.windows.net/c/b.rdb?sig=b  \n"
+	want := redismgmt.ImportRDBParameters{
+		Format: &format,
+		Files: &[]string{
+			"https://cool.blob.core.windows.net/c/a.rdb?sig=a",
+			"https://cool.blob.core.windows.net/c/b.rdb?sig=b",
+		},
+	}
+	if diff := cmp.Diff(want, NewImportParameters(i, files)); diff != "" {
+		t.Errorf("NewImportParameters(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestImportCondition(t *testing.T) {
+	seeded := func(op *v1alpha3.AsyncOperation) *v1beta1.Redis {
+		cr := &v1beta1.Redis{}
+		cr.Spec.ForProvider.ImportFrom = &v1beta1.RedisImport{}
+		cr.Status.AtProvider.Import = op
+		return cr
+	}
+	old := metav1.NewTime(time.Now().Add(-time.Hour))
+	type want struct {
+		c       xpv1.Condition
+		ok      bool
+		pending bool
+	}
+	cases := map[string]struct {
+		cr   *v1beta1.Redis
+		want want
+	}{
+		"NoImport": {
+			cr: &v1beta1.Redis{},
+		},
+		"NotStarted": {
+			cr:   seeded(nil),
+			want: want{c: xpv1.Unavailable().WithMessage(msgImportPending), ok: true, pending: true},
+		},
+		"InProgress": {
+			cr:   seeded(&v1alpha3.AsyncOperation{PollingURL: "crossplane.io", Status: azure.AsyncOperationStatusInProgress}),
+			want: want{c: xpv1.Unavailable().WithMessage(msgImportInProgress), ok: true},
+		},
+		"Unanswered": {
+			cr:   seeded(&v1alpha3.AsyncOperation{Status: azure.AsyncOperationStatusInProgress, StartTime: &old}),
+			want: want{c: xpv1.Unavailable().WithMessage(msgImportPending), ok: true, pending: true},
+		},
+		"Succeeded": {
+			cr: seeded(&v1alpha3.AsyncOperation{Status: ImportStatusSucceeded}),
+		},
+		"Failed": {
+			cr:   seeded(&v1alpha3.AsyncOperation{Status: "Failed", ErrorMessage: "boom"}),
+			want: want{c: xpv1.Unavailable().WithMessage(msgImportFailed + "boom"), ok: true},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c, ok := ImportCondition(tc.cr)
+			if diff := cmp.Diff(tc.want.c, c); diff != "" {
+				t.Errorf("ImportCondition(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.ok, ok); diff != "" {
+				t.Errorf("ImportCondition(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.pending, ImportPending(tc.cr)); diff != "" {
+				t.Errorf("ImportPending(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
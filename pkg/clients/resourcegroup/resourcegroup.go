@@ -27,6 +27,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
 
 	"github.com/crossplane/provider-azure/apis/v1alpha3"
+	"github.com/crossplane/provider-azure/apis/v1beta1"
 	azure "github.com/crossplane/provider-azure/pkg/clients"
 )
 
@@ -40,7 +41,7 @@ func NewClient(credentials []byte) (GroupsClient, error) {
 	if err := json.Unmarshal(credentials, &c); err != nil {
 		return nil, errors.Wrap(err, "cannot unmarshal Azure client secret data")
 	}
-	client := resources.NewGroupsClient(c.SubscriptionID)
+	client := resources.NewGroupsClientWithBaseURI(c.BaseURI(), c.SubscriptionID)
 
 	cfg := auth.ClientCredentialsConfig{
 		ClientID:     c.ClientID,
@@ -67,5 +68,21 @@ func NewParameters(r *v1alpha3.ResourceGroup) resources.Group {
 	return resources.Group{
 		Name:     azure.ToStringPtr(meta.GetExternalName(r)),
 		Location: azure.ToStringPtr(r.Spec.Location),
+		Tags:     azure.ToStringPtrMap(r.Spec.Tags),
 	}
 }
+
+// LateInitialize fills the empty fields of the supplied Resource Group spec
+// with their values in the supplied Azure Resource Group.
+func LateInitialize(r *v1alpha3.ResourceGroup, g resources.Group, tp v1beta1.TagPolicy) {
+	if r.Spec.Location == "" {
+		r.Spec.Location = azure.ToString(g.Location)
+	}
+	r.Spec.Tags = azure.LateInitializeTags(r.Spec.Tags, g.Tags, tp)
+}
+
+// IsUpToDate returns true if the supplied Azure Resource Group matches the
+// supplied Resource Group spec.
+func IsUpToDate(r *v1alpha3.ResourceGroup, g resources.Group) bool {
+	return !azure.TagsNeedUpdate(r.Spec.Tags, g.Tags)
+}
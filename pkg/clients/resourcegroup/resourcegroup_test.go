@@ -25,6 +25,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
 
 	"github.com/crossplane/provider-azure/apis/v1alpha3"
+	"github.com/crossplane/provider-azure/apis/v1beta1"
 	azure "github.com/crossplane/provider-azure/pkg/clients"
 )
 
@@ -45,6 +46,7 @@ func TestNewParameters(t *testing.T) {
 				r := &v1alpha3.ResourceGroup{
 					Spec: v1alpha3.ResourceGroupSpec{
 						Location: location,
+						Tags:     map[string]string{"team": "platform"},
 					},
 				}
 				meta.SetExternalName(r, name)
@@ -53,6 +55,7 @@ func TestNewParameters(t *testing.T) {
 			want: resources.Group{
 				Name:     azure.ToStringPtr(name),
 				Location: azure.ToStringPtr(location),
+				Tags:     map[string]*string{"team": azure.ToStringPtr("platform")},
 			},
 		},
 	}
@@ -66,3 +69,71 @@ func TestNewParameters(t *testing.T) {
 		})
 	}
 }
+
+func TestLateInitialize(t *testing.T) {
+	cases := []struct {
+		name string
+		r    *v1alpha3.ResourceGroup
+		g    resources.Group
+		want *v1alpha3.ResourceGroup
+	}{
+		{
+			name: "Empty",
+			r:    &v1alpha3.ResourceGroup{},
+			g:    resources.Group{Location: azure.ToStringPtr(location)},
+			want: &v1alpha3.ResourceGroup{Spec: v1alpha3.ResourceGroupSpec{Location: location}},
+		},
+		{
+			name: "Set",
+			r:    &v1alpha3.ResourceGroup{Spec: v1alpha3.ResourceGroupSpec{Location: location}},
+			g:    resources.Group{Location: azure.ToStringPtr("us-east-1")},
+			want: &v1alpha3.ResourceGroup{Spec: v1alpha3.ResourceGroupSpec{Location: location}},
+		},
+		{
+			name: "Tags",
+			r:    &v1alpha3.ResourceGroup{Spec: v1alpha3.ResourceGroupSpec{Location: location}},
+			g:    resources.Group{Tags: map[string]*string{"team": azure.ToStringPtr("platform")}},
+			want: &v1alpha3.ResourceGroup{Spec: v1alpha3.ResourceGroupSpec{Location: location, Tags: map[string]string{"team": "platform"}}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			LateInitialize(tc.r, tc.g, v1beta1.TagPolicyReplace)
+			if diff := cmp.Diff(tc.want, tc.r); diff != "" {
+				t.Errorf("LateInitialize(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	cases := []struct {
+		name string
+		r    *v1alpha3.ResourceGroup
+		g    resources.Group
+		want bool
+	}{
+		{
+			name: "UpToDate",
+			r:    &v1alpha3.ResourceGroup{Spec: v1alpha3.ResourceGroupSpec{Tags: map[string]string{"team": "platform"}}},
+			g:    resources.Group{Tags: map[string]*string{"team": azure.ToStringPtr("platform")}},
+			want: true,
+		},
+		{
+			name: "TagsDrifted",
+			r:    &v1alpha3.ResourceGroup{Spec: v1alpha3.ResourceGroupSpec{Tags: map[string]string{"team": "platform"}}},
+			g:    resources.Group{Tags: map[string]*string{"team": azure.ToStringPtr("data")}},
+			want: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := IsUpToDate(tc.r, tc.g)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("IsUpToDate(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// A KeyedSemaphore limits the number of writes in progress per key, e.g. per
+// resource group or virtual network, because Azure serializes some of their
+// operations and rejects those that arrive while another is in progress with
+// AnotherOperationInProgress. A write holds its slot of a key from when it is
+// sent until the managed resource it is for is observed to have settled, or
+// until its lease expires, e.g. because the managed resource was deleted in
+// the meantime. Keys are case insensitive, like the names of Azure resources.
+// A nil KeyedSemaphore limits nothing.
+type KeyedSemaphore struct {
+	limit int
+	lease time.Duration
+	now   func() time.Time
+
+	mu   sync.Mutex
+	held map[string]map[types.UID]time.Time
+}
+
+// NewKeyedSemaphore returns a KeyedSemaphore that allows the supplied number
+// of writes in progress per key, each for at most the supplied lease.
+func NewKeyedSemaphore(limit int, lease time.Duration) *KeyedSemaphore {
+	return &KeyedSemaphore{limit: limit, lease: lease, now: time.Now, held: make(map[string]map[types.UID]time.Time)}
+}
+
+// TryAcquire a slot of the supplied key for a write to the supplied managed
+// resource. It returns false if all slots of the key are held by writes to
+// other managed resources. A managed resource that already holds a slot
+// renews its lease.
+func (s *KeyedSemaphore) TryAcquire(key string, mg resource.Managed) bool {
+	if s == nil {
+		return true
+	}
+	key = strings.ToLower(key)
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	h := s.held[key]
+	for uid, expiry := range h {
+		if !now.Before(expiry) {
+			delete(h, uid)
+		}
+	}
+	if _, ok := h[mg.GetUID()]; !ok && len(h) >= s.limit {
+		return false
+	}
+	if h == nil {
+		h = make(map[types.UID]time.Time)
+		s.held[key] = h
+	}
+	h[mg.GetUID()] = now.Add(s.lease)
+	return true
+}
+
+// Release the slot of the supplied key held by the supplied managed resource,
+// if any.
+func (s *KeyedSemaphore) Release(key string, mg resource.Managed) {
+	if s == nil {
+		return
+	}
+	key = strings.ToLower(key)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.held[key], mg.GetUID())
+	if len(s.held[key]) == 0 {
+		delete(s.held, key)
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+)
+
+func TestKeyedSemaphore(t *testing.T) {
+	managed := func(uid types.UID) resource.Managed {
+		return &fake.Managed{ObjectMeta: metav1.ObjectMeta{UID: uid}}
+	}
+	now := time.Now()
+
+	cases := map[string]struct {
+		steps func(s *KeyedSemaphore)
+		key   string
+		mg    resource.Managed
+		want  bool
+	}{
+		"Free": {
+			steps: func(s *KeyedSemaphore) {},
+			key:   "rg/vnet",
+			mg:    managed("a"),
+			want:  true,
+		},
+		"Held": {
+			steps: func(s *KeyedSemaphore) { s.TryAcquire("rg/vnet", managed("a")) },
+			key:   "rg/vnet",
+			mg:    managed("b"),
+		},
+		"HeldIgnoringCase": {
+			steps: func(s *KeyedSemaphore) { s.TryAcquire("RG/VNet", managed("a")) },
+			key:   "rg/vnet",
+			mg:    managed("b"),
+		},
+		"HeldBySameResource": {
+			steps: func(s *KeyedSemaphore) { s.TryAcquire("rg/vnet", managed("a")) },
+			key:   "rg/vnet",
+			mg:    managed("a"),
+			want:  true,
+		},
+		"OtherKeyHeld": {
+			steps: func(s *KeyedSemaphore) { s.TryAcquire("rg/other", managed("a")) },
+			key:   "rg/vnet",
+			mg:    managed("b"),
+			want:  true,
+		},
+		"Released": {
+			steps: func(s *KeyedSemaphore) {
+				s.TryAcquire("rg/vnet", managed("a"))
+				s.Release("rg/vnet", managed("a"))
+			},
+			key:  "rg/vnet",
+			mg:   managed("b"),
+			want: true,
+		},
+		"LeaseExpired": {
+			steps: func(s *KeyedSemaphore) {
+				s.TryAcquire("rg/vnet", managed("a"))
+				s.now = func() time.Time { return now.Add(time.Hour) }
+			},
+			key:  "rg/vnet",
+			mg:   managed("b"),
+			want: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := NewKeyedSemaphore(1, time.Minute)
+			s.now = func() time.Time { return now }
+			tc.steps(s)
+			if got := s.TryAcquire(tc.key, tc.mg); got != tc.want {
+				t.Errorf("TryAcquire(...): want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
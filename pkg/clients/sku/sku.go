@@ -0,0 +1,171 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sku
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-07-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/mysql/mgmt/2017-12-01/mysql"
+	"github.com/Azure/azure-sdk-for-go/services/postgresql/mgmt/2017-12-01/postgresql"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/provider-azure/apis/v1alpha3"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+// Resource types of the SKUs of database servers.
+const (
+	ResourceTypeMySQLServer      = "Microsoft.DBforMySQL/servers"
+	ResourceTypePostgreSQLServer = "Microsoft.DBforPostgreSQL/servers"
+)
+
+// Error strings.
+const (
+	errListCompute    = "cannot list compute SKUs in location %q"
+	errListMySQL      = "cannot list MySQL performance tiers in location %q"
+	errListPostgreSQL = "cannot list PostgreSQL performance tiers in location %q"
+)
+
+// A Lister lists the SKUs offered to a subscription in a location.
+type Lister interface {
+	List(ctx context.Context, location string) ([]v1alpha3.SKUOffering, error)
+}
+
+// A Client lists compute and database SKUs.
+type Client struct {
+	compute    compute.ResourceSkusClient
+	mysql      mysql.LocationBasedPerformanceTierClient
+	postgresql postgresql.LocationBasedPerformanceTierClient
+}
+
+// NewClient returns a Client for the subscription of the supplied credentials.
+func NewClient(creds map[string]string, auth autorest.Authorizer) *Client {
+	cc := compute.NewResourceSkusClientWithBaseURI(azure.BaseURI(creds), creds[azure.CredentialsKeySubscriptionID])
+	cc.Authorizer = auth
+	_ = cc.AddToUserAgent(azure.UserAgent)
+
+	mc := mysql.NewLocationBasedPerformanceTierClientWithBaseURI(azure.BaseURI(creds), creds[azure.CredentialsKeySubscriptionID])
+	mc.Authorizer = auth
+	_ = mc.AddToUserAgent(azure.UserAgent)
+
+	pc := postgresql.NewLocationBasedPerformanceTierClientWithBaseURI(azure.BaseURI(creds), creds[azure.CredentialsKeySubscriptionID])
+	pc.Authorizer = auth
+	_ = pc.AddToUserAgent(azure.UserAgent)
+
+	return &Client{compute: cc, mysql: mc, postgresql: pc}
+}
+
+// List the compute and database SKUs offered in the supplied location. Redis
+// is not included since its SKUs are not listed by any Azure API.
+func (c *Client) List(ctx context.Context, location string) ([]v1alpha3.SKUOffering, error) {
+	l := azure.NormalizeLocation(location)
+
+	skus := make([]compute.ResourceSku, 0)
+	for it, err := c.compute.ListComplete(ctx, fmt.Sprintf("location eq '%s'", l)); it.NotDone(); err = it.NextWithContext(ctx) {
+		if err != nil {
+			return nil, errors.Wrapf(err, errListCompute, l)
+		}
+		skus = append(skus, it.Value())
+	}
+	o := ComputeOfferings(l, skus)
+
+	mt, err := c.mysql.List(ctx, l)
+	if err != nil {
+		return nil, errors.Wrapf(err, errListMySQL, l)
+	}
+	ids := make([]string, 0)
+	if mt.Value != nil {
+		for _, t := range *mt.Value {
+			if t.ServiceLevelObjectives == nil {
+				continue
+			}
+			for _, slo := range *t.ServiceLevelObjectives {
+				ids = append(ids, azure.ToString(slo.ID))
+			}
+		}
+	}
+	sort.Strings(ids)
+	o = append(o, v1alpha3.SKUOffering{Location: l, ResourceType: ResourceTypeMySQLServer, Names: ids})
+
+	pt, err := c.postgresql.List(ctx, l)
+	if err != nil {
+		return nil, errors.Wrapf(err, errListPostgreSQL, l)
+	}
+	ids = make([]string, 0)
+	if pt.Value != nil {
+		for _, t := range *pt.Value {
+			if t.ServiceLevelObjectives == nil {
+				continue
+			}
+			for _, slo := range *t.ServiceLevelObjectives {
+				ids = append(ids, azure.ToString(slo.ID))
+			}
+		}
+	}
+	sort.Strings(ids)
+	return append(o, v1alpha3.SKUOffering{Location: l, ResourceType: ResourceTypePostgreSQLServer, Names: ids}), nil
+}
+
+// ComputeOfferings groups the supplied compute SKUs by resource type, leaving
+// out those that are restricted in the supplied location.
+func ComputeOfferings(location string, skus []compute.ResourceSku) []v1alpha3.SKUOffering {
+	names := map[string]map[string]bool{}
+	for _, s := range skus {
+		if s.Name == nil || s.ResourceType == nil || restricted(location, s) {
+			continue
+		}
+		rt := "Microsoft.Compute/" + *s.ResourceType
+		if names[rt] == nil {
+			names[rt] = map[string]bool{}
+		}
+		names[rt][*s.Name] = true
+	}
+
+	o := make([]v1alpha3.SKUOffering, 0, len(names))
+	for rt, n := range names {
+		ns := make([]string, 0, len(n))
+		for name := range n {
+			ns = append(ns, name)
+		}
+		sort.Strings(ns)
+		o = append(o, v1alpha3.SKUOffering{Location: location, ResourceType: rt, Names: ns})
+	}
+	sort.Slice(o, func(i, j int) bool { return o[i].ResourceType < o[j].ResourceType })
+	return o
+}
+
+func restricted(location string, s compute.ResourceSku) bool {
+	if s.Restrictions == nil {
+		return false
+	}
+	for _, r := range *s.Restrictions {
+		if r.Type != compute.Location || r.Values == nil {
+			continue
+		}
+		for _, v := range *r.Values {
+			if strings.EqualFold(v, location) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sku
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-07-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/provider-azure/apis/v1alpha3"
+)
+
+func TestComputeOfferings(t *testing.T) {
+	location := "westus2"
+	skus := []compute.ResourceSku{
+		{ResourceType: to.StringPtr("virtualMachines"), Name: to.StringPtr("Standard_DS2_v2")},
+		{ResourceType: to.StringPtr("virtualMachines"), Name: to.StringPtr("Standard_B2s")},
+		{ResourceType: to.StringPtr("disks"), Name: to.StringPtr("Premium_LRS")},
+		{
+			ResourceType: to.StringPtr("virtualMachines"),
+			Name:         to.StringPtr("Standard_NC6"),
+			Restrictions: &[]compute.ResourceSkuRestrictions{{Type: compute.Location, Values: &[]string{"WestUS2"}}},
+		},
+	}
+	want := []v1alpha3.SKUOffering{
+		{Location: location, ResourceType: "Microsoft.Compute/disks", Names: []string{"Premium_LRS"}},
+		{Location: location, ResourceType: "Microsoft.Compute/virtualMachines", Names: []string{"Standard_B2s", "Standard_DS2_v2"}},
+	}
+	if diff := cmp.Diff(want, ComputeOfferings(location, skus)); diff != "" {
+		t.Errorf("ComputeOfferings(...): -want, +got:\n%s", diff)
+	}
+}
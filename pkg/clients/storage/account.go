@@ -20,8 +20,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 
 	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2017-06-01/storage"
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/azure/auth"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/pkg/errors"
@@ -29,6 +31,12 @@ import (
 	azure "github.com/crossplane/provider-azure/pkg/clients"
 )
 
+// MinimumTLSVersionAPIVersion is the version of the storage API that the
+// minimum TLS version of accounts is managed with.
+const MinimumTLSVersionAPIVersion = "2019-06-01"
+
+const accountPath = "/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/Microsoft.Storage/storageAccounts/{accountName}"
+
 // NewStorageAccountClient create Azure storage.AccountClient using provided credentials data
 func NewStorageAccountClient(data []byte) (*storage.AccountsClient, error) {
 	creds := &azure.Credentials{}
@@ -45,7 +53,7 @@ func NewStorageAccountClient(data []byte) (*storage.AccountsClient, error) {
 		return nil, fmt.Errorf("failed to get authorizer from config: %+v", err)
 	}
 
-	client := storage.NewAccountsClient(creds.SubscriptionID)
+	client := storage.NewAccountsClientWithBaseURI(creds.BaseURI(), creds.SubscriptionID)
 	client.Authorizer = authorizer
 
 	if err := client.AddToUserAgent(azure.UserAgent); err != nil {
@@ -62,6 +70,8 @@ type AccountOperations interface {
 	Delete(ctx context.Context) error
 	IsAccountNameAvailable(context.Context, string) error
 	ListKeys(context.Context) ([]storage.AccountKey, error)
+	GetMinimumTLSVersion(ctx context.Context) (string, error)
+	UpdateMinimumTLSVersion(ctx context.Context, v string) error
 }
 
 // AccountHandle implements AccountOperations interface
@@ -157,3 +167,40 @@ func (a *AccountHandle) ListKeys(ctx context.Context) ([]storage.AccountKey, err
 
 	return *rs.Keys, nil
 }
+
+type minimumTLSVersion struct {
+	Properties struct {
+		MinimumTLSVersion string `json:"minimumTlsVersion,omitempty"`
+	} `json:"properties"`
+}
+
+// GetMinimumTLSVersion returns the minimum TLS version of this storage account
+func (a *AccountHandle) GetMinimumTLSVersion(ctx context.Context) (string, error) {
+	v := minimumTLSVersion{}
+	err := a.arm().Do(ctx, "GetMinimumTLSVersion", autorest.AsGet(), a.path(), nil, &v, http.StatusOK)
+	return v.Properties.MinimumTLSVersion, err
+}
+
+// UpdateMinimumTLSVersion of this storage account
+func (a *AccountHandle) UpdateMinimumTLSVersion(ctx context.Context, v string) error {
+	body := minimumTLSVersion{}
+	body.Properties.MinimumTLSVersion = v
+	return a.arm().Do(ctx, "UpdateMinimumTLSVersion", autorest.AsPatch(), a.path(), body, nil, http.StatusOK)
+}
+
+// arm returns a client that sends requests for this storage account to the
+// API version its minimum TLS version is managed with, using the
+// authorization and senders of its storage client.
+func (a *AccountHandle) arm() azure.ARMClient {
+	c := azure.NewARMClientWithBaseURI("storage.AccountsClient", a.client.BaseURI, a.client.SubscriptionID, MinimumTLSVersionAPIVersion)
+	c.Client = a.client.Client
+	return c
+}
+
+func (a *AccountHandle) path() autorest.PrepareDecorator {
+	return autorest.WithPathParameters(accountPath, map[string]interface{}{
+		"subscriptionId":    autorest.Encode("path", a.client.SubscriptionID),
+		"resourceGroupName": autorest.Encode("path", a.groupName),
+		"accountName":       autorest.Encode("path", a.accountName),
+	})
+}
@@ -17,6 +17,10 @@ limitations under the License.
 package storage
 
 import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2017-06-01/storage"
@@ -105,3 +109,36 @@ func TestNewAccountHandle(t *testing.T) {
 		})
 	}
 }
+
+func TestMinimumTLSVersion(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.URL.Path, "/subscriptions/sub/resourceGroups/test-group/providers/Microsoft.Storage/storageAccounts/test-account"; got != want {
+			t.Errorf("path: want %q, got %q", want, got)
+		}
+		if got := r.URL.Query().Get("api-version"); got != MinimumTLSVersionAPIVersion {
+			t.Errorf("api-version: want %q, got %q", MinimumTLSVersionAPIVersion, got)
+		}
+		if r.Method == http.MethodPatch {
+			body, _ := ioutil.ReadAll(r.Body)
+			if diff := cmp.Diff(`{"properties":{"minimumTlsVersion":"TLS1_2"}}`, string(body)); diff != "" {
+				t.Errorf("UpdateMinimumTLSVersion(...): -want body, +got body:\n%s", diff)
+			}
+		}
+		_, _ = w.Write([]byte(`{"properties":{"minimumTlsVersion":"TLS1_2"}}`))
+	}))
+	defer srv.Close()
+
+	cl := storage.NewAccountsClientWithBaseURI(srv.URL, "sub")
+	h := NewAccountHandle(&cl, "test-group", "test-account")
+
+	if err := h.UpdateMinimumTLSVersion(context.Background(), "TLS1_2"); err != nil {
+		t.Errorf("UpdateMinimumTLSVersion(...): %s", err)
+	}
+	got, err := h.GetMinimumTLSVersion(context.Background())
+	if err != nil {
+		t.Errorf("GetMinimumTLSVersion(...): %s", err)
+	}
+	if diff := cmp.Diff("TLS1_2", got); diff != "" {
+		t.Errorf("GetMinimumTLSVersion(...): -want, +got:\n%s", diff)
+	}
+}
@@ -22,9 +22,11 @@ import (
 	"net/http"
 	"net/url"
 
+	"github.com/Azure/azure-pipeline-go/pipeline"
 	"github.com/Azure/azure-storage-blob-go/azblob"
 
 	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/fips"
 )
 
 // ContainerOperations interface to perform operations on Container resources
@@ -52,9 +54,13 @@ func NewContainerHandle(accountName, accountKey, containerName string) (*Contain
 		return nil, err
 	}
 
-	p := azblob.NewPipeline(c, azblob.PipelineOptions{
+	o := azblob.PipelineOptions{
 		Telemetry: azblob.TelemetryOptions{Value: azure.UserAgent},
-	})
+	}
+	if fips.Enabled() {
+		o.HTTPSender = newSender(&http.Client{Transport: fips.NewTransport(http.DefaultTransport)})
+	}
+	p := azblob.NewPipeline(c, o)
 
 	u, _ := url.Parse(fmt.Sprintf(blobFormatString, accountName))
 	service := azblob.NewServiceURL(*u, p)
@@ -95,6 +101,15 @@ func (a *ContainerHandle) Delete(ctx context.Context) error {
 	return err
 }
 
+// Upload creates or replaces the named block blob in the container with the
+// supplied data.
+func (a *ContainerHandle) Upload(ctx context.Context, blob, contentType string, data []byte) error {
+	_, err := azblob.UploadBufferToBlockBlob(ctx, data, a.ContainerURL.NewBlockBlobURL(blob), azblob.UploadToBlockBlobOptions{
+		BlobHTTPHeaders: azblob.BlobHTTPHeaders{ContentType: contentType},
+	})
+	return err
+}
+
 func emtpyMetaToNil(m azblob.Metadata) azblob.Metadata {
 	if len(m) == 0 {
 		return nil
@@ -111,3 +126,18 @@ func IsNotFoundError(err error) bool {
 
 	return storageErr.Response().StatusCode == http.StatusNotFound // nolint: bodyclose
 }
+
+// newSender returns a pipeline factory that sends the requests of Azure
+// Storage clients with the supplied HTTP client, rather than with one of
+// their own.
+func newSender(c *http.Client) pipeline.Factory {
+	return pipeline.FactoryFunc(func(_ pipeline.Policy, _ *pipeline.PolicyOptions) pipeline.PolicyFunc {
+		return func(ctx context.Context, req pipeline.Request) (pipeline.Response, error) {
+			rsp, err := c.Do(req.WithContext(ctx))
+			if err != nil {
+				return nil, pipeline.NewError(err, "HTTP request failed")
+			}
+			return pipeline.NewHTTPResponse(rsp), nil
+		}
+	})
+}
@@ -26,12 +26,14 @@ import (
 
 // MockAccountOperations mock implementation of AccountOperations
 type MockAccountOperations struct {
-	MockCreate                 func(context.Context, storage.AccountCreateParameters) (*storage.Account, error)
-	MockUpdate                 func(context.Context, storage.AccountUpdateParameters) (*storage.Account, error)
-	MockGet                    func(ctx context.Context) (*storage.Account, error)
-	MockDelete                 func(ctx context.Context) error
-	MockIsAccountNameAvailable func(context.Context, string) error
-	MockListKeys               func(context.Context) ([]storage.AccountKey, error)
+	MockCreate                  func(context.Context, storage.AccountCreateParameters) (*storage.Account, error)
+	MockUpdate                  func(context.Context, storage.AccountUpdateParameters) (*storage.Account, error)
+	MockGet                     func(ctx context.Context) (*storage.Account, error)
+	MockDelete                  func(ctx context.Context) error
+	MockIsAccountNameAvailable  func(context.Context, string) error
+	MockListKeys                func(context.Context) ([]storage.AccountKey, error)
+	MockGetMinimumTLSVersion    func(ctx context.Context) (string, error)
+	MockUpdateMinimumTLSVersion func(ctx context.Context, v string) error
 }
 
 var _ azurestorage.AccountOperations = &MockAccountOperations{}
@@ -57,6 +59,12 @@ func NewMockAccountOperations() *MockAccountOperations {
 		MockListKeys: func(i context.Context) ([]storage.AccountKey, error) {
 			return nil, nil
 		},
+		MockGetMinimumTLSVersion: func(ctx context.Context) (string, error) {
+			return "", nil
+		},
+		MockUpdateMinimumTLSVersion: func(ctx context.Context, v string) error {
+			return nil
+		},
 	}
 }
 
@@ -89,3 +97,13 @@ func (m *MockAccountOperations) IsAccountNameAvailable(ctx context.Context, name
 func (m *MockAccountOperations) ListKeys(ctx context.Context) ([]storage.AccountKey, error) {
 	return m.MockListKeys(ctx)
 }
+
+// GetMinimumTLSVersion mock get minimum TLS version
+func (m *MockAccountOperations) GetMinimumTLSVersion(ctx context.Context) (string, error) {
+	return m.MockGetMinimumTLSVersion(ctx)
+}
+
+// UpdateMinimumTLSVersion mock update minimum TLS version
+func (m *MockAccountOperations) UpdateMinimumTLSVersion(ctx context.Context, v string) error {
+	return m.MockUpdateMinimumTLSVersion(ctx, v)
+}
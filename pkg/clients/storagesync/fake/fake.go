@@ -0,0 +1,110 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/storagesync/mgmt/2019-10-01/storagesync"
+	"github.com/Azure/azure-sdk-for-go/services/storagesync/mgmt/2019-10-01/storagesync/storagesyncapi"
+	"github.com/Azure/go-autorest/autorest"
+)
+
+var _ storagesyncapi.ServicesClientAPI = &MockServicesClient{}
+
+// MockServicesClient is a fake implementation of storagesync.ServicesClient.
+type MockServicesClient struct {
+	storagesyncapi.ServicesClientAPI
+
+	MockCreate func(ctx context.Context, resourceGroupName string, storageSyncServiceName string, parameters storagesync.ServiceCreateParameters) (result storagesync.Service, err error)
+	MockDelete func(ctx context.Context, resourceGroupName string, storageSyncServiceName string) (result autorest.Response, err error)
+	MockGet    func(ctx context.Context, resourceGroupName string, storageSyncServiceName string) (result storagesync.Service, err error)
+	MockUpdate func(ctx context.Context, resourceGroupName string, storageSyncServiceName string, parameters *storagesync.ServiceUpdateParameters) (result storagesync.Service, err error)
+}
+
+// Create calls the MockServicesClient's MockCreate method.
+func (c *MockServicesClient) Create(ctx context.Context, resourceGroupName string, storageSyncServiceName string, parameters storagesync.ServiceCreateParameters) (result storagesync.Service, err error) {
+	return c.MockCreate(ctx, resourceGroupName, storageSyncServiceName, parameters)
+}
+
+// Delete calls the MockServicesClient's MockDelete method.
+func (c *MockServicesClient) Delete(ctx context.Context, resourceGroupName string, storageSyncServiceName string) (result autorest.Response, err error) {
+	return c.MockDelete(ctx, resourceGroupName, storageSyncServiceName)
+}
+
+// Get calls the MockServicesClient's MockGet method.
+func (c *MockServicesClient) Get(ctx context.Context, resourceGroupName string, storageSyncServiceName string) (result storagesync.Service, err error) {
+	return c.MockGet(ctx, resourceGroupName, storageSyncServiceName)
+}
+
+// Update calls the MockServicesClient's MockUpdate method.
+func (c *MockServicesClient) Update(ctx context.Context, resourceGroupName string, storageSyncServiceName string, parameters *storagesync.ServiceUpdateParameters) (result storagesync.Service, err error) {
+	return c.MockUpdate(ctx, resourceGroupName, storageSyncServiceName, parameters)
+}
+
+var _ storagesyncapi.SyncGroupsClientAPI = &MockSyncGroupsClient{}
+
+// MockSyncGroupsClient is a fake implementation of storagesync.SyncGroupsClient.
+type MockSyncGroupsClient struct {
+	storagesyncapi.SyncGroupsClientAPI
+
+	MockCreate func(ctx context.Context, resourceGroupName string, storageSyncServiceName string, syncGroupName string, parameters storagesync.SyncGroupCreateParameters) (result storagesync.SyncGroup, err error)
+	MockDelete func(ctx context.Context, resourceGroupName string, storageSyncServiceName string, syncGroupName string) (result autorest.Response, err error)
+	MockGet    func(ctx context.Context, resourceGroupName string, storageSyncServiceName string, syncGroupName string) (result storagesync.SyncGroup, err error)
+}
+
+// Create calls the MockSyncGroupsClient's MockCreate method.
+func (c *MockSyncGroupsClient) Create(ctx context.Context, resourceGroupName string, storageSyncServiceName string, syncGroupName string, parameters storagesync.SyncGroupCreateParameters) (result storagesync.SyncGroup, err error) {
+	return c.MockCreate(ctx, resourceGroupName, storageSyncServiceName, syncGroupName, parameters)
+}
+
+// Delete calls the MockSyncGroupsClient's MockDelete method.
+func (c *MockSyncGroupsClient) Delete(ctx context.Context, resourceGroupName string, storageSyncServiceName string, syncGroupName string) (result autorest.Response, err error) {
+	return c.MockDelete(ctx, resourceGroupName, storageSyncServiceName, syncGroupName)
+}
+
+// Get calls the MockSyncGroupsClient's MockGet method.
+func (c *MockSyncGroupsClient) Get(ctx context.Context, resourceGroupName string, storageSyncServiceName string, syncGroupName string) (result storagesync.SyncGroup, err error) {
+	return c.MockGet(ctx, resourceGroupName, storageSyncServiceName, syncGroupName)
+}
+
+var _ storagesyncapi.CloudEndpointsClientAPI = &MockCloudEndpointsClient{}
+
+// MockCloudEndpointsClient is a fake implementation of
+// storagesync.CloudEndpointsClient.
+type MockCloudEndpointsClient struct {
+	storagesyncapi.CloudEndpointsClientAPI
+
+	MockCreate func(ctx context.Context, resourceGroupName string, storageSyncServiceName string, syncGroupName string, cloudEndpointName string, parameters storagesync.CloudEndpointCreateParameters) (result storagesync.CloudEndpointsCreateFuture, err error)
+	MockDelete func(ctx context.Context, resourceGroupName string, storageSyncServiceName string, syncGroupName string, cloudEndpointName string) (result storagesync.CloudEndpointsDeleteFuture, err error)
+	MockGet    func(ctx context.Context, resourceGroupName string, storageSyncServiceName string, syncGroupName string, cloudEndpointName string) (result storagesync.CloudEndpoint, err error)
+}
+
+// Create calls the MockCloudEndpointsClient's MockCreate method.
+func (c *MockCloudEndpointsClient) Create(ctx context.Context, resourceGroupName string, storageSyncServiceName string, syncGroupName string, cloudEndpointName string, parameters storagesync.CloudEndpointCreateParameters) (result storagesync.CloudEndpointsCreateFuture, err error) {
+	return c.MockCreate(ctx, resourceGroupName, storageSyncServiceName, syncGroupName, cloudEndpointName, parameters)
+}
+
+// Delete calls the MockCloudEndpointsClient's MockDelete method.
+func (c *MockCloudEndpointsClient) Delete(ctx context.Context, resourceGroupName string, storageSyncServiceName string, syncGroupName string, cloudEndpointName string) (result storagesync.CloudEndpointsDeleteFuture, err error) {
+	return c.MockDelete(ctx, resourceGroupName, storageSyncServiceName, syncGroupName, cloudEndpointName)
+}
+
+// Get calls the MockCloudEndpointsClient's MockGet method.
+func (c *MockCloudEndpointsClient) Get(ctx context.Context, resourceGroupName string, storageSyncServiceName string, syncGroupName string, cloudEndpointName string) (result storagesync.CloudEndpoint, err error) {
+	return c.MockGet(ctx, resourceGroupName, storageSyncServiceName, syncGroupName, cloudEndpointName)
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package storagesync contains helpers for Azure File Sync clients.
+package storagesync
+
+import (
+	"github.com/Azure/azure-sdk-for-go/services/storagesync/mgmt/2019-10-01/storagesync"
+
+	"github.com/crossplane/provider-azure/apis/storagesync/v1alpha1"
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+// Provisioning states of cloud endpoints.
+const (
+	ProvisioningStateSucceeded = "Succeeded"
+	ProvisioningStateFailed    = "Failed"
+)
+
+// NewServiceCreateParameters returns the parameters Azure creates the Storage
+// Sync Service of the supplied StorageSyncService with.
+func NewServiceCreateParameters(p v1alpha1.StorageSyncServiceParameters) storagesync.ServiceCreateParameters {
+	return storagesync.ServiceCreateParameters{
+		Location:   azure.ToStringPtr(p.Location),
+		Tags:       azure.ToStringPtrMap(p.Tags),
+		Properties: map[string]interface{}{},
+	}
+}
+
+// NewServiceUpdateParameters returns the parameters Azure updates the Storage
+// Sync Service of the supplied StorageSyncService with. Only its tags can be
+// updated.
+func NewServiceUpdateParameters(p v1alpha1.StorageSyncServiceParameters) *storagesync.ServiceUpdateParameters {
+	return &storagesync.ServiceUpdateParameters{Tags: azure.ToStringPtrMap(p.Tags)}
+}
+
+// ServiceNeedsUpdate returns true if the supplied parameters differ from the
+// supplied Storage Sync Service.
+func ServiceNeedsUpdate(p v1alpha1.StorageSyncServiceParameters, az storagesync.Service) bool {
+	return azure.TagsNeedUpdate(p.Tags, az.Tags)
+}
+
+// LateInitializeService fills the empty fields of the supplied parameters
+// with the values of the supplied Storage Sync Service.
+func LateInitializeService(p *v1alpha1.StorageSyncServiceParameters, az storagesync.Service, tp v1beta1.TagPolicy) {
+	if p.Location == "" {
+		p.Location = azure.ToString(az.Location)
+	}
+	p.Tags = azure.LateInitializeTags(p.Tags, az.Tags, tp)
+}
+
+// GenerateServiceObservation returns the observation of the supplied Storage
+// Sync Service.
+func GenerateServiceObservation(az storagesync.Service) v1alpha1.StorageSyncServiceObservation {
+	o := v1alpha1.StorageSyncServiceObservation{ID: azure.ToString(az.ID)}
+	if az.ServiceProperties == nil {
+		return o
+	}
+	o.StorageSyncServiceStatus = azure.ToInt(az.StorageSyncServiceStatus)
+	o.StorageSyncServiceUID = azure.ToString(az.StorageSyncServiceUID)
+	return o
+}
+
+// NewSyncGroupCreateParameters returns the parameters Azure creates sync
+// groups with. Sync groups have no parameters.
+func NewSyncGroupCreateParameters() storagesync.SyncGroupCreateParameters {
+	return storagesync.SyncGroupCreateParameters{Properties: map[string]interface{}{}}
+}
+
+// GenerateSyncGroupObservation returns the observation of the supplied sync
+// group.
+func GenerateSyncGroupObservation(az storagesync.SyncGroup) v1alpha1.SyncGroupObservation {
+	o := v1alpha1.SyncGroupObservation{ID: azure.ToString(az.ID)}
+	if az.SyncGroupProperties == nil {
+		return o
+	}
+	o.UniqueID = azure.ToString(az.UniqueID)
+	o.SyncGroupStatus = azure.ToString(az.SyncGroupStatus)
+	return o
+}
+
+// NewCloudEndpointCreateParameters returns the parameters Azure creates the
+// cloud endpoint of the supplied CloudEndpoint with. The storage account is
+// assumed to be in the supplied tenant unless the CloudEndpoint specifies
+// another.
+func NewCloudEndpointCreateParameters(p v1alpha1.CloudEndpointParameters, tenantID string) storagesync.CloudEndpointCreateParameters {
+	tenant := azure.ToStringPtr(tenantID)
+	if p.StorageAccountTenantID != nil {
+		tenant = p.StorageAccountTenantID
+	}
+	return storagesync.CloudEndpointCreateParameters{
+		CloudEndpointCreateParametersProperties: &storagesync.CloudEndpointCreateParametersProperties{
+			StorageAccountResourceID: azure.ToStringPtr(p.StorageAccountID),
+			AzureFileShareName:       azure.ToStringPtr(p.AzureFileShareName),
+			StorageAccountTenantID:   tenant,
+			FriendlyName:             p.FriendlyName,
+		},
+	}
+}
+
+// LateInitializeCloudEndpoint fills the empty fields of the supplied
+// parameters with the values of the supplied cloud endpoint.
+func LateInitializeCloudEndpoint(p *v1alpha1.CloudEndpointParameters, az storagesync.CloudEndpoint) {
+	if az.CloudEndpointProperties == nil {
+		return
+	}
+	p.StorageAccountTenantID = azure.LateInitializeStringPtrFromPtr(p.StorageAccountTenantID, az.StorageAccountTenantID)
+	p.FriendlyName = azure.LateInitializeStringPtrFromPtr(p.FriendlyName, az.FriendlyName)
+}
+
+// GenerateCloudEndpointObservation returns the observation of the supplied
+// cloud endpoint.
+func GenerateCloudEndpointObservation(az storagesync.CloudEndpoint) v1alpha1.CloudEndpointObservation {
+	o := v1alpha1.CloudEndpointObservation{ID: azure.ToString(az.ID)}
+	if az.CloudEndpointProperties == nil {
+		return o
+	}
+	o.ProvisioningState = azure.ToString(az.ProvisioningState)
+	o.PartnershipID = azure.ToString(az.PartnershipID)
+	o.BackupEnabled = azure.ToString(az.BackupEnabled)
+	o.LastWorkflowID = azure.ToString(az.LastWorkflowID)
+	o.LastOperationName = azure.ToString(az.LastOperationName)
+	return o
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storagesync
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/storagesync/mgmt/2019-10-01/storagesync"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/provider-azure/apis/storagesync/v1alpha1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+const (
+	accountID = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/acc"
+	shareName = "share"
+	tenantID  = "tenant"
+)
+
+func TestServiceNeedsUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		p      v1alpha1.StorageSyncServiceParameters
+		az     storagesync.Service
+		want   bool
+	}{
+		"UpToDate": {
+			reason: "A service with the desired tags should not need an update",
+			p:      v1alpha1.StorageSyncServiceParameters{Tags: map[string]string{"team": "data"}},
+			az:     storagesync.Service{Tags: map[string]*string{"team": azure.ToStringPtr("data")}},
+			want:   false,
+		},
+		"TagsDiffer": {
+			reason: "A service whose tags differ should need an update",
+			p:      v1alpha1.StorageSyncServiceParameters{Tags: map[string]string{"team": "data"}},
+			az:     storagesync.Service{Tags: map[string]*string{"team": azure.ToStringPtr("platform")}},
+			want:   true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ServiceNeedsUpdate(tc.p, tc.az)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nServiceNeedsUpdate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestNewCloudEndpointCreateParameters(t *testing.T) {
+	other := "other-tenant"
+
+	cases := map[string]struct {
+		reason string
+		p      v1alpha1.CloudEndpointParameters
+		want   storagesync.CloudEndpointCreateParameters
+	}{
+		"DefaultTenant": {
+			reason: "The storage account should be assumed to be in the tenant of the credentials",
+			p:      v1alpha1.CloudEndpointParameters{StorageAccountID: accountID, AzureFileShareName: shareName},
+			want: storagesync.CloudEndpointCreateParameters{
+				CloudEndpointCreateParametersProperties: &storagesync.CloudEndpointCreateParametersProperties{
+					StorageAccountResourceID: azure.ToStringPtr(accountID),
+					AzureFileShareName:       azure.ToStringPtr(shareName),
+					StorageAccountTenantID:   azure.ToStringPtr(tenantID),
+				},
+			},
+		},
+		"OtherTenant": {
+			reason: "The tenant of the storage account should be used if it is specified",
+			p:      v1alpha1.CloudEndpointParameters{StorageAccountID: accountID, AzureFileShareName: shareName, StorageAccountTenantID: &other},
+			want: storagesync.CloudEndpointCreateParameters{
+				CloudEndpointCreateParametersProperties: &storagesync.CloudEndpointCreateParametersProperties{
+					StorageAccountResourceID: azure.ToStringPtr(accountID),
+					AzureFileShareName:       azure.ToStringPtr(shareName),
+					StorageAccountTenantID:   &other,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := NewCloudEndpointCreateParameters(tc.p, tenantID)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nNewCloudEndpointCreateParameters(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLateInitializeCloudEndpoint(t *testing.T) {
+	friendly := "friendly"
+
+	cases := map[string]struct {
+		reason string
+		p      v1alpha1.CloudEndpointParameters
+		az     storagesync.CloudEndpoint
+		want   v1alpha1.CloudEndpointParameters
+	}{
+		"NoProperties": {
+			reason: "Nothing should be late initialized from a cloud endpoint without properties",
+			p:      v1alpha1.CloudEndpointParameters{AzureFileShareName: shareName},
+			az:     storagesync.CloudEndpoint{},
+			want:   v1alpha1.CloudEndpointParameters{AzureFileShareName: shareName},
+		},
+		"EmptyFields": {
+			reason: "The tenant and friendly name should be late initialized if they are empty",
+			p:      v1alpha1.CloudEndpointParameters{AzureFileShareName: shareName},
+			az: storagesync.CloudEndpoint{CloudEndpointProperties: &storagesync.CloudEndpointProperties{
+				StorageAccountTenantID: azure.ToStringPtr(tenantID),
+				FriendlyName:           &friendly,
+			}},
+			want: v1alpha1.CloudEndpointParameters{
+				AzureFileShareName:     shareName,
+				StorageAccountTenantID: azure.ToStringPtr(tenantID),
+				FriendlyName:           &friendly,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializeCloudEndpoint(&tc.p, tc.az)
+			if diff := cmp.Diff(tc.want, tc.p); diff != "" {
+				t.Errorf("\n%s\nLateInitializeCloudEndpoint(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+)
+
+// TagPolicyOf returns the TagPolicy of the ProviderConfig the supplied managed
+// resource uses. Resources that use the deprecated Provider, and those whose
+// ProviderConfig has no TagPolicy, use TagPolicyReplace.
+func TagPolicyOf(ctx context.Context, c client.Reader, mg resource.Managed) (v1beta1.TagPolicy, error) {
+	ref := mg.GetProviderConfigReference()
+	if ref == nil {
+		return v1beta1.TagPolicyReplace, nil
+	}
+	pc := &v1beta1.ProviderConfig{}
+	if err := c.Get(ctx, types.NamespacedName{Name: ref.Name}, pc); err != nil {
+		return "", errors.Wrap(err, errGetProviderConfig)
+	}
+	if pc.Spec.TagPolicy == nil {
+		return v1beta1.TagPolicyReplace, nil
+	}
+	return *pc.Spec.TagPolicy, nil
+}
+
+// LateInitializeTags late-inits the supplied tags of a managed resource with
+// the tags of its Azure resource. Under TagPolicyMerge the tags that were
+// added to the Azure resource outside of Crossplane are added to the supplied
+// tags, so that they are kept when the Azure resource is updated. Otherwise
+// the Azure tags are only adopted by managed resources without tags.
+func LateInitializeTags(in map[string]string, from map[string]*string, p v1beta1.TagPolicy) map[string]string {
+	if p == v1beta1.TagPolicyMerge {
+		return LateInitializeStringMapKeys(in, from)
+	}
+	return LateInitializeStringMap(in, from)
+}
+
+// TagsNeedUpdate returns true if the supplied tags of a managed resource
+// differ from the supplied tags of its Azure resource. No tags and empty tags
+// are equal.
+func TagsNeedUpdate(in map[string]string, az map[string]*string) bool {
+	if len(in) != len(az) {
+		return true
+	}
+	for k, v := range az {
+		w, ok := in[k]
+		if !ok || w != ToString(v) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/v1alpha3"
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+)
+
+func TestTagPolicyOf(t *testing.T) {
+	errBoom := errors.New("boom")
+	merge := v1beta1.TagPolicyMerge
+	withPC := &v1alpha3.ResourceGroup{Spec: v1alpha3.ResourceGroupSpec{ResourceSpec: xpv1.ResourceSpec{
+		ProviderConfigReference: &xpv1.Reference{Name: "default"},
+	}}}
+
+	type want struct {
+		p   v1beta1.TagPolicy
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		c      client.Reader
+		mg     *v1alpha3.ResourceGroup
+		want   want
+	}{
+		"NoProviderConfig": {
+			reason: "Resources that do not use a ProviderConfig should replace tags",
+			mg:     &v1alpha3.ResourceGroup{},
+			want:   want{p: v1beta1.TagPolicyReplace},
+		},
+		"GetFailed": {
+			reason: "Errors getting the ProviderConfig should be returned",
+			c:      &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			mg:     withPC,
+			want:   want{err: errors.Wrap(errBoom, errGetProviderConfig)},
+		},
+		"NoTagPolicy": {
+			reason: "Resources whose ProviderConfig has no tag policy should replace tags",
+			c:      &test.MockClient{MockGet: test.NewMockGetFn(nil)},
+			mg:     withPC,
+			want:   want{p: v1beta1.TagPolicyReplace},
+		},
+		"TagPolicy": {
+			reason: "Resources should use the tag policy of their ProviderConfig",
+			c: &test.MockClient{MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+				o.(*v1beta1.ProviderConfig).Spec.TagPolicy = &merge
+				return nil
+			})},
+			mg:   withPC,
+			want: want{p: v1beta1.TagPolicyMerge},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p, err := TagPolicyOf(context.Background(), tc.c, tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nTagPolicyOf(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.p, p); diff != "" {
+				t.Errorf("\n%s\nTagPolicyOf(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLateInitializeTags(t *testing.T) {
+	external := map[string]*string{"team": ToStringPtr("data"), "costCenter": ToStringPtr("42")}
+
+	cases := map[string]struct {
+		reason string
+		in     map[string]string
+		p      v1beta1.TagPolicy
+		want   map[string]string
+	}{
+		"ReplaceNoTags": {
+			reason: "Under the Replace policy the Azure tags should be adopted by a resource without tags",
+			p:      v1beta1.TagPolicyReplace,
+			want:   map[string]string{"team": "data", "costCenter": "42"},
+		},
+		"ReplaceTags": {
+			reason: "Under the Replace policy the tags of a resource with tags should not change",
+			in:     map[string]string{"team": "web"},
+			p:      v1beta1.TagPolicyReplace,
+			want:   map[string]string{"team": "web"},
+		},
+		"Merge": {
+			reason: "Under the Merge policy tags added outside of Crossplane should be added to the tags of a resource",
+			in:     map[string]string{"team": "web"},
+			p:      v1beta1.TagPolicyMerge,
+			want:   map[string]string{"team": "web", "costCenter": "42"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := LateInitializeTags(tc.in, external, tc.p)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nLateInitializeTags(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestTagsNeedUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		in     map[string]string
+		az     map[string]*string
+		want   bool
+	}{
+		"NoTags": {
+			reason: "No tags should equal empty tags",
+			az:     map[string]*string{},
+			want:   false,
+		},
+		"Equal": {
+			reason: "Equal tags should not need an update",
+			in:     map[string]string{"team": "data"},
+			az:     map[string]*string{"team": ToStringPtr("data")},
+			want:   false,
+		},
+		"ValueChanged": {
+			reason: "A tag whose value differs should need an update",
+			in:     map[string]string{"team": "data"},
+			az:     map[string]*string{"team": ToStringPtr("web")},
+			want:   true,
+		},
+		"TagAdded": {
+			reason: "A tag that was added to the Azure resource should need an update",
+			in:     map[string]string{"team": "data"},
+			az:     map[string]*string{"team": ToStringPtr("data"), "costCenter": ToStringPtr("42")},
+			want:   true,
+		},
+		"TagRemoved": {
+			reason: "A tag that was removed from the Azure resource should need an update",
+			in:     map[string]string{"team": "data"},
+			want:   true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := TagsNeedUpdate(tc.in, tc.az)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nTagsNeedUpdate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
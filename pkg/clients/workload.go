@@ -0,0 +1,99 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"io/ioutil"
+	"net/url"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/adal"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/pkg/errors"
+)
+
+// DefaultFederatedTokenFile is the path the Azure workload identity webhook
+// projects the service account token of a pod to.
+const DefaultFederatedTokenFile = "/var/run/secrets/azure/tokens/azure-identity-token"
+
+const clientAssertionTypeJWTBearer = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+
+// Error strings.
+const (
+	errReadFederatedToken            = "cannot read federated token file"
+	errEmptyFederatedToken           = "federated token file is empty"
+	errGetWorkloadIdentityAuthorizer = "cannot get authorizer from workload identity config"
+)
+
+// UseWorkloadIdentity returns the necessary information to construct an
+// Azure client that authenticates as the identity with the supplied client ID
+// by exchanging the federated token in the supplied file, or in
+// DefaultFederatedTokenFile if it is nil, for Azure access tokens. Access
+// tokens are requested for the supplied Endpoint, or for that of the public
+// Azure cloud if it is nil.
+func UseWorkloadIdentity(subscriptionID, tenantID, clientID string, tokenFile *string, e *Endpoint) (content map[string]string, authorizer autorest.Authorizer, err error) {
+	if e == nil {
+		e = &PublicEndpoint
+	}
+	path := DefaultFederatedTokenFile
+	if tokenFile != nil {
+		path = *tokenFile
+	}
+	a, err := DefaultAuthorizerCache.Get(Fingerprint("workload-identity", tenantID, clientID, path, e.Audience), func() (autorest.Authorizer, error) {
+		cfg, err := adal.NewOAuthConfig(azure.PublicCloud.ActiveDirectoryEndpoint, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		t, err := adal.NewServicePrincipalTokenWithSecret(*cfg, clientID, e.Audience, &FederatedTokenSecret{Path: path})
+		if err != nil {
+			return nil, err
+		}
+		return autorest.NewBearerAuthorizer(t), nil
+	})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, errGetWorkloadIdentityAuthorizer)
+	}
+	m := e.content(subscriptionID)
+	m[CredentialsKeyTenantID] = tenantID
+	m[CredentialsKeyClientID] = clientID
+	return m, a, nil
+}
+
+// A FederatedTokenSecret authenticates the token requests of a service
+// principal with a federated token, i.e. a Kubernetes service account token
+// that Azure AD trusts. The token is read from its file on every request, since
+// the kubelet rotates it.
+type FederatedTokenSecret struct {
+	Path string
+}
+
+// SetAuthenticationValues sets the federated token as the client assertion of
+// a token request.
+func (s *FederatedTokenSecret) SetAuthenticationValues(_ *adal.ServicePrincipalToken, v *url.Values) error {
+	b, err := ioutil.ReadFile(s.Path)
+	if err != nil {
+		return errors.Wrap(err, errReadFederatedToken)
+	}
+	t := strings.TrimSpace(string(b))
+	if t == "" {
+		return errors.New(errEmptyFederatedToken)
+	}
+	v.Set("client_assertion", t)
+	v.Set("client_assertion_type", clientAssertionTypeJWTBearer)
+	return nil
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestFederatedTokenSecret(t *testing.T) {
+	dir, err := ioutil.TempDir("", "federated-token")
+	if err != nil {
+		t.Fatalf("TempDir(...): %s", err)
+	}
+	defer os.RemoveAll(dir) // nolint:errcheck
+
+	write := func(name, content string) string {
+		p := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(p, []byte(content), 0600); err != nil {
+			t.Fatalf("WriteFile(...): %s", err)
+		}
+		return p
+	}
+
+	cases := map[string]struct {
+		path    string
+		want    url.Values
+		wantErr error
+	}{
+		"Token": {
+			path: write("token", "cool.jwt.token\n"),
+			want: url.Values{
+				"client_assertion":      []string{"cool.jwt.token"},
+				"client_assertion_type": []string{clientAssertionTypeJWTBearer},
+			},
+		},
+		"EmptyToken": {
+			path:    write("empty", "\n"),
+			want:    url.Values{},
+			wantErr: errors.New(errEmptyFederatedToken),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			v := url.Values{}
+			err := (&FederatedTokenSecret{Path: tc.path}).SetAuthenticationValues(nil, &v)
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("SetAuthenticationValues(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want, v); diff != "" {
+				t.Errorf("SetAuthenticationValues(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
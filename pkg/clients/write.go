@@ -0,0 +1,97 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// A WriteTracker remembers which generation of each managed resource was last
+// written to Azure, and the etag Azure reported once that write settled. As
+// long as neither has changed since, nothing changed on either side and
+// another write would be a no-op, even if the spec and the Azure resource do
+// not compare equal, e.g. because Azure normalizes some fields. A nil
+// WriteTracker tracks nothing.
+type WriteTracker struct {
+	mu      sync.Mutex
+	written map[types.UID]write
+}
+
+type write struct {
+	generation int64
+	etag       string
+}
+
+// NewWriteTracker returns an empty WriteTracker.
+func NewWriteTracker() *WriteTracker {
+	return &WriteTracker{written: make(map[types.UID]write)}
+}
+
+// Written records that the current generation of the supplied managed
+// resource was written to Azure.
+func (t *WriteTracker) Written(mg resource.Managed) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.written[mg.GetUID()] = write{generation: mg.GetGeneration()}
+}
+
+// Observed records the etag Azure reports for the supplied managed resource
+// if it is the first one observed since the last write. It must only be called
+// once provisioning has settled, so that the etag reflects the write.
+func (t *WriteTracker) Observed(mg resource.Managed, etag string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	w, ok := t.written[mg.GetUID()]
+	if !ok || w.etag != "" || w.generation != mg.GetGeneration() {
+		return
+	}
+	w.etag = etag
+	t.written[mg.GetUID()] = w
+}
+
+// Unchanged returns true if the current generation of the supplied managed
+// resource was written to Azure, and Azure still reports the supplied etag
+// that it reported once that write settled.
+func (t *WriteTracker) Unchanged(mg resource.Managed, etag string) bool {
+	if t == nil || etag == "" {
+		return false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	w, ok := t.written[mg.GetUID()]
+	return ok && w.generation == mg.GetGeneration() && w.etag == etag
+}
+
+// Forget the writes of the supplied managed resource.
+func (t *WriteTracker) Forget(mg resource.Managed) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.written, mg.GetUID())
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+)
+
+func TestWriteTracker(t *testing.T) {
+	managed := func(gen int64) resource.Managed {
+		return &fake.Managed{ObjectMeta: metav1.ObjectMeta{UID: "cool-uid", Generation: gen}}
+	}
+
+	cases := map[string]struct {
+		steps func(w *WriteTracker)
+		mg    resource.Managed
+		etag  string
+		want  bool
+	}{
+		"NeverWritten": {
+			steps: func(w *WriteTracker) { w.Observed(managed(1), "a") },
+			mg:    managed(1),
+			etag:  "a",
+		},
+		"WrittenNotObserved": {
+			steps: func(w *WriteTracker) { w.Written(managed(1)) },
+			mg:    managed(1),
+			etag:  "a",
+		},
+		"Unchanged": {
+			steps: func(w *WriteTracker) {
+				w.Written(managed(1))
+				w.Observed(managed(1), "a")
+				w.Observed(managed(1), "b")
+			},
+			mg:   managed(1),
+			etag: "a",
+			want: true,
+		},
+		"ChangedInAzure": {
+			steps: func(w *WriteTracker) {
+				w.Written(managed(1))
+				w.Observed(managed(1), "a")
+			},
+			mg:   managed(1),
+			etag: "b",
+		},
+		"SpecChanged": {
+			steps: func(w *WriteTracker) {
+				w.Written(managed(1))
+				w.Observed(managed(1), "a")
+			},
+			mg:   managed(2),
+			etag: "a",
+		},
+		"Forgotten": {
+			steps: func(w *WriteTracker) {
+				w.Written(managed(1))
+				w.Observed(managed(1), "a")
+				w.Forget(managed(1))
+			},
+			mg:   managed(1),
+			etag: "a",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			w := NewWriteTracker()
+			tc.steps(w)
+			if got := w.Unchanged(tc.mg, tc.etag); got != tc.want {
+				t.Errorf("Unchanged(...): want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
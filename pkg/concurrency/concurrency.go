@@ -0,0 +1,91 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package concurrency configures how many managed resources of a kind their
+// controller reconciles in parallel.
+package concurrency
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// GroupSuffix is the suffix of the API groups of the managed resources of the
+// provider. Limits may name a group without it, e.g. compute.
+const GroupSuffix = ".azure.crossplane.io"
+
+// Error strings.
+const (
+	errFormat = "invalid limit %q, must be group=n"
+	errLimit  = "invalid limit %q of group %s, must be a positive integer"
+)
+
+// DefaultLimits are the Limits of the provider. They are configured by the
+// flags of the provider at startup.
+var DefaultLimits = &Limits{Default: 1}
+
+// Limits of the number of managed resources the controller of a kind
+// reconciles concurrently.
+type Limits struct {
+	// Default is the limit of the kinds of groups without one of their own.
+	Default int
+
+	// Groups are the limits of the kinds of an API group, by group.
+	Groups map[string]int
+}
+
+// Of returns the limit of the kind of the supplied group kind, e.g.
+// Redis.cache.azure.crossplane.io.
+func (l *Limits) Of(groupKind string) int {
+	g := groupKind
+	if i := strings.Index(g, "."); i >= 0 {
+		g = g[i+1:]
+	}
+	if n, ok := l.Groups[g]; ok {
+		return n
+	}
+	if n, ok := l.Groups[strings.TrimSuffix(g, GroupSuffix)]; ok {
+		return n
+	}
+	if l.Default < 1 {
+		return 1
+	}
+	return l.Default
+}
+
+// Parse the comma separated limits of API groups, e.g. compute=4,database=2.
+func Parse(s string) (map[string]int, error) {
+	out := make(map[string]int)
+	for _, l := range strings.Split(s, ",") {
+		l = strings.TrimSpace(l)
+		if l == "" {
+			continue
+		}
+		kv := strings.SplitN(l, "=", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+			return nil, errors.Errorf(errFormat, l)
+		}
+		g := strings.TrimSpace(kv[0])
+		n, err := strconv.Atoi(strings.TrimSpace(kv[1]))
+		if err != nil || n < 1 {
+			return nil, errors.Errorf(errLimit, kv[1], g)
+		}
+		out[g] = n
+	}
+	return out, nil
+}
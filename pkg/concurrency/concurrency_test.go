@@ -0,0 +1,115 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package concurrency
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestParse(t *testing.T) {
+	type want struct {
+		g   map[string]int
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		s      string
+		want   want
+	}{
+		"Empty": {
+			reason: "No limits should be parsed from an empty string.",
+			s:      "",
+			want:   want{g: map[string]int{}},
+		},
+		"Limits": {
+			reason: "Comma separated limits should be parsed, ignoring whitespace.",
+			s:      "compute=4, database.azure.crossplane.io = 2",
+			want:   want{g: map[string]int{"compute": 4, "database.azure.crossplane.io": 2}},
+		},
+		"MissingLimit": {
+			reason: "A group without a limit should be rejected.",
+			s:      "compute",
+			want:   want{err: errors.Errorf(errFormat, "compute")},
+		},
+		"InvalidLimit": {
+			reason: "A limit that is not a positive integer should be rejected.",
+			s:      "compute=0",
+			want:   want{err: errors.Errorf(errLimit, "0", "compute")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			g, err := Parse(tc.s)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nParse(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.g, g); diff != "" {
+				t.Errorf("\n%s\nParse(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestOf(t *testing.T) {
+	cases := map[string]struct {
+		reason    string
+		l         *Limits
+		groupKind string
+		want      int
+	}{
+		"Default": {
+			reason:    "The default limit should apply to kinds of groups without one of their own.",
+			l:         &Limits{Default: 3, Groups: map[string]int{"compute": 4}},
+			groupKind: "Redis.cache.azure.crossplane.io",
+			want:      3,
+		},
+		"ShortGroup": {
+			reason:    "The limit of a group named without its suffix should apply to its kinds.",
+			l:         &Limits{Default: 3, Groups: map[string]int{"cache": 4}},
+			groupKind: "Redis.cache.azure.crossplane.io",
+			want:      4,
+		},
+		"Group": {
+			reason:    "The limit of a group should apply to its kinds.",
+			l:         &Limits{Default: 3, Groups: map[string]int{"cache.azure.crossplane.io": 5}},
+			groupKind: "Redis.cache.azure.crossplane.io",
+			want:      5,
+		},
+		"NoDefault": {
+			reason:    "Kinds should be reconciled one at a time if there is no default limit.",
+			l:         &Limits{},
+			groupKind: "Redis.cache.azure.crossplane.io",
+			want:      1,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := tc.l.Of(tc.groupKind)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nOf(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
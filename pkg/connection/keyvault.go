@@ -0,0 +1,150 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package connection
+
+import (
+	"bytes"
+	"context"
+
+	azurekeyvault "github.com/Azure/azure-sdk-for-go/services/keyvault/v7.0/keyvault"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/keyvault"
+)
+
+// Error strings.
+const (
+	errGetProviderConfig = "cannot get ProviderConfig of connection secret Key Vault"
+	errNewSecretsClient  = "cannot create client of connection secret Key Vault"
+	errGetVaultSecret    = "cannot get Key Vault secret %q"
+	errSetVaultSecret    = "cannot set Key Vault secret %q"
+	errDeleteVaultSecret = "cannot delete Key Vault secret %q"
+)
+
+// A KeyVaultPublisher publishes the ConnectionDetails of managed resources
+// whose ProviderConfig has a ConnectionSecretKeyVault to a secret of that Key
+// Vault, rather than with the publishers it wraps, so that they are never
+// stored in etcd. The secret holds the connection details as a JSON object
+// and is named after the kind and name of its managed resource, e.g.
+// redis-example. The ConnectionDetails of all other managed resources are
+// published with the wrapped publishers.
+type KeyVaultPublisher struct {
+	client     client.Client
+	typer      runtime.ObjectTyper
+	newSecrets func(ctx context.Context, c client.Client, pc *v1beta1.ProviderConfig) (keyvault.SecretsAPI, error)
+	publisher  managed.ConnectionPublisher
+}
+
+// NewKeyVaultPublisher returns a new KeyVaultPublisher that wraps the supplied
+// publishers.
+func NewKeyVaultPublisher(c client.Client, ot runtime.ObjectTyper, p ...managed.ConnectionPublisher) *KeyVaultPublisher {
+	return &KeyVaultPublisher{client: c, typer: ot, newSecrets: keyvault.NewSecretsClient, publisher: managed.PublisherChain(p)}
+}
+
+// vault returns the Key Vault the connection details of the supplied managed
+// resource are published to and a client of it, or nil if its ProviderConfig
+// has none.
+func (p *KeyVaultPublisher) vault(ctx context.Context, mg resource.Managed) (*v1beta1.KeyVault, keyvault.SecretsAPI, error) {
+	ref := mg.GetProviderConfigReference()
+	if ref == nil {
+		return nil, nil, nil
+	}
+	pc := &v1beta1.ProviderConfig{}
+	if err := p.client.Get(ctx, types.NamespacedName{Name: ref.Name}, pc); err != nil {
+		return nil, nil, errors.Wrap(err, errGetProviderConfig)
+	}
+	if pc.Spec.ConnectionSecretKeyVault == nil {
+		return nil, nil, nil
+	}
+	s, err := p.newSecrets(ctx, p.client, pc)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, errNewSecretsClient)
+	}
+	return pc.Spec.ConnectionSecretKeyVault, s, nil
+}
+
+// PublishConnection publishes the supplied ConnectionDetails to the Key Vault
+// of the ProviderConfig of the supplied managed resource, or with the wrapped
+// publishers if it has none. Like the keys of a Kubernetes connection secret,
+// the ConnectionDetails are merged into those already published. The secret
+// is only written when they change, so that not every reconcile adds a
+// version to it.
+func (p *KeyVaultPublisher) PublishConnection(ctx context.Context, mg resource.Managed, c managed.ConnectionDetails) error {
+	kv, s, err := p.vault(ctx, mg)
+	if err != nil {
+		return err
+	}
+	if kv == nil {
+		return p.publisher.PublishConnection(ctx, mg, c)
+	}
+	if len(c) == 0 {
+		return nil
+	}
+
+	name := keyvault.SecretName(resource.MustGetKind(mg, p.typer).Kind, mg.GetName())
+	current := managed.ConnectionDetails{}
+	b, err := s.GetSecret(ctx, kv.VaultURL, name, "")
+	if resource.Ignore(azure.IsNotFound, err) != nil {
+		return errors.Wrapf(err, errGetVaultSecret, name)
+	}
+	if err == nil {
+		if current, err = keyvault.Unmarshal(azure.ToString(b.Value)); err != nil {
+			return errors.Wrapf(err, errGetVaultSecret, name)
+		}
+	}
+
+	changed := false
+	for k, v := range c {
+		if cv, ok := current[k]; !ok || !bytes.Equal(cv, v) {
+			current[k] = v
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	_, err = s.SetSecret(ctx, kv.VaultURL, name, azurekeyvault.SecretSetParameters{
+		Value:       azure.ToStringPtr(keyvault.Marshal(current)),
+		ContentType: azure.ToStringPtr(keyvault.ContentType),
+	})
+	return errors.Wrapf(err, errSetVaultSecret, name)
+}
+
+// UnpublishConnection deletes the Key Vault secret of the supplied managed
+// resource, or unpublishes the supplied ConnectionDetails with the wrapped
+// publishers if its ProviderConfig has no Key Vault. Deleted secrets are kept
+// by Key Vaults that have soft delete enabled until they are purged.
+func (p *KeyVaultPublisher) UnpublishConnection(ctx context.Context, mg resource.Managed, c managed.ConnectionDetails) error {
+	kv, s, err := p.vault(ctx, mg)
+	if err != nil {
+		return err
+	}
+	if kv == nil {
+		return p.publisher.UnpublishConnection(ctx, mg, c)
+	}
+	name := keyvault.SecretName(resource.MustGetKind(mg, p.typer).Kind, mg.GetName())
+	_, err = s.DeleteSecret(ctx, kv.VaultURL, name)
+	return errors.Wrapf(resource.Ignore(azure.IsNotFound, err), errDeleteVaultSecret, name)
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package connection
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	azurekeyvault "github.com/Azure/azure-sdk-for-go/services/keyvault/v7.0/keyvault"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/keyvault"
+	kvfake "github.com/crossplane/provider-azure/pkg/clients/keyvault/fake"
+)
+
+var _ managed.ConnectionPublisher = &KeyVaultPublisher{}
+
+func TestKeyVaultPublisherPublishConnection(t *testing.T) {
+	errBoom := errors.New("boom")
+	vaultURL := "https://cool.vault.azure.net"
+	mg := &fake.Managed{
+		ObjectMeta:               metav1.ObjectMeta{Name: "cool"},
+		ProviderConfigReferencer: fake.ProviderConfigReferencer{Ref: &xpv1.Reference{Name: "default"}},
+	}
+	name := keyvault.SecretName(fake.GVK(mg).Kind, "cool")
+
+	// withVault returns a client whose ProviderConfig has a Key Vault.
+	withVault := &test.MockClient{MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+		o.(*v1beta1.ProviderConfig).Spec.ConnectionSecretKeyVault = &v1beta1.KeyVault{VaultURL: vaultURL}
+		return nil
+	})}
+	// existing returns a fake Key Vault whose secret of the managed resource
+	// holds the supplied value, and that records the value it is set to.
+	existing := func(value string, getErr error, set *string) *kvfake.MockSecretsClient {
+		return &kvfake.MockSecretsClient{
+			MockGetSecret: func(_ context.Context, u, n, _ string) (azurekeyvault.SecretBundle, error) {
+				if u != vaultURL || n != name {
+					t.Errorf("GetSecret(...): want %s %s, got %s %s", vaultURL, name, u, n)
+				}
+				return azurekeyvault.SecretBundle{Value: azure.ToStringPtr(value)}, getErr
+			},
+			MockSetSecret: func(_ context.Context, _, _ string, p azurekeyvault.SecretSetParameters) (azurekeyvault.SecretBundle, error) {
+				*set = azure.ToString(p.Value)
+				return azurekeyvault.SecretBundle{}, nil
+			},
+		}
+	}
+	notFound := autorest.DetailedError{StatusCode: http.StatusNotFound}
+
+	type want struct {
+		err       error
+		set       string
+		published bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Client
+		vault  func(set *string) keyvault.SecretsAPI
+		c      managed.ConnectionDetails
+		want   want
+	}{
+		"NoKeyVault": {
+			reason: "Connection details of managed resources whose ProviderConfig has no Key Vault should be published with the wrapped publishers",
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(nil)},
+			c:      managed.ConnectionDetails{"password": []byte("s3cret")},
+			want:   want{published: true},
+		},
+		"GetProviderConfigError": {
+			reason: "Errors getting the ProviderConfig should be returned",
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			want:   want{err: errors.Wrap(errBoom, errGetProviderConfig)},
+		},
+		"NewSecret": {
+			reason: "Connection details should be written to a new Key Vault secret",
+			kube:   withVault,
+			vault: func(set *string) keyvault.SecretsAPI {
+				return existing("", notFound, set)
+			},
+			c:    managed.ConnectionDetails{"password": []byte("s3cret")},
+			want: want{set: `{"password":"s3cret"}`},
+		},
+		"Merged": {
+			reason: "Connection details should be merged into those of an existing Key Vault secret",
+			kube:   withVault,
+			vault: func(set *string) keyvault.SecretsAPI {
+				return existing(`{"endpoint":"example.org","password":"old"}`, nil, set)
+			},
+			c:    managed.ConnectionDetails{"password": []byte("s3cret")},
+			want: want{set: `{"endpoint":"example.org","password":"s3cret"}`},
+		},
+		"Unchanged": {
+			reason: "A Key Vault secret that already holds the connection details should not be written",
+			kube:   withVault,
+			vault: func(set *string) keyvault.SecretsAPI {
+				return existing(`{"endpoint":"example.org","password":"s3cret"}`, nil, set)
+			},
+			c: managed.ConnectionDetails{"password": []byte("s3cret")},
+		},
+		"GetSecretError": {
+			reason: "Errors getting the Key Vault secret should be returned",
+			kube:   withVault,
+			vault: func(set *string) keyvault.SecretsAPI {
+				return existing("", errBoom, set)
+			},
+			c:    managed.ConnectionDetails{"password": []byte("s3cret")},
+			want: want{err: errors.Wrapf(errBoom, errGetVaultSecret, name)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			set, published := "", false
+			p := &KeyVaultPublisher{
+				client: tc.kube,
+				typer:  fake.SchemeWith(&fake.Managed{}),
+				newSecrets: func(_ context.Context, _ client.Client, _ *v1beta1.ProviderConfig) (keyvault.SecretsAPI, error) {
+					return tc.vault(&set), nil
+				},
+				publisher: managed.ConnectionPublisherFns{
+					PublishConnectionFn: func(_ context.Context, _ resource.Managed, _ managed.ConnectionDetails) error {
+						published = true
+						return nil
+					},
+				},
+			}
+			err := p.PublishConnection(context.Background(), mg, tc.c)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nPublishConnection(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.set, set); diff != "" {
+				t.Errorf("\n%s\nPublishConnection(...): -want secret, +got secret:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.published, published); diff != "" {
+				t.Errorf("\n%s\nPublishConnection(...): -want published, +got published:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package connection
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// Error strings.
+const (
+	errNamespaceNotAllowedFmt = "connection secrets may not be written to namespace %q; allowed namespaces are %s"
+	errUpdateManaged          = "cannot update managed resource with default connection secret"
+)
+
+// DefaultNamespacePolicy is the NamespacePolicy of the connection secrets of
+// all managed resources. The provider configures it from its flags before it
+// sets up its controllers. It allows every namespace and defaults none unless
+// configured.
+var DefaultNamespacePolicy = &NamespacePolicy{}
+
+// A NamespacePolicy defaults and restricts the namespaces managed resources
+// may write their connection secrets to, so that the credentials of Azure
+// resources cannot be leaked into arbitrary namespaces.
+type NamespacePolicy struct {
+	// Default is the namespace the connection secrets of managed resources
+	// that do not specify a writeConnectionSecretToRef are written to. Their
+	// connection details are not published at all if it is empty.
+	Default string
+
+	// Allowed namespaces connection secrets may be written to. Any namespace
+	// is allowed if it is empty. The Default namespace is always allowed.
+	Allowed []string
+}
+
+// ParseNamespaces parses a comma separated list of namespaces.
+func ParseNamespaces(s string) []string {
+	ns := make([]string, 0)
+	for _, n := range strings.Split(s, ",") {
+		if n = strings.TrimSpace(n); n != "" {
+			ns = append(ns, n)
+		}
+	}
+	return ns
+}
+
+// Allows returns true if connection secrets may be written to the supplied
+// namespace.
+func (p *NamespacePolicy) Allows(namespace string) bool {
+	if len(p.Allowed) == 0 || namespace == p.Default {
+		return true
+	}
+	for _, n := range p.Allowed {
+		if n == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// Check returns an error if connection secrets may not be written to the
+// supplied namespace.
+func (p *NamespacePolicy) Check(namespace string) error {
+	if p.Allows(namespace) {
+		return nil
+	}
+	allowed := p.Allowed
+	if p.Default != "" {
+		allowed = append([]string{p.Default}, allowed...)
+	}
+	return errors.Errorf(errNamespaceNotAllowedFmt, namespace, strings.Join(allowed, ", "))
+}
+
+// A NamespaceInitializer writes the connection secret of a managed resource
+// that does not specify a writeConnectionSecretToRef to the Default namespace
+// of its NamespacePolicy. The secret is named after the UID of the managed
+// resource, so that the secrets of managed resources of different kinds with
+// the same name do not collide.
+type NamespaceInitializer struct {
+	client client.Client
+	policy *NamespacePolicy
+}
+
+// NewNamespaceInitializer returns a new NamespaceInitializer.
+func NewNamespaceInitializer(c client.Client, p *NamespacePolicy) *NamespaceInitializer {
+	return &NamespaceInitializer{client: c, policy: p}
+}
+
+// Initialize the writeConnectionSecretToRef of the supplied managed resource.
+func (i *NamespaceInitializer) Initialize(ctx context.Context, mg resource.Managed) error {
+	if i.policy.Default == "" || mg.GetWriteConnectionSecretToReference() != nil {
+		return nil
+	}
+	mg.SetWriteConnectionSecretToReference(&xpv1.SecretReference{Namespace: i.policy.Default, Name: string(mg.GetUID())})
+	return errors.Wrap(i.client.Update(ctx, mg), errUpdateManaged)
+}
+
+// A NamespacePolicyPublisher passes ConnectionDetails on to the publishers it
+// wraps only if its NamespacePolicy allows the namespace of the connection
+// secret and every additional namespace the managed resource requests.
+type NamespacePolicyPublisher struct {
+	policy    *NamespacePolicy
+	publisher managed.ConnectionPublisher
+}
+
+// NewNamespacePolicyPublisher returns a new NamespacePolicyPublisher that
+// wraps the supplied publishers.
+func NewNamespacePolicyPublisher(np *NamespacePolicy, p ...managed.ConnectionPublisher) *NamespacePolicyPublisher {
+	return &NamespacePolicyPublisher{policy: np, publisher: managed.PublisherChain(p)}
+}
+
+// PublishConnection publishes the supplied ConnectionDetails with the wrapped
+// publishers if the NamespacePolicy allows all namespaces they would be
+// written to.
+func (p *NamespacePolicyPublisher) PublishConnection(ctx context.Context, mg resource.Managed, c managed.ConnectionDetails) error {
+	if ref := mg.GetWriteConnectionSecretToReference(); ref != nil {
+		for _, ns := range append([]string{ref.Namespace}, AdditionalNamespaces(mg)...) {
+			if err := p.policy.Check(ns); err != nil {
+				return err
+			}
+		}
+	}
+	return p.publisher.PublishConnection(ctx, mg, c)
+}
+
+// UnpublishConnection unpublishes the supplied ConnectionDetails with the
+// wrapped publishers, regardless of the NamespacePolicy, which may have
+// changed since they were published.
+func (p *NamespacePolicyPublisher) UnpublishConnection(ctx context.Context, mg resource.Managed, c managed.ConnectionDetails) error {
+	return p.publisher.UnpublishConnection(ctx, mg, c)
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package connection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+var (
+	_ managed.ConnectionPublisher = &NamespacePolicyPublisher{}
+	_ managed.Initializer         = &NamespaceInitializer{}
+)
+
+func TestNamespacePolicyCheck(t *testing.T) {
+	cases := map[string]struct {
+		policy    NamespacePolicy
+		namespace string
+		want      error
+	}{
+		"AllNamespacesAllowed": {
+			policy:    NamespacePolicy{Default: "crossplane-system"},
+			namespace: "team-a",
+		},
+		"Allowed": {
+			policy:    NamespacePolicy{Allowed: []string{"team-a", "team-b"}},
+			namespace: "team-b",
+		},
+		"DefaultAllowed": {
+			policy:    NamespacePolicy{Default: "crossplane-system", Allowed: []string{"team-a"}},
+			namespace: "crossplane-system",
+		},
+		"NotAllowed": {
+			policy:    NamespacePolicy{Default: "crossplane-system", Allowed: []string{"team-a"}},
+			namespace: "kube-system",
+			want:      errors.Errorf(errNamespaceNotAllowedFmt, "kube-system", "crossplane-system, team-a"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := tc.policy.Check(tc.namespace)
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors()); diff != "" {
+				t.Errorf("Check(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNamespaceInitializer(t *testing.T) {
+	errBoom := errors.New("boom")
+	ref := &xpv1.SecretReference{Namespace: "team-a", Name: "coolsecret"}
+
+	type want struct {
+		ref *xpv1.SecretReference
+		err error
+	}
+
+	cases := map[string]struct {
+		policy NamespacePolicy
+		c      client.Client
+		ref    *xpv1.SecretReference
+		want   want
+	}{
+		"NoDefault": {
+			policy: NamespacePolicy{},
+		},
+		"HasReference": {
+			policy: NamespacePolicy{Default: "crossplane-system"},
+			ref:    ref,
+			want:   want{ref: ref},
+		},
+		"Defaulted": {
+			policy: NamespacePolicy{Default: "crossplane-system"},
+			c:      &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+			want:   want{ref: &xpv1.SecretReference{Namespace: "crossplane-system", Name: "cool-uid"}},
+		},
+		"UpdateError": {
+			policy: NamespacePolicy{Default: "crossplane-system"},
+			c:      &test.MockClient{MockUpdate: test.NewMockUpdateFn(errBoom)},
+			want: want{
+				ref: &xpv1.SecretReference{Namespace: "crossplane-system", Name: "cool-uid"},
+				err: errors.Wrap(errBoom, errUpdateManaged),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := &fake.Managed{
+				ObjectMeta:               metav1.ObjectMeta{UID: "cool-uid"},
+				ConnectionSecretWriterTo: fake.ConnectionSecretWriterTo{Ref: tc.ref},
+			}
+			err := NewNamespaceInitializer(tc.c, &tc.policy).Initialize(context.Background(), mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Initialize(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.ref, mg.GetWriteConnectionSecretToReference()); diff != "" {
+				t.Errorf("Initialize(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNamespacePolicyPublisher(t *testing.T) {
+	errBoom := errors.New("boom")
+	policy := &NamespacePolicy{Allowed: []string{"team-a"}}
+
+	cases := map[string]struct {
+		reason string
+		mg     resource.Managed
+		want   error
+	}{
+		"ResourceDoesNotPublishSecret": {
+			reason: "A managed resource with a nil GetWriteConnectionSecretToReference should be passed on",
+			mg:     &fake.Managed{},
+			want:   errBoom,
+		},
+		"NamespaceNotAllowed": {
+			reason: "A connection secret in a namespace that is not allowed should not be published",
+			mg: &fake.Managed{ConnectionSecretWriterTo: fake.ConnectionSecretWriterTo{Ref: &xpv1.SecretReference{
+				Namespace: "kube-system",
+				Name:      "coolsecret",
+			}}},
+			want: errors.Errorf(errNamespaceNotAllowedFmt, "kube-system", "team-a"),
+		},
+		"AdditionalNamespaceNotAllowed": {
+			reason: "A connection secret should not be published if one of its additional namespaces is not allowed",
+			mg: &fake.Managed{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{AnnotationKeyAdditionalNamespaces: "team-a,kube-system"}},
+				ConnectionSecretWriterTo: fake.ConnectionSecretWriterTo{Ref: &xpv1.SecretReference{
+					Namespace: "team-a",
+					Name:      "coolsecret",
+				}},
+			},
+			want: errors.Errorf(errNamespaceNotAllowedFmt, "kube-system", "team-a"),
+		},
+		"Allowed": {
+			reason: "A connection secret in an allowed namespace should be passed on",
+			mg: &fake.Managed{ConnectionSecretWriterTo: fake.ConnectionSecretWriterTo{Ref: &xpv1.SecretReference{
+				Namespace: "team-a",
+				Name:      "coolsecret",
+			}}},
+			want: errBoom,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := NewNamespacePolicyPublisher(policy, managed.ConnectionPublisherFns{
+				PublishConnectionFn: func(_ context.Context, _ resource.Managed, _ managed.ConnectionDetails) error { return errBoom },
+			})
+			got := p.PublishConnection(context.Background(), tc.mg, managed.ConnectionDetails{})
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nPublishConnection(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package connection contains connection detail publishers that complement
+// the ones provided by crossplane-runtime.
+package connection
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// AnnotationKeyAdditionalNamespaces is the annotation a managed resource uses
+// to list, comma separated, the namespaces its connection secret should be
+// published to in addition to the one of its writeConnectionSecretToRef.
+const AnnotationKeyAdditionalNamespaces = "azure.crossplane.io/connection-secret-namespaces"
+
+// LabelKeyCopyOf is the label of the copies of a connection secret made by
+// the AdditionalNamespacesPublisher. Its value is the UID of the managed
+// resource the secret belongs to.
+const LabelKeyCopyOf = "azure.crossplane.io/connection-secret-copy-of"
+
+// Error strings.
+const (
+	errApplyAdditionalSecret  = "cannot create or update connection secret in namespace %q"
+	errListAdditionalSecrets  = "cannot list copies of connection secret"
+	errDeleteAdditionalSecret = "cannot delete connection secret in namespace %q"
+)
+
+// AdditionalNamespaces returns the namespaces the connection secret of the
+// supplied managed resource should be copied to, as listed by its
+// AnnotationKeyAdditionalNamespaces annotation.
+func AdditionalNamespaces(mg resource.Managed) []string {
+	v := mg.GetAnnotations()[AnnotationKeyAdditionalNamespaces]
+	if v == "" {
+		return nil
+	}
+	return ParseNamespaces(v)
+}
+
+// An AdditionalNamespacesPublisher publishes ConnectionDetails to a copy of
+// the connection secret in each namespace returned by AdditionalNamespaces.
+// The copies have the same name as the secret referenced by the
+// writeConnectionSecretToRef of the managed resource.
+type AdditionalNamespacesPublisher struct {
+	client client.Client
+	secret resource.Applicator
+	typer  runtime.ObjectTyper
+}
+
+// NewAdditionalNamespacesPublisher returns a new AdditionalNamespacesPublisher.
+func NewAdditionalNamespacesPublisher(c client.Client, ot runtime.ObjectTyper) *AdditionalNamespacesPublisher {
+	return &AdditionalNamespacesPublisher{client: c, secret: resource.NewAPIPatchingApplicator(c), typer: ot}
+}
+
+// PublishConnection publishes the supplied ConnectionDetails to a Secret in
+// every additional namespace requested by the supplied managed resource, and
+// deletes the copies in namespaces that are no longer requested. It is a no-op
+// if the resource does not write a connection secret at all.
+func (a *AdditionalNamespacesPublisher) PublishConnection(ctx context.Context, mg resource.Managed, c managed.ConnectionDetails) error {
+	ref := mg.GetWriteConnectionSecretToReference()
+	if ref == nil {
+		return nil
+	}
+	want := map[string]bool{}
+	for _, ns := range AdditionalNamespaces(mg) {
+		if ns == ref.Namespace {
+			continue
+		}
+		want[ns] = true
+		s := resource.ConnectionSecretFor(mg, resource.MustGetKind(mg, a.typer))
+		s.SetNamespace(ns)
+		meta.AddLabels(s, map[string]string{LabelKeyCopyOf: string(mg.GetUID())})
+		s.Data = c
+		if err := a.secret.Apply(ctx, s, resource.ConnectionSecretMustBeControllableBy(mg.GetUID())); err != nil {
+			return errors.Wrapf(err, errApplyAdditionalSecret, ns)
+		}
+	}
+	return a.prune(ctx, mg, ref, want)
+}
+
+// prune deletes the copies of the connection secret of the supplied managed
+// resource that are in none of the wanted namespaces. Only the metadata of the
+// copies is read, so that listing them does not cache secret data.
+func (a *AdditionalNamespacesPublisher) prune(ctx context.Context, mg resource.Managed, ref *xpv1.SecretReference, want map[string]bool) error {
+	l := &metav1.PartialObjectMetadataList{}
+	l.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("SecretList"))
+	if err := a.client.List(ctx, l, client.MatchingLabels{LabelKeyCopyOf: string(mg.GetUID())}); err != nil {
+		return errors.Wrap(err, errListAdditionalSecrets)
+	}
+	for i := range l.Items {
+		s := &l.Items[i]
+		if s.GetName() != ref.Name || s.GetNamespace() == ref.Namespace || want[s.GetNamespace()] || !metav1.IsControlledBy(s, mg) {
+			continue
+		}
+		s.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Secret"))
+		if err := a.client.Delete(ctx, s); resource.Ignore(kerrors.IsNotFound, err) != nil {
+			return errors.Wrapf(err, errDeleteAdditionalSecret, s.GetNamespace())
+		}
+	}
+	return nil
+}
+
+// UnpublishConnection is no-op since PublishConnection only creates secrets
+// that are controlled by the managed resource and thus will be garbage
+// collected by Kubernetes when it is deleted.
+func (a *AdditionalNamespacesPublisher) UnpublishConnection(_ context.Context, _ resource.Managed, _ managed.ConnectionDetails) error {
+	return nil
+}
@@ -0,0 +1,235 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package connection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+var _ managed.ConnectionPublisher = &AdditionalNamespacesPublisher{}
+
+func TestAdditionalNamespaces(t *testing.T) {
+	cases := map[string]struct {
+		annotations map[string]string
+		want        []string
+	}{
+		"NoAnnotation": {},
+		"SingleNamespace": {
+			annotations: map[string]string{AnnotationKeyAdditionalNamespaces: "team-a"},
+			want:        []string{"team-a"},
+		},
+		"MultipleNamespaces": {
+			annotations: map[string]string{AnnotationKeyAdditionalNamespaces: " team-a, team-b,,"},
+			want:        []string{"team-a", "team-b"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := &fake.Managed{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+			got := AdditionalNamespaces(mg)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("AdditionalNamespaces(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAdditionalNamespacesPublisher(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	mg := &fake.Managed{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:         "cool-uid",
+			Annotations: map[string]string{AnnotationKeyAdditionalNamespaces: "coolnamespace,othernamespace"},
+		},
+		ConnectionSecretWriterTo: fake.ConnectionSecretWriterTo{Ref: &xpv1.SecretReference{
+			Namespace: "coolnamespace",
+			Name:      "coolsecret",
+		}},
+	}
+
+	cd := managed.ConnectionDetails{"cool": {42}}
+
+	// secretCopy returns the metadata of a copy of the connection secret of mg in
+	// the supplied namespace, controlled by the supplied owner.
+	secretCopy := func(ns string, owner resource.Managed) metav1.PartialObjectMetadata {
+		s := resource.ConnectionSecretFor(owner, fake.GVK(owner))
+		return metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{
+			Namespace:       ns,
+			Name:            s.GetName(),
+			OwnerReferences: s.GetOwnerReferences(),
+		}}
+	}
+	list := func(ns ...string) test.MockListFn {
+		return func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+			l := obj.(*metav1.PartialObjectMetadataList)
+			for _, n := range ns {
+				l.Items = append(l.Items, secretCopy(n, mg))
+			}
+			return nil
+		}
+	}
+
+	type fields struct {
+		client client.Client
+		secret resource.Applicator
+		typer  runtime.ObjectTyper
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+		c   managed.ConnectionDetails
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   error
+	}{
+		"ResourceDoesNotPublishSecret": {
+			reason: "A managed resource with a nil GetWriteConnectionSecretToReference should not publish any secret",
+			args: args{
+				ctx: context.Background(),
+				mg:  &fake.Managed{ObjectMeta: metav1.ObjectMeta{Annotations: mg.GetAnnotations()}},
+			},
+		},
+		"ApplyError": {
+			reason: "An error applying a connection secret should be returned",
+			fields: fields{
+				secret: resource.ApplyFn(func(_ context.Context, _ client.Object, _ ...resource.ApplyOption) error { return errBoom }),
+				typer:  fake.SchemeWith(&fake.Managed{}),
+			},
+			args: args{
+				ctx: context.Background(),
+				mg:  mg,
+			},
+			want: errors.Wrapf(errBoom, errApplyAdditionalSecret, "othernamespace"),
+		},
+		"ListError": {
+			reason: "An error listing the copies of a connection secret should be returned",
+			fields: fields{
+				client: &test.MockClient{MockList: test.NewMockListFn(errBoom)},
+				secret: resource.ApplyFn(func(_ context.Context, _ client.Object, _ ...resource.ApplyOption) error { return nil }),
+				typer:  fake.SchemeWith(&fake.Managed{}),
+			},
+			args: args{
+				ctx: context.Background(),
+				mg:  mg,
+			},
+			want: errors.Wrap(errBoom, errListAdditionalSecrets),
+		},
+		"DeleteError": {
+			reason: "An error deleting a copy of a connection secret should be returned",
+			fields: fields{
+				client: &test.MockClient{
+					MockList:   list("stalenamespace"),
+					MockDelete: test.NewMockDeleteFn(errBoom),
+				},
+				secret: resource.ApplyFn(func(_ context.Context, _ client.Object, _ ...resource.ApplyOption) error { return nil }),
+				typer:  fake.SchemeWith(&fake.Managed{}),
+			},
+			args: args{
+				ctx: context.Background(),
+				mg:  mg,
+			},
+			want: errors.Wrapf(errBoom, errDeleteAdditionalSecret, "stalenamespace"),
+		},
+		"PruneStaleCopies": {
+			reason: "Copies in namespaces that are no longer listed should be deleted, unless they are controlled by another resource",
+			fields: fields{
+				client: &test.MockClient{
+					MockList: func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+						other := &fake.Managed{
+							ObjectMeta: metav1.ObjectMeta{UID: "other-uid"},
+							ConnectionSecretWriterTo: fake.ConnectionSecretWriterTo{Ref: &xpv1.SecretReference{
+								Namespace: "foreignnamespace",
+								Name:      "coolsecret",
+							}},
+						}
+						obj.(*metav1.PartialObjectMetadataList).Items = []metav1.PartialObjectMetadata{
+							secretCopy("coolnamespace", mg),
+							secretCopy("othernamespace", mg),
+							secretCopy("stalenamespace", mg),
+							secretCopy("foreignnamespace", other),
+						}
+						return nil
+					},
+					MockDelete: func(_ context.Context, obj client.Object, _ ...client.DeleteOption) error {
+						if obj.GetNamespace() != "stalenamespace" {
+							t.Errorf("Delete(...): unexpected delete of copy in namespace %q", obj.GetNamespace())
+						}
+						return nil
+					},
+				},
+				secret: resource.ApplyFn(func(_ context.Context, _ client.Object, _ ...resource.ApplyOption) error { return nil }),
+				typer:  fake.SchemeWith(&fake.Managed{}),
+			},
+			args: args{
+				ctx: context.Background(),
+				mg:  mg,
+			},
+		},
+		"Success": {
+			reason: "Only the namespaces other than the one of writeConnectionSecretToRef should get a copy of the secret",
+			fields: fields{
+				client: &test.MockClient{MockList: list()},
+				secret: resource.ApplyFn(func(_ context.Context, o client.Object, _ ...resource.ApplyOption) error {
+					want := resource.ConnectionSecretFor(mg, fake.GVK(mg))
+					want.SetNamespace("othernamespace")
+					want.SetLabels(map[string]string{LabelKeyCopyOf: "cool-uid"})
+					want.Data = cd
+					if diff := cmp.Diff(want, o); diff != "" {
+						t.Errorf("-want, +got:\n%s", diff)
+					}
+					return nil
+				}),
+				typer: fake.SchemeWith(&fake.Managed{}),
+			},
+			args: args{
+				ctx: context.Background(),
+				mg:  mg,
+				c:   cd,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			a := &AdditionalNamespacesPublisher{client: tc.fields.client, secret: tc.fields.secret, typer: tc.fields.typer}
+			got := a.PublishConnection(tc.args.ctx, tc.args.mg, tc.args.c)
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nPublishConnection(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package connection
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/url"
+	"text/template"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// AnnotationKeyTemplate is the annotation a managed resource uses to declare,
+// as a JSON object, additional connection secret keys and the Go templates
+// their values are rendered from. The templates are executed with the other
+// connection details as data, e.g.
+// {"REDIS_URL": "rediss://:{{ .password | pathEscape }}@{{ .endpoint }}:{{ .port }}"}.
+const AnnotationKeyTemplate = "azure.crossplane.io/connection-secret-template"
+
+// AnnotationKeyKeys is the annotation a managed resource uses to declare, as a
+// JSON object, the keys its connection details are published under instead of
+// their own, e.g. {"endpoint": "host"}. A detail mapped to the empty string is
+// not published.
+const AnnotationKeyKeys = "azure.crossplane.io/connection-secret-keys"
+
+// Error strings.
+const (
+	errParseKeys       = "cannot parse connection secret keys"
+	errParseTemplates  = "cannot parse connection secret templates"
+	errParseTemplate   = "cannot parse connection secret template of key %q"
+	errExecuteTemplate = "cannot execute connection secret template of key %q"
+)
+
+// TemplateFuncs are the functions available to connection secret templates in
+// addition to the text/template builtins.
+var TemplateFuncs = template.FuncMap{
+	"pathEscape":  url.PathEscape,
+	"queryEscape": url.QueryEscape,
+}
+
+// Render returns the supplied ConnectionDetails renamed as declared by the
+// AnnotationKeyKeys annotation of the supplied managed resource, with the keys
+// declared by its AnnotationKeyTemplate annotation added. Templates refer to
+// the connection details by their original names, and a declared key replaces
+// a connection detail of the same name. Templates that refer to a connection
+// detail that does not exist fail to render.
+func Render(mg resource.Managed, c managed.ConnectionDetails) (managed.ConnectionDetails, error) {
+	keys := map[string]string{}
+	if v := mg.GetAnnotations()[AnnotationKeyKeys]; v != "" {
+		if err := json.Unmarshal([]byte(v), &keys); err != nil {
+			return nil, errors.Wrap(err, errParseKeys)
+		}
+	}
+	tmpls := map[string]string{}
+	if v := mg.GetAnnotations()[AnnotationKeyTemplate]; v != "" {
+		if err := json.Unmarshal([]byte(v), &tmpls); err != nil {
+			return nil, errors.Wrap(err, errParseTemplates)
+		}
+	}
+	if len(keys) == 0 && len(tmpls) == 0 {
+		return c, nil
+	}
+
+	data := make(map[string]string, len(c))
+	out := make(managed.ConnectionDetails, len(c)+len(tmpls))
+	for k, v := range c {
+		data[k] = string(v)
+		nk, ok := keys[k]
+		if !ok {
+			nk = k
+		}
+		if nk != "" {
+			out[nk] = v
+		}
+	}
+	for k, tmpl := range tmpls {
+		t, err := template.New(k).Funcs(TemplateFuncs).Option("missingkey=error").Parse(tmpl)
+		if err != nil {
+			return nil, errors.Wrapf(err, errParseTemplate, k)
+		}
+		b := &bytes.Buffer{}
+		if err := t.Execute(b, data); err != nil {
+			return nil, errors.Wrapf(err, errExecuteTemplate, k)
+		}
+		out[k] = b.Bytes()
+	}
+	return out, nil
+}
+
+// A TemplatingPublisher renames and adds the connection secret keys declared by
+// the AnnotationKeyKeys and AnnotationKeyTemplate annotations of a managed
+// resource to its ConnectionDetails before passing them on to the publishers
+// it wraps.
+type TemplatingPublisher struct {
+	publisher managed.ConnectionPublisher
+}
+
+// NewTemplatingPublisher returns a new TemplatingPublisher that wraps the
+// supplied publishers.
+func NewTemplatingPublisher(p ...managed.ConnectionPublisher) *TemplatingPublisher {
+	return &TemplatingPublisher{publisher: managed.PublisherChain(p)}
+}
+
+// PublishConnection renders the connection secret templates of the supplied
+// managed resource and publishes the result with the wrapped publishers.
+func (t *TemplatingPublisher) PublishConnection(ctx context.Context, mg resource.Managed, c managed.ConnectionDetails) error {
+	r, err := Render(mg, c)
+	if err != nil {
+		return err
+	}
+	return t.publisher.PublishConnection(ctx, mg, r)
+}
+
+// UnpublishConnection unpublishes the supplied ConnectionDetails with the
+// wrapped publishers.
+func (t *TemplatingPublisher) UnpublishConnection(ctx context.Context, mg resource.Managed, c managed.ConnectionDetails) error {
+	return t.publisher.UnpublishConnection(ctx, mg, c)
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package connection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+)
+
+var _ managed.ConnectionPublisher = &TemplatingPublisher{}
+
+func TestRender(t *testing.T) {
+	cd := managed.ConnectionDetails{
+		"endpoint": []byte("cool.redis.cache.windows.net"),
+		"port":     []byte("6380"),
+		"password": []byte("s3cr/t"),
+	}
+
+	cases := map[string]struct {
+		reason   string
+		keys     string
+		template string
+		want     managed.ConnectionDetails
+		wantErr  bool
+	}{
+		"NoTemplate": {
+			reason: "Connection details should be unchanged if no template is declared",
+			want:   cd,
+		},
+		"Template": {
+			reason:   "Declared keys should be added to the connection details",
+			template: `{"REDIS_URL": "rediss://:{{ .password | pathEscape }}@{{ .endpoint }}:{{ .port }}"}`,
+			want: managed.ConnectionDetails{
+				"endpoint":  []byte("cool.redis.cache.windows.net"),
+				"port":      []byte("6380"),
+				"password":  []byte("s3cr/t"),
+				"REDIS_URL": []byte("rediss://:s3cr%2Ft@cool.redis.cache.windows.net:6380"),
+			},
+		},
+		"Keys": {
+			reason: "Connection details should be published under their declared keys, and omitted if declared with an empty key",
+			keys:   `{"endpoint": "host", "password": ""}`,
+			want: managed.ConnectionDetails{
+				"host": []byte("cool.redis.cache.windows.net"),
+				"port": []byte("6380"),
+			},
+		},
+		"KeysAndTemplate": {
+			reason:   "Templates should refer to connection details by their original keys",
+			keys:     `{"endpoint": "host"}`,
+			template: `{"connectionString": "{{ .endpoint }}:{{ .port }}"}`,
+			want: managed.ConnectionDetails{
+				"host":             []byte("cool.redis.cache.windows.net"),
+				"port":             []byte("6380"),
+				"password":         []byte("s3cr/t"),
+				"connectionString": []byte("cool.redis.cache.windows.net:6380"),
+			},
+		},
+		"InvalidKeys": {
+			reason:  "A keys annotation that is not a JSON object should fail to render",
+			keys:    `host`,
+			wantErr: true,
+		},
+		"InvalidJSON": {
+			reason:   "An annotation that is not a JSON object should fail to render",
+			template: `REDIS_URL`,
+			wantErr:  true,
+		},
+		"MissingKey": {
+			reason:   "A template referring to a connection detail that does not exist should fail to render",
+			template: `{"URL": "{{ .host }}"}`,
+			wantErr:  true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := &fake.Managed{}
+			mg.SetAnnotations(map[string]string{AnnotationKeyKeys: tc.keys, AnnotationKeyTemplate: tc.template})
+			got, err := Render(mg, cd)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("\n%s\nRender(...): want error %t, got %v", tc.reason, tc.wantErr, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nRender(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestTemplatingPublisher(t *testing.T) {
+	mg := &fake.Managed{ObjectMeta: metav1.ObjectMeta{
+		Annotations: map[string]string{AnnotationKeyTemplate: `{"URL": "https://{{ .endpoint }}"}`},
+	}}
+	want := managed.ConnectionDetails{"endpoint": []byte("example.org"), "URL": []byte("https://example.org")}
+
+	var got managed.ConnectionDetails
+	p := NewTemplatingPublisher(managed.ConnectionPublisherFns{
+		PublishConnectionFn: func(_ context.Context, _ resource.Managed, c managed.ConnectionDetails) error {
+			got = c
+			return nil
+		},
+	})
+	if err := p.PublishConnection(context.Background(), mg, managed.ConnectionDetails{"endpoint": []byte("example.org")}); err != nil {
+		t.Fatalf("PublishConnection(...): %s", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("PublishConnection(...): -want, +got:\n%s", diff)
+	}
+}
@@ -17,13 +17,22 @@ limitations under the License.
 package controller
 
 import (
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 
+	"github.com/crossplane/provider-azure/apis"
 	"github.com/crossplane/provider-azure/pkg/controller/cache"
+	"github.com/crossplane/provider-azure/pkg/controller/cognitiveservices/openaideployment"
 	"github.com/crossplane/provider-azure/pkg/controller/compute"
+	"github.com/crossplane/provider-azure/pkg/controller/compute/capacityreservation"
+	"github.com/crossplane/provider-azure/pkg/controller/compute/capacityreservationgroup"
+	"github.com/crossplane/provider-azure/pkg/controller/compute/dedicatedhost"
+	"github.com/crossplane/provider-azure/pkg/controller/compute/dedicatedhostgroup"
+	"github.com/crossplane/provider-azure/pkg/controller/compute/imagetemplate"
 	"github.com/crossplane/provider-azure/pkg/controller/config"
 	"github.com/crossplane/provider-azure/pkg/controller/database/cosmosdb"
 	"github.com/crossplane/provider-azure/pkg/controller/database/mysqlserver"
@@ -32,19 +41,59 @@ import (
 	"github.com/crossplane/provider-azure/pkg/controller/database/postgresqlserver"
 	"github.com/crossplane/provider-azure/pkg/controller/database/postgresqlserverfirewallrule"
 	"github.com/crossplane/provider-azure/pkg/controller/database/postgresqlservervirtualnetworkrule"
+	"github.com/crossplane/provider-azure/pkg/controller/eventhub/schemaregistrygroup"
+	"github.com/crossplane/provider-azure/pkg/controller/kubernetes/connectedcluster"
+	kustocluster "github.com/crossplane/provider-azure/pkg/controller/kusto/cluster"
+	kustodatabase "github.com/crossplane/provider-azure/pkg/controller/kusto/database"
+	kustoscript "github.com/crossplane/provider-azure/pkg/controller/kusto/script"
+	"github.com/crossplane/provider-azure/pkg/controller/monitor/datacollectionendpoint"
+	"github.com/crossplane/provider-azure/pkg/controller/monitor/datacollectionrule"
+	"github.com/crossplane/provider-azure/pkg/controller/monitor/datacollectionruleassociation"
+	"github.com/crossplane/provider-azure/pkg/controller/monitor/privatelinkscope"
+	"github.com/crossplane/provider-azure/pkg/controller/monitor/privatelinkscopedresource"
+	"github.com/crossplane/provider-azure/pkg/controller/monitor/workspace"
 	"github.com/crossplane/provider-azure/pkg/controller/network/subnet"
 	"github.com/crossplane/provider-azure/pkg/controller/network/virtualnetwork"
 	"github.com/crossplane/provider-azure/pkg/controller/resourcegroup"
+	"github.com/crossplane/provider-azure/pkg/controller/resourceobservation"
+	"github.com/crossplane/provider-azure/pkg/controller/skucatalog"
 	"github.com/crossplane/provider-azure/pkg/controller/storage/account"
 	"github.com/crossplane/provider-azure/pkg/controller/storage/container"
+	"github.com/crossplane/provider-azure/pkg/controller/storagesync/cloudendpoint"
+	"github.com/crossplane/provider-azure/pkg/controller/storagesync/storagesyncservice"
+	"github.com/crossplane/provider-azure/pkg/controller/storagesync/syncgroup"
+	"github.com/crossplane/provider-azure/pkg/defaults"
+	"github.com/crossplane/provider-azure/pkg/ignored"
+	"github.com/crossplane/provider-azure/pkg/immutable"
+	"github.com/crossplane/provider-azure/pkg/replacement"
+	"github.com/crossplane/provider-azure/pkg/supported"
+	"github.com/crossplane/provider-azure/pkg/zones"
 )
 
-// Setup Azure controllers.
-func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
+// Setup Azure controllers. Only managed resources that match the supplied
+// label selector are reconciled.
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, sel labels.Selector) error {
 	for _, setup := range []func(ctrl.Manager, logging.Logger, workqueue.RateLimiter) error{
 		config.Setup,
+		config.SetupQuota,
+		config.SetupCredentials,
+		config.SetupProvider,
+		skucatalog.Setup,
+		resourceobservation.Setup,
+	} {
+		if err := setup(mgr, l, rl); err != nil {
+			return err
+		}
+	}
+	for _, setup := range []func(ctrl.Manager, logging.Logger, workqueue.RateLimiter, labels.Selector) error{
 		cache.SetupRedis,
+		openaideployment.Setup,
 		compute.SetupAKSCluster,
+		dedicatedhostgroup.Setup,
+		dedicatedhost.Setup,
+		capacityreservationgroup.Setup,
+		capacityreservation.Setup,
+		imagetemplate.Setup,
 		mysqlserver.Setup,
 		mysqlserverfirewallrule.Setup,
 		mysqlservervirtualnetworkrule.Setup,
@@ -52,13 +101,53 @@ func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
 		postgresqlserverfirewallrule.Setup,
 		postgresqlservervirtualnetworkrule.Setup,
 		cosmosdb.Setup,
+		schemaregistrygroup.Setup,
+		connectedcluster.Setup,
+		kustocluster.Setup,
+		kustodatabase.Setup,
+		kustoscript.Setup,
+		privatelinkscope.Setup,
+		privatelinkscopedresource.Setup,
+		workspace.Setup,
+		datacollectionendpoint.Setup,
+		datacollectionrule.Setup,
+		datacollectionruleassociation.Setup,
 		virtualnetwork.Setup,
 		subnet.Setup,
 		resourcegroup.Setup,
 		account.Setup,
 		container.Setup,
+		storagesyncservice.Setup,
+		syncgroup.Setup,
+		cloudendpoint.Setup,
 	} {
-		if err := setup(mgr, l, rl); err != nil {
+		if err := setup(mgr, l, rl, sel); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetupWebhooks registers a conversion webhook for every conversion hub, i.e.
+// the version of every Azure kind that is served at more than one version
+// which the other versions convert to and from. It also registers
+// the webhook that applies the defaults of their ProviderConfig to managed
+// resources when they are created, the webhook that rejects changes to their
+// immutable fields, the webhook that rejects availability zones their
+// location does not support, and the webhook that warns about their fields
+// that are ignored.
+func SetupWebhooks(mgr ctrl.Manager) error {
+	mgr.GetWebhookServer().Register(defaults.WebhookPath, &webhook.Admission{Handler: defaults.NewDefaulter(mgr.GetClient(), mgr.GetScheme())})
+	mgr.GetWebhookServer().Register(immutable.WebhookPath, &webhook.Admission{Handler: immutable.NewValidator(mgr.GetScheme(), immutable.WithReplaceable(replacement.Replaceable))})
+	mgr.GetWebhookServer().Register(ignored.WebhookPath, &webhook.Admission{Handler: ignored.NewWarner(mgr.GetScheme())})
+	mgr.GetWebhookServer().Register(supported.WebhookPath, &webhook.Admission{Handler: supported.NewValidator(mgr.GetClient())})
+	mgr.GetWebhookServer().Register(zones.WebhookPath, &webhook.Admission{Handler: zones.NewValidator()})
+	hubs, err := apis.Hubs(mgr.GetScheme())
+	if err != nil {
+		return err
+	}
+	for _, h := range hubs {
+		if err := ctrl.NewWebhookManagedBy(mgr).For(h).Complete(); err != nil {
 			return err
 		}
 	}
@@ -0,0 +1,181 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package backup contains a controller that periodically snapshots the managed
+// resources of this provider to an Azure Blob Storage container, as a record
+// of them that does not depend on backups of the API server.
+package backup
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-azure/apis"
+	"github.com/crossplane/provider-azure/pkg/clients/storage"
+)
+
+const (
+	snapshotTimeout = 5 * time.Minute
+	contentType     = "application/yaml"
+
+	// blobNameFormat is the time format of the names of snapshot blobs.
+	blobNameFormat = "20060102T150405Z.yaml"
+)
+
+// Error strings.
+const (
+	errGetSecret   = "cannot get storage account connection secret"
+	errNewUploader = "cannot create blob container client"
+	errList        = "cannot list %s resources"
+	errMarshal     = "cannot marshal %s %q"
+	errUpload      = "cannot upload snapshot"
+)
+
+// Options configure where and how often snapshots are written.
+type Options struct {
+	// SecretRef is the connection secret of the storage Account that holds
+	// the snapshot container.
+	SecretRef types.NamespacedName
+
+	// Container the snapshots are written to.
+	Container string
+
+	// Interval between snapshots.
+	Interval time.Duration
+}
+
+// An Uploader uploads blobs to a container.
+type Uploader interface {
+	Upload(ctx context.Context, blob, contentType string, data []byte) error
+}
+
+// A NewUploaderFn returns an Uploader for the supplied container of the
+// supplied storage account.
+type NewUploaderFn func(account, key, container string) (Uploader, error)
+
+// Setup adds a Snapshotter to the supplied manager. Like controllers, it only
+// runs while the manager is the leader.
+func Setup(mgr ctrl.Manager, l logging.Logger, o Options) error {
+	return mgr.Add(NewSnapshotter(mgr.GetClient(), mgr.GetScheme(), l.WithValues("controller", "backup"), o))
+}
+
+// A Snapshotter writes the YAML of every managed resource, including its
+// status, to a blob container. Connection details are not included; they are
+// only ever written to connection secrets.
+type Snapshotter struct {
+	kube        client.Client
+	scheme      *runtime.Scheme
+	opts        Options
+	log         logging.Logger
+	newUploader NewUploaderFn
+	now         func() time.Time
+}
+
+// NewSnapshotter returns a new Snapshotter.
+func NewSnapshotter(c client.Client, s *runtime.Scheme, l logging.Logger, o Options) *Snapshotter {
+	return &Snapshotter{
+		kube:   c,
+		scheme: s,
+		opts:   o,
+		log:    l,
+		newUploader: func(account, key, container string) (Uploader, error) {
+			return storage.NewContainerHandle(account, key, container)
+		},
+		now: time.Now,
+	}
+}
+
+// Start writes a snapshot immediately and then at every interval, until the
+// supplied context is done.
+func (s *Snapshotter) Start(ctx context.Context) error {
+	t := time.NewTicker(s.opts.Interval)
+	defer t.Stop()
+	for {
+		if err := s.Snapshot(ctx); err != nil {
+			s.log.Info("Cannot snapshot managed resources", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-t.C:
+		}
+	}
+}
+
+// Snapshot writes the YAML of every managed resource to a new blob named
+// after the current time.
+func (s *Snapshotter) Snapshot(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, snapshotTimeout)
+	defer cancel()
+
+	data, err := s.marshal(ctx)
+	if err != nil {
+		return err
+	}
+
+	sec := &corev1.Secret{}
+	if err := s.kube.Get(ctx, s.opts.SecretRef, sec); err != nil {
+		return errors.Wrap(err, errGetSecret)
+	}
+	u, err := s.newUploader(string(sec.Data[xpv1.ResourceCredentialsSecretUserKey]), string(sec.Data[xpv1.ResourceCredentialsSecretPasswordKey]), s.opts.Container)
+	if err != nil {
+		return errors.Wrap(err, errNewUploader)
+	}
+	name := s.now().UTC().Format(blobNameFormat)
+	if err := u.Upload(ctx, name, contentType, data); err != nil {
+		return errors.Wrap(err, errUpload)
+	}
+	s.log.Debug("Wrote snapshot of managed resources", "blob", name, "bytes", len(data))
+	return nil
+}
+
+// marshal returns a multi-document YAML stream of every managed resource.
+func (s *Snapshotter) marshal(ctx context.Context) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	for _, l := range apis.ManagedLists(s.scheme) {
+		gvk := resource.MustGetKind(l, s.scheme)
+		gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+		if err := s.kube.List(ctx, l); err != nil {
+			return nil, errors.Wrapf(err, errList, gvk.Kind)
+		}
+		for _, mg := range l.GetItems() {
+			// Managed fields are noise in a record of the desired state.
+			mg.SetManagedFields(nil)
+			mg.GetObjectKind().SetGroupVersionKind(gvk)
+			b, err := yaml.Marshal(mg)
+			if err != nil {
+				return nil, errors.Wrapf(err, errMarshal, gvk.Kind, mg.GetName())
+			}
+			buf.WriteString("---\n")
+			buf.Write(b)
+		}
+	}
+	return buf.Bytes(), nil
+}
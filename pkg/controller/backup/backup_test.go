@@ -0,0 +1,150 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/cache/v1beta1"
+)
+
+type uploadFn func(ctx context.Context, blob, contentType string, data []byte) error
+
+func (fn uploadFn) Upload(ctx context.Context, blob, contentType string, data []byte) error {
+	return fn(ctx, blob, contentType, data)
+}
+
+func TestSnapshot(t *testing.T) {
+	errBoom := errors.New("boom")
+	now := time.Date(2021, time.March, 4, 5, 6, 7, 0, time.UTC)
+
+	s := runtime.NewScheme()
+	_ = v1beta1.SchemeBuilder.AddToScheme(s)
+
+	stored := v1beta1.Redis{ObjectMeta: metav1.ObjectMeta{
+		Name:          "cool-redis",
+		ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "crossplane"}},
+	}}
+	want := v1beta1.Redis{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1beta1.SchemeGroupVersion.String(), Kind: v1beta1.RedisKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "cool-redis"},
+	}
+	doc, _ := yaml.Marshal(&want)
+
+	list := test.NewMockListFn(nil, func(o client.ObjectList) error {
+		if l, ok := o.(*v1beta1.RedisList); ok {
+			l.Items = []v1beta1.Redis{stored}
+		}
+		return nil
+	})
+	get := test.NewMockGetFn(nil, func(o client.Object) error {
+		o.(*corev1.Secret).Data = map[string][]byte{
+			xpv1.ResourceCredentialsSecretUserKey:     []byte("coolaccount"),
+			xpv1.ResourceCredentialsSecretPasswordKey: []byte("coolkey"),
+		}
+		return nil
+	})
+
+	type fields struct {
+		kube   client.Client
+		upload uploadFn
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		want   error
+	}{
+		"ListError": {
+			reason: "Errors listing managed resources should be returned",
+			fields: fields{
+				kube: &test.MockClient{MockList: test.NewMockListFn(errBoom)},
+			},
+			want: errors.Wrapf(errBoom, errList, v1beta1.RedisKind),
+		},
+		"GetSecretError": {
+			reason: "Errors getting the storage account connection secret should be returned",
+			fields: fields{
+				kube: &test.MockClient{MockList: list, MockGet: test.NewMockGetFn(errBoom)},
+			},
+			want: errors.Wrap(errBoom, errGetSecret),
+		},
+		"UploadError": {
+			reason: "Errors uploading the snapshot should be returned",
+			fields: fields{
+				kube: &test.MockClient{MockList: list, MockGet: get},
+				upload: func(_ context.Context, _, _ string, _ []byte) error {
+					return errBoom
+				},
+			},
+			want: errors.Wrap(errBoom, errUpload),
+		},
+		"Success": {
+			reason: "Every managed resource should be uploaded, without managed fields, to a blob named after the current time",
+			fields: fields{
+				kube: &test.MockClient{MockList: list, MockGet: get},
+				upload: func(_ context.Context, blob, ct string, data []byte) error {
+					if diff := cmp.Diff("20210304T050607Z.yaml", blob); diff != "" {
+						t.Errorf("blob: -want, +got:\n%s", diff)
+					}
+					if diff := cmp.Diff(contentType, ct); diff != "" {
+						t.Errorf("contentType: -want, +got:\n%s", diff)
+					}
+					if diff := cmp.Diff("---\n"+string(doc), string(data)); diff != "" {
+						t.Errorf("data: -want, +got:\n%s", diff)
+					}
+					return nil
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			sn := NewSnapshotter(tc.fields.kube, s, logging.NewNopLogger(), Options{
+				SecretRef: types.NamespacedName{Namespace: "crossplane-system", Name: "backup"},
+				Container: "snapshots",
+			})
+			sn.now = func() time.Time { return now }
+			sn.newUploader = func(account, key, container string) (Uploader, error) {
+				if account != "coolaccount" || key != "coolkey" || container != "snapshots" {
+					t.Errorf("newUploader(%q, %q, %q): unexpected arguments", account, key, container)
+				}
+				return tc.fields.upload, nil
+			}
+			err := sn.Snapshot(context.Background())
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nSnapshot(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
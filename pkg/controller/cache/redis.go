@@ -18,15 +18,22 @@ package cache
 
 import (
 	"context"
+	"net/http"
 	"strconv"
 
 	"github.com/Azure/azure-sdk-for-go/profiles/latest/redis/mgmt/redis"
 	"github.com/Azure/azure-sdk-for-go/profiles/latest/redis/mgmt/redis/redisapi"
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
@@ -37,8 +44,22 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/crossplane/provider-azure/apis/cache/v1beta1"
+	azurev1alpha3 "github.com/crossplane/provider-azure/apis/v1alpha3"
+	apisv1beta1 "github.com/crossplane/provider-azure/apis/v1beta1"
+	"github.com/crossplane/provider-azure/pkg/approval"
+	"github.com/crossplane/provider-azure/pkg/chain"
 	azure "github.com/crossplane/provider-azure/pkg/clients"
 	redisclients "github.com/crossplane/provider-azure/pkg/clients/redis"
+	"github.com/crossplane/provider-azure/pkg/concurrency"
+	"github.com/crossplane/provider-azure/pkg/connection"
+	"github.com/crossplane/provider-azure/pkg/credentials"
+	"github.com/crossplane/provider-azure/pkg/defaults"
+	"github.com/crossplane/provider-azure/pkg/dependency"
+	"github.com/crossplane/provider-azure/pkg/externalname"
+	"github.com/crossplane/provider-azure/pkg/location"
+	"github.com/crossplane/provider-azure/pkg/probe"
+	"github.com/crossplane/provider-azure/pkg/replacement"
+	"github.com/crossplane/provider-azure/pkg/tenancy"
 )
 
 const (
@@ -51,43 +72,71 @@ const (
 	errCreateFailed         = "cannot create the Redis instance"
 	errUpdateFailed         = "cannot update the Redis instance"
 	errDeleteFailed         = "cannot delete the Redis instance"
+	errFetchImportFailed    = "cannot fetch the status of the data import"
+	errGetImportFiles       = "cannot get the secret that lists the files to import"
+	errImportFailed         = "cannot import data into the Redis instance"
 )
 
 // SetupRedis adds a controller that reconciles Redis resources.
-func SetupRedis(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
+func SetupRedis(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, sel labels.Selector) error {
 	name := managed.ControllerName(v1beta1.RedisGroupKind)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	gate := approval.NewProviderConfigGate(mgr.GetClient(), mgr.GetScheme())
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(controller.Options{
-			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: concurrency.DefaultLimits.Of(v1beta1.RedisGroupKind),
 		}).
 		For(&v1beta1.Redis{}).
-		Complete(managed.NewReconciler(mgr,
-			resource.ManagedKind(v1beta1.RedisGroupVersionKind),
-			managed.WithExternalConnecter(&connector{kube: mgr.GetClient()}),
-			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
-			managed.WithLogger(l.WithValues("controller", name)),
-			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+		Watches(&source.Kind{Type: &corev1.Secret{}}, credentials.EnqueueRequestsForRotation(mgr.GetClient(), &v1beta1.RedisList{}, l.WithValues("controller", name)), builder.OnlyMetadata).
+		Watches(&source.Kind{Type: &azurev1alpha3.ResourceGroup{}}, dependency.EnqueueRequestsForDependents(mgr.GetClient(), &v1beta1.RedisList{}, l.WithValues("controller", name)), builder.WithPredicates(dependency.BecameAvailable())).
+		Complete(chain.NewReconciler(mgr, resource.ManagedKind(v1beta1.RedisGroupVersionKind),
+			managed.NewReconciler(mgr,
+				resource.ManagedKind(v1beta1.RedisGroupVersionKind),
+				managed.WithConnectionPublishers(connection.NewNamespacePolicyPublisher(connection.DefaultNamespacePolicy, connection.NewTemplatingPublisher(connection.NewKeyVaultPublisher(mgr.GetClient(), mgr.GetScheme(),
+					managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme()),
+					connection.NewAdditionalNamespacesPublisher(mgr.GetClient(), mgr.GetScheme()))))),
+				managed.WithExternalConnecter(chain.NewConnecter(mgr, v1beta1.RedisGroupKind, &connector{kube: mgr.GetClient(), gate: gate}, chain.WithReplacement())),
+				managed.WithInitializers(managed.NewNameAsExternalName(mgr.GetClient()), externalname.NewInitializer(mgr.GetClient()), tenancy.NewDefaultProviderInitializer(mgr.GetClient()), location.NewInitializer(mgr.GetClient()), defaults.NewInitializer(mgr.GetClient()), replacement.NewInitializer(mgr.GetClient()), connection.NewNamespaceInitializer(mgr.GetClient(), connection.DefaultNamespacePolicy)),
+				managed.WithReferenceResolver(dependency.NewReferenceResolver(mgr.GetClient())),
+				managed.WithLogger(l.WithValues("controller", name)),
+				managed.WithRecorder(recorder)),
+			sel))
 }
 
 type connector struct {
 	kube client.Client
+	gate approval.Gate
 }
 
 func (c connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1beta1.Redis)
+	if !ok {
+		return nil, errors.New(errNotRedis)
+	}
 	creds, auth, err := azure.GetAuthInfo(ctx, c.kube, mg)
 	if err != nil {
 		return nil, errors.Wrap(err, errConnectFailed)
 	}
-	cl := redis.NewClient(creds[azure.CredentialsKeySubscriptionID])
+	cl := redis.NewClientWithBaseURI(azure.BaseURI(creds), azure.SubscriptionID(creds, cr.Spec.ForProvider.SubscriptionID))
 	cl.Authorizer = auth
-	return &external{kube: c.kube, client: cl}, nil
+	cl.RequestInspector = azure.WithClientRequestIDFromContext()
+	tp, err := azure.TagPolicyOf(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &external{kube: c.kube, client: cl, sender: cl.Client, probe: probe.TLS, gate: c.gate, tagPolicy: tp}, nil
 }
 
 type external struct {
-	kube   client.Client
-	client redisapi.ClientAPI
+	kube      client.Client
+	client    redisapi.ClientAPI
+	sender    autorest.Sender
+	probe     probe.Fn
+	gate      approval.Gate
+	tagPolicy apisv1beta1.TagPolicy
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -100,11 +149,16 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{ResourceExists: false}, errors.Wrap(resource.Ignore(azure.IsNotFound, err), errGetFailed)
 	}
 
-	redisclients.LateInitialize(&cr.Spec.ForProvider, cache)
+	redisclients.LateInitialize(&cr.Spec.ForProvider, cache, c.tagPolicy)
 	if err := c.kube.Update(ctx, cr); err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(err, errUpdateRedisCRFailed)
 	}
+	imp := cr.Status.AtProvider.Import
 	cr.Status.AtProvider = redisclients.GenerateObservation(cache)
+	cr.Status.AtProvider.Import = imp
+	if err := azure.FetchAsyncOperation(ctx, c.sender, cr.Status.AtProvider.Import); err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errFetchImportFailed)
+	}
 
 	var conn managed.ConnectionDetails
 	switch cr.Status.AtProvider.ProvisioningState {
@@ -118,7 +172,11 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 			xpv1.ResourceCredentialsSecretPortKey:     []byte(strconv.Itoa(cr.Status.AtProvider.Port)),
 			xpv1.ResourceCredentialsSecretPasswordKey: []byte(azure.ToString(k.PrimaryKey)),
 		}
-		cr.Status.SetConditions(xpv1.Available())
+		cr.Status.SetConditions(probe.Condition(ctx, cr, c.probe, cr.Status.AtProvider.HostName, strconv.Itoa(cr.Status.AtProvider.SSLPort)))
+		// A seeded cache is not Available until its data is imported.
+		if ic, ok := redisclients.ImportCondition(cr); ok {
+			cr.Status.SetConditions(ic)
+		}
 	case redisclients.ProvisioningStateCreating:
 		cr.Status.SetConditions(xpv1.Creating())
 	case redisclients.ProvisioningStateDeleting:
@@ -128,7 +186,7 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	}
 	return managed.ExternalObservation{
 		ResourceExists:    true,
-		ResourceUpToDate:  !redisclients.NeedsUpdate(cr.Spec.ForProvider, cache),
+		ResourceUpToDate:  !redisclients.NeedsUpdate(cr.Spec.ForProvider, cache) && !redisclients.ImportPending(cr),
 		ConnectionDetails: conn,
 	}, nil
 }
@@ -153,10 +211,18 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 	if cr.Status.AtProvider.ProvisioningState != redisclients.ProvisioningStateSucceeded {
 		return managed.ExternalUpdate{}, nil
 	}
+	if redisclients.ImportPending(cr) {
+		return managed.ExternalUpdate{}, errors.Wrap(c.importData(ctx, cr), errImportFailed)
+	}
 	cache, err := c.client.Get(ctx, cr.Spec.ForProvider.ResourceGroupName, meta.GetExternalName(cr))
 	if err != nil {
 		return managed.ExternalUpdate{}, errors.Wrap(err, errGetFailed)
 	}
+	if redisclients.IsSKUDowngrade(cr.Spec.ForProvider.SKU, cache) {
+		if err := approval.Hold(ctx, c.gate, cr, approval.OperationUpdate); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+		}
+	}
 	_, err = c.client.Update(
 		ctx,
 		cr.Spec.ForProvider.ResourceGroupName,
@@ -165,6 +231,32 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
 }
 
+// importData starts the import of the files of the supplied Redis. The
+// operation is recorded before the request is made so that an import whose
+// request times out is not repeated while it may be in progress.
+func (c *external) importData(ctx context.Context, cr *v1beta1.Redis) error {
+	ref := cr.Spec.ForProvider.ImportFrom.FilesSecretRef
+	s := &corev1.Secret{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+		return errors.Wrap(err, errGetImportFiles)
+	}
+	op := azure.NewAsyncOperation(http.MethodPost)
+	cr.Status.AtProvider.Import = &op
+	f, err := c.client.ImportData(
+		azure.WithClientRequestID(ctx, op.ClientRequestID),
+		cr.Spec.ForProvider.ResourceGroupName,
+		meta.GetExternalName(cr),
+		redisclients.NewImportParameters(*cr.Spec.ForProvider.ImportFrom, string(s.Data[ref.Key])))
+	if err != nil {
+		if azure.IsRejected(err) {
+			cr.Status.AtProvider.Import = nil
+		}
+		return err
+	}
+	cr.Status.AtProvider.Import.PollingURL = f.PollingURL()
+	return nil
+}
+
 func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 	cr, ok := mg.(*v1beta1.Redis)
 	if !ok {
@@ -27,6 +27,7 @@ import (
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
@@ -52,7 +53,7 @@ var (
 	subnetID         = "coolsubnet"
 	staticIP         = "172.16.0.1"
 	shardCount       = 3
-	location         = "coolplace"
+	testLocation     = "coolplace"
 	minTLSVersion    = "1.1"
 	tenantSettings   = map[string]string{"tenant1": "is-crazy"}
 	hostName         = "108.8.8.1"
@@ -86,6 +87,10 @@ func withPort(p int) redisResourceModifier {
 	return func(r *v1beta1.Redis) { r.Status.AtProvider.Port = p }
 }
 
+func withImportFrom(i *v1beta1.RedisImport) redisResourceModifier {
+	return func(r *v1beta1.Redis) { r.Spec.ForProvider.ImportFrom = i }
+}
+
 func instance(rm ...redisResourceModifier) *v1beta1.Redis {
 	r := &v1beta1.Redis{
 		Spec: v1beta1.RedisSpec{
@@ -96,7 +101,7 @@ func instance(rm ...redisResourceModifier) *v1beta1.Redis {
 				},
 			},
 			ForProvider: v1beta1.RedisParameters{
-				Location:          location,
+				Location:          testLocation,
 				ResourceGroupName: "group1",
 				SKU: v1beta1.SKU{
 					Name:     skuName,
@@ -185,6 +190,48 @@ func TestObserve(t *testing.T) {
 				},
 			},
 		},
+		"ImportPending": {
+			args: args{
+				cr: instance(withImportFrom(&v1beta1.RedisImport{})),
+				kube: &test.MockClient{
+					MockUpdate: test.NewMockUpdateFn(nil),
+				},
+				r: &fake.MockClient{
+					MockGet: func(_ context.Context, resourceGroupName string, name string) (result redis.ResourceType, err error) {
+						return redis.ResourceType{
+							Properties: &redis.Properties{
+								ProvisioningState: redis.Succeeded,
+								HostName:          &hostName,
+								Port:              azure.ToInt32(&port),
+							},
+						}, nil
+					},
+					MockListKeys: func(ctx context.Context, resourceGroupName string, name string) (result redis.AccessKeys, err error) {
+						return redis.AccessKeys{
+							PrimaryKey: azure.ToStringPtr(primaryKey),
+						}, nil
+					},
+				},
+			},
+			want: want{
+				cr: instance(
+					withImportFrom(&v1beta1.RedisImport{}),
+					withProvisioningState(redisclient.ProvisioningStateSucceeded),
+					withHostName(hostName),
+					withPort(port),
+					withConditions(xpv1.Unavailable().WithMessage("waiting for the data import to start")),
+				),
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+					ConnectionDetails: managed.ConnectionDetails{
+						xpv1.ResourceCredentialsSecretEndpointKey: []byte(hostName),
+						xpv1.ResourceCredentialsSecretPortKey:     []byte(strconv.Itoa(port)),
+						xpv1.ResourceCredentialsSecretPasswordKey: []byte(primaryKey),
+					},
+				},
+			},
+		},
 		"GetFailed": {
 			args: args{
 				cr: instance(),
@@ -404,9 +451,16 @@ func TestCreate(t *testing.T) {
 }
 
 func TestUpdate(t *testing.T) {
+	imp := &v1beta1.RedisImport{FilesSecretRef: xpv1.SecretKeySelector{
+		SecretReference: xpv1.SecretReference{Namespace: namespace, Name: "cool-files"},
+		Key:             "files",
+	}}
+	rejected := autorest.DetailedError{StatusCode: http.StatusBadRequest}
+
 	type args struct {
-		cr *v1beta1.Redis
-		r  redisapi.ClientAPI
+		cr   *v1beta1.Redis
+		r    redisapi.ClientAPI
+		kube client.Client
 	}
 	type want struct {
 		cr  *v1beta1.Redis
@@ -472,11 +526,44 @@ func TestUpdate(t *testing.T) {
 				err: errors.Wrap(errorBoom, errUpdateFailed),
 			},
 		},
+		"ImportRejected": {
+			args: args{
+				cr: instance(withProvisioningState(redisclient.ProvisioningStateSucceeded), withImportFrom(imp)),
+				kube: &test.MockClient{
+					MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+						obj.(*corev1.Secret).Data = map[string][]byte{"files": []byte("https://cool.blob.core.windows.net/c/a.rdb?sig=a")}
+						return nil
+					},
+				},
+				r: &fake.MockClient{
+					MockImportData: func(_ context.Context, _ string, _ string, parameters redis.ImportRDBParameters) (result redis.ImportDataFuture, err error) {
+						if diff := cmp.Diff(&[]string{"https://cool.blob.core.windows.net/c/a.rdb?sig=a"}, parameters.Files); diff != "" {
+							t.Errorf("ImportData(...): -want, +got\n%s", diff)
+						}
+						return redis.ImportDataFuture{}, rejected
+					},
+				},
+			},
+			want: want{
+				cr:  instance(withProvisioningState(redisclient.ProvisioningStateSucceeded), withImportFrom(imp)),
+				err: errors.Wrap(rejected, errImportFailed),
+			},
+		},
+		"GetImportFilesFailed": {
+			args: args{
+				cr:   instance(withProvisioningState(redisclient.ProvisioningStateSucceeded), withImportFrom(imp)),
+				kube: &test.MockClient{MockGet: test.NewMockGetFn(errorBoom)},
+			},
+			want: want{
+				cr:  instance(withProvisioningState(redisclient.ProvisioningStateSucceeded), withImportFrom(imp)),
+				err: errors.Wrap(errors.Wrap(errorBoom, errGetImportFiles), errImportFailed),
+			},
+		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := external{client: tc.r}
+			e := external{client: tc.r, kube: tc.kube}
 
 			c, err := e.Update(context.Background(), tc.args.cr)
 			if diff := cmp.Diff(tc.want.cr, tc.args.cr); diff != "" {
@@ -0,0 +1,200 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openaideployment
+
+import (
+	"context"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-azure/apis/cognitiveservices/v1alpha1"
+	azurev1alpha3 "github.com/crossplane/provider-azure/apis/v1alpha3"
+	"github.com/crossplane/provider-azure/pkg/chain"
+	azureclients "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/cognitiveservices"
+	"github.com/crossplane/provider-azure/pkg/concurrency"
+	"github.com/crossplane/provider-azure/pkg/connection"
+	"github.com/crossplane/provider-azure/pkg/credentials"
+	"github.com/crossplane/provider-azure/pkg/defaults"
+	"github.com/crossplane/provider-azure/pkg/dependency"
+	"github.com/crossplane/provider-azure/pkg/externalname"
+	"github.com/crossplane/provider-azure/pkg/tenancy"
+)
+
+// Error strings.
+const (
+	errNotDeployment    = "managed resource is not an OpenAIDeployment"
+	errCreateDeployment = "cannot create OpenAI deployment"
+	errUpdateDeployment = "cannot update OpenAI deployment"
+	errGetDeployment    = "cannot get OpenAI deployment"
+	errDeleteDeployment = "cannot delete OpenAI deployment"
+	errGetAccount       = "cannot get Cognitive Services account of OpenAI deployment"
+	errListKeys         = "cannot list keys of Cognitive Services account of OpenAI deployment"
+)
+
+// Setup adds a controller that reconciles OpenAIDeployments.
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, sel labels.Selector) error {
+	name := managed.ControllerName(v1alpha1.OpenAIDeploymentGroupKind)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: concurrency.DefaultLimits.Of(v1alpha1.OpenAIDeploymentGroupKind),
+		}).
+		For(&v1alpha1.OpenAIDeployment{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, credentials.EnqueueRequestsForRotation(mgr.GetClient(), &v1alpha1.OpenAIDeploymentList{}, l.WithValues("controller", name)), builder.OnlyMetadata).
+		Watches(&source.Kind{Type: &azurev1alpha3.ResourceGroup{}}, dependency.EnqueueRequestsForDependents(mgr.GetClient(), &v1alpha1.OpenAIDeploymentList{}, l.WithValues("controller", name)), builder.WithPredicates(dependency.BecameAvailable())).
+		Complete(chain.NewReconciler(mgr, resource.ManagedKind(v1alpha1.OpenAIDeploymentGroupVersionKind),
+			managed.NewReconciler(mgr,
+				resource.ManagedKind(v1alpha1.OpenAIDeploymentGroupVersionKind),
+				managed.WithConnectionPublishers(connection.NewNamespacePolicyPublisher(connection.DefaultNamespacePolicy, connection.NewTemplatingPublisher(connection.NewKeyVaultPublisher(mgr.GetClient(), mgr.GetScheme(),
+					managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme()),
+					connection.NewAdditionalNamespacesPublisher(mgr.GetClient(), mgr.GetScheme()))))),
+				managed.WithExternalConnecter(chain.NewConnecter(mgr, v1alpha1.OpenAIDeploymentGroupKind, &connecter{client: mgr.GetClient()})),
+				managed.WithInitializers(managed.NewNameAsExternalName(mgr.GetClient()), externalname.NewInitializer(mgr.GetClient()), tenancy.NewDefaultProviderInitializer(mgr.GetClient()), defaults.NewInitializer(mgr.GetClient()), connection.NewNamespaceInitializer(mgr.GetClient(), connection.DefaultNamespacePolicy)),
+				managed.WithReferenceResolver(dependency.NewReferenceResolver(mgr.GetClient())),
+				managed.WithLogger(l.WithValues("controller", name)),
+				managed.WithRecorder(recorder)),
+			sel))
+}
+
+type connecter struct {
+	client client.Client
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	d, ok := mg.(*v1alpha1.OpenAIDeployment)
+	if !ok {
+		return nil, errors.New(errNotDeployment)
+	}
+	creds, auth, err := azureclients.GetAuthInfo(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	cl := cognitiveservices.NewDeploymentsClientWithBaseURI(azureclients.BaseURI(creds), azureclients.SubscriptionID(creds, d.Spec.ForProvider.SubscriptionID))
+	cl.Authorizer = auth
+	return &external{client: cl}, nil
+}
+
+type external struct {
+	client cognitiveservices.DeploymentsAPI
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	d, ok := mg.(*v1alpha1.OpenAIDeployment)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotDeployment)
+	}
+
+	p := d.Spec.ForProvider
+	az, err := e.client.Get(ctx, p.ResourceGroupName, p.AccountName, meta.GetExternalName(d))
+	if azureclients.IsNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetDeployment)
+	}
+	a, err := e.client.GetAccount(ctx, p.ResourceGroupName, p.AccountName)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetAccount)
+	}
+
+	current := d.Spec.ForProvider.DeepCopy()
+	cognitiveservices.LateInitializeDeployment(&d.Spec.ForProvider, az)
+
+	d.Status.AtProvider = cognitiveservices.GenerateDeploymentObservation(az, a)
+	var conn managed.ConnectionDetails
+	switch d.Status.AtProvider.ProvisioningState {
+	case cognitiveservices.ProvisioningStateSucceeded:
+		k, err := e.client.ListKeys(ctx, p.ResourceGroupName, p.AccountName)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errListKeys)
+		}
+		conn = managed.ConnectionDetails{
+			xpv1.ResourceCredentialsSecretEndpointKey:     []byte(d.Status.AtProvider.Endpoint),
+			cognitiveservices.ConnectionKeyAPIKey:         []byte(azureclients.ToString(k.Key1)),
+			cognitiveservices.ConnectionKeyDeploymentName: []byte(meta.GetExternalName(d)),
+		}
+		d.SetConditions(xpv1.Available())
+	case cognitiveservices.ProvisioningStateFailed:
+		d.SetConditions(xpv1.Unavailable())
+	case cognitiveservices.ProvisioningStateDeleting:
+		d.SetConditions(xpv1.Deleting())
+	default:
+		d.SetConditions(xpv1.Creating())
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        !cognitiveservices.DeploymentNeedsUpdate(d.Spec.ForProvider, az),
+		ResourceLateInitialized: !cmp.Equal(current, &d.Spec.ForProvider),
+		ConnectionDetails:       conn,
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	d, ok := mg.(*v1alpha1.OpenAIDeployment)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotDeployment)
+	}
+
+	d.SetConditions(xpv1.Creating())
+	p := d.Spec.ForProvider
+	err := e.client.CreateOrUpdate(ctx, p.ResourceGroupName, p.AccountName, meta.GetExternalName(d), cognitiveservices.NewDeployment(p))
+	return managed.ExternalCreation{}, errors.Wrap(err, errCreateDeployment)
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	d, ok := mg.(*v1alpha1.OpenAIDeployment)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotDeployment)
+	}
+
+	p := d.Spec.ForProvider
+	err := e.client.CreateOrUpdate(ctx, p.ResourceGroupName, p.AccountName, meta.GetExternalName(d), cognitiveservices.NewDeployment(p))
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateDeployment)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	d, ok := mg.(*v1alpha1.OpenAIDeployment)
+	if !ok {
+		return errors.New(errNotDeployment)
+	}
+
+	d.SetConditions(xpv1.Deleting())
+	err := e.client.Delete(ctx, d.Spec.ForProvider.ResourceGroupName, d.Spec.ForProvider.AccountName, meta.GetExternalName(d))
+	return errors.Wrap(resource.Ignore(azureclients.IsNotFound, err), errDeleteDeployment)
+}
@@ -0,0 +1,243 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openaideployment
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/cognitiveservices/v1alpha1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/cognitiveservices"
+	"github.com/crossplane/provider-azure/pkg/clients/cognitiveservices/fake"
+)
+
+const (
+	name     = "coolDeployment"
+	endpoint = "https://cool.openai.azure.com/"
+	key      = "coolKey"
+)
+
+var errBoom = errors.New("boom")
+
+type deploymentModifier func(*v1alpha1.OpenAIDeployment)
+
+func withConditions(c ...xpv1.Condition) deploymentModifier {
+	return func(d *v1alpha1.OpenAIDeployment) { d.Status.ConditionedStatus.Conditions = c }
+}
+
+func withCapacity(c int32) deploymentModifier {
+	return func(d *v1alpha1.OpenAIDeployment) { d.Spec.ForProvider.SKU.Capacity = c }
+}
+
+func withLateInitialized() deploymentModifier {
+	return func(d *v1alpha1.OpenAIDeployment) {
+		d.Spec.ForProvider.Model.Format = azure.ToStringPtr(cognitiveservices.ModelFormatOpenAI)
+		d.Spec.ForProvider.Model.Version = azure.ToStringPtr("2024-05-13")
+	}
+}
+
+func withObservation(state string) deploymentModifier {
+	return func(d *v1alpha1.OpenAIDeployment) {
+		d.Status.AtProvider = v1alpha1.OpenAIDeploymentObservation{ProvisioningState: state, Endpoint: endpoint}
+	}
+}
+
+func deployment(m ...deploymentModifier) *v1alpha1.OpenAIDeployment {
+	d := &v1alpha1.OpenAIDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1alpha1.OpenAIDeploymentSpec{
+			ForProvider: v1alpha1.OpenAIDeploymentParameters{
+				ResourceGroupName: "coolRG",
+				AccountName:       "coolAccount",
+				Model:             v1alpha1.OpenAIModel{Name: "gpt-4o"},
+				SKU:               v1alpha1.OpenAISKU{Name: "Standard", Capacity: 10},
+			},
+		},
+	}
+	meta.SetExternalName(d, name)
+	for _, fn := range m {
+		fn(d)
+	}
+	return d
+}
+
+func observed(state string) cognitiveservices.Deployment {
+	capacity := int32(10)
+	return cognitiveservices.Deployment{
+		SKU: &cognitiveservices.SKU{Name: azure.ToStringPtr("Standard"), Capacity: &capacity},
+		Properties: &cognitiveservices.DeploymentProperties{
+			ProvisioningState: azure.ToStringPtr(state),
+			Model: &cognitiveservices.DeploymentModel{
+				Format:  azure.ToStringPtr(cognitiveservices.ModelFormatOpenAI),
+				Name:    azure.ToStringPtr("gpt-4o"),
+				Version: azure.ToStringPtr("2024-05-13"),
+			},
+		},
+	}
+}
+
+func mockClient(state string) *fake.MockDeploymentsClient {
+	return &fake.MockDeploymentsClient{
+		MockGet: func(_ context.Context, _, _, _ string) (cognitiveservices.Deployment, error) {
+			return observed(state), nil
+		},
+		MockGetAccount: func(_ context.Context, _, _ string) (cognitiveservices.Account, error) {
+			return cognitiveservices.Account{Properties: &cognitiveservices.AccountProperties{Endpoint: azure.ToStringPtr(endpoint)}}, nil
+		},
+		MockListKeys: func(_ context.Context, _, _ string) (cognitiveservices.AccountKeys, error) {
+			return cognitiveservices.AccountKeys{Key1: azure.ToStringPtr(key)}, nil
+		},
+	}
+}
+
+var _ managed.ExternalClient = &external{}
+var _ managed.ExternalConnecter = &connecter{}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   want
+	}{
+		"NotFound": {
+			reason: "A deployment that is not found should not exist",
+			e: &external{client: &fake.MockDeploymentsClient{
+				MockGet: func(_ context.Context, _, _, _ string) (cognitiveservices.Deployment, error) {
+					return cognitiveservices.Deployment{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			}},
+			mg:   deployment(),
+			want: want{mg: deployment()},
+		},
+		"GetFailed": {
+			reason: "Errors getting the deployment should be returned",
+			e: &external{client: &fake.MockDeploymentsClient{
+				MockGet: func(_ context.Context, _, _, _ string) (cognitiveservices.Deployment, error) {
+					return cognitiveservices.Deployment{}, errBoom
+				},
+			}},
+			mg:   deployment(),
+			want: want{mg: deployment(), err: errors.Wrap(errBoom, errGetDeployment)},
+		},
+		"Creating": {
+			reason: "A deployment that is still being provisioned should be creating and publish no connection details",
+			e:      &external{client: mockClient("Accepted")},
+			mg:     deployment(),
+			want: want{
+				mg: deployment(withLateInitialized(), withObservation("Accepted"), withConditions(xpv1.Creating())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true},
+			},
+		},
+		"Available": {
+			reason: "A provisioned deployment should be available and publish the endpoint and key of its account",
+			e:      &external{client: mockClient(cognitiveservices.ProvisioningStateSucceeded)},
+			mg:     deployment(withLateInitialized()),
+			want: want{
+				mg: deployment(withLateInitialized(), withObservation(cognitiveservices.ProvisioningStateSucceeded), withConditions(xpv1.Available())),
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+					ConnectionDetails: managed.ConnectionDetails{
+						xpv1.ResourceCredentialsSecretEndpointKey:     []byte(endpoint),
+						cognitiveservices.ConnectionKeyAPIKey:         []byte(key),
+						cognitiveservices.ConnectionKeyDeploymentName: []byte(name),
+					},
+				},
+			},
+		},
+		"CapacityChanged": {
+			reason: "A deployment whose capacity differs should need an update",
+			e:      &external{client: mockClient("Accepted")},
+			mg:     deployment(withLateInitialized(), withCapacity(20)),
+			want: want{
+				mg: deployment(withLateInitialized(), withCapacity(20), withObservation("Accepted"), withConditions(xpv1.Creating())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o, err := tc.e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want managed, +got managed:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   error
+	}{
+		"NotFound": {
+			reason: "A deployment that is already gone should be considered deleted",
+			e: &external{client: &fake.MockDeploymentsClient{
+				MockDelete: func(_ context.Context, _, _, _ string) error {
+					return autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			}},
+			mg: deployment(),
+		},
+		"Failed": {
+			reason: "Errors deleting the deployment should be returned",
+			e: &external{client: &fake.MockDeploymentsClient{
+				MockDelete: func(_ context.Context, _, _, _ string) error { return errBoom },
+			}},
+			mg:   deployment(),
+			want: errors.Wrap(errBoom, errDeleteDeployment),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
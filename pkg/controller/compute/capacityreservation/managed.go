@@ -0,0 +1,186 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacityreservation
+
+import (
+	"context"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-azure/apis/compute/v1alpha3"
+	azurev1alpha3 "github.com/crossplane/provider-azure/apis/v1alpha3"
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+	"github.com/crossplane/provider-azure/pkg/chain"
+	azureclients "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/compute"
+	"github.com/crossplane/provider-azure/pkg/concurrency"
+	"github.com/crossplane/provider-azure/pkg/credentials"
+	"github.com/crossplane/provider-azure/pkg/defaults"
+	"github.com/crossplane/provider-azure/pkg/dependency"
+	"github.com/crossplane/provider-azure/pkg/externalname"
+	"github.com/crossplane/provider-azure/pkg/location"
+	"github.com/crossplane/provider-azure/pkg/tenancy"
+)
+
+// Error strings.
+const (
+	errNotCapacityReservation    = "managed resource is not a CapacityReservation"
+	errCreateCapacityReservation = "cannot create capacity reservation"
+	errUpdateCapacityReservation = "cannot update capacity reservation"
+	errGetCapacityReservation    = "cannot get capacity reservation"
+	errDeleteCapacityReservation = "cannot delete capacity reservation"
+)
+
+// Setup adds a controller that reconciles CapacityReservations.
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, sel labels.Selector) error {
+	name := managed.ControllerName(v1alpha3.CapacityReservationGroupKindName)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: concurrency.DefaultLimits.Of(v1alpha3.CapacityReservationGroupKindName),
+		}).
+		For(&v1alpha3.CapacityReservation{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, credentials.EnqueueRequestsForRotation(mgr.GetClient(), &v1alpha3.CapacityReservationList{}, l.WithValues("controller", name)), builder.OnlyMetadata).
+		Watches(&source.Kind{Type: &azurev1alpha3.ResourceGroup{}}, dependency.EnqueueRequestsForDependents(mgr.GetClient(), &v1alpha3.CapacityReservationList{}, l.WithValues("controller", name)), builder.WithPredicates(dependency.BecameAvailable())).
+		Watches(&source.Kind{Type: &v1alpha3.CapacityReservationGroup{}}, dependency.EnqueueRequestsForDependents(mgr.GetClient(), &v1alpha3.CapacityReservationList{}, l.WithValues("controller", name)), builder.WithPredicates(dependency.BecameAvailable())).
+		Complete(chain.NewReconciler(mgr, resource.ManagedKind(v1alpha3.CapacityReservationGroupVersionKind),
+			managed.NewReconciler(mgr,
+				resource.ManagedKind(v1alpha3.CapacityReservationGroupVersionKind),
+				managed.WithConnectionPublishers(),
+				managed.WithExternalConnecter(chain.NewConnecter(mgr, v1alpha3.CapacityReservationGroupKindName, &connecter{client: mgr.GetClient()})),
+				managed.WithInitializers(managed.NewNameAsExternalName(mgr.GetClient()), externalname.NewInitializer(mgr.GetClient()), tenancy.NewDefaultProviderInitializer(mgr.GetClient()), location.NewInitializer(mgr.GetClient()), defaults.NewInitializer(mgr.GetClient())),
+				managed.WithReferenceResolver(dependency.NewReferenceResolver(mgr.GetClient())),
+				managed.WithLogger(l.WithValues("controller", name)),
+				managed.WithRecorder(recorder)),
+			sel))
+}
+
+type connecter struct {
+	client client.Client
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	r, ok := mg.(*v1alpha3.CapacityReservation)
+	if !ok {
+		return nil, errors.New(errNotCapacityReservation)
+	}
+	creds, auth, err := azureclients.GetAuthInfo(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	cl := compute.NewCapacityReservationsClientWithBaseURI(azureclients.BaseURI(creds), azureclients.SubscriptionID(creds, r.Spec.ForProvider.SubscriptionID))
+	cl.Authorizer = auth
+	tp, err := azureclients.TagPolicyOf(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &external{client: cl, tagPolicy: tp}, nil
+}
+
+type external struct {
+	client    compute.CapacityReservationsAPI
+	tagPolicy v1beta1.TagPolicy
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	r, ok := mg.(*v1alpha3.CapacityReservation)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotCapacityReservation)
+	}
+
+	p := r.Spec.ForProvider
+	az, err := e.client.Get(ctx, p.ResourceGroupName, p.CapacityReservationGroupName, meta.GetExternalName(r))
+	if azureclients.IsNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetCapacityReservation)
+	}
+
+	current := r.Spec.ForProvider.DeepCopy()
+	compute.LateInitializeCapacityReservation(&r.Spec.ForProvider, az, e.tagPolicy)
+
+	r.Status.AtProvider = compute.GenerateCapacityReservationObservation(az)
+	switch r.Status.AtProvider.ProvisioningState {
+	case compute.ProvisioningStateSucceeded:
+		r.SetConditions(xpv1.Available())
+	case compute.ProvisioningStateFailed:
+		r.SetConditions(xpv1.Unavailable())
+	default:
+		r.SetConditions(xpv1.Creating())
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        !compute.CapacityReservationNeedsUpdate(r.Spec.ForProvider, az),
+		ResourceLateInitialized: !cmp.Equal(current, &r.Spec.ForProvider),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	r, ok := mg.(*v1alpha3.CapacityReservation)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotCapacityReservation)
+	}
+
+	r.SetConditions(xpv1.Creating())
+	p := r.Spec.ForProvider
+	err := e.client.CreateOrUpdate(ctx, p.ResourceGroupName, p.CapacityReservationGroupName, meta.GetExternalName(r), compute.NewCapacityReservation(p))
+	return managed.ExternalCreation{}, errors.Wrap(err, errCreateCapacityReservation)
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	r, ok := mg.(*v1alpha3.CapacityReservation)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotCapacityReservation)
+	}
+
+	p := r.Spec.ForProvider
+	err := e.client.CreateOrUpdate(ctx, p.ResourceGroupName, p.CapacityReservationGroupName, meta.GetExternalName(r), compute.NewCapacityReservation(p))
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateCapacityReservation)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	r, ok := mg.(*v1alpha3.CapacityReservation)
+	if !ok {
+		return errors.New(errNotCapacityReservation)
+	}
+
+	r.SetConditions(xpv1.Deleting())
+	err := e.client.Delete(ctx, r.Spec.ForProvider.ResourceGroupName, r.Spec.ForProvider.CapacityReservationGroupName, meta.GetExternalName(r))
+	return errors.Wrap(resource.Ignore(azureclients.IsNotFound, err), errDeleteCapacityReservation)
+}
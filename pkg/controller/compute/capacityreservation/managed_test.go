@@ -0,0 +1,230 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacityreservation
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/compute/v1alpha3"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/compute"
+	"github.com/crossplane/provider-azure/pkg/clients/compute/fake"
+)
+
+const (
+	name         = "coolReservation"
+	testLocation = "westus2"
+	sku          = "Standard_D2s_v3"
+)
+
+var errBoom = errors.New("boom")
+
+type reservationModifier func(*v1alpha3.CapacityReservation)
+
+func withConditions(c ...xpv1.Condition) reservationModifier {
+	return func(r *v1alpha3.CapacityReservation) { r.Status.ConditionedStatus.Conditions = c }
+}
+
+func withCapacity(c int) reservationModifier {
+	return func(r *v1alpha3.CapacityReservation) { r.Spec.ForProvider.Capacity = c }
+}
+
+func withState(s string) reservationModifier {
+	return func(r *v1alpha3.CapacityReservation) { r.Status.AtProvider.ProvisioningState = s }
+}
+
+func reservation(m ...reservationModifier) *v1alpha3.CapacityReservation {
+	r := &v1alpha3.CapacityReservation{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1alpha3.CapacityReservationSpec{
+			ForProvider: v1alpha3.CapacityReservationParameters{
+				ResourceGroupName:            "coolRG",
+				CapacityReservationGroupName: "coolGroup",
+				Location:                     testLocation,
+				SKU:                          sku,
+				Capacity:                     2,
+			},
+		},
+	}
+	meta.SetExternalName(r, name)
+	for _, fn := range m {
+		fn(r)
+	}
+	return r
+}
+
+func observed(state string, capacity int64) compute.CapacityReservation {
+	return compute.CapacityReservation{
+		Location:   azure.ToStringPtr(testLocation),
+		Sku:        &compute.CapacityReservationSku{Name: azure.ToStringPtr(sku), Capacity: to.Int64Ptr(capacity)},
+		Properties: &compute.CapacityReservationProperties{ProvisioningState: azure.ToStringPtr(state)},
+	}
+}
+
+var _ managed.ExternalClient = &external{}
+var _ managed.ExternalConnecter = &connecter{}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   want
+	}{
+		"NotCapacityReservation": {
+			reason: "An error should be returned if the managed resource is not a CapacityReservation",
+			e:      &external{client: &fake.MockCapacityReservationsClient{}},
+			mg:     &v1alpha3.CapacityReservationGroup{},
+			want:   want{mg: &v1alpha3.CapacityReservationGroup{}, err: errors.New(errNotCapacityReservation)},
+		},
+		"NotFound": {
+			reason: "A capacity reservation that is not found should not exist",
+			e: &external{client: &fake.MockCapacityReservationsClient{
+				MockGet: func(_ context.Context, _, _, _ string) (compute.CapacityReservation, error) {
+					return compute.CapacityReservation{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			}},
+			mg:   reservation(),
+			want: want{mg: reservation()},
+		},
+		"GetFailed": {
+			reason: "Errors getting the capacity reservation should be returned",
+			e: &external{client: &fake.MockCapacityReservationsClient{
+				MockGet: func(_ context.Context, _, _, _ string) (compute.CapacityReservation, error) {
+					return compute.CapacityReservation{}, errBoom
+				},
+			}},
+			mg:   reservation(),
+			want: want{mg: reservation(), err: errors.Wrap(errBoom, errGetCapacityReservation)},
+		},
+		"Provisioning": {
+			reason: "A capacity reservation that is still being provisioned should be creating",
+			e: &external{client: &fake.MockCapacityReservationsClient{
+				MockGet: func(_ context.Context, _, _, _ string) (compute.CapacityReservation, error) {
+					return observed("Creating", 2), nil
+				},
+			}},
+			mg: reservation(),
+			want: want{
+				mg: reservation(withState("Creating"), withConditions(xpv1.Creating())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"Failed": {
+			reason: "A capacity reservation that failed to be provisioned should be unavailable",
+			e: &external{client: &fake.MockCapacityReservationsClient{
+				MockGet: func(_ context.Context, _, _, _ string) (compute.CapacityReservation, error) {
+					return observed("Failed", 2), nil
+				},
+			}},
+			mg: reservation(),
+			want: want{
+				mg: reservation(withState("Failed"), withConditions(xpv1.Unavailable())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"CapacityChanged": {
+			reason: "An available capacity reservation whose capacity differs should need an update",
+			e: &external{client: &fake.MockCapacityReservationsClient{
+				MockGet: func(_ context.Context, _, _, _ string) (compute.CapacityReservation, error) {
+					return observed("Succeeded", 2), nil
+				},
+			}},
+			mg: reservation(withCapacity(4)),
+			want: want{
+				mg: reservation(withCapacity(4), withState("Succeeded"), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o, err := tc.e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want managed, +got managed:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   error
+	}{
+		"Successful": {
+			reason: "The capacity of the capacity reservation should be updated",
+			e: &external{client: &fake.MockCapacityReservationsClient{
+				MockCreateOrUpdate: func(_ context.Context, _, _, _ string, r compute.CapacityReservation) error {
+					if diff := cmp.Diff(to.Int64Ptr(4), r.Sku.Capacity); diff != "" {
+						t.Errorf("Update(...): -want capacity, +got capacity:\n%s", diff)
+					}
+					return nil
+				},
+			}},
+			mg: reservation(withCapacity(4)),
+		},
+		"Failed": {
+			reason: "Errors updating the capacity reservation should be returned",
+			e: &external{client: &fake.MockCapacityReservationsClient{
+				MockCreateOrUpdate: func(_ context.Context, _, _, _ string, _ compute.CapacityReservation) error {
+					return errBoom
+				},
+			}},
+			mg:   reservation(),
+			want: errors.Wrap(errBoom, errUpdateCapacityReservation),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dedicatedhost
+
+import (
+	"context"
+
+	azurecompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-07-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-07-01/compute/computeapi"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-azure/apis/compute/v1alpha3"
+	azurev1alpha3 "github.com/crossplane/provider-azure/apis/v1alpha3"
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+	"github.com/crossplane/provider-azure/pkg/chain"
+	azureclients "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/compute"
+	"github.com/crossplane/provider-azure/pkg/concurrency"
+	"github.com/crossplane/provider-azure/pkg/credentials"
+	"github.com/crossplane/provider-azure/pkg/defaults"
+	"github.com/crossplane/provider-azure/pkg/dependency"
+	"github.com/crossplane/provider-azure/pkg/externalname"
+	"github.com/crossplane/provider-azure/pkg/location"
+	"github.com/crossplane/provider-azure/pkg/tenancy"
+)
+
+// Error strings.
+const (
+	errNotDedicatedHost    = "managed resource is not a DedicatedHost"
+	errCreateDedicatedHost = "cannot create dedicated host"
+	errUpdateDedicatedHost = "cannot update dedicated host"
+	errGetDedicatedHost    = "cannot get dedicated host"
+	errDeleteDedicatedHost = "cannot delete dedicated host"
+)
+
+// Setup adds a controller that reconciles DedicatedHosts.
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, sel labels.Selector) error {
+	name := managed.ControllerName(v1alpha3.DedicatedHostGroupKindName)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: concurrency.DefaultLimits.Of(v1alpha3.DedicatedHostGroupKindName),
+		}).
+		For(&v1alpha3.DedicatedHost{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, credentials.EnqueueRequestsForRotation(mgr.GetClient(), &v1alpha3.DedicatedHostList{}, l.WithValues("controller", name)), builder.OnlyMetadata).
+		Watches(&source.Kind{Type: &azurev1alpha3.ResourceGroup{}}, dependency.EnqueueRequestsForDependents(mgr.GetClient(), &v1alpha3.DedicatedHostList{}, l.WithValues("controller", name)), builder.WithPredicates(dependency.BecameAvailable())).
+		Watches(&source.Kind{Type: &v1alpha3.DedicatedHostGroup{}}, dependency.EnqueueRequestsForDependents(mgr.GetClient(), &v1alpha3.DedicatedHostList{}, l.WithValues("controller", name)), builder.WithPredicates(dependency.BecameAvailable())).
+		Complete(chain.NewReconciler(mgr, resource.ManagedKind(v1alpha3.DedicatedHostGroupVersionKind),
+			managed.NewReconciler(mgr,
+				resource.ManagedKind(v1alpha3.DedicatedHostGroupVersionKind),
+				managed.WithConnectionPublishers(),
+				managed.WithExternalConnecter(chain.NewConnecter(mgr, v1alpha3.DedicatedHostGroupKindName, &connecter{client: mgr.GetClient()})),
+				managed.WithInitializers(managed.NewNameAsExternalName(mgr.GetClient()), externalname.NewInitializer(mgr.GetClient()), tenancy.NewDefaultProviderInitializer(mgr.GetClient()), location.NewInitializer(mgr.GetClient()), defaults.NewInitializer(mgr.GetClient())),
+				managed.WithReferenceResolver(dependency.NewReferenceResolver(mgr.GetClient())),
+				managed.WithLogger(l.WithValues("controller", name)),
+				managed.WithRecorder(recorder)),
+			sel))
+}
+
+type connecter struct {
+	client client.Client
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	h, ok := mg.(*v1alpha3.DedicatedHost)
+	if !ok {
+		return nil, errors.New(errNotDedicatedHost)
+	}
+	creds, auth, err := azureclients.GetAuthInfo(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	cl := azurecompute.NewDedicatedHostsClientWithBaseURI(azureclients.BaseURI(creds), azureclients.SubscriptionID(creds, h.Spec.ForProvider.SubscriptionID))
+	cl.Authorizer = auth
+	tp, err := azureclients.TagPolicyOf(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &external{client: cl, tagPolicy: tp}, nil
+}
+
+type external struct {
+	client    computeapi.DedicatedHostsClientAPI
+	tagPolicy v1beta1.TagPolicy
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	h, ok := mg.(*v1alpha3.DedicatedHost)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotDedicatedHost)
+	}
+
+	p := h.Spec.ForProvider
+	az, err := e.client.Get(ctx, p.ResourceGroupName, p.HostGroupName, meta.GetExternalName(h), "")
+	if azureclients.IsNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetDedicatedHost)
+	}
+
+	current := h.Spec.ForProvider.DeepCopy()
+	compute.LateInitializeDedicatedHost(&h.Spec.ForProvider, az, e.tagPolicy)
+
+	h.Status.AtProvider = compute.GenerateDedicatedHostObservation(az)
+	switch h.Status.AtProvider.ProvisioningState {
+	case compute.ProvisioningStateSucceeded:
+		h.SetConditions(xpv1.Available())
+	case compute.ProvisioningStateFailed:
+		h.SetConditions(xpv1.Unavailable())
+	default:
+		h.SetConditions(xpv1.Creating())
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        !compute.DedicatedHostNeedsUpdate(h.Spec.ForProvider, az),
+		ResourceLateInitialized: !cmp.Equal(current, &h.Spec.ForProvider),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	h, ok := mg.(*v1alpha3.DedicatedHost)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotDedicatedHost)
+	}
+
+	h.SetConditions(xpv1.Creating())
+	p := h.Spec.ForProvider
+	_, err := e.client.CreateOrUpdate(ctx, p.ResourceGroupName, p.HostGroupName, meta.GetExternalName(h), compute.NewDedicatedHost(p))
+	return managed.ExternalCreation{}, errors.Wrap(err, errCreateDedicatedHost)
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	h, ok := mg.(*v1alpha3.DedicatedHost)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotDedicatedHost)
+	}
+
+	p := h.Spec.ForProvider
+	_, err := e.client.Update(ctx, p.ResourceGroupName, p.HostGroupName, meta.GetExternalName(h), compute.NewDedicatedHostUpdate(p))
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateDedicatedHost)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	h, ok := mg.(*v1alpha3.DedicatedHost)
+	if !ok {
+		return errors.New(errNotDedicatedHost)
+	}
+
+	h.SetConditions(xpv1.Deleting())
+	_, err := e.client.Delete(ctx, h.Spec.ForProvider.ResourceGroupName, h.Spec.ForProvider.HostGroupName, meta.GetExternalName(h))
+	return errors.Wrap(resource.Ignore(azureclients.IsNotFound, err), errDeleteDedicatedHost)
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dedicatedhost
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-07-01/compute"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/compute/v1alpha3"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/compute/fake"
+)
+
+const (
+	name         = "coolHost"
+	testLocation = "westus2"
+)
+
+var errBoom = errors.New("boom")
+
+type hostModifier func(*v1alpha3.DedicatedHost)
+
+func withConditions(c ...xpv1.Condition) hostModifier {
+	return func(r *v1alpha3.DedicatedHost) { r.Status.ConditionedStatus.Conditions = c }
+}
+
+func withTags(t map[string]string) hostModifier {
+	return func(r *v1alpha3.DedicatedHost) { r.Spec.ForProvider.Tags = t }
+}
+
+func withState(s string) hostModifier {
+	return func(r *v1alpha3.DedicatedHost) { r.Status.AtProvider.ProvisioningState = s }
+}
+
+func host(m ...hostModifier) *v1alpha3.DedicatedHost {
+	r := &v1alpha3.DedicatedHost{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1alpha3.DedicatedHostSpec{
+			ForProvider: v1alpha3.DedicatedHostParameters{
+				ResourceGroupName: "coolRG",
+				HostGroupName:     "coolGroup",
+				Location:          testLocation,
+				SKU:               "DSv3-Type1",
+			},
+		},
+	}
+	meta.SetExternalName(r, name)
+	for _, fn := range m {
+		fn(r)
+	}
+	return r
+}
+
+func observed(state string) compute.DedicatedHost {
+	return compute.DedicatedHost{
+		Location:                azure.ToStringPtr(testLocation),
+		DedicatedHostProperties: &compute.DedicatedHostProperties{ProvisioningState: azure.ToStringPtr(state)},
+	}
+}
+
+var _ managed.ExternalClient = &external{}
+var _ managed.ExternalConnecter = &connecter{}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   want
+	}{
+		"NotDedicatedHost": {
+			reason: "An error should be returned if the managed resource is not a DedicatedHost",
+			e:      &external{client: &fake.MockDedicatedHostsClient{}},
+			mg:     &v1alpha3.DedicatedHostGroup{},
+			want:   want{mg: &v1alpha3.DedicatedHostGroup{}, err: errors.New(errNotDedicatedHost)},
+		},
+		"NotFound": {
+			reason: "A dedicated host that is not found should not exist",
+			e: &external{client: &fake.MockDedicatedHostsClient{
+				MockGet: func(_ context.Context, _, _, _ string, _ compute.InstanceViewTypes) (compute.DedicatedHost, error) {
+					return compute.DedicatedHost{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			}},
+			mg:   host(),
+			want: want{mg: host()},
+		},
+		"GetFailed": {
+			reason: "Errors getting the dedicated host should be returned",
+			e: &external{client: &fake.MockDedicatedHostsClient{
+				MockGet: func(_ context.Context, _, _, _ string, _ compute.InstanceViewTypes) (compute.DedicatedHost, error) {
+					return compute.DedicatedHost{}, errBoom
+				},
+			}},
+			mg:   host(),
+			want: want{mg: host(), err: errors.Wrap(errBoom, errGetDedicatedHost)},
+		},
+		"Provisioning": {
+			reason: "A dedicated host that is still being provisioned should be creating",
+			e: &external{client: &fake.MockDedicatedHostsClient{
+				MockGet: func(_ context.Context, _, _, _ string, _ compute.InstanceViewTypes) (compute.DedicatedHost, error) {
+					return observed("Creating"), nil
+				},
+			}},
+			mg: host(),
+			want: want{
+				mg: host(withState("Creating"), withConditions(xpv1.Creating())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"Failed": {
+			reason: "A dedicated host that failed to be provisioned should be unavailable",
+			e: &external{client: &fake.MockDedicatedHostsClient{
+				MockGet: func(_ context.Context, _, _, _ string, _ compute.InstanceViewTypes) (compute.DedicatedHost, error) {
+					return observed("Failed"), nil
+				},
+			}},
+			mg: host(),
+			want: want{
+				mg: host(withState("Failed"), withConditions(xpv1.Unavailable())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"NeedsUpdate": {
+			reason: "An available dedicated host whose tags differ should need an update",
+			e: &external{client: &fake.MockDedicatedHostsClient{
+				MockGet: func(_ context.Context, _, _, _ string, _ compute.InstanceViewTypes) (compute.DedicatedHost, error) {
+					h := observed("Succeeded")
+					h.Tags = map[string]*string{"team": azure.ToStringPtr("platform")}
+					return h, nil
+				},
+			}},
+			mg: host(withTags(map[string]string{"team": "data"})),
+			want: want{
+				mg: host(withTags(map[string]string{"team": "data"}), withState("Succeeded"), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o, err := tc.e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want managed, +got managed:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   error
+	}{
+		"Successful": {
+			reason: "The tags of the dedicated host should be updated",
+			e: &external{client: &fake.MockDedicatedHostsClient{
+				MockUpdate: func(_ context.Context, _, _, _ string, p compute.DedicatedHostUpdate) (compute.DedicatedHostsUpdateFuture, error) {
+					if diff := cmp.Diff(map[string]*string{"team": azure.ToStringPtr("data")}, p.Tags); diff != "" {
+						t.Errorf("Update(...): -want tags, +got tags:\n%s", diff)
+					}
+					return compute.DedicatedHostsUpdateFuture{}, nil
+				},
+			}},
+			mg: host(withTags(map[string]string{"team": "data"})),
+		},
+		"Failed": {
+			reason: "Errors updating the dedicated host should be returned",
+			e: &external{client: &fake.MockDedicatedHostsClient{
+				MockUpdate: func(_ context.Context, _, _, _ string, _ compute.DedicatedHostUpdate) (compute.DedicatedHostsUpdateFuture, error) {
+					return compute.DedicatedHostsUpdateFuture{}, errBoom
+				},
+			}},
+			mg:   host(),
+			want: errors.Wrap(errBoom, errUpdateDedicatedHost),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
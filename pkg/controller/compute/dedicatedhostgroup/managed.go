@@ -0,0 +1,177 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dedicatedhostgroup
+
+import (
+	"context"
+
+	azurecompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-07-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-07-01/compute/computeapi"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-azure/apis/compute/v1alpha3"
+	azurev1alpha3 "github.com/crossplane/provider-azure/apis/v1alpha3"
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+	"github.com/crossplane/provider-azure/pkg/chain"
+	azureclients "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/compute"
+	"github.com/crossplane/provider-azure/pkg/concurrency"
+	"github.com/crossplane/provider-azure/pkg/credentials"
+	"github.com/crossplane/provider-azure/pkg/defaults"
+	"github.com/crossplane/provider-azure/pkg/dependency"
+	"github.com/crossplane/provider-azure/pkg/externalname"
+	"github.com/crossplane/provider-azure/pkg/location"
+	"github.com/crossplane/provider-azure/pkg/tenancy"
+)
+
+// Error strings.
+const (
+	errNotDedicatedHostGroup    = "managed resource is not a DedicatedHostGroup"
+	errCreateDedicatedHostGroup = "cannot create dedicated host group"
+	errUpdateDedicatedHostGroup = "cannot update dedicated host group"
+	errGetDedicatedHostGroup    = "cannot get dedicated host group"
+	errDeleteDedicatedHostGroup = "cannot delete dedicated host group"
+)
+
+// Setup adds a controller that reconciles DedicatedHostGroups.
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, sel labels.Selector) error {
+	name := managed.ControllerName(v1alpha3.DedicatedHostGroupGroupKind)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: concurrency.DefaultLimits.Of(v1alpha3.DedicatedHostGroupGroupKind),
+		}).
+		For(&v1alpha3.DedicatedHostGroup{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, credentials.EnqueueRequestsForRotation(mgr.GetClient(), &v1alpha3.DedicatedHostGroupList{}, l.WithValues("controller", name)), builder.OnlyMetadata).
+		Watches(&source.Kind{Type: &azurev1alpha3.ResourceGroup{}}, dependency.EnqueueRequestsForDependents(mgr.GetClient(), &v1alpha3.DedicatedHostGroupList{}, l.WithValues("controller", name)), builder.WithPredicates(dependency.BecameAvailable())).
+		Complete(chain.NewReconciler(mgr, resource.ManagedKind(v1alpha3.DedicatedHostGroupGroupVersionKind),
+			managed.NewReconciler(mgr,
+				resource.ManagedKind(v1alpha3.DedicatedHostGroupGroupVersionKind),
+				managed.WithConnectionPublishers(),
+				managed.WithExternalConnecter(chain.NewConnecter(mgr, v1alpha3.DedicatedHostGroupGroupKind, &connecter{client: mgr.GetClient()})),
+				managed.WithInitializers(managed.NewNameAsExternalName(mgr.GetClient()), externalname.NewInitializer(mgr.GetClient()), tenancy.NewDefaultProviderInitializer(mgr.GetClient()), location.NewInitializer(mgr.GetClient()), defaults.NewInitializer(mgr.GetClient())),
+				managed.WithReferenceResolver(dependency.NewReferenceResolver(mgr.GetClient())),
+				managed.WithLogger(l.WithValues("controller", name)),
+				managed.WithRecorder(recorder)),
+			sel))
+}
+
+type connecter struct {
+	client client.Client
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	g, ok := mg.(*v1alpha3.DedicatedHostGroup)
+	if !ok {
+		return nil, errors.New(errNotDedicatedHostGroup)
+	}
+	creds, auth, err := azureclients.GetAuthInfo(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	cl := azurecompute.NewDedicatedHostGroupsClientWithBaseURI(azureclients.BaseURI(creds), azureclients.SubscriptionID(creds, g.Spec.ForProvider.SubscriptionID))
+	cl.Authorizer = auth
+	tp, err := azureclients.TagPolicyOf(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &external{client: cl, tagPolicy: tp}, nil
+}
+
+type external struct {
+	client    computeapi.DedicatedHostGroupsClientAPI
+	tagPolicy v1beta1.TagPolicy
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	g, ok := mg.(*v1alpha3.DedicatedHostGroup)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotDedicatedHostGroup)
+	}
+
+	az, err := e.client.Get(ctx, g.Spec.ForProvider.ResourceGroupName, meta.GetExternalName(g))
+	if azureclients.IsNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetDedicatedHostGroup)
+	}
+
+	current := g.Spec.ForProvider.DeepCopy()
+	compute.LateInitializeDedicatedHostGroup(&g.Spec.ForProvider, az, e.tagPolicy)
+
+	g.Status.AtProvider = compute.GenerateDedicatedHostGroupObservation(az)
+	g.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        !compute.DedicatedHostGroupNeedsUpdate(g.Spec.ForProvider, az),
+		ResourceLateInitialized: !cmp.Equal(current, &g.Spec.ForProvider),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	g, ok := mg.(*v1alpha3.DedicatedHostGroup)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotDedicatedHostGroup)
+	}
+
+	g.SetConditions(xpv1.Creating())
+	_, err := e.client.CreateOrUpdate(ctx, g.Spec.ForProvider.ResourceGroupName, meta.GetExternalName(g), compute.NewDedicatedHostGroup(g.Spec.ForProvider))
+	return managed.ExternalCreation{}, errors.Wrap(err, errCreateDedicatedHostGroup)
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	g, ok := mg.(*v1alpha3.DedicatedHostGroup)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotDedicatedHostGroup)
+	}
+
+	_, err := e.client.Update(ctx, g.Spec.ForProvider.ResourceGroupName, meta.GetExternalName(g), compute.NewDedicatedHostGroupUpdate(g.Spec.ForProvider))
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateDedicatedHostGroup)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	g, ok := mg.(*v1alpha3.DedicatedHostGroup)
+	if !ok {
+		return errors.New(errNotDedicatedHostGroup)
+	}
+
+	g.SetConditions(xpv1.Deleting())
+	_, err := e.client.Delete(ctx, g.Spec.ForProvider.ResourceGroupName, meta.GetExternalName(g))
+	return errors.Wrap(resource.Ignore(azureclients.IsNotFound, err), errDeleteDedicatedHostGroup)
+}
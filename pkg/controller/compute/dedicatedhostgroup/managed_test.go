@@ -0,0 +1,315 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dedicatedhostgroup
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-07-01/compute"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/compute/v1alpha3"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/compute/fake"
+)
+
+const (
+	name         = "coolGroup"
+	testLocation = "westus2"
+	id           = "coolID"
+	hostID       = "coolHostID"
+)
+
+var errBoom = errors.New("boom")
+
+type groupModifier func(*v1alpha3.DedicatedHostGroup)
+
+func withConditions(c ...xpv1.Condition) groupModifier {
+	return func(r *v1alpha3.DedicatedHostGroup) { r.Status.ConditionedStatus.Conditions = c }
+}
+
+func withZones(z ...string) groupModifier {
+	return func(r *v1alpha3.DedicatedHostGroup) { r.Spec.ForProvider.Zones = z }
+}
+
+func withTags(t map[string]string) groupModifier {
+	return func(r *v1alpha3.DedicatedHostGroup) { r.Spec.ForProvider.Tags = t }
+}
+
+func withObservation(o v1alpha3.DedicatedHostGroupObservation) groupModifier {
+	return func(r *v1alpha3.DedicatedHostGroup) { r.Status.AtProvider = o }
+}
+
+func group(m ...groupModifier) *v1alpha3.DedicatedHostGroup {
+	r := &v1alpha3.DedicatedHostGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1alpha3.DedicatedHostGroupSpec{
+			ForProvider: v1alpha3.DedicatedHostGroupParameters{
+				ResourceGroupName:        "coolRG",
+				Location:                 testLocation,
+				PlatformFaultDomainCount: 2,
+			},
+		},
+	}
+	meta.SetExternalName(r, name)
+	for _, fn := range m {
+		fn(r)
+	}
+	return r
+}
+
+func observed(tags map[string]string) compute.DedicatedHostGroup {
+	return compute.DedicatedHostGroup{
+		ID:       azure.ToStringPtr(id),
+		Location: azure.ToStringPtr(testLocation),
+		Zones:    &[]string{"1"},
+		Tags:     azure.ToStringPtrMap(tags),
+		DedicatedHostGroupProperties: &compute.DedicatedHostGroupProperties{
+			PlatformFaultDomainCount: azure.ToInt32Ptr(2),
+			Hosts:                    &[]compute.SubResourceReadOnly{{ID: azure.ToStringPtr(hostID)}},
+		},
+	}
+}
+
+var _ managed.ExternalClient = &external{}
+var _ managed.ExternalConnecter = &connecter{}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	obs := v1alpha3.DedicatedHostGroupObservation{ID: id, Hosts: []string{hostID}}
+
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   want
+	}{
+		"NotDedicatedHostGroup": {
+			reason: "An error should be returned if the managed resource is not a DedicatedHostGroup",
+			e:      &external{client: &fake.MockDedicatedHostGroupsClient{}},
+			mg:     &v1alpha3.DedicatedHost{},
+			want:   want{mg: &v1alpha3.DedicatedHost{}, err: errors.New(errNotDedicatedHostGroup)},
+		},
+		"NotFound": {
+			reason: "A dedicated host group that is not found should not exist",
+			e: &external{client: &fake.MockDedicatedHostGroupsClient{
+				MockGet: func(_ context.Context, _, _ string) (compute.DedicatedHostGroup, error) {
+					return compute.DedicatedHostGroup{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			}},
+			mg:   group(),
+			want: want{mg: group()},
+		},
+		"GetFailed": {
+			reason: "Errors getting the dedicated host group should be returned",
+			e: &external{client: &fake.MockDedicatedHostGroupsClient{
+				MockGet: func(_ context.Context, _, _ string) (compute.DedicatedHostGroup, error) {
+					return compute.DedicatedHostGroup{}, errBoom
+				},
+			}},
+			mg:   group(),
+			want: want{mg: group(), err: errors.Wrap(errBoom, errGetDedicatedHostGroup)},
+		},
+		"LateInitialized": {
+			reason: "The zones and tags of the dedicated host group should be late initialized, and its hosts observed",
+			e: &external{client: &fake.MockDedicatedHostGroupsClient{
+				MockGet: func(_ context.Context, _, _ string) (compute.DedicatedHostGroup, error) {
+					return observed(map[string]string{"team": "cool"}), nil
+				},
+			}},
+			mg: group(),
+			want: want{
+				mg: group(
+					withZones("1"),
+					withTags(map[string]string{"team": "cool"}),
+					withObservation(obs),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true},
+			},
+		},
+		"NeedsUpdate": {
+			reason: "A dedicated host group whose tags differ from the spec should need an update",
+			e: &external{client: &fake.MockDedicatedHostGroupsClient{
+				MockGet: func(_ context.Context, _, _ string) (compute.DedicatedHostGroup, error) {
+					return observed(map[string]string{"team": "uncool"}), nil
+				},
+			}},
+			mg: group(withZones("1"), withTags(map[string]string{"team": "cool"})),
+			want: want{
+				mg: group(
+					withZones("1"),
+					withTags(map[string]string{"team": "cool"}),
+					withObservation(obs),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o, err := tc.e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want managed, +got managed:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   error
+	}{
+		"Successful": {
+			reason: "The dedicated host group should be created with the fault domain count of the spec",
+			e: &external{client: &fake.MockDedicatedHostGroupsClient{
+				MockCreateOrUpdate: func(_ context.Context, _, _ string, p compute.DedicatedHostGroup) (compute.DedicatedHostGroup, error) {
+					if diff := cmp.Diff(2, azure.ToInt(p.PlatformFaultDomainCount)); diff != "" {
+						t.Errorf("CreateOrUpdate(...): -want fault domains, +got fault domains:\n%s", diff)
+					}
+					return compute.DedicatedHostGroup{}, nil
+				},
+			}},
+			mg: group(),
+		},
+		"Failed": {
+			reason: "Errors creating the dedicated host group should be returned",
+			e: &external{client: &fake.MockDedicatedHostGroupsClient{
+				MockCreateOrUpdate: func(_ context.Context, _, _ string, _ compute.DedicatedHostGroup) (compute.DedicatedHostGroup, error) {
+					return compute.DedicatedHostGroup{}, errBoom
+				},
+			}},
+			mg:   group(),
+			want: errors.Wrap(errBoom, errCreateDedicatedHostGroup),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   error
+	}{
+		"Successful": {
+			reason: "The tags of the dedicated host group should be updated",
+			e: &external{client: &fake.MockDedicatedHostGroupsClient{
+				MockUpdate: func(_ context.Context, _, _ string, p compute.DedicatedHostGroupUpdate) (compute.DedicatedHostGroup, error) {
+					if diff := cmp.Diff(azure.ToStringPtrMap(map[string]string{"team": "cool"}), p.Tags); diff != "" {
+						t.Errorf("Update(...): -want tags, +got tags:\n%s", diff)
+					}
+					return compute.DedicatedHostGroup{}, nil
+				},
+			}},
+			mg: group(withTags(map[string]string{"team": "cool"})),
+		},
+		"Failed": {
+			reason: "Errors updating the dedicated host group should be returned",
+			e: &external{client: &fake.MockDedicatedHostGroupsClient{
+				MockUpdate: func(_ context.Context, _, _ string, _ compute.DedicatedHostGroupUpdate) (compute.DedicatedHostGroup, error) {
+					return compute.DedicatedHostGroup{}, errBoom
+				},
+			}},
+			mg:   group(),
+			want: errors.Wrap(errBoom, errUpdateDedicatedHostGroup),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   error
+	}{
+		"NotFound": {
+			reason: "A dedicated host group that is already gone should be deleted",
+			e: &external{client: &fake.MockDedicatedHostGroupsClient{
+				MockDelete: func(_ context.Context, _, _ string) (autorest.Response, error) {
+					return autorest.Response{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			}},
+			mg: group(),
+		},
+		"Failed": {
+			reason: "Errors deleting the dedicated host group should be returned",
+			e: &external{client: &fake.MockDedicatedHostGroupsClient{
+				MockDelete: func(_ context.Context, _, _ string) (autorest.Response, error) {
+					return autorest.Response{}, errBoom
+				},
+			}},
+			mg:   group(),
+			want: errors.Wrap(errBoom, errDeleteDedicatedHostGroup),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,239 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imagetemplate
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-azure/apis/compute/v1alpha3"
+	azurev1alpha3 "github.com/crossplane/provider-azure/apis/v1alpha3"
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+	"github.com/crossplane/provider-azure/pkg/chain"
+	azureclients "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/compute"
+	"github.com/crossplane/provider-azure/pkg/concurrency"
+	"github.com/crossplane/provider-azure/pkg/credentials"
+	"github.com/crossplane/provider-azure/pkg/defaults"
+	"github.com/crossplane/provider-azure/pkg/dependency"
+	"github.com/crossplane/provider-azure/pkg/externalname"
+	"github.com/crossplane/provider-azure/pkg/location"
+	"github.com/crossplane/provider-azure/pkg/tenancy"
+)
+
+// Error strings.
+const (
+	errNotImageTemplate      = "managed resource is not an ImageTemplate"
+	errCreateImageTemplate   = "cannot create image template"
+	errUpdateImageTemplate   = "cannot update image template"
+	errGetImageTemplate      = "cannot get image template"
+	errDeleteImageTemplate   = "cannot delete image template"
+	errListRunOutputs        = "cannot list run outputs of image template"
+	errBuildImageTemplate    = "cannot start build of image template"
+	errGetConfigMap          = "cannot get ConfigMap %s/%s of customizer %d"
+	errConfigMapKeyNotExists = "ConfigMap %s/%s of customizer %d has no key %s"
+)
+
+// Setup adds a controller that reconciles ImageTemplates.
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, sel labels.Selector) error {
+	name := managed.ControllerName(v1alpha3.ImageTemplateGroupKind)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: concurrency.DefaultLimits.Of(v1alpha3.ImageTemplateGroupKind),
+		}).
+		For(&v1alpha3.ImageTemplate{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, credentials.EnqueueRequestsForRotation(mgr.GetClient(), &v1alpha3.ImageTemplateList{}, l.WithValues("controller", name)), builder.OnlyMetadata).
+		Watches(&source.Kind{Type: &azurev1alpha3.ResourceGroup{}}, dependency.EnqueueRequestsForDependents(mgr.GetClient(), &v1alpha3.ImageTemplateList{}, l.WithValues("controller", name)), builder.WithPredicates(dependency.BecameAvailable())).
+		Complete(chain.NewReconciler(mgr, resource.ManagedKind(v1alpha3.ImageTemplateGroupVersionKind),
+			managed.NewReconciler(mgr,
+				resource.ManagedKind(v1alpha3.ImageTemplateGroupVersionKind),
+				managed.WithConnectionPublishers(),
+				managed.WithExternalConnecter(chain.NewConnecter(mgr, v1alpha3.ImageTemplateGroupKind, &connecter{client: mgr.GetClient()})),
+				managed.WithInitializers(managed.NewNameAsExternalName(mgr.GetClient()), externalname.NewInitializer(mgr.GetClient()), tenancy.NewDefaultProviderInitializer(mgr.GetClient()), location.NewInitializer(mgr.GetClient()), defaults.NewInitializer(mgr.GetClient())),
+				managed.WithReferenceResolver(dependency.NewReferenceResolver(mgr.GetClient())),
+				managed.WithLogger(l.WithValues("controller", name)),
+				managed.WithRecorder(recorder)),
+			sel))
+}
+
+type connecter struct {
+	client client.Client
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	t, ok := mg.(*v1alpha3.ImageTemplate)
+	if !ok {
+		return nil, errors.New(errNotImageTemplate)
+	}
+	creds, auth, err := azureclients.GetAuthInfo(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	cl := compute.NewImageTemplatesClientWithBaseURI(azureclients.BaseURI(creds), azureclients.SubscriptionID(creds, t.Spec.ForProvider.SubscriptionID))
+	cl.Authorizer = auth
+	tp, err := azureclients.TagPolicyOf(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &external{kube: c.client, client: cl, tagPolicy: tp}, nil
+}
+
+type external struct {
+	kube      client.Client
+	client    compute.ImageTemplatesAPI
+	tagPolicy v1beta1.TagPolicy
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	t, ok := mg.(*v1alpha3.ImageTemplate)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotImageTemplate)
+	}
+
+	p := t.Spec.ForProvider
+	az, err := e.client.Get(ctx, p.ResourceGroupName, meta.GetExternalName(t))
+	if azureclients.IsNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetImageTemplate)
+	}
+
+	var outputs []compute.RunOutput
+	if az.Properties != nil && azureclients.ToString(az.Properties.ProvisioningState) == compute.ProvisioningStateSucceeded {
+		if outputs, err = e.client.ListRunOutputs(ctx, p.ResourceGroupName, meta.GetExternalName(t)); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errListRunOutputs)
+		}
+	}
+
+	current := t.Spec.ForProvider.DeepCopy()
+	compute.LateInitializeImageTemplate(&t.Spec.ForProvider, az, e.tagPolicy)
+
+	// Azure does not report which builds were started on behalf of the
+	// build annotation, so the last one is carried over.
+	last := t.Status.AtProvider.LastBuild
+	t.Status.AtProvider = compute.GenerateImageTemplateObservation(az, outputs)
+	t.Status.AtProvider.LastBuild = last
+	switch t.Status.AtProvider.ProvisioningState {
+	case compute.ProvisioningStateSucceeded:
+		t.SetConditions(xpv1.Available())
+	case compute.ProvisioningStateFailed:
+		t.SetConditions(xpv1.Unavailable())
+	default:
+		t.SetConditions(xpv1.Creating())
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        !compute.ImageTemplateNeedsUpdate(t.Spec.ForProvider, az) && !compute.ImageTemplateNeedsBuild(t),
+		ResourceLateInitialized: !cmp.Equal(current, &t.Spec.ForProvider),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	t, ok := mg.(*v1alpha3.ImageTemplate)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotImageTemplate)
+	}
+
+	t.SetConditions(xpv1.Creating())
+	p, err := e.inline(ctx, t.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateImageTemplate)
+	}
+	err = e.client.CreateOrUpdate(ctx, p.ResourceGroupName, meta.GetExternalName(t), compute.NewImageTemplate(p))
+	return managed.ExternalCreation{}, errors.Wrap(err, errCreateImageTemplate)
+}
+
+// Update the tags of the image template, or start a build of its image if
+// its build annotation changed. A build is started before the tags are
+// updated, which happens at the next reconcile.
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	t, ok := mg.(*v1alpha3.ImageTemplate)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotImageTemplate)
+	}
+
+	p := t.Spec.ForProvider
+	if compute.ImageTemplateNeedsBuild(t) {
+		if err := e.client.Run(ctx, p.ResourceGroupName, meta.GetExternalName(t)); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errBuildImageTemplate)
+		}
+		t.Status.AtProvider.LastBuild = t.GetAnnotations()[compute.AnnotationKeyBuild]
+		return managed.ExternalUpdate{}, nil
+	}
+	err := e.client.UpdateTags(ctx, p.ResourceGroupName, meta.GetExternalName(t), azureclients.ToStringPtrMap(p.Tags))
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateImageTemplate)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	t, ok := mg.(*v1alpha3.ImageTemplate)
+	if !ok {
+		return errors.New(errNotImageTemplate)
+	}
+
+	t.SetConditions(xpv1.Deleting())
+	err := e.client.Delete(ctx, t.Spec.ForProvider.ResourceGroupName, meta.GetExternalName(t))
+	return errors.Wrap(resource.Ignore(azureclients.IsNotFound, err), errDeleteImageTemplate)
+}
+
+// inline returns a copy of the supplied parameters whose customizers run the
+// lines of the ConfigMap keys they select, after their other inline
+// commands.
+func (e *external) inline(ctx context.Context, p v1alpha3.ImageTemplateParameters) (v1alpha3.ImageTemplateParameters, error) {
+	out := p.DeepCopy()
+	for i, c := range out.Customizers {
+		ref := c.InlineConfigMapRef
+		if ref == nil {
+			continue
+		}
+		cm := &corev1.ConfigMap{}
+		if err := e.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, cm); err != nil {
+			return p, errors.Wrapf(err, errGetConfigMap, ref.Namespace, ref.Name, i)
+		}
+		v, ok := cm.Data[ref.Key]
+		if !ok {
+			return p, errors.Errorf(errConfigMapKeyNotExists, ref.Namespace, ref.Name, i, ref.Key)
+		}
+		out.Customizers[i].Inline = append(out.Customizers[i].Inline, strings.Split(strings.TrimRight(v, "\n"), "\n")...)
+	}
+	return *out, nil
+}
@@ -0,0 +1,348 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imagetemplate
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/compute/v1alpha3"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/compute"
+	"github.com/crossplane/provider-azure/pkg/clients/compute/fake"
+)
+
+const (
+	name         = "coolTemplate"
+	testLocation = "westus2"
+)
+
+var errBoom = errors.New("boom")
+
+type templateModifier func(*v1alpha3.ImageTemplate)
+
+func withConditions(c ...xpv1.Condition) templateModifier {
+	return func(t *v1alpha3.ImageTemplate) { t.Status.ConditionedStatus.Conditions = c }
+}
+
+func withBuild(b string) templateModifier {
+	return func(t *v1alpha3.ImageTemplate) {
+		meta.AddAnnotations(t, map[string]string{compute.AnnotationKeyBuild: b})
+	}
+}
+
+func withObservation(o v1alpha3.ImageTemplateObservation) templateModifier {
+	return func(t *v1alpha3.ImageTemplate) { t.Status.AtProvider = o }
+}
+
+func withCustomizers(c ...v1alpha3.ImageTemplateCustomizer) templateModifier {
+	return func(t *v1alpha3.ImageTemplate) { t.Spec.ForProvider.Customizers = c }
+}
+
+func template(m ...templateModifier) *v1alpha3.ImageTemplate {
+	t := &v1alpha3.ImageTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1alpha3.ImageTemplateSpec{
+			ForProvider: v1alpha3.ImageTemplateParameters{
+				ResourceGroupName: "coolRG",
+				Location:          testLocation,
+				IdentityID:        "coolIdentity",
+				Source:            v1alpha3.ImageTemplateSource{Type: v1alpha3.ImageSourceManagedImage, ImageID: azure.ToStringPtr("coolImage")},
+				Distributors: []v1alpha3.ImageTemplateDistributor{{
+					Type:           v1alpha3.ImageDistributorSharedImage,
+					RunOutputName:  "coolOutput",
+					GalleryImageID: azure.ToStringPtr("coolGalleryImage"),
+				}},
+			},
+		},
+	}
+	meta.SetExternalName(t, name)
+	for _, fn := range m {
+		fn(t)
+	}
+	return t
+}
+
+func observed(state string) compute.ImageTemplate {
+	return compute.ImageTemplate{
+		Location:   azure.ToStringPtr(testLocation),
+		Properties: &compute.ImageTemplateProperties{ProvisioningState: azure.ToStringPtr(state)},
+	}
+}
+
+var _ managed.ExternalClient = &external{}
+var _ managed.ExternalConnecter = &connecter{}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	outputs := func(_ context.Context, _, _ string) ([]compute.RunOutput, error) {
+		return []compute.RunOutput{{
+			Name:       azure.ToStringPtr("coolOutput"),
+			Properties: &compute.RunOutputProperties{ArtifactID: azure.ToStringPtr("coolImageVersion")},
+		}}, nil
+	}
+	runOutputs := []v1alpha3.ImageTemplateRunOutput{{Name: "coolOutput", ArtifactID: "coolImageVersion"}}
+
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   want
+	}{
+		"NotImageTemplate": {
+			reason: "An error should be returned if the managed resource is not an ImageTemplate",
+			e:      &external{client: &fake.MockImageTemplatesClient{}},
+			mg:     &v1alpha3.CapacityReservation{},
+			want:   want{mg: &v1alpha3.CapacityReservation{}, err: errors.New(errNotImageTemplate)},
+		},
+		"NotFound": {
+			reason: "An image template that is not found should not exist",
+			e: &external{client: &fake.MockImageTemplatesClient{
+				MockGet: func(_ context.Context, _, _ string) (compute.ImageTemplate, error) {
+					return compute.ImageTemplate{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			}},
+			mg:   template(),
+			want: want{mg: template()},
+		},
+		"GetFailed": {
+			reason: "Errors getting the image template should be returned",
+			e: &external{client: &fake.MockImageTemplatesClient{
+				MockGet: func(_ context.Context, _, _ string) (compute.ImageTemplate, error) {
+					return compute.ImageTemplate{}, errBoom
+				},
+			}},
+			mg:   template(),
+			want: want{mg: template(), err: errors.Wrap(errBoom, errGetImageTemplate)},
+		},
+		"Provisioning": {
+			reason: "An image template that is still being provisioned should be creating",
+			e: &external{client: &fake.MockImageTemplatesClient{
+				MockGet: func(_ context.Context, _, _ string) (compute.ImageTemplate, error) {
+					return observed("Creating"), nil
+				},
+			}},
+			mg: template(),
+			want: want{
+				mg: template(withObservation(v1alpha3.ImageTemplateObservation{ProvisioningState: "Creating"}), withConditions(xpv1.Creating())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"ListRunOutputsFailed": {
+			reason: "Errors listing the run outputs of the image template should be returned",
+			e: &external{client: &fake.MockImageTemplatesClient{
+				MockGet: func(_ context.Context, _, _ string) (compute.ImageTemplate, error) {
+					return observed(compute.ProvisioningStateSucceeded), nil
+				},
+				MockListRunOutputs: func(_ context.Context, _, _ string) ([]compute.RunOutput, error) {
+					return nil, errBoom
+				},
+			}},
+			mg:   template(),
+			want: want{mg: template(), err: errors.Wrap(errBoom, errListRunOutputs)},
+		},
+		"Built": {
+			reason: "An image template that was built for its build annotation should report its run outputs and be up to date",
+			e: &external{client: &fake.MockImageTemplatesClient{
+				MockGet: func(_ context.Context, _, _ string) (compute.ImageTemplate, error) {
+					return observed(compute.ProvisioningStateSucceeded), nil
+				},
+				MockListRunOutputs: outputs,
+			}},
+			mg: template(withBuild("v1"), withObservation(v1alpha3.ImageTemplateObservation{LastBuild: "v1"})),
+			want: want{
+				mg: template(withBuild("v1"), withConditions(xpv1.Available()), withObservation(v1alpha3.ImageTemplateObservation{
+					ProvisioningState: compute.ProvisioningStateSucceeded,
+					LastBuild:         "v1",
+					RunOutputs:        runOutputs,
+				})),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"BuildChanged": {
+			reason: "An image template whose build annotation changed since its last build should need an update",
+			e: &external{client: &fake.MockImageTemplatesClient{
+				MockGet: func(_ context.Context, _, _ string) (compute.ImageTemplate, error) {
+					return observed(compute.ProvisioningStateSucceeded), nil
+				},
+				MockListRunOutputs: outputs,
+			}},
+			mg: template(withBuild("v2"), withObservation(v1alpha3.ImageTemplateObservation{LastBuild: "v1"})),
+			want: want{
+				mg: template(withBuild("v2"), withConditions(xpv1.Available()), withObservation(v1alpha3.ImageTemplateObservation{
+					ProvisioningState: compute.ProvisioningStateSucceeded,
+					LastBuild:         "v1",
+					RunOutputs:        runOutputs,
+				})),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o, err := tc.e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want managed, +got managed:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	script := v1alpha3.ImageTemplateCustomizer{
+		Type:               v1alpha3.ImageCustomizerShell,
+		Inline:             []string{"set -e"},
+		InlineConfigMapRef: &v1alpha3.ConfigMapKeySelector{Namespace: "coolNamespace", Name: "coolScripts", Key: "setup.sh"},
+	}
+
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   error
+	}{
+		"InlineConfigMap": {
+			reason: "The lines of the ConfigMap key a customizer selects should be appended to its inline commands",
+			e: &external{
+				kube: &test.MockClient{
+					MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+						obj.(*corev1.ConfigMap).Data = map[string]string{"setup.sh": "apt-get update\napt-get upgrade -y\n"}
+						return nil
+					},
+				},
+				client: &fake.MockImageTemplatesClient{
+					MockCreateOrUpdate: func(_ context.Context, _, _ string, it compute.ImageTemplate) error {
+						want := &[]string{"set -e", "apt-get update", "apt-get upgrade -y"}
+						if diff := cmp.Diff(want, (*it.Properties.Customize)[0].Inline); diff != "" {
+							t.Errorf("Create(...): -want inline, +got inline:\n%s", diff)
+						}
+						return nil
+					},
+				},
+			},
+			mg: template(withCustomizers(script)),
+		},
+		"ConfigMapKeyNotExists": {
+			reason: "An error should be returned if the ConfigMap a customizer selects has no such key",
+			e: &external{
+				kube:   &test.MockClient{MockGet: test.NewMockGetFn(nil)},
+				client: &fake.MockImageTemplatesClient{},
+			},
+			mg:   template(withCustomizers(script)),
+			want: errors.Wrap(errors.Errorf(errConfigMapKeyNotExists, "coolNamespace", "coolScripts", 0, "setup.sh"), errCreateImageTemplate),
+		},
+		"Failed": {
+			reason: "Errors creating the image template should be returned",
+			e: &external{client: &fake.MockImageTemplatesClient{
+				MockCreateOrUpdate: func(_ context.Context, _, _ string, _ compute.ImageTemplate) error {
+					return errBoom
+				},
+			}},
+			mg:   template(),
+			want: errors.Wrap(errBoom, errCreateImageTemplate),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		err error
+	}
+
+	provisioned := v1alpha3.ImageTemplateObservation{ProvisioningState: compute.ProvisioningStateSucceeded, LastBuild: "v1"}
+	built := v1alpha3.ImageTemplateObservation{ProvisioningState: compute.ProvisioningStateSucceeded, LastBuild: "v2"}
+
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   want
+	}{
+		"Build": {
+			reason: "A build should be started and recorded if the build annotation changed",
+			e: &external{client: &fake.MockImageTemplatesClient{
+				MockRun: func(_ context.Context, _, _ string) error { return nil },
+			}},
+			mg:   template(withBuild("v2"), withObservation(provisioned)),
+			want: want{mg: template(withBuild("v2"), withObservation(built))},
+		},
+		"BuildFailed": {
+			reason: "Errors starting a build should be returned, and the build should not be recorded",
+			e: &external{client: &fake.MockImageTemplatesClient{
+				MockRun: func(_ context.Context, _, _ string) error { return errBoom },
+			}},
+			mg:   template(withBuild("v2"), withObservation(provisioned)),
+			want: want{mg: template(withBuild("v2"), withObservation(provisioned)), err: errors.Wrap(errBoom, errBuildImageTemplate)},
+		},
+		"Tags": {
+			reason: "The tags of the image template should be updated if no build is needed",
+			e: &external{client: &fake.MockImageTemplatesClient{
+				MockUpdateTags: func(_ context.Context, _, _ string, _ map[string]*string) error { return errBoom },
+			}},
+			mg:   template(withBuild("v1"), withObservation(provisioned)),
+			want: want{mg: template(withBuild("v1"), withObservation(provisioned)), err: errors.Wrap(errBoom, errUpdateImageTemplate)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want managed, +got managed:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
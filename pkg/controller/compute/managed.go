@@ -20,12 +20,17 @@ import (
 	"context"
 
 	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
@@ -37,8 +42,20 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/crossplane/provider-azure/apis/compute/v1alpha3"
+	networkv1beta1 "github.com/crossplane/provider-azure/apis/network/v1beta1"
+	azurev1alpha3 "github.com/crossplane/provider-azure/apis/v1alpha3"
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+	"github.com/crossplane/provider-azure/pkg/chain"
 	azure "github.com/crossplane/provider-azure/pkg/clients"
 	"github.com/crossplane/provider-azure/pkg/clients/compute"
+	"github.com/crossplane/provider-azure/pkg/concurrency"
+	"github.com/crossplane/provider-azure/pkg/connection"
+	"github.com/crossplane/provider-azure/pkg/credentials"
+	"github.com/crossplane/provider-azure/pkg/defaults"
+	"github.com/crossplane/provider-azure/pkg/dependency"
+	"github.com/crossplane/provider-azure/pkg/externalname"
+	"github.com/crossplane/provider-azure/pkg/location"
+	"github.com/crossplane/provider-azure/pkg/tenancy"
 )
 
 // Error strings.
@@ -48,25 +65,37 @@ const (
 	errCreateAKSCluster = "cannot create AKSCluster"
 	errGetAKSCluster    = "cannot get AKSCluster"
 	errGetKubeConfig    = "cannot get AKSCluster kubeconfig"
+	errUpdateAKSCluster = "cannot update AKSCluster"
 	errDeleteAKSCluster = "cannot delete AKSCluster"
 )
 
 // SetupAKSCluster adds a controller that reconciles AKSClusters.
-func SetupAKSCluster(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
+func SetupAKSCluster(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, sel labels.Selector) error {
 	name := managed.ControllerName(v1alpha3.AKSClusterGroupKind)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(controller.Options{
-			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: concurrency.DefaultLimits.Of(v1alpha3.AKSClusterGroupKind),
 		}).
 		For(&v1alpha3.AKSCluster{}).
-		Complete(managed.NewReconciler(mgr,
-			resource.ManagedKind(v1alpha3.AKSClusterGroupVersionKind),
-			managed.WithExternalConnecter(&connecter{client: mgr.GetClient()}),
-			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
-			managed.WithLogger(l.WithValues("controller", name)),
-			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+		Watches(&source.Kind{Type: &corev1.Secret{}}, credentials.EnqueueRequestsForRotation(mgr.GetClient(), &v1alpha3.AKSClusterList{}, l.WithValues("controller", name)), builder.OnlyMetadata).
+		Watches(&source.Kind{Type: &azurev1alpha3.ResourceGroup{}}, dependency.EnqueueRequestsForDependents(mgr.GetClient(), &v1alpha3.AKSClusterList{}, l.WithValues("controller", name)), builder.WithPredicates(dependency.BecameAvailable())).
+		Watches(&source.Kind{Type: &networkv1beta1.Subnet{}}, dependency.EnqueueRequestsForDependents(mgr.GetClient(), &v1alpha3.AKSClusterList{}, l.WithValues("controller", name)), builder.WithPredicates(dependency.BecameAvailable())).
+		Complete(chain.NewReconciler(mgr, resource.ManagedKind(v1alpha3.AKSClusterGroupVersionKind),
+			managed.NewReconciler(mgr,
+				resource.ManagedKind(v1alpha3.AKSClusterGroupVersionKind),
+				managed.WithConnectionPublishers(connection.NewNamespacePolicyPublisher(connection.DefaultNamespacePolicy, connection.NewTemplatingPublisher(connection.NewKeyVaultPublisher(mgr.GetClient(), mgr.GetScheme(),
+					managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme()),
+					connection.NewAdditionalNamespacesPublisher(mgr.GetClient(), mgr.GetScheme()))))),
+				managed.WithExternalConnecter(chain.NewConnecter(mgr, v1alpha3.AKSClusterGroupKind, &connecter{client: mgr.GetClient()})),
+				managed.WithInitializers(managed.NewNameAsExternalName(mgr.GetClient()), externalname.NewInitializer(mgr.GetClient()), tenancy.NewDefaultProviderInitializer(mgr.GetClient()), location.NewInitializer(mgr.GetClient()), defaults.NewInitializer(mgr.GetClient()), connection.NewNamespaceInitializer(mgr.GetClient(), connection.DefaultNamespacePolicy)),
+				managed.WithReferenceResolver(dependency.NewReferenceResolver(mgr.GetClient())),
+				managed.WithLogger(l.WithValues("controller", name)),
+				managed.WithRecorder(recorder)),
+			sel))
 }
 
 type connecter struct {
@@ -82,13 +111,18 @@ func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.E
 	if err != nil {
 		return nil, err
 	}
-	return &external{kube: c.client, client: cl, newPasswordFn: password.Generate}, nil
+	tp, err := azure.TagPolicyOf(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &external{kube: c.client, client: cl, newPasswordFn: password.Generate, tagPolicy: tp}, nil
 }
 
 type external struct {
 	kube          client.Client
 	client        compute.AKSClient
 	newPasswordFn func() (password string, err error)
+	tagPolicy     v1beta1.TagPolicy
 }
 
 func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -105,13 +139,26 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.Wrap(err, errGetAKSCluster)
 	}
 
+	current := cr.Spec.AKSClusterParameters.DeepCopy()
+	compute.LateInitialize(&cr.Spec.AKSClusterParameters, c, e.tagPolicy)
+	li := !cmp.Equal(current, &cr.Spec.AKSClusterParameters)
+
 	cr.Status.ProviderID = to.String(c.ID)
 	cr.Status.State = to.String(c.ProvisioningState)
 	cr.Status.Endpoint = to.String(c.Fqdn)
 
+	// A cluster that failed to provision may have left VMs, NICs and disks in
+	// its node resource group. Unless the cluster was ever available we report
+	// that it does not exist, so that it is created again, which makes Azure
+	// retry its provisioning. A cluster that is being deleted is reported as
+	// existing so that it is deleted, and its nodes with it.
+	if cr.Status.State == compute.ProvisioningStateFailed && cr.GetCondition(xpv1.TypeReady).Reason == xpv1.ReasonCreating && !meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
 	if cr.Status.State != "Succeeded" {
-		// AKS clusters are always up to date because we can't yet update them.
-		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+		// AKS clusters can't be updated until they are provisioned.
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: li}, nil
 	}
 
 	kubeconfig, err := e.client.GetKubeConfig(ctx, cr)
@@ -126,11 +173,12 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 
 	cr.SetConditions(xpv1.Available())
 
-	// AKS clusters are always up to date because we can't yet update them.
+	// Tags are the only thing we can yet update of AKS clusters.
 	o := managed.ExternalObservation{
-		ResourceExists:    true,
-		ResourceUpToDate:  true,
-		ConnectionDetails: cd,
+		ResourceExists:          true,
+		ResourceUpToDate:        !azure.TagsNeedUpdate(cr.Spec.Tags, c.Tags),
+		ResourceLateInitialized: li,
+		ConnectionDetails:       cd,
 	}
 	return o, nil
 }
@@ -149,8 +197,12 @@ func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 }
 
 func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
-	// TODO(negz): Support updates.
-	return managed.ExternalUpdate{}, nil
+	cr, ok := mg.(*v1alpha3.AKSCluster)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotAKSCluster)
+	}
+	// TODO(negz): Support updates of more than tags.
+	return managed.ExternalUpdate{}, errors.Wrap(e.client.UpdateManagedClusterTags(ctx, cr), errUpdateAKSCluster)
 }
 
 func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
@@ -21,17 +21,20 @@ import (
 	"net/http"
 	"testing"
 
-	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2018-03-31/containerservice"
+	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2019-02-01/containerservice"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 
 	"github.com/crossplane/provider-azure/apis/compute/v1alpha3"
+	"github.com/crossplane/provider-azure/pkg/clients/compute"
 	"github.com/crossplane/provider-azure/pkg/clients/compute/fake"
 )
 
@@ -55,6 +58,24 @@ func withEndpoint(ep string) modifier {
 	}
 }
 
+func withDNSNamePrefix(p string) modifier {
+	return func(c *v1alpha3.AKSCluster) {
+		c.Spec.DNSNamePrefix = p
+	}
+}
+
+func withDeletionTimestamp(t metav1.Time) modifier {
+	return func(c *v1alpha3.AKSCluster) {
+		c.SetDeletionTimestamp(&t)
+	}
+}
+
+func withConditions(c ...xpv1.Condition) modifier {
+	return func(ac *v1alpha3.AKSCluster) {
+		ac.SetConditions(c...)
+	}
+}
+
 func aksCluster(m ...modifier) *v1alpha3.AKSCluster {
 	ac := &v1alpha3.AKSCluster{}
 
@@ -66,6 +87,8 @@ func aksCluster(m ...modifier) *v1alpha3.AKSCluster {
 }
 
 func TestObserve(t *testing.T) {
+	now := metav1.Now()
+
 	errBoom := errors.New("boom")
 	id := "koolAD"
 	stateSucceeded := "Succeeded"
@@ -157,6 +180,96 @@ func TestObserve(t *testing.T) {
 				),
 			},
 		},
+		"NotReadyLateInitialized": {
+			e: &external{
+				client: fake.AKSClient{
+					MockGetManagedCluster: func(_ context.Context, _ *v1alpha3.AKSCluster) (containerservice.ManagedCluster, error) {
+						return containerservice.ManagedCluster{ManagedClusterProperties: &containerservice.ManagedClusterProperties{
+							ProvisioningState: to.StringPtr(stateWat),
+							DNSPrefix:         to.StringPtr("cool"),
+						}}, nil
+					},
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				mg:  aksCluster(),
+			},
+			want: want{
+				eo: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true},
+				mg: aksCluster(
+					withDNSNamePrefix("cool"),
+					withState(stateWat),
+				),
+			},
+		},
+		"ProvisioningFailed": {
+			e: &external{
+				client: fake.AKSClient{
+					MockGetManagedCluster: func(_ context.Context, _ *v1alpha3.AKSCluster) (containerservice.ManagedCluster, error) {
+						return containerservice.ManagedCluster{ManagedClusterProperties: &containerservice.ManagedClusterProperties{
+							ProvisioningState: to.StringPtr(compute.ProvisioningStateFailed),
+						}}, nil
+					},
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				mg:  aksCluster(withConditions(xpv1.Creating())),
+			},
+			want: want{
+				eo: managed.ExternalObservation{ResourceExists: false},
+				mg: aksCluster(
+					withConditions(xpv1.Creating()),
+					withState(compute.ProvisioningStateFailed),
+				),
+			},
+		},
+		"ProvisioningFailedDeleted": {
+			e: &external{
+				client: fake.AKSClient{
+					MockGetManagedCluster: func(_ context.Context, _ *v1alpha3.AKSCluster) (containerservice.ManagedCluster, error) {
+						return containerservice.ManagedCluster{ManagedClusterProperties: &containerservice.ManagedClusterProperties{
+							ProvisioningState: to.StringPtr(compute.ProvisioningStateFailed),
+						}}, nil
+					},
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				mg:  aksCluster(withConditions(xpv1.Creating()), withDeletionTimestamp(now)),
+			},
+			want: want{
+				eo: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				mg: aksCluster(
+					withConditions(xpv1.Creating()),
+					withDeletionTimestamp(now),
+					withState(compute.ProvisioningStateFailed),
+				),
+			},
+		},
+		"FailedAfterAvailable": {
+			e: &external{
+				client: fake.AKSClient{
+					MockGetManagedCluster: func(_ context.Context, _ *v1alpha3.AKSCluster) (containerservice.ManagedCluster, error) {
+						return containerservice.ManagedCluster{ManagedClusterProperties: &containerservice.ManagedClusterProperties{
+							ProvisioningState: to.StringPtr(compute.ProvisioningStateFailed),
+						}}, nil
+					},
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				mg:  aksCluster(withConditions(xpv1.Available())),
+			},
+			want: want{
+				eo: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				mg: aksCluster(
+					withConditions(xpv1.Available()),
+					withState(compute.ProvisioningStateFailed),
+				),
+			},
+		},
 		"ErrGetKubeConfig": {
 			e: &external{
 				client: fake.AKSClient{
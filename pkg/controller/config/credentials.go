@@ -0,0 +1,126 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+const (
+	credentialsReconcileTimeout = 1 * time.Minute
+	credentialsPollInterval     = 10 * time.Minute
+)
+
+// A ValidatorFn returns an error if the supplied credentials cannot
+// authenticate to the Azure API.
+type ValidatorFn func(ctx context.Context, creds map[string]string, auth autorest.Authorizer) error
+
+// A ResolverFn returns the credentials method of the supplied ProviderConfig.
+type ResolverFn func(ctx context.Context, c client.Client, pc *v1beta1.ProviderConfig) (v1beta1.CredentialsMethod, error)
+
+// SetupCredentials adds a controller that reports whether the credentials of
+// ProviderConfigs are valid in their CredentialsValid condition, and which
+// credentials method they use in their CredentialsMethod condition.
+func SetupCredentials(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
+	name := "credentials/" + strings.ToLower(v1beta1.ProviderConfigGroupKind)
+
+	r := &CredentialsReconciler{
+		client:   mgr.GetClient(),
+		validate: azure.ValidateCredentials,
+		resolve:  azure.DefaultMethodResolver.Resolve,
+		log:      l.WithValues("controller", name),
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1beta1.ProviderConfig{}).
+		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		Complete(r)
+}
+
+// A CredentialsReconciler periodically makes a cheap authenticated call to the
+// Azure API with the credentials of a ProviderConfig, and records its error,
+// if any, in the CredentialsValid condition of the ProviderConfig. This tells
+// a bad credentials secret apart from a bad managed resource spec. It also
+// records which of the credentials methods of the ProviderConfig is used in
+// its CredentialsMethod condition.
+type CredentialsReconciler struct {
+	client   client.Client
+	validate ValidatorFn
+	resolve  ResolverFn
+
+	log logging.Logger
+}
+
+// Reconcile the CredentialsValid and CredentialsMethod conditions of a
+// ProviderConfig.
+func (r *CredentialsReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	log := r.log.WithValues("request", req)
+	log.Debug("Reconciling")
+
+	ctx, cancel := context.WithTimeout(ctx, credentialsReconcileTimeout)
+	defer cancel()
+
+	pc := &v1beta1.ProviderConfig{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: req.Name}, pc); err != nil {
+		return reconcile.Result{}, errors.Wrap(resource.IgnoreNotFound(err), errGetPC)
+	}
+
+	m, err := r.resolve(ctx, r.client, pc)
+	mc := v1beta1.CredentialsMethodActive(m)
+	if err != nil {
+		mc = v1beta1.CredentialsMethodUnavailable(err)
+	}
+
+	c := v1beta1.CredentialsValid()
+	creds, auth, err := azure.GetProviderConfigAuthInfo(ctx, r.client, pc)
+	if err == nil {
+		err = r.validate(ctx, creds, auth)
+	}
+	if err != nil {
+		log.Debug("Credentials are invalid", "error", err)
+		c = v1beta1.CredentialsInvalid(err)
+	}
+
+	if pc.Status.GetCondition(v1beta1.TypeCredentialsValid).Equal(c) && pc.Status.GetCondition(v1beta1.TypeCredentialsMethod).Equal(mc) {
+		return reconcile.Result{RequeueAfter: credentialsPollInterval}, nil
+	}
+	pc.Status.SetConditions(c, mc)
+	return reconcile.Result{RequeueAfter: credentialsPollInterval}, errors.Wrap(r.client.Status().Update(ctx, pc), errUpdateStatus)
+}
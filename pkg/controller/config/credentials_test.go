@@ -0,0 +1,214 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+)
+
+func TestCredentialsReconcile(t *testing.T) {
+	errBoom := errors.New("boom")
+	errUnauthorized := autorest.NewErrorWithError(errBoom, "resources.GroupsClient", "List", &http.Response{StatusCode: http.StatusUnauthorized}, "Failure responding to request")
+	errUnreachable := &net.OpError{Op: "dial", Net: "tcp", Err: errBoom}
+	errNoMethod := errors.New("none of credentialsSecretRef, useManagedIdentity, useWorkloadIdentity and useAzureCLI was supplied")
+	mi := &v1beta1.ManagedIdentity{SubscriptionID: "sub"}
+
+	withStatus := func(s v1beta1.ProviderConfigSpec, c ...xpv1.Condition) test.ObjectFn {
+		return func(obj client.Object) error {
+			pc := obj.(*v1beta1.ProviderConfig)
+			pc.Spec = s
+			pc.Status.SetConditions(c...)
+			return nil
+		}
+	}
+	resolve := func(m v1beta1.CredentialsMethod, err error) ResolverFn {
+		return func(_ context.Context, _ client.Client, _ *v1beta1.ProviderConfig) (v1beta1.CredentialsMethod, error) {
+			return m, err
+		}
+	}
+	validate := func(err error) ValidatorFn {
+		return func(_ context.Context, _ map[string]string, _ autorest.Authorizer) error { return err }
+	}
+
+	type fields struct {
+		client   client.Client
+		validate ValidatorFn
+		resolve  ResolverFn
+	}
+	type want struct {
+		r          reconcile.Result
+		err        error
+		conditions []xpv1.Condition
+	}
+	cases := map[string]struct {
+		reason string
+		fields fields
+		want   want
+	}{
+		"GetProviderConfigError": {
+			reason: "Errors getting the ProviderConfig should be returned.",
+			fields: fields{
+				client: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			},
+			want: want{err: errors.Wrap(errBoom, errGetPC)},
+		},
+		"ProviderConfigNotFound": {
+			reason: "ProviderConfigs that no longer exist should be ignored.",
+			fields: fields{
+				client: &test.MockClient{MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, ""))},
+			},
+			want: want{},
+		},
+		"Valid": {
+			reason: "Credentials that can authenticate to Azure should be reported as valid.",
+			fields: fields{
+				client: &test.MockClient{
+					MockGet:          test.NewMockGetFn(nil, withStatus(v1beta1.ProviderConfigSpec{UseManagedIdentity: mi})),
+					MockStatusUpdate: test.NewMockStatusUpdateFn(nil),
+				},
+				validate: validate(nil),
+				resolve:  resolve(v1beta1.CredentialsMethodManagedIdentity, nil),
+			},
+			want: want{
+				r:          reconcile.Result{RequeueAfter: credentialsPollInterval},
+				conditions: []xpv1.Condition{v1beta1.CredentialsValid(), v1beta1.CredentialsMethodActive(v1beta1.CredentialsMethodManagedIdentity)},
+			},
+		},
+		"Invalid": {
+			reason: "Credentials that Azure rejects should be reported as invalid, with the error of Azure.",
+			fields: fields{
+				client: &test.MockClient{
+					MockGet:          test.NewMockGetFn(nil, withStatus(v1beta1.ProviderConfigSpec{UseManagedIdentity: mi})),
+					MockStatusUpdate: test.NewMockStatusUpdateFn(nil),
+				},
+				validate: validate(errUnauthorized),
+				resolve:  resolve(v1beta1.CredentialsMethodManagedIdentity, nil),
+			},
+			want: want{
+				r:          reconcile.Result{RequeueAfter: credentialsPollInterval},
+				conditions: []xpv1.Condition{v1beta1.CredentialsInvalid(errUnauthorized), v1beta1.CredentialsMethodActive(v1beta1.CredentialsMethodManagedIdentity)},
+			},
+		},
+		"Unreachable": {
+			reason: "Credentials that cannot be checked because Azure is unreachable should be reported as invalid, with the network error.",
+			fields: fields{
+				client: &test.MockClient{
+					MockGet:          test.NewMockGetFn(nil, withStatus(v1beta1.ProviderConfigSpec{UseManagedIdentity: mi})),
+					MockStatusUpdate: test.NewMockStatusUpdateFn(nil),
+				},
+				validate: validate(errUnreachable),
+				resolve:  resolve(v1beta1.CredentialsMethodManagedIdentity, nil),
+			},
+			want: want{
+				r:          reconcile.Result{RequeueAfter: credentialsPollInterval},
+				conditions: []xpv1.Condition{v1beta1.CredentialsInvalid(errUnreachable), v1beta1.CredentialsMethodActive(v1beta1.CredentialsMethodManagedIdentity)},
+			},
+		},
+		"NoCredentialsMethod": {
+			reason: "ProviderConfigs without a usable credentials method should be reported as such, and their credentials as invalid.",
+			fields: fields{
+				client: &test.MockClient{
+					MockGet:          test.NewMockGetFn(nil, withStatus(v1beta1.ProviderConfigSpec{})),
+					MockStatusUpdate: test.NewMockStatusUpdateFn(nil),
+				},
+				validate: validate(nil),
+				resolve:  resolve("", errBoom),
+			},
+			want: want{
+				r: reconcile.Result{RequeueAfter: credentialsPollInterval},
+				conditions: []xpv1.Condition{
+					v1beta1.CredentialsInvalid(errNoMethod),
+					v1beta1.CredentialsMethodUnavailable(errBoom),
+				},
+			},
+		},
+		"Unchanged": {
+			reason: "The status should not be updated if the conditions have not changed.",
+			fields: fields{
+				client: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, withStatus(v1beta1.ProviderConfigSpec{UseManagedIdentity: mi},
+						v1beta1.CredentialsValid(), v1beta1.CredentialsMethodActive(v1beta1.CredentialsMethodManagedIdentity))),
+				},
+				validate: validate(nil),
+				resolve:  resolve(v1beta1.CredentialsMethodManagedIdentity, nil),
+			},
+			want: want{r: reconcile.Result{RequeueAfter: credentialsPollInterval}},
+		},
+		"UpdateStatusError": {
+			reason: "Errors updating the status should be returned.",
+			fields: fields{
+				client: &test.MockClient{
+					MockGet:          test.NewMockGetFn(nil, withStatus(v1beta1.ProviderConfigSpec{UseManagedIdentity: mi})),
+					MockStatusUpdate: test.NewMockStatusUpdateFn(errBoom),
+				},
+				validate: validate(nil),
+				resolve:  resolve(v1beta1.CredentialsMethodManagedIdentity, nil),
+			},
+			want: want{
+				r:          reconcile.Result{RequeueAfter: credentialsPollInterval},
+				err:        errors.Wrap(errBoom, errUpdateStatus),
+				conditions: []xpv1.Condition{v1beta1.CredentialsValid(), v1beta1.CredentialsMethodActive(v1beta1.CredentialsMethodManagedIdentity)},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var conditions []xpv1.Condition
+			if mc, ok := tc.fields.client.(*test.MockClient); ok && mc.MockStatusUpdate != nil {
+				update := mc.MockStatusUpdate
+				mc.MockStatusUpdate = func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+					conditions = obj.(*v1beta1.ProviderConfig).Status.Conditions
+					return update(ctx, obj, opts...)
+				}
+			}
+			r := &CredentialsReconciler{
+				client:   tc.fields.client,
+				validate: tc.fields.validate,
+				resolve:  tc.fields.resolve,
+				log:      logging.NewNopLogger(),
+			}
+			got, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "default"}})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.r, got); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.conditions, conditions, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want conditions, +got conditions:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
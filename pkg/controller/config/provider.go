@@ -0,0 +1,182 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-azure/apis"
+	"github.com/crossplane/provider-azure/apis/v1alpha3"
+)
+
+const (
+	providerReconcileTimeout = 2 * time.Minute
+	providerInUseWait        = 30 * time.Second
+
+	// providerFinalizer is the finalizer that blocks the deletion of a
+	// Provider while managed resources use it. It is the finalizer of
+	// ProviderConfigs that are in use.
+	providerFinalizer = "in-use.crossplane.io"
+
+	reasonProviderInUse event.Reason = "ProviderInUse"
+
+	// providerRefIndex indexes managed resources by the name of the Provider
+	// they reference.
+	providerRefIndex = "spec.providerRef.name"
+)
+
+// Error strings.
+const (
+	errGetProvider      = "cannot get Provider"
+	errListUsers        = "cannot list %s resources"
+	errIndexUsers       = "cannot index %s resources by Provider"
+	errAddFinalizer     = "cannot add Provider finalizer"
+	errRemoveFinalizer  = "cannot remove Provider finalizer"
+	errProviderInUseFmt = "blocking deletion while %d managed resources use the Provider"
+)
+
+// SetupProvider adds a controller that blocks the deletion of Providers until
+// no managed resource uses them. ProviderConfigs track their usage, but the
+// deprecated Provider kind does not.
+func SetupProvider(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
+	name := "usage/" + strings.ToLower(v1alpha3.ProviderGroupKind)
+
+	if err := IndexProviderReferences(context.Background(), mgr.GetFieldIndexer(), mgr.GetScheme()); err != nil {
+		return err
+	}
+
+	r := &ProviderReconciler{
+		client:    mgr.GetClient(),
+		scheme:    mgr.GetScheme(),
+		finalizer: resource.NewAPIFinalizer(mgr.GetClient(), providerFinalizer),
+		log:       l.WithValues("controller", name),
+		record:    event.NewAPIRecorder(mgr.GetEventRecorderFor(name)),
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha3.Provider{}).
+		Complete(r)
+}
+
+// A ProviderReconciler adds a finalizer to Providers, and removes it from a
+// deleted Provider once no managed resource references it, so that the
+// external resources of the managed resources can still be reconciled and
+// deleted.
+type ProviderReconciler struct {
+	client    client.Client
+	scheme    *runtime.Scheme
+	finalizer resource.Finalizer
+
+	log    logging.Logger
+	record event.Recorder
+}
+
+// Reconcile the finalizer of a Provider.
+func (r *ProviderReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	log := r.log.WithValues("request", req)
+	log.Debug("Reconciling")
+
+	ctx, cancel := context.WithTimeout(ctx, providerReconcileTimeout)
+	defer cancel()
+
+	p := &v1alpha3.Provider{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: req.Name}, p); err != nil {
+		return reconcile.Result{}, errors.Wrap(resource.IgnoreNotFound(err), errGetProvider)
+	}
+
+	if !meta.WasDeleted(p) {
+		return reconcile.Result{}, errors.Wrap(r.finalizer.AddFinalizer(ctx, p), errAddFinalizer)
+	}
+
+	users, err := ProviderUsers(ctx, r.client, r.scheme, req.Name)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if users > 0 {
+		log.Debug("Blocking deletion while managed resources use the Provider", "users", users)
+		r.record.Event(p, event.Warning(reasonProviderInUse, errors.Errorf(errProviderInUseFmt, users)))
+		return reconcile.Result{RequeueAfter: providerInUseWait}, nil
+	}
+	return reconcile.Result{}, errors.Wrap(r.finalizer.RemoveFinalizer(ctx, p), errRemoveFinalizer)
+}
+
+// IndexProviderReferences indexes the managed resources known to the supplied
+// scheme by the name of the Provider they reference, so that the users of a
+// Provider can be listed without listing every managed resource.
+func IndexProviderReferences(ctx context.Context, i client.FieldIndexer, s *runtime.Scheme) error {
+	for _, l := range apis.ManagedLists(s) {
+		gvk := resource.MustGetKind(l, s)
+		gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+		o, err := s.New(gvk)
+		if err != nil {
+			return errors.Wrapf(err, errIndexUsers, gvk.Kind)
+		}
+		if err := i.IndexField(ctx, o.(client.Object), providerRefIndex, providerRef); err != nil {
+			return errors.Wrapf(err, errIndexUsers, gvk.Kind)
+		}
+	}
+	return nil
+}
+
+// providerRef returns the name of the Provider the supplied managed resource
+// references, if any.
+func providerRef(o client.Object) []string {
+	mg, ok := o.(resource.Managed)
+	if !ok {
+		return nil
+	}
+	ref := mg.GetProviderReference()
+	if ref == nil || ref.Name == "" {
+		return nil
+	}
+	return []string{ref.Name}
+}
+
+// ProviderUsers returns the number of managed resources known to the supplied
+// scheme that reference the Provider with the supplied name. The managed
+// resources must be indexed by IndexProviderReferences.
+func ProviderUsers(ctx context.Context, c client.Client, s *runtime.Scheme, name string) (int, error) {
+	users := 0
+	for _, l := range apis.ManagedLists(s) {
+		if err := c.List(ctx, l, client.MatchingFields{providerRefIndex: name}); err != nil {
+			return 0, errors.Wrapf(err, errListUsers, strings.TrimSuffix(resource.MustGetKind(l, s).Kind, "List"))
+		}
+		users += len(l.GetItems())
+	}
+	return users, nil
+}
@@ -0,0 +1,240 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	cachev1beta1 "github.com/crossplane/provider-azure/apis/cache/v1beta1"
+	"github.com/crossplane/provider-azure/apis/v1alpha3"
+)
+
+type mockIndexer struct {
+	MockIndexField func(ctx context.Context, obj client.Object, field string, extractValue client.IndexerFunc) error
+}
+
+func (m *mockIndexer) IndexField(ctx context.Context, obj client.Object, field string, extractValue client.IndexerFunc) error {
+	return m.MockIndexField(ctx, obj, field, extractValue)
+}
+
+func TestProviderReconcile(t *testing.T) {
+	errBoom := errors.New("boom")
+	now := metav1.Now()
+
+	s := runtime.NewScheme()
+	if err := cachev1beta1.SchemeBuilder.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	deleted := func(obj client.Object) error {
+		obj.SetDeletionTimestamp(&now)
+		return nil
+	}
+	users := func(n int) test.ObjectListFn {
+		return func(obj client.ObjectList) error {
+			l := obj.(*cachev1beta1.RedisList)
+			for i := 0; i < n; i++ {
+				l.Items = append(l.Items, cachev1beta1.Redis{Spec: cachev1beta1.RedisSpec{ResourceSpec: xpv1.ResourceSpec{ProviderReference: &xpv1.Reference{Name: "default"}}}})
+			}
+			return nil
+		}
+	}
+	list := func(err error, ofn ...test.ObjectListFn) test.MockListFn {
+		fn := test.NewMockListFn(err, ofn...)
+		return func(ctx context.Context, obj client.ObjectList, opts ...client.ListOption) error {
+			o := &client.ListOptions{}
+			o.ApplyOptions(opts)
+			if diff := cmp.Diff(providerRefIndex+"=default", o.FieldSelector.String()); diff != "" {
+				t.Errorf("List(...): -want field selector, +got field selector:\n%s", diff)
+			}
+			return fn(ctx, obj, opts...)
+		}
+	}
+
+	type fields struct {
+		client    client.Client
+		finalizer resource.Finalizer
+	}
+	type want struct {
+		r       reconcile.Result
+		err     error
+		reasons []event.Reason
+	}
+	cases := map[string]struct {
+		reason string
+		fields fields
+		want   want
+	}{
+		"GetProviderError": {
+			reason: "Errors getting the Provider should be returned.",
+			fields: fields{
+				client: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			},
+			want: want{err: errors.Wrap(errBoom, errGetProvider)},
+		},
+		"ProviderNotFound": {
+			reason: "Providers that no longer exist should be ignored.",
+			fields: fields{
+				client: &test.MockClient{MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, ""))},
+			},
+			want: want{},
+		},
+		"AddFinalizer": {
+			reason: "Providers that are not being deleted should get a finalizer.",
+			fields: fields{
+				client:    &test.MockClient{MockGet: test.NewMockGetFn(nil)},
+				finalizer: resource.FinalizerFns{AddFinalizerFn: func(_ context.Context, _ resource.Object) error { return nil }},
+			},
+			want: want{},
+		},
+		"AddFinalizerError": {
+			reason: "Errors adding the finalizer should be returned.",
+			fields: fields{
+				client:    &test.MockClient{MockGet: test.NewMockGetFn(nil)},
+				finalizer: resource.FinalizerFns{AddFinalizerFn: func(_ context.Context, _ resource.Object) error { return errBoom }},
+			},
+			want: want{err: errors.Wrap(errBoom, errAddFinalizer)},
+		},
+		"ListUsersError": {
+			reason: "Errors listing the users of a deleted Provider should be returned.",
+			fields: fields{
+				client: &test.MockClient{
+					MockGet:  test.NewMockGetFn(nil, deleted),
+					MockList: list(errBoom),
+				},
+			},
+			want: want{err: errors.Wrapf(errBoom, errListUsers, cachev1beta1.RedisKind)},
+		},
+		"InUse": {
+			reason: "The finalizer of a deleted Provider should be kept while managed resources use it.",
+			fields: fields{
+				client: &test.MockClient{
+					MockGet:  test.NewMockGetFn(nil, deleted),
+					MockList: list(nil, users(2)),
+				},
+			},
+			want: want{r: reconcile.Result{RequeueAfter: providerInUseWait}, reasons: []event.Reason{reasonProviderInUse}},
+		},
+		"RemoveFinalizer": {
+			reason: "The finalizer of a deleted Provider should be removed once no managed resource uses it.",
+			fields: fields{
+				client: &test.MockClient{
+					MockGet:  test.NewMockGetFn(nil, deleted),
+					MockList: list(nil),
+				},
+				finalizer: resource.FinalizerFns{RemoveFinalizerFn: func(_ context.Context, _ resource.Object) error { return nil }},
+			},
+			want: want{},
+		},
+		"RemoveFinalizerError": {
+			reason: "Errors removing the finalizer should be returned.",
+			fields: fields{
+				client: &test.MockClient{
+					MockGet:  test.NewMockGetFn(nil, deleted),
+					MockList: list(nil),
+				},
+				finalizer: resource.FinalizerFns{RemoveFinalizerFn: func(_ context.Context, _ resource.Object) error { return errBoom }},
+			},
+			want: want{err: errors.Wrap(errBoom, errRemoveFinalizer)},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rec := &recorder{}
+			r := &ProviderReconciler{
+				client:    tc.fields.client,
+				scheme:    s,
+				finalizer: tc.fields.finalizer,
+				log:       logging.NewNopLogger(),
+				record:    rec,
+			}
+			got, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "default"}})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.r, got); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.reasons, rec.reasons); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want events, +got events:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestIndexProviderReferences(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := cachev1beta1.SchemeBuilder.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	indexed := map[string]client.IndexerFunc{}
+	i := &mockIndexer{MockIndexField: func(_ context.Context, obj client.Object, field string, fn client.IndexerFunc) error {
+		if diff := cmp.Diff(providerRefIndex, field); diff != "" {
+			t.Errorf("IndexField(...): -want field, +got field:\n%s", diff)
+		}
+		indexed[resource.MustGetKind(obj, s).Kind] = fn
+		return nil
+	}}
+	if err := IndexProviderReferences(context.Background(), i, s); err != nil {
+		t.Fatalf("IndexProviderReferences(...): %s", err)
+	}
+
+	fn, ok := indexed[cachev1beta1.RedisKind]
+	if !ok {
+		t.Fatalf("IndexProviderReferences(...): %s was not indexed", cachev1beta1.RedisKind)
+	}
+	cases := map[string]struct {
+		obj  client.Object
+		want []string
+	}{
+		"Referenced": {
+			obj:  &cachev1beta1.Redis{Spec: cachev1beta1.RedisSpec{ResourceSpec: xpv1.ResourceSpec{ProviderReference: &xpv1.Reference{Name: "default"}}}},
+			want: []string{"default"},
+		},
+		"NotReferenced": {
+			obj: &cachev1beta1.Redis{},
+		},
+		"NotManaged": {
+			obj: &v1alpha3.Provider{},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, fn(tc.obj)); diff != "" {
+				t.Errorf("providerRef(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/quota"
+	"github.com/crossplane/provider-azure/pkg/feature"
+	"github.com/crossplane/provider-azure/pkg/region"
+)
+
+const (
+	quotaReconcileTimeout = 2 * time.Minute
+	quotaPollInterval     = 10 * time.Minute
+
+	reasonQuotaThreshold event.Reason = "QuotaThresholdExceeded"
+	reasonListQuotas     event.Reason = "CannotListQuotas"
+)
+
+// Error strings.
+const (
+	errGetPC          = "cannot get ProviderConfig"
+	errGetCreds       = "cannot get credentials of ProviderConfig"
+	errListQuotas     = "cannot list quota usage"
+	errUpdateStatus   = "cannot update ProviderConfig status"
+	errQuotaThreshold = "usage of quota %q in location %q is %d of %d"
+)
+
+// A ListerFn returns a quota.Lister using the supplied credentials.
+type ListerFn func(creds map[string]string, auth autorest.Authorizer) quota.Lister
+
+// SetupQuota adds a controller that records the usage of the quotas watched by
+// ProviderConfigs in their status.
+func SetupQuota(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
+	name := "quota/" + strings.ToLower(v1beta1.ProviderConfigGroupKind)
+
+	r := &QuotaReconciler{
+		client: mgr.GetClient(),
+		newLister: func(creds map[string]string, auth autorest.Authorizer) quota.Lister {
+			return quota.NewClient(creds, auth)
+		},
+		gate:   feature.DefaultGate,
+		log:    l.WithValues("controller", name),
+		record: event.NewAPIRecorder(mgr.GetEventRecorderFor(name)),
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1beta1.ProviderConfig{}).
+		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		Complete(r)
+}
+
+// A QuotaReconciler records the usage of the quotas watched by a
+// ProviderConfig in its status, and fires a warning event when the usage of a
+// quota crosses the configured threshold.
+type QuotaReconciler struct {
+	client    client.Client
+	newLister ListerFn
+	gate      *feature.Gate
+
+	log    logging.Logger
+	record event.Recorder
+}
+
+// Reconcile the quota usage of a ProviderConfig.
+func (r *QuotaReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	log := r.log.WithValues("request", req)
+	log.Debug("Reconciling")
+
+	ctx, cancel := context.WithTimeout(ctx, quotaReconcileTimeout)
+	defer cancel()
+
+	pc := &v1beta1.ProviderConfig{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: req.Name}, pc); err != nil {
+		return reconcile.Result{}, errors.Wrap(resource.IgnoreNotFound(err), errGetPC)
+	}
+
+	if pc.Spec.QuotaWatch == nil {
+		if pc.Status.Quotas == nil {
+			return reconcile.Result{}, nil
+		}
+		pc.Status.Quotas = nil
+		return reconcile.Result{}, errors.Wrap(r.client.Status().Update(ctx, pc), errUpdateStatus)
+	}
+
+	// The last recorded usage is kept while quota usage is disabled, e.g.
+	// because the usage APIs are blocked in the subscription.
+	if !r.gate.Enabled(feature.QuotaUsage) {
+		log.Debug("Quota usage is disabled")
+		return reconcile.Result{RequeueAfter: quotaPollInterval}, nil
+	}
+
+	creds, auth, err := azure.GetProviderConfigAuthInfo(ctx, r.client, pc)
+	if err != nil {
+		log.Debug(errGetCreds, "error", err)
+		return reconcile.Result{RequeueAfter: quotaPollInterval}, nil
+	}
+	l := r.newLister(creds, auth)
+
+	mu := sync.Mutex{}
+	usage := make(map[string][]v1beta1.QuotaUsage, len(pc.Spec.QuotaWatch.Locations))
+	results := region.Reconcile(ctx, pc.Spec.QuotaWatch.Locations, region.DefaultMaxConcurrency, func(ctx context.Context, loc string) error {
+		u, err := l.List(ctx, loc)
+		r.gate.Observe(feature.QuotaUsage, err)
+		mu.Lock()
+		defer mu.Unlock()
+		usage[loc] = u
+		return err
+	})
+	if err := region.Error(results); err != nil {
+		log.Debug(errListQuotas, "error", err)
+		r.record.Event(pc, event.Warning(reasonListQuotas, errors.Wrap(err, errListQuotas)))
+		return reconcile.Result{RequeueAfter: quotaPollInterval}, nil
+	}
+	current := make([]v1beta1.QuotaUsage, 0)
+	for _, res := range results {
+		current = append(current, usage[res.Region]...)
+	}
+
+	threshold := quota.DefaultThresholdPercent
+	if pc.Spec.QuotaWatch.ThresholdPercent != nil {
+		threshold = *pc.Spec.QuotaWatch.ThresholdPercent
+	}
+	for _, u := range quota.Crossed(pc.Status.Quotas, current, threshold) {
+		r.record.Event(pc, event.Warning(reasonQuotaThreshold, errors.Errorf(errQuotaThreshold, u.Name, u.Location, u.CurrentValue, u.Limit)))
+	}
+
+	pc.Status.Quotas = current
+	return reconcile.Result{RequeueAfter: quotaPollInterval}, errors.Wrap(r.client.Status().Update(ctx, pc), errUpdateStatus)
+}
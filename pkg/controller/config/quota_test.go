@@ -0,0 +1,225 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+	"github.com/crossplane/provider-azure/pkg/clients/quota"
+	"github.com/crossplane/provider-azure/pkg/feature"
+)
+
+type mockLister struct {
+	MockList func(ctx context.Context, location string) ([]v1beta1.QuotaUsage, error)
+}
+
+func (m *mockLister) List(ctx context.Context, location string) ([]v1beta1.QuotaUsage, error) {
+	return m.MockList(ctx, location)
+}
+
+// A recorder records the reasons of the events it is asked to record.
+type recorder struct {
+	reasons []event.Reason
+}
+
+func (r *recorder) Event(_ runtime.Object, e event.Event) {
+	r.reasons = append(r.reasons, e.Reason)
+}
+
+func (r *recorder) WithAnnotations(_ ...string) event.Recorder {
+	return r
+}
+
+func TestQuotaReconcile(t *testing.T) {
+	errBoom := errors.New("boom")
+	watch := &v1beta1.QuotaWatch{Locations: []string{"westus2"}}
+	mi := &v1beta1.ManagedIdentity{SubscriptionID: "sub"}
+	cores := v1beta1.QuotaUsage{Location: "westus2", Name: "cores", CurrentValue: 9, Limit: 10}
+
+	withSpec := func(s v1beta1.ProviderConfigSpec, q ...v1beta1.QuotaUsage) test.ObjectFn {
+		return func(obj client.Object) error {
+			pc := obj.(*v1beta1.ProviderConfig)
+			pc.Spec = s
+			pc.Status.Quotas = q
+			return nil
+		}
+	}
+
+	type fields struct {
+		client   client.Client
+		lister   quota.Lister
+		disabled bool
+	}
+	type want struct {
+		r       reconcile.Result
+		err     error
+		quotas  []v1beta1.QuotaUsage
+		reasons []event.Reason
+	}
+	cases := map[string]struct {
+		reason string
+		fields fields
+		want   want
+	}{
+		"GetProviderConfigError": {
+			reason: "Errors getting the ProviderConfig should be returned.",
+			fields: fields{
+				client: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			},
+			want: want{err: errors.Wrap(errBoom, errGetPC)},
+		},
+		"ProviderConfigNotFound": {
+			reason: "ProviderConfigs that no longer exist should be ignored.",
+			fields: fields{
+				client: &test.MockClient{MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, ""))},
+			},
+			want: want{},
+		},
+		"NoQuotaWatch": {
+			reason: "Quota usage recorded before the QuotaWatch was removed should be cleared.",
+			fields: fields{
+				client: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, withSpec(v1beta1.ProviderConfigSpec{}, cores)),
+					MockStatusUpdate: test.NewMockStatusUpdateFn(nil, func(obj client.Object) error {
+						if q := obj.(*v1beta1.ProviderConfig).Status.Quotas; q != nil {
+							t.Errorf("Status().Update(...): want no quotas, got %v", q)
+						}
+						return nil
+					}),
+				},
+			},
+			want: want{},
+		},
+		"QuotaUsageDisabled": {
+			reason: "Quota usage should not be listed while the feature is disabled.",
+			fields: fields{
+				client:   &test.MockClient{MockGet: test.NewMockGetFn(nil, withSpec(v1beta1.ProviderConfigSpec{QuotaWatch: watch}))},
+				disabled: true,
+			},
+			want: want{r: reconcile.Result{RequeueAfter: quotaPollInterval}},
+		},
+		"CredentialsError": {
+			reason: "ProviderConfigs whose credentials cannot be loaded should be polled again later.",
+			fields: fields{
+				client: &test.MockClient{MockGet: test.NewMockGetFn(nil, withSpec(v1beta1.ProviderConfigSpec{QuotaWatch: watch}))},
+			},
+			want: want{r: reconcile.Result{RequeueAfter: quotaPollInterval}},
+		},
+		"ListQuotasError": {
+			reason: "Errors listing quota usage should be surfaced as an event.",
+			fields: fields{
+				client: &test.MockClient{MockGet: test.NewMockGetFn(nil, withSpec(v1beta1.ProviderConfigSpec{QuotaWatch: watch, UseManagedIdentity: mi}))},
+				lister: &mockLister{MockList: func(_ context.Context, _ string) ([]v1beta1.QuotaUsage, error) {
+					return nil, errBoom
+				}},
+			},
+			want: want{r: reconcile.Result{RequeueAfter: quotaPollInterval}, reasons: []event.Reason{reasonListQuotas}},
+		},
+		"ThresholdCrossed": {
+			reason: "Quota usage should be recorded in the status, and crossing the threshold should fire an event.",
+			fields: fields{
+				client: &test.MockClient{
+					MockGet:          test.NewMockGetFn(nil, withSpec(v1beta1.ProviderConfigSpec{QuotaWatch: watch, UseManagedIdentity: mi})),
+					MockStatusUpdate: test.NewMockStatusUpdateFn(nil),
+				},
+				lister: &mockLister{MockList: func(_ context.Context, _ string) ([]v1beta1.QuotaUsage, error) {
+					return []v1beta1.QuotaUsage{cores}, nil
+				}},
+			},
+			want: want{r: reconcile.Result{RequeueAfter: quotaPollInterval}, quotas: []v1beta1.QuotaUsage{cores}, reasons: []event.Reason{reasonQuotaThreshold}},
+		},
+		"ThresholdAlreadyCrossed": {
+			reason: "A quota whose usage was already above the threshold should not fire another event.",
+			fields: fields{
+				client: &test.MockClient{
+					MockGet:          test.NewMockGetFn(nil, withSpec(v1beta1.ProviderConfigSpec{QuotaWatch: watch, UseManagedIdentity: mi}, cores)),
+					MockStatusUpdate: test.NewMockStatusUpdateFn(nil),
+				},
+				lister: &mockLister{MockList: func(_ context.Context, _ string) ([]v1beta1.QuotaUsage, error) {
+					return []v1beta1.QuotaUsage{cores}, nil
+				}},
+			},
+			want: want{r: reconcile.Result{RequeueAfter: quotaPollInterval}, quotas: []v1beta1.QuotaUsage{cores}},
+		},
+		"UpdateStatusError": {
+			reason: "Errors recording quota usage should be returned.",
+			fields: fields{
+				client: &test.MockClient{
+					MockGet:          test.NewMockGetFn(nil, withSpec(v1beta1.ProviderConfigSpec{QuotaWatch: watch, UseManagedIdentity: mi}, cores)),
+					MockStatusUpdate: test.NewMockStatusUpdateFn(errBoom),
+				},
+				lister: &mockLister{MockList: func(_ context.Context, _ string) ([]v1beta1.QuotaUsage, error) {
+					return []v1beta1.QuotaUsage{cores}, nil
+				}},
+			},
+			want: want{r: reconcile.Result{RequeueAfter: quotaPollInterval}, err: errors.Wrap(errBoom, errUpdateStatus), quotas: []v1beta1.QuotaUsage{cores}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			g := feature.NewGate()
+			if tc.fields.disabled {
+				g.Disable(feature.QuotaUsage)
+			}
+			rec := &recorder{}
+			var quotas []v1beta1.QuotaUsage
+			if mc, ok := tc.fields.client.(*test.MockClient); ok && mc.MockStatusUpdate != nil {
+				update := mc.MockStatusUpdate
+				mc.MockStatusUpdate = func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+					quotas = obj.(*v1beta1.ProviderConfig).Status.Quotas
+					return update(ctx, obj, opts...)
+				}
+			}
+			r := &QuotaReconciler{
+				client:    tc.fields.client,
+				newLister: func(_ map[string]string, _ autorest.Authorizer) quota.Lister { return tc.fields.lister },
+				gate:      g,
+				log:       logging.NewNopLogger(),
+				record:    rec,
+			}
+			got, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "default"}})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.r, got); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.quotas, quotas); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want quotas, +got quotas:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.reasons, rec.reasons); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want events, +got events:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cost contains controllers that annotate managed resources, and the
+// claims they are bound to, with an estimate of their monthly cost.
+package cost
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	cachev1beta1 "github.com/crossplane/provider-azure/apis/cache/v1beta1"
+	computev1alpha3 "github.com/crossplane/provider-azure/apis/compute/v1alpha3"
+	databasev1beta1 "github.com/crossplane/provider-azure/apis/database/v1beta1"
+	"github.com/crossplane/provider-azure/pkg/clients/cost"
+)
+
+// AnnotationKeyEstimatedMonthlyCost is the annotation that holds the estimated
+// monthly cost of a managed resource, e.g. "73.00 USD".
+const AnnotationKeyEstimatedMonthlyCost = "azure.crossplane.io/estimated-monthly-cost"
+
+const (
+	reconcileTimeout = 1 * time.Minute
+	refreshInterval  = 24 * time.Hour
+
+	reasonEstimate event.Reason = "CannotEstimateCost"
+	reasonClaim    event.Reason = "CannotAnnotateClaim"
+)
+
+// Error strings.
+const (
+	errGetManaged    = "cannot get managed resource"
+	errUpdateManaged = "cannot annotate managed resource with its estimated cost"
+	errGetComposite  = "cannot get composite resource"
+	errPatchClaim    = "cannot annotate claim with its estimated cost"
+)
+
+// A QueryFn returns the query for the price of the supplied managed resource.
+type QueryFn func(mg resource.Managed) cost.Query
+
+// Setup adds controllers that annotate the managed resources whose SKU or size
+// can be priced with their estimated monthly cost.
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
+	e := cost.NewRetailPricesClient()
+	for _, k := range []struct {
+		kind  resource.ManagedKind
+		obj   resource.Managed
+		query QueryFn
+	}{
+		{
+			kind: resource.ManagedKind(cachev1beta1.RedisGroupVersionKind),
+			obj:  &cachev1beta1.Redis{},
+			query: func(mg resource.Managed) cost.Query {
+				return cost.ForRedis(mg.(*cachev1beta1.Redis).Spec.ForProvider)
+			},
+		},
+		{
+			kind: resource.ManagedKind(computev1alpha3.AKSClusterGroupVersionKind),
+			obj:  &computev1alpha3.AKSCluster{},
+			query: func(mg resource.Managed) cost.Query {
+				return cost.ForAKSCluster(mg.(*computev1alpha3.AKSCluster).Spec.AKSClusterParameters)
+			},
+		},
+		{
+			kind: resource.ManagedKind(databasev1beta1.MySQLServerGroupVersionKind),
+			obj:  &databasev1beta1.MySQLServer{},
+			query: func(mg resource.Managed) cost.Query {
+				return cost.ForMySQLServer(mg.(*databasev1beta1.MySQLServer).Spec.ForProvider)
+			},
+		},
+		{
+			kind: resource.ManagedKind(databasev1beta1.PostgreSQLServerGroupVersionKind),
+			obj:  &databasev1beta1.PostgreSQLServer{},
+			query: func(mg resource.Managed) cost.Query {
+				return cost.ForPostgreSQLServer(mg.(*databasev1beta1.PostgreSQLServer).Spec.ForProvider)
+			},
+		},
+	} {
+		name := "cost/" + strings.ToLower(schema.GroupVersionKind(k.kind).GroupKind().String())
+		r := NewReconciler(mgr, k.kind, k.query, e, l.WithValues("controller", name), event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))
+		err := ctrl.NewControllerManagedBy(mgr).
+			Named(name).
+			WithOptions(controller.Options{
+				RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+			}).
+			For(k.obj).
+			// Re-estimate when the annotations change too, so that an estimate
+			// that was removed or edited is restored.
+			WithEventFilter(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{})).
+			Complete(r)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// A Reconciler annotates managed resources of one kind with their estimated
+// monthly cost.
+type Reconciler struct {
+	client     client.Client
+	newManaged func() resource.Managed
+	query      QueryFn
+	estimator  cost.Estimator
+
+	log    logging.Logger
+	record event.Recorder
+}
+
+// NewReconciler returns a Reconciler that annotates managed resources of the
+// supplied kind with their estimated monthly cost.
+func NewReconciler(m ctrl.Manager, of resource.ManagedKind, q QueryFn, e cost.Estimator, l logging.Logger, r event.Recorder) *Reconciler {
+	nm := func() resource.Managed {
+		return resource.MustCreateObject(schema.GroupVersionKind(of), m.GetScheme()).(resource.Managed)
+	}
+	// Panic early if we've been asked to reconcile a resource kind that has
+	// not been registered with our controller manager's scheme.
+	_ = nm()
+
+	return &Reconciler{
+		client:     m.GetClient(),
+		newManaged: nm,
+		query:      q,
+		estimator:  e,
+		log:        l,
+		record:     r,
+	}
+}
+
+// Reconcile the estimated monthly cost annotation of a managed resource.
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	log := r.log.WithValues("request", req)
+	log.Debug("Reconciling")
+
+	ctx, cancel := context.WithTimeout(ctx, reconcileTimeout)
+	defer cancel()
+
+	mg := r.newManaged()
+	if err := r.client.Get(ctx, req.NamespacedName, mg); err != nil {
+		return reconcile.Result{}, errors.Wrap(resource.IgnoreNotFound(err), errGetManaged)
+	}
+	if meta.WasDeleted(mg) {
+		return reconcile.Result{}, nil
+	}
+
+	e, err := r.estimator.Estimate(ctx, r.query(mg))
+	if err != nil {
+		// Cost estimates are informational, so we don't retry aggressively.
+		log.Debug("Cannot estimate cost", "error", err)
+		r.record.Event(mg, event.Warning(reasonEstimate, err))
+		return reconcile.Result{RequeueAfter: refreshInterval}, nil
+	}
+
+	v := e.String()
+	if mg.GetAnnotations()[AnnotationKeyEstimatedMonthlyCost] != v {
+		meta.AddAnnotations(mg, map[string]string{AnnotationKeyEstimatedMonthlyCost: v})
+		if err := r.client.Update(ctx, mg); err != nil {
+			return reconcile.Result{}, errors.Wrap(err, errUpdateManaged)
+		}
+	}
+
+	// The claim is annotated on a best effort basis; the provider may not be
+	// allowed to read composite resources or write claims.
+	if err := r.annotateClaim(ctx, mg, v); err != nil {
+		log.Debug("Cannot annotate claim", "error", err)
+		r.record.Event(mg, event.Warning(reasonClaim, err))
+	}
+
+	return reconcile.Result{RequeueAfter: refreshInterval}, nil
+}
+
+// annotateClaim annotates the claim the supplied managed resource was composed
+// for, if any, with the supplied estimated cost. Crossplane records the claim
+// on the composite resource that controls the managed resource.
+func (r *Reconciler) annotateClaim(ctx context.Context, mg resource.Managed, v string) error {
+	or := metav1.GetControllerOf(mg)
+	if or == nil {
+		return nil
+	}
+	xr := &unstructured.Unstructured{}
+	xr.SetAPIVersion(or.APIVersion)
+	xr.SetKind(or.Kind)
+	if err := r.client.Get(ctx, types.NamespacedName{Name: or.Name}, xr); err != nil {
+		return errors.Wrap(resource.IgnoreNotFound(err), errGetComposite)
+	}
+	ref := &corev1.ObjectReference{}
+	if err := fieldpath.Pave(xr.Object).GetValueInto("spec.claimRef", ref); err != nil {
+		return nil
+	}
+
+	cm := &unstructured.Unstructured{}
+	cm.SetAPIVersion(ref.APIVersion)
+	cm.SetKind(ref.Kind)
+	cm.SetNamespace(ref.Namespace)
+	cm.SetName(ref.Name)
+	p, _ := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{AnnotationKeyEstimatedMonthlyCost: v},
+		},
+	})
+	return errors.Wrap(resource.IgnoreNotFound(r.client.Patch(ctx, cm, client.RawPatch(types.MergePatchType, p))), errPatchClaim)
+}
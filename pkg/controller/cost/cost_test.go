@@ -0,0 +1,202 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cost
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	cachev1beta1 "github.com/crossplane/provider-azure/apis/cache/v1beta1"
+	"github.com/crossplane/provider-azure/pkg/clients/cost"
+)
+
+type mockEstimator struct {
+	e   cost.Estimate
+	err error
+}
+
+func (m mockEstimator) Estimate(_ context.Context, _ cost.Query) (cost.Estimate, error) {
+	return m.e, m.err
+}
+
+func TestReconcile(t *testing.T) {
+	errBoom := errors.New("boom")
+	estimate := cost.Estimate{Monthly: 73, Currency: "USD"}
+	isController := true
+	composed := metav1.OwnerReference{APIVersion: "example.org/v1", Kind: "XCache", Name: "xr", Controller: &isController}
+
+	type fields struct {
+		client    client.Client
+		estimator cost.Estimator
+	}
+	type want struct {
+		r   reconcile.Result
+		err error
+	}
+	cases := map[string]struct {
+		reason string
+		fields fields
+		want   want
+	}{
+		"GetManagedError": {
+			reason: "Errors getting the managed resource should be returned.",
+			fields: fields{
+				client: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			},
+			want: want{err: errors.Wrap(errBoom, errGetManaged)},
+		},
+		"ManagedNotFound": {
+			reason: "Managed resources that no longer exist should be ignored.",
+			fields: fields{
+				client: &test.MockClient{MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, ""))},
+			},
+			want: want{},
+		},
+		"EstimateError": {
+			reason: "Failing to estimate the cost should be retried after the refresh interval.",
+			fields: fields{
+				client:    &test.MockClient{MockGet: test.NewMockGetFn(nil)},
+				estimator: mockEstimator{err: errBoom},
+			},
+			want: want{r: reconcile.Result{RequeueAfter: refreshInterval}},
+		},
+		"UpdateManagedError": {
+			reason: "Errors annotating the managed resource should be returned.",
+			fields: fields{
+				client: &test.MockClient{
+					MockGet:    test.NewMockGetFn(nil),
+					MockUpdate: test.NewMockUpdateFn(errBoom),
+				},
+				estimator: mockEstimator{e: estimate},
+			},
+			want: want{err: errors.Wrap(errBoom, errUpdateManaged)},
+		},
+		"AnnotateManaged": {
+			reason: "The managed resource should be annotated with its estimated cost.",
+			fields: fields{
+				client: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil),
+					MockUpdate: test.NewMockUpdateFn(nil, func(obj client.Object) error {
+						if got := obj.GetAnnotations()[AnnotationKeyEstimatedMonthlyCost]; got != estimate.String() {
+							t.Errorf("Update(...): want annotation %q, got %q", estimate.String(), got)
+						}
+						return nil
+					}),
+				},
+				estimator: mockEstimator{e: estimate},
+			},
+			want: want{r: reconcile.Result{RequeueAfter: refreshInterval}},
+		},
+		"ManagedUpToDate": {
+			reason: "A managed resource that is already annotated with its estimated cost should not be updated.",
+			fields: fields{
+				client: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						obj.SetAnnotations(map[string]string{AnnotationKeyEstimatedMonthlyCost: estimate.String()})
+						return nil
+					}),
+					MockUpdate: test.NewMockUpdateFn(errBoom),
+				},
+				estimator: mockEstimator{e: estimate},
+			},
+			want: want{r: reconcile.Result{RequeueAfter: refreshInterval}},
+		},
+		"AnnotateClaim": {
+			reason: "The claim recorded on the composite resource that controls the managed resource should be annotated.",
+			fields: fields{
+				client: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						switch o := obj.(type) {
+						case *unstructured.Unstructured:
+							o.Object["spec"] = map[string]interface{}{
+								"claimRef": map[string]interface{}{"apiVersion": "example.org/v1", "kind": "Cache", "namespace": "default", "name": "claim"},
+							}
+						default:
+							o.SetOwnerReferences([]metav1.OwnerReference{composed})
+							o.SetAnnotations(map[string]string{AnnotationKeyEstimatedMonthlyCost: estimate.String()})
+						}
+						return nil
+					}),
+					MockPatch: func(_ context.Context, obj client.Object, p client.Patch, _ ...client.PatchOption) error {
+						want := types.NamespacedName{Namespace: "default", Name: "claim"}
+						if got := (types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}); got != want {
+							t.Errorf("Patch(...): want claim %s, got %s", want, got)
+						}
+						d, _ := p.Data(obj)
+						if want := `{"metadata":{"annotations":{"azure.crossplane.io/estimated-monthly-cost":"73.00 USD"}}}`; string(d) != want {
+							t.Errorf("Patch(...): want %s, got %s", want, d)
+						}
+						return nil
+					},
+				},
+				estimator: mockEstimator{e: estimate},
+			},
+			want: want{r: reconcile.Result{RequeueAfter: refreshInterval}},
+		},
+		"AnnotateClaimError": {
+			reason: "Claims are annotated on a best effort basis, so errors doing so should not be returned.",
+			fields: fields{
+				client: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						if _, ok := obj.(*unstructured.Unstructured); ok {
+							return errBoom
+						}
+						obj.SetOwnerReferences([]metav1.OwnerReference{composed})
+						obj.SetAnnotations(map[string]string{AnnotationKeyEstimatedMonthlyCost: estimate.String()})
+						return nil
+					}),
+				},
+				estimator: mockEstimator{e: estimate},
+			},
+			want: want{r: reconcile.Result{RequeueAfter: refreshInterval}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &Reconciler{
+				client:     tc.fields.client,
+				newManaged: func() resource.Managed { return &cachev1beta1.Redis{} },
+				query:      func(mg resource.Managed) cost.Query { return cost.Query{Quantity: 1} },
+				estimator:  tc.fields.estimator,
+				log:        logging.NewNopLogger(),
+				record:     event.NewNopRecorder(),
+			}
+			got, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "cache"}})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.r, got); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
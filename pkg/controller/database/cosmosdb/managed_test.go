@@ -45,7 +45,7 @@ const (
 	id                = "myid"
 	name              = "mycosmosaccount"
 	resourcegroupname = "cool-rg"
-	location          = "coolplace"
+	testLocation      = "coolplace"
 	kind              = "mongodb"
 
 	stateSucceeded = "Succeeded"
@@ -96,13 +96,13 @@ func cosmosDBAccount(rm ...cosmosDBAccountModifier) *v1alpha3.CosmosDBAccount {
 		},
 		Spec: v1alpha3.CosmosDBAccountSpec{
 			ForProvider: v1alpha3.CosmosDBAccountParameters{
-				Location:          location,
+				Location:          testLocation,
 				Kind:              kind,
 				ResourceGroupName: resourcegroupname,
 				Properties: v1alpha3.CosmosDBAccountProperties{
 					Locations: []v1alpha3.CosmosDBAccountLocation{
 						{
-							LocationName:     location,
+							LocationName:     testLocation,
 							IsZoneRedundant:  true,
 							FailoverPriority: 0,
 						},
@@ -209,12 +209,12 @@ func TestObserve(t *testing.T) {
 						return documentdb.DatabaseAccount{
 							ID:       azure.ToStringPtr(id),
 							Kind:     kind,
-							Location: azure.ToStringPtr(location),
+							Location: azure.ToStringPtr(testLocation),
 							DatabaseAccountProperties: &documentdb.DatabaseAccountProperties{
 								ProvisioningState: azure.ToStringPtr(stateSucceeded),
 								ReadLocations: &[]documentdb.Location{
 									{
-										LocationName:     azure.ToStringPtr(location),
+										LocationName:     azure.ToStringPtr(testLocation),
 										FailoverPriority: azure.ToInt32Ptr(0, azure.FieldRequired),
 										IsZoneRedundant:  azure.ToBoolPtr(true),
 									},
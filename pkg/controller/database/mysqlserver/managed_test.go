@@ -37,6 +37,7 @@ import (
 
 	"github.com/crossplane/provider-azure/apis/database/v1beta1"
 	azurev1alpha3 "github.com/crossplane/provider-azure/apis/v1alpha3"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
 	"github.com/crossplane/provider-azure/pkg/clients/database"
 )
 
@@ -180,6 +181,25 @@ func TestObserve(t *testing.T) {
 				},
 			},
 		},
+		"ServerCreateFailed": {
+			e: &external{
+				client: &MockMySQLServerAPI{
+					MockGetServer: func(_ context.Context, _ *v1beta1.MySQLServer) (mysql.Server, error) {
+						return mysql.Server{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+					},
+					MockGetRESTClient: func() autorest.Sender {
+						return nil
+					},
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				mg:  mysqlserver(withLastOperation(azurev1alpha3.AsyncOperation{Method: http.MethodPut, Status: azure.AsyncOperationStatusFailed, ErrorMessage: "boom"})),
+			},
+			want: want{
+				err: errors.Errorf(errLastCreateFailed, "boom"),
+			},
+		},
 		"ServerNotFound": {
 			e: &external{
 				client: &MockMySQLServerAPI{
@@ -237,6 +257,7 @@ func TestObserve(t *testing.T) {
 					ConnectionDetails: managed.ConnectionDetails{
 						xpv1.ResourceCredentialsSecretEndpointKey: []byte(endpoint),
 						xpv1.ResourceCredentialsSecretUserKey:     []byte(fmt.Sprintf("%s@%s", admin, name)),
+						database.ServerCACertificateKey:           []byte(database.ServerCACertificates),
 					},
 				},
 			},
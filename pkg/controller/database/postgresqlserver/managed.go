@@ -22,11 +22,15 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/services/postgresql/mgmt/2017-12-01/postgresql"
 	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
@@ -38,8 +42,20 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/crossplane/provider-azure/apis/database/v1beta1"
+	azurev1alpha3 "github.com/crossplane/provider-azure/apis/v1alpha3"
+	apisv1beta1 "github.com/crossplane/provider-azure/apis/v1beta1"
+	"github.com/crossplane/provider-azure/pkg/chain"
 	azure "github.com/crossplane/provider-azure/pkg/clients"
 	"github.com/crossplane/provider-azure/pkg/clients/database"
+	"github.com/crossplane/provider-azure/pkg/concurrency"
+	"github.com/crossplane/provider-azure/pkg/connection"
+	"github.com/crossplane/provider-azure/pkg/credentials"
+	"github.com/crossplane/provider-azure/pkg/defaults"
+	"github.com/crossplane/provider-azure/pkg/dependency"
+	"github.com/crossplane/provider-azure/pkg/externalname"
+	"github.com/crossplane/provider-azure/pkg/location"
+	"github.com/crossplane/provider-azure/pkg/probe"
+	"github.com/crossplane/provider-azure/pkg/tenancy"
 )
 
 // Error strings.
@@ -52,24 +68,35 @@ const (
 	errGetPostgreSQLServer    = "cannot get PostgreSQLServer"
 	errDeletePostgreSQLServer = "cannot delete PostgreSQLServer"
 	errFetchLastOperation     = "cannot fetch last operation"
+	errLastCreateFailed       = "last create operation failed: %s"
 )
 
 // Setup adds a controller that reconciles PostgreSQLInstances.
-func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, sel labels.Selector) error {
 	name := managed.ControllerName(v1beta1.PostgreSQLServerGroupKind)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(controller.Options{
-			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: concurrency.DefaultLimits.Of(v1beta1.PostgreSQLServerGroupKind),
 		}).
 		For(&v1beta1.PostgreSQLServer{}).
-		Complete(managed.NewReconciler(mgr,
-			resource.ManagedKind(v1beta1.PostgreSQLServerGroupVersionKind),
-			managed.WithExternalConnecter(&connecter{client: mgr.GetClient()}),
-			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
-			managed.WithLogger(l.WithValues("controller", name)),
-			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+		Watches(&source.Kind{Type: &corev1.Secret{}}, credentials.EnqueueRequestsForRotation(mgr.GetClient(), &v1beta1.PostgreSQLServerList{}, l.WithValues("controller", name)), builder.OnlyMetadata).
+		Watches(&source.Kind{Type: &azurev1alpha3.ResourceGroup{}}, dependency.EnqueueRequestsForDependents(mgr.GetClient(), &v1beta1.PostgreSQLServerList{}, l.WithValues("controller", name)), builder.WithPredicates(dependency.BecameAvailable())).
+		Complete(chain.NewReconciler(mgr, resource.ManagedKind(v1beta1.PostgreSQLServerGroupVersionKind),
+			managed.NewReconciler(mgr,
+				resource.ManagedKind(v1beta1.PostgreSQLServerGroupVersionKind),
+				managed.WithConnectionPublishers(connection.NewNamespacePolicyPublisher(connection.DefaultNamespacePolicy, connection.NewTemplatingPublisher(connection.NewKeyVaultPublisher(mgr.GetClient(), mgr.GetScheme(),
+					managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme()),
+					connection.NewAdditionalNamespacesPublisher(mgr.GetClient(), mgr.GetScheme()))))),
+				managed.WithExternalConnecter(chain.NewConnecter(mgr, v1beta1.PostgreSQLServerGroupKind, &connecter{client: mgr.GetClient()})),
+				managed.WithInitializers(managed.NewNameAsExternalName(mgr.GetClient()), externalname.NewInitializer(mgr.GetClient()), tenancy.NewDefaultProviderInitializer(mgr.GetClient()), location.NewInitializer(mgr.GetClient()), defaults.NewInitializer(mgr.GetClient()), connection.NewNamespaceInitializer(mgr.GetClient(), connection.DefaultNamespacePolicy)),
+				managed.WithReferenceResolver(dependency.NewReferenceResolver(mgr.GetClient())),
+				managed.WithLogger(l.WithValues("controller", name)),
+				managed.WithRecorder(recorder)),
+			sel))
 }
 
 type connecter struct {
@@ -77,19 +104,30 @@ type connecter struct {
 }
 
 func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1beta1.PostgreSQLServer)
+	if !ok {
+		return nil, errors.New(errNotPostgreSQLServer)
+	}
 	creds, auth, err := azure.GetAuthInfo(ctx, c.client, mg)
 	if err != nil {
 		return nil, err
 	}
-	cl := postgresql.NewServersClient(creds[azure.CredentialsKeySubscriptionID])
+	cl := postgresql.NewServersClientWithBaseURI(azure.BaseURI(creds), azure.SubscriptionID(creds, cr.Spec.ForProvider.SubscriptionID))
 	cl.Authorizer = auth
-	return &external{kube: c.client, client: database.NewPostgreSQLServerClient(cl), newPasswordFn: password.Generate}, nil
+	cl.RequestInspector = azure.WithClientRequestIDFromContext()
+	tp, err := azure.TagPolicyOf(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &external{kube: c.client, client: database.NewPostgreSQLServerClient(cl), newPasswordFn: password.Generate, probe: probe.TCP, tagPolicy: tp}, nil
 }
 
 type external struct {
 	kube          client.Client
 	client        database.PostgreSQLServerAPI
 	newPasswordFn func() (password string, err error)
+	probe         probe.Fn
+	tagPolicy     apisv1beta1.TagPolicy
 }
 
 func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -102,18 +140,28 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		if err := azure.FetchAsyncOperation(ctx, e.client.GetRESTClient(), &cr.Status.AtProvider.LastOperation); err != nil {
 			return managed.ExternalObservation{}, errors.Wrap(err, errFetchLastOperation)
 		}
+		op := cr.Status.AtProvider.LastOperation
+		if op.Method == "PUT" {
+			cr.SetConditions(azure.LastOperationCondition(op))
+		}
+		// A create that failed, e.g. because the quota of the subscription is
+		// exhausted or the SKU is not available in the region, would fail the
+		// same way if it was repeated, so it is not repeated until the spec
+		// changes.
+		if op.Method == "PUT" && azure.IsAsyncOperationFailed(op) && op.Generation == cr.GetGeneration() {
+			return managed.ExternalObservation{}, errors.Errorf(errLastCreateFailed, azure.LastOperationCondition(op).Message)
+		}
 		// Azure returns NotFound for GET calls until creation is completed
 		// successfully and we cannot return `ResourceExists: false` during creation
 		// since this will cause `Create` to be called again and it's not idempotent.
 		// So, we check whether a creation operation in fact is in motion.
-		creating := cr.Status.AtProvider.LastOperation.Method == "PUT" &&
-			cr.Status.AtProvider.LastOperation.Status == azure.AsyncOperationStatusInProgress
+		creating := op.Method == "PUT" && azure.IsAsyncOperationInFlight(op)
 		return managed.ExternalObservation{ResourceExists: creating}, nil
 	}
 	if err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(err, errGetPostgreSQLServer)
 	}
-	database.LateInitializePostgreSQL(&cr.Spec.ForProvider, server)
+	database.LateInitializePostgreSQL(&cr.Spec.ForProvider, server, e.tagPolicy)
 	if err := e.kube.Update(ctx, cr); err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(err, errUpdateCR)
 	}
@@ -125,10 +173,13 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	if err := azure.FetchAsyncOperation(ctx, e.client.GetRESTClient(), &cr.Status.AtProvider.LastOperation); err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(err, errFetchLastOperation)
 	}
+	if cr.Status.AtProvider.LastOperation.Method != "" {
+		cr.SetConditions(azure.LastOperationCondition(cr.Status.AtProvider.LastOperation))
+	}
 	// Any state beside 'ready' is considered unavailable.
 	switch server.UserVisibleState { //nolint:exhaustive
 	case v1beta1.StateReady:
-		cr.SetConditions(xpv1.Available())
+		cr.SetConditions(probe.Condition(ctx, cr, e.probe, cr.Status.AtProvider.FullyQualifiedDomainName, v1beta1.PostgreSQLServerPort))
 	default:
 		cr.SetConditions(xpv1.Unavailable())
 	}
@@ -139,6 +190,7 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		ConnectionDetails: managed.ConnectionDetails{
 			xpv1.ResourceCredentialsSecretEndpointKey: []byte(cr.Status.AtProvider.FullyQualifiedDomainName),
 			xpv1.ResourceCredentialsSecretUserKey:     []byte(fmt.Sprintf("%s@%s", cr.Spec.ForProvider.AdministratorLogin, meta.GetExternalName(cr))),
+			database.ServerCACertificateKey:           []byte(database.ServerCACertificates),
 			xpv1.ResourceCredentialsSecretPortKey:     []byte(v1beta1.PostgreSQLServerPort),
 		},
 	}
@@ -153,6 +205,11 @@ func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 	}
 
 	cr.SetConditions(xpv1.Creating())
+	if azure.ToString(cr.Spec.ForProvider.StorageProfile.GeoRedundantBackup) == "Enabled" {
+		if err := azure.ValidateGeoRedundancy(cr.Spec.ForProvider.Location); err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errCreatePostgreSQLServer)
+		}
+	}
 
 	pw, err := e.newPasswordFn()
 	if err != nil {
@@ -176,7 +233,7 @@ func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 	if !ok {
 		return managed.ExternalUpdate{}, errors.New(errNotPostgreSQLServer)
 	}
-	if cr.Status.AtProvider.LastOperation.Status == azure.AsyncOperationStatusInProgress {
+	if azure.IsAsyncOperationInFlight(cr.Status.AtProvider.LastOperation) {
 		return managed.ExternalUpdate{}, nil
 	}
 	if err := e.client.UpdateServer(ctx, cr); err != nil {
@@ -0,0 +1,174 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemaregistrygroup
+
+import (
+	"context"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-azure/apis/eventhub/v1alpha1"
+	azurev1alpha3 "github.com/crossplane/provider-azure/apis/v1alpha3"
+	"github.com/crossplane/provider-azure/pkg/chain"
+	azureclients "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/eventhub"
+	"github.com/crossplane/provider-azure/pkg/concurrency"
+	"github.com/crossplane/provider-azure/pkg/credentials"
+	"github.com/crossplane/provider-azure/pkg/defaults"
+	"github.com/crossplane/provider-azure/pkg/dependency"
+	"github.com/crossplane/provider-azure/pkg/externalname"
+	"github.com/crossplane/provider-azure/pkg/tenancy"
+)
+
+// Error strings.
+const (
+	errNotSchemaRegistryGroup    = "managed resource is not a SchemaRegistryGroup"
+	errCreateSchemaRegistryGroup = "cannot create schema registry group"
+	errUpdateSchemaRegistryGroup = "cannot update schema registry group"
+	errGetSchemaRegistryGroup    = "cannot get schema registry group"
+	errDeleteSchemaRegistryGroup = "cannot delete schema registry group"
+)
+
+// Setup adds a controller that reconciles SchemaRegistryGroups.
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, sel labels.Selector) error {
+	name := managed.ControllerName(v1alpha1.SchemaRegistryGroupGroupKind)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: concurrency.DefaultLimits.Of(v1alpha1.SchemaRegistryGroupGroupKind),
+		}).
+		For(&v1alpha1.SchemaRegistryGroup{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, credentials.EnqueueRequestsForRotation(mgr.GetClient(), &v1alpha1.SchemaRegistryGroupList{}, l.WithValues("controller", name)), builder.OnlyMetadata).
+		Watches(&source.Kind{Type: &azurev1alpha3.ResourceGroup{}}, dependency.EnqueueRequestsForDependents(mgr.GetClient(), &v1alpha1.SchemaRegistryGroupList{}, l.WithValues("controller", name)), builder.WithPredicates(dependency.BecameAvailable())).
+		Complete(chain.NewReconciler(mgr, resource.ManagedKind(v1alpha1.SchemaRegistryGroupGroupVersionKind),
+			managed.NewReconciler(mgr,
+				resource.ManagedKind(v1alpha1.SchemaRegistryGroupGroupVersionKind),
+				managed.WithConnectionPublishers(),
+				managed.WithExternalConnecter(chain.NewConnecter(mgr, v1alpha1.SchemaRegistryGroupGroupKind, &connecter{client: mgr.GetClient()})),
+				managed.WithInitializers(managed.NewNameAsExternalName(mgr.GetClient()), externalname.NewInitializer(mgr.GetClient()), tenancy.NewDefaultProviderInitializer(mgr.GetClient()), defaults.NewInitializer(mgr.GetClient())),
+				managed.WithReferenceResolver(dependency.NewReferenceResolver(mgr.GetClient())),
+				managed.WithLogger(l.WithValues("controller", name)),
+				managed.WithRecorder(recorder)),
+			sel))
+}
+
+type connecter struct {
+	client client.Client
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	g, ok := mg.(*v1alpha1.SchemaRegistryGroup)
+	if !ok {
+		return nil, errors.New(errNotSchemaRegistryGroup)
+	}
+	creds, auth, err := azureclients.GetAuthInfo(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	cl := eventhub.NewSchemaGroupsClientWithBaseURI(azureclients.BaseURI(creds), azureclients.SubscriptionID(creds, g.Spec.ForProvider.SubscriptionID))
+	cl.Authorizer = auth
+	return &external{client: cl}, nil
+}
+
+type external struct {
+	client eventhub.SchemaGroupsAPI
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	g, ok := mg.(*v1alpha1.SchemaRegistryGroup)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotSchemaRegistryGroup)
+	}
+
+	p := g.Spec.ForProvider
+	az, err := e.client.Get(ctx, p.ResourceGroupName, p.NamespaceName, meta.GetExternalName(g))
+	if azureclients.IsNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetSchemaRegistryGroup)
+	}
+
+	current := g.Spec.ForProvider.DeepCopy()
+	eventhub.LateInitializeSchemaGroup(&g.Spec.ForProvider, az)
+
+	// Schema registry groups have no provisioning state; they are ready to
+	// hold schemas as soon as Azure returns them.
+	g.Status.AtProvider = eventhub.GenerateSchemaGroupObservation(az)
+	g.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        !eventhub.SchemaGroupNeedsUpdate(g.Spec.ForProvider, az),
+		ResourceLateInitialized: !cmp.Equal(current, &g.Spec.ForProvider),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	g, ok := mg.(*v1alpha1.SchemaRegistryGroup)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotSchemaRegistryGroup)
+	}
+
+	g.SetConditions(xpv1.Creating())
+	p := g.Spec.ForProvider
+	err := e.client.CreateOrUpdate(ctx, p.ResourceGroupName, p.NamespaceName, meta.GetExternalName(g), eventhub.NewSchemaGroup(p))
+	return managed.ExternalCreation{}, errors.Wrap(err, errCreateSchemaRegistryGroup)
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	g, ok := mg.(*v1alpha1.SchemaRegistryGroup)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotSchemaRegistryGroup)
+	}
+
+	p := g.Spec.ForProvider
+	err := e.client.CreateOrUpdate(ctx, p.ResourceGroupName, p.NamespaceName, meta.GetExternalName(g), eventhub.NewSchemaGroup(p))
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateSchemaRegistryGroup)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	g, ok := mg.(*v1alpha1.SchemaRegistryGroup)
+	if !ok {
+		return errors.New(errNotSchemaRegistryGroup)
+	}
+
+	g.SetConditions(xpv1.Deleting())
+	p := g.Spec.ForProvider
+	err := e.client.Delete(ctx, p.ResourceGroupName, p.NamespaceName, meta.GetExternalName(g))
+	return errors.Wrap(resource.Ignore(azureclients.IsNotFound, err), errDeleteSchemaRegistryGroup)
+}
@@ -0,0 +1,261 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemaregistrygroup
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/eventhub/v1alpha1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/eventhub"
+	"github.com/crossplane/provider-azure/pkg/clients/eventhub/fake"
+)
+
+const (
+	name      = "coolGroup"
+	namespace = "coolNamespace"
+)
+
+var errBoom = errors.New("boom")
+
+type groupModifier func(*v1alpha1.SchemaRegistryGroup)
+
+func withConditions(c ...xpv1.Condition) groupModifier {
+	return func(g *v1alpha1.SchemaRegistryGroup) { g.Status.ConditionedStatus.Conditions = c }
+}
+
+func withSchemaCompatibility(c v1alpha1.SchemaCompatibility) groupModifier {
+	return func(g *v1alpha1.SchemaRegistryGroup) { g.Spec.ForProvider.SchemaCompatibility = &c }
+}
+
+func withSchemaType(t v1alpha1.SchemaType) groupModifier {
+	return func(g *v1alpha1.SchemaRegistryGroup) { g.Spec.ForProvider.SchemaType = &t }
+}
+
+func withID(id string) groupModifier {
+	return func(g *v1alpha1.SchemaRegistryGroup) { g.Status.AtProvider.ID = id }
+}
+
+func group(m ...groupModifier) *v1alpha1.SchemaRegistryGroup {
+	g := &v1alpha1.SchemaRegistryGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1alpha1.SchemaRegistryGroupSpec{
+			ForProvider: v1alpha1.SchemaRegistryGroupParameters{ResourceGroupName: "coolRG", NamespaceName: namespace},
+		},
+	}
+	meta.SetExternalName(g, name)
+	for _, fn := range m {
+		fn(g)
+	}
+	return g
+}
+
+func observed(c v1alpha1.SchemaCompatibility) eventhub.SchemaGroup {
+	return eventhub.SchemaGroup{
+		ID: azure.ToStringPtr("id"),
+		Properties: &eventhub.SchemaGroupProperties{
+			SchemaCompatibility: azure.ToStringPtr(string(c)),
+			SchemaType:          azure.ToStringPtr(string(v1alpha1.SchemaTypeAvro)),
+		},
+	}
+}
+
+var _ managed.ExternalClient = &external{}
+var _ managed.ExternalConnecter = &connecter{}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   want
+	}{
+		"NotSchemaRegistryGroup": {
+			reason: "An error should be returned if the managed resource is not a SchemaRegistryGroup",
+			e:      &external{client: &fake.MockSchemaGroupsClient{}},
+			want:   want{err: errors.New(errNotSchemaRegistryGroup)},
+		},
+		"NotFound": {
+			reason: "A schema registry group that is not found should not exist",
+			e: &external{client: &fake.MockSchemaGroupsClient{
+				MockGet: func(_ context.Context, _, _, _ string) (eventhub.SchemaGroup, error) {
+					return eventhub.SchemaGroup{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			}},
+			mg:   group(),
+			want: want{mg: group()},
+		},
+		"GetFailed": {
+			reason: "Errors getting the schema registry group should be returned",
+			e: &external{client: &fake.MockSchemaGroupsClient{
+				MockGet: func(_ context.Context, _, _, _ string) (eventhub.SchemaGroup, error) {
+					return eventhub.SchemaGroup{}, errBoom
+				},
+			}},
+			mg:   group(),
+			want: want{mg: group(), err: errors.Wrap(errBoom, errGetSchemaRegistryGroup)},
+		},
+		"LateInitialized": {
+			reason: "The compatibility and type of a schema registry group should be late initialized",
+			e: &external{client: &fake.MockSchemaGroupsClient{
+				MockGet: func(_ context.Context, _, _, _ string) (eventhub.SchemaGroup, error) {
+					return observed(v1alpha1.SchemaCompatibilityNone), nil
+				},
+			}},
+			mg: group(),
+			want: want{
+				mg: group(
+					withSchemaCompatibility(v1alpha1.SchemaCompatibilityNone),
+					withSchemaType(v1alpha1.SchemaTypeAvro),
+					withID("id"),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true},
+			},
+		},
+		"CompatibilityChanged": {
+			reason: "A schema registry group whose compatibility differs should need an update",
+			e: &external{client: &fake.MockSchemaGroupsClient{
+				MockGet: func(_ context.Context, _, _, _ string) (eventhub.SchemaGroup, error) {
+					return observed(v1alpha1.SchemaCompatibilityNone), nil
+				},
+			}},
+			mg: group(withSchemaCompatibility(v1alpha1.SchemaCompatibilityBackward), withSchemaType(v1alpha1.SchemaTypeAvro)),
+			want: want{
+				mg: group(
+					withSchemaCompatibility(v1alpha1.SchemaCompatibilityBackward),
+					withSchemaType(v1alpha1.SchemaTypeAvro),
+					withID("id"),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o, err := tc.e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want managed, +got managed:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   error
+	}{
+		"Success": {
+			reason: "The schema registry group should be created in the namespace of its spec",
+			e: &external{client: &fake.MockSchemaGroupsClient{
+				MockCreateOrUpdate: func(_ context.Context, _, ns, _ string, g eventhub.SchemaGroup) error {
+					if ns != namespace {
+						return errors.Errorf("unexpected namespace %s", ns)
+					}
+					if azure.ToString(g.Properties.SchemaType) != string(v1alpha1.SchemaTypeAvro) {
+						return errors.New("schema type should default to Avro")
+					}
+					return nil
+				},
+			}},
+			mg: group(),
+		},
+		"Failed": {
+			reason: "Errors creating the schema registry group should be returned",
+			e: &external{client: &fake.MockSchemaGroupsClient{
+				MockCreateOrUpdate: func(_ context.Context, _, _, _ string, _ eventhub.SchemaGroup) error { return errBoom },
+			}},
+			mg:   group(),
+			want: errors.Wrap(errBoom, errCreateSchemaRegistryGroup),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   error
+	}{
+		"NotFound": {
+			reason: "A schema registry group that is already gone should be considered deleted",
+			e: &external{client: &fake.MockSchemaGroupsClient{
+				MockDelete: func(_ context.Context, _, _, _ string) error {
+					return autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			}},
+			mg: group(),
+		},
+		"Failed": {
+			reason: "Errors deleting the schema registry group should be returned",
+			e: &external{client: &fake.MockSchemaGroupsClient{
+				MockDelete: func(_ context.Context, _, _, _ string) error { return errBoom },
+			}},
+			mg:   group(),
+			want: errors.Wrap(errBoom, errDeleteSchemaRegistryGroup),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
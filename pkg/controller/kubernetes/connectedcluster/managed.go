@@ -0,0 +1,202 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package connectedcluster
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/hybridkubernetes/mgmt/2020-01-01-preview/hybridkubernetes"
+	"github.com/Azure/azure-sdk-for-go/services/preview/hybridkubernetes/mgmt/2020-01-01-preview/hybridkubernetes/hybridkubernetesapi"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-azure/apis/kubernetes/v1alpha1"
+	azurev1alpha3 "github.com/crossplane/provider-azure/apis/v1alpha3"
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+	"github.com/crossplane/provider-azure/pkg/chain"
+	azureclients "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/kubernetes"
+	"github.com/crossplane/provider-azure/pkg/concurrency"
+	"github.com/crossplane/provider-azure/pkg/credentials"
+	"github.com/crossplane/provider-azure/pkg/defaults"
+	"github.com/crossplane/provider-azure/pkg/dependency"
+	"github.com/crossplane/provider-azure/pkg/externalname"
+	"github.com/crossplane/provider-azure/pkg/location"
+	"github.com/crossplane/provider-azure/pkg/tenancy"
+)
+
+// Error strings.
+const (
+	errNotConnectedCluster    = "managed resource is not a ConnectedCluster"
+	errCreateConnectedCluster = "cannot create connected cluster"
+	errUpdateConnectedCluster = "cannot update connected cluster"
+	errGetConnectedCluster    = "cannot get connected cluster"
+	errDeleteConnectedCluster = "cannot delete connected cluster"
+)
+
+// Setup adds a controller that reconciles ConnectedClusters.
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, sel labels.Selector) error {
+	name := managed.ControllerName(v1alpha1.ConnectedClusterGroupKind)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: concurrency.DefaultLimits.Of(v1alpha1.ConnectedClusterGroupKind),
+		}).
+		For(&v1alpha1.ConnectedCluster{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, credentials.EnqueueRequestsForRotation(mgr.GetClient(), &v1alpha1.ConnectedClusterList{}, l.WithValues("controller", name)), builder.OnlyMetadata).
+		Watches(&source.Kind{Type: &azurev1alpha3.ResourceGroup{}}, dependency.EnqueueRequestsForDependents(mgr.GetClient(), &v1alpha1.ConnectedClusterList{}, l.WithValues("controller", name)), builder.WithPredicates(dependency.BecameAvailable())).
+		Complete(chain.NewReconciler(mgr, resource.ManagedKind(v1alpha1.ConnectedClusterGroupVersionKind),
+			managed.NewReconciler(mgr,
+				resource.ManagedKind(v1alpha1.ConnectedClusterGroupVersionKind),
+				managed.WithExternalConnecter(chain.NewConnecter(mgr, v1alpha1.ConnectedClusterGroupKind, &connecter{client: mgr.GetClient()})),
+				managed.WithInitializers(managed.NewNameAsExternalName(mgr.GetClient()), externalname.NewInitializer(mgr.GetClient()), tenancy.NewDefaultProviderInitializer(mgr.GetClient()), location.NewInitializer(mgr.GetClient()), defaults.NewInitializer(mgr.GetClient())),
+				managed.WithReferenceResolver(dependency.NewReferenceResolver(mgr.GetClient())),
+				managed.WithLogger(l.WithValues("controller", name)),
+				managed.WithRecorder(recorder)),
+			sel))
+}
+
+type connecter struct {
+	client client.Client
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.ConnectedCluster)
+	if !ok {
+		return nil, errors.New(errNotConnectedCluster)
+	}
+	creds, auth, err := azureclients.GetAuthInfo(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	cl := hybridkubernetes.NewConnectedClusterClientWithBaseURI(azureclients.BaseURI(creds), azureclients.SubscriptionID(creds, cr.Spec.ForProvider.SubscriptionID))
+	cl.Authorizer = auth
+	tp, err := azureclients.TagPolicyOf(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &external{client: cl, creds: creds, newKeyPair: kubernetes.NewAgentKeyPair, tagPolicy: tp}, nil
+}
+
+type external struct {
+	client     hybridkubernetesapi.ConnectedClusterClientAPI
+	creds      map[string]string
+	newKeyPair func() (string, []byte, error)
+	tagPolicy  v1beta1.TagPolicy
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.ConnectedCluster)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotConnectedCluster)
+	}
+
+	az, err := e.client.Get(ctx, cr.Spec.ForProvider.ResourceGroupName, meta.GetExternalName(cr))
+	if azureclients.IsNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetConnectedCluster)
+	}
+
+	current := cr.Spec.ForProvider.DeepCopy()
+	kubernetes.LateInitializeConnectedCluster(&cr.Spec.ForProvider, az, e.tagPolicy)
+
+	cr.Status.AtProvider = kubernetes.GenerateConnectedClusterObservation(az)
+	switch hybridkubernetes.ProvisioningState(cr.Status.AtProvider.ProvisioningState) {
+	case hybridkubernetes.Succeeded:
+		cr.SetConditions(xpv1.Available())
+	case hybridkubernetes.Failed, hybridkubernetes.Canceled:
+		cr.SetConditions(xpv1.Unavailable())
+	default:
+		cr.SetConditions(xpv1.Creating())
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        !kubernetes.ConnectedClusterNeedsUpdate(cr.Spec.ForProvider, az),
+		ResourceLateInitialized: !cmp.Equal(current, &cr.Spec.ForProvider),
+		ConnectionDetails:       kubernetes.ConnectionDetails(cr, e.creds),
+	}, nil
+}
+
+// Create the connected cluster of the supplied ConnectedCluster. Unless it
+// specifies the public key of its agents a key pair is generated, whose
+// private key is only ever published here since Azure does not return it.
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.ConnectedCluster)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotConnectedCluster)
+	}
+
+	cr.SetConditions(xpv1.Creating())
+	cd := kubernetes.ConnectionDetails(cr, e.creds)
+	pub := azureclients.ToString(cr.Spec.ForProvider.AgentPublicKeyCertificate)
+	if pub == "" {
+		p, priv, err := e.newKeyPair()
+		if err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errCreateConnectedCluster)
+		}
+		pub = p
+		cd[kubernetes.ConnectionKeyOnboardingPrivateKey] = priv
+	}
+	_, err := e.client.Create(ctx, cr.Spec.ForProvider.ResourceGroupName, meta.GetExternalName(cr), kubernetes.NewConnectedCluster(cr.Spec.ForProvider, pub))
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateConnectedCluster)
+	}
+	return managed.ExternalCreation{ConnectionDetails: cd}, nil
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.ConnectedCluster)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotConnectedCluster)
+	}
+
+	_, err := e.client.Update(ctx, cr.Spec.ForProvider.ResourceGroupName, meta.GetExternalName(cr), kubernetes.NewConnectedClusterPatch(cr.Spec.ForProvider))
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateConnectedCluster)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.ConnectedCluster)
+	if !ok {
+		return errors.New(errNotConnectedCluster)
+	}
+
+	cr.SetConditions(xpv1.Deleting())
+	_, err := e.client.Delete(ctx, cr.Spec.ForProvider.ResourceGroupName, meta.GetExternalName(cr))
+	return errors.Wrap(resource.Ignore(azureclients.IsNotFound, err), errDeleteConnectedCluster)
+}
@@ -0,0 +1,350 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package connectedcluster
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/hybridkubernetes/mgmt/2020-01-01-preview/hybridkubernetes"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/kubernetes/v1alpha1"
+	azurev1alpha3 "github.com/crossplane/provider-azure/apis/v1alpha3"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/kubernetes"
+	"github.com/crossplane/provider-azure/pkg/clients/kubernetes/fake"
+)
+
+const (
+	name          = "coolCluster"
+	testLocation  = "westus2"
+	resourceGroup = "coolRG"
+	subscription  = "coolSubscription"
+	tenant        = "coolTenant"
+	publicKey     = "coolPublicKey"
+)
+
+var (
+	errBoom = errors.New("boom")
+
+	creds = map[string]string{
+		azure.CredentialsKeySubscriptionID: subscription,
+		azure.CredentialsKeyTenantID:       tenant,
+	}
+	privateKey = []byte("coolPrivateKey")
+)
+
+type clusterModifier func(*v1alpha1.ConnectedCluster)
+
+func withConditions(c ...xpv1.Condition) clusterModifier {
+	return func(r *v1alpha1.ConnectedCluster) { r.Status.ConditionedStatus.Conditions = c }
+}
+
+func withAgentPublicKey(k string) clusterModifier {
+	return func(r *v1alpha1.ConnectedCluster) {
+		r.Spec.ForProvider.AgentPublicKeyCertificate = azure.ToStringPtr(k)
+	}
+}
+
+func withState(s string) clusterModifier {
+	return func(r *v1alpha1.ConnectedCluster) { r.Status.AtProvider.ProvisioningState = s }
+}
+
+func cluster(m ...clusterModifier) *v1alpha1.ConnectedCluster {
+	r := &v1alpha1.ConnectedCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1alpha1.ConnectedClusterSpec{
+			ForProvider: v1alpha1.ConnectedClusterParameters{
+				ResourceGroupName: resourceGroup,
+				Location:          testLocation,
+			},
+		},
+	}
+	meta.SetExternalName(r, name)
+	for _, fn := range m {
+		fn(r)
+	}
+	return r
+}
+
+func observed(state hybridkubernetes.ProvisioningState) hybridkubernetes.ConnectedCluster {
+	return hybridkubernetes.ConnectedCluster{
+		Location: azure.ToStringPtr(testLocation),
+		ConnectedClusterProperties: &hybridkubernetes.ConnectedClusterProperties{
+			AgentPublicKeyCertificate: azure.ToStringPtr(publicKey),
+			ProvisioningState:         state,
+		},
+	}
+}
+
+func connectionDetails() managed.ConnectionDetails {
+	return managed.ConnectionDetails{
+		kubernetes.ConnectionKeySubscriptionID:    []byte(subscription),
+		kubernetes.ConnectionKeyTenantID:          []byte(tenant),
+		kubernetes.ConnectionKeyResourceGroupName: []byte(resourceGroup),
+		kubernetes.ConnectionKeyResourceName:      []byte(name),
+		kubernetes.ConnectionKeyLocation:          []byte(testLocation),
+	}
+}
+
+var _ managed.ExternalClient = &external{}
+var _ managed.ExternalConnecter = &connecter{}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   want
+	}{
+		"NotConnectedCluster": {
+			reason: "An error should be returned if the managed resource is not a ConnectedCluster",
+			e:      &external{client: &fake.MockConnectedClusterClient{}},
+			mg:     &azurev1alpha3.ResourceGroup{},
+			want:   want{mg: &azurev1alpha3.ResourceGroup{}, err: errors.New(errNotConnectedCluster)},
+		},
+		"NotFound": {
+			reason: "A connected cluster that is not found should not exist",
+			e: &external{client: &fake.MockConnectedClusterClient{
+				MockGet: func(_ context.Context, _, _ string) (hybridkubernetes.ConnectedCluster, error) {
+					return hybridkubernetes.ConnectedCluster{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			}},
+			mg:   cluster(),
+			want: want{mg: cluster()},
+		},
+		"GetFailed": {
+			reason: "Errors getting the connected cluster should be returned",
+			e: &external{client: &fake.MockConnectedClusterClient{
+				MockGet: func(_ context.Context, _, _ string) (hybridkubernetes.ConnectedCluster, error) {
+					return hybridkubernetes.ConnectedCluster{}, errBoom
+				},
+			}},
+			mg:   cluster(),
+			want: want{mg: cluster(), err: errors.Wrap(errBoom, errGetConnectedCluster)},
+		},
+		"Provisioning": {
+			reason: "A connected cluster that is still being provisioned should be creating, and its agent public key late initialized",
+			e: &external{creds: creds, client: &fake.MockConnectedClusterClient{
+				MockGet: func(_ context.Context, _, _ string) (hybridkubernetes.ConnectedCluster, error) {
+					return observed(hybridkubernetes.Provisioning), nil
+				},
+			}},
+			mg: cluster(),
+			want: want{
+				mg: cluster(withAgentPublicKey(publicKey), withState("Provisioning"), withConditions(xpv1.Creating())),
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+					ConnectionDetails:       connectionDetails(),
+				},
+			},
+		},
+		"Available": {
+			reason: "A connected cluster that was provisioned should be available and publish the values its agents are installed with",
+			e: &external{creds: creds, client: &fake.MockConnectedClusterClient{
+				MockGet: func(_ context.Context, _, _ string) (hybridkubernetes.ConnectedCluster, error) {
+					return observed(hybridkubernetes.Succeeded), nil
+				},
+			}},
+			mg: cluster(withAgentPublicKey(publicKey)),
+			want: want{
+				mg: cluster(withAgentPublicKey(publicKey), withState("Succeeded"), withConditions(xpv1.Available())),
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: connectionDetails(),
+				},
+			},
+		},
+		"Failed": {
+			reason: "A connected cluster that failed to be provisioned should be unavailable",
+			e: &external{creds: creds, client: &fake.MockConnectedClusterClient{
+				MockGet: func(_ context.Context, _, _ string) (hybridkubernetes.ConnectedCluster, error) {
+					return observed(hybridkubernetes.Failed), nil
+				},
+			}},
+			mg: cluster(withAgentPublicKey(publicKey)),
+			want: want{
+				mg: cluster(withAgentPublicKey(publicKey), withState("Failed"), withConditions(xpv1.Unavailable())),
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: connectionDetails(),
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o, err := tc.e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want managed, +got managed:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type want struct {
+		c   managed.ExternalCreation
+		err error
+	}
+
+	withPrivateKey := connectionDetails()
+	withPrivateKey[kubernetes.ConnectionKeyOnboardingPrivateKey] = privateKey
+
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   want
+	}{
+		"GeneratedKeyPair": {
+			reason: "A key pair should be generated for the agents of a connected cluster whose public key is not specified, and its private key published",
+			e: &external{
+				creds:      creds,
+				newKeyPair: func() (string, []byte, error) { return publicKey, privateKey, nil },
+				client: &fake.MockConnectedClusterClient{
+					MockCreate: func(_ context.Context, _, _ string, c hybridkubernetes.ConnectedCluster) (hybridkubernetes.ConnectedClusterCreateFuture, error) {
+						if diff := cmp.Diff(publicKey, azure.ToString(c.AgentPublicKeyCertificate)); diff != "" {
+							t.Errorf("Create(...): -want public key, +got public key:\n%s", diff)
+						}
+						return hybridkubernetes.ConnectedClusterCreateFuture{}, nil
+					},
+				},
+			},
+			mg:   cluster(),
+			want: want{c: managed.ExternalCreation{ConnectionDetails: withPrivateKey}},
+		},
+		"SpecifiedPublicKey": {
+			reason: "No key pair should be generated for the agents of a connected cluster whose public key is specified",
+			e: &external{
+				creds:      creds,
+				newKeyPair: func() (string, []byte, error) { return "", nil, errBoom },
+				client: &fake.MockConnectedClusterClient{
+					MockCreate: func(_ context.Context, _, _ string, c hybridkubernetes.ConnectedCluster) (hybridkubernetes.ConnectedClusterCreateFuture, error) {
+						if diff := cmp.Diff(publicKey, azure.ToString(c.AgentPublicKeyCertificate)); diff != "" {
+							t.Errorf("Create(...): -want public key, +got public key:\n%s", diff)
+						}
+						return hybridkubernetes.ConnectedClusterCreateFuture{}, nil
+					},
+				},
+			},
+			mg:   cluster(withAgentPublicKey(publicKey)),
+			want: want{c: managed.ExternalCreation{ConnectionDetails: connectionDetails()}},
+		},
+		"KeyPairFailed": {
+			reason: "Errors generating the agent key pair should be returned",
+			e: &external{
+				creds:      creds,
+				newKeyPair: func() (string, []byte, error) { return "", nil, errBoom },
+				client:     &fake.MockConnectedClusterClient{},
+			},
+			mg:   cluster(),
+			want: want{err: errors.Wrap(errBoom, errCreateConnectedCluster)},
+		},
+		"CreateFailed": {
+			reason: "Errors creating the connected cluster should be returned",
+			e: &external{
+				creds: creds,
+				client: &fake.MockConnectedClusterClient{
+					MockCreate: func(_ context.Context, _, _ string, _ hybridkubernetes.ConnectedCluster) (hybridkubernetes.ConnectedClusterCreateFuture, error) {
+						return hybridkubernetes.ConnectedClusterCreateFuture{}, errBoom
+					},
+				},
+			},
+			mg:   cluster(withAgentPublicKey(publicKey)),
+			want: want{err: errors.Wrap(errBoom, errCreateConnectedCluster)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c, err := tc.e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.c, c); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   error
+	}{
+		"NotFound": {
+			reason: "A connected cluster that is already gone should be deleted",
+			e: &external{client: &fake.MockConnectedClusterClient{
+				MockDelete: func(_ context.Context, _, _ string) (hybridkubernetes.ConnectedClusterDeleteFuture, error) {
+					return hybridkubernetes.ConnectedClusterDeleteFuture{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			}},
+			mg: cluster(),
+		},
+		"Failed": {
+			reason: "Errors deleting the connected cluster should be returned",
+			e: &external{client: &fake.MockConnectedClusterClient{
+				MockDelete: func(_ context.Context, _, _ string) (hybridkubernetes.ConnectedClusterDeleteFuture, error) {
+					return hybridkubernetes.ConnectedClusterDeleteFuture{}, errBoom
+				},
+			}},
+			mg:   cluster(),
+			want: errors.Wrap(errBoom, errDeleteConnectedCluster),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,288 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/kusto/v1alpha1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/kusto"
+	"github.com/crossplane/provider-azure/pkg/clients/kusto/fake"
+)
+
+const (
+	name             = "coolcluster"
+	testLocation     = "westeurope"
+	uri              = "https://coolcluster.westeurope.kusto.windows.net"
+	dataIngestionURI = "https://ingest-coolcluster.westeurope.kusto.windows.net"
+)
+
+var errBoom = errors.New("boom")
+
+type clusterModifier func(*v1alpha1.KustoCluster)
+
+func withConditions(c ...xpv1.Condition) clusterModifier {
+	return func(cr *v1alpha1.KustoCluster) { cr.Status.ConditionedStatus.Conditions = c }
+}
+
+func withCapacity(c int32) clusterModifier {
+	return func(cr *v1alpha1.KustoCluster) { cr.Spec.ForProvider.SKU.Capacity = &c }
+}
+
+func withLateInitialized() clusterModifier {
+	return func(cr *v1alpha1.KustoCluster) {
+		c := int32(2)
+		cr.Spec.ForProvider.Location = testLocation
+		cr.Spec.ForProvider.SKU.Capacity = &c
+	}
+}
+
+func withObservation(provisioningState, state string) clusterModifier {
+	return func(cr *v1alpha1.KustoCluster) {
+		cr.Status.AtProvider = v1alpha1.KustoClusterObservation{
+			State:             state,
+			ProvisioningState: provisioningState,
+			URI:               uri,
+			DataIngestionURI:  dataIngestionURI,
+		}
+	}
+}
+
+func cluster(m ...clusterModifier) *v1alpha1.KustoCluster {
+	cr := &v1alpha1.KustoCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1alpha1.KustoClusterSpec{
+			ForProvider: v1alpha1.KustoClusterParameters{
+				ResourceGroupName: "coolRG",
+				SKU:               v1alpha1.KustoClusterSKU{Name: "Standard_E8ads_v5", Tier: "Standard"},
+			},
+		},
+	}
+	meta.SetExternalName(cr, name)
+	for _, fn := range m {
+		fn(cr)
+	}
+	return cr
+}
+
+func observed(provisioningState, state string) kusto.Cluster {
+	capacity := int32(2)
+	return kusto.Cluster{
+		Location: azure.ToStringPtr(testLocation),
+		Sku:      &kusto.ClusterSku{Name: azure.ToStringPtr("Standard_E8ads_v5"), Tier: azure.ToStringPtr("Standard"), Capacity: &capacity},
+		Properties: &kusto.ClusterProperties{
+			State:             azure.ToStringPtr(state),
+			ProvisioningState: azure.ToStringPtr(provisioningState),
+			URI:               azure.ToStringPtr(uri),
+			DataIngestionURI:  azure.ToStringPtr(dataIngestionURI),
+		},
+	}
+}
+
+func mockClient(provisioningState, state string) *fake.MockClustersClient {
+	return &fake.MockClustersClient{
+		MockGetCluster: func(_ context.Context, _, _ string) (kusto.Cluster, error) {
+			return observed(provisioningState, state), nil
+		},
+	}
+}
+
+var _ managed.ExternalClient = &external{}
+var _ managed.ExternalConnecter = &connecter{}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   want
+	}{
+		"NotFound": {
+			reason: "A cluster that is not found should not exist",
+			e: &external{client: &fake.MockClustersClient{
+				MockGetCluster: func(_ context.Context, _, _ string) (kusto.Cluster, error) {
+					return kusto.Cluster{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			}},
+			mg:   cluster(),
+			want: want{mg: cluster()},
+		},
+		"GetFailed": {
+			reason: "Errors getting the cluster should be returned",
+			e: &external{client: &fake.MockClustersClient{
+				MockGetCluster: func(_ context.Context, _, _ string) (kusto.Cluster, error) {
+					return kusto.Cluster{}, errBoom
+				},
+			}},
+			mg:   cluster(),
+			want: want{mg: cluster(), err: errors.Wrap(errBoom, errGetCluster)},
+		},
+		"Creating": {
+			reason: "A cluster that is still being provisioned should be creating and publish no connection details",
+			e:      &external{client: mockClient("Creating", "Creating")},
+			mg:     cluster(),
+			want: want{
+				mg: cluster(withLateInitialized(), withObservation("Creating", "Creating"), withConditions(xpv1.Creating())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true},
+			},
+		},
+		"Available": {
+			reason: "A provisioned cluster should be available and publish its URIs",
+			e:      &external{client: mockClient(kusto.ProvisioningStateSucceeded, "Running")},
+			mg:     cluster(withLateInitialized()),
+			want: want{
+				mg: cluster(withLateInitialized(), withObservation(kusto.ProvisioningStateSucceeded, "Running"), withConditions(xpv1.Available())),
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+					ConnectionDetails: managed.ConnectionDetails{
+						kusto.ConnectionKeyURI:              []byte(uri),
+						kusto.ConnectionKeyDataIngestionURI: []byte(dataIngestionURI),
+					},
+				},
+			},
+		},
+		"Stopped": {
+			reason: "A stopped cluster should be unavailable",
+			e:      &external{client: mockClient(kusto.ProvisioningStateSucceeded, kusto.StateStopped)},
+			mg:     cluster(withLateInitialized()),
+			want: want{
+				mg: cluster(withLateInitialized(), withObservation(kusto.ProvisioningStateSucceeded, kusto.StateStopped), withConditions(xpv1.Unavailable())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"CapacityChanged": {
+			reason: "A cluster whose capacity differs should need an update",
+			e:      &external{client: mockClient(kusto.ProvisioningStateSucceeded, "Running")},
+			mg:     cluster(withLateInitialized(), withCapacity(4)),
+			want: want{
+				mg: cluster(withLateInitialized(), withCapacity(4), withObservation(kusto.ProvisioningStateSucceeded, "Running"), withConditions(xpv1.Available())),
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+					ConnectionDetails: managed.ConnectionDetails{
+						kusto.ConnectionKeyURI:              []byte(uri),
+						kusto.ConnectionKeyDataIngestionURI: []byte(dataIngestionURI),
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o, err := tc.e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want managed, +got managed:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   error
+	}{
+		"StillUpdating": {
+			reason: "A cluster that is still being updated should not be updated again",
+			e:      &external{client: &fake.MockClustersClient{}},
+			mg:     cluster(withObservation("Updating", "Updating")),
+		},
+		"Failed": {
+			reason: "Errors updating the cluster should be returned",
+			e: &external{client: &fake.MockClustersClient{
+				MockCreateOrUpdateCluster: func(_ context.Context, _, _ string, _ kusto.Cluster) error { return errBoom },
+			}},
+			mg:   cluster(withObservation(kusto.ProvisioningStateSucceeded, "Running")),
+			want: errors.Wrap(errBoom, errUpdateCluster),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   error
+	}{
+		"NotFound": {
+			reason: "A cluster that is already gone should be considered deleted",
+			e: &external{client: &fake.MockClustersClient{
+				MockDeleteCluster: func(_ context.Context, _, _ string) error {
+					return autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			}},
+			mg: cluster(),
+		},
+		"Failed": {
+			reason: "Errors deleting the cluster should be returned",
+			e: &external{client: &fake.MockClustersClient{
+				MockDeleteCluster: func(_ context.Context, _, _ string) error { return errBoom },
+			}},
+			mg:   cluster(),
+			want: errors.Wrap(errBoom, errDeleteCluster),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,351 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/kusto/v1alpha1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/kusto"
+	"github.com/crossplane/provider-azure/pkg/clients/kusto/fake"
+)
+
+const (
+	name         = "coolDatabase"
+	clusterName  = "coolCluster"
+	testLocation = "westeurope"
+	softDelete   = "P365D"
+	hotCache     = "P31D"
+)
+
+var errBoom = errors.New("boom")
+
+type databaseModifier func(*v1alpha1.KustoDatabase)
+
+func withConditions(c ...xpv1.Condition) databaseModifier {
+	return func(r *v1alpha1.KustoDatabase) { r.Status.ConditionedStatus.Conditions = c }
+}
+
+func withLocation(l string) databaseModifier {
+	return func(r *v1alpha1.KustoDatabase) { r.Spec.ForProvider.Location = l }
+}
+
+func withSoftDeletePeriod(p string) databaseModifier {
+	return func(r *v1alpha1.KustoDatabase) { r.Spec.ForProvider.SoftDeletePeriod = &p }
+}
+
+func withHotCachePeriod(p string) databaseModifier {
+	return func(r *v1alpha1.KustoDatabase) { r.Spec.ForProvider.HotCachePeriod = &p }
+}
+
+func withState(s string) databaseModifier {
+	return func(r *v1alpha1.KustoDatabase) { r.Status.AtProvider.ProvisioningState = s }
+}
+
+func database(m ...databaseModifier) *v1alpha1.KustoDatabase {
+	r := &v1alpha1.KustoDatabase{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1alpha1.KustoDatabaseSpec{
+			ForProvider: v1alpha1.KustoDatabaseParameters{
+				ResourceGroupName: "coolRG",
+				ClusterName:       clusterName,
+			},
+		},
+	}
+	meta.SetExternalName(r, name)
+	for _, fn := range m {
+		fn(r)
+	}
+	return r
+}
+
+// observed returns a database as Azure reports it, with its periods in lower
+// case.
+func observed(state string) kusto.Database {
+	return kusto.Database{
+		Location: azure.ToStringPtr(testLocation),
+		Properties: &kusto.DatabaseProperties{
+			ProvisioningState: azure.ToStringPtr(state),
+			SoftDeletePeriod:  azure.ToStringPtr("p365d"),
+			HotCachePeriod:    azure.ToStringPtr("p31d"),
+		},
+	}
+}
+
+var _ managed.ExternalClient = &external{}
+var _ managed.ExternalConnecter = &connecter{}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   want
+	}{
+		"NotKustoDatabase": {
+			reason: "An error should be returned if the managed resource is not a KustoDatabase",
+			e:      &external{client: &fake.MockClustersClient{}},
+			mg:     &v1alpha1.KustoScript{},
+			want:   want{mg: &v1alpha1.KustoScript{}, err: errors.New(errNotDatabase)},
+		},
+		"NotFound": {
+			reason: "A database that is not found should not exist",
+			e: &external{client: &fake.MockClustersClient{
+				MockGetDatabase: func(_ context.Context, _, _, _ string) (kusto.Database, error) {
+					return kusto.Database{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			}},
+			mg:   database(),
+			want: want{mg: database()},
+		},
+		"GetFailed": {
+			reason: "Errors getting the database should be returned",
+			e: &external{client: &fake.MockClustersClient{
+				MockGetDatabase: func(_ context.Context, _, _, _ string) (kusto.Database, error) {
+					return kusto.Database{}, errBoom
+				},
+			}},
+			mg:   database(),
+			want: want{mg: database(), err: errors.Wrap(errBoom, errGetDatabase)},
+		},
+		"Provisioning": {
+			reason: "A database that is still being provisioned should be creating, and late initialized",
+			e: &external{client: &fake.MockClustersClient{
+				MockGetDatabase: func(_ context.Context, _, cluster, _ string) (kusto.Database, error) {
+					if diff := cmp.Diff(clusterName, cluster); diff != "" {
+						t.Errorf("GetDatabase(...): -want cluster, +got cluster:\n%s", diff)
+					}
+					return observed("Creating"), nil
+				},
+			}},
+			mg: database(),
+			want: want{
+				mg: database(
+					withLocation(testLocation),
+					withSoftDeletePeriod("p365d"),
+					withHotCachePeriod("p31d"),
+					withState("Creating"),
+					withConditions(xpv1.Creating()),
+				),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true},
+			},
+		},
+		"Succeeded": {
+			reason: "A database that was provisioned should be available, and up to date regardless of the case of its periods",
+			e: &external{client: &fake.MockClustersClient{
+				MockGetDatabase: func(_ context.Context, _, _, _ string) (kusto.Database, error) {
+					return observed(kusto.ProvisioningStateSucceeded), nil
+				},
+			}},
+			mg: database(withLocation(testLocation), withSoftDeletePeriod(softDelete), withHotCachePeriod(hotCache)),
+			want: want{
+				mg: database(
+					withLocation(testLocation),
+					withSoftDeletePeriod(softDelete),
+					withHotCachePeriod(hotCache),
+					withState(kusto.ProvisioningStateSucceeded),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"NeedsUpdate": {
+			reason: "A database whose hot cache period differs from the spec should need an update",
+			e: &external{client: &fake.MockClustersClient{
+				MockGetDatabase: func(_ context.Context, _, _, _ string) (kusto.Database, error) {
+					return observed(kusto.ProvisioningStateSucceeded), nil
+				},
+			}},
+			mg: database(withLocation(testLocation), withSoftDeletePeriod(softDelete), withHotCachePeriod("P7D")),
+			want: want{
+				mg: database(
+					withLocation(testLocation),
+					withSoftDeletePeriod(softDelete),
+					withHotCachePeriod("P7D"),
+					withState(kusto.ProvisioningStateSucceeded),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"Failed": {
+			reason: "A database that failed to be provisioned should be unavailable",
+			e: &external{client: &fake.MockClustersClient{
+				MockGetDatabase: func(_ context.Context, _, _, _ string) (kusto.Database, error) {
+					return observed(kusto.ProvisioningStateFailed), nil
+				},
+			}},
+			mg: database(withLocation(testLocation), withSoftDeletePeriod(softDelete), withHotCachePeriod(hotCache)),
+			want: want{
+				mg: database(
+					withLocation(testLocation),
+					withSoftDeletePeriod(softDelete),
+					withHotCachePeriod(hotCache),
+					withState(kusto.ProvisioningStateFailed),
+					withConditions(xpv1.Unavailable()),
+				),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o, err := tc.e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want managed, +got managed:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   error
+	}{
+		"Successful": {
+			reason: "The database should be created in its cluster with the periods of the spec",
+			e: &external{client: &fake.MockClustersClient{
+				MockCreateOrUpdateDatabase: func(_ context.Context, _, cluster, _ string, d kusto.Database) error {
+					if diff := cmp.Diff(clusterName, cluster); diff != "" {
+						t.Errorf("CreateOrUpdateDatabase(...): -want cluster, +got cluster:\n%s", diff)
+					}
+					if diff := cmp.Diff(softDelete, azure.ToString(d.Properties.SoftDeletePeriod)); diff != "" {
+						t.Errorf("CreateOrUpdateDatabase(...): -want soft delete period, +got soft delete period:\n%s", diff)
+					}
+					return nil
+				},
+			}},
+			mg: database(withSoftDeletePeriod(softDelete)),
+		},
+		"Failed": {
+			reason: "Errors creating the database should be returned",
+			e: &external{client: &fake.MockClustersClient{
+				MockCreateOrUpdateDatabase: func(_ context.Context, _, _, _ string, _ kusto.Database) error {
+					return errBoom
+				},
+			}},
+			mg:   database(),
+			want: errors.Wrap(errBoom, errCreateDatabase),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   error
+	}{
+		"Failed": {
+			reason: "Errors updating the database should be returned",
+			e: &external{client: &fake.MockClustersClient{
+				MockCreateOrUpdateDatabase: func(_ context.Context, _, _, _ string, _ kusto.Database) error {
+					return errBoom
+				},
+			}},
+			mg:   database(),
+			want: errors.Wrap(errBoom, errUpdateDatabase),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   error
+	}{
+		"NotFound": {
+			reason: "A database that is already gone should be deleted",
+			e: &external{client: &fake.MockClustersClient{
+				MockDeleteDatabase: func(_ context.Context, _, _, _ string) error {
+					return autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			}},
+			mg: database(),
+		},
+		"Failed": {
+			reason: "Errors deleting the database should be returned",
+			e: &external{client: &fake.MockClustersClient{
+				MockDeleteDatabase: func(_ context.Context, _, _, _ string) error {
+					return errBoom
+				},
+			}},
+			mg:   database(),
+			want: errors.Wrap(errBoom, errDeleteDatabase),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
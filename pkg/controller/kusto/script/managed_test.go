@@ -0,0 +1,323 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package script
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/kusto/v1alpha1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/kusto"
+	"github.com/crossplane/provider-azure/pkg/clients/kusto/fake"
+)
+
+const (
+	name         = "coolScript"
+	databaseName = "coolDatabase"
+	content      = ".create table CoolTable (Timestamp: datetime, Message: string)"
+)
+
+var errBoom = errors.New("boom")
+
+type scriptModifier func(*v1alpha1.KustoScript)
+
+func withConditions(c ...xpv1.Condition) scriptModifier {
+	return func(r *v1alpha1.KustoScript) { r.Status.ConditionedStatus.Conditions = c }
+}
+
+func withContent(c string) scriptModifier {
+	return func(r *v1alpha1.KustoScript) { r.Spec.ForProvider.Content = c }
+}
+
+func withObservation(o v1alpha1.KustoScriptObservation) scriptModifier {
+	return func(r *v1alpha1.KustoScript) { r.Status.AtProvider = o }
+}
+
+func script(m ...scriptModifier) *v1alpha1.KustoScript {
+	r := &v1alpha1.KustoScript{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1alpha1.KustoScriptSpec{
+			ForProvider: v1alpha1.KustoScriptParameters{
+				ResourceGroupName: "coolRG",
+				ClusterName:       "coolCluster",
+				DatabaseName:      databaseName,
+				Content:           content,
+			},
+		},
+	}
+	meta.SetExternalName(r, name)
+	for _, fn := range m {
+		fn(r)
+	}
+	return r
+}
+
+// observed returns a script that ran the content of the supplied
+// KustoScript. Azure does not return the content of scripts.
+func observed(r *v1alpha1.KustoScript, state string) kusto.Script {
+	return kusto.Script{Properties: &kusto.ScriptProperties{
+		ForceUpdateTag:    azure.ToStringPtr(kusto.ForceUpdateTag(r.Spec.ForProvider)),
+		ProvisioningState: azure.ToStringPtr(state),
+	}}
+}
+
+var _ managed.ExternalClient = &external{}
+var _ managed.ExternalConnecter = &connecter{}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	obs := func(state string) v1alpha1.KustoScriptObservation {
+		return v1alpha1.KustoScriptObservation{ProvisioningState: state, ForceUpdateTag: kusto.ForceUpdateTag(script().Spec.ForProvider)}
+	}
+
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   want
+	}{
+		"NotKustoScript": {
+			reason: "An error should be returned if the managed resource is not a KustoScript",
+			e:      &external{client: &fake.MockClustersClient{}},
+			mg:     &v1alpha1.KustoDatabase{},
+			want:   want{mg: &v1alpha1.KustoDatabase{}, err: errors.New(errNotScript)},
+		},
+		"NotFound": {
+			reason: "A script that is not found should not exist",
+			e: &external{client: &fake.MockClustersClient{
+				MockGetScript: func(_ context.Context, _, _, _, _ string) (kusto.Script, error) {
+					return kusto.Script{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			}},
+			mg:   script(),
+			want: want{mg: script()},
+		},
+		"GetFailed": {
+			reason: "Errors getting the script should be returned",
+			e: &external{client: &fake.MockClustersClient{
+				MockGetScript: func(_ context.Context, _, _, _, _ string) (kusto.Script, error) {
+					return kusto.Script{}, errBoom
+				},
+			}},
+			mg:   script(),
+			want: want{mg: script(), err: errors.Wrap(errBoom, errGetScript)},
+		},
+		"Running": {
+			reason: "A script that is still running should be creating",
+			e: &external{client: &fake.MockClustersClient{
+				MockGetScript: func(_ context.Context, _, _, database, _ string) (kusto.Script, error) {
+					if diff := cmp.Diff(databaseName, database); diff != "" {
+						t.Errorf("GetScript(...): -want database, +got database:\n%s", diff)
+					}
+					return observed(script(), "Running"), nil
+				},
+			}},
+			mg: script(),
+			want: want{
+				mg: script(withObservation(obs("Running")), withConditions(xpv1.Creating())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"Succeeded": {
+			reason: "A script that ran should be available",
+			e: &external{client: &fake.MockClustersClient{
+				MockGetScript: func(_ context.Context, _, _, _, _ string) (kusto.Script, error) {
+					return observed(script(), kusto.ProvisioningStateSucceeded), nil
+				},
+			}},
+			mg: script(),
+			want: want{
+				mg: script(withObservation(obs(kusto.ProvisioningStateSucceeded)), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"ContentChanged": {
+			reason: "A script whose content changed since it ran should need an update, so that it runs again",
+			e: &external{client: &fake.MockClustersClient{
+				MockGetScript: func(_ context.Context, _, _, _, _ string) (kusto.Script, error) {
+					return observed(script(), kusto.ProvisioningStateSucceeded), nil
+				},
+			}},
+			mg: script(withContent(content + "\n.create table OtherTable (Message: string)")),
+			want: want{
+				mg: script(
+					withContent(content+"\n.create table OtherTable (Message: string)"),
+					withObservation(obs(kusto.ProvisioningStateSucceeded)),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"Failed": {
+			reason: "A script that failed to run should be unavailable",
+			e: &external{client: &fake.MockClustersClient{
+				MockGetScript: func(_ context.Context, _, _, _, _ string) (kusto.Script, error) {
+					return observed(script(), kusto.ProvisioningStateFailed), nil
+				},
+			}},
+			mg: script(),
+			want: want{
+				mg: script(withObservation(obs(kusto.ProvisioningStateFailed)), withConditions(xpv1.Unavailable())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o, err := tc.e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want managed, +got managed:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   error
+	}{
+		"Successful": {
+			reason: "The script should be created with its content, tagged with a hash of it",
+			e: &external{client: &fake.MockClustersClient{
+				MockCreateOrUpdateScript: func(_ context.Context, _, _, _, _ string, s kusto.Script) error {
+					if diff := cmp.Diff(content, azure.ToString(s.Properties.ScriptContent)); diff != "" {
+						t.Errorf("CreateOrUpdateScript(...): -want content, +got content:\n%s", diff)
+					}
+					if diff := cmp.Diff(kusto.ForceUpdateTag(script().Spec.ForProvider), azure.ToString(s.Properties.ForceUpdateTag)); diff != "" {
+						t.Errorf("CreateOrUpdateScript(...): -want force update tag, +got force update tag:\n%s", diff)
+					}
+					return nil
+				},
+			}},
+			mg: script(),
+		},
+		"Failed": {
+			reason: "Errors creating the script should be returned",
+			e: &external{client: &fake.MockClustersClient{
+				MockCreateOrUpdateScript: func(_ context.Context, _, _, _, _ string, _ kusto.Script) error {
+					return errBoom
+				},
+			}},
+			mg:   script(),
+			want: errors.Wrap(errBoom, errCreateScript),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   error
+	}{
+		"Failed": {
+			reason: "Errors running the script again should be returned",
+			e: &external{client: &fake.MockClustersClient{
+				MockCreateOrUpdateScript: func(_ context.Context, _, _, _, _ string, _ kusto.Script) error {
+					return errBoom
+				},
+			}},
+			mg:   script(),
+			want: errors.Wrap(errBoom, errUpdateScript),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   error
+	}{
+		"NotFound": {
+			reason: "A script that is already gone should be deleted",
+			e: &external{client: &fake.MockClustersClient{
+				MockDeleteScript: func(_ context.Context, _, _, _, _ string) error {
+					return autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			}},
+			mg: script(),
+		},
+		"Failed": {
+			reason: "Errors deleting the script should be returned",
+			e: &external{client: &fake.MockClustersClient{
+				MockDeleteScript: func(_ context.Context, _, _, _, _ string) error {
+					return errBoom
+				},
+			}},
+			mg:   script(),
+			want: errors.Wrap(errBoom, errDeleteScript),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
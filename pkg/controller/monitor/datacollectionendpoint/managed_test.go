@@ -0,0 +1,351 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datacollectionendpoint
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/monitor/v1alpha1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/monitor"
+	"github.com/crossplane/provider-azure/pkg/clients/monitor/fake"
+)
+
+const (
+	name         = "coolEndpoint"
+	testLocation = "westeurope"
+	description  = "cool endpoint"
+	logsURL      = "https://cool-endpoint.westeurope-1.ingest.monitor.azure.com"
+)
+
+var errBoom = errors.New("boom")
+
+type endpointModifier func(*v1alpha1.DataCollectionEndpoint)
+
+func withConditions(c ...xpv1.Condition) endpointModifier {
+	return func(r *v1alpha1.DataCollectionEndpoint) { r.Status.ConditionedStatus.Conditions = c }
+}
+
+func withDescription(d string) endpointModifier {
+	return func(r *v1alpha1.DataCollectionEndpoint) { r.Spec.ForProvider.Description = &d }
+}
+
+func withPublicNetworkAccess(a v1alpha1.PublicNetworkAccess) endpointModifier {
+	return func(r *v1alpha1.DataCollectionEndpoint) { r.Spec.ForProvider.PublicNetworkAccess = &a }
+}
+
+func withObservation(o v1alpha1.DataCollectionEndpointObservation) endpointModifier {
+	return func(r *v1alpha1.DataCollectionEndpoint) { r.Status.AtProvider = o }
+}
+
+func dce(m ...endpointModifier) *v1alpha1.DataCollectionEndpoint {
+	r := &v1alpha1.DataCollectionEndpoint{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1alpha1.DataCollectionEndpointSpec{
+			ForProvider: v1alpha1.DataCollectionEndpointParameters{
+				ResourceGroupName: "coolRG",
+				Location:          testLocation,
+			},
+		},
+	}
+	meta.SetExternalName(r, name)
+	for _, fn := range m {
+		fn(r)
+	}
+	return r
+}
+
+func observed(state string, access v1alpha1.PublicNetworkAccess) monitor.DataCollectionEndpoint {
+	return monitor.DataCollectionEndpoint{
+		Location: azure.ToStringPtr(testLocation),
+		Properties: &monitor.DataCollectionEndpointProperties{
+			Description:       azure.ToStringPtr(description),
+			LogsIngestion:     &monitor.Endpoint{Endpoint: azure.ToStringPtr(logsURL)},
+			NetworkAcls:       &monitor.NetworkAcls{PublicNetworkAccess: azure.ToStringPtr(string(access))},
+			ProvisioningState: azure.ToStringPtr(state),
+		},
+	}
+}
+
+var _ managed.ExternalClient = &external{}
+var _ managed.ExternalConnecter = &connecter{}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	obs := func(state string) v1alpha1.DataCollectionEndpointObservation {
+		return v1alpha1.DataCollectionEndpointObservation{ProvisioningState: state, LogsIngestionEndpoint: logsURL}
+	}
+
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   want
+	}{
+		"NotDataCollectionEndpoint": {
+			reason: "An error should be returned if the managed resource is not a DataCollectionEndpoint",
+			e:      &external{client: &fake.MockDataCollectionClient{}},
+			mg:     &v1alpha1.DataCollectionRule{},
+			want:   want{mg: &v1alpha1.DataCollectionRule{}, err: errors.New(errNotDataCollectionEndpoint)},
+		},
+		"NotFound": {
+			reason: "A data collection endpoint that is not found should not exist",
+			e: &external{client: &fake.MockDataCollectionClient{
+				MockGetEndpoint: func(_ context.Context, _, _ string) (monitor.DataCollectionEndpoint, error) {
+					return monitor.DataCollectionEndpoint{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			}},
+			mg:   dce(),
+			want: want{mg: dce()},
+		},
+		"GetFailed": {
+			reason: "Errors getting the data collection endpoint should be returned",
+			e: &external{client: &fake.MockDataCollectionClient{
+				MockGetEndpoint: func(_ context.Context, _, _ string) (monitor.DataCollectionEndpoint, error) {
+					return monitor.DataCollectionEndpoint{}, errBoom
+				},
+			}},
+			mg:   dce(),
+			want: want{mg: dce(), err: errors.Wrap(errBoom, errGetDataCollectionEndpoint)},
+		},
+		"Provisioning": {
+			reason: "A data collection endpoint that is still being provisioned should be creating, and late initialized",
+			e: &external{client: &fake.MockDataCollectionClient{
+				MockGetEndpoint: func(_ context.Context, _, _ string) (monitor.DataCollectionEndpoint, error) {
+					return observed("Creating", v1alpha1.PublicNetworkAccessEnabled), nil
+				},
+			}},
+			mg: dce(),
+			want: want{
+				mg: dce(
+					withDescription(description),
+					withPublicNetworkAccess(v1alpha1.PublicNetworkAccessEnabled),
+					withObservation(obs("Creating")),
+					withConditions(xpv1.Creating()),
+				),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true},
+			},
+		},
+		"Succeeded": {
+			reason: "A data collection endpoint that was provisioned should be available",
+			e: &external{client: &fake.MockDataCollectionClient{
+				MockGetEndpoint: func(_ context.Context, _, _ string) (monitor.DataCollectionEndpoint, error) {
+					return observed(monitor.ProvisioningStateSucceeded, v1alpha1.PublicNetworkAccessEnabled), nil
+				},
+			}},
+			mg: dce(withDescription(description), withPublicNetworkAccess(v1alpha1.PublicNetworkAccessEnabled)),
+			want: want{
+				mg: dce(
+					withDescription(description),
+					withPublicNetworkAccess(v1alpha1.PublicNetworkAccessEnabled),
+					withObservation(obs(monitor.ProvisioningStateSucceeded)),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"NeedsUpdate": {
+			reason: "A data collection endpoint whose public network access differs from the spec should need an update",
+			e: &external{client: &fake.MockDataCollectionClient{
+				MockGetEndpoint: func(_ context.Context, _, _ string) (monitor.DataCollectionEndpoint, error) {
+					return observed(monitor.ProvisioningStateSucceeded, v1alpha1.PublicNetworkAccessEnabled), nil
+				},
+			}},
+			mg: dce(withDescription(description), withPublicNetworkAccess(v1alpha1.PublicNetworkAccessDisabled)),
+			want: want{
+				mg: dce(
+					withDescription(description),
+					withPublicNetworkAccess(v1alpha1.PublicNetworkAccessDisabled),
+					withObservation(obs(monitor.ProvisioningStateSucceeded)),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"Failed": {
+			reason: "A data collection endpoint that failed to be provisioned should be unavailable",
+			e: &external{client: &fake.MockDataCollectionClient{
+				MockGetEndpoint: func(_ context.Context, _, _ string) (monitor.DataCollectionEndpoint, error) {
+					return observed(monitor.ProvisioningStateFailed, v1alpha1.PublicNetworkAccessEnabled), nil
+				},
+			}},
+			mg: dce(withDescription(description), withPublicNetworkAccess(v1alpha1.PublicNetworkAccessEnabled)),
+			want: want{
+				mg: dce(
+					withDescription(description),
+					withPublicNetworkAccess(v1alpha1.PublicNetworkAccessEnabled),
+					withObservation(obs(monitor.ProvisioningStateFailed)),
+					withConditions(xpv1.Unavailable()),
+				),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o, err := tc.e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want managed, +got managed:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   error
+	}{
+		"Successful": {
+			reason: "The data collection endpoint should be created with public network access enabled by default",
+			e: &external{client: &fake.MockDataCollectionClient{
+				MockCreateOrUpdateEndpoint: func(_ context.Context, _, _ string, e monitor.DataCollectionEndpoint) error {
+					if diff := cmp.Diff(string(v1alpha1.PublicNetworkAccessEnabled), azure.ToString(e.Properties.NetworkAcls.PublicNetworkAccess)); diff != "" {
+						t.Errorf("CreateOrUpdateEndpoint(...): -want public network access, +got public network access:\n%s", diff)
+					}
+					return nil
+				},
+			}},
+			mg: dce(),
+		},
+		"Failed": {
+			reason: "Errors creating the data collection endpoint should be returned",
+			e: &external{client: &fake.MockDataCollectionClient{
+				MockCreateOrUpdateEndpoint: func(_ context.Context, _, _ string, _ monitor.DataCollectionEndpoint) error {
+					return errBoom
+				},
+			}},
+			mg:   dce(),
+			want: errors.Wrap(errBoom, errCreateDataCollectionEndpoint),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   error
+	}{
+		"Successful": {
+			reason: "The data collection endpoint should be updated with the public network access of the spec",
+			e: &external{client: &fake.MockDataCollectionClient{
+				MockCreateOrUpdateEndpoint: func(_ context.Context, _, _ string, e monitor.DataCollectionEndpoint) error {
+					if diff := cmp.Diff(string(v1alpha1.PublicNetworkAccessDisabled), azure.ToString(e.Properties.NetworkAcls.PublicNetworkAccess)); diff != "" {
+						t.Errorf("CreateOrUpdateEndpoint(...): -want public network access, +got public network access:\n%s", diff)
+					}
+					return nil
+				},
+			}},
+			mg: dce(withPublicNetworkAccess(v1alpha1.PublicNetworkAccessDisabled)),
+		},
+		"Failed": {
+			reason: "Errors updating the data collection endpoint should be returned",
+			e: &external{client: &fake.MockDataCollectionClient{
+				MockCreateOrUpdateEndpoint: func(_ context.Context, _, _ string, _ monitor.DataCollectionEndpoint) error {
+					return errBoom
+				},
+			}},
+			mg:   dce(),
+			want: errors.Wrap(errBoom, errUpdateDataCollectionEndpoint),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   error
+	}{
+		"NotFound": {
+			reason: "A data collection endpoint that is already gone should be deleted",
+			e: &external{client: &fake.MockDataCollectionClient{
+				MockDeleteEndpoint: func(_ context.Context, _, _ string) error {
+					return autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			}},
+			mg: dce(),
+		},
+		"Failed": {
+			reason: "Errors deleting the data collection endpoint should be returned",
+			e: &external{client: &fake.MockDataCollectionClient{
+				MockDeleteEndpoint: func(_ context.Context, _, _ string) error {
+					return errBoom
+				},
+			}},
+			mg:   dce(),
+			want: errors.Wrap(errBoom, errDeleteDataCollectionEndpoint),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,326 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datacollectionrule
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/monitor/v1alpha1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/monitor"
+	"github.com/crossplane/provider-azure/pkg/clients/monitor/fake"
+)
+
+const (
+	name         = "coolRule"
+	testLocation = "westeurope"
+	workspaceID  = "/subscriptions/sub/resourceGroups/coolRG/providers/Microsoft.OperationalInsights/workspaces/coolWorkspace"
+	immutableID  = "dcr-cool"
+)
+
+var errBoom = errors.New("boom")
+
+type ruleModifier func(*v1alpha1.DataCollectionRule)
+
+func withConditions(c ...xpv1.Condition) ruleModifier {
+	return func(r *v1alpha1.DataCollectionRule) { r.Status.ConditionedStatus.Conditions = c }
+}
+
+func withKind(k v1alpha1.DataCollectionKind) ruleModifier {
+	return func(r *v1alpha1.DataCollectionRule) { r.Spec.ForProvider.Kind = &k }
+}
+
+func withStreams(s ...string) ruleModifier {
+	return func(r *v1alpha1.DataCollectionRule) { r.Spec.ForProvider.DataFlows[0].Streams = s }
+}
+
+func withObservation(o v1alpha1.DataCollectionRuleObservation) ruleModifier {
+	return func(r *v1alpha1.DataCollectionRule) { r.Status.AtProvider = o }
+}
+
+func rule(m ...ruleModifier) *v1alpha1.DataCollectionRule {
+	r := &v1alpha1.DataCollectionRule{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1alpha1.DataCollectionRuleSpec{
+			ForProvider: v1alpha1.DataCollectionRuleParameters{
+				ResourceGroupName: "coolRG",
+				Location:          testLocation,
+				Destinations: v1alpha1.DataCollectionRuleDestinations{
+					LogAnalytics: []v1alpha1.LogAnalyticsDestination{{Name: "coolWorkspace", WorkspaceResourceID: workspaceID}},
+				},
+				DataFlows: []v1alpha1.DataFlow{{Streams: []string{"Microsoft-Syslog"}, Destinations: []string{"coolWorkspace"}}},
+			},
+		},
+	}
+	meta.SetExternalName(r, name)
+	for _, fn := range m {
+		fn(r)
+	}
+	return r
+}
+
+// observed returns the data collection rule Azure reports for the supplied
+// DataCollectionRule. Azure returns resource IDs in lower case.
+func observed(r *v1alpha1.DataCollectionRule, state string) monitor.DataCollectionRule {
+	az := monitor.NewDataCollectionRule(r.Spec.ForProvider)
+	az.Kind = azure.ToStringPtr(string(v1alpha1.DataCollectionKindLinux))
+	az.Properties.ImmutableID = azure.ToStringPtr(immutableID)
+	az.Properties.ProvisioningState = azure.ToStringPtr(state)
+	for i := range az.Properties.Destinations.LogAnalytics {
+		az.Properties.Destinations.LogAnalytics[i].WorkspaceResourceID = azure.ToStringPtr(strings.ToLower(workspaceID))
+	}
+	return az
+}
+
+var _ managed.ExternalClient = &external{}
+var _ managed.ExternalConnecter = &connecter{}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	obs := func(state string) v1alpha1.DataCollectionRuleObservation {
+		return v1alpha1.DataCollectionRuleObservation{ProvisioningState: state, ImmutableID: immutableID}
+	}
+
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   want
+	}{
+		"NotDataCollectionRule": {
+			reason: "An error should be returned if the managed resource is not a DataCollectionRule",
+			e:      &external{client: &fake.MockDataCollectionClient{}},
+			mg:     &v1alpha1.DataCollectionEndpoint{},
+			want:   want{mg: &v1alpha1.DataCollectionEndpoint{}, err: errors.New(errNotDataCollectionRule)},
+		},
+		"NotFound": {
+			reason: "A data collection rule that is not found should not exist",
+			e: &external{client: &fake.MockDataCollectionClient{
+				MockGetRule: func(_ context.Context, _, _ string) (monitor.DataCollectionRule, error) {
+					return monitor.DataCollectionRule{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			}},
+			mg:   rule(),
+			want: want{mg: rule()},
+		},
+		"GetFailed": {
+			reason: "Errors getting the data collection rule should be returned",
+			e: &external{client: &fake.MockDataCollectionClient{
+				MockGetRule: func(_ context.Context, _, _ string) (monitor.DataCollectionRule, error) {
+					return monitor.DataCollectionRule{}, errBoom
+				},
+			}},
+			mg:   rule(),
+			want: want{mg: rule(), err: errors.Wrap(errBoom, errGetDataCollectionRule)},
+		},
+		"Provisioning": {
+			reason: "A data collection rule that is still being provisioned should be creating, and its kind late initialized",
+			e: &external{client: &fake.MockDataCollectionClient{
+				MockGetRule: func(_ context.Context, _, _ string) (monitor.DataCollectionRule, error) {
+					return observed(rule(), "Creating"), nil
+				},
+			}},
+			mg: rule(),
+			want: want{
+				mg: rule(withKind(v1alpha1.DataCollectionKindLinux), withObservation(obs("Creating")), withConditions(xpv1.Creating())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true},
+			},
+		},
+		"Succeeded": {
+			reason: "A data collection rule that was provisioned should be available, and up to date regardless of the case of resource IDs",
+			e: &external{client: &fake.MockDataCollectionClient{
+				MockGetRule: func(_ context.Context, _, _ string) (monitor.DataCollectionRule, error) {
+					return observed(rule(), monitor.ProvisioningStateSucceeded), nil
+				},
+			}},
+			mg: rule(withKind(v1alpha1.DataCollectionKindLinux)),
+			want: want{
+				mg: rule(withKind(v1alpha1.DataCollectionKindLinux), withObservation(obs(monitor.ProvisioningStateSucceeded)), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"NeedsUpdate": {
+			reason: "A data collection rule whose data flows differ from the spec should need an update",
+			e: &external{client: &fake.MockDataCollectionClient{
+				MockGetRule: func(_ context.Context, _, _ string) (monitor.DataCollectionRule, error) {
+					return observed(rule(), monitor.ProvisioningStateSucceeded), nil
+				},
+			}},
+			mg: rule(withKind(v1alpha1.DataCollectionKindLinux), withStreams("Microsoft-Perf")),
+			want: want{
+				mg: rule(withKind(v1alpha1.DataCollectionKindLinux), withStreams("Microsoft-Perf"), withObservation(obs(monitor.ProvisioningStateSucceeded)), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"Failed": {
+			reason: "A data collection rule that failed to be provisioned should be unavailable",
+			e: &external{client: &fake.MockDataCollectionClient{
+				MockGetRule: func(_ context.Context, _, _ string) (monitor.DataCollectionRule, error) {
+					return observed(rule(), monitor.ProvisioningStateFailed), nil
+				},
+			}},
+			mg: rule(withKind(v1alpha1.DataCollectionKindLinux)),
+			want: want{
+				mg: rule(withKind(v1alpha1.DataCollectionKindLinux), withObservation(obs(monitor.ProvisioningStateFailed)), withConditions(xpv1.Unavailable())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o, err := tc.e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want managed, +got managed:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   error
+	}{
+		"Successful": {
+			reason: "The data collection rule should be created with the data flows of the spec",
+			e: &external{client: &fake.MockDataCollectionClient{
+				MockCreateOrUpdateRule: func(_ context.Context, _, _ string, r monitor.DataCollectionRule) error {
+					want := []monitor.DataFlow{{Streams: []string{"Microsoft-Syslog"}, Destinations: []string{"coolWorkspace"}}}
+					if diff := cmp.Diff(want, r.Properties.DataFlows); diff != "" {
+						t.Errorf("CreateOrUpdateRule(...): -want data flows, +got data flows:\n%s", diff)
+					}
+					return nil
+				},
+			}},
+			mg: rule(),
+		},
+		"Failed": {
+			reason: "Errors creating the data collection rule should be returned",
+			e: &external{client: &fake.MockDataCollectionClient{
+				MockCreateOrUpdateRule: func(_ context.Context, _, _ string, _ monitor.DataCollectionRule) error {
+					return errBoom
+				},
+			}},
+			mg:   rule(),
+			want: errors.Wrap(errBoom, errCreateDataCollectionRule),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   error
+	}{
+		"Failed": {
+			reason: "Errors updating the data collection rule should be returned",
+			e: &external{client: &fake.MockDataCollectionClient{
+				MockCreateOrUpdateRule: func(_ context.Context, _, _ string, _ monitor.DataCollectionRule) error {
+					return errBoom
+				},
+			}},
+			mg:   rule(),
+			want: errors.Wrap(errBoom, errUpdateDataCollectionRule),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   error
+	}{
+		"NotFound": {
+			reason: "A data collection rule that is already gone should be deleted",
+			e: &external{client: &fake.MockDataCollectionClient{
+				MockDeleteRule: func(_ context.Context, _, _ string) error {
+					return autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			}},
+			mg: rule(),
+		},
+		"Failed": {
+			reason: "Errors deleting the data collection rule should be returned",
+			e: &external{client: &fake.MockDataCollectionClient{
+				MockDeleteRule: func(_ context.Context, _, _ string) error {
+					return errBoom
+				},
+			}},
+			mg:   rule(),
+			want: errors.Wrap(errBoom, errDeleteDataCollectionRule),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
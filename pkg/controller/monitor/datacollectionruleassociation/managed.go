@@ -0,0 +1,180 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datacollectionruleassociation
+
+import (
+	"context"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	computev1alpha3 "github.com/crossplane/provider-azure/apis/compute/v1alpha3"
+	"github.com/crossplane/provider-azure/apis/monitor/v1alpha1"
+	"github.com/crossplane/provider-azure/pkg/chain"
+	azureclients "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/monitor"
+	"github.com/crossplane/provider-azure/pkg/concurrency"
+	"github.com/crossplane/provider-azure/pkg/credentials"
+	"github.com/crossplane/provider-azure/pkg/defaults"
+	"github.com/crossplane/provider-azure/pkg/dependency"
+	"github.com/crossplane/provider-azure/pkg/externalname"
+	"github.com/crossplane/provider-azure/pkg/tenancy"
+)
+
+// Error strings.
+const (
+	errNotAssociation    = "managed resource is not a DataCollectionRuleAssociation"
+	errCreateAssociation = "cannot create data collection rule association"
+	errUpdateAssociation = "cannot update data collection rule association"
+	errGetAssociation    = "cannot get data collection rule association"
+	errDeleteAssociation = "cannot delete data collection rule association"
+)
+
+// Setup adds a controller that reconciles DataCollectionRuleAssociations.
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, sel labels.Selector) error {
+	name := managed.ControllerName(v1alpha1.DataCollectionRuleAssociationGroupKind)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: concurrency.DefaultLimits.Of(v1alpha1.DataCollectionRuleAssociationGroupKind),
+		}).
+		For(&v1alpha1.DataCollectionRuleAssociation{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, credentials.EnqueueRequestsForRotation(mgr.GetClient(), &v1alpha1.DataCollectionRuleAssociationList{}, l.WithValues("controller", name)), builder.OnlyMetadata).
+		Watches(&source.Kind{Type: &computev1alpha3.AKSCluster{}}, dependency.EnqueueRequestsForDependents(mgr.GetClient(), &v1alpha1.DataCollectionRuleAssociationList{}, l.WithValues("controller", name)), builder.WithPredicates(dependency.BecameAvailable())).
+		Watches(&source.Kind{Type: &v1alpha1.DataCollectionRule{}}, dependency.EnqueueRequestsForDependents(mgr.GetClient(), &v1alpha1.DataCollectionRuleAssociationList{}, l.WithValues("controller", name)), builder.WithPredicates(dependency.BecameAvailable())).
+		Watches(&source.Kind{Type: &v1alpha1.DataCollectionEndpoint{}}, dependency.EnqueueRequestsForDependents(mgr.GetClient(), &v1alpha1.DataCollectionRuleAssociationList{}, l.WithValues("controller", name)), builder.WithPredicates(dependency.BecameAvailable())).
+		Complete(chain.NewReconciler(mgr, resource.ManagedKind(v1alpha1.DataCollectionRuleAssociationGroupVersionKind),
+			managed.NewReconciler(mgr,
+				resource.ManagedKind(v1alpha1.DataCollectionRuleAssociationGroupVersionKind),
+				managed.WithConnectionPublishers(),
+				managed.WithExternalConnecter(chain.NewConnecter(mgr, v1alpha1.DataCollectionRuleAssociationGroupKind, &connecter{client: mgr.GetClient()})),
+				managed.WithInitializers(managed.NewNameAsExternalName(mgr.GetClient()), externalname.NewInitializer(mgr.GetClient()), tenancy.NewDefaultProviderInitializer(mgr.GetClient()), defaults.NewInitializer(mgr.GetClient())),
+				managed.WithReferenceResolver(dependency.NewReferenceResolver(mgr.GetClient())),
+				managed.WithLogger(l.WithValues("controller", name)),
+				managed.WithRecorder(recorder)),
+			sel))
+}
+
+type connecter struct {
+	client client.Client
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	if _, ok := mg.(*v1alpha1.DataCollectionRuleAssociation); !ok {
+		return nil, errors.New(errNotAssociation)
+	}
+	creds, auth, err := azureclients.GetAuthInfo(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	// Associations are addressed by the ID of their target resource, which
+	// includes its subscription.
+	cl := monitor.NewDataCollectionClientWithBaseURI(azureclients.BaseURI(creds), azureclients.SubscriptionID(creds, nil))
+	cl.Authorizer = auth
+	return &external{client: cl}, nil
+}
+
+type external struct {
+	client monitor.DataCollectionAPI
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	a, ok := mg.(*v1alpha1.DataCollectionRuleAssociation)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotAssociation)
+	}
+
+	az, err := e.client.GetAssociation(ctx, a.Spec.ForProvider.TargetResourceID, meta.GetExternalName(a))
+	if azureclients.IsNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetAssociation)
+	}
+
+	current := a.Spec.ForProvider.DeepCopy()
+	if az.Properties != nil {
+		a.Spec.ForProvider.Description = azureclients.LateInitializeStringPtrFromPtr(a.Spec.ForProvider.Description, az.Properties.Description)
+	}
+
+	a.Status.AtProvider = monitor.GenerateDataCollectionRuleAssociationObservation(az)
+	switch a.Status.AtProvider.ProvisioningState {
+	case monitor.ProvisioningStateFailed:
+		a.SetConditions(xpv1.Unavailable())
+	default:
+		// Associations are created synchronously, and Azure does not
+		// always report their provisioning state.
+		a.SetConditions(xpv1.Available())
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        !monitor.DataCollectionRuleAssociationNeedsUpdate(a.Spec.ForProvider, az),
+		ResourceLateInitialized: !cmp.Equal(current, &a.Spec.ForProvider),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	a, ok := mg.(*v1alpha1.DataCollectionRuleAssociation)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotAssociation)
+	}
+
+	a.SetConditions(xpv1.Creating())
+	err := e.client.CreateOrUpdateAssociation(ctx, a.Spec.ForProvider.TargetResourceID, meta.GetExternalName(a), monitor.NewDataCollectionRuleAssociation(a.Spec.ForProvider))
+	return managed.ExternalCreation{}, errors.Wrap(err, errCreateAssociation)
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	a, ok := mg.(*v1alpha1.DataCollectionRuleAssociation)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotAssociation)
+	}
+
+	err := e.client.CreateOrUpdateAssociation(ctx, a.Spec.ForProvider.TargetResourceID, meta.GetExternalName(a), monitor.NewDataCollectionRuleAssociation(a.Spec.ForProvider))
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateAssociation)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	a, ok := mg.(*v1alpha1.DataCollectionRuleAssociation)
+	if !ok {
+		return errors.New(errNotAssociation)
+	}
+
+	a.SetConditions(xpv1.Deleting())
+	err := e.client.DeleteAssociation(ctx, a.Spec.ForProvider.TargetResourceID, meta.GetExternalName(a))
+	return errors.Wrap(resource.Ignore(azureclients.IsNotFound, err), errDeleteAssociation)
+}
@@ -0,0 +1,307 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datacollectionruleassociation
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/monitor/v1alpha1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/monitor"
+	"github.com/crossplane/provider-azure/pkg/clients/monitor/fake"
+)
+
+const (
+	name        = "coolAssociation"
+	targetID    = "/subscriptions/sub/resourceGroups/coolRG/providers/Microsoft.Compute/virtualMachines/coolVM"
+	ruleID      = "/subscriptions/sub/resourceGroups/coolRG/providers/Microsoft.Insights/dataCollectionRules/coolRule"
+	description = "cool association"
+)
+
+var errBoom = errors.New("boom")
+
+type associationModifier func(*v1alpha1.DataCollectionRuleAssociation)
+
+func withConditions(c ...xpv1.Condition) associationModifier {
+	return func(r *v1alpha1.DataCollectionRuleAssociation) { r.Status.ConditionedStatus.Conditions = c }
+}
+
+func withDescription(d string) associationModifier {
+	return func(r *v1alpha1.DataCollectionRuleAssociation) { r.Spec.ForProvider.Description = &d }
+}
+
+func withRule(id string) associationModifier {
+	return func(r *v1alpha1.DataCollectionRuleAssociation) { r.Spec.ForProvider.DataCollectionRuleID = &id }
+}
+
+func withState(s string) associationModifier {
+	return func(r *v1alpha1.DataCollectionRuleAssociation) { r.Status.AtProvider.ProvisioningState = s }
+}
+
+func association(m ...associationModifier) *v1alpha1.DataCollectionRuleAssociation {
+	r := &v1alpha1.DataCollectionRuleAssociation{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1alpha1.DataCollectionRuleAssociationSpec{
+			ForProvider: v1alpha1.DataCollectionRuleAssociationParameters{
+				TargetResourceID:     targetID,
+				DataCollectionRuleID: azure.ToStringPtr(ruleID),
+			},
+		},
+	}
+	meta.SetExternalName(r, name)
+	for _, fn := range m {
+		fn(r)
+	}
+	return r
+}
+
+// observed returns an association as Azure reports it, with the resource ID
+// of its rule in lower case.
+func observed(state string) monitor.DataCollectionRuleAssociation {
+	return monitor.DataCollectionRuleAssociation{Properties: &monitor.DataCollectionRuleAssociationProperties{
+		Description:          azure.ToStringPtr(description),
+		DataCollectionRuleID: azure.ToStringPtr(strings.ToLower(ruleID)),
+		ProvisioningState:    azure.ToStringPtr(state),
+	}}
+}
+
+var _ managed.ExternalClient = &external{}
+var _ managed.ExternalConnecter = &connecter{}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   want
+	}{
+		"NotAssociation": {
+			reason: "An error should be returned if the managed resource is not a DataCollectionRuleAssociation",
+			e:      &external{client: &fake.MockDataCollectionClient{}},
+			mg:     &v1alpha1.DataCollectionRule{},
+			want:   want{mg: &v1alpha1.DataCollectionRule{}, err: errors.New(errNotAssociation)},
+		},
+		"NotFound": {
+			reason: "An association that is not found should not exist",
+			e: &external{client: &fake.MockDataCollectionClient{
+				MockGetAssociation: func(_ context.Context, _, _ string) (monitor.DataCollectionRuleAssociation, error) {
+					return monitor.DataCollectionRuleAssociation{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			}},
+			mg:   association(),
+			want: want{mg: association()},
+		},
+		"GetFailed": {
+			reason: "Errors getting the association should be returned",
+			e: &external{client: &fake.MockDataCollectionClient{
+				MockGetAssociation: func(_ context.Context, _, _ string) (monitor.DataCollectionRuleAssociation, error) {
+					return monitor.DataCollectionRuleAssociation{}, errBoom
+				},
+			}},
+			mg:   association(),
+			want: want{mg: association(), err: errors.Wrap(errBoom, errGetAssociation)},
+		},
+		"LateInitialized": {
+			reason: "An association without a provisioning state should be available, and its description late initialized",
+			e: &external{client: &fake.MockDataCollectionClient{
+				MockGetAssociation: func(_ context.Context, target, _ string) (monitor.DataCollectionRuleAssociation, error) {
+					if diff := cmp.Diff(targetID, target); diff != "" {
+						t.Errorf("GetAssociation(...): -want target, +got target:\n%s", diff)
+					}
+					return observed(""), nil
+				},
+			}},
+			mg: association(),
+			want: want{
+				mg: association(withDescription(description), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true},
+			},
+		},
+		"NeedsUpdate": {
+			reason: "An association of another rule should need an update",
+			e: &external{client: &fake.MockDataCollectionClient{
+				MockGetAssociation: func(_ context.Context, _, _ string) (monitor.DataCollectionRuleAssociation, error) {
+					return observed(monitor.ProvisioningStateSucceeded), nil
+				},
+			}},
+			mg: association(withDescription(description), withRule(ruleID+"-other")),
+			want: want{
+				mg: association(withDescription(description), withRule(ruleID+"-other"), withState(monitor.ProvisioningStateSucceeded), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"Failed": {
+			reason: "An association that failed to be provisioned should be unavailable",
+			e: &external{client: &fake.MockDataCollectionClient{
+				MockGetAssociation: func(_ context.Context, _, _ string) (monitor.DataCollectionRuleAssociation, error) {
+					return observed(monitor.ProvisioningStateFailed), nil
+				},
+			}},
+			mg: association(withDescription(description)),
+			want: want{
+				mg: association(withDescription(description), withState(monitor.ProvisioningStateFailed), withConditions(xpv1.Unavailable())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o, err := tc.e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want managed, +got managed:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   error
+	}{
+		"Successful": {
+			reason: "The association should be created on its target resource",
+			e: &external{client: &fake.MockDataCollectionClient{
+				MockCreateOrUpdateAssociation: func(_ context.Context, target, _ string, a monitor.DataCollectionRuleAssociation) error {
+					if diff := cmp.Diff(targetID, target); diff != "" {
+						t.Errorf("CreateOrUpdateAssociation(...): -want target, +got target:\n%s", diff)
+					}
+					if diff := cmp.Diff(ruleID, azure.ToString(a.Properties.DataCollectionRuleID)); diff != "" {
+						t.Errorf("CreateOrUpdateAssociation(...): -want rule, +got rule:\n%s", diff)
+					}
+					return nil
+				},
+			}},
+			mg: association(),
+		},
+		"Failed": {
+			reason: "Errors creating the association should be returned",
+			e: &external{client: &fake.MockDataCollectionClient{
+				MockCreateOrUpdateAssociation: func(_ context.Context, _, _ string, _ monitor.DataCollectionRuleAssociation) error {
+					return errBoom
+				},
+			}},
+			mg:   association(),
+			want: errors.Wrap(errBoom, errCreateAssociation),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   error
+	}{
+		"Failed": {
+			reason: "Errors updating the association should be returned",
+			e: &external{client: &fake.MockDataCollectionClient{
+				MockCreateOrUpdateAssociation: func(_ context.Context, _, _ string, _ monitor.DataCollectionRuleAssociation) error {
+					return errBoom
+				},
+			}},
+			mg:   association(),
+			want: errors.Wrap(errBoom, errUpdateAssociation),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   error
+	}{
+		"NotFound": {
+			reason: "An association that is already gone should be deleted",
+			e: &external{client: &fake.MockDataCollectionClient{
+				MockDeleteAssociation: func(_ context.Context, _, _ string) error {
+					return autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			}},
+			mg: association(),
+		},
+		"Failed": {
+			reason: "Errors deleting the association should be returned",
+			e: &external{client: &fake.MockDataCollectionClient{
+				MockDeleteAssociation: func(_ context.Context, _, _ string) error {
+					return errBoom
+				},
+			}},
+			mg:   association(),
+			want: errors.Wrap(errBoom, errDeleteAssociation),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
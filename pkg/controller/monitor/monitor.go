@@ -0,0 +1,300 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package monitor contains a controller that forwards the Events and condition
+// transitions of the managed resources of this provider to an Azure Monitor
+// Log Analytics workspace, so they can be queried and alerted on alongside the
+// activity logs of the resources they manage.
+package monitor
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	kcache "k8s.io/client-go/tools/cache"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-azure/apis"
+	"github.com/crossplane/provider-azure/pkg/clients/monitor"
+)
+
+const (
+	sendTimeout = 1 * time.Minute
+
+	// maxBuffered is the number of records buffered between sends. Records
+	// are dropped while the buffer is full.
+	maxBuffered = 5000
+
+	// groupSuffix is the API group suffix of the managed resources of this
+	// provider.
+	groupSuffix = "azure.crossplane.io"
+)
+
+// Keys of the workspace secret.
+const (
+	SecretKeyWorkspaceID = "workspaceId"
+	SecretKeySharedKey   = "sharedKey"
+)
+
+// Categories of records.
+const (
+	CategoryEvent     = "Event"
+	CategoryCondition = "Condition"
+)
+
+// Error strings.
+const (
+	errGetSecret   = "cannot get Log Analytics workspace secret"
+	errNewSender   = "cannot create Log Analytics client"
+	errGetInformer = "cannot get informer for %s"
+	errSend        = "cannot send records to Log Analytics"
+)
+
+// Options configure where and how often records are sent.
+type Options struct {
+	// SecretRef is the secret that holds the ID and shared key of the Log
+	// Analytics workspace.
+	SecretRef types.NamespacedName
+
+	// LogType of the records, i.e. the name of the custom log table without
+	// its _CL suffix.
+	LogType string
+
+	// Interval between sends of buffered records.
+	Interval time.Duration
+}
+
+// A Record of an Event or a condition transition of a managed resource.
+type Record struct {
+	TimeGenerated time.Time `json:"TimeGenerated"`
+	Category      string    `json:"Category"`
+	APIVersion    string    `json:"APIVersion"`
+	Kind          string    `json:"Kind"`
+	Name          string    `json:"Name"`
+	UID           string    `json:"UID"`
+
+	// Type is the type of an Event, e.g. Warning, or that of a condition,
+	// e.g. Ready.
+	Type    string `json:"Type"`
+	Status  string `json:"Status,omitempty"`
+	Reason  string `json:"Reason"`
+	Message string `json:"Message"`
+	Count   int32  `json:"Count,omitempty"`
+}
+
+// A NewSenderFn returns a Sender for the supplied workspace.
+type NewSenderFn func(workspaceID, sharedKey string) (monitor.Sender, error)
+
+// Setup adds an Exporter to the supplied manager. Like controllers, it only
+// runs while the manager is the leader.
+func Setup(mgr ctrl.Manager, l logging.Logger, o Options) error {
+	return mgr.Add(NewExporter(mgr.GetClient(), mgr.GetCache(), mgr.GetScheme(), l.WithValues("controller", "monitor"), o))
+}
+
+// An Exporter watches Events and managed resources and periodically sends
+// records of the Events of managed resources and of the transitions of their
+// conditions to a Log Analytics workspace.
+type Exporter struct {
+	kube      client.Client
+	informers cache.Informers
+	scheme    *runtime.Scheme
+	opts      Options
+	log       logging.Logger
+	newSender NewSenderFn
+	records   chan Record
+	now       func() time.Time
+}
+
+// NewExporter returns a new Exporter.
+func NewExporter(c client.Client, i cache.Informers, s *runtime.Scheme, l logging.Logger, o Options) *Exporter {
+	return &Exporter{
+		kube:      c,
+		informers: i,
+		scheme:    s,
+		opts:      o,
+		log:       l,
+		newSender: func(workspaceID, sharedKey string) (monitor.Sender, error) {
+			return monitor.NewDataCollectorClient(workspaceID, sharedKey)
+		},
+		records: make(chan Record, maxBuffered),
+		now:     time.Now,
+	}
+}
+
+// Start watches Events and managed resources and sends the buffered records
+// at every interval, until the supplied context is done. Events that were
+// last seen before the Exporter started are not sent.
+func (e *Exporter) Start(ctx context.Context) error {
+	started := e.now()
+
+	i, err := e.informers.GetInformer(ctx, &corev1.Event{})
+	if err != nil {
+		return errors.Wrapf(err, errGetInformer, "Event")
+	}
+	onEvent := func(obj interface{}) {
+		if ev, ok := obj.(*corev1.Event); ok && !ev.LastTimestamp.Time.Before(started) {
+			if r, ok := EventRecord(ev); ok {
+				e.buffer(r)
+			}
+		}
+	}
+	i.AddEventHandler(kcache.ResourceEventHandlerFuncs{
+		AddFunc:    onEvent,
+		UpdateFunc: func(_, obj interface{}) { onEvent(obj) },
+	})
+
+	for _, l := range apis.ManagedLists(e.scheme) {
+		gvk := resource.MustGetKind(l, e.scheme)
+		gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+		o, err := e.scheme.New(gvk)
+		if err != nil {
+			return errors.Wrapf(err, errGetInformer, gvk.Kind)
+		}
+		i, err := e.informers.GetInformer(ctx, o.(client.Object))
+		if err != nil {
+			return errors.Wrapf(err, errGetInformer, gvk.Kind)
+		}
+		apiVersion, kind := gvk.ToAPIVersionAndKind()
+		i.AddEventHandler(kcache.ResourceEventHandlerFuncs{
+			UpdateFunc: func(old, obj interface{}) {
+				o, ook := old.(resource.Managed)
+				n, nok := obj.(resource.Managed)
+				if !ook || !nok {
+					return
+				}
+				for _, r := range ConditionRecords(o, n) {
+					r.APIVersion, r.Kind = apiVersion, kind
+					e.buffer(r)
+				}
+			},
+		})
+	}
+
+	t := time.NewTicker(e.opts.Interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-t.C:
+		}
+		if err := e.Send(ctx); err != nil {
+			e.log.Info("Cannot export records to Log Analytics", "error", err)
+		}
+	}
+}
+
+func (e *Exporter) buffer(r Record) {
+	select {
+	case e.records <- r:
+	default:
+		e.log.Debug("Dropped record; buffer is full", "kind", r.Kind, "name", r.Name, "reason", r.Reason)
+	}
+}
+
+// Send the buffered records to the workspace. Records that cannot be sent are
+// dropped rather than retried, so that an unavailable workspace does not grow
+// the buffer.
+func (e *Exporter) Send(ctx context.Context) error {
+	records := make([]Record, 0, len(e.records))
+	for len(records) < cap(records) {
+		records = append(records, <-e.records)
+	}
+	if len(records) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	sec := &corev1.Secret{}
+	if err := e.kube.Get(ctx, e.opts.SecretRef, sec); err != nil {
+		return errors.Wrap(err, errGetSecret)
+	}
+	s, err := e.newSender(string(sec.Data[SecretKeyWorkspaceID]), string(sec.Data[SecretKeySharedKey]))
+	if err != nil {
+		return errors.Wrap(err, errNewSender)
+	}
+	if err := s.Send(ctx, e.opts.LogType, records); err != nil {
+		return errors.Wrap(err, errSend)
+	}
+	e.log.Debug("Exported records to Log Analytics", "count", len(records))
+	return nil
+}
+
+// EventRecord returns the record of the supplied Event, and false if it does
+// not involve a managed resource of this provider.
+func EventRecord(ev *corev1.Event) (Record, bool) {
+	ref := ev.InvolvedObject
+	if g := strings.SplitN(ref.APIVersion, "/", 2)[0]; !strings.HasSuffix(g, groupSuffix) {
+		return Record{}, false
+	}
+	t := ev.LastTimestamp.Time
+	if t.IsZero() {
+		t = ev.EventTime.Time
+	}
+	return Record{
+		TimeGenerated: t,
+		Category:      CategoryEvent,
+		APIVersion:    ref.APIVersion,
+		Kind:          ref.Kind,
+		Name:          ref.Name,
+		UID:           string(ref.UID),
+		Type:          ev.Type,
+		Reason:        ev.Reason,
+		Message:       ev.Message,
+		Count:         ev.Count,
+	}, true
+}
+
+// ConditionRecords returns a record of each condition of the supplied new
+// managed resource whose status or reason differs from that of the supplied
+// old one. The records have no APIVersion or Kind, since managed resources
+// read from a cache have no type metadata.
+func ConditionRecords(old, mg resource.Managed) []Record {
+	var records []Record
+	for _, t := range []xpv1.ConditionType{xpv1.TypeReady, xpv1.TypeSynced} {
+		o, n := old.GetCondition(t), mg.GetCondition(t)
+		if n.Status == corev1.ConditionUnknown && n.Reason == "" {
+			continue
+		}
+		if o.Status == n.Status && o.Reason == n.Reason {
+			continue
+		}
+		records = append(records, Record{
+			TimeGenerated: n.LastTransitionTime.Time,
+			Category:      CategoryCondition,
+			Name:          mg.GetName(),
+			UID:           string(mg.GetUID()),
+			Type:          string(n.Type),
+			Status:        string(n.Status),
+			Reason:        string(n.Reason),
+			Message:       n.Message,
+		})
+	}
+	return records
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package monitor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-azure/apis/cache/v1beta1"
+)
+
+func TestEventRecord(t *testing.T) {
+	now := metav1.NewTime(time.Date(2021, time.March, 4, 5, 6, 7, 0, time.UTC))
+
+	type want struct {
+		r  Record
+		ok bool
+	}
+	cases := map[string]struct {
+		ev   *corev1.Event
+		want want
+	}{
+		"NotManaged": {
+			ev: &corev1.Event{InvolvedObject: corev1.ObjectReference{APIVersion: "v1", Kind: "Pod"}},
+		},
+		"Managed": {
+			ev: &corev1.Event{
+				InvolvedObject: corev1.ObjectReference{APIVersion: "cache.azure.crossplane.io/v1beta1", Kind: "Redis", Name: "cool-redis", UID: "cool-uid"},
+				LastTimestamp:  now,
+				Type:           corev1.EventTypeWarning,
+				Reason:         "CannotObserveExternalResource",
+				Message:        "boom",
+				Count:          2,
+			},
+			want: want{
+				r: Record{
+					TimeGenerated: now.Time,
+					Category:      CategoryEvent,
+					APIVersion:    "cache.azure.crossplane.io/v1beta1",
+					Kind:          "Redis",
+					Name:          "cool-redis",
+					UID:           "cool-uid",
+					Type:          corev1.EventTypeWarning,
+					Reason:        "CannotObserveExternalResource",
+					Message:       "boom",
+					Count:         2,
+				},
+				ok: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r, ok := EventRecord(tc.ev)
+			if diff := cmp.Diff(tc.want.r, r); diff != "" {
+				t.Errorf("EventRecord(...): -want, +got:\n%s", diff)
+			}
+			if ok != tc.want.ok {
+				t.Errorf("EventRecord(...): want ok %t, got %t", tc.want.ok, ok)
+			}
+		})
+	}
+}
+
+func TestConditionRecords(t *testing.T) {
+	now := metav1.NewTime(time.Date(2021, time.March, 4, 5, 6, 7, 0, time.UTC))
+	redis := func(c ...xpv1.Condition) resource.Managed {
+		r := &v1beta1.Redis{ObjectMeta: metav1.ObjectMeta{Name: "cool-redis", UID: "cool-uid"}}
+		r.SetConditions(c...)
+		return r
+	}
+	available := xpv1.Available()
+	available.LastTransitionTime = now
+
+	cases := map[string]struct {
+		old  resource.Managed
+		mg   resource.Managed
+		want []Record
+	}{
+		"NoConditions": {
+			old: redis(),
+			mg:  redis(),
+		},
+		"Unchanged": {
+			old: redis(xpv1.Available(), xpv1.ReconcileSuccess()),
+			mg:  redis(xpv1.Available(), xpv1.ReconcileSuccess()),
+		},
+		"BecameReady": {
+			old: redis(xpv1.Creating(), xpv1.ReconcileSuccess()),
+			mg:  redis(available, xpv1.ReconcileSuccess()),
+			want: []Record{{
+				TimeGenerated: now.Time,
+				Category:      CategoryCondition,
+				Name:          "cool-redis",
+				UID:           "cool-uid",
+				Type:          string(xpv1.TypeReady),
+				Status:        string(corev1.ConditionTrue),
+				Reason:        string(xpv1.ReasonAvailable),
+			}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ConditionRecords(tc.old, tc.mg)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ConditionRecords(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privatelinkscope
+
+import (
+	"context"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-azure/apis/monitor/v1alpha1"
+	azurev1alpha3 "github.com/crossplane/provider-azure/apis/v1alpha3"
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+	"github.com/crossplane/provider-azure/pkg/chain"
+	azureclients "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/monitor"
+	"github.com/crossplane/provider-azure/pkg/concurrency"
+	"github.com/crossplane/provider-azure/pkg/credentials"
+	"github.com/crossplane/provider-azure/pkg/defaults"
+	"github.com/crossplane/provider-azure/pkg/dependency"
+	"github.com/crossplane/provider-azure/pkg/externalname"
+	"github.com/crossplane/provider-azure/pkg/tenancy"
+)
+
+// Error strings.
+const (
+	errNotPrivateLinkScope    = "managed resource is not an AzureMonitorPrivateLinkScope"
+	errCreatePrivateLinkScope = "cannot create private link scope"
+	errUpdatePrivateLinkScope = "cannot update private link scope"
+	errGetPrivateLinkScope    = "cannot get private link scope"
+	errDeletePrivateLinkScope = "cannot delete private link scope"
+)
+
+// Setup adds a controller that reconciles AzureMonitorPrivateLinkScopes.
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, sel labels.Selector) error {
+	name := managed.ControllerName(v1alpha1.AzureMonitorPrivateLinkScopeGroupKind)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: concurrency.DefaultLimits.Of(v1alpha1.AzureMonitorPrivateLinkScopeGroupKind),
+		}).
+		For(&v1alpha1.AzureMonitorPrivateLinkScope{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, credentials.EnqueueRequestsForRotation(mgr.GetClient(), &v1alpha1.AzureMonitorPrivateLinkScopeList{}, l.WithValues("controller", name)), builder.OnlyMetadata).
+		Watches(&source.Kind{Type: &azurev1alpha3.ResourceGroup{}}, dependency.EnqueueRequestsForDependents(mgr.GetClient(), &v1alpha1.AzureMonitorPrivateLinkScopeList{}, l.WithValues("controller", name)), builder.WithPredicates(dependency.BecameAvailable())).
+		Complete(chain.NewReconciler(mgr, resource.ManagedKind(v1alpha1.AzureMonitorPrivateLinkScopeGroupVersionKind),
+			managed.NewReconciler(mgr,
+				resource.ManagedKind(v1alpha1.AzureMonitorPrivateLinkScopeGroupVersionKind),
+				managed.WithConnectionPublishers(),
+				managed.WithExternalConnecter(chain.NewConnecter(mgr, v1alpha1.AzureMonitorPrivateLinkScopeGroupKind, &connecter{client: mgr.GetClient()})),
+				managed.WithInitializers(managed.NewNameAsExternalName(mgr.GetClient()), externalname.NewInitializer(mgr.GetClient()), tenancy.NewDefaultProviderInitializer(mgr.GetClient()), defaults.NewInitializer(mgr.GetClient())),
+				managed.WithReferenceResolver(dependency.NewReferenceResolver(mgr.GetClient())),
+				managed.WithLogger(l.WithValues("controller", name)),
+				managed.WithRecorder(recorder)),
+			sel))
+}
+
+type connecter struct {
+	client client.Client
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	s, ok := mg.(*v1alpha1.AzureMonitorPrivateLinkScope)
+	if !ok {
+		return nil, errors.New(errNotPrivateLinkScope)
+	}
+	creds, auth, err := azureclients.GetAuthInfo(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	cl := monitor.NewPrivateLinkScopesClientWithBaseURI(azureclients.BaseURI(creds), azureclients.SubscriptionID(creds, s.Spec.ForProvider.SubscriptionID))
+	cl.Authorizer = auth
+	tp, err := azureclients.TagPolicyOf(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &external{client: cl, tagPolicy: tp}, nil
+}
+
+type external struct {
+	client    monitor.PrivateLinkScopesAPI
+	tagPolicy v1beta1.TagPolicy
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	s, ok := mg.(*v1alpha1.AzureMonitorPrivateLinkScope)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotPrivateLinkScope)
+	}
+
+	az, err := e.client.Get(ctx, s.Spec.ForProvider.ResourceGroupName, meta.GetExternalName(s))
+	if azureclients.IsNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetPrivateLinkScope)
+	}
+
+	current := s.Spec.ForProvider.DeepCopy()
+	monitor.LateInitializePrivateLinkScope(&s.Spec.ForProvider, az, e.tagPolicy)
+
+	s.Status.AtProvider = monitor.GeneratePrivateLinkScopeObservation(az)
+	switch s.Status.AtProvider.ProvisioningState {
+	case monitor.ProvisioningStateSucceeded:
+		s.SetConditions(xpv1.Available())
+	case monitor.ProvisioningStateFailed:
+		s.SetConditions(xpv1.Unavailable())
+	default:
+		s.SetConditions(xpv1.Creating())
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        !monitor.PrivateLinkScopeNeedsUpdate(s.Spec.ForProvider, az),
+		ResourceLateInitialized: !cmp.Equal(current, &s.Spec.ForProvider),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	s, ok := mg.(*v1alpha1.AzureMonitorPrivateLinkScope)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotPrivateLinkScope)
+	}
+
+	s.SetConditions(xpv1.Creating())
+	err := e.client.CreateOrUpdate(ctx, s.Spec.ForProvider.ResourceGroupName, meta.GetExternalName(s), monitor.NewPrivateLinkScope(s.Spec.ForProvider))
+	return managed.ExternalCreation{}, errors.Wrap(err, errCreatePrivateLinkScope)
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	s, ok := mg.(*v1alpha1.AzureMonitorPrivateLinkScope)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotPrivateLinkScope)
+	}
+
+	err := e.client.CreateOrUpdate(ctx, s.Spec.ForProvider.ResourceGroupName, meta.GetExternalName(s), monitor.NewPrivateLinkScope(s.Spec.ForProvider))
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdatePrivateLinkScope)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	s, ok := mg.(*v1alpha1.AzureMonitorPrivateLinkScope)
+	if !ok {
+		return errors.New(errNotPrivateLinkScope)
+	}
+
+	s.SetConditions(xpv1.Deleting())
+	err := e.client.Delete(ctx, s.Spec.ForProvider.ResourceGroupName, meta.GetExternalName(s))
+	return errors.Wrap(resource.Ignore(azureclients.IsNotFound, err), errDeletePrivateLinkScope)
+}
@@ -0,0 +1,238 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privatelinkscope
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/monitor/v1alpha1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/monitor"
+	"github.com/crossplane/provider-azure/pkg/clients/monitor/fake"
+)
+
+const name = "coolScope"
+
+var errBoom = errors.New("boom")
+
+type scopeModifier func(*v1alpha1.AzureMonitorPrivateLinkScope)
+
+func withConditions(c ...xpv1.Condition) scopeModifier {
+	return func(s *v1alpha1.AzureMonitorPrivateLinkScope) { s.Status.ConditionedStatus.Conditions = c }
+}
+
+func withIngestionAccessMode(m v1alpha1.AccessMode) scopeModifier {
+	return func(s *v1alpha1.AzureMonitorPrivateLinkScope) { s.Spec.ForProvider.IngestionAccessMode = &m }
+}
+
+func withQueryAccessMode(m v1alpha1.AccessMode) scopeModifier {
+	return func(s *v1alpha1.AzureMonitorPrivateLinkScope) { s.Spec.ForProvider.QueryAccessMode = &m }
+}
+
+func withState(st string) scopeModifier {
+	return func(s *v1alpha1.AzureMonitorPrivateLinkScope) { s.Status.AtProvider.ProvisioningState = st }
+}
+
+func scope(m ...scopeModifier) *v1alpha1.AzureMonitorPrivateLinkScope {
+	s := &v1alpha1.AzureMonitorPrivateLinkScope{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1alpha1.AzureMonitorPrivateLinkScopeSpec{
+			ForProvider: v1alpha1.AzureMonitorPrivateLinkScopeParameters{ResourceGroupName: "coolRG"},
+		},
+	}
+	meta.SetExternalName(s, name)
+	for _, fn := range m {
+		fn(s)
+	}
+	return s
+}
+
+func observed(state string, ingestion v1alpha1.AccessMode) monitor.PrivateLinkScope {
+	return monitor.PrivateLinkScope{
+		Location: azure.ToStringPtr(monitor.PrivateLinkScopeLocation),
+		Properties: &monitor.PrivateLinkScopeProperties{
+			ProvisioningState: azure.ToStringPtr(state),
+			AccessModeSettings: &monitor.AccessModeSettings{
+				QueryAccessMode:     azure.ToStringPtr(string(v1alpha1.AccessModeOpen)),
+				IngestionAccessMode: azure.ToStringPtr(string(ingestion)),
+			},
+		},
+	}
+}
+
+var _ managed.ExternalClient = &external{}
+var _ managed.ExternalConnecter = &connecter{}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   want
+	}{
+		"NotPrivateLinkScope": {
+			reason: "An error should be returned if the managed resource is not an AzureMonitorPrivateLinkScope",
+			e:      &external{client: &fake.MockPrivateLinkScopesClient{}},
+			mg:     &v1alpha1.AzureMonitorPrivateLinkScopedResource{},
+			want:   want{mg: &v1alpha1.AzureMonitorPrivateLinkScopedResource{}, err: errors.New(errNotPrivateLinkScope)},
+		},
+		"NotFound": {
+			reason: "A private link scope that is not found should not exist",
+			e: &external{client: &fake.MockPrivateLinkScopesClient{
+				MockGet: func(_ context.Context, _, _ string) (monitor.PrivateLinkScope, error) {
+					return monitor.PrivateLinkScope{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			}},
+			mg:   scope(),
+			want: want{mg: scope()},
+		},
+		"GetFailed": {
+			reason: "Errors getting the private link scope should be returned",
+			e: &external{client: &fake.MockPrivateLinkScopesClient{
+				MockGet: func(_ context.Context, _, _ string) (monitor.PrivateLinkScope, error) {
+					return monitor.PrivateLinkScope{}, errBoom
+				},
+			}},
+			mg:   scope(),
+			want: want{mg: scope(), err: errors.Wrap(errBoom, errGetPrivateLinkScope)},
+		},
+		"LateInitialized": {
+			reason: "The access modes of a private link scope should be late initialized",
+			e: &external{client: &fake.MockPrivateLinkScopesClient{
+				MockGet: func(_ context.Context, _, _ string) (monitor.PrivateLinkScope, error) {
+					return observed(monitor.ProvisioningStateSucceeded, v1alpha1.AccessModeOpen), nil
+				},
+			}},
+			mg: scope(),
+			want: want{
+				mg: scope(
+					withQueryAccessMode(v1alpha1.AccessModeOpen),
+					withIngestionAccessMode(v1alpha1.AccessModeOpen),
+					withState(monitor.ProvisioningStateSucceeded),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true},
+			},
+		},
+		"Failed": {
+			reason: "A private link scope that failed to be provisioned should be unavailable",
+			e: &external{client: &fake.MockPrivateLinkScopesClient{
+				MockGet: func(_ context.Context, _, _ string) (monitor.PrivateLinkScope, error) {
+					return observed(monitor.ProvisioningStateFailed, v1alpha1.AccessModeOpen), nil
+				},
+			}},
+			mg: scope(withQueryAccessMode(v1alpha1.AccessModeOpen), withIngestionAccessMode(v1alpha1.AccessModeOpen)),
+			want: want{
+				mg: scope(
+					withQueryAccessMode(v1alpha1.AccessModeOpen),
+					withIngestionAccessMode(v1alpha1.AccessModeOpen),
+					withState(monitor.ProvisioningStateFailed),
+					withConditions(xpv1.Unavailable()),
+				),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"AccessModeChanged": {
+			reason: "A private link scope whose ingestion access mode differs should need an update",
+			e: &external{client: &fake.MockPrivateLinkScopesClient{
+				MockGet: func(_ context.Context, _, _ string) (monitor.PrivateLinkScope, error) {
+					return observed(monitor.ProvisioningStateSucceeded, v1alpha1.AccessModeOpen), nil
+				},
+			}},
+			mg: scope(withQueryAccessMode(v1alpha1.AccessModeOpen), withIngestionAccessMode(v1alpha1.AccessModePrivateOnly)),
+			want: want{
+				mg: scope(
+					withQueryAccessMode(v1alpha1.AccessModeOpen),
+					withIngestionAccessMode(v1alpha1.AccessModePrivateOnly),
+					withState(monitor.ProvisioningStateSucceeded),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o, err := tc.e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want managed, +got managed:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   error
+	}{
+		"NotFound": {
+			reason: "A private link scope that is already gone should be considered deleted",
+			e: &external{client: &fake.MockPrivateLinkScopesClient{
+				MockDelete: func(_ context.Context, _, _ string) error {
+					return autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			}},
+			mg: scope(),
+		},
+		"Failed": {
+			reason: "Errors deleting the private link scope should be returned",
+			e: &external{client: &fake.MockPrivateLinkScopesClient{
+				MockDelete: func(_ context.Context, _, _ string) error { return errBoom },
+			}},
+			mg:   scope(),
+			want: errors.Wrap(errBoom, errDeletePrivateLinkScope),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privatelinkscopedresource
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-azure/apis/monitor/v1alpha1"
+	azurev1alpha3 "github.com/crossplane/provider-azure/apis/v1alpha3"
+	"github.com/crossplane/provider-azure/pkg/chain"
+	azureclients "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/monitor"
+	"github.com/crossplane/provider-azure/pkg/concurrency"
+	"github.com/crossplane/provider-azure/pkg/credentials"
+	"github.com/crossplane/provider-azure/pkg/defaults"
+	"github.com/crossplane/provider-azure/pkg/dependency"
+	"github.com/crossplane/provider-azure/pkg/externalname"
+	"github.com/crossplane/provider-azure/pkg/tenancy"
+)
+
+// Error strings.
+const (
+	errNotScopedResource    = "managed resource is not an AzureMonitorPrivateLinkScopedResource"
+	errCreateScopedResource = "cannot create private link scoped resource"
+	errGetScopedResource    = "cannot get private link scoped resource"
+	errDeleteScopedResource = "cannot delete private link scoped resource"
+)
+
+// Setup adds a controller that reconciles
+// AzureMonitorPrivateLinkScopedResources.
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, sel labels.Selector) error {
+	name := managed.ControllerName(v1alpha1.AzureMonitorPrivateLinkScopedResourceGroupKind)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: concurrency.DefaultLimits.Of(v1alpha1.AzureMonitorPrivateLinkScopedResourceGroupKind),
+		}).
+		For(&v1alpha1.AzureMonitorPrivateLinkScopedResource{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, credentials.EnqueueRequestsForRotation(mgr.GetClient(), &v1alpha1.AzureMonitorPrivateLinkScopedResourceList{}, l.WithValues("controller", name)), builder.OnlyMetadata).
+		Watches(&source.Kind{Type: &azurev1alpha3.ResourceGroup{}}, dependency.EnqueueRequestsForDependents(mgr.GetClient(), &v1alpha1.AzureMonitorPrivateLinkScopedResourceList{}, l.WithValues("controller", name)), builder.WithPredicates(dependency.BecameAvailable())).
+		Watches(&source.Kind{Type: &v1alpha1.AzureMonitorPrivateLinkScope{}}, dependency.EnqueueRequestsForDependents(mgr.GetClient(), &v1alpha1.AzureMonitorPrivateLinkScopedResourceList{}, l.WithValues("controller", name)), builder.WithPredicates(dependency.BecameAvailable())).
+		Complete(chain.NewReconciler(mgr, resource.ManagedKind(v1alpha1.AzureMonitorPrivateLinkScopedResourceGroupVersionKind),
+			managed.NewReconciler(mgr,
+				resource.ManagedKind(v1alpha1.AzureMonitorPrivateLinkScopedResourceGroupVersionKind),
+				managed.WithConnectionPublishers(),
+				managed.WithExternalConnecter(chain.NewConnecter(mgr, v1alpha1.AzureMonitorPrivateLinkScopedResourceGroupKind, &connecter{client: mgr.GetClient()})),
+				managed.WithInitializers(managed.NewNameAsExternalName(mgr.GetClient()), externalname.NewInitializer(mgr.GetClient()), tenancy.NewDefaultProviderInitializer(mgr.GetClient()), defaults.NewInitializer(mgr.GetClient())),
+				managed.WithReferenceResolver(dependency.NewReferenceResolver(mgr.GetClient())),
+				managed.WithLogger(l.WithValues("controller", name)),
+				managed.WithRecorder(recorder)),
+			sel))
+}
+
+type connecter struct {
+	client client.Client
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	r, ok := mg.(*v1alpha1.AzureMonitorPrivateLinkScopedResource)
+	if !ok {
+		return nil, errors.New(errNotScopedResource)
+	}
+	creds, auth, err := azureclients.GetAuthInfo(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	cl := monitor.NewPrivateLinkScopesClientWithBaseURI(azureclients.BaseURI(creds), azureclients.SubscriptionID(creds, r.Spec.ForProvider.SubscriptionID))
+	cl.Authorizer = auth
+	return &external{client: cl}, nil
+}
+
+type external struct {
+	client monitor.PrivateLinkScopesAPI
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	r, ok := mg.(*v1alpha1.AzureMonitorPrivateLinkScopedResource)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotScopedResource)
+	}
+
+	p := r.Spec.ForProvider
+	az, err := e.client.GetScopedResource(ctx, p.ResourceGroupName, p.PrivateLinkScopeName, meta.GetExternalName(r))
+	if azureclients.IsNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetScopedResource)
+	}
+
+	r.Status.AtProvider = monitor.GenerateScopedResourceObservation(az)
+	switch r.Status.AtProvider.ProvisioningState {
+	case monitor.ProvisioningStateSucceeded:
+		r.SetConditions(xpv1.Available())
+	case monitor.ProvisioningStateFailed:
+		r.SetConditions(xpv1.Unavailable())
+	default:
+		r.SetConditions(xpv1.Creating())
+	}
+
+	// A scoped resource has nothing to update; its linked resource is
+	// immutable.
+	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	r, ok := mg.(*v1alpha1.AzureMonitorPrivateLinkScopedResource)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotScopedResource)
+	}
+
+	r.SetConditions(xpv1.Creating())
+	p := r.Spec.ForProvider
+	err := e.client.CreateOrUpdateScopedResource(ctx, p.ResourceGroupName, p.PrivateLinkScopeName, meta.GetExternalName(r), monitor.NewScopedResource(p))
+	return managed.ExternalCreation{}, errors.Wrap(err, errCreateScopedResource)
+}
+
+func (e *external) Update(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	r, ok := mg.(*v1alpha1.AzureMonitorPrivateLinkScopedResource)
+	if !ok {
+		return errors.New(errNotScopedResource)
+	}
+
+	r.SetConditions(xpv1.Deleting())
+	err := e.client.DeleteScopedResource(ctx, r.Spec.ForProvider.ResourceGroupName, r.Spec.ForProvider.PrivateLinkScopeName, meta.GetExternalName(r))
+	return errors.Wrap(resource.Ignore(azureclients.IsNotFound, err), errDeleteScopedResource)
+}
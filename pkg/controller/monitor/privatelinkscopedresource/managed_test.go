@@ -0,0 +1,266 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privatelinkscopedresource
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/monitor/v1alpha1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/monitor"
+	"github.com/crossplane/provider-azure/pkg/clients/monitor/fake"
+)
+
+const (
+	name      = "coolScopedResource"
+	scopeName = "coolScope"
+	linkedID  = "/subscriptions/sub/resourceGroups/coolRG/providers/Microsoft.OperationalInsights/workspaces/coolWorkspace"
+)
+
+var errBoom = errors.New("boom")
+
+type scopedResourceModifier func(*v1alpha1.AzureMonitorPrivateLinkScopedResource)
+
+func withConditions(c ...xpv1.Condition) scopedResourceModifier {
+	return func(r *v1alpha1.AzureMonitorPrivateLinkScopedResource) { r.Status.ConditionedStatus.Conditions = c }
+}
+
+func withState(s string) scopedResourceModifier {
+	return func(r *v1alpha1.AzureMonitorPrivateLinkScopedResource) { r.Status.AtProvider.ProvisioningState = s }
+}
+
+func scopedResource(m ...scopedResourceModifier) *v1alpha1.AzureMonitorPrivateLinkScopedResource {
+	r := &v1alpha1.AzureMonitorPrivateLinkScopedResource{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1alpha1.AzureMonitorPrivateLinkScopedResourceSpec{
+			ForProvider: v1alpha1.AzureMonitorPrivateLinkScopedResourceParameters{
+				ResourceGroupName:    "coolRG",
+				PrivateLinkScopeName: scopeName,
+				LinkedResourceID:     linkedID,
+			},
+		},
+	}
+	meta.SetExternalName(r, name)
+	for _, fn := range m {
+		fn(r)
+	}
+	return r
+}
+
+func observed(state string) monitor.ScopedResource {
+	return monitor.ScopedResource{Properties: &monitor.ScopedResourceProperties{
+		LinkedResourceID:  azure.ToStringPtr(linkedID),
+		ProvisioningState: azure.ToStringPtr(state),
+	}}
+}
+
+var _ managed.ExternalClient = &external{}
+var _ managed.ExternalConnecter = &connecter{}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   want
+	}{
+		"NotScopedResource": {
+			reason: "An error should be returned if the managed resource is not an AzureMonitorPrivateLinkScopedResource",
+			e:      &external{client: &fake.MockPrivateLinkScopesClient{}},
+			mg:     &v1alpha1.AzureMonitorPrivateLinkScope{},
+			want:   want{mg: &v1alpha1.AzureMonitorPrivateLinkScope{}, err: errors.New(errNotScopedResource)},
+		},
+		"NotFound": {
+			reason: "A scoped resource that is not found should not exist",
+			e: &external{client: &fake.MockPrivateLinkScopesClient{
+				MockGetScopedResource: func(_ context.Context, _, _, _ string) (monitor.ScopedResource, error) {
+					return monitor.ScopedResource{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			}},
+			mg:   scopedResource(),
+			want: want{mg: scopedResource()},
+		},
+		"GetFailed": {
+			reason: "Errors getting the scoped resource should be returned",
+			e: &external{client: &fake.MockPrivateLinkScopesClient{
+				MockGetScopedResource: func(_ context.Context, _, _, _ string) (monitor.ScopedResource, error) {
+					return monitor.ScopedResource{}, errBoom
+				},
+			}},
+			mg:   scopedResource(),
+			want: want{mg: scopedResource(), err: errors.Wrap(errBoom, errGetScopedResource)},
+		},
+		"Provisioning": {
+			reason: "A scoped resource that is still being provisioned should be creating",
+			e: &external{client: &fake.MockPrivateLinkScopesClient{
+				MockGetScopedResource: func(_ context.Context, _, _, _ string) (monitor.ScopedResource, error) {
+					return observed("Creating"), nil
+				},
+			}},
+			mg: scopedResource(),
+			want: want{
+				mg: scopedResource(withState("Creating"), withConditions(xpv1.Creating())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"Succeeded": {
+			reason: "A scoped resource that was provisioned should be available",
+			e: &external{client: &fake.MockPrivateLinkScopesClient{
+				MockGetScopedResource: func(_ context.Context, _, scope, _ string) (monitor.ScopedResource, error) {
+					if diff := cmp.Diff(scopeName, scope); diff != "" {
+						t.Errorf("GetScopedResource(...): -want scope, +got scope:\n%s", diff)
+					}
+					return observed(monitor.ProvisioningStateSucceeded), nil
+				},
+			}},
+			mg: scopedResource(),
+			want: want{
+				mg: scopedResource(withState(monitor.ProvisioningStateSucceeded), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"Failed": {
+			reason: "A scoped resource that failed to be provisioned should be unavailable",
+			e: &external{client: &fake.MockPrivateLinkScopesClient{
+				MockGetScopedResource: func(_ context.Context, _, _, _ string) (monitor.ScopedResource, error) {
+					return observed(monitor.ProvisioningStateFailed), nil
+				},
+			}},
+			mg: scopedResource(),
+			want: want{
+				mg: scopedResource(withState(monitor.ProvisioningStateFailed), withConditions(xpv1.Unavailable())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o, err := tc.e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want managed, +got managed:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   error
+	}{
+		"Successful": {
+			reason: "The scoped resource should link the resource of the spec to its private link scope",
+			e: &external{client: &fake.MockPrivateLinkScopesClient{
+				MockCreateOrUpdateScopedResource: func(_ context.Context, _, scope, _ string, r monitor.ScopedResource) error {
+					if diff := cmp.Diff(scopeName, scope); diff != "" {
+						t.Errorf("CreateOrUpdateScopedResource(...): -want scope, +got scope:\n%s", diff)
+					}
+					if diff := cmp.Diff(linkedID, azure.ToString(r.Properties.LinkedResourceID)); diff != "" {
+						t.Errorf("CreateOrUpdateScopedResource(...): -want linked resource, +got linked resource:\n%s", diff)
+					}
+					return nil
+				},
+			}},
+			mg: scopedResource(),
+		},
+		"Failed": {
+			reason: "Errors creating the scoped resource should be returned",
+			e: &external{client: &fake.MockPrivateLinkScopesClient{
+				MockCreateOrUpdateScopedResource: func(_ context.Context, _, _, _ string, _ monitor.ScopedResource) error {
+					return errBoom
+				},
+			}},
+			mg:   scopedResource(),
+			want: errors.Wrap(errBoom, errCreateScopedResource),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   error
+	}{
+		"NotFound": {
+			reason: "A scoped resource that is already gone should be deleted",
+			e: &external{client: &fake.MockPrivateLinkScopesClient{
+				MockDeleteScopedResource: func(_ context.Context, _, _, _ string) error {
+					return autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			}},
+			mg: scopedResource(),
+		},
+		"Failed": {
+			reason: "Errors deleting the scoped resource should be returned",
+			e: &external{client: &fake.MockPrivateLinkScopesClient{
+				MockDeleteScopedResource: func(_ context.Context, _, _, _ string) error {
+					return errBoom
+				},
+			}},
+			mg:   scopedResource(),
+			want: errors.Wrap(errBoom, errDeleteScopedResource),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-azure/apis/monitor/v1alpha1"
+	azurev1alpha3 "github.com/crossplane/provider-azure/apis/v1alpha3"
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+	"github.com/crossplane/provider-azure/pkg/chain"
+	azureclients "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/monitor"
+	"github.com/crossplane/provider-azure/pkg/concurrency"
+	"github.com/crossplane/provider-azure/pkg/credentials"
+	"github.com/crossplane/provider-azure/pkg/defaults"
+	"github.com/crossplane/provider-azure/pkg/dependency"
+	"github.com/crossplane/provider-azure/pkg/externalname"
+	"github.com/crossplane/provider-azure/pkg/location"
+	"github.com/crossplane/provider-azure/pkg/tenancy"
+)
+
+// Error strings.
+const (
+	errNotWorkspace    = "managed resource is not an AzureMonitorWorkspace"
+	errCreateWorkspace = "cannot create Azure Monitor workspace"
+	errUpdateWorkspace = "cannot update Azure Monitor workspace"
+	errGetWorkspace    = "cannot get Azure Monitor workspace"
+	errDeleteWorkspace = "cannot delete Azure Monitor workspace"
+)
+
+// Setup adds a controller that reconciles AzureMonitorWorkspaces.
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, sel labels.Selector) error {
+	name := managed.ControllerName(v1alpha1.AzureMonitorWorkspaceGroupKind)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: concurrency.DefaultLimits.Of(v1alpha1.AzureMonitorWorkspaceGroupKind),
+		}).
+		For(&v1alpha1.AzureMonitorWorkspace{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, credentials.EnqueueRequestsForRotation(mgr.GetClient(), &v1alpha1.AzureMonitorWorkspaceList{}, l.WithValues("controller", name)), builder.OnlyMetadata).
+		Watches(&source.Kind{Type: &azurev1alpha3.ResourceGroup{}}, dependency.EnqueueRequestsForDependents(mgr.GetClient(), &v1alpha1.AzureMonitorWorkspaceList{}, l.WithValues("controller", name)), builder.WithPredicates(dependency.BecameAvailable())).
+		Complete(chain.NewReconciler(mgr, resource.ManagedKind(v1alpha1.AzureMonitorWorkspaceGroupVersionKind),
+			managed.NewReconciler(mgr,
+				resource.ManagedKind(v1alpha1.AzureMonitorWorkspaceGroupVersionKind),
+				managed.WithExternalConnecter(chain.NewConnecter(mgr, v1alpha1.AzureMonitorWorkspaceGroupKind, &connecter{client: mgr.GetClient()})),
+				managed.WithInitializers(managed.NewNameAsExternalName(mgr.GetClient()), externalname.NewInitializer(mgr.GetClient()), tenancy.NewDefaultProviderInitializer(mgr.GetClient()), location.NewInitializer(mgr.GetClient()), defaults.NewInitializer(mgr.GetClient())),
+				managed.WithReferenceResolver(dependency.NewReferenceResolver(mgr.GetClient())),
+				managed.WithLogger(l.WithValues("controller", name)),
+				managed.WithRecorder(recorder)),
+			sel))
+}
+
+type connecter struct {
+	client client.Client
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	w, ok := mg.(*v1alpha1.AzureMonitorWorkspace)
+	if !ok {
+		return nil, errors.New(errNotWorkspace)
+	}
+	creds, auth, err := azureclients.GetAuthInfo(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	cl := monitor.NewWorkspacesClientWithBaseURI(azureclients.BaseURI(creds), azureclients.SubscriptionID(creds, w.Spec.ForProvider.SubscriptionID))
+	cl.Authorizer = auth
+	tp, err := azureclients.TagPolicyOf(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &external{client: cl, tagPolicy: tp}, nil
+}
+
+type external struct {
+	client    monitor.WorkspacesAPI
+	tagPolicy v1beta1.TagPolicy
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	w, ok := mg.(*v1alpha1.AzureMonitorWorkspace)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotWorkspace)
+	}
+
+	az, err := e.client.Get(ctx, w.Spec.ForProvider.ResourceGroupName, meta.GetExternalName(w))
+	if azureclients.IsNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetWorkspace)
+	}
+
+	current := w.Spec.ForProvider.DeepCopy()
+	monitor.LateInitializeWorkspace(&w.Spec.ForProvider, az, e.tagPolicy)
+
+	w.Status.AtProvider = monitor.GenerateWorkspaceObservation(az)
+	switch w.Status.AtProvider.ProvisioningState {
+	case monitor.ProvisioningStateSucceeded:
+		w.SetConditions(xpv1.Available())
+	case monitor.ProvisioningStateFailed:
+		w.SetConditions(xpv1.Unavailable())
+	default:
+		w.SetConditions(xpv1.Creating())
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        !monitor.WorkspaceNeedsUpdate(w.Spec.ForProvider, az),
+		ResourceLateInitialized: !cmp.Equal(current, &w.Spec.ForProvider),
+		ConnectionDetails:       connectionDetails(w.Status.AtProvider),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	w, ok := mg.(*v1alpha1.AzureMonitorWorkspace)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotWorkspace)
+	}
+
+	w.SetConditions(xpv1.Creating())
+	err := e.client.CreateOrUpdate(ctx, w.Spec.ForProvider.ResourceGroupName, meta.GetExternalName(w), monitor.NewWorkspace(w.Spec.ForProvider))
+	return managed.ExternalCreation{}, errors.Wrap(err, errCreateWorkspace)
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	w, ok := mg.(*v1alpha1.AzureMonitorWorkspace)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotWorkspace)
+	}
+
+	err := e.client.CreateOrUpdate(ctx, w.Spec.ForProvider.ResourceGroupName, meta.GetExternalName(w), monitor.NewWorkspace(w.Spec.ForProvider))
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateWorkspace)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	w, ok := mg.(*v1alpha1.AzureMonitorWorkspace)
+	if !ok {
+		return errors.New(errNotWorkspace)
+	}
+
+	w.SetConditions(xpv1.Deleting())
+	err := e.client.Delete(ctx, w.Spec.ForProvider.ResourceGroupName, meta.GetExternalName(w))
+	return errors.Wrap(resource.Ignore(azureclients.IsNotFound, err), errDeleteWorkspace)
+}
+
+// connectionDetails returns the endpoint Prometheus clients, e.g. Grafana,
+// query the metrics of the supplied workspace at.
+func connectionDetails(o v1alpha1.AzureMonitorWorkspaceObservation) managed.ConnectionDetails {
+	if o.PrometheusQueryEndpoint == "" {
+		return nil
+	}
+	return managed.ConnectionDetails{monitor.ConnectionKeyPrometheusQueryEndpoint: []byte(o.PrometheusQueryEndpoint)}
+}
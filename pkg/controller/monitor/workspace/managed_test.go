@@ -0,0 +1,225 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/monitor/v1alpha1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/monitor"
+	"github.com/crossplane/provider-azure/pkg/clients/monitor/fake"
+)
+
+const (
+	name     = "coolWorkspace"
+	endpoint = "https://coolworkspace.eastus.prometheus.monitor.azure.com"
+)
+
+var errBoom = errors.New("boom")
+
+type workspaceModifier func(*v1alpha1.AzureMonitorWorkspace)
+
+func withConditions(c ...xpv1.Condition) workspaceModifier {
+	return func(w *v1alpha1.AzureMonitorWorkspace) { w.Status.ConditionedStatus.Conditions = c }
+}
+
+func withPublicNetworkAccess(a v1alpha1.PublicNetworkAccess) workspaceModifier {
+	return func(w *v1alpha1.AzureMonitorWorkspace) { w.Spec.ForProvider.PublicNetworkAccess = &a }
+}
+
+func withObservation(o v1alpha1.AzureMonitorWorkspaceObservation) workspaceModifier {
+	return func(w *v1alpha1.AzureMonitorWorkspace) { w.Status.AtProvider = o }
+}
+
+func workspace(m ...workspaceModifier) *v1alpha1.AzureMonitorWorkspace {
+	w := &v1alpha1.AzureMonitorWorkspace{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1alpha1.AzureMonitorWorkspaceSpec{
+			ForProvider: v1alpha1.AzureMonitorWorkspaceParameters{ResourceGroupName: "coolRG", Location: "eastus"},
+		},
+	}
+	meta.SetExternalName(w, name)
+	for _, fn := range m {
+		fn(w)
+	}
+	return w
+}
+
+func observed(state string) monitor.Workspace {
+	return monitor.Workspace{
+		Location: azure.ToStringPtr("eastus"),
+		Properties: &monitor.WorkspaceProperties{
+			ProvisioningState:   azure.ToStringPtr(state),
+			PublicNetworkAccess: azure.ToStringPtr(string(v1alpha1.PublicNetworkAccessEnabled)),
+			Metrics:             &monitor.WorkspaceMetrics{PrometheusQueryEndpoint: azure.ToStringPtr(endpoint)},
+		},
+	}
+}
+
+var _ managed.ExternalClient = &external{}
+var _ managed.ExternalConnecter = &connecter{}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   want
+	}{
+		"NotWorkspace": {
+			reason: "An error should be returned if the managed resource is not an AzureMonitorWorkspace",
+			e:      &external{client: &fake.MockWorkspacesClient{}},
+			mg:     &v1alpha1.AzureMonitorPrivateLinkScope{},
+			want:   want{mg: &v1alpha1.AzureMonitorPrivateLinkScope{}, err: errors.New(errNotWorkspace)},
+		},
+		"NotFound": {
+			reason: "A workspace that is not found should not exist",
+			e: &external{client: &fake.MockWorkspacesClient{
+				MockGet: func(_ context.Context, _, _ string) (monitor.Workspace, error) {
+					return monitor.Workspace{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			}},
+			mg:   workspace(),
+			want: want{mg: workspace()},
+		},
+		"GetFailed": {
+			reason: "Errors getting the workspace should be returned",
+			e: &external{client: &fake.MockWorkspacesClient{
+				MockGet: func(_ context.Context, _, _ string) (monitor.Workspace, error) {
+					return monitor.Workspace{}, errBoom
+				},
+			}},
+			mg:   workspace(),
+			want: want{mg: workspace(), err: errors.Wrap(errBoom, errGetWorkspace)},
+		},
+		"Creating": {
+			reason: "A workspace that is still being provisioned should be creating and not publish a query endpoint",
+			e: &external{client: &fake.MockWorkspacesClient{
+				MockGet: func(_ context.Context, _, _ string) (monitor.Workspace, error) {
+					w := observed("Creating")
+					w.Properties.Metrics = nil
+					return w, nil
+				},
+			}},
+			mg: workspace(withPublicNetworkAccess(v1alpha1.PublicNetworkAccessEnabled)),
+			want: want{
+				mg: workspace(
+					withPublicNetworkAccess(v1alpha1.PublicNetworkAccessEnabled),
+					withObservation(v1alpha1.AzureMonitorWorkspaceObservation{ProvisioningState: "Creating"}),
+					withConditions(xpv1.Creating()),
+				),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"Available": {
+			reason: "A provisioned workspace should be late initialized and publish its query endpoint",
+			e: &external{client: &fake.MockWorkspacesClient{
+				MockGet: func(_ context.Context, _, _ string) (monitor.Workspace, error) {
+					return observed(monitor.ProvisioningStateSucceeded), nil
+				},
+			}},
+			mg: workspace(),
+			want: want{
+				mg: workspace(
+					withPublicNetworkAccess(v1alpha1.PublicNetworkAccessEnabled),
+					withObservation(v1alpha1.AzureMonitorWorkspaceObservation{
+						ProvisioningState:       monitor.ProvisioningStateSucceeded,
+						PrometheusQueryEndpoint: endpoint,
+					}),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+					ConnectionDetails:       managed.ConnectionDetails{monitor.ConnectionKeyPrometheusQueryEndpoint: []byte(endpoint)},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o, err := tc.e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want managed, +got managed:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   error
+	}{
+		"NotFound": {
+			reason: "A workspace that is already gone should be considered deleted",
+			e: &external{client: &fake.MockWorkspacesClient{
+				MockDelete: func(_ context.Context, _, _ string) error {
+					return autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			}},
+			mg: workspace(),
+		},
+		"Failed": {
+			reason: "Errors deleting the workspace should be returned",
+			e: &external{client: &fake.MockWorkspacesClient{
+				MockDelete: func(_ context.Context, _, _ string) error { return errBoom },
+			}},
+			mg:   workspace(),
+			want: errors.Wrap(errBoom, errDeleteWorkspace),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
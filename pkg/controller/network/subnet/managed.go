@@ -21,11 +21,16 @@ import (
 
 	azurenetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network/networkapi"
+	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
@@ -35,9 +40,19 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
-	"github.com/crossplane/provider-azure/apis/network/v1alpha3"
+	"github.com/crossplane/provider-azure/apis/network/v1beta1"
+	azurev1alpha3 "github.com/crossplane/provider-azure/apis/v1alpha3"
+	"github.com/crossplane/provider-azure/pkg/chain"
 	azureclients "github.com/crossplane/provider-azure/pkg/clients"
 	"github.com/crossplane/provider-azure/pkg/clients/network"
+	"github.com/crossplane/provider-azure/pkg/concurrency"
+	"github.com/crossplane/provider-azure/pkg/connection"
+	"github.com/crossplane/provider-azure/pkg/credentials"
+	"github.com/crossplane/provider-azure/pkg/defaults"
+	"github.com/crossplane/provider-azure/pkg/dependency"
+	"github.com/crossplane/provider-azure/pkg/drift"
+	"github.com/crossplane/provider-azure/pkg/externalname"
+	"github.com/crossplane/provider-azure/pkg/tenancy"
 )
 
 // Error strings.
@@ -47,112 +62,194 @@ const (
 	errUpdateSubnet = "cannot update Subnet"
 	errGetSubnet    = "cannot get Subnet"
 	errDeleteSubnet = "cannot delete Subnet"
+
+	errWriteInProgress = "another write to virtual network %q or one of its subnets is in progress"
 )
 
 // Setup adds a controller that reconciles Subnets.
-func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
-	name := managed.ControllerName(v1alpha3.SubnetGroupKind)
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, sel labels.Selector) error {
+	name := managed.ControllerName(v1beta1.SubnetGroupKind)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(controller.Options{
-			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: concurrency.DefaultLimits.Of(v1beta1.SubnetGroupKind),
 		}).
-		For(&v1alpha3.Subnet{}).
-		Complete(managed.NewReconciler(mgr,
-			resource.ManagedKind(v1alpha3.SubnetGroupVersionKind),
-			managed.WithConnectionPublishers(),
-			managed.WithExternalConnecter(&connecter{client: mgr.GetClient()}),
-			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
-			managed.WithLogger(l.WithValues("controller", name)),
-			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+		For(&v1beta1.Subnet{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, credentials.EnqueueRequestsForRotation(mgr.GetClient(), &v1beta1.SubnetList{}, l.WithValues("controller", name)), builder.OnlyMetadata).
+		Watches(&source.Kind{Type: &azurev1alpha3.ResourceGroup{}}, dependency.EnqueueRequestsForDependents(mgr.GetClient(), &v1beta1.SubnetList{}, l.WithValues("controller", name)), builder.WithPredicates(dependency.BecameAvailable())).
+		Watches(&source.Kind{Type: &v1beta1.VirtualNetwork{}}, dependency.EnqueueRequestsForDependents(mgr.GetClient(), &v1beta1.SubnetList{}, l.WithValues("controller", name)), builder.WithPredicates(dependency.BecameAvailable())).
+		Complete(chain.NewReconciler(mgr, resource.ManagedKind(v1beta1.SubnetGroupVersionKind),
+			managed.NewReconciler(mgr,
+				resource.ManagedKind(v1beta1.SubnetGroupVersionKind),
+				managed.WithConnectionPublishers(connection.NewNamespacePolicyPublisher(connection.DefaultNamespacePolicy, connection.NewTemplatingPublisher(connection.NewKeyVaultPublisher(mgr.GetClient(), mgr.GetScheme(),
+					managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme()),
+					connection.NewAdditionalNamespacesPublisher(mgr.GetClient(), mgr.GetScheme()))))),
+				managed.WithExternalConnecter(chain.NewConnecter(mgr, v1beta1.SubnetGroupKind, drift.NewConnecter(&connecter{client: mgr.GetClient(), writes: azureclients.NewWriteTracker(), serial: network.VirtualNetworkWrites}, recorder))),
+				managed.WithInitializers(managed.NewNameAsExternalName(mgr.GetClient()), externalname.NewInitializer(mgr.GetClient()), tenancy.NewDefaultProviderInitializer(mgr.GetClient()), defaults.NewInitializer(mgr.GetClient()), connection.NewNamespaceInitializer(mgr.GetClient(), connection.DefaultNamespacePolicy)),
+				managed.WithReferenceResolver(dependency.NewReferenceResolver(mgr.GetClient())),
+				managed.WithLogger(l.WithValues("controller", name)),
+				managed.WithRecorder(recorder)),
+			sel))
 }
 
 type connecter struct {
 	client client.Client
+	writes *azureclients.WriteTracker
+	serial *azureclients.KeyedSemaphore
 }
 
 func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	s, ok := mg.(*v1beta1.Subnet)
+	if !ok {
+		return nil, errors.New(errNotSubnet)
+	}
 	creds, auth, err := azureclients.GetAuthInfo(ctx, c.client, mg)
 	if err != nil {
 		return nil, err
 	}
-	cl := azurenetwork.NewSubnetsClient(creds[azureclients.CredentialsKeySubscriptionID])
+	cl := azurenetwork.NewSubnetsClientWithBaseURI(azureclients.BaseURI(creds), azureclients.SubscriptionID(creds, s.Spec.ForProvider.SubscriptionID))
 	cl.Authorizer = auth
-	return &external{client: cl}, nil
+	cl.RequestInspector = azureclients.WithIfMatchFromContext()
+	return &external{client: cl, writes: c.writes, serial: c.serial}, nil
 }
 
-type external struct{ client networkapi.SubnetsClientAPI }
+type external struct {
+	client networkapi.SubnetsClientAPI
+	writes *azureclients.WriteTracker
+	serial *azureclients.KeyedSemaphore
+}
 
 func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
-	s, ok := mg.(*v1alpha3.Subnet)
+	s, ok := mg.(*v1beta1.Subnet)
 	if !ok {
 		return managed.ExternalObservation{}, errors.New(errNotSubnet)
 	}
 
-	az, err := e.client.Get(ctx, s.Spec.ResourceGroupName, s.Spec.VirtualNetworkName, meta.GetExternalName(s), "")
+	key := network.VirtualNetworkKey(s.Spec.ForProvider.ResourceGroupName, s.Spec.ForProvider.VirtualNetworkName)
+	az, err := e.client.Get(ctx, s.Spec.ForProvider.ResourceGroupName, s.Spec.ForProvider.VirtualNetworkName, meta.GetExternalName(s), "")
 	if azureclients.IsNotFound(err) {
+		e.serial.Release(key, s)
 		return managed.ExternalObservation{ResourceExists: false}, nil
 	}
 	if err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(err, errGetSubnet)
 	}
 
-	network.UpdateSubnetStatusFromAzure(s, az)
-	s.SetConditions(xpv1.Available())
+	current := s.Spec.DeepCopy()
+	network.LateInitializeSubnet(s, az)
+
+	s.Status.AtProvider = network.GenerateSubnetObservation(az)
+	s.Status.Drift = network.SubnetDrift(s, az)
+	s.SetConditions(azureclients.ProvisioningCondition(s.Status.AtProvider.ProvisioningState))
+	if !azureclients.IsTransitioning(s.Status.AtProvider.ProvisioningState) {
+		e.serial.Release(key, s)
+	}
+	if s.Status.AtProvider.ProvisioningState == string(azurenetwork.Succeeded) {
+		e.writes.Observed(s, s.Status.AtProvider.Etag)
+	}
 
 	o := managed.ExternalObservation{
-		ResourceExists:    true,
-		ConnectionDetails: managed.ConnectionDetails{},
+		ResourceExists:          true,
+		ResourceUpToDate:        azureclients.IsTransitioning(s.Status.AtProvider.ProvisioningState) || !e.needsUpdate(s, az),
+		ResourceLateInitialized: !cmp.Equal(current, &s.Spec),
+		ConnectionDetails:       network.SubnetConnectionDetails(s.Status.AtProvider),
 	}
 
 	return o, nil
 }
 
 func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
-	s, ok := mg.(*v1alpha3.Subnet)
+	s, ok := mg.(*v1beta1.Subnet)
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errNotSubnet)
 	}
 
 	s.Status.SetConditions(xpv1.Creating())
 
+	// A matching subnet may have appeared since it was observed, e.g. because
+	// a previous creation was still in progress. Writing it again would be a
+	// no-op.
+	az, err := e.client.Get(ctx, s.Spec.ForProvider.ResourceGroupName, s.Spec.ForProvider.VirtualNetworkName, meta.GetExternalName(s), "")
+	if resource.Ignore(azureclients.IsNotFound, err) != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errGetSubnet)
+	}
+	if err == nil && !network.SubnetNeedsUpdate(s, az) {
+		return managed.ExternalCreation{}, nil
+	}
+
+	key := network.VirtualNetworkKey(s.Spec.ForProvider.ResourceGroupName, s.Spec.ForProvider.VirtualNetworkName)
+	if !e.serial.TryAcquire(key, s) {
+		return managed.ExternalCreation{}, errors.Errorf(errWriteInProgress, s.Spec.ForProvider.VirtualNetworkName)
+	}
 	snet := network.NewSubnetParameters(s)
-	if _, err := e.client.CreateOrUpdate(ctx, s.Spec.ResourceGroupName, s.Spec.VirtualNetworkName, meta.GetExternalName(s), snet); err != nil {
+	if _, err := e.client.CreateOrUpdate(ctx, s.Spec.ForProvider.ResourceGroupName, s.Spec.ForProvider.VirtualNetworkName, meta.GetExternalName(s), snet); err != nil {
+		e.serial.Release(key, s)
 		return managed.ExternalCreation{}, errors.Wrap(err, errCreateSubnet)
 	}
+	e.writes.Written(s)
 
 	return managed.ExternalCreation{}, nil
 }
 
 func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
-	s, ok := mg.(*v1alpha3.Subnet)
+	s, ok := mg.(*v1beta1.Subnet)
 	if !ok {
 		return managed.ExternalUpdate{}, errors.New(errNotSubnet)
 	}
+	if azureclients.IsTransitioning(s.Status.AtProvider.ProvisioningState) {
+		return managed.ExternalUpdate{}, nil
+	}
 
-	az, err := e.client.Get(ctx, s.Spec.ResourceGroupName, s.Spec.VirtualNetworkName, meta.GetExternalName(s), "")
+	az, err := e.client.Get(ctx, s.Spec.ForProvider.ResourceGroupName, s.Spec.ForProvider.VirtualNetworkName, meta.GetExternalName(s), "")
 	if err != nil {
 		return managed.ExternalUpdate{}, errors.Wrap(err, errGetSubnet)
 	}
 
-	if network.SubnetNeedsUpdate(s, az) {
+	if e.needsUpdate(s, az) {
+		key := network.VirtualNetworkKey(s.Spec.ForProvider.ResourceGroupName, s.Spec.ForProvider.VirtualNetworkName)
+		if !e.serial.TryAcquire(key, s) {
+			return managed.ExternalUpdate{}, errors.Errorf(errWriteInProgress, s.Spec.ForProvider.VirtualNetworkName)
+		}
 		snet := network.NewSubnetParameters(s)
-		if _, err := e.client.CreateOrUpdate(ctx, s.Spec.ResourceGroupName, s.Spec.VirtualNetworkName, meta.GetExternalName(s), snet); err != nil {
-			return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateSubnet)
+		// Only write if the resource is still the one that was observed, in
+		// order not to silently revert a change made by someone else since.
+		if _, err := e.client.CreateOrUpdate(azureclients.WithIfMatch(ctx, s.Status.AtProvider.Etag), s.Spec.ForProvider.ResourceGroupName, s.Spec.ForProvider.VirtualNetworkName, meta.GetExternalName(s), snet); err != nil {
+			e.serial.Release(key, s)
+			return managed.ExternalUpdate{}, errors.Wrap(azureclients.ExplainPreconditionFailed(err), errUpdateSubnet)
 		}
+		e.writes.Written(s)
 	}
 	return managed.ExternalUpdate{}, nil
 }
 
 func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
-	s, ok := mg.(*v1alpha3.Subnet)
+	s, ok := mg.(*v1beta1.Subnet)
 	if !ok {
 		return errors.New(errNotSubnet)
 	}
 
 	mg.SetConditions(xpv1.Deleting())
+	if azureclients.IsTransitioning(s.Status.AtProvider.ProvisioningState) {
+		return nil
+	}
 
-	_, err := e.client.Delete(ctx, s.Spec.ResourceGroupName, s.Spec.VirtualNetworkName, meta.GetExternalName(s))
+	key := network.VirtualNetworkKey(s.Spec.ForProvider.ResourceGroupName, s.Spec.ForProvider.VirtualNetworkName)
+	if !e.serial.TryAcquire(key, s) {
+		return errors.Errorf(errWriteInProgress, s.Spec.ForProvider.VirtualNetworkName)
+	}
+	e.writes.Forget(s)
+	_, err := e.client.Delete(ctx, s.Spec.ForProvider.ResourceGroupName, s.Spec.ForProvider.VirtualNetworkName, meta.GetExternalName(s))
+	if err != nil {
+		e.serial.Release(key, s)
+	}
 	return errors.Wrap(resource.Ignore(azureclients.IsNotFound, err), errDeleteSubnet)
 }
+
+// needsUpdate returns true if the supplied subnet differs from its spec and
+// was not already written since either last changed.
+func (e *external) needsUpdate(s *v1beta1.Subnet, az azurenetwork.Subnet) bool {
+	return network.SubnetNeedsUpdate(s, az) && !e.writes.Unchanged(s, azureclients.ToString(az.Etag))
+}
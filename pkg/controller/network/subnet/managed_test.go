@@ -20,6 +20,7 @@ import (
 	"context"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
 	"github.com/Azure/go-autorest/autorest"
@@ -34,7 +35,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 
-	"github.com/crossplane/provider-azure/apis/network/v1alpha3"
+	"github.com/crossplane/provider-azure/apis/network/v1beta1"
 	azure "github.com/crossplane/provider-azure/pkg/clients"
 	"github.com/crossplane/provider-azure/pkg/clients/network/fake"
 )
@@ -60,30 +61,39 @@ type testCase struct {
 	wantErr error
 }
 
-type subnetModifier func(*v1alpha3.Subnet)
+type subnetModifier func(*v1beta1.Subnet)
 
 func withConditions(c ...xpv1.Condition) subnetModifier {
-	return func(r *v1alpha3.Subnet) { r.Status.ConditionedStatus.Conditions = c }
+	return func(r *v1beta1.Subnet) { r.Status.ConditionedStatus.Conditions = c }
 }
 
 func withState(s string) subnetModifier {
-	return func(r *v1alpha3.Subnet) { r.Status.State = s }
+	return func(r *v1beta1.Subnet) { r.Status.AtProvider.ProvisioningState = s }
 }
-func subnet(sm ...subnetModifier) *v1alpha3.Subnet {
-	r := &v1alpha3.Subnet{
+
+func withDrift(d ...string) subnetModifier {
+	return func(r *v1beta1.Subnet) { r.Status.Drift = d }
+}
+
+func withAtProvider(o v1beta1.SubnetObservation) subnetModifier {
+	return func(r *v1beta1.Subnet) { r.Status.AtProvider = o }
+}
+
+func subnet(sm ...subnetModifier) *v1beta1.Subnet {
+	r := &v1beta1.Subnet{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:       name,
 			UID:        uid,
 			Finalizers: []string{},
 		},
-		Spec: v1alpha3.SubnetSpec{
-			VirtualNetworkName: virtualNetworkName,
-			ResourceGroupName:  resourceGroupName,
-			SubnetPropertiesFormat: v1alpha3.SubnetPropertiesFormat{
-				AddressPrefix: addressPrefix,
+		Spec: v1beta1.SubnetSpec{
+			ForProvider: v1beta1.SubnetParameters{
+				VirtualNetworkName: virtualNetworkName,
+				ResourceGroupName:  resourceGroupName,
+				AddressPrefix:      addressPrefix,
 			},
 		},
-		Status: v1alpha3.SubnetStatus{},
+		Status: v1beta1.SubnetStatus{},
 	}
 
 	meta.SetExternalName(r, name)
@@ -99,18 +109,29 @@ func subnet(sm ...subnetModifier) *v1alpha3.Subnet {
 var _ managed.ExternalClient = &external{}
 var _ managed.ExternalConnecter = &connecter{}
 
+// writing returns a KeyedSemaphore whose slot of the virtual network of the
+// subnet is held by a write to another subnet.
+func writing() *azure.KeyedSemaphore {
+	s := azure.NewKeyedSemaphore(1, time.Minute)
+	s.TryAcquire(resourceGroupName+"/"+virtualNetworkName, &v1beta1.Subnet{ObjectMeta: metav1.ObjectMeta{UID: "another-uuid"}})
+	return s
+}
+
 func TestCreate(t *testing.T) {
 	cases := []testCase{
 		{
 			name:    "NotSubnet",
 			e:       &external{client: &fake.MockSubnetsClient{}},
-			r:       &v1alpha3.VirtualNetwork{},
-			want:    &v1alpha3.VirtualNetwork{},
+			r:       &v1beta1.VirtualNetwork{},
+			want:    &v1beta1.VirtualNetwork{},
 			wantErr: errors.New(errNotSubnet),
 		},
 		{
 			name: "SuccessfulCreate",
 			e: &external{client: &fake.MockSubnetsClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string, _ string) (result network.Subnet, err error) {
+					return network.Subnet{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
 				MockCreateOrUpdate: func(_ context.Context, _ string, _ string, _ string, _ network.Subnet) (network.SubnetsCreateOrUpdateFuture, error) {
 					return network.SubnetsCreateOrUpdateFuture{}, nil
 				},
@@ -123,6 +144,9 @@ func TestCreate(t *testing.T) {
 		{
 			name: "FailedCreate",
 			e: &external{client: &fake.MockSubnetsClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string, _ string) (result network.Subnet, err error) {
+					return network.Subnet{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
 				MockCreateOrUpdate: func(_ context.Context, _ string, _ string, _ string, _ network.Subnet) (network.SubnetsCreateOrUpdateFuture, error) {
 					return network.SubnetsCreateOrUpdateFuture{}, errorBoom
 				},
@@ -133,6 +157,48 @@ func TestCreate(t *testing.T) {
 			),
 			wantErr: errors.Wrap(errorBoom, errCreateSubnet),
 		},
+		{
+			name: "WriteInProgress",
+			e: &external{client: &fake.MockSubnetsClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string, _ string) (result network.Subnet, err error) {
+					return network.Subnet{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			}, serial: writing()},
+			r: subnet(),
+			want: subnet(
+				withConditions(xpv1.Creating()),
+			),
+			wantErr: errors.Errorf(errWriteInProgress, virtualNetworkName),
+		},
+		{
+			name: "AlreadyExists",
+			e: &external{client: &fake.MockSubnetsClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string, _ string) (result network.Subnet, err error) {
+					return network.Subnet{
+						SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+							AddressPrefix: azure.ToStringPtr(addressPrefix),
+						},
+					}, nil
+				},
+			}},
+			r: subnet(),
+			want: subnet(
+				withConditions(xpv1.Creating()),
+			),
+		},
+		{
+			name: "FailedGet",
+			e: &external{client: &fake.MockSubnetsClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string, _ string) (result network.Subnet, err error) {
+					return network.Subnet{}, errorBoom
+				},
+			}},
+			r: subnet(),
+			want: subnet(
+				withConditions(xpv1.Creating()),
+			),
+			wantErr: errors.Wrap(errorBoom, errGetSubnet),
+		},
 	}
 
 	for _, tc := range cases {
@@ -155,8 +221,8 @@ func TestObserve(t *testing.T) {
 		{
 			name:    "NotSubnet",
 			e:       &external{client: &fake.MockSubnetsClient{}},
-			r:       &v1alpha3.VirtualNetwork{},
-			want:    &v1alpha3.VirtualNetwork{},
+			r:       &v1beta1.VirtualNetwork{},
+			want:    &v1beta1.VirtualNetwork{},
 			wantErr: errors.New(errNotSubnet),
 		},
 		{
@@ -164,12 +230,12 @@ func TestObserve(t *testing.T) {
 			e: &external{client: &fake.MockSubnetsClient{
 				MockGet: func(_ context.Context, _ string, _ string, _ string, _ string) (result network.Subnet, err error) {
 					return network.Subnet{
-							SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
-								AddressPrefix: azure.ToStringPtr(addressPrefix),
-							},
-						}, autorest.DetailedError{
-							StatusCode: http.StatusNotFound,
-						}
+						SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+							AddressPrefix: azure.ToStringPtr(addressPrefix),
+						},
+					}, autorest.DetailedError{
+						StatusCode: http.StatusNotFound,
+					}
 				},
 			}},
 			r:    subnet(),
@@ -190,7 +256,53 @@ func TestObserve(t *testing.T) {
 			r: subnet(),
 			want: subnet(
 				withConditions(xpv1.Available()),
-				withState(string(network.Available)),
+				withAtProvider(v1beta1.SubnetObservation{
+					ProvisioningState: string(network.Available),
+					AddressPrefixes:   []string{addressPrefix},
+				}),
+			),
+		},
+		{
+			name: "SuccessfulObserveDrifted",
+			e: &external{client: &fake.MockSubnetsClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string, _ string) (result network.Subnet, err error) {
+					return network.Subnet{
+						SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+							AddressPrefix:     azure.ToStringPtr("10.1.0.0/16"),
+							ProvisioningState: azure.ToStringPtr(string(network.Available)),
+						},
+					}, nil
+				},
+			}},
+			r: subnet(),
+			want: subnet(
+				withConditions(xpv1.Available()),
+				withDrift("spec.forProvider.addressPrefix"),
+				withAtProvider(v1beta1.SubnetObservation{
+					ProvisioningState: string(network.Available),
+					AddressPrefixes:   []string{"10.1.0.0/16"},
+				}),
+			),
+		},
+		{
+			name: "SuccessfulObserveUpdating",
+			e: &external{client: &fake.MockSubnetsClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string, _ string) (result network.Subnet, err error) {
+					return network.Subnet{
+						SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+							AddressPrefix:     azure.ToStringPtr(addressPrefix),
+							ProvisioningState: azure.ToStringPtr(string(network.Updating)),
+						},
+					}, nil
+				},
+			}},
+			r: subnet(),
+			want: subnet(
+				withConditions(azure.Updating()),
+				withAtProvider(v1beta1.SubnetObservation{
+					ProvisioningState: string(network.Updating),
+					AddressPrefixes:   []string{addressPrefix},
+				}),
 			),
 		},
 		{
@@ -226,10 +338,16 @@ func TestUpdate(t *testing.T) {
 		{
 			name:    "NotSubnet",
 			e:       &external{client: &fake.MockSubnetsClient{}},
-			r:       &v1alpha3.VirtualNetwork{},
-			want:    &v1alpha3.VirtualNetwork{},
+			r:       &v1beta1.VirtualNetwork{},
+			want:    &v1beta1.VirtualNetwork{},
 			wantErr: errors.New(errNotSubnet),
 		},
+		{
+			name: "Transitioning",
+			e:    &external{client: &fake.MockSubnetsClient{}},
+			r:    subnet(withState(azure.ProvisioningStateUpdating)),
+			want: subnet(withState(azure.ProvisioningStateUpdating)),
+		},
 		{
 			name: "SuccessfulDoesNotNeedUpdate",
 			e: &external{client: &fake.MockSubnetsClient{
@@ -294,6 +412,24 @@ func TestUpdate(t *testing.T) {
 			want:    subnet(),
 			wantErr: errors.Wrap(errorBoom, errUpdateSubnet),
 		},
+		{
+			name: "ChangedSinceObserved",
+			e: &external{client: &fake.MockSubnetsClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string, _ string) (result network.Subnet, err error) {
+					return network.Subnet{
+						SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+							AddressPrefix: azure.ToStringPtr("10.1.0.0/16"),
+						},
+					}, nil
+				},
+				MockCreateOrUpdate: func(_ context.Context, _ string, _ string, _ string, _ network.Subnet) (network.SubnetsCreateOrUpdateFuture, error) {
+					return network.SubnetsCreateOrUpdateFuture{}, autorest.DetailedError{StatusCode: http.StatusPreconditionFailed}
+				},
+			}},
+			r:       subnet(),
+			want:    subnet(),
+			wantErr: errors.Wrap(azure.ExplainPreconditionFailed(autorest.DetailedError{StatusCode: http.StatusPreconditionFailed}), errUpdateSubnet),
+		},
 	}
 
 	for _, tc := range cases {
@@ -316,10 +452,19 @@ func TestDelete(t *testing.T) {
 		{
 			name:    "NotSubnet",
 			e:       &external{client: &fake.MockSubnetsClient{}},
-			r:       &v1alpha3.VirtualNetwork{},
-			want:    &v1alpha3.VirtualNetwork{},
+			r:       &v1beta1.VirtualNetwork{},
+			want:    &v1beta1.VirtualNetwork{},
 			wantErr: errors.New(errNotSubnet),
 		},
+		{
+			name: "Transitioning",
+			e:    &external{client: &fake.MockSubnetsClient{}},
+			r:    subnet(withState(azure.ProvisioningStateDeleting)),
+			want: subnet(
+				withConditions(xpv1.Deleting()),
+				withState(azure.ProvisioningStateDeleting),
+			),
+		},
 		{
 			name: "Successful",
 			e: &external{client: &fake.MockSubnetsClient{
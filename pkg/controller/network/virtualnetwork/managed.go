@@ -21,11 +21,16 @@ import (
 
 	azurenetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network/networkapi"
+	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
@@ -35,9 +40,23 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
-	"github.com/crossplane/provider-azure/apis/network/v1alpha3"
+	"github.com/crossplane/provider-azure/apis/network/v1beta1"
+	azurev1alpha3 "github.com/crossplane/provider-azure/apis/v1alpha3"
+	apisv1beta1 "github.com/crossplane/provider-azure/apis/v1beta1"
+	"github.com/crossplane/provider-azure/pkg/approval"
+	"github.com/crossplane/provider-azure/pkg/chain"
 	azureclients "github.com/crossplane/provider-azure/pkg/clients"
 	"github.com/crossplane/provider-azure/pkg/clients/network"
+	"github.com/crossplane/provider-azure/pkg/concurrency"
+	"github.com/crossplane/provider-azure/pkg/connection"
+	"github.com/crossplane/provider-azure/pkg/credentials"
+	"github.com/crossplane/provider-azure/pkg/defaults"
+	"github.com/crossplane/provider-azure/pkg/dependency"
+	"github.com/crossplane/provider-azure/pkg/drift"
+	"github.com/crossplane/provider-azure/pkg/externalname"
+	"github.com/crossplane/provider-azure/pkg/inuse"
+	"github.com/crossplane/provider-azure/pkg/location"
+	"github.com/crossplane/provider-azure/pkg/tenancy"
 )
 
 // Error strings.
@@ -47,81 +66,123 @@ const (
 	errUpdateVirtualNetwork = "cannot update VirtualNetwork"
 	errGetVirtualNetwork    = "cannot get VirtualNetwork"
 	errDeleteVirtualNetwork = "cannot delete VirtualNetwork"
+
+	errWriteInProgress = "another write to virtual network %q or one of its subnets is in progress"
 )
 
 // Setup adds a controller that reconciles VirtualNetworks.
-func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
-	name := managed.ControllerName(v1alpha3.VirtualNetworkGroupKind)
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, sel labels.Selector) error {
+	name := managed.ControllerName(v1beta1.VirtualNetworkGroupKind)
+	gate := approval.NewProviderConfigGate(mgr.GetClient(), mgr.GetScheme())
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(controller.Options{
-			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: concurrency.DefaultLimits.Of(v1beta1.VirtualNetworkGroupKind),
 		}).
-		For(&v1alpha3.VirtualNetwork{}).
-		Complete(managed.NewReconciler(mgr,
-			resource.ManagedKind(v1alpha3.VirtualNetworkGroupVersionKind),
-			managed.WithConnectionPublishers(),
-			managed.WithExternalConnecter(&connecter{client: mgr.GetClient()}),
-			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
-			managed.WithLogger(l.WithValues("controller", name)),
-			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+		For(&v1beta1.VirtualNetwork{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, credentials.EnqueueRequestsForRotation(mgr.GetClient(), &v1beta1.VirtualNetworkList{}, l.WithValues("controller", name)), builder.OnlyMetadata).
+		Watches(&source.Kind{Type: &azurev1alpha3.ResourceGroup{}}, dependency.EnqueueRequestsForDependents(mgr.GetClient(), &v1beta1.VirtualNetworkList{}, l.WithValues("controller", name)), builder.WithPredicates(dependency.BecameAvailable())).
+		Complete(chain.NewReconciler(mgr, resource.ManagedKind(v1beta1.VirtualNetworkGroupVersionKind),
+			managed.NewReconciler(mgr,
+				resource.ManagedKind(v1beta1.VirtualNetworkGroupVersionKind),
+				managed.WithConnectionPublishers(connection.NewNamespacePolicyPublisher(connection.DefaultNamespacePolicy, connection.NewTemplatingPublisher(connection.NewKeyVaultPublisher(mgr.GetClient(), mgr.GetScheme(),
+					managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme()),
+					connection.NewAdditionalNamespacesPublisher(mgr.GetClient(), mgr.GetScheme()))))),
+				managed.WithExternalConnecter(chain.NewConnecter(mgr, v1beta1.VirtualNetworkGroupKind, drift.NewConnecter(&connecter{client: mgr.GetClient(), gate: gate, serial: network.VirtualNetworkWrites}, recorder), chain.WithUsers(inuse.VirtualNetworkUsers))),
+				managed.WithInitializers(managed.NewNameAsExternalName(mgr.GetClient()), externalname.NewInitializer(mgr.GetClient()), tenancy.NewDefaultProviderInitializer(mgr.GetClient()), location.NewInitializer(mgr.GetClient()), defaults.NewInitializer(mgr.GetClient()), connection.NewNamespaceInitializer(mgr.GetClient(), connection.DefaultNamespacePolicy)),
+				managed.WithReferenceResolver(dependency.NewReferenceResolver(mgr.GetClient())),
+				managed.WithLogger(l.WithValues("controller", name)),
+				managed.WithRecorder(recorder)),
+			sel))
 }
 
 type connecter struct {
 	client client.Client
+	gate   approval.Gate
+	serial *azureclients.KeyedSemaphore
 }
 
 func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	v, ok := mg.(*v1beta1.VirtualNetwork)
+	if !ok {
+		return nil, errors.New(errNotVirtualNetwork)
+	}
 	creds, auth, err := azureclients.GetAuthInfo(ctx, c.client, mg)
 	if err != nil {
 		return nil, err
 	}
-	cl := azurenetwork.NewVirtualNetworksClient(creds[azureclients.CredentialsKeySubscriptionID])
+	cl := azurenetwork.NewVirtualNetworksClientWithBaseURI(azureclients.BaseURI(creds), azureclients.SubscriptionID(creds, v.Spec.ForProvider.SubscriptionID))
 	cl.Authorizer = auth
-	return &external{client: cl}, nil
+	cl.RequestInspector = azureclients.WithIfMatchFromContext()
+	tp, err := azureclients.TagPolicyOf(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &external{client: cl, gate: c.gate, serial: c.serial, tagPolicy: tp}, nil
 }
 
 type external struct {
-	client networkapi.VirtualNetworksClientAPI
+	client    networkapi.VirtualNetworksClientAPI
+	gate      approval.Gate
+	serial    *azureclients.KeyedSemaphore
+	tagPolicy apisv1beta1.TagPolicy
 }
 
 func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
-	v, ok := mg.(*v1alpha3.VirtualNetwork)
+	v, ok := mg.(*v1beta1.VirtualNetwork)
 	if !ok {
 		return managed.ExternalObservation{}, errors.New(errNotVirtualNetwork)
 	}
 
-	az, err := e.client.Get(ctx, v.Spec.ResourceGroupName, meta.GetExternalName(v), "")
+	key := network.VirtualNetworkKey(v.Spec.ForProvider.ResourceGroupName, meta.GetExternalName(v))
+	az, err := e.client.Get(ctx, v.Spec.ForProvider.ResourceGroupName, meta.GetExternalName(v), "")
 	if azureclients.IsNotFound(err) {
+		e.serial.Release(key, v)
 		return managed.ExternalObservation{ResourceExists: false}, nil
 	}
 	if err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(err, errGetVirtualNetwork)
 	}
 
-	network.UpdateVirtualNetworkStatusFromAzure(v, az)
+	current := v.Spec.DeepCopy()
+	network.LateInitializeVirtualNetwork(v, az, e.tagPolicy)
 
-	v.SetConditions(xpv1.Available())
+	v.Status.AtProvider = network.GenerateVirtualNetworkObservation(az)
+	v.Status.Drift = network.VirtualNetworkDrift(v, az)
+
+	v.SetConditions(azureclients.ProvisioningCondition(v.Status.AtProvider.ProvisioningState))
+	if !azureclients.IsTransitioning(v.Status.AtProvider.ProvisioningState) {
+		e.serial.Release(key, v)
+	}
 
 	o := managed.ExternalObservation{
-		ResourceExists:    true,
-		ConnectionDetails: managed.ConnectionDetails{},
+		ResourceExists:          true,
+		ResourceUpToDate:        azureclients.IsTransitioning(v.Status.AtProvider.ProvisioningState),
+		ResourceLateInitialized: !cmp.Equal(current, &v.Spec),
+		ConnectionDetails:       network.VirtualNetworkConnectionDetails(v.Status.AtProvider),
 	}
 
 	return o, nil
 }
 
 func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
-	v, ok := mg.(*v1alpha3.VirtualNetwork)
+	v, ok := mg.(*v1beta1.VirtualNetwork)
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errNotVirtualNetwork)
 	}
 
 	v.Status.SetConditions(xpv1.Creating())
 
+	key := network.VirtualNetworkKey(v.Spec.ForProvider.ResourceGroupName, meta.GetExternalName(v))
+	if !e.serial.TryAcquire(key, v) {
+		return managed.ExternalCreation{}, errors.Errorf(errWriteInProgress, meta.GetExternalName(v))
+	}
 	vnet := network.NewVirtualNetworkParameters(v)
-	if _, err := e.client.CreateOrUpdate(ctx, v.Spec.ResourceGroupName, meta.GetExternalName(v), vnet); err != nil {
+	if _, err := e.client.CreateOrUpdate(ctx, v.Spec.ForProvider.ResourceGroupName, meta.GetExternalName(v), vnet); err != nil {
+		e.serial.Release(key, v)
 		return managed.ExternalCreation{}, errors.Wrap(err, errCreateVirtualNetwork)
 	}
 
@@ -129,33 +190,58 @@ func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 }
 
 func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
-	v, ok := mg.(*v1alpha3.VirtualNetwork)
+	v, ok := mg.(*v1beta1.VirtualNetwork)
 	if !ok {
 		return managed.ExternalUpdate{}, errors.New(errNotVirtualNetwork)
 	}
+	if azureclients.IsTransitioning(v.Status.AtProvider.ProvisioningState) {
+		return managed.ExternalUpdate{}, nil
+	}
 
-	az, err := e.client.Get(ctx, v.Spec.ResourceGroupName, meta.GetExternalName(v), "")
+	az, err := e.client.Get(ctx, v.Spec.ForProvider.ResourceGroupName, meta.GetExternalName(v), "")
 	if err != nil {
 		return managed.ExternalUpdate{}, errors.Wrap(err, errGetVirtualNetwork)
 	}
 
 	if network.VirtualNetworkNeedsUpdate(v, az) {
+		if network.AddressSpaceShrinks(v, az) {
+			if err := approval.Hold(ctx, e.gate, v, approval.OperationUpdate); err != nil {
+				return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateVirtualNetwork)
+			}
+		}
+		key := network.VirtualNetworkKey(v.Spec.ForProvider.ResourceGroupName, meta.GetExternalName(v))
+		if !e.serial.TryAcquire(key, v) {
+			return managed.ExternalUpdate{}, errors.Errorf(errWriteInProgress, meta.GetExternalName(v))
+		}
 		vnet := network.NewVirtualNetworkParameters(v)
-		if _, err := e.client.CreateOrUpdate(ctx, v.Spec.ResourceGroupName, meta.GetExternalName(v), vnet); err != nil {
-			return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateVirtualNetwork)
+		// Only write if the resource is still the one that was observed, in
+		// order not to silently revert a change made by someone else since.
+		if _, err := e.client.CreateOrUpdate(azureclients.WithIfMatch(ctx, v.Status.AtProvider.Etag), v.Spec.ForProvider.ResourceGroupName, meta.GetExternalName(v), vnet); err != nil {
+			e.serial.Release(key, v)
+			return managed.ExternalUpdate{}, errors.Wrap(azureclients.ExplainPreconditionFailed(err), errUpdateVirtualNetwork)
 		}
 	}
 	return managed.ExternalUpdate{}, nil
 }
 
 func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
-	v, ok := mg.(*v1alpha3.VirtualNetwork)
+	v, ok := mg.(*v1beta1.VirtualNetwork)
 	if !ok {
 		return errors.New(errNotVirtualNetwork)
 	}
 
 	mg.SetConditions(xpv1.Deleting())
+	if azureclients.IsTransitioning(v.Status.AtProvider.ProvisioningState) {
+		return nil
+	}
 
-	_, err := e.client.Delete(ctx, v.Spec.ResourceGroupName, meta.GetExternalName(v))
+	key := network.VirtualNetworkKey(v.Spec.ForProvider.ResourceGroupName, meta.GetExternalName(v))
+	if !e.serial.TryAcquire(key, v) {
+		return errors.Errorf(errWriteInProgress, meta.GetExternalName(v))
+	}
+	_, err := e.client.Delete(ctx, v.Spec.ForProvider.ResourceGroupName, meta.GetExternalName(v))
+	if err != nil {
+		e.serial.Release(key, v)
+	}
 	return errors.Wrap(resource.Ignore(azureclients.IsNotFound, err), errDeleteVirtualNetwork)
 }
@@ -34,7 +34,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 
-	"github.com/crossplane/provider-azure/apis/network/v1alpha3"
+	"github.com/crossplane/provider-azure/apis/network/v1beta1"
 	azure "github.com/crossplane/provider-azure/pkg/clients"
 	"github.com/crossplane/provider-azure/pkg/clients/network/fake"
 )
@@ -44,7 +44,7 @@ const (
 	uid               = types.UID("definitely-a-uuid")
 	addressPrefix     = "10.0.0.0/16"
 	resourceGroupName = "coolRG"
-	location          = "coolplace"
+	testLocation      = "coolplace"
 )
 
 var (
@@ -61,36 +61,38 @@ type testCase struct {
 	wantErr error
 }
 
-type virtualNetworkModifier func(*v1alpha3.VirtualNetwork)
+type virtualNetworkModifier func(*v1beta1.VirtualNetwork)
 
 func withConditions(c ...xpv1.Condition) virtualNetworkModifier {
-	return func(r *v1alpha3.VirtualNetwork) { r.Status.ConditionedStatus.Conditions = c }
+	return func(r *v1beta1.VirtualNetwork) { r.Status.ConditionedStatus.Conditions = c }
 }
 
 func withState(s string) virtualNetworkModifier {
-	return func(r *v1alpha3.VirtualNetwork) { r.Status.State = s }
+	return func(r *v1beta1.VirtualNetwork) { r.Status.AtProvider.ProvisioningState = s }
 }
 
-func virtualNetwork(vm ...virtualNetworkModifier) *v1alpha3.VirtualNetwork {
-	r := &v1alpha3.VirtualNetwork{
+func withAtProvider(o v1beta1.VirtualNetworkObservation) virtualNetworkModifier {
+	return func(r *v1beta1.VirtualNetwork) { r.Status.AtProvider = o }
+}
+
+func virtualNetwork(vm ...virtualNetworkModifier) *v1beta1.VirtualNetwork {
+	r := &v1beta1.VirtualNetwork{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:       name,
 			UID:        uid,
 			Finalizers: []string{},
 		},
-		Spec: v1alpha3.VirtualNetworkSpec{
-			ResourceGroupName: resourceGroupName,
-			VirtualNetworkPropertiesFormat: v1alpha3.VirtualNetworkPropertiesFormat{
-				AddressSpace: v1alpha3.AddressSpace{
-					AddressPrefixes: []string{addressPrefix},
-				},
+		Spec: v1beta1.VirtualNetworkSpec{
+			ForProvider: v1beta1.VirtualNetworkParameters{
+				ResourceGroupName:    resourceGroupName,
+				AddressPrefixes:      []string{addressPrefix},
 				EnableDDOSProtection: true,
 				EnableVMProtection:   true,
+				Location:             testLocation,
+				Tags:                 tags,
 			},
-			Location: location,
-			Tags:     tags,
 		},
-		Status: v1alpha3.VirtualNetworkStatus{},
+		Status: v1beta1.VirtualNetworkStatus{},
 	}
 	meta.SetExternalName(r, name)
 
@@ -110,8 +112,8 @@ func TestCreate(t *testing.T) {
 		{
 			name:    "NotVirtualNetwok",
 			e:       &external{client: &fake.MockVirtualNetworksClient{}},
-			r:       &v1alpha3.Subnet{},
-			want:    &v1alpha3.Subnet{},
+			r:       &v1beta1.Subnet{},
+			want:    &v1beta1.Subnet{},
 			wantErr: errors.New(errNotVirtualNetwork),
 		},
 		{
@@ -161,8 +163,8 @@ func TestObserve(t *testing.T) {
 		{
 			name:    "NotVirtualNetwok",
 			e:       &external{client: &fake.MockVirtualNetworksClient{}},
-			r:       &v1alpha3.Subnet{},
-			want:    &v1alpha3.Subnet{},
+			r:       &v1beta1.Subnet{},
+			want:    &v1beta1.Subnet{},
 			wantErr: errors.New(errNotVirtualNetwork),
 		},
 		{
@@ -170,17 +172,17 @@ func TestObserve(t *testing.T) {
 			e: &external{client: &fake.MockVirtualNetworksClient{
 				MockGet: func(_ context.Context, _ string, _ string, _ string) (result network.VirtualNetwork, err error) {
 					return network.VirtualNetwork{
-							Tags: azure.ToStringPtrMap(tags),
-							VirtualNetworkPropertiesFormat: &network.VirtualNetworkPropertiesFormat{
-								AddressSpace: &network.AddressSpace{
-									AddressPrefixes: &[]string{addressPrefix},
-								},
-								EnableDdosProtection: azure.ToBoolPtr(true),
-								EnableVMProtection:   azure.ToBoolPtr(true),
+						Tags: azure.ToStringPtrMap(tags),
+						VirtualNetworkPropertiesFormat: &network.VirtualNetworkPropertiesFormat{
+							AddressSpace: &network.AddressSpace{
+								AddressPrefixes: &[]string{addressPrefix},
 							},
-						}, autorest.DetailedError{
-							StatusCode: http.StatusNotFound,
-						}
+							EnableDdosProtection: azure.ToBoolPtr(true),
+							EnableVMProtection:   azure.ToBoolPtr(true),
+						},
+					}, autorest.DetailedError{
+						StatusCode: http.StatusNotFound,
+					}
 				},
 			}},
 			r:    virtualNetwork(),
@@ -206,7 +208,10 @@ func TestObserve(t *testing.T) {
 			r: virtualNetwork(),
 			want: virtualNetwork(
 				withConditions(xpv1.Available()),
-				withState(string(network.Available)),
+				withAtProvider(v1beta1.VirtualNetworkObservation{
+					ProvisioningState: string(network.Available),
+					AddressPrefixes:   []string{addressPrefix},
+				}),
 			),
 		},
 		{
@@ -242,10 +247,16 @@ func TestUpdate(t *testing.T) {
 		{
 			name:    "NotVirtualNetwok",
 			e:       &external{client: &fake.MockVirtualNetworksClient{}},
-			r:       &v1alpha3.Subnet{},
-			want:    &v1alpha3.Subnet{},
+			r:       &v1beta1.Subnet{},
+			want:    &v1beta1.Subnet{},
 			wantErr: errors.New(errNotVirtualNetwork),
 		},
+		{
+			name: "Transitioning",
+			e:    &external{client: &fake.MockVirtualNetworksClient{}},
+			r:    virtualNetwork(withState(azure.ProvisioningStateUpdating)),
+			want: virtualNetwork(withState(azure.ProvisioningStateUpdating)),
+		},
 		{
 			name: "SuccessfulDoesNotNeedUpdate",
 			e: &external{client: &fake.MockVirtualNetworksClient{
@@ -330,6 +341,29 @@ func TestUpdate(t *testing.T) {
 			want:    virtualNetwork(),
 			wantErr: errors.Wrap(errorBoom, errUpdateVirtualNetwork),
 		},
+		{
+			name: "ChangedSinceObserved",
+			e: &external{client: &fake.MockVirtualNetworksClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (result network.VirtualNetwork, err error) {
+					return network.VirtualNetwork{
+						Tags: azure.ToStringPtrMap(tags),
+						VirtualNetworkPropertiesFormat: &network.VirtualNetworkPropertiesFormat{
+							AddressSpace: &network.AddressSpace{
+								AddressPrefixes: &[]string{"10.1.0.0/16"},
+							},
+							EnableDdosProtection: azure.ToBoolPtr(true),
+							EnableVMProtection:   azure.ToBoolPtr(true),
+						},
+					}, nil
+				},
+				MockCreateOrUpdate: func(_ context.Context, _ string, _ string, _ network.VirtualNetwork) (result network.VirtualNetworksCreateOrUpdateFuture, err error) {
+					return network.VirtualNetworksCreateOrUpdateFuture{}, autorest.DetailedError{StatusCode: http.StatusPreconditionFailed}
+				},
+			}},
+			r:       virtualNetwork(),
+			want:    virtualNetwork(),
+			wantErr: errors.Wrap(azure.ExplainPreconditionFailed(autorest.DetailedError{StatusCode: http.StatusPreconditionFailed}), errUpdateVirtualNetwork),
+		},
 	}
 
 	for _, tc := range cases {
@@ -352,10 +386,19 @@ func TestDelete(t *testing.T) {
 		{
 			name:    "NotVirtualNetwok",
 			e:       &external{client: &fake.MockVirtualNetworksClient{}},
-			r:       &v1alpha3.Subnet{},
-			want:    &v1alpha3.Subnet{},
+			r:       &v1beta1.Subnet{},
+			want:    &v1beta1.Subnet{},
 			wantErr: errors.New(errNotVirtualNetwork),
 		},
+		{
+			name: "Transitioning",
+			e:    &external{client: &fake.MockVirtualNetworksClient{}},
+			r:    virtualNetwork(withState(azure.ProvisioningStateDeleting)),
+			want: virtualNetwork(
+				withConditions(xpv1.Deleting()),
+				withState(azure.ProvisioningStateDeleting),
+			),
+		},
 		{
 			name: "Successful",
 			e: &external{client: &fake.MockVirtualNetworksClient{
@@ -40,9 +40,9 @@ import (
 )
 
 const (
-	uid      = types.UID("definitely-a-uuid")
-	name     = "cool-rg"
-	location = "coolplace"
+	uid          = types.UID("definitely-a-uuid")
+	name         = "cool-rg"
+	testLocation = "coolplace"
 )
 
 type resourceGroupModifier func(*v1alpha3.ResourceGroup)
@@ -63,7 +63,7 @@ func resourceGrp(rm ...resourceGroupModifier) *v1alpha3.ResourceGroup {
 			Finalizers: []string{},
 		},
 		Spec: v1alpha3.ResourceGroupSpec{
-			Location: location,
+			Location: testLocation,
 		},
 		Status: v1alpha3.ResourceGroupStatus{},
 	}
@@ -271,6 +271,72 @@ func TestCreate(t *testing.T) {
 	}
 }
 
+func TestUpdate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		e    managed.ExternalClient
+		args args
+		want want
+	}{
+		"NotResourceGroup": {
+			e: &external{},
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotResourceGroup),
+			},
+		},
+		"UpdateError": {
+			e: &external{
+				client: &fakerg.MockClient{
+					MockUpdate: func(_ context.Context, _ string, _ resources.GroupPatchable) (result resources.Group, err error) {
+						return resources.Group{}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: resourceGrp(),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errUpdateResourceGroup),
+			},
+		},
+		"Success": {
+			e: &external{
+				client: &fakerg.MockClient{
+					MockUpdate: func(_ context.Context, _ string, _ resources.GroupPatchable) (result resources.Group, err error) {
+						return resources.Group{}, nil
+					},
+				},
+			},
+			args: args{
+				mg: resourceGrp(),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.e.Update(tc.args.ctx, tc.args.mg)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("tc.e.Update(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestDelete(t *testing.T) {
 	errBoom := errors.New("boom")
 
@@ -0,0 +1,133 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourceobservation
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-azure/apis/v1alpha3"
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/observation"
+)
+
+const (
+	reconcileTimeout = 1 * time.Minute
+	syncInterval     = 10 * time.Minute
+	retryInterval    = 1 * time.Minute
+)
+
+// Error strings.
+const (
+	errGetObservation = "cannot get AzureResourceObservation"
+	errGetPC          = "cannot get referenced ProviderConfig"
+	errGetCreds       = "cannot get credentials of ProviderConfig"
+	errObserve        = "cannot observe Azure resource"
+	errUpdateStatus   = "cannot update AzureResourceObservation status"
+)
+
+// An ObserverFn returns an observation.Observer using the supplied
+// credentials.
+type ObserverFn func(creds map[string]string, auth autorest.Authorizer) observation.Observer
+
+// Setup adds a controller that populates AzureResourceObservations.
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
+	name := "resourceobservation/" + strings.ToLower(v1alpha3.AzureResourceObservationGroupKind)
+
+	r := &Reconciler{
+		client: mgr.GetClient(),
+		newObserver: func(creds map[string]string, auth autorest.Authorizer) observation.Observer {
+			return observation.NewClient(creds, auth)
+		},
+		log: l.WithValues("controller", name),
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha3.AzureResourceObservation{}).
+		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		Complete(r)
+}
+
+// A Reconciler mirrors the Azure resource of an AzureResourceObservation into
+// its status.
+type Reconciler struct {
+	client      client.Client
+	newObserver ObserverFn
+
+	log logging.Logger
+}
+
+// Reconcile an AzureResourceObservation.
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	log := r.log.WithValues("request", req)
+	log.Debug("Reconciling")
+
+	ctx, cancel := context.WithTimeout(ctx, reconcileTimeout)
+	defer cancel()
+
+	o := &v1alpha3.AzureResourceObservation{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: req.Name}, o); err != nil {
+		return reconcile.Result{}, errors.Wrap(resource.IgnoreNotFound(err), errGetObservation)
+	}
+
+	obs, err := r.observe(ctx, o)
+	if err != nil {
+		log.Debug(errObserve, "error", err)
+		o.Status.SetConditions(xpv1.Unavailable().WithMessage(err.Error()))
+		return reconcile.Result{RequeueAfter: retryInterval}, errors.Wrap(r.client.Status().Update(ctx, o), errUpdateStatus)
+	}
+
+	now := metav1.Now()
+	o.Status.AtProvider = &obs
+	o.Status.LastSyncTime = &now
+	o.Status.SetConditions(xpv1.Available())
+	return reconcile.Result{RequeueAfter: syncInterval}, errors.Wrap(r.client.Status().Update(ctx, o), errUpdateStatus)
+}
+
+func (r *Reconciler) observe(ctx context.Context, o *v1alpha3.AzureResourceObservation) (v1alpha3.ObservedAzureResource, error) {
+	pc := &v1beta1.ProviderConfig{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: o.Spec.ProviderConfigReference.Name}, pc); err != nil {
+		return v1alpha3.ObservedAzureResource{}, errors.Wrap(err, errGetPC)
+	}
+	creds, auth, err := azure.GetProviderConfigAuthInfo(ctx, r.client, pc)
+	if err != nil {
+		return v1alpha3.ObservedAzureResource{}, errors.Wrap(err, errGetCreds)
+	}
+	return r.newObserver(creds, auth).Observe(ctx, o.Spec.ResourceID, azure.ToString(o.Spec.APIVersion))
+}
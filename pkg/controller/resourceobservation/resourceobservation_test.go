@@ -0,0 +1,188 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourceobservation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/v1alpha3"
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+	"github.com/crossplane/provider-azure/pkg/clients/observation"
+)
+
+const (
+	id         = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet"
+	apiVersion = "2020-06-01"
+)
+
+type mockObserver struct {
+	MockObserve func(ctx context.Context, id, apiVersion string) (v1alpha3.ObservedAzureResource, error)
+}
+
+func (m *mockObserver) Observe(ctx context.Context, id, apiVersion string) (v1alpha3.ObservedAzureResource, error) {
+	return m.MockObserve(ctx, id, apiVersion)
+}
+
+func TestReconcile(t *testing.T) {
+	errBoom := errors.New("boom")
+	observed := v1alpha3.ObservedAzureResource{ID: id, Name: "vnet", Type: "Microsoft.Network/virtualNetworks", APIVersion: apiVersion}
+
+	// get returns the observation, and a ProviderConfig that authenticates as
+	// a managed identity.
+	get := func(pcErr error) test.MockGetFn {
+		return func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			switch o := obj.(type) {
+			case *v1alpha3.AzureResourceObservation:
+				v := apiVersion
+				o.Spec = v1alpha3.AzureResourceObservationSpec{ProviderConfigReference: xpv1.Reference{Name: "default"}, ResourceID: id, APIVersion: &v}
+			case *v1beta1.ProviderConfig:
+				o.Spec.UseManagedIdentity = &v1beta1.ManagedIdentity{SubscriptionID: "sub"}
+				return pcErr
+			}
+			return nil
+		}
+	}
+	// status returns a MockStatusUpdateFn that expects the supplied status.
+	status := func(want v1alpha3.AzureResourceObservationStatus, err error) test.MockStatusUpdateFn {
+		return test.NewMockStatusUpdateFn(err, func(obj client.Object) error {
+			got := obj.(*v1alpha3.AzureResourceObservation).Status
+			if diff := cmp.Diff(want, got, test.EquateConditions(), cmpopts.IgnoreFields(v1alpha3.AzureResourceObservationStatus{}, "LastSyncTime")); diff != "" {
+				t.Errorf("Status().Update(...): -want, +got:\n%s", diff)
+			}
+			return nil
+		})
+	}
+	unavailable := func(msg string) v1alpha3.AzureResourceObservationStatus {
+		s := v1alpha3.AzureResourceObservationStatus{}
+		s.SetConditions(xpv1.Unavailable().WithMessage(msg))
+		return s
+	}
+	available := v1alpha3.AzureResourceObservationStatus{AtProvider: &observed}
+	available.SetConditions(xpv1.Available())
+
+	type fields struct {
+		client   client.Client
+		observer observation.Observer
+	}
+	type want struct {
+		r   reconcile.Result
+		err error
+	}
+	cases := map[string]struct {
+		reason string
+		fields fields
+		want   want
+	}{
+		"GetObservationError": {
+			reason: "Errors getting the AzureResourceObservation should be returned.",
+			fields: fields{
+				client: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			},
+			want: want{err: errors.Wrap(errBoom, errGetObservation)},
+		},
+		"ObservationNotFound": {
+			reason: "AzureResourceObservations that no longer exist should be ignored.",
+			fields: fields{
+				client: &test.MockClient{MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, ""))},
+			},
+			want: want{},
+		},
+		"GetProviderConfigError": {
+			reason: "An AzureResourceObservation whose ProviderConfig cannot be read should be unavailable.",
+			fields: fields{
+				client: &test.MockClient{
+					MockGet:          get(errBoom),
+					MockStatusUpdate: status(unavailable(errors.Wrap(errBoom, errGetPC).Error()), nil),
+				},
+			},
+			want: want{r: reconcile.Result{RequeueAfter: retryInterval}},
+		},
+		"ObserveError": {
+			reason: "An AzureResourceObservation whose resource cannot be observed should be unavailable.",
+			fields: fields{
+				client: &test.MockClient{
+					MockGet:          get(nil),
+					MockStatusUpdate: status(unavailable(errBoom.Error()), nil),
+				},
+				observer: &mockObserver{MockObserve: func(_ context.Context, _, _ string) (v1alpha3.ObservedAzureResource, error) {
+					return v1alpha3.ObservedAzureResource{}, errBoom
+				}},
+			},
+			want: want{r: reconcile.Result{RequeueAfter: retryInterval}},
+		},
+		"Success": {
+			reason: "The observed resource should be mirrored into the status of the AzureResourceObservation.",
+			fields: fields{
+				client: &test.MockClient{
+					MockGet:          get(nil),
+					MockStatusUpdate: status(available, nil),
+				},
+				observer: &mockObserver{MockObserve: func(_ context.Context, gotID, gotVersion string) (v1alpha3.ObservedAzureResource, error) {
+					if gotID != id || gotVersion != apiVersion {
+						t.Errorf("Observe(...): want %s?api-version=%s, got %s?api-version=%s", id, apiVersion, gotID, gotVersion)
+					}
+					return observed, nil
+				}},
+			},
+			want: want{r: reconcile.Result{RequeueAfter: syncInterval}},
+		},
+		"UpdateStatusError": {
+			reason: "Errors updating the status of an AzureResourceObservation should be returned.",
+			fields: fields{
+				client: &test.MockClient{
+					MockGet:          get(nil),
+					MockStatusUpdate: status(available, errBoom),
+				},
+				observer: &mockObserver{MockObserve: func(_ context.Context, _, _ string) (v1alpha3.ObservedAzureResource, error) {
+					return observed, nil
+				}},
+			},
+			want: want{r: reconcile.Result{RequeueAfter: syncInterval}, err: errors.Wrap(errBoom, errUpdateStatus)},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &Reconciler{
+				client:      tc.fields.client,
+				newObserver: func(_ map[string]string, _ autorest.Authorizer) observation.Observer { return tc.fields.observer },
+				log:         logging.NewNopLogger(),
+			}
+			got, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "vnet"}})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.r, got); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package skucatalog
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-azure/apis/v1alpha3"
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/sku"
+	"github.com/crossplane/provider-azure/pkg/feature"
+)
+
+const (
+	reconcileTimeout = 5 * time.Minute
+	syncInterval     = 6 * time.Hour
+	retryInterval    = 5 * time.Minute
+)
+
+// Error strings.
+const (
+	errGetCatalog   = "cannot get AzureSKUCatalog"
+	errGetPC        = "cannot get referenced ProviderConfig"
+	errGetCreds     = "cannot get credentials of ProviderConfig"
+	errListSKUs     = "cannot list SKUs"
+	errUpdateStatus = "cannot update AzureSKUCatalog status"
+	errDisabled     = "the sku-catalog feature is disabled, or Azure forbids its requests"
+)
+
+// A ListerFn returns a sku.Lister using the supplied credentials.
+type ListerFn func(creds map[string]string, auth autorest.Authorizer) sku.Lister
+
+// Setup adds a controller that populates AzureSKUCatalogs.
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
+	name := "skucatalog/" + strings.ToLower(v1alpha3.AzureSKUCatalogGroupKind)
+
+	r := &Reconciler{
+		client: mgr.GetClient(),
+		newLister: func(creds map[string]string, auth autorest.Authorizer) sku.Lister {
+			return sku.NewClient(creds, auth)
+		},
+		gate: feature.DefaultGate,
+		log:  l.WithValues("controller", name),
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha3.AzureSKUCatalog{}).
+		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		Complete(r)
+}
+
+// A Reconciler populates an AzureSKUCatalog with the SKUs Azure offers in its
+// locations.
+type Reconciler struct {
+	client    client.Client
+	newLister ListerFn
+	gate      *feature.Gate
+
+	log logging.Logger
+}
+
+// Reconcile an AzureSKUCatalog.
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	log := r.log.WithValues("request", req)
+	log.Debug("Reconciling")
+
+	ctx, cancel := context.WithTimeout(ctx, reconcileTimeout)
+	defer cancel()
+
+	c := &v1alpha3.AzureSKUCatalog{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: req.Name}, c); err != nil {
+		return reconcile.Result{}, errors.Wrap(resource.IgnoreNotFound(err), errGetCatalog)
+	}
+
+	if !r.gate.Enabled(feature.SKUCatalog) {
+		log.Debug(errDisabled)
+		c.Status.SetConditions(xpv1.Unavailable().WithMessage(errDisabled))
+		return reconcile.Result{RequeueAfter: retryInterval}, errors.Wrap(r.client.Status().Update(ctx, c), errUpdateStatus)
+	}
+
+	skus, err := r.list(ctx, c)
+	if err != nil {
+		log.Debug(errListSKUs, "error", err)
+		r.gate.Observe(feature.SKUCatalog, err)
+		c.Status.SetConditions(xpv1.Unavailable().WithMessage(err.Error()))
+		return reconcile.Result{RequeueAfter: retryInterval}, errors.Wrap(r.client.Status().Update(ctx, c), errUpdateStatus)
+	}
+
+	now := metav1.Now()
+	c.Status.SKUs = skus
+	c.Status.LastSyncTime = &now
+	c.Status.SetConditions(xpv1.Available())
+	return reconcile.Result{RequeueAfter: syncInterval}, errors.Wrap(r.client.Status().Update(ctx, c), errUpdateStatus)
+}
+
+func (r *Reconciler) list(ctx context.Context, c *v1alpha3.AzureSKUCatalog) ([]v1alpha3.SKUOffering, error) {
+	pc := &v1beta1.ProviderConfig{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: c.Spec.ProviderConfigReference.Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+	creds, auth, err := azure.GetProviderConfigAuthInfo(ctx, r.client, pc)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+	l := r.newLister(creds, auth)
+
+	skus := make([]v1alpha3.SKUOffering, 0)
+	for _, loc := range c.Spec.Locations {
+		o, err := l.List(ctx, loc)
+		if err != nil {
+			return nil, err
+		}
+		skus = append(skus, o...)
+	}
+	return skus, nil
+}
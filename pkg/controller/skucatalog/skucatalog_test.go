@@ -0,0 +1,203 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package skucatalog
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/v1alpha3"
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+	"github.com/crossplane/provider-azure/pkg/clients/sku"
+	"github.com/crossplane/provider-azure/pkg/feature"
+)
+
+type mockLister struct {
+	MockList func(ctx context.Context, location string) ([]v1alpha3.SKUOffering, error)
+}
+
+func (m *mockLister) List(ctx context.Context, location string) ([]v1alpha3.SKUOffering, error) {
+	return m.MockList(ctx, location)
+}
+
+func TestReconcile(t *testing.T) {
+	errBoom := errors.New("boom")
+	errForbidden := autorest.DetailedError{StatusCode: http.StatusForbidden}
+	offering := v1alpha3.SKUOffering{Location: "westus2", ResourceType: "virtualMachines", Names: []string{"Standard_DS2_v2"}}
+
+	// get returns the catalog, and a ProviderConfig that authenticates as a
+	// managed identity.
+	get := func(pcErr error) test.MockGetFn {
+		return func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			switch o := obj.(type) {
+			case *v1alpha3.AzureSKUCatalog:
+				o.Spec = v1alpha3.AzureSKUCatalogSpec{ProviderConfigReference: xpv1.Reference{Name: "default"}, Locations: []string{"westus2"}}
+			case *v1beta1.ProviderConfig:
+				o.Spec.UseManagedIdentity = &v1beta1.ManagedIdentity{SubscriptionID: "sub"}
+				return pcErr
+			}
+			return nil
+		}
+	}
+	// status returns a MockStatusUpdateFn that expects the supplied status.
+	status := func(want v1alpha3.AzureSKUCatalogStatus, err error) test.MockStatusUpdateFn {
+		return test.NewMockStatusUpdateFn(err, func(obj client.Object) error {
+			got := obj.(*v1alpha3.AzureSKUCatalog).Status
+			if diff := cmp.Diff(want, got, test.EquateConditions(), cmpopts.IgnoreFields(v1alpha3.AzureSKUCatalogStatus{}, "LastSyncTime")); diff != "" {
+				t.Errorf("Status().Update(...): -want, +got:\n%s", diff)
+			}
+			return nil
+		})
+	}
+	unavailable := func(msg string) v1alpha3.AzureSKUCatalogStatus {
+		s := v1alpha3.AzureSKUCatalogStatus{}
+		s.SetConditions(xpv1.Unavailable().WithMessage(msg))
+		return s
+	}
+	available := v1alpha3.AzureSKUCatalogStatus{SKUs: []v1alpha3.SKUOffering{offering}}
+	available.SetConditions(xpv1.Available())
+
+	type fields struct {
+		client   client.Client
+		lister   sku.Lister
+		disabled bool
+	}
+	type want struct {
+		r       reconcile.Result
+		err     error
+		enabled bool
+	}
+	cases := map[string]struct {
+		reason string
+		fields fields
+		want   want
+	}{
+		"GetCatalogError": {
+			reason: "Errors getting the AzureSKUCatalog should be returned.",
+			fields: fields{
+				client: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			},
+			want: want{err: errors.Wrap(errBoom, errGetCatalog), enabled: true},
+		},
+		"CatalogNotFound": {
+			reason: "AzureSKUCatalogs that no longer exist should be ignored.",
+			fields: fields{
+				client: &test.MockClient{MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, ""))},
+			},
+			want: want{enabled: true},
+		},
+		"Disabled": {
+			reason: "An AzureSKUCatalog should be unavailable while the feature is disabled.",
+			fields: fields{
+				client: &test.MockClient{
+					MockGet:          get(nil),
+					MockStatusUpdate: status(unavailable(errDisabled), nil),
+				},
+				disabled: true,
+			},
+			want: want{r: reconcile.Result{RequeueAfter: retryInterval}},
+		},
+		"GetProviderConfigError": {
+			reason: "An AzureSKUCatalog whose ProviderConfig cannot be read should be unavailable.",
+			fields: fields{
+				client: &test.MockClient{
+					MockGet:          get(errBoom),
+					MockStatusUpdate: status(unavailable(errors.Wrap(errBoom, errGetPC).Error()), nil),
+				},
+			},
+			want: want{r: reconcile.Result{RequeueAfter: retryInterval}, enabled: true},
+		},
+		"ListForbidden": {
+			reason: "An AzureSKUCatalog whose SKUs Azure forbids listing should be unavailable, and the feature should back off.",
+			fields: fields{
+				client: &test.MockClient{
+					MockGet:          get(nil),
+					MockStatusUpdate: status(unavailable(errForbidden.Error()), nil),
+				},
+				lister: &mockLister{MockList: func(_ context.Context, _ string) ([]v1alpha3.SKUOffering, error) {
+					return nil, errForbidden
+				}},
+			},
+			want: want{r: reconcile.Result{RequeueAfter: retryInterval}},
+		},
+		"Success": {
+			reason: "The SKUs offered in the locations of an AzureSKUCatalog should be recorded in its status.",
+			fields: fields{
+				client: &test.MockClient{
+					MockGet:          get(nil),
+					MockStatusUpdate: status(available, nil),
+				},
+				lister: &mockLister{MockList: func(_ context.Context, _ string) ([]v1alpha3.SKUOffering, error) {
+					return []v1alpha3.SKUOffering{offering}, nil
+				}},
+			},
+			want: want{r: reconcile.Result{RequeueAfter: syncInterval}, enabled: true},
+		},
+		"UpdateStatusError": {
+			reason: "Errors updating the status of an AzureSKUCatalog should be returned.",
+			fields: fields{
+				client: &test.MockClient{
+					MockGet:          get(nil),
+					MockStatusUpdate: status(available, errBoom),
+				},
+				lister: &mockLister{MockList: func(_ context.Context, _ string) ([]v1alpha3.SKUOffering, error) {
+					return []v1alpha3.SKUOffering{offering}, nil
+				}},
+			},
+			want: want{r: reconcile.Result{RequeueAfter: syncInterval}, err: errors.Wrap(errBoom, errUpdateStatus), enabled: true},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			g := feature.NewGate()
+			if tc.fields.disabled {
+				g.Disable(feature.SKUCatalog)
+			}
+			r := &Reconciler{
+				client:    tc.fields.client,
+				newLister: func(_ map[string]string, _ autorest.Authorizer) sku.Lister { return tc.fields.lister },
+				gate:      g,
+				log:       logging.NewNopLogger(),
+			}
+			got, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "catalog"}})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.r, got); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.enabled, g.Enabled(feature.SKUCatalog)); diff != "" {
+				t.Errorf("\n%s\ng.Enabled(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
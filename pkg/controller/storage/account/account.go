@@ -26,12 +26,15 @@ import (
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
@@ -41,8 +44,20 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/crossplane/provider-azure/apis/storage/v1alpha3"
+	azurev1alpha3 "github.com/crossplane/provider-azure/apis/v1alpha3"
 	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/redact"
 	azurestorage "github.com/crossplane/provider-azure/pkg/clients/storage"
+	"github.com/crossplane/provider-azure/pkg/concurrency"
+	"github.com/crossplane/provider-azure/pkg/connection"
+	"github.com/crossplane/provider-azure/pkg/credentials"
+	"github.com/crossplane/provider-azure/pkg/defaults"
+	"github.com/crossplane/provider-azure/pkg/dependency"
+	"github.com/crossplane/provider-azure/pkg/externalname"
+	"github.com/crossplane/provider-azure/pkg/location"
+	"github.com/crossplane/provider-azure/pkg/pause"
+	"github.com/crossplane/provider-azure/pkg/resync"
+	"github.com/crossplane/provider-azure/pkg/tenancy"
 )
 
 const (
@@ -71,24 +86,28 @@ type Reconciler struct {
 }
 
 // Setup adds a controller that reconciles Accounts.
-func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, sel labels.Selector) error {
 	name := managed.ControllerName(v1alpha3.AccountGroupKind)
 
 	r := &Reconciler{
-		Client:           mgr.GetClient(),
-		syncdeleterMaker: &accountSyncdeleterMaker{mgr.GetClient()},
-		Initializer:      managed.NewNameAsExternalName(mgr.GetClient()),
-		log:              l.WithValues("controller", name),
+		Client:            mgr.GetClient(),
+		syncdeleterMaker:  &accountSyncdeleterMaker{mgr.GetClient()},
+		ReferenceResolver: dependency.NewReferenceResolver(mgr.GetClient()),
+		Initializer:       managed.InitializerChain{managed.NewNameAsExternalName(mgr.GetClient()), externalname.NewInitializer(mgr.GetClient()), tenancy.NewDefaultProviderInitializer(mgr.GetClient()), location.NewInitializer(mgr.GetClient()), defaults.NewInitializer(mgr.GetClient())},
+		log:               l.WithValues("controller", name),
 	}
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(controller.Options{
-			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: concurrency.DefaultLimits.Of(v1alpha3.AccountGroupKind),
 		}).
 		For(&v1alpha3.Account{}).
-		Owns(&corev1.Secret{}).
-		Complete(r)
+		Watches(&source.Kind{Type: &corev1.Secret{}}, credentials.EnqueueRequestsForRotation(mgr.GetClient(), &v1alpha3.AccountList{}, l.WithValues("controller", name)), builder.OnlyMetadata).
+		Watches(&source.Kind{Type: &azurev1alpha3.ResourceGroup{}}, dependency.EnqueueRequestsForDependents(mgr.GetClient(), &v1alpha3.AccountList{}, l.WithValues("controller", name)), builder.WithPredicates(dependency.BecameAvailable())).
+		Owns(&corev1.Secret{}, builder.OnlyMetadata).
+		Complete(resync.NewReconciler(resync.DefaultMonitor, name, pause.NewReconciler(mgr, resource.ManagedKind(v1alpha3.AccountGroupVersionKind), r, pause.WithSelector(sel))))
 }
 
 // Reconcile reads that state of the cluster for a Provider acct and makes changes based on the state read
@@ -110,9 +129,18 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 		return reconcile.Result{}, err
 	}
 
+	// The resource group of an Account that is being deleted must not be
+	// resolved again, since the referenced ResourceGroup may be gone.
+	if b.DeletionTimestamp == nil {
+		if err := r.ResolveReferences(ctx, b); err != nil {
+			b.Status.SetConditions(xpv1.ReconcileError(err))
+			return requeueOnWait, r.Status().Update(ctx, b)
+		}
+	}
+
 	bh, err := r.newSyncdeleter(ctx, b)
 	if err != nil {
-		b.Status.SetConditions(xpv1.ReconcileError(err))
+		b.Status.SetConditions(xpv1.ReconcileError(redact.Error(err)))
 		return resultRequeue, r.Status().Update(ctx, b)
 	}
 
@@ -138,7 +166,7 @@ func (m *accountSyncdeleterMaker) newSyncdeleter(ctx context.Context, b *v1alpha
 		return nil, errors.Wrap(err, "cannot get auth information")
 	}
 
-	cl := storage.NewAccountsClient(creds[azure.CredentialsKeySubscriptionID])
+	cl := storage.NewAccountsClientWithBaseURI(azure.BaseURI(creds), creds[azure.CredentialsKeySubscriptionID])
 	cl.Authorizer = auth
 
 	return newAccountSyncDeleter(
@@ -196,7 +224,7 @@ func (asd *accountSyncDeleter) delete(ctx context.Context) (reconcile.Result, er
 	switch asd.acct.Spec.DeletionPolicy {
 	case xpv1.DeletionDelete, "":
 		if err := asd.Delete(ctx); err != nil && !azure.IsNotFound(err) {
-			asd.acct.Status.SetConditions(xpv1.ReconcileError(err))
+			asd.acct.Status.SetConditions(xpv1.ReconcileError(redact.Error(err)))
 			return resultRequeue, asd.kube.Status().Update(ctx, asd.acct)
 		}
 	case xpv1.DeletionOrphan:
@@ -215,7 +243,7 @@ func (asd *accountSyncDeleter) delete(ctx context.Context) (reconcile.Result, er
 func (asd *accountSyncDeleter) sync(ctx context.Context) (reconcile.Result, error) {
 	account, err := asd.Get(ctx)
 	if err != nil && !azure.IsNotFound(err) {
-		asd.acct.Status.SetConditions(xpv1.ReconcileError(err))
+		asd.acct.Status.SetConditions(xpv1.ReconcileError(redact.Error(err)))
 		return resultRequeue, asd.kube.Status().Update(ctx, asd.acct)
 	}
 
@@ -260,7 +288,7 @@ func (acu *accountCreateUpdater) create(ctx context.Context) (reconcile.Result,
 
 	a, err := acu.Create(ctx, accountSpec)
 	if err != nil {
-		acu.acct.Status.SetConditions(xpv1.ReconcileError(err))
+		acu.acct.Status.SetConditions(xpv1.ReconcileError(redact.Error(err)))
 		return resultRequeue, acu.kube.Status().Update(ctx, acu.acct)
 	}
 
@@ -272,7 +300,21 @@ func (acu *accountCreateUpdater) update(ctx context.Context, account *storage.Ac
 	if account.ProvisioningState == storage.Succeeded {
 		acu.acct.Status.SetConditions(xpv1.Available())
 
-		current := v1alpha3.NewStorageAccountSpec(account)
+		// The storage API version accounts are otherwise managed with has no
+		// minimum TLS version, so it is read and updated on its own.
+		tls := minimumTLSVersion(acu.acct.Spec.StorageAccountSpec)
+		if tls != "" {
+			observed, err := acu.GetMinimumTLSVersion(ctx)
+			if err == nil && observed != tls {
+				err = acu.UpdateMinimumTLSVersion(ctx, tls)
+			}
+			if err != nil {
+				acu.acct.Status.SetConditions(xpv1.ReconcileError(redact.Error(err)))
+				return resultRequeue, acu.kube.Status().Update(ctx, acu.acct)
+			}
+		}
+
+		current := withMinimumTLSVersion(v1alpha3.NewStorageAccountSpec(account), tls)
 		if reflect.DeepEqual(current, acu.acct.Spec.StorageAccountSpec) {
 			acu.acct.Status.SetConditions(xpv1.ReconcileSuccess())
 			return requeueOnSuccess, acu.kube.Status().Update(ctx, acu.acct)
@@ -280,7 +322,7 @@ func (acu *accountCreateUpdater) update(ctx context.Context, account *storage.Ac
 
 		a, err := acu.Update(ctx, v1alpha3.ToStorageAccountUpdate(acu.acct.Spec.StorageAccountSpec))
 		if err != nil {
-			acu.acct.Status.SetConditions(xpv1.ReconcileError(err))
+			acu.acct.Status.SetConditions(xpv1.ReconcileError(redact.Error(err)))
 			return resultRequeue, acu.kube.Status().Update(ctx, acu.acct)
 		}
 		account = a
@@ -304,7 +346,8 @@ func newAccountSyncBacker(ao azurestorage.AccountOperations, kube client.Client,
 }
 
 func (asb *accountSyncbacker) syncback(ctx context.Context, acct *storage.Account) (reconcile.Result, error) {
-	asb.acct.Spec.StorageAccountSpec = v1alpha3.NewStorageAccountSpec(acct)
+	tls := minimumTLSVersion(asb.acct.Spec.StorageAccountSpec)
+	asb.acct.Spec.StorageAccountSpec = withMinimumTLSVersion(v1alpha3.NewStorageAccountSpec(acct), tls)
 	if err := asb.kube.Update(ctx, asb.acct); err != nil {
 		return resultRequeue, err
 	}
@@ -317,7 +360,7 @@ func (asb *accountSyncbacker) syncback(ctx context.Context, acct *storage.Accoun
 	}
 
 	if err := asb.updatesecret(ctx, acct); err != nil {
-		asb.acct.Status.SetConditions(xpv1.ReconcileError(err))
+		asb.acct.Status.SetConditions(xpv1.ReconcileError(redact.Error(err)))
 		return resultRequeue, asb.kube.Status().Update(ctx, asb.acct)
 	}
 
@@ -325,6 +368,28 @@ func (asb *accountSyncbacker) syncback(ctx context.Context, acct *storage.Accoun
 	return requeueOnSuccess, asb.kube.Status().Update(ctx, asb.acct)
 }
 
+// minimumTLSVersion returns the minimum TLS version of the supplied spec, if
+// any.
+func minimumTLSVersion(s *v1alpha3.StorageAccountSpec) string {
+	if s == nil || s.StorageAccountSpecProperties == nil {
+		return ""
+	}
+	return s.MinimumTLSVersion
+}
+
+// withMinimumTLSVersion sets the minimum TLS version of the supplied spec,
+// which is not part of the storage accounts specs are read from.
+func withMinimumTLSVersion(s *v1alpha3.StorageAccountSpec, v string) *v1alpha3.StorageAccountSpec {
+	if s == nil || v == "" {
+		return s
+	}
+	if s.StorageAccountSpecProperties == nil {
+		s.StorageAccountSpecProperties = &v1alpha3.StorageAccountSpecProperties{}
+	}
+	s.MinimumTLSVersion = v
+	return s
+}
+
 type accountSecretUpdater struct {
 	azurestorage.AccountOperations
 	acct *v1alpha3.Account
@@ -342,6 +407,9 @@ func newAccountSecretUpdater(ao azurestorage.AccountOperations, kube client.Clie
 func (asu *accountSecretUpdater) updatesecret(ctx context.Context, acct *storage.Account) error {
 	secret := resource.ConnectionSecretFor(asu.acct, v1alpha3.AccountGroupVersionKind)
 	key := types.NamespacedName{Namespace: secret.Namespace, Name: secret.Name}
+	if err := connection.DefaultNamespacePolicy.Check(secret.Namespace); err != nil {
+		return err
+	}
 
 	if acct.PrimaryEndpoints != nil {
 		secret.Data[xpv1.ResourceCredentialsSecretEndpointKey] = []byte(to.String(acct.PrimaryEndpoints.Blob))
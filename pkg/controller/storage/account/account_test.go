@@ -41,6 +41,7 @@ import (
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 
 	"github.com/crossplane/provider-azure/apis/storage/v1alpha3"
@@ -194,8 +195,9 @@ func TestReconciler_Reconcile(t *testing.T) {
 	errBoom := errors.New("boom")
 
 	type fields struct {
-		client client.Client
-		maker  syncdeleterMaker
+		client   client.Client
+		maker    syncdeleterMaker
+		resolver managed.ReferenceResolver
 	}
 	type want struct {
 		res  reconcile.Result
@@ -223,6 +225,23 @@ func TestReconciler_Reconcile(t *testing.T) {
 			},
 			want: want{res: rsDone, err: errBoom},
 		},
+		{
+			name: "ResolveReferencesError",
+			fields: fields{
+				client: fake.NewClientBuilder().WithObjects(v1alpha3test.NewMockAccount(name).WithFinalizer("foo.bar").Account).Build(),
+				resolver: managed.ReferenceResolverFn(func(context.Context, resource.Managed) error {
+					return errBoom
+				}),
+			},
+			want: want{
+				res: requeueOnWait,
+				acct: v1alpha3test.NewMockAccount(name).
+					WithStatusConditions(
+						xpv1.ReconcileError(errBoom),
+					).
+					WithFinalizer("foo.bar").Account,
+			},
+		},
 		{
 			name: "AccountHandlerError",
 			fields: fields{
@@ -259,11 +278,16 @@ func TestReconciler_Reconcile(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			resolver := tt.fields.resolver
+			if resolver == nil {
+				resolver = managed.ReferenceResolverFn(func(context.Context, resource.Managed) error { return nil })
+			}
 			r := &Reconciler{
-				Client:           tt.fields.client,
-				syncdeleterMaker: tt.fields.maker,
-				Initializer:      managed.NewNameAsExternalName(tt.fields.client),
-				log:              logging.NewNopLogger(),
+				Client:            tt.fields.client,
+				syncdeleterMaker:  tt.fields.maker,
+				ReferenceResolver: resolver,
+				Initializer:       managed.NewNameAsExternalName(tt.fields.client),
+				log:               logging.NewNopLogger(),
 			}
 			got, err := r.Reconcile(context.Background(), req)
 			if diff := cmp.Diff(tt.want.err, err, test.EquateErrors()); diff != "" {
@@ -680,6 +704,62 @@ func Test_bucketCreateUpdater_update(t *testing.T) {
 					Account,
 			},
 		},
+		{
+			name: "MinimumTLSVersionChanged",
+			attrs: &storage.Account{
+				AccountProperties: &storage.AccountProperties{ProvisioningState: storage.Succeeded},
+			},
+			fields: fields{
+				acct: v1alpha3test.NewMockAccount(name).
+					WithSpecStorageAccountSpec(withMinimumTLSVersion(newStoragAccountSpecWithProperties(), "TLS1_2")).
+					Account,
+				ao: &azurestoragefake.MockAccountOperations{
+					MockGetMinimumTLSVersion: func(ctx context.Context) (string, error) {
+						return "TLS1_0", nil
+					},
+					MockUpdateMinimumTLSVersion: func(ctx context.Context, v string) error {
+						if v != "TLS1_2" {
+							t.Errorf("UpdateMinimumTLSVersion(...): want TLS1_2, got %s", v)
+						}
+						return nil
+					},
+				},
+				kube: test.NewMockClient(),
+			},
+			want: want{
+				res: requeueOnSuccess,
+				acct: v1alpha3test.NewMockAccount(name).
+					WithSpecStorageAccountSpec(withMinimumTLSVersion(newStoragAccountSpecWithProperties(), "TLS1_2")).
+					WithStatusConditions(xpv1.Available(), xpv1.ReconcileSuccess()).
+					Account,
+			},
+		},
+		{
+			name: "GetMinimumTLSVersionFailed",
+			attrs: &storage.Account{
+				AccountProperties: &storage.AccountProperties{ProvisioningState: storage.Succeeded},
+			},
+			fields: fields{
+				acct: v1alpha3test.NewMockAccount(name).
+					WithSpecStorageAccountSpec(withMinimumTLSVersion(newStoragAccountSpecWithProperties(), "TLS1_2")).
+					Account,
+				ao: &azurestoragefake.MockAccountOperations{
+					MockGetMinimumTLSVersion: func(ctx context.Context) (string, error) {
+						return "", errBoom
+					},
+				},
+				kube: &test.MockClient{
+					MockStatusUpdate: func(ctx context.Context, obj client.Object, _ ...client.UpdateOption) error { return nil },
+				},
+			},
+			want: want{
+				res: resultRequeue,
+				acct: v1alpha3test.NewMockAccount(name).
+					WithSpecStorageAccountSpec(withMinimumTLSVersion(newStoragAccountSpecWithProperties(), "TLS1_2")).
+					WithStatusConditions(xpv1.Available(), xpv1.ReconcileError(errBoom)).
+					Account,
+			},
+		},
 		{
 			name: "UpdateFailed",
 			attrs: &storage.Account{
@@ -815,6 +895,24 @@ func Test_accountSyncBacker_syncback(t *testing.T) {
 					Account,
 			},
 		},
+		{
+			name: "MinimumTLSVersionKept",
+			fields: fields{
+				acct: v1alpha3test.NewMockAccount(name).
+					WithSpecStorageAccountSpec(withMinimumTLSVersion(newStoragAccountSpecWithProperties(), "TLS1_2")).
+					Account,
+				kube: test.NewMockClient(),
+			},
+			acct: &storage.Account{AccountProperties: &storage.AccountProperties{ProvisioningState: storage.Creating}},
+			want: want{
+				res: requeueOnWait,
+				acct: v1alpha3test.NewMockAccount(name).
+					WithSpecStatusFromProperties(&storage.AccountProperties{ProvisioningState: storage.Creating}).
+					WithSpecStorageAccountSpec(withMinimumTLSVersion(newStoragAccountSpecWithProperties(), "TLS1_2")).
+					WithStatusConditions(xpv1.ReconcileSuccess()).
+					Account,
+			},
+		},
 		{
 			name: "UpdateSecretFailed",
 			fields: fields{
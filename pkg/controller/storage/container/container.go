@@ -26,12 +26,15 @@ import (
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
@@ -41,9 +44,15 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/concurrency"
+	"github.com/crossplane/provider-azure/pkg/credentials"
 
 	"github.com/crossplane/provider-azure/apis/storage/v1alpha3"
+	"github.com/crossplane/provider-azure/pkg/clients/redact"
 	"github.com/crossplane/provider-azure/pkg/clients/storage"
+	"github.com/crossplane/provider-azure/pkg/pause"
+	"github.com/crossplane/provider-azure/pkg/resync"
+	"github.com/crossplane/provider-azure/pkg/tenancy"
 )
 
 const (
@@ -75,23 +84,25 @@ type Reconciler struct {
 }
 
 // Setup adds a controller that reconciles Containers.
-func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, sel labels.Selector) error {
 	name := managed.ControllerName(v1alpha3.ContainerGroupKind)
 
 	r := &Reconciler{
 		Client:           mgr.GetClient(),
 		syncdeleterMaker: &containerSyncdeleterMaker{mgr.GetClient()},
-		Initializer:      managed.NewNameAsExternalName(mgr.GetClient()),
+		Initializer:      managed.InitializerChain{managed.NewNameAsExternalName(mgr.GetClient()), tenancy.NewDefaultProviderInitializer(mgr.GetClient())},
 		log:              l.WithValues("controller", name),
 	}
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(controller.Options{
-			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: concurrency.DefaultLimits.Of(v1alpha3.ContainerGroupKind),
 		}).
 		For(&v1alpha3.Container{}).
-		Complete(r)
+		Watches(&source.Kind{Type: &corev1.Secret{}}, credentials.EnqueueRequestsForRotation(mgr.GetClient(), &v1alpha3.ContainerList{}, l.WithValues("controller", name)), builder.OnlyMetadata).
+		Complete(resync.NewReconciler(resync.DefaultMonitor, name, pause.NewReconciler(mgr, resource.ManagedKind(v1alpha3.ContainerGroupVersionKind), r, pause.WithSelector(sel))))
 }
 
 // Reconcile reads that state of the cluster for a Provider acct and makes changes based on the state read
@@ -112,7 +123,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 
 	sd, err := r.newSyncdeleter(ctx, c)
 	if err != nil {
-		c.Status.SetConditions(xpv1.ReconcileError(err))
+		c.Status.SetConditions(xpv1.ReconcileError(redact.Error(err)))
 		return resultRequeue, r.Status().Update(ctx, c)
 	}
 
@@ -228,11 +239,14 @@ type containerSyncdeleter struct {
 
 func (csd *containerSyncdeleter) delete(ctx context.Context) (reconcile.Result, error) {
 	csd.container.Status.SetConditions(xpv1.Deleting())
-	if csd.container.Spec.DeletionPolicy == xpv1.DeletionDelete {
+	switch csd.container.Spec.DeletionPolicy {
+	case xpv1.DeletionDelete, "":
 		if err := csd.Delete(ctx); err != nil && !azure.IsNotFound(err) {
-			csd.container.Status.SetConditions(xpv1.ReconcileError(err))
+			csd.container.Status.SetConditions(xpv1.ReconcileError(redact.Error(err)))
 			return resultRequeue, csd.kube.Status().Update(ctx, csd.container)
 		}
+	case xpv1.DeletionOrphan:
+		// No need to do anything if we plan to orphan this container.
 	}
 
 	// NOTE(negz): We don't update the conditioned status here because assuming
@@ -245,7 +259,7 @@ func (csd *containerSyncdeleter) delete(ctx context.Context) (reconcile.Result,
 func (csd *containerSyncdeleter) sync(ctx context.Context) (reconcile.Result, error) {
 	access, meta, err := csd.Get(ctx)
 	if err != nil && !storage.IsNotFoundError(err) {
-		csd.container.Status.SetConditions(xpv1.ReconcileError(err))
+		csd.container.Status.SetConditions(xpv1.ReconcileError(redact.Error(err)))
 		return resultRequeue, csd.kube.Status().Update(ctx, csd.container)
 	}
 
@@ -281,7 +295,7 @@ func (ccu *containerCreateUpdater) create(ctx context.Context) (reconcile.Result
 
 	spec := container.Spec
 	if err := ccu.Create(ctx, spec.PublicAccessType, spec.Metadata); err != nil {
-		container.Status.SetConditions(xpv1.ReconcileError(err))
+		container.Status.SetConditions(xpv1.ReconcileError(redact.Error(err)))
 		return resultRequeue, ccu.kube.Status().Update(ctx, container)
 	}
 
@@ -295,7 +309,7 @@ func (ccu *containerCreateUpdater) update(ctx context.Context, accessType *azblo
 
 	if !reflect.DeepEqual(*accessType, spec.PublicAccessType) || !reflect.DeepEqual(meta, spec.Metadata) {
 		if err := ccu.Update(ctx, spec.PublicAccessType, spec.Metadata); err != nil {
-			container.Status.SetConditions(xpv1.ReconcileError(err))
+			container.Status.SetConditions(xpv1.ReconcileError(redact.Error(err)))
 			return resultRequeue, ccu.kube.Status().Update(ctx, container)
 		}
 	}
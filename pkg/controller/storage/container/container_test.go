@@ -518,6 +518,25 @@ func Test_containerSyncdeleter_delete(t *testing.T) {
 					Container,
 			},
 		},
+		{
+			name: "DeletionPolicyUnset",
+			fields: fields{
+				kube: test.NewMockClient(),
+				ContainerOperations: &azurestoragefake.MockContainerOperations{
+					MockDelete: func(ctx context.Context) error { return nil },
+				},
+				container: v1alpha3test.NewMockContainer(testContainerName).
+					WithFinalizer(finalizer).Container,
+			},
+			args: args{ctx: ctx},
+			want: want{
+				res: reconcile.Result{},
+				cont: v1alpha3test.NewMockContainer(testContainerName).
+					WithFinalizers([]string{}).
+					WithStatusConditions(xpv1.Deleting()).
+					Container,
+			},
+		},
 		{
 			name: "DeleteErrorNotFound",
 			fields: fields{
@@ -0,0 +1,179 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudendpoint
+
+import (
+	"context"
+
+	azurestoragesync "github.com/Azure/azure-sdk-for-go/services/storagesync/mgmt/2019-10-01/storagesync"
+	"github.com/Azure/azure-sdk-for-go/services/storagesync/mgmt/2019-10-01/storagesync/storagesyncapi"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	storagev1alpha3 "github.com/crossplane/provider-azure/apis/storage/v1alpha3"
+	"github.com/crossplane/provider-azure/apis/storagesync/v1alpha1"
+	azurev1alpha3 "github.com/crossplane/provider-azure/apis/v1alpha3"
+	"github.com/crossplane/provider-azure/pkg/chain"
+	azureclients "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/storagesync"
+	"github.com/crossplane/provider-azure/pkg/concurrency"
+	"github.com/crossplane/provider-azure/pkg/credentials"
+	"github.com/crossplane/provider-azure/pkg/defaults"
+	"github.com/crossplane/provider-azure/pkg/dependency"
+	"github.com/crossplane/provider-azure/pkg/externalname"
+	"github.com/crossplane/provider-azure/pkg/tenancy"
+)
+
+// Error strings.
+const (
+	errNotCloudEndpoint    = "managed resource is not a CloudEndpoint"
+	errCreateCloudEndpoint = "cannot create cloud endpoint"
+	errGetCloudEndpoint    = "cannot get cloud endpoint"
+	errDeleteCloudEndpoint = "cannot delete cloud endpoint"
+)
+
+// Setup adds a controller that reconciles CloudEndpoints.
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, sel labels.Selector) error {
+	name := managed.ControllerName(v1alpha1.CloudEndpointGroupKind)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: concurrency.DefaultLimits.Of(v1alpha1.CloudEndpointGroupKind),
+		}).
+		For(&v1alpha1.CloudEndpoint{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, credentials.EnqueueRequestsForRotation(mgr.GetClient(), &v1alpha1.CloudEndpointList{}, l.WithValues("controller", name)), builder.OnlyMetadata).
+		Watches(&source.Kind{Type: &azurev1alpha3.ResourceGroup{}}, dependency.EnqueueRequestsForDependents(mgr.GetClient(), &v1alpha1.CloudEndpointList{}, l.WithValues("controller", name)), builder.WithPredicates(dependency.BecameAvailable())).
+		Watches(&source.Kind{Type: &v1alpha1.StorageSyncService{}}, dependency.EnqueueRequestsForDependents(mgr.GetClient(), &v1alpha1.CloudEndpointList{}, l.WithValues("controller", name)), builder.WithPredicates(dependency.BecameAvailable())).
+		Watches(&source.Kind{Type: &v1alpha1.SyncGroup{}}, dependency.EnqueueRequestsForDependents(mgr.GetClient(), &v1alpha1.CloudEndpointList{}, l.WithValues("controller", name)), builder.WithPredicates(dependency.BecameAvailable())).
+		Watches(&source.Kind{Type: &storagev1alpha3.Account{}}, dependency.EnqueueRequestsForDependents(mgr.GetClient(), &v1alpha1.CloudEndpointList{}, l.WithValues("controller", name)), builder.WithPredicates(dependency.BecameAvailable())).
+		Complete(chain.NewReconciler(mgr, resource.ManagedKind(v1alpha1.CloudEndpointGroupVersionKind),
+			managed.NewReconciler(mgr,
+				resource.ManagedKind(v1alpha1.CloudEndpointGroupVersionKind),
+				managed.WithConnectionPublishers(),
+				managed.WithExternalConnecter(chain.NewConnecter(mgr, v1alpha1.CloudEndpointGroupKind, &connecter{client: mgr.GetClient()})),
+				managed.WithInitializers(managed.NewNameAsExternalName(mgr.GetClient()), externalname.NewInitializer(mgr.GetClient()), tenancy.NewDefaultProviderInitializer(mgr.GetClient()), defaults.NewInitializer(mgr.GetClient())),
+				managed.WithReferenceResolver(dependency.NewReferenceResolver(mgr.GetClient())),
+				managed.WithLogger(l.WithValues("controller", name)),
+				managed.WithRecorder(recorder)),
+			sel))
+}
+
+type connecter struct {
+	client client.Client
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	ep, ok := mg.(*v1alpha1.CloudEndpoint)
+	if !ok {
+		return nil, errors.New(errNotCloudEndpoint)
+	}
+	creds, auth, err := azureclients.GetAuthInfo(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	cl := azurestoragesync.NewCloudEndpointsClientWithBaseURI(azureclients.BaseURI(creds), azureclients.SubscriptionID(creds, ep.Spec.ForProvider.SubscriptionID))
+	cl.Authorizer = auth
+	return &external{client: cl, tenantID: creds[azureclients.CredentialsKeyTenantID]}, nil
+}
+
+type external struct {
+	client   storagesyncapi.CloudEndpointsClientAPI
+	tenantID string
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	ep, ok := mg.(*v1alpha1.CloudEndpoint)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotCloudEndpoint)
+	}
+
+	p := ep.Spec.ForProvider
+	az, err := e.client.Get(ctx, p.ResourceGroupName, p.StorageSyncServiceName, p.SyncGroupName, meta.GetExternalName(ep))
+	if azureclients.IsNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetCloudEndpoint)
+	}
+
+	current := ep.Spec.ForProvider.DeepCopy()
+	storagesync.LateInitializeCloudEndpoint(&ep.Spec.ForProvider, az)
+
+	ep.Status.AtProvider = storagesync.GenerateCloudEndpointObservation(az)
+	switch ep.Status.AtProvider.ProvisioningState {
+	case storagesync.ProvisioningStateSucceeded:
+		ep.SetConditions(xpv1.Available())
+	case storagesync.ProvisioningStateFailed:
+		ep.SetConditions(xpv1.Unavailable())
+	default:
+		ep.SetConditions(xpv1.Creating())
+	}
+
+	// Cloud endpoints cannot be updated.
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        true,
+		ResourceLateInitialized: !cmp.Equal(current, &ep.Spec.ForProvider),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	ep, ok := mg.(*v1alpha1.CloudEndpoint)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotCloudEndpoint)
+	}
+
+	ep.SetConditions(xpv1.Creating())
+	p := ep.Spec.ForProvider
+	_, err := e.client.Create(ctx, p.ResourceGroupName, p.StorageSyncServiceName, p.SyncGroupName, meta.GetExternalName(ep), storagesync.NewCloudEndpointCreateParameters(p, e.tenantID))
+	return managed.ExternalCreation{}, errors.Wrap(err, errCreateCloudEndpoint)
+}
+
+func (e *external) Update(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	ep, ok := mg.(*v1alpha1.CloudEndpoint)
+	if !ok {
+		return errors.New(errNotCloudEndpoint)
+	}
+
+	ep.SetConditions(xpv1.Deleting())
+	p := ep.Spec.ForProvider
+	_, err := e.client.Delete(ctx, p.ResourceGroupName, p.StorageSyncServiceName, p.SyncGroupName, meta.GetExternalName(ep))
+	return errors.Wrap(resource.Ignore(azureclients.IsNotFound, err), errDeleteCloudEndpoint)
+}
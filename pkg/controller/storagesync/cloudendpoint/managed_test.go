@@ -0,0 +1,227 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudendpoint
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/storagesync/mgmt/2019-10-01/storagesync"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/storagesync/v1alpha1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/storagesync/fake"
+)
+
+const (
+	name      = "coolEndpoint"
+	tenantID  = "coolTenant"
+	shareName = "coolShare"
+)
+
+var errBoom = errors.New("boom")
+
+type endpointModifier func(*v1alpha1.CloudEndpoint)
+
+func withConditions(c ...xpv1.Condition) endpointModifier {
+	return func(r *v1alpha1.CloudEndpoint) { r.Status.ConditionedStatus.Conditions = c }
+}
+
+func withTenant(t string) endpointModifier {
+	return func(r *v1alpha1.CloudEndpoint) { r.Spec.ForProvider.StorageAccountTenantID = &t }
+}
+
+func withState(s string) endpointModifier {
+	return func(r *v1alpha1.CloudEndpoint) { r.Status.AtProvider.ProvisioningState = s }
+}
+
+func endpoint(m ...endpointModifier) *v1alpha1.CloudEndpoint {
+	r := &v1alpha1.CloudEndpoint{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1alpha1.CloudEndpointSpec{
+			ForProvider: v1alpha1.CloudEndpointParameters{
+				ResourceGroupName:      "coolRG",
+				StorageSyncServiceName: "coolService",
+				SyncGroupName:          "coolGroup",
+				AzureFileShareName:     shareName,
+			},
+		},
+	}
+	meta.SetExternalName(r, name)
+	for _, fn := range m {
+		fn(r)
+	}
+	return r
+}
+
+func observed(state string) storagesync.CloudEndpoint {
+	return storagesync.CloudEndpoint{CloudEndpointProperties: &storagesync.CloudEndpointProperties{
+		ProvisioningState:      azure.ToStringPtr(state),
+		StorageAccountTenantID: azure.ToStringPtr(tenantID),
+	}}
+}
+
+var _ managed.ExternalClient = &external{}
+var _ managed.ExternalConnecter = &connecter{}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   want
+	}{
+		"NotCloudEndpoint": {
+			reason: "An error should be returned if the managed resource is not a CloudEndpoint",
+			e:      &external{client: &fake.MockCloudEndpointsClient{}},
+			mg:     &v1alpha1.SyncGroup{},
+			want:   want{mg: &v1alpha1.SyncGroup{}, err: errors.New(errNotCloudEndpoint)},
+		},
+		"NotFound": {
+			reason: "A cloud endpoint that is not found should not exist",
+			e: &external{client: &fake.MockCloudEndpointsClient{
+				MockGet: func(_ context.Context, _, _, _, _ string) (storagesync.CloudEndpoint, error) {
+					return storagesync.CloudEndpoint{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			}},
+			mg:   endpoint(),
+			want: want{mg: endpoint()},
+		},
+		"GetFailed": {
+			reason: "Errors getting the cloud endpoint should be returned",
+			e: &external{client: &fake.MockCloudEndpointsClient{
+				MockGet: func(_ context.Context, _, _, _, _ string) (storagesync.CloudEndpoint, error) {
+					return storagesync.CloudEndpoint{}, errBoom
+				},
+			}},
+			mg:   endpoint(),
+			want: want{mg: endpoint(), err: errors.Wrap(errBoom, errGetCloudEndpoint)},
+		},
+		"Provisioning": {
+			reason: "A cloud endpoint that is still being provisioned should be creating, and late initialized",
+			e: &external{client: &fake.MockCloudEndpointsClient{
+				MockGet: func(_ context.Context, _, _, _, _ string) (storagesync.CloudEndpoint, error) {
+					return observed("NewReplicaGroup"), nil
+				},
+			}},
+			mg: endpoint(),
+			want: want{
+				mg: endpoint(withTenant(tenantID), withState("NewReplicaGroup"), withConditions(xpv1.Creating())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true},
+			},
+		},
+		"Succeeded": {
+			reason: "A cloud endpoint that was provisioned should be available",
+			e: &external{client: &fake.MockCloudEndpointsClient{
+				MockGet: func(_ context.Context, _, _, _, _ string) (storagesync.CloudEndpoint, error) {
+					return observed("Succeeded"), nil
+				},
+			}},
+			mg: endpoint(withTenant(tenantID)),
+			want: want{
+				mg: endpoint(withTenant(tenantID), withState("Succeeded"), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"Failed": {
+			reason: "A cloud endpoint that failed to be provisioned should be unavailable",
+			e: &external{client: &fake.MockCloudEndpointsClient{
+				MockGet: func(_ context.Context, _, _, _, _ string) (storagesync.CloudEndpoint, error) {
+					return observed("Failed"), nil
+				},
+			}},
+			mg: endpoint(withTenant(tenantID)),
+			want: want{
+				mg: endpoint(withTenant(tenantID), withState("Failed"), withConditions(xpv1.Unavailable())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o, err := tc.e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want managed, +got managed:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   error
+	}{
+		"Successful": {
+			reason: "The cloud endpoint should be created in the tenant of the credentials by default",
+			e: &external{tenantID: tenantID, client: &fake.MockCloudEndpointsClient{
+				MockCreate: func(_ context.Context, _, _, _, _ string, p storagesync.CloudEndpointCreateParameters) (storagesync.CloudEndpointsCreateFuture, error) {
+					if diff := cmp.Diff(tenantID, azure.ToString(p.StorageAccountTenantID)); diff != "" {
+						t.Errorf("Create(...): -want tenant, +got tenant:\n%s", diff)
+					}
+					return storagesync.CloudEndpointsCreateFuture{}, nil
+				},
+			}},
+			mg: endpoint(),
+		},
+		"Failed": {
+			reason: "Errors creating the cloud endpoint should be returned",
+			e: &external{client: &fake.MockCloudEndpointsClient{
+				MockCreate: func(_ context.Context, _, _, _, _ string, _ storagesync.CloudEndpointCreateParameters) (storagesync.CloudEndpointsCreateFuture, error) {
+					return storagesync.CloudEndpointsCreateFuture{}, errBoom
+				},
+			}},
+			mg:   endpoint(),
+			want: errors.Wrap(errBoom, errCreateCloudEndpoint),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storagesyncservice
+
+import (
+	"context"
+
+	azurestoragesync "github.com/Azure/azure-sdk-for-go/services/storagesync/mgmt/2019-10-01/storagesync"
+	"github.com/Azure/azure-sdk-for-go/services/storagesync/mgmt/2019-10-01/storagesync/storagesyncapi"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-azure/apis/storagesync/v1alpha1"
+	azurev1alpha3 "github.com/crossplane/provider-azure/apis/v1alpha3"
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+	"github.com/crossplane/provider-azure/pkg/chain"
+	azureclients "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/storagesync"
+	"github.com/crossplane/provider-azure/pkg/concurrency"
+	"github.com/crossplane/provider-azure/pkg/credentials"
+	"github.com/crossplane/provider-azure/pkg/defaults"
+	"github.com/crossplane/provider-azure/pkg/dependency"
+	"github.com/crossplane/provider-azure/pkg/externalname"
+	"github.com/crossplane/provider-azure/pkg/location"
+	"github.com/crossplane/provider-azure/pkg/tenancy"
+)
+
+// Error strings.
+const (
+	errNotStorageSyncService    = "managed resource is not a StorageSyncService"
+	errCreateStorageSyncService = "cannot create Storage Sync Service"
+	errUpdateStorageSyncService = "cannot update Storage Sync Service"
+	errGetStorageSyncService    = "cannot get Storage Sync Service"
+	errDeleteStorageSyncService = "cannot delete Storage Sync Service"
+)
+
+// Setup adds a controller that reconciles StorageSyncServices.
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, sel labels.Selector) error {
+	name := managed.ControllerName(v1alpha1.StorageSyncServiceGroupKind)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: concurrency.DefaultLimits.Of(v1alpha1.StorageSyncServiceGroupKind),
+		}).
+		For(&v1alpha1.StorageSyncService{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, credentials.EnqueueRequestsForRotation(mgr.GetClient(), &v1alpha1.StorageSyncServiceList{}, l.WithValues("controller", name)), builder.OnlyMetadata).
+		Watches(&source.Kind{Type: &azurev1alpha3.ResourceGroup{}}, dependency.EnqueueRequestsForDependents(mgr.GetClient(), &v1alpha1.StorageSyncServiceList{}, l.WithValues("controller", name)), builder.WithPredicates(dependency.BecameAvailable())).
+		Complete(chain.NewReconciler(mgr, resource.ManagedKind(v1alpha1.StorageSyncServiceGroupVersionKind),
+			managed.NewReconciler(mgr,
+				resource.ManagedKind(v1alpha1.StorageSyncServiceGroupVersionKind),
+				managed.WithConnectionPublishers(),
+				managed.WithExternalConnecter(chain.NewConnecter(mgr, v1alpha1.StorageSyncServiceGroupKind, &connecter{client: mgr.GetClient()})),
+				managed.WithInitializers(managed.NewNameAsExternalName(mgr.GetClient()), externalname.NewInitializer(mgr.GetClient()), tenancy.NewDefaultProviderInitializer(mgr.GetClient()), location.NewInitializer(mgr.GetClient()), defaults.NewInitializer(mgr.GetClient())),
+				managed.WithReferenceResolver(dependency.NewReferenceResolver(mgr.GetClient())),
+				managed.WithLogger(l.WithValues("controller", name)),
+				managed.WithRecorder(recorder)),
+			sel))
+}
+
+type connecter struct {
+	client client.Client
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	s, ok := mg.(*v1alpha1.StorageSyncService)
+	if !ok {
+		return nil, errors.New(errNotStorageSyncService)
+	}
+	creds, auth, err := azureclients.GetAuthInfo(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	cl := azurestoragesync.NewServicesClientWithBaseURI(azureclients.BaseURI(creds), azureclients.SubscriptionID(creds, s.Spec.ForProvider.SubscriptionID))
+	cl.Authorizer = auth
+	tp, err := azureclients.TagPolicyOf(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &external{client: cl, tagPolicy: tp}, nil
+}
+
+type external struct {
+	client    storagesyncapi.ServicesClientAPI
+	tagPolicy v1beta1.TagPolicy
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	s, ok := mg.(*v1alpha1.StorageSyncService)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotStorageSyncService)
+	}
+
+	az, err := e.client.Get(ctx, s.Spec.ForProvider.ResourceGroupName, meta.GetExternalName(s))
+	if azureclients.IsNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetStorageSyncService)
+	}
+
+	current := s.Spec.ForProvider.DeepCopy()
+	storagesync.LateInitializeService(&s.Spec.ForProvider, az, e.tagPolicy)
+
+	s.Status.AtProvider = storagesync.GenerateServiceObservation(az)
+	s.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        !storagesync.ServiceNeedsUpdate(s.Spec.ForProvider, az),
+		ResourceLateInitialized: !cmp.Equal(current, &s.Spec.ForProvider),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	s, ok := mg.(*v1alpha1.StorageSyncService)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotStorageSyncService)
+	}
+
+	s.SetConditions(xpv1.Creating())
+	_, err := e.client.Create(ctx, s.Spec.ForProvider.ResourceGroupName, meta.GetExternalName(s), storagesync.NewServiceCreateParameters(s.Spec.ForProvider))
+	return managed.ExternalCreation{}, errors.Wrap(err, errCreateStorageSyncService)
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	s, ok := mg.(*v1alpha1.StorageSyncService)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotStorageSyncService)
+	}
+
+	_, err := e.client.Update(ctx, s.Spec.ForProvider.ResourceGroupName, meta.GetExternalName(s), storagesync.NewServiceUpdateParameters(s.Spec.ForProvider))
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateStorageSyncService)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	s, ok := mg.(*v1alpha1.StorageSyncService)
+	if !ok {
+		return errors.New(errNotStorageSyncService)
+	}
+
+	s.SetConditions(xpv1.Deleting())
+	_, err := e.client.Delete(ctx, s.Spec.ForProvider.ResourceGroupName, meta.GetExternalName(s))
+	return errors.Wrap(resource.Ignore(azureclients.IsNotFound, err), errDeleteStorageSyncService)
+}
@@ -0,0 +1,303 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storagesyncservice
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/storagesync/mgmt/2019-10-01/storagesync"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/storagesync/v1alpha1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/storagesync/fake"
+)
+
+const (
+	name         = "coolService"
+	testLocation = "westeurope"
+	id           = "coolID"
+)
+
+var errBoom = errors.New("boom")
+
+type serviceModifier func(*v1alpha1.StorageSyncService)
+
+func withConditions(c ...xpv1.Condition) serviceModifier {
+	return func(r *v1alpha1.StorageSyncService) { r.Status.ConditionedStatus.Conditions = c }
+}
+
+func withLocation(l string) serviceModifier {
+	return func(r *v1alpha1.StorageSyncService) { r.Spec.ForProvider.Location = l }
+}
+
+func withTags(t map[string]string) serviceModifier {
+	return func(r *v1alpha1.StorageSyncService) { r.Spec.ForProvider.Tags = t }
+}
+
+func withObservation(o v1alpha1.StorageSyncServiceObservation) serviceModifier {
+	return func(r *v1alpha1.StorageSyncService) { r.Status.AtProvider = o }
+}
+
+func service(m ...serviceModifier) *v1alpha1.StorageSyncService {
+	r := &v1alpha1.StorageSyncService{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1alpha1.StorageSyncServiceSpec{
+			ForProvider: v1alpha1.StorageSyncServiceParameters{ResourceGroupName: "coolRG"},
+		},
+	}
+	meta.SetExternalName(r, name)
+	for _, fn := range m {
+		fn(r)
+	}
+	return r
+}
+
+func observed(tags map[string]string) storagesync.Service {
+	return storagesync.Service{
+		ID:       azure.ToStringPtr(id),
+		Location: azure.ToStringPtr(testLocation),
+		Tags:     azure.ToStringPtrMap(tags),
+	}
+}
+
+var _ managed.ExternalClient = &external{}
+var _ managed.ExternalConnecter = &connecter{}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   want
+	}{
+		"NotStorageSyncService": {
+			reason: "An error should be returned if the managed resource is not a StorageSyncService",
+			e:      &external{client: &fake.MockServicesClient{}},
+			mg:     &v1alpha1.SyncGroup{},
+			want:   want{mg: &v1alpha1.SyncGroup{}, err: errors.New(errNotStorageSyncService)},
+		},
+		"NotFound": {
+			reason: "A Storage Sync Service that is not found should not exist",
+			e: &external{client: &fake.MockServicesClient{
+				MockGet: func(_ context.Context, _, _ string) (storagesync.Service, error) {
+					return storagesync.Service{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			}},
+			mg:   service(),
+			want: want{mg: service()},
+		},
+		"GetFailed": {
+			reason: "Errors getting the Storage Sync Service should be returned",
+			e: &external{client: &fake.MockServicesClient{
+				MockGet: func(_ context.Context, _, _ string) (storagesync.Service, error) {
+					return storagesync.Service{}, errBoom
+				},
+			}},
+			mg:   service(),
+			want: want{mg: service(), err: errors.Wrap(errBoom, errGetStorageSyncService)},
+		},
+		"LateInitialized": {
+			reason: "The testLocation and tags of the Storage Sync Service should be late initialized",
+			e: &external{client: &fake.MockServicesClient{
+				MockGet: func(_ context.Context, _, _ string) (storagesync.Service, error) {
+					return observed(map[string]string{"team": "cool"}), nil
+				},
+			}},
+			mg: service(),
+			want: want{
+				mg: service(
+					withLocation(testLocation),
+					withTags(map[string]string{"team": "cool"}),
+					withObservation(v1alpha1.StorageSyncServiceObservation{ID: id}),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true},
+			},
+		},
+		"NeedsUpdate": {
+			reason: "A Storage Sync Service whose tags differ from the spec should need an update",
+			e: &external{client: &fake.MockServicesClient{
+				MockGet: func(_ context.Context, _, _ string) (storagesync.Service, error) {
+					return observed(map[string]string{"team": "uncool"}), nil
+				},
+			}},
+			mg: service(withLocation(testLocation), withTags(map[string]string{"team": "cool"})),
+			want: want{
+				mg: service(
+					withLocation(testLocation),
+					withTags(map[string]string{"team": "cool"}),
+					withObservation(v1alpha1.StorageSyncServiceObservation{ID: id}),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o, err := tc.e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want managed, +got managed:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   error
+	}{
+		"Successful": {
+			reason: "The Storage Sync Service should be created in the testLocation of the spec",
+			e: &external{client: &fake.MockServicesClient{
+				MockCreate: func(_ context.Context, _, _ string, p storagesync.ServiceCreateParameters) (storagesync.Service, error) {
+					if diff := cmp.Diff(testLocation, azure.ToString(p.Location)); diff != "" {
+						t.Errorf("Create(...): -want testLocation, +got testLocation:\n%s", diff)
+					}
+					return storagesync.Service{}, nil
+				},
+			}},
+			mg: service(withLocation(testLocation)),
+		},
+		"Failed": {
+			reason: "Errors creating the Storage Sync Service should be returned",
+			e: &external{client: &fake.MockServicesClient{
+				MockCreate: func(_ context.Context, _, _ string, _ storagesync.ServiceCreateParameters) (storagesync.Service, error) {
+					return storagesync.Service{}, errBoom
+				},
+			}},
+			mg:   service(),
+			want: errors.Wrap(errBoom, errCreateStorageSyncService),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   error
+	}{
+		"Successful": {
+			reason: "The tags of the Storage Sync Service should be updated",
+			e: &external{client: &fake.MockServicesClient{
+				MockUpdate: func(_ context.Context, _, _ string, p *storagesync.ServiceUpdateParameters) (storagesync.Service, error) {
+					if diff := cmp.Diff(azure.ToStringPtrMap(map[string]string{"team": "cool"}), p.Tags); diff != "" {
+						t.Errorf("Update(...): -want tags, +got tags:\n%s", diff)
+					}
+					return storagesync.Service{}, nil
+				},
+			}},
+			mg: service(withTags(map[string]string{"team": "cool"})),
+		},
+		"Failed": {
+			reason: "Errors updating the Storage Sync Service should be returned",
+			e: &external{client: &fake.MockServicesClient{
+				MockUpdate: func(_ context.Context, _, _ string, _ *storagesync.ServiceUpdateParameters) (storagesync.Service, error) {
+					return storagesync.Service{}, errBoom
+				},
+			}},
+			mg:   service(),
+			want: errors.Wrap(errBoom, errUpdateStorageSyncService),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   error
+	}{
+		"NotFound": {
+			reason: "A Storage Sync Service that is already gone should be deleted",
+			e: &external{client: &fake.MockServicesClient{
+				MockDelete: func(_ context.Context, _, _ string) (autorest.Response, error) {
+					return autorest.Response{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			}},
+			mg: service(),
+		},
+		"Failed": {
+			reason: "Errors deleting the Storage Sync Service should be returned",
+			e: &external{client: &fake.MockServicesClient{
+				MockDelete: func(_ context.Context, _, _ string) (autorest.Response, error) {
+					return autorest.Response{}, errBoom
+				},
+			}},
+			mg:   service(),
+			want: errors.Wrap(errBoom, errDeleteStorageSyncService),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,240 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncgroup
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/storagesync/mgmt/2019-10-01/storagesync"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/storagesync/v1alpha1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/storagesync/fake"
+)
+
+const (
+	name        = "coolGroup"
+	serviceName = "coolService"
+)
+
+var errBoom = errors.New("boom")
+
+type groupModifier func(*v1alpha1.SyncGroup)
+
+func withConditions(c ...xpv1.Condition) groupModifier {
+	return func(r *v1alpha1.SyncGroup) { r.Status.ConditionedStatus.Conditions = c }
+}
+
+func withObservation(o v1alpha1.SyncGroupObservation) groupModifier {
+	return func(r *v1alpha1.SyncGroup) { r.Status.AtProvider = o }
+}
+
+func group(m ...groupModifier) *v1alpha1.SyncGroup {
+	r := &v1alpha1.SyncGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1alpha1.SyncGroupSpec{
+			ForProvider: v1alpha1.SyncGroupParameters{
+				ResourceGroupName:      "coolRG",
+				StorageSyncServiceName: serviceName,
+			},
+		},
+	}
+	meta.SetExternalName(r, name)
+	for _, fn := range m {
+		fn(r)
+	}
+	return r
+}
+
+var _ managed.ExternalClient = &external{}
+var _ managed.ExternalConnecter = &connecter{}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   want
+	}{
+		"NotSyncGroup": {
+			reason: "An error should be returned if the managed resource is not a SyncGroup",
+			e:      &external{client: &fake.MockSyncGroupsClient{}},
+			mg:     &v1alpha1.CloudEndpoint{},
+			want:   want{mg: &v1alpha1.CloudEndpoint{}, err: errors.New(errNotSyncGroup)},
+		},
+		"NotFound": {
+			reason: "A sync group that is not found should not exist",
+			e: &external{client: &fake.MockSyncGroupsClient{
+				MockGet: func(_ context.Context, _, _, _ string) (storagesync.SyncGroup, error) {
+					return storagesync.SyncGroup{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			}},
+			mg:   group(),
+			want: want{mg: group()},
+		},
+		"GetFailed": {
+			reason: "Errors getting the sync group should be returned",
+			e: &external{client: &fake.MockSyncGroupsClient{
+				MockGet: func(_ context.Context, _, _, _ string) (storagesync.SyncGroup, error) {
+					return storagesync.SyncGroup{}, errBoom
+				},
+			}},
+			mg:   group(),
+			want: want{mg: group(), err: errors.Wrap(errBoom, errGetSyncGroup)},
+		},
+		"Available": {
+			reason: "A sync group that exists should be available and up to date",
+			e: &external{client: &fake.MockSyncGroupsClient{
+				MockGet: func(_ context.Context, _, service, _ string) (storagesync.SyncGroup, error) {
+					if diff := cmp.Diff(serviceName, service); diff != "" {
+						t.Errorf("Get(...): -want service, +got service:\n%s", diff)
+					}
+					return storagesync.SyncGroup{
+						ID: azure.ToStringPtr("coolID"),
+						SyncGroupProperties: &storagesync.SyncGroupProperties{
+							UniqueID:        azure.ToStringPtr("coolUID"),
+							SyncGroupStatus: azure.ToStringPtr("0"),
+						},
+					}, nil
+				},
+			}},
+			mg: group(),
+			want: want{
+				mg: group(
+					withObservation(v1alpha1.SyncGroupObservation{ID: "coolID", UniqueID: "coolUID", SyncGroupStatus: "0"}),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o, err := tc.e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want managed, +got managed:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   error
+	}{
+		"Successful": {
+			reason: "The sync group should be created in its Storage Sync Service",
+			e: &external{client: &fake.MockSyncGroupsClient{
+				MockCreate: func(_ context.Context, _, service, n string, _ storagesync.SyncGroupCreateParameters) (storagesync.SyncGroup, error) {
+					if diff := cmp.Diff(serviceName, service); diff != "" {
+						t.Errorf("Create(...): -want service, +got service:\n%s", diff)
+					}
+					if diff := cmp.Diff(name, n); diff != "" {
+						t.Errorf("Create(...): -want name, +got name:\n%s", diff)
+					}
+					return storagesync.SyncGroup{}, nil
+				},
+			}},
+			mg: group(),
+		},
+		"Failed": {
+			reason: "Errors creating the sync group should be returned",
+			e: &external{client: &fake.MockSyncGroupsClient{
+				MockCreate: func(_ context.Context, _, _, _ string, _ storagesync.SyncGroupCreateParameters) (storagesync.SyncGroup, error) {
+					return storagesync.SyncGroup{}, errBoom
+				},
+			}},
+			mg:   group(),
+			want: errors.Wrap(errBoom, errCreateSyncGroup),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     resource.Managed
+		want   error
+	}{
+		"NotFound": {
+			reason: "A sync group that is already gone should be deleted",
+			e: &external{client: &fake.MockSyncGroupsClient{
+				MockDelete: func(_ context.Context, _, _, _ string) (autorest.Response, error) {
+					return autorest.Response{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			}},
+			mg: group(),
+		},
+		"Failed": {
+			reason: "Errors deleting the sync group should be returned",
+			e: &external{client: &fake.MockSyncGroupsClient{
+				MockDelete: func(_ context.Context, _, _, _ string) (autorest.Response, error) {
+					return autorest.Response{}, errBoom
+				},
+			}},
+			mg:   group(),
+			want: errors.Wrap(errBoom, errDeleteSyncGroup),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package credentials reacts to the rotation of the credentials secrets of
+// Providers and ProviderConfigs.
+package credentials
+
+import (
+	"context"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-azure/apis/v1alpha3"
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+)
+
+// Rotated returns true if the data of the supplied secret changed.
+func Rotated(old, s *corev1.Secret) bool {
+	return !reflect.DeepEqual(old.Data, s.Data)
+}
+
+// MetadataRotated returns true if the supplied secret, of which only the
+// metadata is known, was updated without changing its labels or annotations,
+// i.e. if its data likely changed.
+func MetadataRotated(old, s metav1.Object) bool {
+	return old.GetResourceVersion() != s.GetResourceVersion() &&
+		reflect.DeepEqual(old.GetLabels(), s.GetLabels()) &&
+		reflect.DeepEqual(old.GetAnnotations(), s.GetAnnotations())
+}
+
+// rotated returns true if the data of the secret of the supplied update
+// event changed. Secrets that are watched as metadata only are assumed to be
+// rotated by updates that do not change their labels or annotations.
+func rotated(ev event.UpdateEvent) bool {
+	if old, ok := ev.ObjectOld.(*corev1.Secret); ok {
+		s, ok := ev.ObjectNew.(*corev1.Secret)
+		return ok && Rotated(old, s)
+	}
+	if old, ok := ev.ObjectOld.(*metav1.PartialObjectMetadata); ok {
+		s, ok := ev.ObjectNew.(*metav1.PartialObjectMetadata)
+		return ok && MetadataRotated(old, s)
+	}
+	return false
+}
+
+// EnqueueRequestsForRotation returns an event handler that enqueues a request
+// for every managed resource of the kind of the supplied list that uses a
+// Provider or ProviderConfig whose credentials secret was rotated. Clients are
+// built from the credentials on every reconcile, so requeueing a resource is
+// enough for the rotated credentials to take effect immediately rather than
+// at its next poll, which may be long for resources waiting on an operation.
+// Secrets may be watched in full or as metadata only.
+func EnqueueRequestsForRotation(c client.Client, l resource.ManagedList, log logging.Logger) handler.EventHandler {
+	return handler.Funcs{
+		UpdateFunc: func(ev event.UpdateEvent, q workqueue.RateLimitingInterface) {
+			if !rotated(ev) {
+				return
+			}
+			s := ev.ObjectNew
+			reqs, err := Requests(context.Background(), c, l.DeepCopyObject().(resource.ManagedList), types.NamespacedName{Namespace: s.GetNamespace(), Name: s.GetName()})
+			if err != nil {
+				log.Info("Cannot requeue managed resources after credentials rotation", "error", err, "secret", s.GetNamespace()+"/"+s.GetName())
+			}
+			for _, r := range reqs {
+				q.Add(r)
+			}
+		},
+	}
+}
+
+// Requests returns a request for every managed resource of the kind of the
+// supplied list that uses a Provider or ProviderConfig whose credentials are
+// the supplied secret.
+func Requests(ctx context.Context, c client.Client, l resource.ManagedList, secret types.NamespacedName) ([]reconcile.Request, error) {
+	pcs := &v1beta1.ProviderConfigList{}
+	if err := c.List(ctx, pcs); err != nil {
+		return nil, err
+	}
+	configs := map[string]bool{}
+	for _, pc := range pcs.Items {
+		if ref := pc.Spec.Credentials.SecretRef; ref != nil && ref.Namespace == secret.Namespace && ref.Name == secret.Name {
+			configs[pc.GetName()] = true
+		}
+	}
+	ps := &v1alpha3.ProviderList{}
+	if err := c.List(ctx, ps); err != nil {
+		return nil, err
+	}
+	providers := map[string]bool{}
+	for _, p := range ps.Items {
+		if ref := p.Spec.CredentialsSecretRef; ref.Namespace == secret.Namespace && ref.Name == secret.Name {
+			providers[p.GetName()] = true
+		}
+	}
+	if len(configs) == 0 && len(providers) == 0 {
+		return nil, nil
+	}
+
+	if err := c.List(ctx, l); err != nil {
+		return nil, err
+	}
+	reqs := make([]reconcile.Request, 0)
+	for _, mg := range l.GetItems() {
+		pc, p := mg.GetProviderConfigReference(), mg.GetProviderReference()
+		if (pc != nil && configs[pc.Name]) || (pc == nil && p != nil && providers[p.Name]) {
+			reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: mg.GetName()}})
+		}
+	}
+	return reqs, nil
+}
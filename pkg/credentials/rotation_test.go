@@ -0,0 +1,136 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/cache/v1beta1"
+	"github.com/crossplane/provider-azure/apis/v1alpha3"
+	apisv1beta1 "github.com/crossplane/provider-azure/apis/v1beta1"
+)
+
+func TestRequests(t *testing.T) {
+	errBoom := errors.New("boom")
+	secret := types.NamespacedName{Namespace: "crossplane-system", Name: "azure-creds"}
+	ref := &xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Namespace: secret.Namespace, Name: secret.Name}, Key: "credentials"}
+
+	redis := func(name string, pc, p *xpv1.Reference) v1beta1.Redis {
+		r := v1beta1.Redis{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		r.SetProviderConfigReference(pc)
+		r.SetProviderReference(p)
+		return r
+	}
+	list := func(items ...v1beta1.Redis) test.MockListFn {
+		return test.NewMockListFn(nil, func(o client.ObjectList) error {
+			switch l := o.(type) {
+			case *apisv1beta1.ProviderConfigList:
+				pc := apisv1beta1.ProviderConfig{ObjectMeta: metav1.ObjectMeta{Name: "rotated"}}
+				pc.Spec.Credentials.SecretRef = ref
+				l.Items = []apisv1beta1.ProviderConfig{pc, {ObjectMeta: metav1.ObjectMeta{Name: "other"}}}
+			case *v1alpha3.ProviderList:
+				l.Items = []v1alpha3.Provider{{ObjectMeta: metav1.ObjectMeta{Name: "rotated"}, Spec: v1alpha3.ProviderSpec{CredentialsSecretRef: *ref}}}
+			case *v1beta1.RedisList:
+				l.Items = items
+			}
+			return nil
+		})
+	}
+
+	type want struct {
+		reqs []reconcile.Request
+		err  error
+	}
+	cases := map[string]struct {
+		list test.MockListFn
+		want want
+	}{
+		"ListError": {
+			list: test.NewMockListFn(errBoom),
+			want: want{err: errBoom},
+		},
+		"Requeued": {
+			list: list(
+				redis("config", &xpv1.Reference{Name: "rotated"}, nil),
+				redis("other-config", &xpv1.Reference{Name: "other"}, &xpv1.Reference{Name: "rotated"}),
+				redis("provider", nil, &xpv1.Reference{Name: "rotated"}),
+				redis("none", nil, nil),
+			),
+			want: want{reqs: []reconcile.Request{
+				{NamespacedName: types.NamespacedName{Name: "config"}},
+				{NamespacedName: types.NamespacedName{Name: "provider"}},
+			}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			reqs, err := Requests(context.Background(), &test.MockClient{MockList: tc.list}, &v1beta1.RedisList{}, secret)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Requests(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.reqs, reqs); diff != "" {
+				t.Errorf("Requests(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMetadataRotated(t *testing.T) {
+	secret := func(rv string, labels map[string]string) *metav1.PartialObjectMetadata {
+		return &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{ResourceVersion: rv, Labels: labels}}
+	}
+
+	cases := map[string]struct {
+		old  *metav1.PartialObjectMetadata
+		new  *metav1.PartialObjectMetadata
+		want bool
+	}{
+		"Resync": {
+			old:  secret("1", nil),
+			new:  secret("1", nil),
+			want: false,
+		},
+		"LabelsChanged": {
+			old:  secret("1", nil),
+			new:  secret("2", map[string]string{"cool": "true"}),
+			want: false,
+		},
+		"DataChanged": {
+			old:  secret("1", map[string]string{"cool": "true"}),
+			new:  secret("2", map[string]string{"cool": "true"}),
+			want: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, MetadataRotated(tc.old, tc.new)); diff != "" {
+				t.Errorf("MetadataRotated(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package defaults applies the defaults of a ProviderConfig, e.g. its default
+// resource group and tags, to the managed resources that use it.
+package defaults
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	cachev1beta1 "github.com/crossplane/provider-azure/apis/cache/v1beta1"
+	cognitiveservicesv1alpha1 "github.com/crossplane/provider-azure/apis/cognitiveservices/v1alpha1"
+	computev1alpha3 "github.com/crossplane/provider-azure/apis/compute/v1alpha3"
+	databasev1alpha3 "github.com/crossplane/provider-azure/apis/database/v1alpha3"
+	databasev1beta1 "github.com/crossplane/provider-azure/apis/database/v1beta1"
+	eventhubv1alpha1 "github.com/crossplane/provider-azure/apis/eventhub/v1alpha1"
+	kubernetesv1alpha1 "github.com/crossplane/provider-azure/apis/kubernetes/v1alpha1"
+	kustov1alpha1 "github.com/crossplane/provider-azure/apis/kusto/v1alpha1"
+	monitorv1alpha1 "github.com/crossplane/provider-azure/apis/monitor/v1alpha1"
+	networkv1beta1 "github.com/crossplane/provider-azure/apis/network/v1beta1"
+	storagev1alpha3 "github.com/crossplane/provider-azure/apis/storage/v1alpha3"
+	storagesyncv1alpha1 "github.com/crossplane/provider-azure/apis/storagesync/v1alpha1"
+	azurev1alpha3 "github.com/crossplane/provider-azure/apis/v1alpha3"
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+)
+
+// Error strings.
+const (
+	errGetPC         = "cannot get referenced ProviderConfig"
+	errUpdateManaged = "cannot update managed resource with defaults"
+)
+
+// ResourceGroupOf returns the resource group name field of the supplied
+// managed resource, or nil if it has none. It also returns true if the
+// resource references or selects its resource group, in which case the field
+// is filled in by reference resolution.
+func ResourceGroupOf(mg resource.Managed) (*string, bool) { // nolint:gocyclo
+	switch cr := mg.(type) {
+	case *cachev1beta1.Redis:
+		p := &cr.Spec.ForProvider
+		return &p.ResourceGroupName, p.ResourceGroupNameRef != nil || p.ResourceGroupNameSelector != nil
+	case *computev1alpha3.AKSCluster:
+		s := &cr.Spec
+		return &s.ResourceGroupName, s.ResourceGroupNameRef != nil || s.ResourceGroupNameSelector != nil
+	case *cognitiveservicesv1alpha1.OpenAIDeployment:
+		p := &cr.Spec.ForProvider
+		return &p.ResourceGroupName, p.ResourceGroupNameRef != nil || p.ResourceGroupNameSelector != nil
+	case *computev1alpha3.DedicatedHostGroup:
+		p := &cr.Spec.ForProvider
+		return &p.ResourceGroupName, p.ResourceGroupNameRef != nil || p.ResourceGroupNameSelector != nil
+	case *computev1alpha3.DedicatedHost:
+		p := &cr.Spec.ForProvider
+		return &p.ResourceGroupName, p.ResourceGroupNameRef != nil || p.ResourceGroupNameSelector != nil
+	case *computev1alpha3.CapacityReservationGroup:
+		p := &cr.Spec.ForProvider
+		return &p.ResourceGroupName, p.ResourceGroupNameRef != nil || p.ResourceGroupNameSelector != nil
+	case *computev1alpha3.CapacityReservation:
+		p := &cr.Spec.ForProvider
+		return &p.ResourceGroupName, p.ResourceGroupNameRef != nil || p.ResourceGroupNameSelector != nil
+	case *computev1alpha3.ImageTemplate:
+		p := &cr.Spec.ForProvider
+		return &p.ResourceGroupName, p.ResourceGroupNameRef != nil || p.ResourceGroupNameSelector != nil
+	case *databasev1beta1.MySQLServer:
+		p := &cr.Spec.ForProvider
+		return &p.ResourceGroupName, p.ResourceGroupNameRef != nil || p.ResourceGroupNameSelector != nil
+	case *databasev1beta1.PostgreSQLServer:
+		p := &cr.Spec.ForProvider
+		return &p.ResourceGroupName, p.ResourceGroupNameRef != nil || p.ResourceGroupNameSelector != nil
+	case *databasev1alpha3.MySQLServerFirewallRule:
+		p := &cr.Spec.ForProvider
+		return &p.ResourceGroupName, p.ResourceGroupNameRef != nil || p.ResourceGroupNameSelector != nil
+	case *databasev1alpha3.PostgreSQLServerFirewallRule:
+		p := &cr.Spec.ForProvider
+		return &p.ResourceGroupName, p.ResourceGroupNameRef != nil || p.ResourceGroupNameSelector != nil
+	case *databasev1alpha3.MySQLServerVirtualNetworkRule:
+		s := &cr.Spec
+		return &s.ResourceGroupName, s.ResourceGroupNameRef != nil || s.ResourceGroupNameSelector != nil
+	case *databasev1alpha3.PostgreSQLServerVirtualNetworkRule:
+		s := &cr.Spec
+		return &s.ResourceGroupName, s.ResourceGroupNameRef != nil || s.ResourceGroupNameSelector != nil
+	case *databasev1alpha3.CosmosDBAccount:
+		p := &cr.Spec.ForProvider
+		return &p.ResourceGroupName, p.ResourceGroupNameRef != nil || p.ResourceGroupNameSelector != nil
+	case *eventhubv1alpha1.SchemaRegistryGroup:
+		p := &cr.Spec.ForProvider
+		return &p.ResourceGroupName, p.ResourceGroupNameRef != nil || p.ResourceGroupNameSelector != nil
+	case *kubernetesv1alpha1.ConnectedCluster:
+		p := &cr.Spec.ForProvider
+		return &p.ResourceGroupName, p.ResourceGroupNameRef != nil || p.ResourceGroupNameSelector != nil
+	case *kustov1alpha1.KustoCluster:
+		p := &cr.Spec.ForProvider
+		return &p.ResourceGroupName, p.ResourceGroupNameRef != nil || p.ResourceGroupNameSelector != nil
+	case *kustov1alpha1.KustoDatabase:
+		p := &cr.Spec.ForProvider
+		return &p.ResourceGroupName, p.ResourceGroupNameRef != nil || p.ResourceGroupNameSelector != nil
+	case *kustov1alpha1.KustoScript:
+		p := &cr.Spec.ForProvider
+		return &p.ResourceGroupName, p.ResourceGroupNameRef != nil || p.ResourceGroupNameSelector != nil
+	case *monitorv1alpha1.AzureMonitorPrivateLinkScope:
+		p := &cr.Spec.ForProvider
+		return &p.ResourceGroupName, p.ResourceGroupNameRef != nil || p.ResourceGroupNameSelector != nil
+	case *monitorv1alpha1.AzureMonitorPrivateLinkScopedResource:
+		p := &cr.Spec.ForProvider
+		return &p.ResourceGroupName, p.ResourceGroupNameRef != nil || p.ResourceGroupNameSelector != nil
+	case *monitorv1alpha1.AzureMonitorWorkspace:
+		p := &cr.Spec.ForProvider
+		return &p.ResourceGroupName, p.ResourceGroupNameRef != nil || p.ResourceGroupNameSelector != nil
+	case *monitorv1alpha1.DataCollectionEndpoint:
+		p := &cr.Spec.ForProvider
+		return &p.ResourceGroupName, p.ResourceGroupNameRef != nil || p.ResourceGroupNameSelector != nil
+	case *monitorv1alpha1.DataCollectionRule:
+		p := &cr.Spec.ForProvider
+		return &p.ResourceGroupName, p.ResourceGroupNameRef != nil || p.ResourceGroupNameSelector != nil
+	case *networkv1beta1.VirtualNetwork:
+		p := &cr.Spec.ForProvider
+		return &p.ResourceGroupName, p.ResourceGroupNameRef != nil || p.ResourceGroupNameSelector != nil
+	case *networkv1beta1.Subnet:
+		p := &cr.Spec.ForProvider
+		return &p.ResourceGroupName, p.ResourceGroupNameRef != nil || p.ResourceGroupNameSelector != nil
+	case *storagev1alpha3.Account:
+		s := &cr.Spec
+		return &s.ResourceGroupName, s.ResourceGroupNameRef != nil || s.ResourceGroupNameSelector != nil
+	case *storagesyncv1alpha1.StorageSyncService:
+		p := &cr.Spec.ForProvider
+		return &p.ResourceGroupName, p.ResourceGroupNameRef != nil || p.ResourceGroupNameSelector != nil
+	case *storagesyncv1alpha1.SyncGroup:
+		p := &cr.Spec.ForProvider
+		return &p.ResourceGroupName, p.ResourceGroupNameRef != nil || p.ResourceGroupNameSelector != nil
+	case *storagesyncv1alpha1.CloudEndpoint:
+		p := &cr.Spec.ForProvider
+		return &p.ResourceGroupName, p.ResourceGroupNameRef != nil || p.ResourceGroupNameSelector != nil
+	}
+	return nil, false
+}
+
+// TagsOf returns the tags field of the supplied managed resource, or nil if it
+// has none.
+func TagsOf(mg resource.Managed) *map[string]string {
+	switch cr := mg.(type) {
+	case *azurev1alpha3.ResourceGroup:
+		return &cr.Spec.Tags
+	case *cachev1beta1.Redis:
+		return &cr.Spec.ForProvider.Tags
+	case *computev1alpha3.AKSCluster:
+		return &cr.Spec.Tags
+	case *computev1alpha3.DedicatedHostGroup:
+		return &cr.Spec.ForProvider.Tags
+	case *computev1alpha3.DedicatedHost:
+		return &cr.Spec.ForProvider.Tags
+	case *computev1alpha3.CapacityReservationGroup:
+		return &cr.Spec.ForProvider.Tags
+	case *computev1alpha3.CapacityReservation:
+		return &cr.Spec.ForProvider.Tags
+	case *computev1alpha3.ImageTemplate:
+		return &cr.Spec.ForProvider.Tags
+	case *databasev1beta1.MySQLServer:
+		return &cr.Spec.ForProvider.Tags
+	case *databasev1beta1.PostgreSQLServer:
+		return &cr.Spec.ForProvider.Tags
+	case *databasev1alpha3.CosmosDBAccount:
+		return &cr.Spec.ForProvider.Tags
+	case *kubernetesv1alpha1.ConnectedCluster:
+		return &cr.Spec.ForProvider.Tags
+	case *kustov1alpha1.KustoCluster:
+		return &cr.Spec.ForProvider.Tags
+	case *monitorv1alpha1.AzureMonitorPrivateLinkScope:
+		return &cr.Spec.ForProvider.Tags
+	case *monitorv1alpha1.AzureMonitorWorkspace:
+		return &cr.Spec.ForProvider.Tags
+	case *monitorv1alpha1.DataCollectionEndpoint:
+		return &cr.Spec.ForProvider.Tags
+	case *monitorv1alpha1.DataCollectionRule:
+		return &cr.Spec.ForProvider.Tags
+	case *networkv1beta1.VirtualNetwork:
+		return &cr.Spec.ForProvider.Tags
+	case *storagev1alpha3.Account:
+		if cr.Spec.StorageAccountSpec == nil {
+			return nil
+		}
+		return &cr.Spec.StorageAccountSpec.Tags
+	case *storagesyncv1alpha1.StorageSyncService:
+		return &cr.Spec.ForProvider.Tags
+	}
+	return nil
+}
+
+// Apply the supplied defaults to the supplied managed resource. It returns
+// true if the resource changed.
+func Apply(mg resource.Managed, spec v1beta1.ProviderConfigSpec) bool {
+	changed := false
+	if rg, referenced := ResourceGroupOf(mg); rg != nil && *rg == "" && !referenced && spec.DefaultResourceGroupName != nil {
+		*rg = *spec.DefaultResourceGroupName
+		changed = true
+	}
+	if tags := TagsOf(mg); tags != nil {
+		for k, v := range spec.DefaultTags {
+			if _, ok := (*tags)[k]; ok {
+				continue
+			}
+			if *tags == nil {
+				*tags = make(map[string]string, len(spec.DefaultTags))
+			}
+			(*tags)[k] = v
+			changed = true
+		}
+	}
+	return changed
+}
+
+// An Initializer sets the resource group and tags that managed resources omit
+// to the defaults of their ProviderConfig. Resources that use the deprecated
+// Provider, and resources of kinds without a resource group or tags, are not
+// affected.
+type Initializer struct {
+	client client.Client
+}
+
+// NewInitializer returns a new Initializer.
+func NewInitializer(c client.Client) *Initializer {
+	return &Initializer{client: c}
+}
+
+// Initialize the resource group and tags of the supplied managed resource.
+func (i *Initializer) Initialize(ctx context.Context, mg resource.Managed) error {
+	ref := mg.GetProviderConfigReference()
+	if ref == nil {
+		return nil
+	}
+	if rg, _ := ResourceGroupOf(mg); rg == nil && TagsOf(mg) == nil {
+		return nil
+	}
+	pc := &v1beta1.ProviderConfig{}
+	if err := i.client.Get(ctx, types.NamespacedName{Name: ref.Name}, pc); err != nil {
+		return errors.Wrap(err, errGetPC)
+	}
+	if !Apply(mg, pc.Spec) {
+		return nil
+	}
+	return errors.Wrap(i.client.Update(ctx, mg), errUpdateManaged)
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defaults
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/cache/v1beta1"
+	apisv1beta1 "github.com/crossplane/provider-azure/apis/v1beta1"
+)
+
+var _ managed.Initializer = &Initializer{}
+
+func TestInitialize(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type redisModifier func(*v1beta1.Redis)
+	withResourceGroup := func(name string) redisModifier {
+		return func(cr *v1beta1.Redis) { cr.Spec.ForProvider.ResourceGroupName = name }
+	}
+	withResourceGroupRef := func(name string) redisModifier {
+		return func(cr *v1beta1.Redis) { cr.Spec.ForProvider.ResourceGroupNameRef = &xpv1.Reference{Name: name} }
+	}
+	withTags := func(tags map[string]string) redisModifier {
+		return func(cr *v1beta1.Redis) { cr.Spec.ForProvider.Tags = tags }
+	}
+	redis := func(m ...redisModifier) *v1beta1.Redis {
+		cr := &v1beta1.Redis{}
+		cr.SetProviderConfigReference(&xpv1.Reference{Name: "cool-pc"})
+		for _, f := range m {
+			f(cr)
+		}
+		return cr
+	}
+	pc := func(spec apisv1beta1.ProviderConfigSpec) test.MockGetFn {
+		return test.NewMockGetFn(nil, func(o client.Object) error {
+			o.(*apisv1beta1.ProviderConfig).SetName("cool-pc")
+			o.(*apisv1beta1.ProviderConfig).Spec = spec
+			return nil
+		})
+	}
+	spec := apisv1beta1.ProviderConfigSpec{
+		DefaultResourceGroupName: to.StringPtr("cool-rg"),
+		DefaultTags:              map[string]string{"team": "platform", "env": "prod"},
+	}
+
+	type want struct {
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		client client.Client
+		mg     resource.Managed
+		want   want
+	}{
+		"NoDefaultableFields": {
+			reason: "Kinds without a resource group or tags should not be affected",
+			mg:     &fake.Managed{ProviderConfigReferencer: fake.ProviderConfigReferencer{Ref: &xpv1.Reference{Name: "cool-pc"}}},
+			want: want{
+				mg: &fake.Managed{ProviderConfigReferencer: fake.ProviderConfigReferencer{Ref: &xpv1.Reference{Name: "cool-pc"}}},
+			},
+		},
+		"GetProviderConfigError": {
+			reason: "Errors getting the ProviderConfig should be returned",
+			client: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			mg:     redis(),
+			want: want{
+				mg:  redis(),
+				err: errors.Wrap(errBoom, errGetPC),
+			},
+		},
+		"Defaulted": {
+			reason: "A missing resource group and tags should be set to the defaults of the ProviderConfig",
+			client: &test.MockClient{MockGet: pc(spec), MockUpdate: test.NewMockUpdateFn(nil)},
+			mg:     redis(),
+			want: want{
+				mg: redis(withResourceGroup("cool-rg"), withTags(map[string]string{"team": "platform", "env": "prod"})),
+			},
+		},
+		"SpecifiedTakesPrecedence": {
+			reason: "A specified resource group and tags should take precedence over the defaults",
+			client: &test.MockClient{MockGet: pc(spec), MockUpdate: test.NewMockUpdateFn(nil)},
+			mg:     redis(withResourceGroup("my-rg"), withTags(map[string]string{"team": "data"})),
+			want: want{
+				mg: redis(withResourceGroup("my-rg"), withTags(map[string]string{"team": "data", "env": "prod"})),
+			},
+		},
+		"ReferencedResourceGroup": {
+			reason: "A referenced resource group should be left to reference resolution",
+			client: &test.MockClient{MockGet: pc(apisv1beta1.ProviderConfigSpec{DefaultResourceGroupName: to.StringPtr("cool-rg")})},
+			mg:     redis(withResourceGroupRef("my-rg")),
+			want:   want{mg: redis(withResourceGroupRef("my-rg"))},
+		},
+		"NoDefaults": {
+			reason: "Resources should not be updated if the ProviderConfig has no defaults",
+			client: &test.MockClient{MockGet: pc(apisv1beta1.ProviderConfigSpec{})},
+			mg:     redis(),
+			want:   want{mg: redis()},
+		},
+		"UpdateError": {
+			reason: "Errors persisting the defaults should be returned",
+			client: &test.MockClient{MockGet: pc(spec), MockUpdate: test.NewMockUpdateFn(errBoom)},
+			mg:     redis(),
+			want: want{
+				mg:  redis(withResourceGroup("cool-rg"), withTags(map[string]string{"team": "platform", "env": "prod"})),
+				err: errors.Wrap(errBoom, errUpdateManaged),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := NewInitializer(tc.client).Initialize(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nInitialize(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg); diff != "" {
+				t.Errorf("\n%s\nInitialize(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defaults
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/pkg/errors"
+	admissionv1 "k8s.io/api/admission/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+	"github.com/crossplane/provider-azure/pkg/location"
+)
+
+// WebhookPath is the path at which the defaulting webhook is served.
+const WebhookPath = "/mutate-defaults"
+
+// Error strings.
+const (
+	errNewObject = "cannot create object of kind %s"
+	errDecode    = "cannot decode object"
+	errEncode    = "cannot encode defaulted object"
+)
+
+// Default applies the supplied defaults to the supplied managed resource,
+// which is about to be created. In addition to the defaults Apply applies,
+// a missing location is set to the default location and a missing external
+// name to the name of the resource prefixed with the external name prefix.
+// It returns true if the resource changed.
+func Default(mg resource.Managed, spec v1beta1.ProviderConfigSpec) bool {
+	changed := Apply(mg, spec)
+	if loc := location.Of(mg); loc != nil && *loc == "" && spec.DefaultLocation != nil {
+		*loc = *spec.DefaultLocation
+		changed = true
+	}
+	// The name of a resource created with a generated name is not known
+	// until after admission. The external name of such a resource is set to
+	// its name by its controller.
+	if spec.ExternalNamePrefix != nil && meta.GetExternalName(mg) == "" && mg.GetName() != "" {
+		meta.SetExternalName(mg, *spec.ExternalNamePrefix+mg.GetName())
+		changed = true
+	}
+	return changed
+}
+
+// A Defaulter is an admission handler that applies the defaults of the
+// ProviderConfig of a managed resource when it is created, so that they are
+// part of its spec from the start rather than filled in by its controller.
+type Defaulter struct {
+	client client.Reader
+	scheme *runtime.Scheme
+}
+
+// NewDefaulter returns a Defaulter that reads ProviderConfigs using the
+// supplied client and decodes the managed resources it defaults using the
+// supplied scheme.
+func NewDefaulter(c client.Reader, s *runtime.Scheme) *Defaulter {
+	return &Defaulter{client: c, scheme: s}
+}
+
+// Handle the supplied admission request. Only managed resources that are
+// being created are defaulted, and only if their ProviderConfig exists.
+func (d *Defaulter) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Create {
+		return admission.Allowed("")
+	}
+	gvk := schema.GroupVersionKind{Group: req.Kind.Group, Version: req.Kind.Version, Kind: req.Kind.Kind}
+	o, err := d.scheme.New(gvk)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, errors.Wrapf(err, errNewObject, gvk.Kind))
+	}
+	mg, ok := o.(resource.Managed)
+	if !ok {
+		return admission.Allowed("")
+	}
+	if err := json.Unmarshal(req.Object.Raw, mg); err != nil {
+		return admission.Errored(http.StatusBadRequest, errors.Wrap(err, errDecode))
+	}
+
+	ref := mg.GetProviderConfigReference()
+	if ref == nil {
+		return admission.Allowed("")
+	}
+	pc := &v1beta1.ProviderConfig{}
+	if err := d.client.Get(ctx, types.NamespacedName{Name: ref.Name}, pc); err != nil {
+		if kerrors.IsNotFound(err) {
+			return admission.Allowed("")
+		}
+		return admission.Errored(http.StatusInternalServerError, errors.Wrap(err, errGetPC))
+	}
+	if !Default(mg, pc.Spec) {
+		return admission.Allowed("")
+	}
+
+	raw, err := json.Marshal(mg)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, errors.Wrap(err, errEncode))
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, raw)
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defaults
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	admissionv1 "k8s.io/api/admission/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/cache/v1beta1"
+	apisv1beta1 "github.com/crossplane/provider-azure/apis/v1beta1"
+)
+
+var _ admission.Handler = &Defaulter{}
+
+func TestDefault(t *testing.T) {
+	type redisModifier func(*v1beta1.Redis)
+	withName := func(n string) redisModifier {
+		return func(cr *v1beta1.Redis) { cr.SetName(n) }
+	}
+	withExternalName := func(n string) redisModifier {
+		return func(cr *v1beta1.Redis) { meta.SetExternalName(cr, n) }
+	}
+	withLocation := func(l string) redisModifier {
+		return func(cr *v1beta1.Redis) { cr.Spec.ForProvider.Location = l }
+	}
+	withResourceGroup := func(name string) redisModifier {
+		return func(cr *v1beta1.Redis) { cr.Spec.ForProvider.ResourceGroupName = name }
+	}
+	redis := func(m ...redisModifier) *v1beta1.Redis {
+		cr := &v1beta1.Redis{}
+		for _, f := range m {
+			f(cr)
+		}
+		return cr
+	}
+
+	type want struct {
+		mg      *v1beta1.Redis
+		changed bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		mg     *v1beta1.Redis
+		spec   apisv1beta1.ProviderConfigSpec
+		want   want
+	}{
+		"NoDefaults": {
+			reason: "Resources should not change if the ProviderConfig has no defaults",
+			mg:     redis(withName("cool-redis")),
+			want:   want{mg: redis(withName("cool-redis"))},
+		},
+		"Defaulted": {
+			reason: "A missing location, resource group and external name should be defaulted",
+			mg:     redis(withName("cool-redis")),
+			spec: apisv1beta1.ProviderConfigSpec{
+				DefaultLocation:          to.StringPtr("westus2"),
+				DefaultResourceGroupName: to.StringPtr("cool-rg"),
+				ExternalNamePrefix:       to.StringPtr("prod-"),
+			},
+			want: want{
+				mg:      redis(withName("cool-redis"), withLocation("westus2"), withResourceGroup("cool-rg"), withExternalName("prod-cool-redis")),
+				changed: true,
+			},
+		},
+		"SpecifiedTakesPrecedence": {
+			reason: "A specified location and external name should take precedence over the defaults",
+			mg:     redis(withName("cool-redis"), withLocation("eastus"), withExternalName("my-redis")),
+			spec: apisv1beta1.ProviderConfigSpec{
+				DefaultLocation:    to.StringPtr("westus2"),
+				ExternalNamePrefix: to.StringPtr("prod-"),
+			},
+			want: want{mg: redis(withName("cool-redis"), withLocation("eastus"), withExternalName("my-redis"))},
+		},
+		"GeneratedName": {
+			reason: "The external name of a resource whose name is not yet generated should not be defaulted",
+			mg:     redis(),
+			spec:   apisv1beta1.ProviderConfigSpec{ExternalNamePrefix: to.StringPtr("prod-")},
+			want:   want{mg: redis()},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			changed := Default(tc.mg, tc.spec)
+			if diff := cmp.Diff(tc.want.changed, changed); diff != "" {
+				t.Errorf("\n%s\nDefault(...): -want changed, +got changed:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg); diff != "" {
+				t.Errorf("\n%s\nDefault(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestHandle(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	s := runtime.NewScheme()
+	if err := v1beta1.SchemeBuilder.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	redis := &v1beta1.Redis{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1beta1.SchemeGroupVersion.String(), Kind: v1beta1.RedisKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "cool-redis"},
+	}
+	redis.SetProviderConfigReference(&xpv1.Reference{Name: "cool-pc"})
+	request := func(op admissionv1.Operation) admission.Request {
+		raw, _ := json.Marshal(redis)
+		return admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: op,
+			Kind:      metav1.GroupVersionKind{Group: v1beta1.Group, Version: v1beta1.Version, Kind: v1beta1.RedisKind},
+			Object:    runtime.RawExtension{Raw: raw},
+		}}
+	}
+	pc := test.NewMockGetFn(nil, func(o client.Object) error {
+		o.(*apisv1beta1.ProviderConfig).Spec.DefaultLocation = to.StringPtr("westus2")
+		return nil
+	})
+
+	type want struct {
+		allowed bool
+		patched bool
+		code    int32
+	}
+
+	cases := map[string]struct {
+		reason string
+		client client.Reader
+		req    admission.Request
+		want   want
+	}{
+		"Update": {
+			reason: "Updates should be allowed without being defaulted.",
+			client: &test.MockClient{MockGet: pc},
+			req:    request(admissionv1.Update),
+			want:   want{allowed: true},
+		},
+		"Create": {
+			reason: "Creates should be defaulted.",
+			client: &test.MockClient{MockGet: pc},
+			req:    request(admissionv1.Create),
+			want:   want{allowed: true, patched: true},
+		},
+		"ProviderConfigNotFound": {
+			reason: "Creates of resources whose ProviderConfig does not exist should be allowed without being defaulted.",
+			client: &test.MockClient{MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "cool-pc"))},
+			req:    request(admissionv1.Create),
+			want:   want{allowed: true},
+		},
+		"GetProviderConfigError": {
+			reason: "Errors getting the ProviderConfig should be returned.",
+			client: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			req:    request(admissionv1.Create),
+			want:   want{code: http.StatusInternalServerError},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := NewDefaulter(tc.client, s).Handle(context.Background(), tc.req)
+			if diff := cmp.Diff(tc.want.allowed, got.Allowed); diff != "" {
+				t.Errorf("\n%s\nHandle(...): -want allowed, +got allowed:\n%s\n%v", tc.reason, diff, got.Result)
+			}
+			if diff := cmp.Diff(tc.want.patched, len(got.Patches) > 0); diff != "" {
+				t.Errorf("\n%s\nHandle(...): -want patched, +got patched:\n%s", tc.reason, diff)
+			}
+			if got.Allowed {
+				return
+			}
+			if diff := cmp.Diff(tc.want.code, got.Result.Code); diff != "" {
+				t.Errorf("\n%s\nHandle(...): -want code, +got code:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
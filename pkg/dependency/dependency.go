@@ -0,0 +1,261 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dependency reports managed resources whose references to other
+// managed resources cannot be resolved yet, and requeues managed resources as
+// soon as the resources they depend on become available.
+package dependency
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// TypeReferencesResolved managed resources have resolved all of their
+// references to other managed resources.
+const TypeReferencesResolved xpv1.ConditionType = "ReferencesResolved"
+
+// Reasons a managed resource has or has not resolved its references.
+const (
+	ReasonResolved           xpv1.ConditionReason = "Resolved"
+	ReasonAwaitingDependency xpv1.ConditionReason = "AwaitingDependency"
+)
+
+// Error strings.
+const (
+	errResolveReferences = "cannot resolve references"
+	errUpdateManaged     = "cannot update managed resource"
+)
+
+// Resolved returns a condition that indicates a managed resource has resolved
+// all of its references.
+func Resolved() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeReferencesResolved,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonResolved,
+	}
+}
+
+// AwaitingDependency returns a condition that indicates a managed resource
+// cannot resolve its reference to the supplied dependency, e.g. because it is
+// not ready yet. The dependency may be empty if it is not known.
+func AwaitingDependency(dependency string, err error) xpv1.Condition {
+	msg := err.Error()
+	if dependency != "" {
+		msg = fmt.Sprintf("waiting for %s: %s", dependency, msg)
+	}
+	return xpv1.Condition{
+		Type:               TypeReferencesResolved,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonAwaitingDependency,
+		Message:            msg,
+	}
+}
+
+// A ReferenceResolver resolves the references of a managed resource by
+// calling its ResolveReferences method, if any, like the API simple reference
+// resolver. It also sets the ReferencesResolved condition of the managed
+// resource, naming the dependency it is waiting for if its references cannot
+// be resolved.
+type ReferenceResolver struct {
+	client client.Client
+}
+
+// NewReferenceResolver returns a ReferenceResolver that reads the referenced
+// managed resources with the supplied client.
+func NewReferenceResolver(c client.Client) *ReferenceResolver {
+	return &ReferenceResolver{client: c}
+}
+
+// ResolveReferences of the supplied managed resource.
+func (r *ReferenceResolver) ResolveReferences(ctx context.Context, mg resource.Managed) error {
+	rr, ok := mg.(interface {
+		ResolveReferences(context.Context, client.Reader) error
+	})
+	if !ok {
+		// This managed resource doesn't have any references to resolve.
+		return nil
+	}
+
+	existing := mg.DeepCopyObject()
+	rec := &recorder{Reader: r.client}
+	if err := rr.ResolveReferences(ctx, rec); err != nil {
+		mg.SetConditions(AwaitingDependency(rec.last, err))
+		return errors.Wrap(err, errResolveReferences)
+	}
+
+	if !cmp.Equal(existing, mg) {
+		if err := r.client.Update(ctx, mg); err != nil {
+			return errors.Wrap(err, errUpdateManaged)
+		}
+	}
+	mg.SetConditions(Resolved())
+	return nil
+}
+
+// A recorder records the last managed resource that was read through it.
+// References are resolved one at a time, and resolution stops at the first
+// reference that cannot be resolved, so the last managed resource that was
+// read is the one that could not be resolved.
+type recorder struct {
+	client.Reader
+	last string
+}
+
+func (r *recorder) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	r.last = fmt.Sprintf("%s %s", kind(obj), key.Name)
+	return r.Reader.Get(ctx, key, obj)
+}
+
+func (r *recorder) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	r.last = fmt.Sprintf("%s matching selector", strings.TrimSuffix(kind(list), "List"))
+	return r.Reader.List(ctx, list, opts...)
+}
+
+func kind(obj interface{}) string {
+	t := reflect.TypeOf(obj)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name()
+}
+
+// BecameAvailable returns a predicate that accepts the events of managed
+// resources that became available, i.e. whose Ready condition became True.
+func BecameAvailable() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc:  func(ev event.CreateEvent) bool { return available(ev.Object) },
+		UpdateFunc:  func(ev event.UpdateEvent) bool { return available(ev.ObjectNew) && !available(ev.ObjectOld) },
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	}
+}
+
+func available(o client.Object) bool {
+	c, ok := o.(resource.Conditioned)
+	return ok && c.GetCondition(xpv1.TypeReady).Status == corev1.ConditionTrue
+}
+
+// EnqueueRequestsForDependents returns an event handler that enqueues a
+// request for every managed resource of the kind of the supplied list that
+// depends on the managed resource of an event. Managed resources that wait
+// for a dependency are otherwise only requeued with backoff or at their next
+// poll, so they would be reconciled long after the dependency became ready.
+// Use it with the BecameAvailable predicate to reconcile them the moment the
+// dependency becomes available.
+func EnqueueRequestsForDependents(c client.Reader, l resource.ManagedList, log logging.Logger) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(o client.Object) []reconcile.Request {
+		reqs, err := Dependents(context.Background(), c, l.DeepCopyObject().(resource.ManagedList), o.GetName())
+		if err != nil {
+			log.Info("Cannot requeue managed resources that depend on a managed resource", "error", err, "name", o.GetName())
+		}
+		return reqs
+	})
+}
+
+// Dependents returns a request for every managed resource of the kind of the
+// supplied list that is not yet available and depends on the managed resource
+// with the supplied name. A managed resource depends on the resources it
+// references, and on any resource it may select if it has not resolved its
+// references yet.
+func Dependents(ctx context.Context, c client.Reader, l resource.ManagedList, name string) ([]reconcile.Request, error) {
+	if err := c.List(ctx, l); err != nil {
+		return nil, err
+	}
+	reqs := make([]reconcile.Request, 0)
+	for _, mg := range l.GetItems() {
+		if available(mg) || meta.WasDeleted(mg) {
+			continue
+		}
+		names, selects := References(mg)
+		resolved := mg.GetCondition(TypeReferencesResolved).Status == corev1.ConditionTrue
+		if names[name] || (selects && !resolved) {
+			reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: mg.GetName()}})
+		}
+	}
+	return reqs, nil
+}
+
+var (
+	referenceType = reflect.TypeOf(xpv1.Reference{})
+	selectorType  = reflect.TypeOf(xpv1.Selector{})
+)
+
+// References returns the names of the managed resources the spec of the
+// supplied managed resource references, and whether it selects any. The
+// provider and ProviderConfig references of the spec are ignored.
+func References(mg resource.Managed) (names map[string]bool, selects bool) {
+	names = map[string]bool{}
+	spec := reflect.ValueOf(mg).Elem().FieldByName("Spec")
+	if !spec.IsValid() {
+		return names, false
+	}
+	var walk func(v reflect.Value)
+	walk = func(v reflect.Value) {
+		switch v.Kind() { // nolint:exhaustive
+		case reflect.Ptr:
+			if !v.IsNil() {
+				walk(v.Elem())
+			}
+		case reflect.Slice:
+			for i := 0; i < v.Len(); i++ {
+				walk(v.Index(i))
+			}
+		case reflect.Struct:
+			switch v.Type() {
+			case referenceType:
+				names[v.Interface().(xpv1.Reference).Name] = true
+				return
+			case selectorType:
+				selects = true
+				return
+			}
+			for i := 0; i < v.NumField(); i++ {
+				if f := v.Type().Field(i); f.PkgPath != "" || isProviderReference(f.Name) {
+					continue
+				}
+				walk(v.Field(i))
+			}
+		}
+	}
+	walk(spec)
+	return names, selects
+}
+
+func isProviderReference(field string) bool {
+	return field == "ProviderReference" || field == "ProviderConfigReference"
+}
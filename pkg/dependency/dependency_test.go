@@ -0,0 +1,261 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dependency
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/network/v1beta1"
+)
+
+func subnet() *v1beta1.Subnet {
+	return &v1beta1.Subnet{
+		ObjectMeta: metav1.ObjectMeta{Name: "cool-subnet"},
+		Spec: v1beta1.SubnetSpec{
+			ForProvider: v1beta1.SubnetParameters{
+				ResourceGroupName:     "cool-rg",
+				VirtualNetworkNameRef: &xpv1.Reference{Name: "cool-vnet"},
+			},
+		},
+	}
+}
+
+func TestReferenceResolver(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		cd  xpv1.Condition
+		err error
+	}
+
+	cases := map[string]struct {
+		c    client.Client
+		want want
+	}{
+		"AwaitingDependency": {
+			c: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			want: want{
+				cd:  AwaitingDependency("VirtualNetwork cool-vnet", errors.Wrap(errors.Wrap(errBoom, "cannot get referenced resource"), "spec.forProvider.virtualNetworkName")),
+				err: errors.Wrap(errors.Wrap(errors.Wrap(errBoom, "cannot get referenced resource"), "spec.forProvider.virtualNetworkName"), errResolveReferences),
+			},
+		},
+		"Resolved": {
+			c: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+					meta.SetExternalName(o, "cool-vnet-external")
+					return nil
+				}),
+				MockUpdate: test.NewMockUpdateFn(nil),
+			},
+			want: want{cd: Resolved()},
+		},
+		"UpdateError": {
+			c: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+					meta.SetExternalName(o, "cool-vnet-external")
+					return nil
+				}),
+				MockUpdate: test.NewMockUpdateFn(errBoom),
+			},
+			// The resolved references were not persisted, so no condition is
+			// set and the condition is reported as unknown.
+			want: want{
+				cd:  xpv1.Condition{Type: TypeReferencesResolved, Status: corev1.ConditionUnknown},
+				err: errors.Wrap(errBoom, errUpdateManaged),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := subnet()
+			err := NewReferenceResolver(tc.c).ResolveReferences(context.Background(), mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("ResolveReferences(...): -want error, +got error:\n%s", diff)
+			}
+			if got := mg.GetCondition(TypeReferencesResolved); !got.Equal(tc.want.cd) {
+				t.Errorf("ResolveReferences(...): want condition %v, got %v", tc.want.cd, got)
+			}
+		})
+	}
+}
+
+func TestBecameAvailable(t *testing.T) {
+	unavailable := subnet()
+	unavailable.SetConditions(xpv1.Creating())
+	available := subnet()
+	available.SetConditions(xpv1.Available())
+
+	cases := map[string]struct {
+		ev   interface{}
+		want bool
+	}{
+		"CreatedAvailable": {
+			ev:   event.CreateEvent{Object: available},
+			want: true,
+		},
+		"CreatedUnavailable": {
+			ev:   event.CreateEvent{Object: unavailable},
+			want: false,
+		},
+		"BecameAvailable": {
+			ev:   event.UpdateEvent{ObjectOld: unavailable, ObjectNew: available},
+			want: true,
+		},
+		"StillAvailable": {
+			ev:   event.UpdateEvent{ObjectOld: available, ObjectNew: available},
+			want: false,
+		},
+		"BecameUnavailable": {
+			ev:   event.UpdateEvent{ObjectOld: available, ObjectNew: unavailable},
+			want: false,
+		},
+		"Deleted": {
+			ev:   event.DeleteEvent{Object: available},
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := BecameAvailable()
+			var got bool
+			switch ev := tc.ev.(type) {
+			case event.CreateEvent:
+				got = p.Create(ev)
+			case event.UpdateEvent:
+				got = p.Update(ev)
+			case event.DeleteEvent:
+				got = p.Delete(ev)
+			}
+			if got != tc.want {
+				t.Errorf("BecameAvailable(): want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestDependents(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	references := subnet()
+	available := subnet()
+	available.SetName("available-subnet")
+	available.SetConditions(xpv1.Available())
+	deleted := subnet()
+	deleted.SetName("deleted-subnet")
+	now := metav1.Now()
+	deleted.SetDeletionTimestamp(&now)
+	other := subnet()
+	other.SetName("other-subnet")
+	other.Spec.ForProvider.VirtualNetworkNameRef = &xpv1.Reference{Name: "other-vnet"}
+	selects := subnet()
+	selects.SetName("selecting-subnet")
+	selects.Spec.ForProvider.VirtualNetworkNameRef = nil
+	selects.Spec.ForProvider.VirtualNetworkNameSelector = &xpv1.Selector{MatchLabels: map[string]string{"cool": "true"}}
+	selects.SetConditions(AwaitingDependency("VirtualNetwork matching selector", errBoom))
+	selected := selects.DeepCopy()
+	selected.SetName("selected-subnet")
+	selected.Spec.ForProvider.VirtualNetworkName = "other-vnet"
+	selected.SetConditions(Resolved())
+
+	type want struct {
+		reqs []reconcile.Request
+		err  error
+	}
+
+	cases := map[string]struct {
+		c    client.Reader
+		want want
+	}{
+		"Dependents": {
+			c: &test.MockClient{MockList: func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+				obj.(*v1beta1.SubnetList).Items = []v1beta1.Subnet{*references, *available, *deleted, *other, *selects, *selected}
+				return nil
+			}},
+			want: want{reqs: []reconcile.Request{
+				{NamespacedName: types.NamespacedName{Name: references.GetName()}},
+				{NamespacedName: types.NamespacedName{Name: selects.GetName()}},
+			}},
+		},
+		"ListError": {
+			c:    &test.MockClient{MockList: test.NewMockListFn(errBoom)},
+			want: want{err: errBoom},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			reqs, err := Dependents(context.Background(), tc.c, &v1beta1.SubnetList{}, "cool-vnet")
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Dependents(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.reqs, reqs); diff != "" {
+				t.Errorf("Dependents(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReferences(t *testing.T) {
+	selects := subnet()
+	selects.Spec.ForProvider.VirtualNetworkNameRef = nil
+	selects.Spec.ForProvider.VirtualNetworkNameSelector = &xpv1.Selector{}
+	selects.Spec.ProviderConfigReference = &xpv1.Reference{Name: "default"}
+
+	cases := map[string]struct {
+		mg      *v1beta1.Subnet
+		names   map[string]bool
+		selects bool
+	}{
+		"References": {
+			mg:    subnet(),
+			names: map[string]bool{"cool-vnet": true},
+		},
+		"Selects": {
+			mg:      selects,
+			names:   map[string]bool{},
+			selects: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			names, selects := References(tc.mg)
+			if diff := cmp.Diff(tc.names, names); diff != "" {
+				t.Errorf("References(...): -want names, +got names:\n%s", diff)
+			}
+			if selects != tc.selects {
+				t.Errorf("References(...): want selects %t, got %t", tc.selects, selects)
+			}
+		})
+	}
+}
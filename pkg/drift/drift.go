@@ -0,0 +1,108 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package drift reports the changes made to Azure resources outside of
+// Crossplane, e.g. in the Azure portal, before their controller overwrites
+// them with the spec of their managed resource.
+package drift
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// FieldPath is the path of the status field to which the clients of managed
+// resources write the paths of the spec fields that differ from their Azure
+// resource.
+const FieldPath = "status.drift"
+
+// ReasonDetected is the reason of the events that report a drift.
+const ReasonDetected event.Reason = "DriftDetected"
+
+// Error strings.
+const (
+	errDrift = "external resource differs from the spec at %s"
+)
+
+// Of returns the drift the supplied managed resource reports in its status,
+// if any.
+func Of(mg resource.Managed) []string {
+	p, err := fieldpath.PaveObject(mg)
+	if err != nil {
+		return nil
+	}
+	d, _ := p.GetStringArray(FieldPath)
+	return d
+}
+
+// NewConnecter returns an ExternalConnecter whose clients record an event
+// whenever the drift a managed resource reports in its status changes to one
+// that is not empty.
+func NewConnecter(c managed.ExternalConnecter, r event.Recorder) managed.ExternalConnecter {
+	return &connecter{ExternalConnecter: c, record: r}
+}
+
+type connecter struct {
+	managed.ExternalConnecter
+	record event.Recorder
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	e, err := c.ExternalConnecter.Connect(ctx, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &external{ExternalClient: e, record: c.record}, nil
+}
+
+type external struct {
+	managed.ExternalClient
+	record event.Recorder
+}
+
+// Observe the external resource of the supplied managed resource. A drift is
+// only recorded once rather than at every observation, since it persists
+// until the external resource is updated.
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	before := Of(mg)
+	o, err := e.ExternalClient.Observe(ctx, mg)
+	if err != nil {
+		return o, err
+	}
+	if d := Of(mg); len(d) > 0 && !equal(before, d) {
+		e.record.Event(mg, event.Warning(ReasonDetected, errors.Errorf(errDrift, strings.Join(d, ", "))))
+	}
+	return o, nil
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drift
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/network/v1beta1"
+)
+
+var errBoom = errors.New("boom")
+
+type recorder struct {
+	events []event.Event
+}
+
+func (r *recorder) Event(_ runtime.Object, e event.Event) { r.events = append(r.events, e) }
+
+func (r *recorder) WithAnnotations(_ ...string) event.Recorder { return r }
+
+func subnet(drift ...string) *v1beta1.Subnet {
+	return &v1beta1.Subnet{Status: v1beta1.SubnetStatus{Drift: drift}}
+}
+
+// observing returns a client whose observations report the supplied drift.
+func observing(err error, drift ...string) managed.ExternalClient {
+	return &managed.ExternalClientFns{
+		ObserveFn: func(_ context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+			mg.(*v1beta1.Subnet).Status.Drift = drift
+			return managed.ExternalObservation{ResourceExists: true}, err
+		},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		events []event.Event
+		err    error
+	}
+
+	cases := map[string]struct {
+		e    managed.ExternalClient
+		mg   resource.Managed
+		want want
+	}{
+		"NoDrift": {
+			e:  observing(nil),
+			mg: subnet(),
+		},
+		"NewDrift": {
+			e:  observing(nil, "spec.forProvider.addressPrefix"),
+			mg: subnet(),
+			want: want{events: []event.Event{
+				event.Warning(ReasonDetected, errors.Errorf(errDrift, "spec.forProvider.addressPrefix")),
+			}},
+		},
+		"ChangedDrift": {
+			e:  observing(nil, "spec.forProvider.addressPrefix", "spec.forProvider.tags"),
+			mg: subnet("spec.forProvider.addressPrefix"),
+			want: want{events: []event.Event{
+				event.Warning(ReasonDetected, errors.Errorf(errDrift, "spec.forProvider.addressPrefix, spec.forProvider.tags")),
+			}},
+		},
+		"SameDrift": {
+			e:  observing(nil, "spec.forProvider.addressPrefix"),
+			mg: subnet("spec.forProvider.addressPrefix"),
+		},
+		"ObserveError": {
+			e:    observing(errBoom, "spec.forProvider.addressPrefix"),
+			mg:   subnet(),
+			want: want{err: errBoom},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &recorder{}
+			e := &external{ExternalClient: tc.e, record: r}
+			_, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.events, r.events); diff != "" {
+				t.Errorf("Observe(...): -want events, +got events:\n%s", diff)
+			}
+		})
+	}
+}
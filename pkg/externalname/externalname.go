@@ -0,0 +1,204 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package externalname lets managed resources adopt existing Azure resources
+// by their full Azure Resource Manager ID. Every controller observes the Azure
+// resource named by the crossplane.io/external-name annotation of a managed
+// resource before it tries to create one, so an existing resource is adopted
+// rather than recreated. The annotation may be the name of the Azure resource,
+// or its ID, e.g. as copied from the Azure portal.
+package externalname
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	cognitiveservicesv1alpha1 "github.com/crossplane/provider-azure/apis/cognitiveservices/v1alpha1"
+	computev1alpha3 "github.com/crossplane/provider-azure/apis/compute/v1alpha3"
+	databasev1alpha3 "github.com/crossplane/provider-azure/apis/database/v1alpha3"
+	eventhubv1alpha1 "github.com/crossplane/provider-azure/apis/eventhub/v1alpha1"
+	kustov1alpha1 "github.com/crossplane/provider-azure/apis/kusto/v1alpha1"
+	monitorv1alpha1 "github.com/crossplane/provider-azure/apis/monitor/v1alpha1"
+	networkv1beta1 "github.com/crossplane/provider-azure/apis/network/v1beta1"
+	storagesyncv1alpha1 "github.com/crossplane/provider-azure/apis/storagesync/v1alpha1"
+	"github.com/crossplane/provider-azure/pkg/defaults"
+)
+
+// Error strings.
+const (
+	errInvalidIDFmt  = "external name %q is not a valid Azure resource ID"
+	errMismatchFmt   = "%s %q of the Azure resource ID of the external name does not match %q"
+	errUpdateManaged = "cannot update managed resource with the name of its Azure resource ID"
+)
+
+// An ID of an Azure resource.
+type ID struct {
+	SubscriptionID string
+	ResourceGroup  string
+
+	// Parent is the name of the parent of a child resource, e.g. the
+	// virtual network of a subnet. It is empty for other resources.
+	Parent string
+
+	// Name of the resource. It is the name of the resource group if the ID is
+	// that of a resource group.
+	Name string
+}
+
+// IsID returns true if the supplied external name is an Azure resource ID
+// rather than the name of an Azure resource.
+func IsID(externalName string) bool {
+	return strings.HasPrefix(strings.ToLower(externalName), "/subscriptions/")
+}
+
+// ParseID parses the Azure resource ID of a resource group, a resource, or a
+// child resource.
+func ParseID(id string) (ID, error) {
+	s := strings.Split(strings.Trim(id, "/"), "/")
+	if len(s) < 4 || !strings.EqualFold(s[0], "subscriptions") || !strings.EqualFold(s[2], "resourceGroups") {
+		return ID{}, errors.Errorf(errInvalidIDFmt, id)
+	}
+	r := ID{SubscriptionID: s[1], ResourceGroup: s[3]}
+	if len(s) == 4 {
+		r.Name = s[3]
+		return r, nil
+	}
+
+	// The provider namespace is followed by pairs of resource types and names.
+	pairs := s[6:]
+	if len(s) < 8 || !strings.EqualFold(s[4], "providers") || len(pairs)%2 != 0 {
+		return ID{}, errors.Errorf(errInvalidIDFmt, id)
+	}
+	r.Name = pairs[len(pairs)-1]
+	if len(pairs) >= 4 {
+		r.Parent = pairs[len(pairs)-3]
+	}
+	for _, n := range []string{r.SubscriptionID, r.ResourceGroup, r.Name} {
+		if n == "" {
+			return ID{}, errors.Errorf(errInvalidIDFmt, id)
+		}
+	}
+	return r, nil
+}
+
+// ResourceGroupOf returns the resource group name field of the supplied
+// managed resource, or nil if it has none.
+func ResourceGroupOf(mg resource.Managed) *string {
+	rg, _ := defaults.ResourceGroupOf(mg)
+	return rg
+}
+
+// ParentOf returns the field of the supplied managed resource that names the
+// parent of its Azure resource, or nil if it is not a child resource.
+func ParentOf(mg resource.Managed) *string {
+	switch cr := mg.(type) {
+	case *networkv1beta1.Subnet:
+		return &cr.Spec.ForProvider.VirtualNetworkName
+	case *cognitiveservicesv1alpha1.OpenAIDeployment:
+		return &cr.Spec.ForProvider.AccountName
+	case *computev1alpha3.DedicatedHost:
+		return &cr.Spec.ForProvider.HostGroupName
+	case *computev1alpha3.CapacityReservation:
+		return &cr.Spec.ForProvider.CapacityReservationGroupName
+	case *databasev1alpha3.MySQLServerFirewallRule:
+		return &cr.Spec.ForProvider.ServerName
+	case *databasev1alpha3.PostgreSQLServerFirewallRule:
+		return &cr.Spec.ForProvider.ServerName
+	case *databasev1alpha3.MySQLServerVirtualNetworkRule:
+		return &cr.Spec.ServerName
+	case *databasev1alpha3.PostgreSQLServerVirtualNetworkRule:
+		return &cr.Spec.ServerName
+	case *eventhubv1alpha1.SchemaRegistryGroup:
+		return &cr.Spec.ForProvider.NamespaceName
+	case *kustov1alpha1.KustoDatabase:
+		return &cr.Spec.ForProvider.ClusterName
+	case *kustov1alpha1.KustoScript:
+		return &cr.Spec.ForProvider.DatabaseName
+	case *monitorv1alpha1.AzureMonitorPrivateLinkScopedResource:
+		return &cr.Spec.ForProvider.PrivateLinkScopeName
+	case *storagesyncv1alpha1.SyncGroup:
+		return &cr.Spec.ForProvider.StorageSyncServiceName
+	case *storagesyncv1alpha1.CloudEndpoint:
+		return &cr.Spec.ForProvider.SyncGroupName
+	}
+	return nil
+}
+
+// set the supplied field to the supplied value of an ID if it is empty. An ID
+// that contradicts a field that is set is an error.
+func set(field *string, value, what string) error {
+	if field == nil || value == "" {
+		return nil
+	}
+	if *field == "" {
+		*field = value
+		return nil
+	}
+	if !strings.EqualFold(*field, value) {
+		return errors.Errorf(errMismatchFmt, what, value, *field)
+	}
+	return nil
+}
+
+// Apply the Azure resource ID of the external name of the supplied managed
+// resource, if it is one. The external name becomes the name of the Azure
+// resource, and the resource group and parent the managed resource omits are
+// taken from the ID. It returns true if the resource changed.
+func Apply(mg resource.Managed) (bool, error) {
+	en := meta.GetExternalName(mg)
+	if !IsID(en) {
+		return false, nil
+	}
+	id, err := ParseID(en)
+	if err != nil {
+		return false, err
+	}
+	if err := set(ResourceGroupOf(mg), id.ResourceGroup, "resource group"); err != nil {
+		return false, err
+	}
+	if err := set(ParentOf(mg), id.Parent, "parent"); err != nil {
+		return false, err
+	}
+	meta.SetExternalName(mg, id.Name)
+	return true, nil
+}
+
+// An Initializer replaces an external name that is an Azure resource ID with
+// the name of the resource it identifies, so that the controller adopts the
+// existing Azure resource.
+type Initializer struct {
+	client client.Client
+}
+
+// NewInitializer returns a new Initializer.
+func NewInitializer(c client.Client) *Initializer {
+	return &Initializer{client: c}
+}
+
+// Initialize the external name of the supplied managed resource.
+func (i *Initializer) Initialize(ctx context.Context, mg resource.Managed) error {
+	changed, err := Apply(mg)
+	if err != nil || !changed {
+		return err
+	}
+	return errors.Wrap(i.client.Update(ctx, mg), errUpdateManaged)
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalname
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	cachev1beta1 "github.com/crossplane/provider-azure/apis/cache/v1beta1"
+	networkv1beta1 "github.com/crossplane/provider-azure/apis/network/v1beta1"
+	"github.com/crossplane/provider-azure/apis/v1alpha3"
+)
+
+const (
+	subnetID = "/subscriptions/cool-sub/resourceGroups/cool-rg/providers/Microsoft.Network/virtualNetworks/cool-vnet/subnets/cool-subnet"
+	redisID  = "/subscriptions/cool-sub/resourceGroups/cool-rg/providers/Microsoft.Cache/Redis/cool-redis"
+	groupID  = "/subscriptions/cool-sub/resourceGroups/cool-rg"
+)
+
+func TestParseID(t *testing.T) {
+	type want struct {
+		id  ID
+		err error
+	}
+
+	cases := map[string]struct {
+		id   string
+		want want
+	}{
+		"ResourceGroup": {
+			id:   groupID,
+			want: want{id: ID{SubscriptionID: "cool-sub", ResourceGroup: "cool-rg", Name: "cool-rg"}},
+		},
+		"Resource": {
+			id:   redisID,
+			want: want{id: ID{SubscriptionID: "cool-sub", ResourceGroup: "cool-rg", Name: "cool-redis"}},
+		},
+		"ChildResource": {
+			id:   subnetID,
+			want: want{id: ID{SubscriptionID: "cool-sub", ResourceGroup: "cool-rg", Parent: "cool-vnet", Name: "cool-subnet"}},
+		},
+		"LowerCase": {
+			id:   "/subscriptions/cool-sub/resourcegroups/cool-rg/providers/microsoft.cache/redis/cool-redis",
+			want: want{id: ID{SubscriptionID: "cool-sub", ResourceGroup: "cool-rg", Name: "cool-redis"}},
+		},
+		"NoName": {
+			id:   "/subscriptions/cool-sub/resourceGroups/cool-rg/providers/Microsoft.Cache/Redis",
+			want: want{err: errors.Errorf(errInvalidIDFmt, "/subscriptions/cool-sub/resourceGroups/cool-rg/providers/Microsoft.Cache/Redis")},
+		},
+		"NoResourceGroup": {
+			id:   "/subscriptions/cool-sub",
+			want: want{err: errors.Errorf(errInvalidIDFmt, "/subscriptions/cool-sub")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			id, err := ParseID(tc.id)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("ParseID(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.id, id); diff != "" {
+				t.Errorf("ParseID(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func withExternalName(mg resource.Managed, name string) resource.Managed {
+	meta.SetExternalName(mg, name)
+	return mg
+}
+
+func TestInitialize(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type args struct {
+		c  client.Client
+		mg resource.Managed
+	}
+	type want struct {
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Name": {
+			args: args{
+				mg: withExternalName(&cachev1beta1.Redis{}, "cool-redis"),
+			},
+			want: want{mg: withExternalName(&cachev1beta1.Redis{}, "cool-redis")},
+		},
+		"Resource": {
+			args: args{
+				c:  &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+				mg: withExternalName(&cachev1beta1.Redis{}, redisID),
+			},
+			want: want{mg: withExternalName(&cachev1beta1.Redis{
+				Spec: cachev1beta1.RedisSpec{ForProvider: cachev1beta1.RedisParameters{ResourceGroupName: "cool-rg"}},
+			}, "cool-redis")},
+		},
+		"ChildResource": {
+			args: args{
+				c:  &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+				mg: withExternalName(&networkv1beta1.Subnet{}, subnetID),
+			},
+			want: want{mg: withExternalName(&networkv1beta1.Subnet{
+				Spec: networkv1beta1.SubnetSpec{ForProvider: networkv1beta1.SubnetParameters{ResourceGroupName: "cool-rg", VirtualNetworkName: "cool-vnet"}},
+			}, "cool-subnet")},
+		},
+		"ResourceGroup": {
+			args: args{
+				c:  &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+				mg: withExternalName(&v1alpha3.ResourceGroup{}, groupID),
+			},
+			want: want{mg: withExternalName(&v1alpha3.ResourceGroup{}, "cool-rg")},
+		},
+		"ResourceGroupMismatch": {
+			args: args{
+				mg: withExternalName(&networkv1beta1.Subnet{
+					Spec: networkv1beta1.SubnetSpec{ForProvider: networkv1beta1.SubnetParameters{ResourceGroupName: "other-rg"}},
+				}, subnetID),
+			},
+			want: want{
+				mg: withExternalName(&networkv1beta1.Subnet{
+					Spec: networkv1beta1.SubnetSpec{ForProvider: networkv1beta1.SubnetParameters{ResourceGroupName: "other-rg"}},
+				}, subnetID),
+				err: errors.Errorf(errMismatchFmt, "resource group", "cool-rg", "other-rg"),
+			},
+		},
+		"UpdateError": {
+			args: args{
+				c:  &test.MockClient{MockUpdate: test.NewMockUpdateFn(errBoom)},
+				mg: withExternalName(&v1alpha3.ResourceGroup{}, groupID),
+			},
+			want: want{
+				mg:  withExternalName(&v1alpha3.ResourceGroup{}, "cool-rg"),
+				err: errors.Wrap(errBoom, errUpdateManaged),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := NewInitializer(tc.args.c).Initialize(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Initialize(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg); diff != "" {
+				t.Errorf("Initialize(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
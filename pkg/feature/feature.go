@@ -0,0 +1,161 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package feature gates the optional features of the provider that call Azure
+// APIs beyond those of the resources it manages, so that the core reconcilers
+// keep working in subscriptions where those APIs are blocked, e.g. by an Azure
+// Policy or a custom role.
+package feature
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+// A Feature of the provider that calls Azure APIs it does not strictly need.
+type Feature string
+
+// Optional features.
+const (
+	// ManagementLocks explains the failures of operations that are rejected
+	// by a management lock with the locks that caused them.
+	ManagementLocks Feature = "management-locks"
+
+	// QuotaUsage records the usage of the quotas watched by ProviderConfigs.
+	QuotaUsage Feature = "quota-usage"
+
+	// SKUCatalog populates AzureSKUCatalogs.
+	SKUCatalog Feature = "sku-catalog"
+)
+
+// Features that can be disabled.
+var Features = []Feature{ManagementLocks, QuotaUsage, SKUCatalog}
+
+// DefaultBackoff is the duration for which a feature is unavailable after
+// Azure rejected one of its requests as forbidden.
+const DefaultBackoff = 1 * time.Hour
+
+// Error strings.
+const (
+	errUnknown = "unknown feature %q, must be one of %s"
+)
+
+// DefaultGate is the Gate of the provider. It is configured by the flags of
+// the provider at startup.
+var DefaultGate = NewGate()
+
+// A Gate tracks which features are enabled. Features are either disabled
+// explicitly, or are unavailable for a while once Azure rejects one of their
+// requests as forbidden.
+type Gate struct {
+	// Backoff is the duration for which a feature is unavailable once it
+	// was forbidden.
+	Backoff time.Duration
+
+	Log logging.Logger
+
+	mu          sync.Mutex
+	disabled    map[Feature]bool
+	unavailable map[Feature]time.Time
+	now         func() time.Time
+}
+
+// NewGate returns a Gate with all features enabled.
+func NewGate() *Gate {
+	return &Gate{
+		Backoff:     DefaultBackoff,
+		Log:         logging.NewNopLogger(),
+		disabled:    make(map[Feature]bool),
+		unavailable: make(map[Feature]time.Time),
+		now:         time.Now,
+	}
+}
+
+// Parse a comma separated list of features, e.g. quota-usage,sku-catalog.
+func Parse(s string) ([]Feature, error) {
+	known := make(map[Feature]bool, len(Features))
+	names := make([]string, 0, len(Features))
+	for _, f := range Features {
+		known[f] = true
+		names = append(names, string(f))
+	}
+	sort.Strings(names)
+
+	out := make([]Feature, 0)
+	for _, n := range strings.Split(s, ",") {
+		f := Feature(strings.TrimSpace(n))
+		if f == "" {
+			continue
+		}
+		if !known[f] {
+			return nil, errors.Errorf(errUnknown, f, strings.Join(names, ", "))
+		}
+		out = append(out, f)
+	}
+	return out, nil
+}
+
+// Disable the supplied features.
+func (g *Gate) Disable(f ...Feature) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for _, ft := range f {
+		g.disabled[ft] = true
+	}
+}
+
+// Enabled returns true if the supplied feature is neither disabled nor
+// unavailable.
+func (g *Gate) Enabled(f Feature) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.disabled[f] {
+		return false
+	}
+	until, ok := g.unavailable[f]
+	if !ok {
+		return true
+	}
+	if g.now().Before(until) {
+		return false
+	}
+	delete(g.unavailable, f)
+	return true
+}
+
+// Observe the supplied error of a request the supplied feature sent to Azure.
+// The feature is unavailable for the Backoff of the Gate if Azure rejected the
+// request as forbidden, in which case Observe returns true.
+func (g *Gate) Observe(f Feature, err error) bool {
+	if !azure.IsForbidden(err) {
+		return false
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if _, ok := g.unavailable[f]; !ok {
+		g.Log.Info("Disabling feature whose requests Azure forbids", "feature", f, "backoff", g.Backoff.String(), "error", err)
+	}
+	g.unavailable[f] = g.now().Add(g.Backoff)
+	return true
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package feature
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestParse(t *testing.T) {
+	type want struct {
+		f   []Feature
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		s      string
+		want   want
+	}{
+		"Empty": {
+			reason: "No features should be parsed from an empty string.",
+			s:      "",
+			want:   want{f: []Feature{}},
+		},
+		"Features": {
+			reason: "Comma separated features should be parsed, ignoring whitespace.",
+			s:      "quota-usage, sku-catalog",
+			want:   want{f: []Feature{QuotaUsage, SKUCatalog}},
+		},
+		"Unknown": {
+			reason: "An unknown feature should be rejected.",
+			s:      "advisor",
+			want:   want{err: errors.Errorf(errUnknown, "advisor", "management-locks, quota-usage, sku-catalog")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			f, err := Parse(tc.s)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nParse(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.f, f); diff != "" {
+				t.Errorf("\n%s\nParse(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestEnabled(t *testing.T) {
+	now := time.Now()
+	forbidden := autorest.DetailedError{StatusCode: http.StatusForbidden}
+
+	cases := map[string]struct {
+		reason string
+		gate   func() *Gate
+		at     time.Time
+		want   bool
+	}{
+		"Enabled": {
+			reason: "Features should be enabled by default.",
+			gate:   NewGate,
+			at:     now,
+			want:   true,
+		},
+		"Disabled": {
+			reason: "A disabled feature should not be enabled.",
+			gate: func() *Gate {
+				g := NewGate()
+				g.Disable(QuotaUsage)
+				return g
+			},
+			at:   now,
+			want: false,
+		},
+		"OtherError": {
+			reason: "A feature should stay enabled if Azure rejects its requests for reasons other than authorization.",
+			gate: func() *Gate {
+				g := NewGate()
+				g.Observe(QuotaUsage, autorest.DetailedError{StatusCode: http.StatusInternalServerError})
+				return g
+			},
+			at:   now,
+			want: true,
+		},
+		"Forbidden": {
+			reason: "A feature should be unavailable during the backoff once Azure forbids one of its requests.",
+			gate: func() *Gate {
+				g := NewGate()
+				g.now = func() time.Time { return now }
+				g.Observe(QuotaUsage, errors.Wrap(forbidden, "cannot list quota usage"))
+				return g
+			},
+			at:   now.Add(DefaultBackoff / 2),
+			want: false,
+		},
+		"BackoffElapsed": {
+			reason: "A feature should be enabled again once its backoff elapsed.",
+			gate: func() *Gate {
+				g := NewGate()
+				g.now = func() time.Time { return now }
+				g.Observe(QuotaUsage, forbidden)
+				return g
+			},
+			at:   now.Add(DefaultBackoff),
+			want: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			g := tc.gate()
+			g.now = func() time.Time { return tc.at }
+			if diff := cmp.Diff(tc.want, g.Enabled(QuotaUsage)); diff != "" {
+				t.Errorf("\n%s\nEnabled(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
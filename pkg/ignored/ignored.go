@@ -0,0 +1,245 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ignored warns about the spec fields of managed resources that do
+// nothing, rather than let a setting be silently ignored. A field does
+// nothing if the API version the provider was built with does not define it,
+// e.g. because the installed CRD is newer than the provider, or if the API
+// server pruned it, e.g. because the installed CRD is older than the
+// manifest or the field is misspelled.
+package ignored
+
+import (
+	"context"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// WebhookPath is the path at which the validating webhook is served.
+const WebhookPath = "/warn-ignored-fields"
+
+// Error and warning strings.
+const (
+	errDecode    = "cannot decode object"
+	errDecodeOld = "cannot decode old object"
+
+	warnUnknown = "%s is not supported by this version of the provider and is ignored"
+	warnPruned  = "%s is not defined by the installed CustomResourceDefinition and was dropped"
+)
+
+var (
+	jsonUnmarshaler = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// Unknown returns the paths of the fields of the supplied JSON object that
+// the supplied Go type does not define, and that would thus be dropped when
+// the object is decoded into it. Types that decode themselves, e.g.
+// metav1.Time, and fields of arbitrary JSON are not inspected.
+func Unknown(obj map[string]interface{}, t reflect.Type) []string {
+	return unknown("", obj, t)
+}
+
+func unknown(path string, v interface{}, t reflect.Type) []string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if reflect.PtrTo(t).Implements(jsonUnmarshaler) || reflect.PtrTo(t).Implements(textUnmarshaler) {
+		return nil
+	}
+	var paths []string
+	switch v := v.(type) {
+	case map[string]interface{}:
+		switch t.Kind() {
+		case reflect.Struct:
+			fields := jsonFields(t)
+			for _, k := range keys(v) {
+				ft, ok := fields[k]
+				if !ok {
+					paths = append(paths, child(path, k))
+					continue
+				}
+				paths = append(paths, unknown(child(path, k), v[k], ft)...)
+			}
+		case reflect.Map:
+			for _, k := range keys(v) {
+				paths = append(paths, unknown(fmt.Sprintf("%s[%s]", path, k), v[k], t.Elem())...)
+			}
+		}
+	case []interface{}:
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			for i := range v {
+				paths = append(paths, unknown(fmt.Sprintf("%s[%d]", path, i), v[i], t.Elem())...)
+			}
+		}
+	}
+	return paths
+}
+
+// jsonFields returns the types of the fields of the supplied struct by their
+// JSON names, including those of embedded structs that are inlined.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" && !f.Anonymous {
+			continue
+		}
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		switch {
+		case name == "-":
+			continue
+		case name == "" && f.Anonymous:
+			et := f.Type
+			if et.Kind() == reflect.Ptr {
+				et = et.Elem()
+			}
+			if et.Kind() == reflect.Struct {
+				for n, ft := range jsonFields(et) {
+					fields[n] = ft
+				}
+				continue
+			}
+			fields[f.Name] = f.Type
+		case name == "":
+			fields[f.Name] = f.Type
+		default:
+			fields[name] = f.Type
+		}
+	}
+	return fields
+}
+
+// Pruned returns the paths of the spec fields of the supplied applied
+// configuration that the supplied object does not have. Fields that were
+// applied as null are removed rather than pruned, and are not returned.
+func Pruned(applied, obj map[string]interface{}) []string {
+	return pruned("spec", applied["spec"], obj["spec"])
+}
+
+func pruned(path string, applied, obj interface{}) []string {
+	var paths []string
+	switch a := applied.(type) {
+	case map[string]interface{}:
+		o, _ := obj.(map[string]interface{})
+		for _, k := range keys(a) {
+			if a[k] == nil {
+				continue
+			}
+			ov, ok := o[k]
+			if !ok {
+				paths = append(paths, child(path, k))
+				continue
+			}
+			paths = append(paths, pruned(child(path, k), a[k], ov)...)
+		}
+	case []interface{}:
+		o, _ := obj.([]interface{})
+		for i := 0; i < len(a) && i < len(o); i++ {
+			paths = append(paths, pruned(fmt.Sprintf("%s[%d]", path, i), a[i], o[i])...)
+		}
+	}
+	return paths
+}
+
+func child(path, k string) string {
+	if path == "" {
+		return k
+	}
+	return path + "." + k
+}
+
+func keys(m map[string]interface{}) []string {
+	k := make([]string, 0, len(m))
+	for key := range m {
+		k = append(k, key)
+	}
+	sort.Strings(k)
+	return k
+}
+
+// A Warner is an admission handler that allows every managed resource, but
+// warns about the fields of those that are created or updated that do
+// nothing.
+type Warner struct {
+	scheme *runtime.Scheme
+}
+
+// NewWarner returns a Warner that looks up the Go types of the managed
+// resources it inspects in the supplied scheme.
+func NewWarner(s *runtime.Scheme) *Warner {
+	return &Warner{scheme: s}
+}
+
+// Handle the supplied admission request. The API server prunes the fields the
+// installed CRD does not define before it sends a request, so pruned fields
+// can only be found in the configuration kubectl apply records in an
+// annotation. The annotation is only compared when this request applied it,
+// since it is stale after any other update.
+func (w *Warner) Handle(_ context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return admission.Allowed("")
+	}
+	o, err := w.scheme.New(schema.GroupVersionKind{Group: req.Kind.Group, Version: req.Kind.Version, Kind: req.Kind.Kind})
+	if err != nil {
+		return admission.Allowed("")
+	}
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal(req.Object.Raw, &obj); err != nil {
+		return admission.Errored(http.StatusBadRequest, errors.Wrap(err, errDecode))
+	}
+	var warnings []string
+	for _, p := range Unknown(obj, reflect.TypeOf(o)) {
+		if strings.HasPrefix(p, "spec.") {
+			warnings = append(warnings, fmt.Sprintf(warnUnknown, p))
+		}
+	}
+	applied := lastApplied(obj)
+	if req.Operation == admissionv1.Update {
+		old := map[string]interface{}{}
+		if err := json.Unmarshal(req.OldObject.Raw, &old); err != nil {
+			return admission.Errored(http.StatusBadRequest, errors.Wrap(err, errDecodeOld))
+		}
+		if lastApplied(old) == applied {
+			applied = ""
+		}
+	}
+	a := map[string]interface{}{}
+	if applied != "" && json.Unmarshal([]byte(applied), &a) == nil {
+		for _, p := range Pruned(a, obj) {
+			warnings = append(warnings, fmt.Sprintf(warnPruned, p))
+		}
+	}
+	return admission.Allowed("").WithWarnings(warnings...)
+}
+
+func lastApplied(obj map[string]interface{}) string {
+	a, _, _ := unstructured.NestedString(obj, "metadata", "annotations", corev1.LastAppliedConfigAnnotation)
+	return a
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ignored
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	cachev1beta1 "github.com/crossplane/provider-azure/apis/cache/v1beta1"
+)
+
+// redis returns the JSON of a Redis whose spec has the supplied extra fields
+// for provider, and which was applied with the supplied configuration.
+func redis(applied string, extra map[string]interface{}) []byte {
+	fp := map[string]interface{}{
+		"resourceGroupName": "coolgroup",
+		"sku":               map[string]interface{}{"name": "Basic", "family": "C", "capacity": 0},
+		"tags":              map[string]interface{}{"team": "cool"},
+		"zones":             []interface{}{"1"},
+	}
+	for k, v := range extra {
+		fp[k] = v
+	}
+	md := map[string]interface{}{"name": "cool", "creationTimestamp": "2021-01-01T00:00:00Z"}
+	if applied != "" {
+		md["annotations"] = map[string]interface{}{"kubectl.kubernetes.io/last-applied-configuration": applied}
+	}
+	raw, _ := json.Marshal(map[string]interface{}{
+		"apiVersion": cachev1beta1.SchemeGroupVersion.String(),
+		"kind":       cachev1beta1.RedisKind,
+		"metadata":   md,
+		"spec": map[string]interface{}{
+			"forProvider":       fp,
+			"providerConfigRef": map[string]interface{}{"name": "default"},
+		},
+	})
+	return raw
+}
+
+func TestUnknown(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		raw    []byte
+		want   []string
+	}{
+		"AllKnown": {
+			reason: "An object whose fields are all defined by its Go type, including those of inlined and embedded structs, should have no unknown fields.",
+			raw:    redis("", nil),
+		},
+		"Unknown": {
+			reason: "The paths of fields that are not defined by the Go type should be returned, however deeply they are nested.",
+			raw: redis("", map[string]interface{}{
+				"publicNetworkAccess": "Disabled",
+				"sku":                 map[string]interface{}{"name": "Basic", "family": "C", "capacity": 0, "tier": "cool"},
+			}),
+			want: []string{"spec.forProvider.publicNetworkAccess", "spec.forProvider.sku.tier"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			obj := map[string]interface{}{}
+			if err := json.Unmarshal(tc.raw, &obj); err != nil {
+				t.Fatal(err)
+			}
+			got := Unknown(obj, reflect.TypeOf(&cachev1beta1.Redis{}))
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nUnknown(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestPruned(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		applied map[string]interface{}
+		obj     map[string]interface{}
+		want    []string
+	}{
+		"NotPruned": {
+			reason:  "Fields of the applied configuration that the object has should not be returned.",
+			applied: map[string]interface{}{"spec": map[string]interface{}{"forProvider": map[string]interface{}{"location": "westus"}}},
+			obj:     map[string]interface{}{"spec": map[string]interface{}{"forProvider": map[string]interface{}{"location": "westus", "sku": "Basic"}}},
+		},
+		"Pruned": {
+			reason: "Fields of the applied configuration that the object does not have should be returned.",
+			applied: map[string]interface{}{"spec": map[string]interface{}{"forProvider": map[string]interface{}{
+				"location": "westus",
+				"rules":    []interface{}{map[string]interface{}{"name": "a", "priority": 1}},
+			}}},
+			obj: map[string]interface{}{"spec": map[string]interface{}{"forProvider": map[string]interface{}{
+				"rules": []interface{}{map[string]interface{}{"name": "a"}},
+			}}},
+			want: []string{"spec.forProvider.location", "spec.forProvider.rules[0].priority"},
+		},
+		"Null": {
+			reason:  "Fields that were applied as null should not be returned.",
+			applied: map[string]interface{}{"spec": map[string]interface{}{"forProvider": map[string]interface{}{"location": nil}}},
+			obj:     map[string]interface{}{"spec": map[string]interface{}{"forProvider": map[string]interface{}{}}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := Pruned(tc.applied, tc.obj)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nPruned(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestHandle(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := cachev1beta1.SchemeBuilder.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	request := func(op admissionv1.Operation, old, obj []byte) admission.Request {
+		return admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: op,
+			Kind:      metav1.GroupVersionKind{Group: cachev1beta1.Group, Version: cachev1beta1.Version, Kind: cachev1beta1.RedisKind},
+			Object:    runtime.RawExtension{Raw: obj},
+			OldObject: runtime.RawExtension{Raw: old},
+		}}
+	}
+
+	applied := `{"spec":{"forProvider":{"resourceGroupName":"coolgroup","redisVersion":"6"}}}`
+
+	cases := map[string]struct {
+		reason string
+		req    admission.Request
+		want   []string
+	}{
+		"Delete": {
+			reason: "Deletes should be allowed without warnings.",
+			req:    request(admissionv1.Delete, nil, nil),
+		},
+		"NoIgnoredFields": {
+			reason: "Creates of resources whose fields all do something should be allowed without warnings.",
+			req:    request(admissionv1.Create, nil, redis("", nil)),
+		},
+		"Unknown": {
+			reason: "Fields the provider does not support should be warned about.",
+			req:    request(admissionv1.Create, nil, redis("", map[string]interface{}{"publicNetworkAccess": "Disabled"})),
+			want:   []string{fmt.Sprintf(warnUnknown, "spec.forProvider.publicNetworkAccess")},
+		},
+		"PrunedOnApply": {
+			reason: "Applied fields that were pruned should be warned about when they are applied.",
+			req:    request(admissionv1.Update, redis("", nil), redis(applied, nil)),
+			want:   []string{fmt.Sprintf(warnPruned, "spec.forProvider.redisVersion")},
+		},
+		"PrunedBefore": {
+			reason: "Applied fields that were pruned should not be warned about by updates that did not apply them.",
+			req:    request(admissionv1.Update, redis(applied, nil), redis(applied, nil)),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := NewWarner(s).Handle(context.Background(), tc.req)
+			if !got.Allowed {
+				t.Errorf("\n%s\nHandle(...): want allowed, got denied: %v", tc.reason, got.Result)
+			}
+			if diff := cmp.Diff(tc.want, got.Warnings); diff != "" {
+				t.Errorf("\n%s\nHandle(...): -want warnings, +got warnings:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,372 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package immutable rejects changes to the fields of managed resources that
+// Azure does not allow to be changed once their external resource exists,
+// e.g. their location or resource group, when the change is admitted rather
+// than when Azure rejects it long after the spec was edited.
+package immutable
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	cachev1beta1 "github.com/crossplane/provider-azure/apis/cache/v1beta1"
+	cognitiveservicesv1alpha1 "github.com/crossplane/provider-azure/apis/cognitiveservices/v1alpha1"
+	computev1alpha3 "github.com/crossplane/provider-azure/apis/compute/v1alpha3"
+	databasev1alpha3 "github.com/crossplane/provider-azure/apis/database/v1alpha3"
+	databasev1beta1 "github.com/crossplane/provider-azure/apis/database/v1beta1"
+	eventhubv1alpha1 "github.com/crossplane/provider-azure/apis/eventhub/v1alpha1"
+	kubernetesv1alpha1 "github.com/crossplane/provider-azure/apis/kubernetes/v1alpha1"
+	kustov1alpha1 "github.com/crossplane/provider-azure/apis/kusto/v1alpha1"
+	monitorv1alpha1 "github.com/crossplane/provider-azure/apis/monitor/v1alpha1"
+	networkv1beta1 "github.com/crossplane/provider-azure/apis/network/v1beta1"
+	storagev1alpha3 "github.com/crossplane/provider-azure/apis/storage/v1alpha3"
+	storagesyncv1alpha1 "github.com/crossplane/provider-azure/apis/storagesync/v1alpha1"
+	"github.com/crossplane/provider-azure/apis/v1alpha3"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+// WebhookPath is the path at which the validating webhook is served.
+const WebhookPath = "/validate-immutable-fields"
+
+// Error strings.
+const (
+	errNewObject = "cannot create object of kind %s"
+	errDecode    = "cannot decode object"
+	errDecodeOld = "cannot decode old object"
+	errPave      = "cannot pave object"
+	errImmutable = "%s is immutable: cannot change it from %s to %s"
+)
+
+// Fields returns the paths of the fields of the supplied managed resource
+// that cannot be changed once its external resource exists, or nil if it has
+// none.
+func Fields(mg resource.Managed) []string {
+	switch mg.(type) {
+	case *v1alpha3.ResourceGroup:
+		return []string{"spec.location"}
+	case *cachev1beta1.Redis:
+		return []string{
+			"spec.forProvider.subscriptionID",
+			"spec.forProvider.resourceGroupName",
+			"spec.forProvider.location",
+			"spec.forProvider.sku.family",
+		}
+	case *cognitiveservicesv1alpha1.OpenAIDeployment:
+		return []string{
+			"spec.forProvider.subscriptionID",
+			"spec.forProvider.resourceGroupName",
+			"spec.forProvider.accountName",
+			"spec.forProvider.model.format",
+			"spec.forProvider.model.name",
+		}
+	case *computev1alpha3.AKSCluster:
+		return []string{"spec.resourceGroupName", "spec.location", "spec.availabilityZones"}
+	case *computev1alpha3.DedicatedHostGroup:
+		return []string{
+			"spec.forProvider.subscriptionID",
+			"spec.forProvider.resourceGroupName",
+			"spec.forProvider.location",
+			"spec.forProvider.zones",
+			"spec.forProvider.platformFaultDomainCount",
+		}
+	case *computev1alpha3.DedicatedHost:
+		return []string{
+			"spec.forProvider.subscriptionID",
+			"spec.forProvider.resourceGroupName",
+			"spec.forProvider.hostGroupName",
+			"spec.forProvider.location",
+			"spec.forProvider.sku",
+			"spec.forProvider.platformFaultDomain",
+		}
+	case *computev1alpha3.CapacityReservationGroup:
+		return []string{
+			"spec.forProvider.subscriptionID",
+			"spec.forProvider.resourceGroupName",
+			"spec.forProvider.location",
+			"spec.forProvider.zones",
+		}
+	case *computev1alpha3.CapacityReservation:
+		return []string{
+			"spec.forProvider.subscriptionID",
+			"spec.forProvider.resourceGroupName",
+			"spec.forProvider.capacityReservationGroupName",
+			"spec.forProvider.location",
+			"spec.forProvider.sku",
+			"spec.forProvider.zones",
+		}
+	case *computev1alpha3.ImageTemplate:
+		return []string{
+			"spec.forProvider.subscriptionID",
+			"spec.forProvider.resourceGroupName",
+			"spec.forProvider.location",
+			"spec.forProvider.identityId",
+			"spec.forProvider.source",
+			"spec.forProvider.customizers",
+			"spec.forProvider.distributors",
+			"spec.forProvider.buildTimeoutInMinutes",
+			"spec.forProvider.vmSize",
+			"spec.forProvider.osDiskSizeGB",
+		}
+	case *databasev1beta1.MySQLServer, *databasev1beta1.PostgreSQLServer:
+		return []string{"spec.forProvider.resourceGroupName", "spec.forProvider.location"}
+	case *databasev1alpha3.MySQLServerFirewallRule, *databasev1alpha3.PostgreSQLServerFirewallRule:
+		return []string{"spec.forProvider.resourceGroupName", "spec.forProvider.serverName"}
+	case *databasev1alpha3.MySQLServerVirtualNetworkRule, *databasev1alpha3.PostgreSQLServerVirtualNetworkRule:
+		return []string{"spec.resourceGroupName", "spec.serverName"}
+	case *databasev1alpha3.CosmosDBAccount:
+		return []string{"spec.forProvider.resourceGroupName", "spec.forProvider.location", "spec.forProvider.kind"}
+	case *eventhubv1alpha1.SchemaRegistryGroup:
+		return []string{
+			"spec.forProvider.subscriptionID",
+			"spec.forProvider.resourceGroupName",
+			"spec.forProvider.namespaceName",
+			"spec.forProvider.schemaType",
+		}
+	case *kubernetesv1alpha1.ConnectedCluster:
+		return []string{
+			"spec.forProvider.subscriptionID",
+			"spec.forProvider.resourceGroupName",
+			"spec.forProvider.location",
+			"spec.forProvider.agentPublicKeyCertificate",
+		}
+	case *kustov1alpha1.KustoCluster:
+		return []string{
+			"spec.forProvider.subscriptionID",
+			"spec.forProvider.resourceGroupName",
+			"spec.forProvider.location",
+			"spec.forProvider.zones",
+		}
+	case *kustov1alpha1.KustoDatabase:
+		return []string{
+			"spec.forProvider.subscriptionID",
+			"spec.forProvider.resourceGroupName",
+			"spec.forProvider.clusterName",
+			"spec.forProvider.location",
+		}
+	case *kustov1alpha1.KustoScript:
+		return []string{
+			"spec.forProvider.subscriptionID",
+			"spec.forProvider.resourceGroupName",
+			"spec.forProvider.clusterName",
+			"spec.forProvider.databaseName",
+		}
+	case *monitorv1alpha1.AzureMonitorPrivateLinkScope:
+		return []string{"spec.forProvider.subscriptionID", "spec.forProvider.resourceGroupName"}
+	case *monitorv1alpha1.AzureMonitorPrivateLinkScopedResource:
+		return []string{
+			"spec.forProvider.subscriptionID",
+			"spec.forProvider.resourceGroupName",
+			"spec.forProvider.privateLinkScopeName",
+			"spec.forProvider.linkedResourceID",
+		}
+	case *monitorv1alpha1.AzureMonitorWorkspace:
+		return []string{"spec.forProvider.subscriptionID", "spec.forProvider.resourceGroupName", "spec.forProvider.location"}
+	case *monitorv1alpha1.DataCollectionEndpoint:
+		return []string{
+			"spec.forProvider.subscriptionID",
+			"spec.forProvider.resourceGroupName",
+			"spec.forProvider.location",
+			"spec.forProvider.kind",
+		}
+	case *monitorv1alpha1.DataCollectionRule:
+		return []string{
+			"spec.forProvider.subscriptionID",
+			"spec.forProvider.resourceGroupName",
+			"spec.forProvider.location",
+			"spec.forProvider.kind",
+		}
+	case *monitorv1alpha1.DataCollectionRuleAssociation:
+		return []string{"spec.forProvider.targetResourceID"}
+	case *networkv1beta1.VirtualNetwork:
+		return []string{"spec.forProvider.resourceGroupName", "spec.forProvider.location"}
+	case *networkv1beta1.Subnet:
+		return []string{"spec.forProvider.resourceGroupName", "spec.forProvider.virtualNetworkName"}
+	case *storagev1alpha3.Account:
+		return []string{"spec.resourceGroupName", "spec.storageAccountSpec.location"}
+	case *storagesyncv1alpha1.StorageSyncService:
+		return []string{
+			"spec.forProvider.subscriptionID",
+			"spec.forProvider.resourceGroupName",
+			"spec.forProvider.location",
+		}
+	case *storagesyncv1alpha1.SyncGroup:
+		return []string{
+			"spec.forProvider.subscriptionID",
+			"spec.forProvider.resourceGroupName",
+			"spec.forProvider.storageSyncServiceName",
+		}
+	case *storagesyncv1alpha1.CloudEndpoint:
+		return []string{
+			"spec.forProvider.subscriptionID",
+			"spec.forProvider.resourceGroupName",
+			"spec.forProvider.storageSyncServiceName",
+			"spec.forProvider.syncGroupName",
+			"spec.forProvider.storageAccountId",
+			"spec.forProvider.azureFileShareName",
+		}
+	}
+	return nil
+}
+
+// Changes returns an error for each immutable field of the supplied managed
+// resource that was changed by updating it from before to after. Fields that were not
+// set before may be set, since they are filled in by defaulting and late
+// initialization. Locations are compared by their programmatic names and
+// other strings case insensitively, like Azure compares them.
+func Changes(before, after resource.Managed) ([]error, error) {
+	bp, err := fieldpath.PaveObject(before)
+	if err != nil {
+		return nil, errors.Wrap(err, errPave)
+	}
+	ap, err := fieldpath.PaveObject(after)
+	if err != nil {
+		return nil, errors.Wrap(err, errPave)
+	}
+	var errs []error
+	for _, path := range Fields(after) {
+		b, err := bp.GetValue(path)
+		if err != nil || empty(b) {
+			continue
+		}
+		a, _ := ap.GetValue(path)
+		if equal(path, b, a) {
+			continue
+		}
+		errs = append(errs, errors.Errorf(errImmutable, path, format(b), format(a)))
+	}
+	return errs, nil
+}
+
+func empty(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Map {
+		return rv.Len() == 0
+	}
+	return rv.IsZero()
+}
+
+func equal(path string, a, b interface{}) bool {
+	as, aok := a.(string)
+	bs, bok := b.(string)
+	if !aok || !bok {
+		return reflect.DeepEqual(a, b)
+	}
+	if strings.HasSuffix(path, ".location") {
+		return azure.NormalizeLocation(as) == azure.NormalizeLocation(bs)
+	}
+	return strings.EqualFold(as, bs)
+}
+
+func format(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
+
+// A Validator is an admission handler that denies updates of managed
+// resources that change their immutable fields.
+type Validator struct {
+	scheme      *runtime.Scheme
+	replaceable func(resource.Managed) bool
+}
+
+// A ValidatorOption configures a Validator.
+type ValidatorOption func(*Validator)
+
+// WithReplaceable allows the immutable fields of the managed resources for
+// which the supplied function returns true to be changed, since their
+// controller replaces their Azure resource rather than update it.
+func WithReplaceable(fn func(resource.Managed) bool) ValidatorOption {
+	return func(v *Validator) {
+		v.replaceable = fn
+	}
+}
+
+// NewValidator returns a Validator that decodes the managed resources it
+// validates using the supplied scheme.
+func NewValidator(s *runtime.Scheme, o ...ValidatorOption) *Validator {
+	v := &Validator{scheme: s, replaceable: func(resource.Managed) bool { return false }}
+	for _, fn := range o {
+		fn(v)
+	}
+	return v
+}
+
+// Handle the supplied admission request. Only updates of managed resources
+// can change immutable fields, so anything else is allowed.
+func (v *Validator) Handle(_ context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Update {
+		return admission.Allowed("")
+	}
+	gvk := schema.GroupVersionKind{Group: req.Kind.Group, Version: req.Kind.Version, Kind: req.Kind.Kind}
+	before, err := v.decode(gvk, req.OldObject.Raw)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, errors.Wrap(err, errDecodeOld))
+	}
+	after, err := v.decode(gvk, req.Object.Raw)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, errors.Wrap(err, errDecode))
+	}
+	if before == nil || after == nil || v.replaceable(after) {
+		return admission.Allowed("")
+	}
+	errs, err := Changes(before, after)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if len(errs) == 0 {
+		return admission.Allowed("")
+	}
+	msgs := make([]string, len(errs))
+	for i := range errs {
+		msgs[i] = errs[i].Error()
+	}
+	return admission.Denied(strings.Join(msgs, "; "))
+}
+
+// decode the supplied object of the supplied kind, or return nil if it is not
+// a managed resource.
+func (v *Validator) decode(gvk schema.GroupVersionKind, raw []byte) (resource.Managed, error) {
+	o, err := v.scheme.New(gvk)
+	if err != nil {
+		return nil, errors.Wrapf(err, errNewObject, gvk.Kind)
+	}
+	mg, ok := o.(resource.Managed)
+	if !ok {
+		return nil, nil
+	}
+	return mg, json.Unmarshal(raw, mg)
+}
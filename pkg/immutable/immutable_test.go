@@ -0,0 +1,208 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package immutable
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	cachev1beta1 "github.com/crossplane/provider-azure/apis/cache/v1beta1"
+)
+
+type redisModifier func(*cachev1beta1.Redis)
+
+func withLocation(l string) redisModifier {
+	return func(r *cachev1beta1.Redis) { r.Spec.ForProvider.Location = l }
+}
+
+func withFamily(f string) redisModifier {
+	return func(r *cachev1beta1.Redis) { r.Spec.ForProvider.SKU.Family = f }
+}
+
+func withCapacity(c int) redisModifier {
+	return func(r *cachev1beta1.Redis) { r.Spec.ForProvider.SKU.Capacity = c }
+}
+
+func redis(m ...redisModifier) *cachev1beta1.Redis {
+	r := &cachev1beta1.Redis{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: cachev1beta1.SchemeGroupVersion.String(),
+			Kind:       cachev1beta1.RedisKind,
+		},
+		Spec: cachev1beta1.RedisSpec{ForProvider: cachev1beta1.RedisParameters{
+			ResourceGroupName: "coolgroup",
+			SKU:               cachev1beta1.SKU{Name: "Basic", Family: "C", Capacity: 0},
+		}},
+	}
+	for _, f := range m {
+		f(r)
+	}
+	return r
+}
+
+func TestChanges(t *testing.T) {
+	type args struct {
+		before resource.Managed
+		after  resource.Managed
+	}
+	type want struct {
+		errs []error
+		err  error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Unchanged": {
+			reason: "An update that does not change any immutable field should be allowed.",
+			args: args{
+				before: redis(withLocation("westus2")),
+				after:  redis(withLocation("westus2"), withCapacity(1)),
+			},
+		},
+		"LateInitialized": {
+			reason: "Immutable fields that were not set before may be set.",
+			args: args{
+				before: redis(),
+				after:  redis(withLocation("westus2")),
+			},
+		},
+		"SameLocation": {
+			reason: "A location should be compared by its programmatic name.",
+			args: args{
+				before: redis(withLocation("West US 2")),
+				after:  redis(withLocation("westus2")),
+			},
+		},
+		"ChangedLocation": {
+			reason: "Changing the location should be rejected.",
+			args: args{
+				before: redis(withLocation("westus2")),
+				after:  redis(withLocation("eastus")),
+			},
+			want: want{errs: []error{
+				errors.Errorf(errImmutable, "spec.forProvider.location", `"westus2"`, `"eastus"`),
+			}},
+		},
+		"ChangedLocationAndFamily": {
+			reason: "Each changed immutable field should be rejected.",
+			args: args{
+				before: redis(withLocation("westus2")),
+				after:  redis(withLocation("eastus"), withFamily("P")),
+			},
+			want: want{errs: []error{
+				errors.Errorf(errImmutable, "spec.forProvider.location", `"westus2"`, `"eastus"`),
+				errors.Errorf(errImmutable, "spec.forProvider.sku.family", `"C"`, `"P"`),
+			}},
+		},
+		"RemovedLocation": {
+			reason: "Removing an immutable field should be rejected.",
+			args: args{
+				before: redis(withLocation("westus2")),
+				after:  redis(),
+			},
+			want: want{errs: []error{
+				errors.Errorf(errImmutable, "spec.forProvider.location", `"westus2"`, "null"),
+			}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			errs, err := Changes(tc.args.before, tc.args.after)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nChanges(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nChanges(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func request(op admissionv1.Operation, before, after runtime.Object) admission.Request {
+	b, _ := json.Marshal(before)
+	a, _ := json.Marshal(after)
+	return admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+		Operation: op,
+		Kind: metav1.GroupVersionKind{
+			Group:   cachev1beta1.Group,
+			Version: cachev1beta1.Version,
+			Kind:    cachev1beta1.RedisKind,
+		},
+		OldObject: runtime.RawExtension{Raw: b},
+		Object:    runtime.RawExtension{Raw: a},
+	}}
+}
+
+func TestHandle(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := cachev1beta1.SchemeBuilder.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]struct {
+		reason  string
+		o       []ValidatorOption
+		req     admission.Request
+		allowed bool
+	}{
+		"Create": {
+			reason:  "Creates should always be allowed.",
+			req:     request(admissionv1.Create, nil, redis(withLocation("eastus"))),
+			allowed: true,
+		},
+		"UpdateUnchanged": {
+			reason:  "Updates that do not change immutable fields should be allowed.",
+			req:     request(admissionv1.Update, redis(withLocation("westus2")), redis(withLocation("westus2"), withCapacity(1))),
+			allowed: true,
+		},
+		"UpdateChanged": {
+			reason:  "Updates that change immutable fields should be denied.",
+			req:     request(admissionv1.Update, redis(withLocation("westus2")), redis(withLocation("eastus"))),
+			allowed: false,
+		},
+		"UpdateReplaceable": {
+			reason:  "Updates that change immutable fields of resources that are replaced when they change should be allowed.",
+			o:       []ValidatorOption{WithReplaceable(func(resource.Managed) bool { return true })},
+			req:     request(admissionv1.Update, redis(withLocation("westus2")), redis(withLocation("eastus"))),
+			allowed: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := NewValidator(s, tc.o...).Handle(context.Background(), tc.req)
+			if diff := cmp.Diff(tc.allowed, got.Allowed); diff != "" {
+				t.Errorf("\n%s\nHandle(...): -want allowed, +got allowed:\n%s\n%v", tc.reason, diff, got.Result)
+			}
+		})
+	}
+}
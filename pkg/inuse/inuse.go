@@ -0,0 +1,265 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package inuse refuses to delete the Azure resources of managed resources
+// that other managed resources still use, e.g. a virtual network whose
+// subnets still exist. Azure would otherwise reject the deletion as InUse
+// over and over until the users are gone.
+package inuse
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	cachev1beta1 "github.com/crossplane/provider-azure/apis/cache/v1beta1"
+	cognitiveservicesv1alpha1 "github.com/crossplane/provider-azure/apis/cognitiveservices/v1alpha1"
+	computev1alpha3 "github.com/crossplane/provider-azure/apis/compute/v1alpha3"
+	databasev1alpha3 "github.com/crossplane/provider-azure/apis/database/v1alpha3"
+	databasev1beta1 "github.com/crossplane/provider-azure/apis/database/v1beta1"
+	eventhubv1alpha1 "github.com/crossplane/provider-azure/apis/eventhub/v1alpha1"
+	kubernetesv1alpha1 "github.com/crossplane/provider-azure/apis/kubernetes/v1alpha1"
+	kustov1alpha1 "github.com/crossplane/provider-azure/apis/kusto/v1alpha1"
+	monitorv1alpha1 "github.com/crossplane/provider-azure/apis/monitor/v1alpha1"
+	networkv1beta1 "github.com/crossplane/provider-azure/apis/network/v1beta1"
+	storagev1alpha3 "github.com/crossplane/provider-azure/apis/storage/v1alpha3"
+	storagesyncv1alpha1 "github.com/crossplane/provider-azure/apis/storagesync/v1alpha1"
+	"github.com/crossplane/provider-azure/pkg/defaults"
+)
+
+// TypeInUse managed resources have had the deletion of their Azure resource
+// refused because other managed resources still use it.
+const TypeInUse xpv1.ConditionType = "InUse"
+
+// Reasons a managed resource is or is not in use.
+const (
+	ReasonInUse    xpv1.ConditionReason = "UsedByManagedResources"
+	ReasonNotInUse xpv1.ConditionReason = "NotInUse"
+)
+
+// Error strings.
+const (
+	errListUsers = "cannot list managed resources that use the external resource"
+	errInUse     = "refusing to delete external resource while it is used by %s"
+)
+
+// maxNamed is the number of users named by the InUse condition.
+const maxNamed = 5
+
+// InUse returns a condition that indicates the deletion of an Azure resource
+// was refused because the supplied managed resources still use it.
+func InUse(users []resource.Managed) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeInUse,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonInUse,
+		Message:            fmt.Sprintf(errInUse, describe(users)),
+	}
+}
+
+// NotInUse returns a condition that indicates an Azure resource is no longer
+// used by other managed resources.
+func NotInUse() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeInUse,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNotInUse,
+	}
+}
+
+func describe(users []resource.Managed) string {
+	names := make([]string, 0, maxNamed)
+	for i, u := range users {
+		if i == maxNamed {
+			names = append(names, fmt.Sprintf("and %d more", len(users)-maxNamed))
+			break
+		}
+		names = append(names, fmt.Sprintf("%s %s", kind(u), u.GetName()))
+	}
+	return strings.Join(names, ", ")
+}
+
+func kind(obj interface{}) string {
+	t := reflect.TypeOf(obj)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name()
+}
+
+// A UsersFn returns the managed resources that use the Azure resource of the
+// supplied managed resource.
+type UsersFn func(ctx context.Context, c client.Reader, mg resource.Managed) ([]resource.Managed, error)
+
+// NewConnecter returns an ExternalConnecter whose clients refuse to delete
+// the Azure resources of managed resources that are used by the managed
+// resources the supplied function returns. The managed resource keeps its
+// finalizer, and is deleted once its users are gone.
+func NewConnecter(c managed.ExternalConnecter, kube client.Reader, fn UsersFn) managed.ExternalConnecter {
+	return &connecter{ExternalConnecter: c, kube: kube, users: fn}
+}
+
+type connecter struct {
+	managed.ExternalConnecter
+	kube  client.Reader
+	users UsersFn
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	e, err := c.ExternalConnecter.Connect(ctx, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &external{ExternalClient: e, kube: c.kube, users: c.users}, nil
+}
+
+type external struct {
+	managed.ExternalClient
+	kube  client.Reader
+	users UsersFn
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	users, err := e.users(ctx, e.kube, mg)
+	if err != nil {
+		return errors.Wrap(err, errListUsers)
+	}
+	if len(users) > 0 {
+		mg.SetConditions(InUse(users))
+		return errors.Errorf(errInUse, describe(users))
+	}
+	if mg.GetCondition(TypeInUse).Reason == ReasonInUse {
+		mg.SetConditions(NotInUse())
+	}
+	return e.ExternalClient.Delete(ctx, mg)
+}
+
+// users returns the managed resources of the supplied kinds the supplied
+// function accepts.
+func users(ctx context.Context, c client.Reader, kinds []resource.ManagedList, accept func(u resource.Managed) bool) ([]resource.Managed, error) {
+	var users []resource.Managed
+	for _, k := range kinds {
+		l := k.DeepCopyObject().(resource.ManagedList)
+		if err := c.List(ctx, l); err != nil {
+			return nil, err
+		}
+		for _, u := range l.GetItems() {
+			if accept(u) {
+				users = append(users, u)
+			}
+		}
+	}
+	return users, nil
+}
+
+// resourceGroupUsers are the kinds of managed resources that live in a
+// resource group.
+var resourceGroupUsers = []resource.ManagedList{
+	&cachev1beta1.RedisList{},
+	&cognitiveservicesv1alpha1.OpenAIDeploymentList{},
+	&computev1alpha3.AKSClusterList{},
+	&computev1alpha3.DedicatedHostGroupList{},
+	&computev1alpha3.DedicatedHostList{},
+	&computev1alpha3.CapacityReservationGroupList{},
+	&computev1alpha3.CapacityReservationList{},
+	&computev1alpha3.ImageTemplateList{},
+	&databasev1beta1.MySQLServerList{},
+	&databasev1beta1.PostgreSQLServerList{},
+	&databasev1alpha3.MySQLServerFirewallRuleList{},
+	&databasev1alpha3.PostgreSQLServerFirewallRuleList{},
+	&databasev1alpha3.MySQLServerVirtualNetworkRuleList{},
+	&databasev1alpha3.PostgreSQLServerVirtualNetworkRuleList{},
+	&databasev1alpha3.CosmosDBAccountList{},
+	&eventhubv1alpha1.SchemaRegistryGroupList{},
+	&kubernetesv1alpha1.ConnectedClusterList{},
+	&kustov1alpha1.KustoClusterList{},
+	&kustov1alpha1.KustoDatabaseList{},
+	&kustov1alpha1.KustoScriptList{},
+	&monitorv1alpha1.AzureMonitorPrivateLinkScopeList{},
+	&monitorv1alpha1.AzureMonitorPrivateLinkScopedResourceList{},
+	&monitorv1alpha1.AzureMonitorWorkspaceList{},
+	&monitorv1alpha1.DataCollectionEndpointList{},
+	&monitorv1alpha1.DataCollectionRuleList{},
+	&networkv1beta1.VirtualNetworkList{},
+	&networkv1beta1.SubnetList{},
+	&storagev1alpha3.AccountList{},
+	&storagesyncv1alpha1.StorageSyncServiceList{},
+	&storagesyncv1alpha1.SyncGroupList{},
+	&storagesyncv1alpha1.CloudEndpointList{},
+}
+
+// ResourceGroupUsers returns the managed resources in the resource group of
+// the supplied ResourceGroup.
+func ResourceGroupUsers(ctx context.Context, c client.Reader, rg resource.Managed) ([]resource.Managed, error) {
+	name := meta.GetExternalName(rg)
+	return users(ctx, c, resourceGroupUsers, func(u resource.Managed) bool {
+		g, _ := defaults.ResourceGroupOf(u)
+		return g != nil && strings.EqualFold(*g, name)
+	})
+}
+
+// virtualNetworkUsers are the kinds of managed resources that live in, or
+// are attached to a subnet of, a virtual network.
+var virtualNetworkUsers = []resource.ManagedList{
+	&networkv1beta1.SubnetList{},
+	&cachev1beta1.RedisList{},
+	&computev1alpha3.AKSClusterList{},
+	&databasev1alpha3.MySQLServerVirtualNetworkRuleList{},
+	&databasev1alpha3.PostgreSQLServerVirtualNetworkRuleList{},
+}
+
+// VirtualNetworkUsers returns the Subnets of the supplied VirtualNetwork,
+// and the managed resources that are attached to one of its subnets.
+func VirtualNetworkUsers(ctx context.Context, c client.Reader, mg resource.Managed) ([]resource.Managed, error) {
+	vnet, ok := mg.(*networkv1beta1.VirtualNetwork)
+	if !ok {
+		return nil, nil
+	}
+	rg, name := vnet.Spec.ForProvider.ResourceGroupName, meta.GetExternalName(vnet)
+	prefix := strings.ToLower(fmt.Sprintf("/resourceGroups/%s/providers/Microsoft.Network/virtualNetworks/%s/subnets/", rg, name))
+	inVirtualNetwork := func(subnetID string) bool {
+		return strings.Contains(strings.ToLower(subnetID), prefix)
+	}
+	return users(ctx, c, virtualNetworkUsers, func(u resource.Managed) bool {
+		switch cr := u.(type) {
+		case *networkv1beta1.Subnet:
+			p := cr.Spec.ForProvider
+			return strings.EqualFold(p.ResourceGroupName, rg) && strings.EqualFold(p.VirtualNetworkName, name)
+		case *cachev1beta1.Redis:
+			return cr.Spec.ForProvider.SubnetID != nil && inVirtualNetwork(*cr.Spec.ForProvider.SubnetID)
+		case *computev1alpha3.AKSCluster:
+			return inVirtualNetwork(cr.Spec.VnetSubnetID)
+		case *databasev1alpha3.MySQLServerVirtualNetworkRule:
+			return inVirtualNetwork(cr.Spec.VirtualNetworkSubnetID)
+		case *databasev1alpha3.PostgreSQLServerVirtualNetworkRule:
+			return inVirtualNetwork(cr.Spec.VirtualNetworkSubnetID)
+		}
+		return false
+	})
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inuse
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	cachev1beta1 "github.com/crossplane/provider-azure/apis/cache/v1beta1"
+	networkv1beta1 "github.com/crossplane/provider-azure/apis/network/v1beta1"
+	"github.com/crossplane/provider-azure/apis/v1alpha3"
+)
+
+var errBoom = errors.New("boom")
+
+func subnet(name, rg, vnet string) networkv1beta1.Subnet {
+	s := networkv1beta1.Subnet{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	s.Spec.ForProvider.ResourceGroupName = rg
+	s.Spec.ForProvider.VirtualNetworkName = vnet
+	return s
+}
+
+func redis(name, subnetID string) cachev1beta1.Redis {
+	r := cachev1beta1.Redis{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	r.Spec.ForProvider.SubnetID = &subnetID
+	return r
+}
+
+// listing returns a client that lists the supplied Subnets and Redis caches.
+func listing(subnets []networkv1beta1.Subnet, caches []cachev1beta1.Redis) client.Reader {
+	return &test.MockClient{
+		MockList: func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+			switch l := obj.(type) {
+			case *networkv1beta1.SubnetList:
+				l.Items = subnets
+			case *cachev1beta1.RedisList:
+				l.Items = caches
+			}
+			return nil
+		},
+	}
+}
+
+func names(users []resource.Managed) []string {
+	n := make([]string, 0, len(users))
+	for _, u := range users {
+		n = append(n, u.GetName())
+	}
+	return n
+}
+
+func TestDelete(t *testing.T) {
+	user := &fake.Managed{ObjectMeta: metav1.ObjectMeta{Name: "cool-user"}}
+
+	type want struct {
+		deleted bool
+		err     error
+		cond    xpv1.Condition
+	}
+
+	cases := map[string]struct {
+		reason string
+		users  UsersFn
+		mg     resource.Managed
+		want   want
+	}{
+		"ListError": {
+			reason: "Errors listing users should be returned",
+			users: func(_ context.Context, _ client.Reader, _ resource.Managed) ([]resource.Managed, error) {
+				return nil, errBoom
+			},
+			mg: &fake.Managed{},
+			want: want{
+				err:  errors.Wrap(errBoom, errListUsers),
+				cond: xpv1.Condition{Type: TypeInUse, Status: corev1.ConditionUnknown},
+			},
+		},
+		"InUse": {
+			reason: "The deletion of external resources that are in use should be refused with an InUse condition",
+			users: func(_ context.Context, _ client.Reader, _ resource.Managed) ([]resource.Managed, error) {
+				return []resource.Managed{user}, nil
+			},
+			mg: &fake.Managed{},
+			want: want{
+				err:  errors.Errorf(errInUse, "Managed cool-user"),
+				cond: InUse([]resource.Managed{user}),
+			},
+		},
+		"NotInUse": {
+			reason: "External resources that are not in use should be deleted",
+			users: func(_ context.Context, _ client.Reader, _ resource.Managed) ([]resource.Managed, error) {
+				return nil, nil
+			},
+			mg: &fake.Managed{},
+			want: want{
+				deleted: true,
+				cond:    xpv1.Condition{Type: TypeInUse, Status: corev1.ConditionUnknown},
+			},
+		},
+		"NoLongerInUse": {
+			reason: "External resources should be deleted, and the condition cleared, once their users are gone",
+			users: func(_ context.Context, _ client.Reader, _ resource.Managed) ([]resource.Managed, error) {
+				return nil, nil
+			},
+			mg: func() resource.Managed {
+				mg := &fake.Managed{}
+				mg.SetConditions(InUse([]resource.Managed{user}))
+				return mg
+			}(),
+			want: want{
+				deleted: true,
+				cond:    NotInUse(),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			deleted := false
+			e := &external{
+				ExternalClient: &managed.ExternalClientFns{
+					DeleteFn: func(_ context.Context, _ resource.Managed) error {
+						deleted = true
+						return nil
+					},
+				},
+				users: tc.users,
+			}
+			err := e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.deleted, deleted); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want deleted, +got deleted:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cond, tc.mg.GetCondition(TypeInUse), test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want condition, +got condition:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestVirtualNetworkUsers(t *testing.T) {
+	vnet := &networkv1beta1.VirtualNetwork{}
+	vnet.Spec.ForProvider.ResourceGroupName = "cool-rg"
+	meta.SetExternalName(vnet, "cool-vnet")
+
+	id := "/subscriptions/cool-sub/resourceGroups/cool-rg/providers/Microsoft.Network/virtualNetworks/%s/subnets/default"
+
+	cases := map[string]struct {
+		reason string
+		c      client.Reader
+		want   []string
+		err    error
+	}{
+		"Users": {
+			reason: "Subnets of the virtual network and resources attached to them should be users, regardless of case",
+			c: listing(
+				[]networkv1beta1.Subnet{subnet("in", "cool-rg", "cool-vnet"), subnet("other-vnet", "cool-rg", "other-vnet"), subnet("other-rg", "other-rg", "cool-vnet")},
+				[]cachev1beta1.Redis{redis("attached", fmt.Sprintf(id, "COOL-VNET")), redis("elsewhere", fmt.Sprintf(id, "other-vnet"))},
+			),
+			want: []string{"in", "attached"},
+		},
+		"ListError": {
+			reason: "Errors listing managed resources should be returned",
+			c: &test.MockClient{
+				MockList: test.NewMockListFn(errBoom),
+			},
+			err: errBoom,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			users, err := VirtualNetworkUsers(context.Background(), tc.c, vnet)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nVirtualNetworkUsers(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if tc.err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want, names(users)); diff != "" {
+				t.Errorf("\n%s\nVirtualNetworkUsers(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestResourceGroupUsers(t *testing.T) {
+	rg := &v1alpha3.ResourceGroup{}
+	meta.SetExternalName(rg, "cool-rg")
+
+	c := listing([]networkv1beta1.Subnet{subnet("in", "Cool-RG", "cool-vnet"), subnet("out", "other-rg", "cool-vnet")}, nil)
+	users, err := ResourceGroupUsers(context.Background(), c, rg)
+	if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+		t.Errorf("ResourceGroupUsers(...): -want error, +got error:\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"in"}, names(users)); diff != "" {
+		t.Errorf("ResourceGroupUsers(...): -want, +got:\n%s", diff)
+	}
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package location applies the location policy of a ProviderConfig to the
+// managed resources that use it.
+package location
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	cachev1beta1 "github.com/crossplane/provider-azure/apis/cache/v1beta1"
+	computev1alpha3 "github.com/crossplane/provider-azure/apis/compute/v1alpha3"
+	databasev1alpha3 "github.com/crossplane/provider-azure/apis/database/v1alpha3"
+	databasev1beta1 "github.com/crossplane/provider-azure/apis/database/v1beta1"
+	kubernetesv1alpha1 "github.com/crossplane/provider-azure/apis/kubernetes/v1alpha1"
+	kustov1alpha1 "github.com/crossplane/provider-azure/apis/kusto/v1alpha1"
+	monitorv1alpha1 "github.com/crossplane/provider-azure/apis/monitor/v1alpha1"
+	networkv1beta1 "github.com/crossplane/provider-azure/apis/network/v1beta1"
+	storagev1alpha3 "github.com/crossplane/provider-azure/apis/storage/v1alpha3"
+	storagesyncv1alpha1 "github.com/crossplane/provider-azure/apis/storagesync/v1alpha1"
+	"github.com/crossplane/provider-azure/apis/v1alpha3"
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+// Error strings.
+const (
+	errGetPC         = "cannot get referenced ProviderConfig"
+	errUpdateManaged = "cannot update managed resource with default location"
+	errNotAllowed    = "location %q is not allowed by ProviderConfig %q"
+)
+
+// Of returns the location field of the supplied managed resource, or nil if
+// it has none.
+func Of(mg resource.Managed) *string {
+	switch cr := mg.(type) {
+	case *v1alpha3.ResourceGroup:
+		return &cr.Spec.Location
+	case *cachev1beta1.Redis:
+		return &cr.Spec.ForProvider.Location
+	case *computev1alpha3.AKSCluster:
+		return &cr.Spec.Location
+	case *computev1alpha3.DedicatedHostGroup:
+		return &cr.Spec.ForProvider.Location
+	case *computev1alpha3.DedicatedHost:
+		return &cr.Spec.ForProvider.Location
+	case *computev1alpha3.CapacityReservationGroup:
+		return &cr.Spec.ForProvider.Location
+	case *computev1alpha3.CapacityReservation:
+		return &cr.Spec.ForProvider.Location
+	case *computev1alpha3.ImageTemplate:
+		return &cr.Spec.ForProvider.Location
+	case *databasev1beta1.MySQLServer:
+		return &cr.Spec.ForProvider.Location
+	case *databasev1beta1.PostgreSQLServer:
+		return &cr.Spec.ForProvider.Location
+	case *databasev1alpha3.CosmosDBAccount:
+		return &cr.Spec.ForProvider.Location
+	case *kubernetesv1alpha1.ConnectedCluster:
+		return &cr.Spec.ForProvider.Location
+	case *kustov1alpha1.KustoCluster:
+		return &cr.Spec.ForProvider.Location
+	case *kustov1alpha1.KustoDatabase:
+		return &cr.Spec.ForProvider.Location
+	case *monitorv1alpha1.AzureMonitorWorkspace:
+		return &cr.Spec.ForProvider.Location
+	case *monitorv1alpha1.DataCollectionEndpoint:
+		return &cr.Spec.ForProvider.Location
+	case *monitorv1alpha1.DataCollectionRule:
+		return &cr.Spec.ForProvider.Location
+	case *networkv1beta1.VirtualNetwork:
+		return &cr.Spec.ForProvider.Location
+	case *storagev1alpha3.Account:
+		if cr.Spec.StorageAccountSpec == nil {
+			return nil
+		}
+		return &cr.Spec.StorageAccountSpec.Location
+	case *storagesyncv1alpha1.StorageSyncService:
+		return &cr.Spec.ForProvider.Location
+	}
+	return nil
+}
+
+// Allowed returns true if the supplied location is one of the supplied
+// allowed locations, or if there are no allowed locations. Locations are
+// compared by their programmatic names, so "West US 2" matches "westus2".
+func Allowed(location string, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, a := range allowed {
+		if azure.NormalizeLocation(a) == azure.NormalizeLocation(location) {
+			return true
+		}
+	}
+	return false
+}
+
+// An Initializer applies the location policy of the ProviderConfig of a
+// managed resource. Resources without a location are set to the default
+// location of the ProviderConfig, and resources in locations it does not
+// allow are rejected. Resources that use the deprecated Provider, and
+// resources of kinds without a location, are not affected.
+type Initializer struct {
+	client client.Client
+}
+
+// NewInitializer returns a new Initializer.
+func NewInitializer(c client.Client) *Initializer {
+	return &Initializer{client: c}
+}
+
+// Initialize the location of the supplied managed resource, returning an
+// error if it is not allowed. Resources being deleted are never rejected.
+func (i *Initializer) Initialize(ctx context.Context, mg resource.Managed) error {
+	ref := mg.GetProviderConfigReference()
+	loc := Of(mg)
+	if ref == nil || loc == nil {
+		return nil
+	}
+	pc := &v1beta1.ProviderConfig{}
+	if err := i.client.Get(ctx, types.NamespacedName{Name: ref.Name}, pc); err != nil {
+		return errors.Wrap(err, errGetPC)
+	}
+	if *loc == "" && pc.Spec.DefaultLocation != nil {
+		*loc = *pc.Spec.DefaultLocation
+		if err := i.client.Update(ctx, mg); err != nil {
+			return errors.Wrap(err, errUpdateManaged)
+		}
+	}
+	if meta.WasDeleted(mg) || Allowed(*loc, pc.Spec.AllowedLocations) {
+		return nil
+	}
+	return errors.Errorf(errNotAllowed, *loc, pc.GetName())
+}
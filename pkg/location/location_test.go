@@ -0,0 +1,172 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package location
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/cache/v1beta1"
+	apisv1beta1 "github.com/crossplane/provider-azure/apis/v1beta1"
+)
+
+var _ managed.Initializer = &Initializer{}
+
+func TestAllowed(t *testing.T) {
+	cases := map[string]struct {
+		location string
+		allowed  []string
+		want     bool
+	}{
+		"NoRestriction": {
+			location: "westus2",
+			want:     true,
+		},
+		"Allowed": {
+			location: "West Europe",
+			allowed:  []string{"westus2", "westeurope"},
+			want:     true,
+		},
+		"NotAllowed": {
+			location: "eastus",
+			allowed:  []string{"westus2", "westeurope"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, Allowed(tc.location, tc.allowed)); diff != "" {
+				t.Errorf("Allowed(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInitialize(t *testing.T) {
+	errBoom := errors.New("boom")
+	now := metav1.Now()
+
+	redis := func(location string, deleted bool) *v1beta1.Redis {
+		cr := &v1beta1.Redis{}
+		cr.SetProviderConfigReference(&xpv1.Reference{Name: "cool-pc"})
+		cr.Spec.ForProvider.Location = location
+		if deleted {
+			cr.SetDeletionTimestamp(&now)
+		}
+		return cr
+	}
+	pc := func(spec apisv1beta1.ProviderConfigSpec) test.MockGetFn {
+		return test.NewMockGetFn(nil, func(o client.Object) error {
+			o.(*apisv1beta1.ProviderConfig).SetName("cool-pc")
+			o.(*apisv1beta1.ProviderConfig).Spec = spec
+			return nil
+		})
+	}
+	policy := apisv1beta1.ProviderConfigSpec{
+		DefaultLocation:  to.StringPtr("westus2"),
+		AllowedLocations: []string{"westus2", "westeurope"},
+	}
+
+	type want struct {
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		client client.Client
+		mg     resource.Managed
+		want   want
+	}{
+		"NoLocation": {
+			reason: "Kinds without a location should not be affected",
+			mg:     &fake.Managed{ProviderConfigReferencer: fake.ProviderConfigReferencer{Ref: &xpv1.Reference{Name: "cool-pc"}}},
+			want: want{
+				mg: &fake.Managed{ProviderConfigReferencer: fake.ProviderConfigReferencer{Ref: &xpv1.Reference{Name: "cool-pc"}}},
+			},
+		},
+		"GetProviderConfigError": {
+			reason: "Errors getting the ProviderConfig should be returned",
+			client: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			mg:     redis("westus2", false),
+			want: want{
+				mg:  redis("westus2", false),
+				err: errors.Wrap(errBoom, errGetPC),
+			},
+		},
+		"Defaulted": {
+			reason: "A missing location should be set to the default location of the ProviderConfig",
+			client: &test.MockClient{MockGet: pc(policy), MockUpdate: test.NewMockUpdateFn(nil)},
+			mg:     redis("", false),
+			want:   want{mg: redis("westus2", false)},
+		},
+		"UpdateError": {
+			reason: "Errors persisting the default location should be returned",
+			client: &test.MockClient{MockGet: pc(policy), MockUpdate: test.NewMockUpdateFn(errBoom)},
+			mg:     redis("", false),
+			want: want{
+				mg:  redis("westus2", false),
+				err: errors.Wrap(errBoom, errUpdateManaged),
+			},
+		},
+		"Allowed": {
+			reason: "Resources in an allowed location should be accepted",
+			client: &test.MockClient{MockGet: pc(policy)},
+			mg:     redis("West Europe", false),
+			want:   want{mg: redis("West Europe", false)},
+		},
+		"NotAllowed": {
+			reason: "Resources in a location the ProviderConfig does not allow should be rejected",
+			client: &test.MockClient{MockGet: pc(policy)},
+			mg:     redis("eastus", false),
+			want: want{
+				mg:  redis("eastus", false),
+				err: errors.Errorf(errNotAllowed, "eastus", "cool-pc"),
+			},
+		},
+		"NotAllowedButDeleted": {
+			reason: "Resources being deleted should not be rejected",
+			client: &test.MockClient{MockGet: pc(policy)},
+			mg:     redis("eastus", true),
+			want:   want{mg: redis("eastus", true)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := NewInitializer(tc.client).Initialize(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nInitialize(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg); diff != "" {
+				t.Errorf("\n%s\nInitialize(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
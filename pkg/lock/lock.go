@@ -0,0 +1,177 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package lock explains the failures of operations that are rejected
+// because a management lock applies to the managed resource.
+package lock
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2016-09-01/locks"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/feature"
+)
+
+// codeScopeLocked is the code of the errors Azure returns for operations that
+// are rejected because of a management lock.
+const codeScopeLocked = "ScopeLocked"
+
+// Error strings.
+const (
+	errLocked = "scope %q is locked by %s; the lock must be removed before this operation can succeed"
+)
+
+// lockedScope matches the first locked scope listed by a ScopeLocked error,
+// e.g. "following scope(s) are locked: '/subscriptions/<id>/resourceGroups/<name>'".
+var lockedScope = regexp.MustCompile(`are locked: '([^']+)'`)
+
+// LockedScope returns the scope whose management lock caused the supplied
+// error, or an empty string if the error was not caused by a lock.
+func LockedScope(err error) string {
+	if err == nil || !strings.Contains(err.Error(), codeScopeLocked) {
+		return ""
+	}
+	m := lockedScope.FindStringSubmatch(err.Error())
+	if m == nil {
+		return ""
+	}
+	return m[1]
+}
+
+// A Lister lists the management locks that apply to a scope.
+type Lister interface {
+	List(ctx context.Context, scope string) ([]locks.ManagementLockObject, error)
+}
+
+// A Client lists management locks.
+type Client struct {
+	locks locks.ManagementLocksClient
+}
+
+// List the management locks that apply to the supplied scope.
+func (c *Client) List(ctx context.Context, scope string) ([]locks.ManagementLockObject, error) {
+	it, err := c.locks.ListByScopeComplete(ctx, scope, "")
+	if err != nil {
+		return nil, err
+	}
+	l := make([]locks.ManagementLockObject, 0)
+	for it.NotDone() {
+		l = append(l, it.Value())
+		if err := it.NextWithContext(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
+// A NewListerFn returns a Lister for the subscription of the supplied managed
+// resource.
+type NewListerFn func(ctx context.Context, mg resource.Managed) (Lister, error)
+
+// NewAPIListerFn returns a NewListerFn that uses the credentials of the
+// ProviderConfig of the managed resource.
+func NewAPIListerFn(kube client.Client) NewListerFn {
+	return func(ctx context.Context, mg resource.Managed) (Lister, error) {
+		creds, auth, err := azure.GetAuthInfo(ctx, kube, mg)
+		if err != nil {
+			return nil, err
+		}
+		c := locks.NewManagementLocksClientWithBaseURI(azure.BaseURI(creds), creds[azure.CredentialsKeySubscriptionID])
+		c.Authorizer = auth
+		_ = c.AddToUserAgent(azure.UserAgent)
+		return &Client{locks: c}, nil
+	}
+}
+
+// Describe returns a description of the supplied locks, e.g.
+// `CanNotDelete lock "do-not-delete"`.
+func Describe(l []locks.ManagementLockObject) string {
+	d := make([]string, 0, len(l))
+	for _, o := range l {
+		level := locks.NotSpecified
+		if o.ManagementLockProperties != nil {
+			level = o.Level
+		}
+		d = append(d, fmt.Sprintf("%s lock %q", level, azure.ToString(o.Name)))
+	}
+	return strings.Join(d, ", ")
+}
+
+// NewConnecter returns an ExternalConnecter whose clients explain the
+// failures of creations and deletions that are rejected because of a
+// management lock, naming the lock.
+func NewConnecter(c managed.ExternalConnecter, fn NewListerFn) managed.ExternalConnecter {
+	return &connecter{ExternalConnecter: c, newLister: fn, gate: feature.DefaultGate}
+}
+
+type connecter struct {
+	managed.ExternalConnecter
+	newLister NewListerFn
+	gate      *feature.Gate
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	e, err := c.ExternalConnecter.Connect(ctx, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &external{ExternalClient: e, newLister: c.newLister, gate: c.gate}, nil
+}
+
+type external struct {
+	managed.ExternalClient
+	newLister NewListerFn
+	gate      *feature.Gate
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, err := e.ExternalClient.Create(ctx, mg)
+	return cr, e.explain(ctx, mg, err)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	return e.explain(ctx, mg, e.ExternalClient.Delete(ctx, mg))
+}
+
+// explain wraps the supplied error with the locks that caused it. Errors that
+// were not caused by a lock, or whose locks cannot be listed, are returned
+// unchanged, as are all errors while the ManagementLocks feature is disabled.
+func (e *external) explain(ctx context.Context, mg resource.Managed, err error) error {
+	scope := LockedScope(err)
+	if scope == "" || !e.gate.Enabled(feature.ManagementLocks) {
+		return err
+	}
+	l, lerr := e.newLister(ctx, mg)
+	if lerr != nil {
+		return err
+	}
+	found, lerr := l.List(ctx, scope)
+	e.gate.Observe(feature.ManagementLocks, lerr)
+	if lerr != nil || len(found) == 0 {
+		return err
+	}
+	return errors.Wrapf(err, errLocked, scope, Describe(found))
+}
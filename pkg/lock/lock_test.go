@@ -0,0 +1,144 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lock
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2016-09-01/locks"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+const scope = "/subscriptions/sub/resourceGroups/group"
+
+var errLockedScope = errors.New(`resources.GroupsClient#Delete: Failure sending request: StatusCode=409 -- Original Error: Code="ScopeLocked" Message="The scope '/subscriptions/sub/resourceGroups/group' cannot perform delete operation because following scope(s) are locked: '/subscriptions/sub/resourceGroups/group'. Please remove the lock and try again."`)
+
+type listFn func(ctx context.Context, scope string) ([]locks.ManagementLockObject, error)
+
+func (fn listFn) List(ctx context.Context, scope string) ([]locks.ManagementLockObject, error) {
+	return fn(ctx, scope)
+}
+
+func TestLockedScope(t *testing.T) {
+	cases := map[string]struct {
+		err  error
+		want string
+	}{
+		"NoError": {},
+		"OtherError": {
+			err: errors.New("boom"),
+		},
+		"Locked": {
+			err:  errors.Wrap(errLockedScope, "cannot delete"),
+			want: scope,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, LockedScope(tc.err)); diff != "" {
+				t.Errorf("LockedScope(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestExplain(t *testing.T) {
+	errBoom := errors.New("boom")
+	found := []locks.ManagementLockObject{
+		{Name: to.StringPtr("do-not-delete"), ManagementLockProperties: &locks.ManagementLockProperties{Level: locks.CanNotDelete}},
+		{Name: to.StringPtr("freeze"), ManagementLockProperties: &locks.ManagementLockProperties{Level: locks.ReadOnly}},
+	}
+
+	cases := map[string]struct {
+		reason    string
+		newLister NewListerFn
+		err       error
+		want      error
+	}{
+		"NotLocked": {
+			reason: "Errors that were not caused by a lock should be returned unchanged",
+			err:    errBoom,
+			want:   errBoom,
+		},
+		"NewListerError": {
+			reason: "Errors should be returned unchanged if their locks cannot be listed",
+			newLister: func(_ context.Context, _ resource.Managed) (Lister, error) {
+				return nil, errBoom
+			},
+			err:  errLockedScope,
+			want: errLockedScope,
+		},
+		"NoLocks": {
+			reason: "Errors should be returned unchanged if no locks are found",
+			newLister: func(_ context.Context, _ resource.Managed) (Lister, error) {
+				return listFn(func(_ context.Context, _ string) ([]locks.ManagementLockObject, error) { return nil, nil }), nil
+			},
+			err:  errLockedScope,
+			want: errLockedScope,
+		},
+		"Explained": {
+			reason: "Errors caused by locks should name the locks of the locked scope",
+			newLister: func(_ context.Context, _ resource.Managed) (Lister, error) {
+				return listFn(func(_ context.Context, s string) ([]locks.ManagementLockObject, error) {
+					if s != scope {
+						t.Errorf("List(...): want scope %q, got %q", scope, s)
+					}
+					return found, nil
+				}), nil
+			},
+			err:  errLockedScope,
+			want: errors.Wrapf(errLockedScope, errLocked, scope, `CanNotDelete lock "do-not-delete", ReadOnly lock "freeze"`),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := NewConnecter(managed.ExternalConnectorFn(func(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+				return &managed.ExternalClientFns{
+					CreateFn: func(_ context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+						return managed.ExternalCreation{}, tc.err
+					},
+					DeleteFn: func(_ context.Context, _ resource.Managed) error {
+						return tc.err
+					},
+				}, nil
+			}), tc.newLister)
+			e, err := c.Connect(context.Background(), &fake.Managed{})
+			if err != nil {
+				t.Fatalf("Connect(...): %s", err)
+			}
+
+			_, err = e.Create(context.Background(), &fake.Managed{})
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			err = e.Delete(context.Background(), &fake.Managed{})
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package management lets managed resources observe Azure resources without
+// ever creating, updating or deleting them, e.g. to reference a centrally
+// managed virtual network from Crossplane without risking its modification.
+package management
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// AnnotationKeyPolicy is the annotation that sets the management policy of a
+// managed resource.
+const AnnotationKeyPolicy = "azure.crossplane.io/management-policy"
+
+// A Policy determines which operations the controller of a managed resource
+// may perform on its Azure resource.
+type Policy string
+
+// Management policies.
+const (
+	// PolicyDefault managed resources create, update and delete their Azure
+	// resource as usual.
+	PolicyDefault Policy = "Default"
+
+	// PolicyObserveOnly managed resources only observe their Azure resource,
+	// which must already exist, and populate their status and connection
+	// details from it. Deleting an ObserveOnly managed resource leaves its
+	// Azure resource intact.
+	PolicyObserveOnly Policy = "ObserveOnly"
+)
+
+// Error strings.
+const (
+	errObserveOnly = "cannot %s external resource of an ObserveOnly managed resource"
+	errNotExist    = "external resource of an ObserveOnly managed resource does not exist"
+)
+
+// PolicyOf returns the management policy of the supplied object.
+func PolicyOf(o metav1.Object) Policy {
+	if Policy(o.GetAnnotations()[AnnotationKeyPolicy]) == PolicyObserveOnly {
+		return PolicyObserveOnly
+	}
+	return PolicyDefault
+}
+
+// IsObserveOnly returns true if the supplied object only observes its Azure
+// resource.
+func IsObserveOnly(o metav1.Object) bool {
+	return PolicyOf(o) == PolicyObserveOnly
+}
+
+// NewConnecter returns an ExternalConnecter whose clients never create, update
+// or delete the external resources of ObserveOnly managed resources.
+func NewConnecter(c managed.ExternalConnecter) managed.ExternalConnecter {
+	return &connecter{ExternalConnecter: c}
+}
+
+type connecter struct {
+	managed.ExternalConnecter
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	e, err := c.ExternalConnecter.Connect(ctx, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &external{ExternalClient: e}, nil
+}
+
+type external struct {
+	managed.ExternalClient
+}
+
+// Observe the external resource of the supplied managed resource. The
+// external resource of an ObserveOnly managed resource is always up to date,
+// so it is never updated, and does not exist once the managed resource is
+// deleted, so it is never deleted.
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	o, err := e.ExternalClient.Observe(ctx, mg)
+	if err != nil || !IsObserveOnly(mg) {
+		return o, err
+	}
+	if meta.WasDeleted(mg) {
+		o.ResourceExists = false
+	}
+	o.ResourceUpToDate = true
+	return o, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	if IsObserveOnly(mg) {
+		return managed.ExternalCreation{}, errors.New(errNotExist)
+	}
+	return e.ExternalClient.Create(ctx, mg)
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	if IsObserveOnly(mg) {
+		return managed.ExternalUpdate{}, errors.Errorf(errObserveOnly, "update")
+	}
+	return e.ExternalClient.Update(ctx, mg)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	if IsObserveOnly(mg) {
+		return errors.Errorf(errObserveOnly, "delete")
+	}
+	return e.ExternalClient.Delete(ctx, mg)
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package management
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+var errBoom = errors.New("boom")
+
+func withPolicy(p Policy) *fake.Managed {
+	return &fake.Managed{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{AnnotationKeyPolicy: string(p)}}}
+}
+
+func deleted(mg *fake.Managed) *fake.Managed {
+	now := metav1.Now()
+	mg.SetDeletionTimestamp(&now)
+	return mg
+}
+
+// wrapped returns a client whose operations always succeed, and whose
+// observations report an existing external resource that is not up to date.
+func wrapped() managed.ExternalClient {
+	return &managed.ExternalClientFns{
+		ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+			return managed.ExternalObservation{ResourceExists: true}, nil
+		},
+		CreateFn: func(_ context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+			return managed.ExternalCreation{}, nil
+		},
+		UpdateFn: func(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+			return managed.ExternalUpdate{}, nil
+		},
+		DeleteFn: func(_ context.Context, _ resource.Managed) error { return nil },
+	}
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		e    managed.ExternalClient
+		mg   resource.Managed
+		want want
+	}{
+		"Default": {
+			e:    wrapped(),
+			mg:   withPolicy(PolicyDefault),
+			want: want{o: managed.ExternalObservation{ResourceExists: true}},
+		},
+		"ObserveOnly": {
+			e:    wrapped(),
+			mg:   withPolicy(PolicyObserveOnly),
+			want: want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"ObserveOnlyDeleted": {
+			e:    wrapped(),
+			mg:   deleted(withPolicy(PolicyObserveOnly)),
+			want: want{o: managed.ExternalObservation{ResourceExists: false, ResourceUpToDate: true}},
+		},
+		"ObserveError": {
+			e: &managed.ExternalClientFns{
+				ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+					return managed.ExternalObservation{}, errBoom
+				},
+			},
+			mg:   withPolicy(PolicyObserveOnly),
+			want: want{err: errBoom},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{ExternalClient: tc.e}
+			o, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMutations(t *testing.T) {
+	type want struct {
+		create error
+		update error
+		delete error
+	}
+
+	cases := map[string]struct {
+		mg   resource.Managed
+		want want
+	}{
+		"Default": {
+			mg: withPolicy(PolicyDefault),
+		},
+		"Unknown": {
+			mg: withPolicy("Sometimes"),
+		},
+		"ObserveOnly": {
+			mg: withPolicy(PolicyObserveOnly),
+			want: want{
+				create: errors.New(errNotExist),
+				update: errors.Errorf(errObserveOnly, "update"),
+				delete: errors.Errorf(errObserveOnly, "delete"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{ExternalClient: wrapped()}
+			_, err := e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.create, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Create(...): -want error, +got error:\n%s", diff)
+			}
+			_, err = e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.update, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Update(...): -want error, +got error:\n%s", diff)
+			}
+			err = e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.delete, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Delete(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/tracing"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Names of the metrics of Azure API requests.
+const (
+	MetricAzureRequests           = "crossplane_azure_api_requests_total"
+	MetricAzureRequestDuration    = "crossplane_azure_api_request_duration_seconds"
+	MetricAzureThrottledRequests  = "crossplane_azure_api_throttled_requests_total"
+	MetricAzureRateLimitRemaining = "crossplane_azure_api_ratelimit_remaining"
+)
+
+// Headers in which Azure Resource Manager returns how many more reads and
+// writes a subscription may send before it is throttled.
+const (
+	headerRemainingReads  = "x-ms-ratelimit-remaining-subscription-reads"
+	headerRemainingWrites = "x-ms-ratelimit-remaining-subscription-writes"
+)
+
+// CodeError is the code label of requests that got no response at all.
+const CodeError = "error"
+
+// AzureAPIMetrics records the number and the duration of the requests Azure
+// SDK clients send, by the API they are sent to and the status code of their
+// response. The API of an Azure Resource Manager request is its resource
+// provider namespace, e.g. Microsoft.Cache, and that of any other request is
+// its host. It also records the requests Azure throttled, and how many more
+// requests each subscription may send before Azure throttles it.
+type AzureAPIMetrics struct {
+	requests  *prometheus.CounterVec
+	duration  *prometheus.HistogramVec
+	throttled *prometheus.CounterVec
+	remaining *prometheus.GaugeVec
+}
+
+// NewAzureAPIMetrics returns metrics of Azure API requests.
+func NewAzureAPIMetrics() *AzureAPIMetrics {
+	return &AzureAPIMetrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: MetricAzureRequests,
+			Help: "Number of requests sent to Azure APIs, by the code of their response.",
+		}, []string{"api", "method", "code"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    MetricAzureRequestDuration,
+			Help:    "Duration of requests sent to Azure APIs, until their response headers are read.",
+			Buckets: prometheus.ExponentialBuckets(0.025, 2, 10),
+		}, []string{"api", "method"}),
+		throttled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: MetricAzureThrottledRequests,
+			Help: "Number of requests sent to Azure APIs that were throttled, i.e. that got a 429 response.",
+		}, []string{"api", "method"}),
+		remaining: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: MetricAzureRateLimitRemaining,
+			Help: "Number of reads or writes a subscription may send to Azure Resource Manager before it is throttled, as of its latest response.",
+		}, []string{"subscription", "operation"}),
+	}
+}
+
+// Describe the metrics of Azure API requests.
+func (m *AzureAPIMetrics) Describe(ch chan<- *prometheus.Desc) {
+	m.requests.Describe(ch)
+	m.duration.Describe(ch)
+	m.throttled.Describe(ch)
+	m.remaining.Describe(ch)
+}
+
+// Collect the metrics of Azure API requests.
+func (m *AzureAPIMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.requests.Collect(ch)
+	m.duration.Collect(ch)
+	m.throttled.Collect(ch)
+	m.remaining.Collect(ch)
+}
+
+// Enable records the requests of every Azure SDK client that does not have its
+// own Sender. It registers a go-autorest tracer, whose transport the clients
+// send their requests through. The requests are sent through the transports
+// the supplied functions wrap around the transport of the clients, e.g. to
+// inject faults, so that the metrics count what the clients see.
+func (m *AzureAPIMetrics) Enable(wrap ...func(http.RoundTripper) http.RoundTripper) {
+	tracing.Register(&tracer{metrics: m, wrap: wrap})
+}
+
+type tracer struct {
+	metrics *AzureAPIMetrics
+	wrap    []func(http.RoundTripper) http.RoundTripper
+}
+
+func (t *tracer) NewTransport(base *http.Transport) http.RoundTripper {
+	var rt http.RoundTripper = base
+	for _, fn := range t.wrap {
+		rt = fn(rt)
+	}
+	return t.metrics.NewTransport(rt)
+}
+
+func (t *tracer) StartSpan(ctx context.Context, _ string) context.Context { return ctx }
+
+func (t *tracer) EndSpan(_ context.Context, _ int, _ error) {}
+
+// NewTransport returns a transport that records the requests it sends through
+// the supplied transport.
+func (m *AzureAPIMetrics) NewTransport(base http.RoundTripper) http.RoundTripper {
+	return &transport{base: base, metrics: m, now: time.Now}
+}
+
+type transport struct {
+	base    http.RoundTripper
+	metrics *AzureAPIMetrics
+	now     func() time.Time
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	api := API(req)
+	started := t.now()
+	rsp, err := t.base.RoundTrip(req)
+	t.metrics.duration.WithLabelValues(api, req.Method).Observe(t.now().Sub(started).Seconds())
+
+	code := CodeError
+	if err == nil {
+		code = strconv.Itoa(rsp.StatusCode)
+		t.metrics.observeRateLimit(req, rsp)
+	}
+	t.metrics.requests.WithLabelValues(api, req.Method, code).Inc()
+	return rsp, err
+}
+
+func (m *AzureAPIMetrics) observeRateLimit(req *http.Request, rsp *http.Response) {
+	if rsp.StatusCode == http.StatusTooManyRequests {
+		m.throttled.WithLabelValues(API(req), req.Method).Inc()
+	}
+	sub := Subscription(req)
+	if sub == "" {
+		return
+	}
+	for op, h := range map[string]string{"reads": headerRemainingReads, "writes": headerRemainingWrites} {
+		if n, err := strconv.ParseFloat(rsp.Header.Get(h), 64); err == nil {
+			m.remaining.WithLabelValues(sub, op).Set(n)
+		}
+	}
+}
+
+// Subscription returns the subscription the supplied Azure Resource Manager
+// request is sent to, or an empty string if it is not sent to one.
+func Subscription(req *http.Request) string {
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	if len(segments) < 2 || !strings.EqualFold(segments[0], "subscriptions") {
+		return ""
+	}
+	return segments[1]
+}
+
+// API returns the API the supplied request is sent to; the resource provider
+// namespace of an Azure Resource Manager request, or the host of any other
+// request.
+func API(req *http.Request) string {
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	if len(segments) == 0 || !strings.EqualFold(segments[0], "subscriptions") {
+		return req.URL.Hostname()
+	}
+	// Extension resources have several providers segments, e.g. the locks of
+	// a resource. The last one is the provider of the requested resource.
+	for i := len(segments) - 2; i >= 0; i-- {
+		if strings.EqualFold(segments[i], "providers") {
+			return segments[i+1]
+		}
+	}
+	// Subscriptions and resource groups are resources of Microsoft.Resources.
+	return "Microsoft.Resources"
+}
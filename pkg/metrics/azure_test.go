@@ -0,0 +1,121 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+type roundTripFn func(r *http.Request) (*http.Response, error)
+
+func (fn roundTripFn) RoundTrip(r *http.Request) (*http.Response, error) { return fn(r) }
+
+func TestAPI(t *testing.T) {
+	cases := map[string]struct {
+		url  string
+		want string
+	}{
+		"Resource": {
+			url:  "https://management.azure.com/subscriptions/cool/resourceGroups/rg/providers/Microsoft.Cache/Redis/cool?api-version=2018-03-01",
+			want: "Microsoft.Cache",
+		},
+		"Operation": {
+			url:  "https://management.azure.com/subscriptions/cool/providers/Microsoft.DBforMySQL/locations/westeurope/azureAsyncOperation/op",
+			want: "Microsoft.DBforMySQL",
+		},
+		"ExtensionResource": {
+			url:  "https://management.azure.com/subscriptions/cool/resourceGroups/rg/providers/Microsoft.Cache/Redis/cool/providers/Microsoft.Authorization/locks/lock",
+			want: "Microsoft.Authorization",
+		},
+		"ResourceGroup": {
+			url:  "https://management.azure.com/subscriptions/cool/resourcegroups/rg",
+			want: "Microsoft.Resources",
+		},
+		"Token": {
+			url:  "https://login.microsoftonline.com/tenant/oauth2/token",
+			want: "login.microsoftonline.com",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, tc.url, nil)
+			if diff := cmp.Diff(tc.want, API(req)); diff != "" {
+				t.Errorf("API(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTransport(t *testing.T) {
+	errBoom := errors.New("boom")
+	u := "https://management.azure.com/subscriptions/cool/resourceGroups/rg/providers/Microsoft.Cache/Redis/cool"
+
+	m := NewAzureAPIMetrics()
+	for _, code := range []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests} {
+		code := code
+		rt := m.NewTransport(roundTripFn(func(r *http.Request) (*http.Response, error) {
+			h := http.Header{}
+			h.Set(headerRemainingReads, "11999")
+			return &http.Response{StatusCode: code, Header: h, Request: r}, nil
+		}))
+		req, _ := http.NewRequest(http.MethodGet, u, nil)
+		if _, err := rt.RoundTrip(req); err != nil {
+			t.Fatalf("RoundTrip(...): %s", err)
+		}
+	}
+	rt := m.NewTransport(roundTripFn(func(r *http.Request) (*http.Response, error) { return nil, errBoom }))
+	req, _ := http.NewRequest(http.MethodPut, u, nil)
+	_, err := rt.RoundTrip(req)
+	if diff := cmp.Diff(errBoom, err, test.EquateErrors()); diff != "" {
+		t.Errorf("RoundTrip(...): -want error, +got error:\n%s", diff)
+	}
+
+	want := `
+# HELP crossplane_azure_api_requests_total Number of requests sent to Azure APIs, by the code of their response.
+# TYPE crossplane_azure_api_requests_total counter
+crossplane_azure_api_requests_total{api="Microsoft.Cache",code="200",method="GET"} 2
+crossplane_azure_api_requests_total{api="Microsoft.Cache",code="429",method="GET"} 1
+crossplane_azure_api_requests_total{api="Microsoft.Cache",code="error",method="PUT"} 1
+`
+	if err := testutil.CollectAndCompare(m, strings.NewReader(want), MetricAzureRequests); err != nil {
+		t.Errorf("Collect(...): %s", err)
+	}
+	if got := testutil.CollectAndCount(m, MetricAzureRequestDuration); got != 2 {
+		t.Errorf("Collect(...): want 2 request duration histograms, got %d", got)
+	}
+
+	want = `
+# HELP crossplane_azure_api_throttled_requests_total Number of requests sent to Azure APIs that were throttled, i.e. that got a 429 response.
+# TYPE crossplane_azure_api_throttled_requests_total counter
+crossplane_azure_api_throttled_requests_total{api="Microsoft.Cache",method="GET"} 1
+# HELP crossplane_azure_api_ratelimit_remaining Number of reads or writes a subscription may send to Azure Resource Manager before it is throttled, as of its latest response.
+# TYPE crossplane_azure_api_ratelimit_remaining gauge
+crossplane_azure_api_ratelimit_remaining{operation="reads",subscription="cool"} 11999
+`
+	if err := testutil.CollectAndCompare(m, strings.NewReader(want), MetricAzureThrottledRequests, MetricAzureRateLimitRemaining); err != nil {
+		t.Errorf("Collect(...): %s", err)
+	}
+}
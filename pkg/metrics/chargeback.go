@@ -0,0 +1,386 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics contains Prometheus collectors that describe the managed
+// resources of this provider and the requests it sends to Azure, and a Grafana
+// dashboard of them.
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	cachev1beta1 "github.com/crossplane/provider-azure/apis/cache/v1beta1"
+	cognitiveservicesv1alpha1 "github.com/crossplane/provider-azure/apis/cognitiveservices/v1alpha1"
+	computev1alpha3 "github.com/crossplane/provider-azure/apis/compute/v1alpha3"
+	databasev1alpha3 "github.com/crossplane/provider-azure/apis/database/v1alpha3"
+	databasev1beta1 "github.com/crossplane/provider-azure/apis/database/v1beta1"
+	eventhubv1alpha1 "github.com/crossplane/provider-azure/apis/eventhub/v1alpha1"
+	kubernetesv1alpha1 "github.com/crossplane/provider-azure/apis/kubernetes/v1alpha1"
+	kustov1alpha1 "github.com/crossplane/provider-azure/apis/kusto/v1alpha1"
+	monitorv1alpha1 "github.com/crossplane/provider-azure/apis/monitor/v1alpha1"
+	networkv1beta1 "github.com/crossplane/provider-azure/apis/network/v1beta1"
+	storagev1alpha3 "github.com/crossplane/provider-azure/apis/storage/v1alpha3"
+	storagesyncv1alpha1 "github.com/crossplane/provider-azure/apis/storagesync/v1alpha1"
+	"github.com/crossplane/provider-azure/apis/v1alpha3"
+)
+
+// DefaultTeamLabel is the label of a managed resource that identifies the
+// team that requested it. Crossplane propagates the labels of a claim to the
+// resources composed for it.
+const DefaultTeamLabel = "team"
+
+// Labels Crossplane propagates to the resources composed for a claim.
+const (
+	LabelKeyClaimNamespace = "crossplane.io/claim-namespace"
+	LabelKeyClaimName      = "crossplane.io/claim-name"
+)
+
+const listTimeout = 10 * time.Second
+
+var infoDesc = prometheus.NewDesc(
+	"crossplane_azure_managed_resource_info",
+	"Information about an Azure managed resource, for joining it to the team that requested it.",
+	[]string{"group", "kind", "name", "external_name", "claim_namespace", "claim_name", "team", "sku", "location"},
+	nil,
+)
+
+// A Kind of managed resource that is described by an info metric.
+type Kind struct {
+	GroupKind schema.GroupKind
+	List      resource.ManagedList
+
+	// SKU and Location return the SKU and the location of the supplied
+	// managed resource. Either may be nil if the kind has no such property.
+	SKU      func(mg resource.Managed) string
+	Location func(mg resource.Managed) string
+}
+
+// Kinds returns every kind of managed resource that is described by an info
+// metric.
+func Kinds() []Kind {
+	return []Kind{
+		{
+			GroupKind: v1alpha3.ResourceGroupGroupVersionKind.GroupKind(),
+			List:      &v1alpha3.ResourceGroupList{},
+			Location:  func(mg resource.Managed) string { return mg.(*v1alpha3.ResourceGroup).Spec.Location },
+		},
+		{
+			GroupKind: cachev1beta1.RedisGroupVersionKind.GroupKind(),
+			List:      &cachev1beta1.RedisList{},
+			SKU: func(mg resource.Managed) string {
+				s := mg.(*cachev1beta1.Redis).Spec.ForProvider.SKU
+				return fmt.Sprintf("%s_%s%d", s.Name, s.Family, s.Capacity)
+			},
+			Location: func(mg resource.Managed) string { return mg.(*cachev1beta1.Redis).Spec.ForProvider.Location },
+		},
+		{
+			GroupKind: cognitiveservicesv1alpha1.OpenAIDeploymentGroupVersionKind.GroupKind(),
+			List:      &cognitiveservicesv1alpha1.OpenAIDeploymentList{},
+			SKU: func(mg resource.Managed) string {
+				s := mg.(*cognitiveservicesv1alpha1.OpenAIDeployment).Spec.ForProvider.SKU
+				return fmt.Sprintf("%s_%d", s.Name, s.Capacity)
+			},
+		},
+		{
+			GroupKind: computev1alpha3.AKSClusterGroupVersionKind.GroupKind(),
+			List:      &computev1alpha3.AKSClusterList{},
+			SKU:       func(mg resource.Managed) string { return mg.(*computev1alpha3.AKSCluster).Spec.NodeVMSize },
+			Location:  func(mg resource.Managed) string { return mg.(*computev1alpha3.AKSCluster).Spec.Location },
+		},
+		{
+			GroupKind: computev1alpha3.DedicatedHostGroupGroupVersionKind.GroupKind(),
+			List:      &computev1alpha3.DedicatedHostGroupList{},
+			Location: func(mg resource.Managed) string {
+				return mg.(*computev1alpha3.DedicatedHostGroup).Spec.ForProvider.Location
+			},
+		},
+		{
+			GroupKind: computev1alpha3.DedicatedHostGroupVersionKind.GroupKind(),
+			List:      &computev1alpha3.DedicatedHostList{},
+			SKU:       func(mg resource.Managed) string { return mg.(*computev1alpha3.DedicatedHost).Spec.ForProvider.SKU },
+			Location:  func(mg resource.Managed) string { return mg.(*computev1alpha3.DedicatedHost).Spec.ForProvider.Location },
+		},
+		{
+			GroupKind: computev1alpha3.CapacityReservationGroupGroupVersionKind.GroupKind(),
+			List:      &computev1alpha3.CapacityReservationGroupList{},
+			Location: func(mg resource.Managed) string {
+				return mg.(*computev1alpha3.CapacityReservationGroup).Spec.ForProvider.Location
+			},
+		},
+		{
+			GroupKind: computev1alpha3.CapacityReservationGroupVersionKind.GroupKind(),
+			List:      &computev1alpha3.CapacityReservationList{},
+			SKU: func(mg resource.Managed) string {
+				return mg.(*computev1alpha3.CapacityReservation).Spec.ForProvider.SKU
+			},
+			Location: func(mg resource.Managed) string {
+				return mg.(*computev1alpha3.CapacityReservation).Spec.ForProvider.Location
+			},
+		},
+		{
+			GroupKind: computev1alpha3.ImageTemplateGroupVersionKind.GroupKind(),
+			List:      &computev1alpha3.ImageTemplateList{},
+			Location: func(mg resource.Managed) string {
+				return mg.(*computev1alpha3.ImageTemplate).Spec.ForProvider.Location
+			},
+		},
+		{
+			GroupKind: databasev1beta1.MySQLServerGroupVersionKind.GroupKind(),
+			List:      &databasev1beta1.MySQLServerList{},
+			SKU: func(mg resource.Managed) string {
+				return sqlSKU(mg.(*databasev1beta1.MySQLServer).Spec.ForProvider.SKU)
+			},
+			Location: func(mg resource.Managed) string { return mg.(*databasev1beta1.MySQLServer).Spec.ForProvider.Location },
+		},
+		{
+			GroupKind: databasev1beta1.PostgreSQLServerGroupVersionKind.GroupKind(),
+			List:      &databasev1beta1.PostgreSQLServerList{},
+			SKU: func(mg resource.Managed) string {
+				return sqlSKU(mg.(*databasev1beta1.PostgreSQLServer).Spec.ForProvider.SKU)
+			},
+			Location: func(mg resource.Managed) string {
+				return mg.(*databasev1beta1.PostgreSQLServer).Spec.ForProvider.Location
+			},
+		},
+		{
+			GroupKind: databasev1alpha3.MySQLServerFirewallRuleGroupVersionKind.GroupKind(),
+			List:      &databasev1alpha3.MySQLServerFirewallRuleList{},
+		},
+		{
+			GroupKind: databasev1alpha3.PostgreSQLServerFirewallRuleGroupVersionKind.GroupKind(),
+			List:      &databasev1alpha3.PostgreSQLServerFirewallRuleList{},
+		},
+		{
+			GroupKind: databasev1alpha3.MySQLServerVirtualNetworkRuleGroupVersionKind.GroupKind(),
+			List:      &databasev1alpha3.MySQLServerVirtualNetworkRuleList{},
+		},
+		{
+			GroupKind: databasev1alpha3.PostgreSQLServerVirtualNetworkRuleGroupVersionKind.GroupKind(),
+			List:      &databasev1alpha3.PostgreSQLServerVirtualNetworkRuleList{},
+		},
+		{
+			GroupKind: databasev1alpha3.CosmosDBAccountGroupVersionKind.GroupKind(),
+			List:      &databasev1alpha3.CosmosDBAccountList{},
+			SKU: func(mg resource.Managed) string {
+				return mg.(*databasev1alpha3.CosmosDBAccount).Spec.ForProvider.Properties.DatabaseAccountOfferType
+			},
+			Location: func(mg resource.Managed) string {
+				return mg.(*databasev1alpha3.CosmosDBAccount).Spec.ForProvider.Location
+			},
+		},
+		{
+			GroupKind: eventhubv1alpha1.SchemaRegistryGroupGroupVersionKind.GroupKind(),
+			List:      &eventhubv1alpha1.SchemaRegistryGroupList{},
+		},
+		{
+			GroupKind: kubernetesv1alpha1.ConnectedClusterGroupVersionKind.GroupKind(),
+			List:      &kubernetesv1alpha1.ConnectedClusterList{},
+			Location: func(mg resource.Managed) string {
+				return mg.(*kubernetesv1alpha1.ConnectedCluster).Spec.ForProvider.Location
+			},
+		},
+		{
+			GroupKind: kustov1alpha1.KustoClusterGroupVersionKind.GroupKind(),
+			List:      &kustov1alpha1.KustoClusterList{},
+			SKU:       func(mg resource.Managed) string { return mg.(*kustov1alpha1.KustoCluster).Spec.ForProvider.SKU.Name },
+			Location: func(mg resource.Managed) string {
+				return mg.(*kustov1alpha1.KustoCluster).Spec.ForProvider.Location
+			},
+		},
+		{
+			GroupKind: kustov1alpha1.KustoDatabaseGroupVersionKind.GroupKind(),
+			List:      &kustov1alpha1.KustoDatabaseList{},
+			Location: func(mg resource.Managed) string {
+				return mg.(*kustov1alpha1.KustoDatabase).Spec.ForProvider.Location
+			},
+		},
+		{
+			GroupKind: monitorv1alpha1.AzureMonitorPrivateLinkScopeGroupVersionKind.GroupKind(),
+			List:      &monitorv1alpha1.AzureMonitorPrivateLinkScopeList{},
+		},
+		{
+			GroupKind: monitorv1alpha1.AzureMonitorPrivateLinkScopedResourceGroupVersionKind.GroupKind(),
+			List:      &monitorv1alpha1.AzureMonitorPrivateLinkScopedResourceList{},
+		},
+		{
+			GroupKind: monitorv1alpha1.AzureMonitorWorkspaceGroupVersionKind.GroupKind(),
+			List:      &monitorv1alpha1.AzureMonitorWorkspaceList{},
+			Location: func(mg resource.Managed) string {
+				return mg.(*monitorv1alpha1.AzureMonitorWorkspace).Spec.ForProvider.Location
+			},
+		},
+		{
+			GroupKind: monitorv1alpha1.DataCollectionEndpointGroupVersionKind.GroupKind(),
+			List:      &monitorv1alpha1.DataCollectionEndpointList{},
+			Location: func(mg resource.Managed) string {
+				return mg.(*monitorv1alpha1.DataCollectionEndpoint).Spec.ForProvider.Location
+			},
+		},
+		{
+			GroupKind: monitorv1alpha1.DataCollectionRuleGroupVersionKind.GroupKind(),
+			List:      &monitorv1alpha1.DataCollectionRuleList{},
+			Location: func(mg resource.Managed) string {
+				return mg.(*monitorv1alpha1.DataCollectionRule).Spec.ForProvider.Location
+			},
+		},
+		{
+			GroupKind: monitorv1alpha1.DataCollectionRuleAssociationGroupVersionKind.GroupKind(),
+			List:      &monitorv1alpha1.DataCollectionRuleAssociationList{},
+		},
+		{
+			GroupKind: networkv1beta1.VirtualNetworkGroupVersionKind.GroupKind(),
+			List:      &networkv1beta1.VirtualNetworkList{},
+			Location:  func(mg resource.Managed) string { return mg.(*networkv1beta1.VirtualNetwork).Spec.ForProvider.Location },
+		},
+		{
+			GroupKind: networkv1beta1.SubnetGroupVersionKind.GroupKind(),
+			List:      &networkv1beta1.SubnetList{},
+		},
+		{
+			GroupKind: storagev1alpha3.AccountGroupVersionKind.GroupKind(),
+			List:      &storagev1alpha3.AccountList{},
+			SKU: func(mg resource.Managed) string {
+				if s := mg.(*storagev1alpha3.Account).Spec.StorageAccountSpec; s != nil && s.Sku != nil {
+					return string(s.Sku.Name)
+				}
+				return ""
+			},
+			Location: func(mg resource.Managed) string {
+				if s := mg.(*storagev1alpha3.Account).Spec.StorageAccountSpec; s != nil {
+					return s.Location
+				}
+				return ""
+			},
+		},
+		{
+			GroupKind: storagev1alpha3.ContainerGroupVersionKind.GroupKind(),
+			List:      &storagev1alpha3.ContainerList{},
+		},
+		{
+			GroupKind: storagesyncv1alpha1.StorageSyncServiceGroupVersionKind.GroupKind(),
+			List:      &storagesyncv1alpha1.StorageSyncServiceList{},
+			Location: func(mg resource.Managed) string {
+				return mg.(*storagesyncv1alpha1.StorageSyncService).Spec.ForProvider.Location
+			},
+		},
+		{
+			GroupKind: storagesyncv1alpha1.SyncGroupGroupVersionKind.GroupKind(),
+			List:      &storagesyncv1alpha1.SyncGroupList{},
+		},
+		{
+			GroupKind: storagesyncv1alpha1.CloudEndpointGroupVersionKind.GroupKind(),
+			List:      &storagesyncv1alpha1.CloudEndpointList{},
+		},
+	}
+}
+
+// sqlSKU returns the SKU name Azure uses for the supplied SQL server SKU,
+// e.g. GP_Gen5_8.
+func sqlSKU(s databasev1beta1.SKU) string {
+	tiers := map[string]string{"Basic": "B", "GeneralPurpose": "GP", "MemoryOptimized": "MO"}
+	return tiers[s.Tier] + "_" + s.Family + "_" + strconv.Itoa(s.Capacity)
+}
+
+// A ChargebackCollector exports an info metric for every managed resource that
+// carries the namespace and name of the claim it was requested by, the team
+// that requested it, its SKU, and its location. Chargeback tooling can join
+// these with the cost of the underlying Azure resources.
+type ChargebackCollector struct {
+	kube      client.Reader
+	kinds     []Kind
+	teamLabel string
+	log       logging.Logger
+}
+
+// A ChargebackCollectorOption configures a ChargebackCollector.
+type ChargebackCollectorOption func(*ChargebackCollector)
+
+// WithTeamLabel configures the label that identifies the team that requested
+// a managed resource. The label is read from the managed resource.
+func WithTeamLabel(l string) ChargebackCollectorOption {
+	return func(c *ChargebackCollector) {
+		c.teamLabel = l
+	}
+}
+
+// WithKinds configures the kinds of managed resources to describe.
+func WithKinds(k ...Kind) ChargebackCollectorOption {
+	return func(c *ChargebackCollector) {
+		c.kinds = k
+	}
+}
+
+// NewChargebackCollector returns a ChargebackCollector that lists managed
+// resources using the supplied reader, typically a cache backed client.
+func NewChargebackCollector(r client.Reader, l logging.Logger, o ...ChargebackCollectorOption) *ChargebackCollector {
+	c := &ChargebackCollector{kube: r, kinds: Kinds(), teamLabel: DefaultTeamLabel, log: l}
+	for _, fn := range o {
+		fn(c)
+	}
+	return c
+}
+
+// Describe the info metric of managed resources.
+func (c *ChargebackCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- infoDesc
+}
+
+// Collect an info metric for every managed resource. Kinds that cannot be
+// listed are skipped so that they don't prevent the others from being
+// collected.
+func (c *ChargebackCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), listTimeout)
+	defer cancel()
+
+	for _, k := range c.kinds {
+		l := k.List.DeepCopyObject().(resource.ManagedList)
+		if err := c.kube.List(ctx, l); err != nil {
+			c.log.Debug("Cannot list managed resources", "kind", k.GroupKind.String(), "error", err)
+			continue
+		}
+		for _, mg := range l.GetItems() {
+			ch <- prometheus.MustNewConstMetric(infoDesc, prometheus.GaugeValue, 1,
+				k.GroupKind.Group,
+				k.GroupKind.Kind,
+				mg.GetName(),
+				meta.GetExternalName(mg),
+				mg.GetLabels()[LabelKeyClaimNamespace],
+				mg.GetLabels()[LabelKeyClaimName],
+				mg.GetLabels()[c.teamLabel],
+				value(k.SKU, mg),
+				value(k.Location, mg),
+			)
+		}
+	}
+}
+
+func value(fn func(mg resource.Managed) string, mg resource.Managed) string {
+	if fn == nil {
+		return ""
+	}
+	return fn(mg)
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	cachev1beta1 "github.com/crossplane/provider-azure/apis/cache/v1beta1"
+	networkv1beta1 "github.com/crossplane/provider-azure/apis/network/v1beta1"
+)
+
+func TestChargebackCollector(t *testing.T) {
+	redis := cachev1beta1.Redis{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cool-redis",
+			Labels: map[string]string{
+				LabelKeyClaimNamespace: "cool-ns",
+				LabelKeyClaimName:      "cool-claim",
+				"owner":                "cool-team",
+			},
+		},
+		Spec: cachev1beta1.RedisSpec{
+			ForProvider: cachev1beta1.RedisParameters{
+				Location: "westeurope",
+				SKU:      cachev1beta1.SKU{Name: "Standard", Family: "C", Capacity: 1},
+			},
+		},
+	}
+	meta.SetExternalName(&redis, "cool-external")
+
+	kube := &test.MockClient{
+		MockList: func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+			switch l := obj.(type) {
+			case *cachev1beta1.RedisList:
+				l.Items = []cachev1beta1.Redis{redis}
+				return nil
+			default:
+				return errors.New("boom")
+			}
+		},
+	}
+
+	kinds := Kinds()
+	c := NewChargebackCollector(kube, logging.NewNopLogger(), WithTeamLabel("owner"), WithKinds(kinds[1], Kind{
+		GroupKind: networkv1beta1.SubnetGroupVersionKind.GroupKind(),
+		List:      &networkv1beta1.SubnetList{},
+	}))
+
+	want := `
+# HELP crossplane_azure_managed_resource_info Information about an Azure managed resource, for joining it to the team that requested it.
+# TYPE crossplane_azure_managed_resource_info gauge
+crossplane_azure_managed_resource_info{claim_name="cool-claim",claim_namespace="cool-ns",external_name="cool-external",group="cache.azure.crossplane.io",kind="Redis",location="westeurope",name="cool-redis",sku="Standard_C1",team="cool-team"} 1
+`
+	if err := testutil.CollectAndCompare(c, strings.NewReader(want)); err != nil {
+		t.Errorf("CollectAndCompare(...): %s", err)
+	}
+}
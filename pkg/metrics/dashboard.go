@@ -0,0 +1,303 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// DashboardPath is the path of the metrics server the Grafana dashboard of
+// this provider is served at.
+const DashboardPath = "/dashboard.json"
+
+// DashboardUID is the unique ID of the Grafana dashboard of this provider.
+// Importing the dashboard again replaces the previously imported one.
+const DashboardUID = "crossplane-provider-azure"
+
+const (
+	panelWidth  = 12
+	panelHeight = 8
+)
+
+// A Dashboard of Grafana. Only the fields this provider uses are modelled.
+type Dashboard struct {
+	UID           string     `json:"uid"`
+	Title         string     `json:"title"`
+	Tags          []string   `json:"tags"`
+	Editable      bool       `json:"editable"`
+	SchemaVersion int        `json:"schemaVersion"`
+	Refresh       string     `json:"refresh"`
+	Time          TimeRange  `json:"time"`
+	Templating    Templating `json:"templating"`
+	Panels        []Panel    `json:"panels"`
+}
+
+// A TimeRange of a Dashboard.
+type TimeRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Templating of a Dashboard.
+type Templating struct {
+	List []Variable `json:"list"`
+}
+
+// A Variable of a Dashboard that its queries may refer to as $name.
+type Variable struct {
+	Name       string `json:"name"`
+	Label      string `json:"label"`
+	Type       string `json:"type"`
+	Query      string `json:"query"`
+	Datasource string `json:"datasource,omitempty"`
+	Refresh    int    `json:"refresh,omitempty"`
+	IncludeAll bool   `json:"includeAll,omitempty"`
+	Multi      bool   `json:"multi,omitempty"`
+}
+
+// A Panel of a Dashboard.
+type Panel struct {
+	ID          int         `json:"id"`
+	Title       string      `json:"title"`
+	Description string      `json:"description,omitempty"`
+	Type        string      `json:"type"`
+	Datasource  string      `json:"datasource"`
+	GridPos     GridPos     `json:"gridPos"`
+	FieldConfig FieldConfig `json:"fieldConfig"`
+	Targets     []Target    `json:"targets"`
+}
+
+// GridPos is the position and the size of a Panel.
+type GridPos struct {
+	H int `json:"h"`
+	W int `json:"w"`
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// FieldConfig of a Panel.
+type FieldConfig struct {
+	Defaults FieldDefaults `json:"defaults"`
+}
+
+// FieldDefaults of a Panel.
+type FieldDefaults struct {
+	Unit string `json:"unit,omitempty"`
+}
+
+// A Target is a Prometheus query of a Panel.
+type Target struct {
+	RefID        string `json:"refId"`
+	Expr         string `json:"expr"`
+	LegendFormat string `json:"legendFormat"`
+}
+
+// selector of the metrics of the deployments of this provider.
+const selector = `job=~"$job"`
+
+// NewDashboard returns the Grafana dashboard of this provider. It shows the
+// depth of the work queue and the duration and rate of the reconciles of each
+// kind of managed resource, which controller-runtime exports, the factor their
+// poll interval is lengthened by, the errors and duration of the operations on
+// their external resources, and the rate, errors, duration and throttling of
+// Azure API requests.
+func NewDashboard() *Dashboard {
+	panels := []Panel{
+		{
+			Title:       "Work queue depth",
+			Description: "Managed resources waiting to be reconciled, by controller.",
+			FieldConfig: FieldConfig{Defaults: FieldDefaults{Unit: "short"}},
+			Targets: []Target{{
+				Expr:         fmt.Sprintf(`sum by (name) (workqueue_depth{%s})`, selector),
+				LegendFormat: "{{name}}",
+			}},
+		},
+		{
+			Title:       "Work queue latency (p99)",
+			Description: "Time managed resources wait in the work queue before they are reconciled, by controller.",
+			FieldConfig: FieldConfig{Defaults: FieldDefaults{Unit: "s"}},
+			Targets: []Target{{
+				Expr:         fmt.Sprintf(`histogram_quantile(0.99, sum by (name, le) (rate(workqueue_queue_duration_seconds_bucket{%s}[5m])))`, selector),
+				LegendFormat: "{{name}}",
+			}},
+		},
+		{
+			Title:       "Poll interval factor",
+			Description: "Factor the poll interval of controllers whose work queue is consistently saturated is lengthened by.",
+			FieldConfig: FieldConfig{Defaults: FieldDefaults{Unit: "short"}},
+			Targets: []Target{{
+				Expr:         fmt.Sprintf(`max by (controller) (crossplane_azure_resync_factor{%s})`, selector),
+				LegendFormat: "{{controller}}",
+			}},
+		},
+		{
+			Title:       "Reconcile duration",
+			Description: "Duration of reconciles, by controller.",
+			FieldConfig: FieldConfig{Defaults: FieldDefaults{Unit: "s"}},
+			Targets: []Target{
+				{
+					Expr:         fmt.Sprintf(`histogram_quantile(0.5, sum by (controller, le) (rate(controller_runtime_reconcile_time_seconds_bucket{%s}[5m])))`, selector),
+					LegendFormat: "{{controller}} p50",
+				},
+				{
+					Expr:         fmt.Sprintf(`histogram_quantile(0.99, sum by (controller, le) (rate(controller_runtime_reconcile_time_seconds_bucket{%s}[5m])))`, selector),
+					LegendFormat: "{{controller}} p99",
+				},
+			},
+		},
+		{
+			Title:       "Reconciles",
+			Description: "Rate of reconciles, by controller and result.",
+			FieldConfig: FieldConfig{Defaults: FieldDefaults{Unit: "ops"}},
+			Targets: []Target{{
+				Expr:         fmt.Sprintf(`sum by (controller, result) (rate(controller_runtime_reconcile_total{%s}[5m]))`, selector),
+				LegendFormat: "{{controller}} {{result}}",
+			}},
+		},
+		{
+			Title:       "External operation errors",
+			Description: "Rate of failed operations on external resources, by kind of managed resource and operation.",
+			FieldConfig: FieldConfig{Defaults: FieldDefaults{Unit: "ops"}},
+			Targets: []Target{{
+				Expr:         fmt.Sprintf(`sum by (kind, operation) (rate(%s{%s, result="%s"}[5m]))`, MetricExternalOperations, selector, ResultError),
+				LegendFormat: "{{kind}} {{operation}}",
+			}},
+		},
+		{
+			Title:       "External operation duration (p99)",
+			Description: "Duration of operations on external resources, by kind of managed resource and operation.",
+			FieldConfig: FieldConfig{Defaults: FieldDefaults{Unit: "s"}},
+			Targets: []Target{{
+				Expr:         fmt.Sprintf(`histogram_quantile(0.99, sum by (kind, operation, le) (rate(%s_bucket{%s}[5m])))`, MetricExternalOperationDuration, selector),
+				LegendFormat: "{{kind}} {{operation}}",
+			}},
+		},
+		{
+			Title:       "Azure API requests",
+			Description: "Rate of requests sent to Azure APIs, by API and response code.",
+			FieldConfig: FieldConfig{Defaults: FieldDefaults{Unit: "reqps"}},
+			Targets: []Target{{
+				Expr:         fmt.Sprintf(`sum by (api, code) (rate(%s{%s}[5m]))`, MetricAzureRequests, selector),
+				LegendFormat: "{{api}} {{code}}",
+			}},
+		},
+		{
+			Title:       "Azure API error rate",
+			Description: "Fraction of requests sent to Azure APIs that failed, by API. Throttled requests are shown separately, and requests for resources that do not exist yet are not errors.",
+			FieldConfig: FieldConfig{Defaults: FieldDefaults{Unit: "percentunit"}},
+			Targets: []Target{
+				{
+					Expr: fmt.Sprintf(`sum by (api) (rate(%[1]s{%[2]s, code=~"4..|5..|%[3]s", code!~"404|429"}[5m])) / sum by (api) (rate(%[1]s{%[2]s}[5m]))`,
+						MetricAzureRequests, selector, CodeError),
+					LegendFormat: "{{api}} errors",
+				},
+				{
+					Expr: fmt.Sprintf(`sum by (api) (rate(%[1]s{%[2]s, code="429"}[5m])) / sum by (api) (rate(%[1]s{%[2]s}[5m]))`,
+						MetricAzureRequests, selector),
+					LegendFormat: "{{api}} throttled",
+				},
+			},
+		},
+		{
+			Title:       "Azure API request duration (p99)",
+			Description: "Duration of requests sent to Azure APIs, by API.",
+			FieldConfig: FieldConfig{Defaults: FieldDefaults{Unit: "s"}},
+			Targets: []Target{{
+				Expr:         fmt.Sprintf(`histogram_quantile(0.99, sum by (api, le) (rate(%s_bucket{%s}[5m])))`, MetricAzureRequestDuration, selector),
+				LegendFormat: "{{api}}",
+			}},
+		},
+		{
+			Title:       "Azure API throttling",
+			Description: "Rate of requests Azure throttled, by API.",
+			FieldConfig: FieldConfig{Defaults: FieldDefaults{Unit: "reqps"}},
+			Targets: []Target{{
+				Expr:         fmt.Sprintf(`sum by (api) (rate(%s{%s}[5m]))`, MetricAzureThrottledRequests, selector),
+				LegendFormat: "{{api}}",
+			}},
+		},
+		{
+			Title:       "Azure Resource Manager requests remaining",
+			Description: "Reads and writes each subscription may send before Azure Resource Manager throttles it.",
+			FieldConfig: FieldConfig{Defaults: FieldDefaults{Unit: "short"}},
+			Targets: []Target{{
+				Expr:         fmt.Sprintf(`min by (subscription, operation) (%s{%s})`, MetricAzureRateLimitRemaining, selector),
+				LegendFormat: "{{subscription}} {{operation}}",
+			}},
+		},
+	}
+
+	for i := range panels {
+		p := &panels[i]
+		p.ID = i + 1
+		p.Type = "timeseries"
+		p.Datasource = "$datasource"
+		p.GridPos = GridPos{H: panelHeight, W: panelWidth, X: (i % 2) * panelWidth, Y: (i / 2) * panelHeight}
+		for j := range p.Targets {
+			p.Targets[j].RefID = string(rune('A' + j))
+		}
+	}
+
+	return &Dashboard{
+		UID:           DashboardUID,
+		Title:         "Crossplane Azure Provider",
+		Tags:          []string{"crossplane", "azure"},
+		Editable:      true,
+		SchemaVersion: 27,
+		Refresh:       "30s",
+		Time:          TimeRange{From: "now-6h", To: "now"},
+		Templating: Templating{List: []Variable{
+			{
+				Name:  "datasource",
+				Label: "Data source",
+				Type:  "datasource",
+				Query: "prometheus",
+			},
+			{
+				Name:       "job",
+				Label:      "Job",
+				Type:       "query",
+				Datasource: "$datasource",
+				Query:      fmt.Sprintf("label_values(%s, job)", MetricAzureRequests),
+				Refresh:    2,
+				IncludeAll: true,
+				Multi:      true,
+			},
+		}},
+		Panels: panels,
+	}
+}
+
+// A DashboardHandler serves the Grafana dashboard of this provider as JSON,
+// ready to be imported into Grafana.
+type DashboardHandler struct {
+	json []byte
+}
+
+// NewDashboardHandler returns a handler that serves the supplied dashboard.
+func NewDashboardHandler(d *Dashboard) (*DashboardHandler, error) {
+	j, err := json.MarshalIndent(d, "", "  ")
+	return &DashboardHandler{json: j}, err
+}
+
+// ServeHTTP serves the dashboard.
+func (h *DashboardHandler) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(h.json)
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDashboardHandler(t *testing.T) {
+	h, err := NewDashboardHandler(NewDashboard())
+	if err != nil {
+		t.Fatalf("NewDashboardHandler(...): %s", err)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, DashboardPath, nil))
+
+	if diff := cmp.Diff("application/json", rec.Header().Get("Content-Type")); diff != "" {
+		t.Errorf("ServeHTTP(...): -want content type, +got content type:\n%s", diff)
+	}
+	got := &Dashboard{}
+	if err := json.Unmarshal(rec.Body.Bytes(), got); err != nil {
+		t.Fatalf("json.Unmarshal(...): %s", err)
+	}
+	if diff := cmp.Diff(NewDashboard(), got); diff != "" {
+		t.Errorf("ServeHTTP(...): -want dashboard, +got dashboard:\n%s", diff)
+	}
+
+	// Every metric the dashboard is required to show must be queried by one
+	// of its panels, and every panel must have a unique ID and position.
+	exprs := []string{}
+	ids := map[int]bool{}
+	pos := map[GridPos]bool{}
+	for _, p := range got.Panels {
+		if ids[p.ID] || pos[p.GridPos] {
+			t.Errorf("Panel %q: duplicate ID %d or position %v", p.Title, p.ID, p.GridPos)
+		}
+		ids[p.ID], pos[p.GridPos] = true, true
+		for _, tg := range p.Targets {
+			exprs = append(exprs, tg.Expr)
+		}
+	}
+	for _, m := range []string{
+		"workqueue_depth",
+		"controller_runtime_reconcile_time_seconds_bucket",
+		MetricAzureRequests,
+		MetricAzureRequestDuration + "_bucket",
+		MetricAzureThrottledRequests,
+		MetricAzureRateLimitRemaining,
+		MetricExternalOperations,
+		MetricExternalOperationDuration + "_bucket",
+	} {
+		if !strings.Contains(strings.Join(exprs, "\n"), m+"{") {
+			t.Errorf("NewDashboard(): no panel queries %s", m)
+		}
+	}
+}
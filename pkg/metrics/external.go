@@ -0,0 +1,159 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// Names of the metrics of the operations on external resources.
+const (
+	MetricExternalOperations        = "crossplane_azure_external_operations_total"
+	MetricExternalOperationDuration = "crossplane_azure_external_operation_duration_seconds"
+)
+
+// Operations on external resources.
+const (
+	OperationConnect = "connect"
+	OperationObserve = "observe"
+	OperationCreate  = "create"
+	OperationUpdate  = "update"
+	OperationDelete  = "delete"
+)
+
+// Results of operations on external resources.
+const (
+	ResultSuccess = "success"
+	ResultError   = "error"
+)
+
+// ExternalMetrics records the number and the duration of the operations the
+// controllers of managed resources perform on their external resources, by
+// the kind of the managed resource and the result of the operation. An
+// operation may send any number of requests to Azure, which AzureAPIMetrics
+// records.
+type ExternalMetrics struct {
+	operations *prometheus.CounterVec
+	duration   *prometheus.HistogramVec
+	now        func() time.Time
+}
+
+// NewExternalMetrics returns metrics of operations on external resources.
+func NewExternalMetrics() *ExternalMetrics {
+	return &ExternalMetrics{
+		operations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: MetricExternalOperations,
+			Help: "Number of operations performed on the external resources of managed resources, by their result.",
+		}, []string{"kind", "operation", "result"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    MetricExternalOperationDuration,
+			Help:    "Duration of operations performed on the external resources of managed resources.",
+			Buckets: prometheus.ExponentialBuckets(0.025, 2, 12),
+		}, []string{"kind", "operation"}),
+		now: time.Now,
+	}
+}
+
+// DefaultExternalMetrics records the operations of all managed resource
+// controllers. The provider registers it before it sets up its controllers.
+var DefaultExternalMetrics = NewExternalMetrics()
+
+// Describe the metrics of operations on external resources.
+func (m *ExternalMetrics) Describe(ch chan<- *prometheus.Desc) {
+	m.operations.Describe(ch)
+	m.duration.Describe(ch)
+}
+
+// Collect the metrics of operations on external resources.
+func (m *ExternalMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.operations.Collect(ch)
+	m.duration.Collect(ch)
+}
+
+// observe records an operation on an external resource of the supplied kind
+// that started at the supplied time and returned the supplied error.
+func (m *ExternalMetrics) observe(kind, op string, started time.Time, err error) {
+	m.duration.WithLabelValues(kind, op).Observe(m.now().Sub(started).Seconds())
+	r := ResultSuccess
+	if err != nil {
+		r = ResultError
+	}
+	m.operations.WithLabelValues(kind, op, r).Inc()
+}
+
+// NewConnecter returns an ExternalConnecter whose operations on the external
+// resources of managed resources of the supplied kind, e.g.
+// Redis.cache.azure.crossplane.io, are recorded by the supplied metrics.
+func NewConnecter(c managed.ExternalConnecter, m *ExternalMetrics, kind string) managed.ExternalConnecter {
+	return &connecter{ExternalConnecter: c, metrics: m, kind: kind}
+}
+
+type connecter struct {
+	managed.ExternalConnecter
+	metrics *ExternalMetrics
+	kind    string
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	started := c.metrics.now()
+	e, err := c.ExternalConnecter.Connect(ctx, mg)
+	c.metrics.observe(c.kind, OperationConnect, started, err)
+	if err != nil {
+		return nil, err
+	}
+	return &external{ExternalClient: e, metrics: c.metrics, kind: c.kind}, nil
+}
+
+type external struct {
+	managed.ExternalClient
+	metrics *ExternalMetrics
+	kind    string
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	started := e.metrics.now()
+	o, err := e.ExternalClient.Observe(ctx, mg)
+	e.metrics.observe(e.kind, OperationObserve, started, err)
+	return o, err
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	started := e.metrics.now()
+	c, err := e.ExternalClient.Create(ctx, mg)
+	e.metrics.observe(e.kind, OperationCreate, started, err)
+	return c, err
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	started := e.metrics.now()
+	u, err := e.ExternalClient.Update(ctx, mg)
+	e.metrics.observe(e.kind, OperationUpdate, started, err)
+	return u, err
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	started := e.metrics.now()
+	err := e.ExternalClient.Delete(ctx, mg)
+	e.metrics.observe(e.kind, OperationDelete, started, err)
+	return err
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	cachev1beta1 "github.com/crossplane/provider-azure/apis/cache/v1beta1"
+)
+
+func TestConnecter(t *testing.T) {
+	errBoom := errors.New("boom")
+	m := NewExternalMetrics()
+	c := NewConnecter(managed.ExternalConnectorFn(func(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+		return &managed.ExternalClientFns{
+			ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+				return managed.ExternalObservation{ResourceExists: true}, nil
+			},
+			DeleteFn: func(_ context.Context, _ resource.Managed) error { return errBoom },
+		}, nil
+	}), m, cachev1beta1.RedisGroupKind)
+
+	mg := &cachev1beta1.Redis{}
+	e, err := c.Connect(context.Background(), mg)
+	if err != nil {
+		t.Fatalf("Connect(...): %s", err)
+	}
+	o, err := e.Observe(context.Background(), mg)
+	if diff := cmp.Diff(managed.ExternalObservation{ResourceExists: true}, o); diff != "" {
+		t.Errorf("Observe(...): -want, +got:\n%s", diff)
+	}
+	if err != nil {
+		t.Errorf("Observe(...): %s", err)
+	}
+	err = e.Delete(context.Background(), mg)
+	if diff := cmp.Diff(errBoom, err, test.EquateErrors()); diff != "" {
+		t.Errorf("Delete(...): -want error, +got error:\n%s", diff)
+	}
+
+	want := `
+# HELP crossplane_azure_external_operations_total Number of operations performed on the external resources of managed resources, by their result.
+# TYPE crossplane_azure_external_operations_total counter
+crossplane_azure_external_operations_total{kind="Redis.cache.azure.crossplane.io",operation="connect",result="success"} 1
+crossplane_azure_external_operations_total{kind="Redis.cache.azure.crossplane.io",operation="delete",result="error"} 1
+crossplane_azure_external_operations_total{kind="Redis.cache.azure.crossplane.io",operation="observe",result="success"} 1
+`
+	if err := testutil.CollectAndCompare(m, strings.NewReader(want), MetricExternalOperations); err != nil {
+		t.Errorf("Collect(...): %s", err)
+	}
+	if got := testutil.CollectAndCount(m, MetricExternalOperationDuration); got != 3 {
+		t.Errorf("Collect(...): want 3 operation duration histograms, got %d", got)
+	}
+}
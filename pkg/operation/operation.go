@@ -0,0 +1,113 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package operation records the errors Azure responds with to the operations
+// on external resources as events of their managed resources, so that they
+// are shown by kubectl describe. The managed resource reconciler already
+// records the successful operations and the errors of all operations, but
+// only under generic reasons such as CannotCreateExternalResource.
+package operation
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/redact"
+)
+
+// Operations whose errors are recorded.
+const (
+	OperationCreate = "Create"
+	OperationUpdate = "Update"
+	OperationDelete = "Delete"
+)
+
+// Reason returns the reason of the events that record the errors of the
+// supplied operation on the external resources of managed resources of the
+// supplied kind, e.g. SubnetCreateFailed.
+func Reason(kind, op string) event.Reason {
+	return event.Reason(kind + op + "Failed")
+}
+
+// Kind returns the kind of the supplied group kind, e.g. Subnet for
+// Subnet.network.azure.crossplane.io.
+func Kind(gk string) string {
+	return strings.SplitN(gk, ".", 2)[0]
+}
+
+// NewConnecter returns an ExternalConnecter whose clients record an event
+// with the code and the message of the error Azure responded with whenever
+// they fail to create, update or delete the external resource of a managed
+// resource of the supplied kind, e.g. Subnet.network.azure.crossplane.io.
+func NewConnecter(c managed.ExternalConnecter, r event.Recorder, kind string) managed.ExternalConnecter {
+	return &connecter{ExternalConnecter: c, record: r, kind: Kind(kind)}
+}
+
+type connecter struct {
+	managed.ExternalConnecter
+	record event.Recorder
+	kind   string
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	e, err := c.ExternalConnecter.Connect(ctx, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &external{ExternalClient: e, record: c.record, kind: c.kind}, nil
+}
+
+type external struct {
+	managed.ExternalClient
+	record event.Recorder
+	kind   string
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	c, err := e.ExternalClient.Create(ctx, mg)
+	e.failed(mg, OperationCreate, err)
+	return c, err
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	u, err := e.ExternalClient.Update(ctx, mg)
+	e.failed(mg, OperationUpdate, err)
+	return u, err
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	err := e.ExternalClient.Delete(ctx, mg)
+	e.failed(mg, OperationDelete, err)
+	return err
+}
+
+// failed records an event if the supplied error of an operation is one Azure
+// responded with. Other errors, e.g. those of the Kubernetes API server, are
+// only recorded by the managed resource reconciler.
+func (e *external) failed(mg resource.Managed, op string, err error) {
+	code, message := azure.ErrorCode(err)
+	if code == "" {
+		return
+	}
+	e.record.Event(mg, event.Warning(Reason(e.kind, op), errors.New(redact.String(code+": "+message))))
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/network/v1beta1"
+)
+
+var errBoom = errors.New("boom")
+
+type recorder struct {
+	events []event.Event
+}
+
+func (r *recorder) Event(_ runtime.Object, e event.Event) { r.events = append(r.events, e) }
+
+func (r *recorder) WithAnnotations(_ ...string) event.Recorder { return r }
+
+func azureError(code, message string) error {
+	err := autorest.NewErrorWithError(&azure.RequestError{ServiceError: &azure.ServiceError{Code: code, Message: message}},
+		"network.SubnetsClient", "CreateOrUpdate", nil, "Failure sending request")
+	return errors.Wrap(err, "cannot create subnet")
+}
+
+func TestCreate(t *testing.T) {
+	type want struct {
+		events []event.Event
+		err    error
+	}
+
+	invalid := azureError("NetcfgInvalidSubnet", "Subnet 'default' is not valid in virtual network 'vnet'.")
+
+	cases := map[string]struct {
+		err  error
+		want want
+	}{
+		"Success": {},
+		"AzureError": {
+			err: invalid,
+			want: want{
+				events: []event.Event{event.Warning("SubnetCreateFailed", errors.New("NetcfgInvalidSubnet: Subnet 'default' is not valid in virtual network 'vnet'."))},
+				err:    invalid,
+			},
+		},
+		"OtherError": {
+			err:  errBoom,
+			want: want{err: errBoom},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &recorder{}
+			e := &external{
+				ExternalClient: &managed.ExternalClientFns{
+					CreateFn: func(_ context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+						return managed.ExternalCreation{}, tc.err
+					},
+				},
+				record: r,
+				kind:   Kind(v1beta1.SubnetGroupKind),
+			}
+			_, err := e.Create(context.Background(), &v1beta1.Subnet{})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Create(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.events, r.events, test.EquateErrors()); diff != "" {
+				t.Errorf("Create(...): -want events, +got events:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package pause allows the reconciliation of managed resources to be paused,
+// for example while the Azure region they are in is having an incident, or
+// while they are remediated manually in the Azure portal.
+package pause
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-azure/apis"
+)
+
+// AnnotationKeyPaused is the annotation that pauses the reconciliation of a
+// managed resource when set to "true".
+const AnnotationKeyPaused = "azure.crossplane.io/paused"
+
+// AnnotationKeyCrossplanePaused is the annotation Crossplane uses to pause
+// the reconciliation of any resource. It is honored like AnnotationKeyPaused.
+const AnnotationKeyCrossplanePaused = "crossplane.io/paused"
+
+// TypePaused managed resources are not reconciled.
+const TypePaused xpv1.ConditionType = "Paused"
+
+// Reasons a managed resource is or is not paused.
+const (
+	ReasonPaused  xpv1.ConditionReason = "ReconcilePaused"
+	ReasonResumed xpv1.ConditionReason = "ReconcileResumed"
+)
+
+// Error strings.
+const (
+	errList         = "cannot list %s resources"
+	errPatch        = "cannot patch %s %q"
+	errUpdateStatus = "cannot update managed resource status"
+)
+
+// IsPaused returns true if the reconciliation of the supplied object is paused.
+func IsPaused(o metav1.Object) bool {
+	a := o.GetAnnotations()
+	return a[AnnotationKeyPaused] == "true" || a[AnnotationKeyCrossplanePaused] == "true"
+}
+
+// Paused returns a condition that indicates the reconciliation of a managed
+// resource is paused.
+func Paused() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypePaused,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPaused,
+	}
+}
+
+// Resumed returns a condition that indicates the reconciliation of a managed
+// resource was resumed.
+func Resumed() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypePaused,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonResumed,
+	}
+}
+
+// A Reconciler skips the reconciliation of paused managed resources, and of
+// those that do not match its label selector, and passes all other requests
+// on to the reconciler it wraps.
+type Reconciler struct {
+	client     client.Client
+	newManaged func() resource.Managed
+	wrapped    reconcile.Reconciler
+	selector   labels.Selector
+}
+
+// A ReconcilerOption configures a Reconciler.
+type ReconcilerOption func(*Reconciler)
+
+// WithSelector makes the Reconciler skip managed resources that do not match
+// the supplied label selector, as if they were paused. This allows several
+// deployments of the provider to run in one cluster, each reconciling only
+// the managed resources that carry its labels. All managed resources match
+// by default.
+func WithSelector(s labels.Selector) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.selector = s
+	}
+}
+
+// NewReconciler returns a Reconciler that wraps the supplied reconciler of
+// managed resources of the supplied kind.
+func NewReconciler(m ctrl.Manager, of resource.ManagedKind, r reconcile.Reconciler, o ...ReconcilerOption) *Reconciler {
+	nm := func() resource.Managed {
+		return resource.MustCreateObject(schema.GroupVersionKind(of), m.GetScheme()).(resource.Managed)
+	}
+	// Panic early if we've been asked to reconcile a resource kind that has
+	// not been registered with our controller manager's scheme.
+	_ = nm()
+
+	pr := &Reconciler{client: m.GetClient(), newManaged: nm, wrapped: r, selector: labels.Everything()}
+	for _, ro := range o {
+		ro(pr)
+	}
+	return pr
+}
+
+// Reconcile the supplied request unless the managed resource it is for is
+// paused or does not match the selector. Skipped resources are not requeued;
+// removing the annotation or adding the labels triggers their next
+// reconciliation. Paused managed resources report that they are paused until
+// they are resumed.
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	mg := r.newManaged()
+	if err := r.client.Get(ctx, req.NamespacedName, mg); err != nil {
+		// The wrapped reconciler handles managed resources that no longer
+		// exist, and errors getting them.
+		return r.wrapped.Reconcile(ctx, req)
+	}
+	if !r.selector.Matches(labels.Set(mg.GetLabels())) {
+		// This managed resource is reconciled by another deployment of the
+		// provider, which reports whether it is paused.
+		return reconcile.Result{}, nil
+	}
+
+	paused := mg.GetCondition(TypePaused).Status == corev1.ConditionTrue
+	switch {
+	case IsPaused(mg) && paused:
+		return reconcile.Result{}, nil
+	case IsPaused(mg):
+		mg.SetConditions(Paused())
+		return reconcile.Result{}, errors.Wrap(r.client.Status().Update(ctx, mg), errUpdateStatus)
+	case paused:
+		mg.SetConditions(Resumed())
+		if err := r.client.Status().Update(ctx, mg); err != nil {
+			return reconcile.Result{}, errors.Wrap(err, errUpdateStatus)
+		}
+	}
+	return r.wrapped.Reconcile(ctx, req)
+}
+
+// SetPaused pauses or resumes the reconciliation of every managed resource
+// known to the supplied scheme that matches the supplied label selector. It
+// returns the names of the resources it changed.
+func SetPaused(ctx context.Context, c client.Client, s *runtime.Scheme, sel labels.Selector, paused bool) ([]string, error) {
+	changed := make([]string, 0)
+	for _, l := range apis.ManagedLists(s) {
+		kind := strings.TrimSuffix(resource.MustGetKind(l, s).Kind, "List")
+		if err := c.List(ctx, l, client.MatchingLabelsSelector{Selector: sel}); err != nil {
+			return changed, errors.Wrapf(err, errList, kind)
+		}
+		for _, mg := range l.GetItems() {
+			if IsPaused(mg) == paused {
+				continue
+			}
+			p := client.MergeFrom(mg.DeepCopyObject().(client.Object))
+			if paused {
+				meta.AddAnnotations(mg, map[string]string{AnnotationKeyPaused: "true"})
+			} else {
+				meta.RemoveAnnotations(mg, AnnotationKeyPaused, AnnotationKeyCrossplanePaused)
+			}
+			if err := c.Patch(ctx, mg, p); err != nil {
+				return changed, errors.Wrapf(err, errPatch, kind, mg.GetName())
+			}
+			changed = append(changed, kind+"/"+mg.GetName())
+		}
+	}
+	return changed, nil
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pause
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/cache/v1beta1"
+)
+
+var _ reconcile.Reconciler = &Reconciler{}
+
+func scheme() *runtime.Scheme {
+	s := runtime.NewScheme()
+	_ = v1beta1.SchemeBuilder.AddToScheme(s)
+	return s
+}
+
+func TestReconcile(t *testing.T) {
+	errBoom := errors.New("boom")
+	delegated := reconcile.Result{RequeueAfter: time.Minute}
+	wrapped := reconcile.Func(func(_ context.Context, _ reconcile.Request) (reconcile.Result, error) {
+		return delegated, nil
+	})
+	withCondition := func(c xpv1.Condition) func(o client.Object) error {
+		return func(o client.Object) error {
+			o.(resource.Managed).SetConditions(c)
+			return nil
+		}
+	}
+	wantCondition := func(c xpv1.Condition) test.MockStatusUpdateFn {
+		return func(_ context.Context, o client.Object, _ ...client.UpdateOption) error {
+			if got := o.(resource.Managed).GetCondition(TypePaused); !got.Equal(c) {
+				t.Errorf("Status().Update(...): want condition %v, got %v", c, got)
+			}
+			return nil
+		}
+	}
+
+	type want struct {
+		result reconcile.Result
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		client client.Client
+		opts   []ReconcilerOption
+		want   want
+	}{
+		"Paused": {
+			reason: "Paused managed resources should not be reconciled, but report that they are paused",
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+					o.SetAnnotations(map[string]string{AnnotationKeyPaused: "true"})
+					return nil
+				}),
+				MockStatusUpdate: wantCondition(Paused()),
+			},
+			want: want{result: reconcile.Result{}},
+		},
+		"PausedByCrossplaneAnnotation": {
+			reason: "Managed resources paused by the Crossplane annotation should not be reconciled",
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+					o.SetAnnotations(map[string]string{AnnotationKeyCrossplanePaused: "true"})
+					return nil
+				}),
+				MockStatusUpdate: wantCondition(Paused()),
+			},
+			want: want{result: reconcile.Result{}},
+		},
+		"StillPaused": {
+			reason: "Managed resources that already report that they are paused should not be updated",
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+					o.SetAnnotations(map[string]string{AnnotationKeyPaused: "true"})
+					return withCondition(Paused())(o)
+				}),
+			},
+			want: want{result: reconcile.Result{}},
+		},
+		"PauseStatusUpdateError": {
+			reason: "Errors reporting that a managed resource is paused should be returned",
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+					o.SetAnnotations(map[string]string{AnnotationKeyPaused: "true"})
+					return nil
+				}),
+				MockStatusUpdate: test.NewMockStatusUpdateFn(errBoom),
+			},
+			want: want{err: errors.Wrap(errBoom, errUpdateStatus)},
+		},
+		"Resumed": {
+			reason: "Resumed managed resources should report that they were resumed and be reconciled by the wrapped reconciler",
+			client: &test.MockClient{
+				MockGet:          test.NewMockGetFn(nil, withCondition(Paused())),
+				MockStatusUpdate: wantCondition(Resumed()),
+			},
+			want: want{result: delegated},
+		},
+		"ResumeStatusUpdateError": {
+			reason: "Errors reporting that a managed resource was resumed should be returned",
+			client: &test.MockClient{
+				MockGet:          test.NewMockGetFn(nil, withCondition(Paused())),
+				MockStatusUpdate: test.NewMockStatusUpdateFn(errBoom),
+			},
+			want: want{err: errors.Wrap(errBoom, errUpdateStatus)},
+		},
+		"NotPaused": {
+			reason: "Managed resources that are not paused should be reconciled by the wrapped reconciler",
+			client: &test.MockClient{MockGet: test.NewMockGetFn(nil)},
+			want:   want{result: delegated},
+		},
+		"NotSelected": {
+			reason: "Managed resources that do not match the selector should not be reconciled",
+			client: &test.MockClient{MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+				o.SetLabels(map[string]string{"instance": "sandbox"})
+				return nil
+			})},
+			opts: []ReconcilerOption{WithSelector(labels.SelectorFromSet(labels.Set{"instance": "prod"}))},
+			want: want{result: reconcile.Result{}},
+		},
+		"Selected": {
+			reason: "Managed resources that match the selector should be reconciled by the wrapped reconciler",
+			client: &test.MockClient{MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+				o.SetLabels(map[string]string{"instance": "prod"})
+				return nil
+			})},
+			opts: []ReconcilerOption{WithSelector(labels.SelectorFromSet(labels.Set{"instance": "prod"}))},
+			want: want{result: delegated},
+		},
+		"GetError": {
+			reason: "Errors getting the managed resource should be left to the wrapped reconciler",
+			client: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			want:   want{result: delegated},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &fake.Manager{Client: tc.client, Scheme: scheme()}
+			r := NewReconciler(m, resource.ManagedKind(v1beta1.RedisGroupVersionKind), wrapped, tc.opts...)
+			got, err := r.Reconcile(context.Background(), reconcile.Request{})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nReconcile(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("\n%s\nReconcile(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSetPaused(t *testing.T) {
+	items := func() []v1beta1.Redis {
+		return []v1beta1.Redis{
+			{ObjectMeta: metav1.ObjectMeta{Name: "paused", Annotations: map[string]string{AnnotationKeyPaused: "true"}}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "running"}},
+		}
+	}
+	kube := &test.MockClient{
+		MockList: func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+			obj.(*v1beta1.RedisList).Items = items()
+			return nil
+		},
+		MockPatch: test.NewMockPatchFn(nil),
+	}
+
+	cases := map[string]struct {
+		paused bool
+		want   []string
+	}{
+		"Pause":  {paused: true, want: []string{"Redis/running"}},
+		"Resume": {paused: false, want: []string{"Redis/paused"}},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := SetPaused(context.Background(), kube, scheme(), labels.Everything(), tc.paused)
+			if err != nil {
+				t.Fatalf("SetPaused(...): %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("SetPaused(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package probe checks whether the endpoints of provisioned Azure resources
+// are actually reachable.
+package probe
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// AnnotationKeyReadinessProbe is the annotation that enables the readiness
+// probe of a managed resource when set to "true". Resources with an enabled
+// probe are not reported as Available until their endpoint accepts
+// connections.
+const AnnotationKeyReadinessProbe = "azure.crossplane.io/readiness-probe"
+
+// DefaultTimeout is the time a single probe waits for a connection to be
+// established.
+const DefaultTimeout = 5 * time.Second
+
+// Error strings.
+const (
+	errDial      = "cannot connect to %s"
+	errHandshake = "cannot complete TLS handshake with %s"
+)
+
+// A Fn probes the supplied endpoint and returns an error if it is not
+// reachable.
+type Fn func(ctx context.Context, host, port string) error
+
+// Enabled returns true if the readiness probe is enabled for the supplied
+// object.
+func Enabled(o metav1.Object) bool {
+	return o.GetAnnotations()[AnnotationKeyReadinessProbe] == "true"
+}
+
+// Condition returns the Available condition if the readiness probe of the
+// supplied object is disabled or if the supplied probe succeeds. Otherwise it
+// returns the Unavailable condition with the reason the probe failed.
+func Condition(ctx context.Context, o metav1.Object, fn Fn, host, port string) xpv1.Condition {
+	if !Enabled(o) {
+		return xpv1.Available()
+	}
+	if err := fn(ctx, host, port); err != nil {
+		return xpv1.Unavailable().WithMessage(err.Error())
+	}
+	return xpv1.Available()
+}
+
+// TCP succeeds if a TCP connection can be established to the supplied
+// endpoint.
+func TCP(ctx context.Context, host, port string) error {
+	addr := net.JoinHostPort(host, port)
+	d := &net.Dialer{Timeout: DefaultTimeout}
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return errors.Wrapf(err, errDial, addr)
+	}
+	return conn.Close()
+}
+
+// TLS succeeds if a TLS session can be established to the supplied endpoint
+// and its certificate is valid for the supplied host.
+func TLS(ctx context.Context, host, port string) error {
+	addr := net.JoinHostPort(host, port)
+	d := &net.Dialer{Timeout: DefaultTimeout}
+	raw, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return errors.Wrapf(err, errDial, addr)
+	}
+	conn := tls.Client(raw, &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12})
+	defer conn.Close() // nolint:errcheck
+	if err := conn.SetDeadline(time.Now().Add(DefaultTimeout)); err != nil {
+		return errors.Wrapf(err, errHandshake, addr)
+	}
+	return errors.Wrapf(conn.Handshake(), errHandshake, addr)
+}
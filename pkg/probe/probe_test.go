@@ -0,0 +1,113 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package probe
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestEnabled(t *testing.T) {
+	cases := map[string]struct {
+		annotations map[string]string
+		want        bool
+	}{
+		"NoAnnotation": {
+			want: false,
+		},
+		"Disabled": {
+			annotations: map[string]string{AnnotationKeyReadinessProbe: "false"},
+			want:        false,
+		},
+		"Enabled": {
+			annotations: map[string]string{AnnotationKeyReadinessProbe: "true"},
+			want:        true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := Enabled(&metav1.ObjectMeta{Annotations: tc.annotations})
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Enabled(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCondition(t *testing.T) {
+	errBoom := errors.New("boom")
+	enabled := map[string]string{AnnotationKeyReadinessProbe: "true"}
+
+	cases := map[string]struct {
+		annotations map[string]string
+		fn          Fn
+		want        xpv1.Condition
+	}{
+		"Disabled": {
+			fn:   func(_ context.Context, _, _ string) error { return errBoom },
+			want: xpv1.Available(),
+		},
+		"ProbeFailed": {
+			annotations: enabled,
+			fn:          func(_ context.Context, _, _ string) error { return errBoom },
+			want:        xpv1.Unavailable().WithMessage(errBoom.Error()),
+		},
+		"ProbeSucceeded": {
+			annotations: enabled,
+			fn:          func(_ context.Context, _, _ string) error { return nil },
+			want:        xpv1.Available(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := Condition(context.Background(), &metav1.ObjectMeta{Annotations: tc.annotations}, tc.fn, "example.com", "6380")
+			if diff := cmp.Diff(tc.want, got, test.EquateConditions()); diff != "" {
+				t.Errorf("Condition(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTCP(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("cannot listen: %s", err)
+	}
+	port := strconv.Itoa(l.Addr().(*net.TCPAddr).Port)
+
+	if err := TCP(context.Background(), "127.0.0.1", port); err != nil {
+		t.Errorf("TCP(...): expected no error for a listening endpoint, got %s", err)
+	}
+
+	if err := l.Close(); err != nil {
+		t.Fatalf("cannot close listener: %s", err)
+	}
+	if err := TCP(context.Background(), "127.0.0.1", port); err == nil {
+		t.Error("TCP(...): expected an error for a closed endpoint")
+	}
+}
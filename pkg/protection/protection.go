@@ -0,0 +1,113 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package protection refuses to delete the Azure resources of managed
+// resources that are protected from deletion, e.g. production databases.
+package protection
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// AnnotationKeyPreventDestroy is the annotation that protects the Azure
+// resource of a managed resource from deletion while its value is "true".
+const AnnotationKeyPreventDestroy = "azure.crossplane.io/prevent-destroy"
+
+// TypeDeletionProtected managed resources have had the deletion of their
+// Azure resource refused because they are protected from deletion.
+const TypeDeletionProtected xpv1.ConditionType = "DeletionProtected"
+
+// Reasons the deletion of an Azure resource is or is not refused.
+const (
+	ReasonPreventDestroy xpv1.ConditionReason = "PreventDestroy"
+	ReasonUnprotected    xpv1.ConditionReason = "Unprotected"
+)
+
+// Error strings.
+const (
+	errPreventDestroy = "refusing to delete external resource while the " + AnnotationKeyPreventDestroy + " annotation is set"
+)
+
+// IsProtected returns true if the Azure resource of the supplied object is
+// protected from deletion.
+func IsProtected(o metav1.Object) bool {
+	return o.GetAnnotations()[AnnotationKeyPreventDestroy] == "true"
+}
+
+// Protected returns a condition that indicates the deletion of an Azure
+// resource was refused because it is protected from deletion.
+func Protected() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDeletionProtected,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPreventDestroy,
+		Message:            errPreventDestroy,
+	}
+}
+
+// Unprotected returns a condition that indicates an Azure resource is no
+// longer protected from deletion.
+func Unprotected() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDeletionProtected,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonUnprotected,
+	}
+}
+
+// NewConnecter returns an ExternalConnecter whose clients refuse to delete
+// the Azure resources of protected managed resources. The managed resource
+// keeps its finalizer, and is deleted once the annotation is removed.
+func NewConnecter(c managed.ExternalConnecter) managed.ExternalConnecter {
+	return &connecter{ExternalConnecter: c}
+}
+
+type connecter struct {
+	managed.ExternalConnecter
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	e, err := c.ExternalConnecter.Connect(ctx, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &external{ExternalClient: e}, nil
+}
+
+type external struct {
+	managed.ExternalClient
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	if IsProtected(mg) {
+		mg.SetConditions(Protected())
+		return errors.New(errPreventDestroy)
+	}
+	if mg.GetCondition(TypeDeletionProtected).Reason == ReasonPreventDestroy {
+		mg.SetConditions(Unprotected())
+	}
+	return e.ExternalClient.Delete(ctx, mg)
+}
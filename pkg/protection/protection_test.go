@@ -0,0 +1,108 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func withAnnotation(v string, c ...xpv1.Condition) *fake.Managed {
+	mg := &fake.Managed{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{AnnotationKeyPreventDestroy: v}}}
+	mg.SetConditions(c...)
+	return mg
+}
+
+func TestDelete(t *testing.T) {
+	type want struct {
+		deleted bool
+		err     error
+		cond    xpv1.Condition
+	}
+
+	cases := map[string]struct {
+		reason string
+		mg     resource.Managed
+		want   want
+	}{
+		"Unprotected": {
+			reason: "The external resources of managed resources without the annotation should be deleted",
+			mg:     &fake.Managed{},
+			want: want{
+				deleted: true,
+				cond:    xpv1.Condition{Type: TypeDeletionProtected, Status: corev1.ConditionUnknown},
+			},
+		},
+		"NotTrue": {
+			reason: "Only the value true should protect an external resource from deletion",
+			mg:     withAnnotation("false"),
+			want: want{
+				deleted: true,
+				cond:    xpv1.Condition{Type: TypeDeletionProtected, Status: corev1.ConditionUnknown},
+			},
+		},
+		"Protected": {
+			reason: "The deletion of protected external resources should be refused with a DeletionProtected condition",
+			mg:     withAnnotation("true"),
+			want: want{
+				err:  errors.New(errPreventDestroy),
+				cond: Protected(),
+			},
+		},
+		"NoLongerProtected": {
+			reason: "External resources should be deleted, and the condition cleared, once the annotation is removed",
+			mg:     withAnnotation("", Protected()),
+			want: want{
+				deleted: true,
+				cond:    Unprotected(),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			deleted := false
+			e := &external{ExternalClient: &managed.ExternalClientFns{
+				DeleteFn: func(_ context.Context, _ resource.Managed) error {
+					deleted = true
+					return nil
+				},
+			}}
+			err := e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.deleted, deleted); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want deleted, +got deleted:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cond, tc.mg.GetCondition(TypeDeletionProtected), test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want condition, +got condition:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package region reconciles the regional parts of a resource, e.g. the
+// replicas of a geo-replicated resource, in parallel rather than one region
+// after the other.
+package region
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// DefaultMaxConcurrency is the number of regions reconciled at a time unless
+// configured otherwise.
+const DefaultMaxConcurrency = 4
+
+const errRegion = "region %q"
+
+// A Fn reconciles the part of a resource in the supplied region.
+type Fn func(ctx context.Context, region string) error
+
+// A Result is the outcome of reconciling one region.
+type Result struct {
+	// Region that was reconciled.
+	Region string
+
+	// Error that occurred while reconciling the region, if any.
+	Error error
+}
+
+// Reconcile calls the supplied Fn for each of the supplied regions, at most max
+// of them at a time, and returns the result of each in the order the regions
+// were supplied. A max below one means DefaultMaxConcurrency. Regions that
+// have not been started when the supplied context is done fail with its
+// error.
+func Reconcile(ctx context.Context, regions []string, max int, fn Fn) []Result {
+	if max < 1 {
+		max = DefaultMaxConcurrency
+	}
+	results := make([]Result, len(regions))
+	sem := make(chan struct{}, max)
+	wg := sync.WaitGroup{}
+	for i, r := range regions {
+		results[i].Region = r
+		if err := ctx.Err(); err != nil {
+			results[i].Error = err
+			continue
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			results[i].Error = ctx.Err()
+			continue
+		}
+		wg.Add(1)
+		go func(i int, r string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i].Error = fn(ctx, r)
+		}(i, r)
+	}
+	wg.Wait()
+	return results
+}
+
+// Error returns an aggregate of the errors of the supplied results, each
+// prefixed with its region, or nil if all regions were reconciled.
+func Error(results []Result) error {
+	errs := make([]error, 0)
+	for _, r := range results {
+		if r.Error != nil {
+			errs = append(errs, errors.Wrapf(r.Error, errRegion, r.Region))
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
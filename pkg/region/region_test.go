@@ -0,0 +1,152 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package region
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestReconcile(t *testing.T) {
+	errBoom := errors.New("boom")
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	type args struct {
+		ctx     context.Context
+		regions []string
+		max     int
+		fn      Fn
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   []Result
+	}{
+		"AllSucceeded": {
+			reason: "The result of every region should be returned in the order the regions were supplied",
+			args: args{
+				ctx:     context.Background(),
+				regions: []string{"westus", "eastus", "northeurope"},
+				fn:      func(_ context.Context, _ string) error { return nil },
+			},
+			want: []Result{{Region: "westus"}, {Region: "eastus"}, {Region: "northeurope"}},
+		},
+		"SomeFailed": {
+			reason: "An error reconciling one region should not prevent the others from being reconciled",
+			args: args{
+				ctx:     context.Background(),
+				regions: []string{"westus", "eastus"},
+				max:     1,
+				fn: func(_ context.Context, r string) error {
+					if r == "westus" {
+						return errBoom
+					}
+					return nil
+				},
+			},
+			want: []Result{{Region: "westus", Error: errBoom}, {Region: "eastus"}},
+		},
+		"ContextDone": {
+			reason: "Regions should not be reconciled once the context is done",
+			args: args{
+				ctx:     cancelled,
+				regions: []string{"westus"},
+				max:     1,
+				fn:      func(_ context.Context, _ string) error { return errBoom },
+			},
+			want: []Result{{Region: "westus", Error: context.Canceled}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := Reconcile(tc.args.ctx, tc.args.regions, tc.args.max, tc.args.fn)
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nReconcile(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestReconcileMaxConcurrency(t *testing.T) {
+	var running, peak int32
+	release := make(chan struct{})
+	started := make(chan struct{}, 4)
+
+	go func() {
+		// Let the regions finish once as many as allowed are running.
+		<-started
+		<-started
+		close(release)
+	}()
+
+	Reconcile(context.Background(), []string{"a", "b", "c", "d"}, 2, func(_ context.Context, _ string) error {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		started <- struct{}{}
+		<-release
+		atomic.AddInt32(&running, -1)
+		return nil
+	})
+
+	if peak != 2 {
+		t.Errorf("Reconcile(...): want 2 regions reconciled at a time, got %d", peak)
+	}
+}
+
+func TestError(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	cases := map[string]struct {
+		results []Result
+		want    error
+	}{
+		"NoErrors": {
+			results: []Result{{Region: "westus"}},
+		},
+		"Errors": {
+			results: []Result{{Region: "westus", Error: errBoom}, {Region: "eastus"}, {Region: "northeurope", Error: errBoom}},
+			want: kerrors.NewAggregate([]error{
+				errors.Wrapf(errBoom, errRegion, "westus"),
+				errors.Wrapf(errBoom, errRegion, "northeurope"),
+			}),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := Error(tc.results)
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors()); diff != "" {
+				t.Errorf("Error(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
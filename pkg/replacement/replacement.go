@@ -0,0 +1,284 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package replacement replaces the Azure resources of managed resources whose
+// immutable fields changed, for managed resources that opt in to it. The
+// replacement is created next to the original Azure resource under another
+// name, and the original is only deleted once the replacement is available,
+// so that changing an immutable field does not cause an outage.
+package replacement
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	cachev1beta1 "github.com/crossplane/provider-azure/apis/cache/v1beta1"
+	"github.com/crossplane/provider-azure/pkg/immutable"
+)
+
+// AnnotationKeyStrategy is the annotation that sets how the Azure resource of
+// a managed resource is replaced when its immutable fields change.
+const AnnotationKeyStrategy = "azure.crossplane.io/replacement-strategy"
+
+// StrategyCreateBeforeDelete replaces an Azure resource by creating its
+// replacement before deleting it. Without a strategy changes of immutable
+// fields are rejected.
+const StrategyCreateBeforeDelete = "CreateBeforeDelete"
+
+const (
+	// AnnotationKeyFields holds the values of the immutable fields of the
+	// current Azure resource of a managed resource, by their path.
+	AnnotationKeyFields = "azure.crossplane.io/replacement-fields"
+
+	// AnnotationKeyReplaced holds the external name and the values of the
+	// immutable fields of the Azure resource that is being replaced.
+	AnnotationKeyReplaced = "azure.crossplane.io/replaced"
+)
+
+// Error strings.
+const (
+	errUpdateManaged = "cannot update managed resource"
+	errFields        = "cannot read immutable fields of managed resource"
+	errReplaced      = "cannot read the Azure resource being replaced"
+	errConnect       = "cannot connect to the Azure resource being replaced"
+	errDelete        = "cannot delete the Azure resource being replaced"
+)
+
+// A Replaced Azure resource, identified by its external name and the values
+// of its immutable fields.
+type Replaced struct {
+	ExternalName string                 `json:"externalName"`
+	Fields       map[string]interface{} `json:"fields"`
+}
+
+// Supported returns true if the Azure resources of the supplied managed
+// resource can be replaced. They must be identified by their name, so that a
+// replacement can be created next to the original under another name.
+func Supported(mg resource.Managed) bool {
+	switch mg.(type) {
+	case *cachev1beta1.Redis:
+		return true
+	}
+	return false
+}
+
+// Enabled returns true if the Azure resource of the supplied managed resource
+// is replaced when its immutable fields change.
+func Enabled(mg resource.Managed) bool {
+	return Supported(mg) && mg.GetAnnotations()[AnnotationKeyStrategy] == StrategyCreateBeforeDelete
+}
+
+// Replaceable returns true if the immutable fields of the supplied managed
+// resource may be changed, i.e. if its Azure resource is replaced when they
+// change and no replacement is in progress.
+func Replaceable(mg resource.Managed) bool {
+	_, replacing := mg.GetAnnotations()[AnnotationKeyReplaced]
+	return Enabled(mg) && !replacing
+}
+
+// fields returns the values of the immutable fields of the supplied managed
+// resource that are set.
+func fields(mg resource.Managed) (map[string]interface{}, error) {
+	p, err := fieldpath.PaveObject(mg)
+	if err != nil {
+		return nil, err
+	}
+	f := map[string]interface{}{}
+	for _, path := range immutable.Fields(mg) {
+		if v, err := p.GetValue(path); err == nil && v != nil && v != "" {
+			f[path] = v
+		}
+	}
+	return f, nil
+}
+
+// withFields returns a copy of the supplied managed resource whose immutable
+// fields are set to the supplied values.
+func withFields(mg resource.Managed, f map[string]interface{}) (resource.Managed, error) {
+	p, err := fieldpath.PaveObject(mg)
+	if err != nil {
+		return nil, err
+	}
+	for path, v := range f {
+		if err := p.SetValue(path, v); err != nil {
+			return nil, err
+		}
+	}
+	c := mg.DeepCopyObject().(resource.Managed)
+	return c, runtime.DefaultUnstructuredConverter.FromUnstructured(p.UnstructuredContent(), c)
+}
+
+// suffix returns a short hash of the supplied field values, which
+// distinguishes the names of an Azure resource and its replacements.
+func suffix(f map[string]interface{}) string {
+	b, _ := json.Marshal(f)
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:])[:5]
+}
+
+// replaced returns the Azure resource the supplied managed resource is
+// replacing, if any.
+func replaced(mg resource.Managed) (*Replaced, error) {
+	a, ok := mg.GetAnnotations()[AnnotationKeyReplaced]
+	if !ok {
+		return nil, nil
+	}
+	r := &Replaced{}
+	return r, errors.Wrap(json.Unmarshal([]byte(a), r), errReplaced)
+}
+
+// An Initializer starts the replacement of the Azure resources of managed
+// resources whose immutable fields changed. It records the immutable fields
+// of their Azure resource, and when they change assigns the managed resource
+// a new external name, so that its replacement is created under that name.
+type Initializer struct {
+	client client.Client
+}
+
+// NewInitializer returns a new Initializer.
+func NewInitializer(c client.Client) *Initializer {
+	return &Initializer{client: c}
+}
+
+// Initialize the replacement of the Azure resource of the supplied managed
+// resource. Only one replacement at a time is in progress.
+func (i *Initializer) Initialize(ctx context.Context, mg resource.Managed) error {
+	if !Enabled(mg) || meta.WasDeleted(mg) {
+		return nil
+	}
+	if _, ok := mg.GetAnnotations()[AnnotationKeyReplaced]; ok {
+		return nil
+	}
+	cur, err := fields(mg)
+	if err != nil {
+		return errors.Wrap(err, errFields)
+	}
+	applied := map[string]interface{}{}
+	if a, ok := mg.GetAnnotations()[AnnotationKeyFields]; ok {
+		if err := json.Unmarshal([]byte(a), &applied); err != nil {
+			return errors.Wrap(err, errFields)
+		}
+	}
+	before, err := withFields(mg, applied)
+	if err != nil {
+		return errors.Wrap(err, errFields)
+	}
+	changes, err := immutable.Changes(before, mg)
+	if err != nil {
+		return errors.Wrap(err, errFields)
+	}
+
+	// Fields that are set for the first time, e.g. by late initialization,
+	// are recorded without replacing the Azure resource.
+	if len(changes) == 0 && len(cur) == len(applied) {
+		return nil
+	}
+	if len(changes) > 0 {
+		r, _ := json.Marshal(Replaced{ExternalName: meta.GetExternalName(mg), Fields: applied})
+		meta.AddAnnotations(mg, map[string]string{AnnotationKeyReplaced: string(r)})
+		base := strings.TrimSuffix(meta.GetExternalName(mg), "-"+suffix(applied))
+		meta.SetExternalName(mg, fmt.Sprintf("%s-%s", base, suffix(cur)))
+	}
+	f, _ := json.Marshal(cur)
+	meta.AddAnnotations(mg, map[string]string{AnnotationKeyFields: string(f)})
+	return errors.Wrap(i.client.Update(ctx, mg), errUpdateManaged)
+}
+
+// NewConnecter returns an ExternalConnecter whose clients delete the Azure
+// resource a managed resource is replacing once its replacement is
+// available, and delete both when the managed resource is deleted.
+func NewConnecter(c managed.ExternalConnecter) managed.ExternalConnecter {
+	return &connecter{ExternalConnecter: c}
+}
+
+type connecter struct {
+	managed.ExternalConnecter
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	e, err := c.ExternalConnecter.Connect(ctx, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &external{ExternalClient: e, connecter: c.ExternalConnecter}, nil
+}
+
+type external struct {
+	managed.ExternalClient
+	connecter managed.ExternalConnecter
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	o, err := e.ExternalClient.Observe(ctx, mg)
+	if err != nil || !o.ResourceExists || mg.GetCondition(xpv1.TypeReady).Status != corev1.ConditionTrue {
+		return o, err
+	}
+	r, err := replaced(mg)
+	if err != nil || r == nil {
+		return o, err
+	}
+	if err := e.deleteReplaced(ctx, mg, r); err != nil {
+		return o, err
+	}
+
+	// The managed reconciler persists the managed resource once it was late
+	// initialized, which records that the replacement is done.
+	meta.RemoveAnnotations(mg, AnnotationKeyReplaced)
+	o.ResourceLateInitialized = true
+	return o, nil
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	if err := e.ExternalClient.Delete(ctx, mg); err != nil {
+		return err
+	}
+	r, err := replaced(mg)
+	if err != nil || r == nil {
+		return err
+	}
+	return e.deleteReplaced(ctx, mg, r)
+}
+
+// deleteReplaced deletes the supplied Azure resource the supplied managed
+// resource is replacing, using a copy of the managed resource that describes
+// it.
+func (e *external) deleteReplaced(ctx context.Context, mg resource.Managed, r *Replaced) error {
+	old, err := withFields(mg, r.Fields)
+	if err != nil {
+		return errors.Wrap(err, errReplaced)
+	}
+	meta.SetExternalName(old, r.ExternalName)
+	ec, err := e.connecter.Connect(ctx, old)
+	if err != nil {
+		return errors.Wrap(err, errConnect)
+	}
+	return errors.Wrap(ec.Delete(ctx, old), errDelete)
+}
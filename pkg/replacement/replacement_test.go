@@ -0,0 +1,222 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replacement
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	cachev1beta1 "github.com/crossplane/provider-azure/apis/cache/v1beta1"
+)
+
+var errBoom = errors.New("boom")
+
+type redisModifier func(*cachev1beta1.Redis)
+
+func withStrategy() redisModifier {
+	return func(r *cachev1beta1.Redis) {
+		meta.AddAnnotations(r, map[string]string{AnnotationKeyStrategy: StrategyCreateBeforeDelete})
+	}
+}
+
+func withLocation(l string) redisModifier {
+	return func(r *cachev1beta1.Redis) { r.Spec.ForProvider.Location = l }
+}
+
+func withExternalName(n string) redisModifier {
+	return func(r *cachev1beta1.Redis) { meta.SetExternalName(r, n) }
+}
+
+func withApplied(f map[string]interface{}) redisModifier {
+	return func(r *cachev1beta1.Redis) {
+		b, _ := json.Marshal(f)
+		meta.AddAnnotations(r, map[string]string{AnnotationKeyFields: string(b)})
+	}
+}
+
+func withReplaced(rp Replaced) redisModifier {
+	return func(r *cachev1beta1.Redis) {
+		b, _ := json.Marshal(rp)
+		meta.AddAnnotations(r, map[string]string{AnnotationKeyReplaced: string(b)})
+	}
+}
+
+func withConditions(c ...xpv1.Condition) redisModifier {
+	return func(r *cachev1beta1.Redis) { r.Status.SetConditions(c...) }
+}
+
+func redis(m ...redisModifier) *cachev1beta1.Redis {
+	r := &cachev1beta1.Redis{
+		Spec: cachev1beta1.RedisSpec{ForProvider: cachev1beta1.RedisParameters{
+			ResourceGroupName: "coolgroup",
+			Location:          "westus2",
+			SKU:               cachev1beta1.SKU{Name: "Basic", Family: "C"},
+		}},
+	}
+	meta.SetExternalName(r, "coolcache")
+	for _, f := range m {
+		f(r)
+	}
+	return r
+}
+
+func fieldsIn(location string) map[string]interface{} {
+	return map[string]interface{}{
+		"spec.forProvider.resourceGroupName": "coolgroup",
+		"spec.forProvider.location":          location,
+		"spec.forProvider.sku.family":        "C",
+	}
+}
+
+func TestInitialize(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		client *test.MockClient
+		mg     resource.Managed
+		want   want
+	}{
+		"NotEnabled": {
+			reason: "Managed resources without a replacement strategy should not be changed",
+			mg:     redis(),
+			want:   want{mg: redis()},
+		},
+		"RecordFields": {
+			reason: "The immutable fields of a managed resource should be recorded",
+			client: &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+			mg:     redis(withStrategy()),
+			want:   want{mg: redis(withStrategy(), withApplied(fieldsIn("westus2")))},
+		},
+		"Unchanged": {
+			reason: "Managed resources whose immutable fields did not change should not be replaced",
+			mg:     redis(withStrategy(), withApplied(fieldsIn("westus2"))),
+			want:   want{mg: redis(withStrategy(), withApplied(fieldsIn("westus2")))},
+		},
+		"Replace": {
+			reason: "Managed resources whose immutable fields changed should be replaced under a new external name",
+			client: &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+			mg:     redis(withStrategy(), withApplied(fieldsIn("westus2")), withLocation("eastus")),
+			want: want{mg: redis(
+				withStrategy(),
+				withLocation("eastus"),
+				withApplied(fieldsIn("eastus")),
+				withReplaced(Replaced{ExternalName: "coolcache", Fields: fieldsIn("westus2")}),
+				withExternalName("coolcache-"+suffix(fieldsIn("eastus"))),
+			)},
+		},
+		"Replacing": {
+			reason: "Only one replacement should be in progress at a time",
+			mg:     redis(withStrategy(), withApplied(fieldsIn("westus2")), withReplaced(Replaced{ExternalName: "old"}), withLocation("eastus")),
+			want:   want{mg: redis(withStrategy(), withApplied(fieldsIn("westus2")), withReplaced(Replaced{ExternalName: "old"}), withLocation("eastus"))},
+		},
+		"UpdateFailed": {
+			reason: "Errors updating the managed resource should be returned",
+			client: &test.MockClient{MockUpdate: test.NewMockUpdateFn(errBoom)},
+			mg:     redis(withStrategy()),
+			want:   want{mg: redis(withStrategy(), withApplied(fieldsIn("westus2"))), err: errors.Wrap(errBoom, errUpdateManaged)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := NewInitializer(tc.client).Initialize(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nInitialize(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg); diff != "" {
+				t.Errorf("\n%s\nInitialize(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	old := Replaced{ExternalName: "coolcache", Fields: fieldsIn("westus2")}
+
+	type want struct {
+		o       managed.ExternalObservation
+		deleted []string
+		err     error
+	}
+
+	cases := map[string]struct {
+		reason string
+		mg     resource.Managed
+		want   want
+	}{
+		"NotReplacing": {
+			reason: "Nothing should be deleted if no replacement is in progress",
+			mg:     redis(withConditions(xpv1.Available())),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true}},
+		},
+		"ReplacementUnavailable": {
+			reason: "The replaced Azure resource should be kept until its replacement is available",
+			mg:     redis(withLocation("eastus"), withReplaced(old), withConditions(xpv1.Creating())),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true}},
+		},
+		"ReplacementAvailable": {
+			reason: "The replaced Azure resource should be deleted once its replacement is available",
+			mg:     redis(withLocation("eastus"), withReplaced(old), withConditions(xpv1.Available())),
+			want: want{
+				o:       managed.ExternalObservation{ResourceExists: true, ResourceLateInitialized: true},
+				deleted: []string{"coolcache/westus2"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var deleted []string
+			c := managed.ExternalConnectorFn(func(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+				return &managed.ExternalClientFns{
+					ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+						return managed.ExternalObservation{ResourceExists: true}, nil
+					},
+					DeleteFn: func(_ context.Context, mg resource.Managed) error {
+						deleted = append(deleted, meta.GetExternalName(mg)+"/"+mg.(*cachev1beta1.Redis).Spec.ForProvider.Location)
+						return nil
+					},
+				}, nil
+			})
+			e, _ := NewConnecter(c).Connect(context.Background(), tc.mg)
+			o, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.deleted, deleted); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want deleted, +got deleted:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
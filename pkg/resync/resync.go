@@ -0,0 +1,301 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package resync adapts the poll interval of managed resource controllers to
+// the depth of their work queue. When the queue of a controller is
+// consistently saturated, e.g. because Azure throttles the provider during a
+// fleet-wide incident, polling every managed resource at the usual interval
+// only deepens the backlog and the throttling. The poll interval of such a
+// controller is lengthened until its queue drains.
+//
+// The poll interval of managed resources may also be configured, both for all
+// managed resources and for each managed resource by annotation. Databases
+// and AKS clusters, for example, rarely need to be polled every minute.
+package resync
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// AnnotationKeySyncPeriod is the annotation that overrides the interval a
+// managed resource is polled at, e.g. 10m or 1h.
+const AnnotationKeySyncPeriod = "azure.crossplane.io/sync-period"
+
+// MetricFactor is the name of the metric of the factor the poll interval of
+// each controller is lengthened by.
+const MetricFactor = "crossplane_azure_resync_factor"
+
+// metricDepth is the name of the metric of the depth of the work queue of
+// each controller, by the name of the controller.
+const metricDepth = "workqueue_depth"
+
+// Defaults of the DefaultMonitor.
+const (
+	DefaultInterval  = 30 * time.Second
+	DefaultSamples   = 4
+	DefaultMaxFactor = 8
+)
+
+// Error strings.
+const (
+	errGather = "cannot gather work queue metrics"
+)
+
+var descFactor = prometheus.NewDesc(MetricFactor,
+	"Factor the poll interval of a controller is lengthened by because its work queue is saturated.",
+	[]string{"controller"}, nil)
+
+// DefaultMonitor adapts the poll interval of all managed resource
+// controllers. The provider configures it from its flags before it sets up
+// its controllers. It never adapts poll intervals unless configured.
+var DefaultMonitor = &Monitor{
+	Gatherer:  crmetrics.Registry,
+	Log:       logging.NewNopLogger(),
+	Interval:  DefaultInterval,
+	Samples:   DefaultSamples,
+	MaxFactor: DefaultMaxFactor,
+}
+
+// A Monitor samples the depth of the work queue of every controller at an
+// interval. It doubles the factor the poll interval of a controller is
+// lengthened by each time its queue is saturated at a number of consecutive
+// samples, and halves it each time its queue is not.
+type Monitor struct {
+	// Gatherer of the work queue metrics of the controllers.
+	Gatherer prometheus.Gatherer
+
+	// Log of adapted poll intervals.
+	Log logging.Logger
+
+	// Depth at which a work queue is saturated. Poll intervals are never
+	// adapted if zero.
+	Depth int
+
+	// Interval at which work queues are sampled.
+	Interval time.Duration
+
+	// Samples a work queue must consecutively be saturated or not at before
+	// the poll interval of its controller is adapted.
+	Samples int
+
+	// MaxFactor a poll interval is lengthened by.
+	MaxFactor int
+
+	// SyncPeriod is the interval managed resources without a sync period
+	// annotation are polled at. The poll interval of the wrapped reconcilers
+	// is used if zero.
+	SyncPeriod time.Duration
+
+	mu     sync.RWMutex
+	queues map[string]*queue
+}
+
+type queue struct {
+	factor int
+
+	// samples the queue was consecutively saturated at if positive, or not
+	// saturated at if negative.
+	samples int
+}
+
+// Start sampling work queues until the supplied context is done.
+func (m *Monitor) Start(ctx context.Context) error {
+	if m.Depth <= 0 {
+		return nil
+	}
+	t := time.NewTicker(m.Interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-t.C:
+		}
+		if err := m.Sample(); err != nil {
+			m.Log.Info("Cannot sample work queues", "error", err)
+		}
+	}
+}
+
+// Sample the depth of the work queue of every controller, and adapt their
+// poll intervals.
+func (m *Monitor) Sample() error {
+	mfs, err := m.Gatherer.Gather()
+	if err != nil {
+		return errors.Wrap(err, errGather)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.queues == nil {
+		m.queues = map[string]*queue{}
+	}
+	for _, mf := range mfs {
+		if mf.GetName() != metricDepth {
+			continue
+		}
+		for _, mt := range mf.GetMetric() {
+			name := ""
+			for _, l := range mt.GetLabel() {
+				if l.GetName() == "name" {
+					name = l.GetValue()
+				}
+			}
+			q, ok := m.queues[name]
+			if !ok {
+				q = &queue{factor: 1}
+				m.queues[name] = q
+			}
+			m.adapt(name, q, mt.GetGauge().GetValue() >= float64(m.Depth))
+		}
+	}
+	return nil
+}
+
+func (m *Monitor) adapt(name string, q *queue, saturated bool) {
+	switch {
+	case saturated && q.samples >= 0:
+		q.samples++
+	case saturated:
+		q.samples = 1
+	case q.samples <= 0:
+		q.samples--
+	default:
+		q.samples = -1
+	}
+
+	prev := q.factor
+	switch {
+	case q.samples >= m.Samples && q.factor < m.MaxFactor:
+		q.factor *= 2
+		if q.factor > m.MaxFactor {
+			q.factor = m.MaxFactor
+		}
+	case q.samples <= -m.Samples && q.factor > 1:
+		q.factor /= 2
+	default:
+		return
+	}
+	q.samples = 0
+	m.Log.Info("Adapting poll interval to work queue depth", "controller", name, "factor", q.factor, "previous-factor", prev)
+}
+
+// Factor the poll interval of the named controller is lengthened by.
+func (m *Monitor) Factor(name string) int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if q, ok := m.queues[name]; ok {
+		return q.factor
+	}
+	return 1
+}
+
+// Describe the metric of the factor poll intervals are lengthened by.
+func (m *Monitor) Describe(ch chan<- *prometheus.Desc) {
+	ch <- descFactor
+}
+
+// Collect the metric of the factor poll intervals are lengthened by.
+func (m *Monitor) Collect(ch chan<- prometheus.Metric) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for name, q := range m.queues {
+		ch <- prometheus.MustNewConstMetric(descFactor, prometheus.GaugeValue, float64(q.factor), name)
+	}
+}
+
+// A Reconciler lengthens the poll interval of the reconciler it wraps by the
+// factor its Monitor reports for the named controller.
+type Reconciler struct {
+	monitor *Monitor
+	name    string
+	wrapped reconcile.Reconciler
+
+	client     client.Reader
+	newManaged func() resource.Managed
+}
+
+// A ReconcilerOption configures a Reconciler.
+type ReconcilerOption func(*Reconciler)
+
+// WithSyncPeriod makes the Reconciler poll managed resources of the supplied
+// kind at their sync period: that of their sync period annotation, or else
+// that of the Monitor. Only reconcilers that requeue after an interval solely
+// to poll, like the managed resource reconciler, may be configured with a sync
+// period; those that also requeue after an interval to wait for an operation
+// would wait for a whole sync period.
+func WithSyncPeriod(m ctrl.Manager, of resource.ManagedKind) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.client = m.GetClient()
+		r.newManaged = func() resource.Managed {
+			return resource.MustCreateObject(schema.GroupVersionKind(of), m.GetScheme()).(resource.Managed)
+		}
+	}
+}
+
+// NewReconciler returns a Reconciler that wraps the supplied reconciler of the
+// named controller.
+func NewReconciler(m *Monitor, name string, r reconcile.Reconciler, o ...ReconcilerOption) *Reconciler {
+	rr := &Reconciler{monitor: m, name: name, wrapped: r}
+	for _, ro := range o {
+		ro(rr)
+	}
+	return rr
+}
+
+// Reconcile the supplied request with the wrapped reconciler. Requests that
+// fail or are requeued immediately are not affected, so that errors are
+// still retried with backoff.
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	res, err := r.wrapped.Reconcile(ctx, req)
+	if err != nil || res.RequeueAfter <= 0 {
+		return res, err
+	}
+	if p := r.syncPeriod(ctx, req); p > 0 {
+		res.RequeueAfter = p
+	}
+	res.RequeueAfter *= time.Duration(r.monitor.Factor(r.name))
+	return res, nil
+}
+
+// syncPeriod returns the sync period of the managed resource of the supplied
+// request, or zero if the poll interval of the wrapped reconciler applies.
+// Sync period annotations that are not a positive duration are ignored.
+func (r *Reconciler) syncPeriod(ctx context.Context, req reconcile.Request) time.Duration {
+	if r.newManaged == nil {
+		return 0
+	}
+	mg := r.newManaged()
+	if err := r.client.Get(ctx, req.NamespacedName, mg); err == nil {
+		if p, err := time.ParseDuration(mg.GetAnnotations()[AnnotationKeySyncPeriod]); err == nil && p > 0 {
+			return p
+		}
+	}
+	return r.monitor.SyncPeriod
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+const controller = "managed/cool.azure.crossplane.io"
+
+var errBoom = errors.New("boom")
+
+func TestSample(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		factor int
+		depths []float64
+		want   int
+	}{
+		"NotSaturated": {
+			reason: "The poll interval should not be lengthened if the queue is not consistently saturated",
+			depths: []float64{10, 200, 10, 200, 200, 200},
+			want:   1,
+		},
+		"Saturated": {
+			reason: "The poll interval should be doubled once the queue is saturated at enough consecutive samples",
+			depths: []float64{100, 200, 300, 400},
+			want:   2,
+		},
+		"ConsistentlySaturated": {
+			reason: "The poll interval should be doubled again each time the queue is saturated at enough consecutive samples",
+			depths: []float64{100, 200, 300, 400, 500, 600, 700, 800},
+			want:   4,
+		},
+		"MaxFactor": {
+			reason: "The poll interval should not be lengthened beyond the maximum factor",
+			factor: 4,
+			depths: []float64{100, 100, 100, 100},
+			want:   4,
+		},
+		"Drained": {
+			reason: "The poll interval should be halved once the queue is not saturated at enough consecutive samples",
+			factor: 4,
+			depths: []float64{100, 0, 0, 0, 0},
+			want:   2,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			depth := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: metricDepth}, []string{"name"})
+			r := prometheus.NewRegistry()
+			r.MustRegister(depth)
+
+			m := &Monitor{Gatherer: r, Log: logging.NewNopLogger(), Depth: 100, Samples: 4, MaxFactor: 4}
+			if tc.factor > 0 {
+				m.queues = map[string]*queue{controller: {factor: tc.factor}}
+			}
+			for _, d := range tc.depths {
+				depth.WithLabelValues(controller).Set(d)
+				if err := m.Sample(); err != nil {
+					t.Fatalf("\n%s\nSample(): %s", tc.reason, err)
+				}
+			}
+			if diff := cmp.Diff(tc.want, m.Factor(controller)); diff != "" {
+				t.Errorf("\n%s\nFactor(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	type want struct {
+		result reconcile.Result
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason     string
+		result     reconcile.Result
+		err        error
+		syncPeriod time.Duration
+		annotation string
+		want       want
+	}{
+		"Poll": {
+			reason: "The poll interval should be lengthened by the factor of the controller",
+			result: reconcile.Result{RequeueAfter: time.Minute},
+			want:   want{result: reconcile.Result{RequeueAfter: 2 * time.Minute}},
+		},
+		"Requeue": {
+			reason: "Immediate requeues should not be delayed",
+			result: reconcile.Result{Requeue: true},
+			want:   want{result: reconcile.Result{Requeue: true}},
+		},
+		"Error": {
+			reason: "Errors should be returned unchanged, so that they are retried with backoff",
+			result: reconcile.Result{RequeueAfter: time.Minute},
+			err:    errBoom,
+			want:   want{result: reconcile.Result{RequeueAfter: time.Minute}, err: errBoom},
+		},
+		"SyncPeriod": {
+			reason:     "The sync period of the monitor should replace the poll interval before it is lengthened",
+			result:     reconcile.Result{RequeueAfter: time.Minute},
+			syncPeriod: 10 * time.Minute,
+			want:       want{result: reconcile.Result{RequeueAfter: 20 * time.Minute}},
+		},
+		"AnnotatedSyncPeriod": {
+			reason:     "The sync period annotation of a managed resource should override the sync period of the monitor",
+			result:     reconcile.Result{RequeueAfter: time.Minute},
+			syncPeriod: 10 * time.Minute,
+			annotation: "1h",
+			want:       want{result: reconcile.Result{RequeueAfter: 2 * time.Hour}},
+		},
+		"InvalidAnnotatedSyncPeriod": {
+			reason:     "Sync period annotations that are not a duration should be ignored",
+			result:     reconcile.Result{RequeueAfter: time.Minute},
+			syncPeriod: 10 * time.Minute,
+			annotation: "hourly",
+			want:       want{result: reconcile.Result{RequeueAfter: 20 * time.Minute}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &Monitor{SyncPeriod: tc.syncPeriod, queues: map[string]*queue{controller: {factor: 2}}}
+			wrapped := reconcile.Func(func(_ context.Context, _ reconcile.Request) (reconcile.Result, error) {
+				return tc.result, tc.err
+			})
+			withManaged := func(r *Reconciler) {
+				r.client = &test.MockClient{MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+					if tc.annotation != "" {
+						meta.AddAnnotations(obj, map[string]string{AnnotationKeySyncPeriod: tc.annotation})
+					}
+					return nil
+				}}
+				r.newManaged = func() resource.Managed { return &fake.Managed{} }
+			}
+			got, err := NewReconciler(m, controller, wrapped, withManaged).Reconcile(context.Background(), reconcile.Request{})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nReconcile(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("\n%s\nReconcile(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
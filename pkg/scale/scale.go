@@ -0,0 +1,345 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package scale measures the throughput of the reconciler plumbing that all
+// managed resource controllers of this provider share, i.e. pausing,
+// initializers, reference resolution, finalizers, approval and lock handling,
+// and connection publishing. It reconciles many Redis managed resources
+// against a fake API server and a fake Azure API, so that only the plumbing
+// is measured. Run go test ./pkg/scale -scale-resources=10000 -v for a scale
+// test, or go test ./pkg/scale -run=NONE -bench=. for benchmarks.
+package scale
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	xpfake "github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+
+	"github.com/crossplane/provider-azure/apis"
+	"github.com/crossplane/provider-azure/apis/cache/v1beta1"
+	apisv1beta1 "github.com/crossplane/provider-azure/apis/v1beta1"
+	"github.com/crossplane/provider-azure/pkg/approval"
+	"github.com/crossplane/provider-azure/pkg/audit"
+	"github.com/crossplane/provider-azure/pkg/clients/redact"
+	"github.com/crossplane/provider-azure/pkg/connection"
+	"github.com/crossplane/provider-azure/pkg/defaults"
+	"github.com/crossplane/provider-azure/pkg/dependency"
+	"github.com/crossplane/provider-azure/pkg/externalname"
+	"github.com/crossplane/provider-azure/pkg/location"
+	"github.com/crossplane/provider-azure/pkg/lock"
+	"github.com/crossplane/provider-azure/pkg/management"
+	"github.com/crossplane/provider-azure/pkg/pause"
+	"github.com/crossplane/provider-azure/pkg/protection"
+	"github.com/crossplane/provider-azure/pkg/resync"
+	"github.com/crossplane/provider-azure/pkg/tenancy"
+)
+
+// maxReconciles of a single managed resource. A resource that is not ready
+// after this many reconciles is counted as failed.
+const maxReconciles = 10
+
+// Error strings.
+const (
+	errAddToScheme = "cannot add APIs to scheme"
+	errCreate      = "cannot create %s"
+	errNotReady    = "%d of %d managed resources did not become ready"
+)
+
+// Options of a scale test.
+type Options struct {
+	// Resources is the number of managed resources to reconcile.
+	Resources int
+
+	// Workers is the number of concurrent reconciles, as configured by the
+	// MaxConcurrentReconciles of a controller. Defaults to 1.
+	Workers int
+
+	// Latency of each call to the fake Azure API.
+	Latency time.Duration
+}
+
+// A Result of a scale test.
+type Result struct {
+	// Reconciles that were run until every managed resource was ready.
+	Reconciles int
+
+	// Duration of the reconciles.
+	Duration time.Duration
+
+	// MaxQueueDepth is the largest number of requests that waited in the
+	// work queue at once.
+	MaxQueueDepth int
+
+	// HeapBytes is the growth of the live heap while the managed resources
+	// were created and reconciled.
+	HeapBytes uint64
+
+	// AllocatedBytes is the total size of the heap allocations made while
+	// the managed resources were created and reconciled.
+	AllocatedBytes uint64
+}
+
+// Throughput returns the number of reconciles per second.
+func (r Result) Throughput() float64 {
+	if r.Duration == 0 {
+		return 0
+	}
+	return float64(r.Reconciles) / r.Duration.Seconds()
+}
+
+// String returns a summary of the result.
+func (r Result) String() string {
+	return fmt.Sprintf("%d reconciles in %s (%.0f/s), max queue depth %d, heap growth %d bytes, allocated %d bytes",
+		r.Reconciles, r.Duration, r.Throughput(), r.MaxQueueDepth, r.HeapBytes, r.AllocatedBytes)
+}
+
+// Run a scale test. It creates the requested number of Redis managed
+// resources in a fake API server and reconciles each of them until it is
+// ready, requeueing reconciles that ask for it as a controller would. Polls of
+// ready resources are not requeued.
+func Run(ctx context.Context, o Options) (Result, error) {
+	before := memStats()
+
+	kube, s, err := setup(ctx, o.Resources)
+	if err != nil {
+		return Result{}, err
+	}
+	r := NewReconciler(kube, s, &FakeAzure{Latency: o.Latency})
+	res, err := reconcileAll(ctx, r, o)
+
+	after := memStats()
+	if after.HeapAlloc > before.HeapAlloc {
+		res.HeapBytes = after.HeapAlloc - before.HeapAlloc
+	}
+	res.AllocatedBytes = after.TotalAlloc - before.TotalAlloc
+	return res, err
+}
+
+// setup returns a fake API server client with the supplied number of Redis
+// managed resources and their ProviderConfig, and its scheme.
+func setup(ctx context.Context, resources int) (client.Client, *kruntime.Scheme, error) {
+	s := kruntime.NewScheme()
+	if err := corev1.AddToScheme(s); err != nil {
+		return nil, nil, errors.Wrap(err, errAddToScheme)
+	}
+	if err := apis.AddToScheme(s); err != nil {
+		return nil, nil, errors.Wrap(err, errAddToScheme)
+	}
+
+	kube := fake.NewClientBuilder().WithScheme(s).Build()
+	pc := &apisv1beta1.ProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "default"},
+		Spec: apisv1beta1.ProviderConfigSpec{
+			DefaultLocation:          stringPtr("westus2"),
+			DefaultResourceGroupName: stringPtr("scale"),
+			DefaultTags:              map[string]string{"test": "scale"},
+		},
+	}
+	if err := kube.Create(ctx, pc); err != nil {
+		return nil, nil, errors.Wrapf(err, errCreate, "ProviderConfig")
+	}
+	for i := 0; i < resources; i++ {
+		if err := kube.Create(ctx, NewRedis(i)); err != nil {
+			return nil, nil, errors.Wrapf(err, errCreate, "Redis")
+		}
+	}
+	return kube, s, nil
+}
+
+// NewRedis returns the i-th Redis managed resource of a scale test. It
+// omits the location and resource group, which are defaulted by its
+// ProviderConfig.
+func NewRedis(i int) *v1beta1.Redis {
+	cr := &v1beta1.Redis{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("scale-%05d", i)}}
+	cr.SetProviderConfigReference(&xpv1.Reference{Name: "default"})
+	cr.Spec.ForProvider.SKU = v1beta1.SKU{Name: "Basic", Family: "C", Capacity: 0}
+	return cr
+}
+
+// NewReconciler returns a reconciler of Redis managed resources that is
+// composed like that of the Redis controller, but that manages external
+// resources with the supplied connecter.
+func NewReconciler(kube client.Client, s *kruntime.Scheme, c managed.ExternalConnecter) reconcile.Reconciler {
+	mgr := &xpfake.Manager{Client: kube, Scheme: s}
+	gate := approval.NewProviderConfigGate(kube, s)
+	return resync.NewReconciler(resync.DefaultMonitor, managed.ControllerName(v1beta1.RedisGroupKind), pause.NewReconciler(mgr, resource.ManagedKind(v1beta1.RedisGroupVersionKind),
+		managed.NewReconciler(mgr,
+			resource.ManagedKind(v1beta1.RedisGroupVersionKind),
+			managed.WithConnectionPublishers(connection.NewNamespacePolicyPublisher(connection.DefaultNamespacePolicy, connection.NewTemplatingPublisher(connection.NewKeyVaultPublisher(kube, s,
+				managed.NewAPISecretPublisher(kube, s),
+				connection.NewAdditionalNamespacesPublisher(kube, s))))),
+			managed.WithExternalConnecter(redact.NewConnecter(management.NewConnecter(lock.NewConnecter(protection.NewConnecter(approval.NewConnecter(audit.NewConnecter(c, kube, s, audit.DefaultTrail), gate)), lock.NewAPIListerFn(kube))))),
+			managed.WithInitializers(managed.NewNameAsExternalName(kube), externalname.NewInitializer(kube), tenancy.NewDefaultProviderInitializer(kube), location.NewInitializer(kube), defaults.NewInitializer(kube), connection.NewNamespaceInitializer(kube, connection.DefaultNamespacePolicy)),
+			managed.WithReferenceResolver(dependency.NewReferenceResolver(kube)))))
+}
+
+func reconcileAll(ctx context.Context, r reconcile.Reconciler, o Options) (Result, error) {
+	workers := o.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	q := workqueue.New()
+	var depth int64
+	add := func(req reconcile.Request) {
+		q.Add(req)
+		for d := int64(q.Len()); ; {
+			m := atomic.LoadInt64(&depth)
+			if d <= m || atomic.CompareAndSwapInt64(&depth, m, d) {
+				break
+			}
+		}
+	}
+
+	var (
+		mu         sync.Mutex
+		attempts   = make(map[types.NamespacedName]int, o.Resources)
+		finished   int
+		failed     int
+		reconciles int64
+	)
+	// done records the outcome of a reconcile and returns true if the
+	// request should be requeued. The queue is shut down once every resource
+	// either became ready or exhausted its reconciles.
+	done := func(nn types.NamespacedName, requeue bool) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts[nn]++
+		if requeue && attempts[nn] < maxReconciles {
+			return true
+		}
+		if requeue {
+			failed++
+		}
+		if finished++; finished == o.Resources {
+			q.ShutDown()
+		}
+		return false
+	}
+
+	start := time.Now()
+	if o.Resources == 0 {
+		q.ShutDown()
+	}
+	for i := 0; i < o.Resources; i++ {
+		add(reconcile.Request{NamespacedName: types.NamespacedName{Name: NewRedis(i).GetName()}})
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				item, shutdown := q.Get()
+				if shutdown {
+					return
+				}
+				req := item.(reconcile.Request)
+				rs, err := r.Reconcile(ctx, req)
+				atomic.AddInt64(&reconciles, 1)
+				requeue := err != nil || rs.Requeue || rs.RequeueAfter == 0
+				if done(req.NamespacedName, requeue) {
+					add(req)
+				}
+				q.Done(item)
+			}
+		}()
+	}
+	wg.Wait()
+
+	res := Result{
+		Reconciles:    int(atomic.LoadInt64(&reconciles)),
+		Duration:      time.Since(start),
+		MaxQueueDepth: int(atomic.LoadInt64(&depth)),
+	}
+	if failed > 0 {
+		return res, errors.Errorf(errNotReady, failed, o.Resources)
+	}
+	return res, nil
+}
+
+func memStats() runtime.MemStats {
+	runtime.GC()
+	ms := runtime.MemStats{}
+	runtime.ReadMemStats(&ms)
+	return ms
+}
+
+func stringPtr(s string) *string { return &s }
+
+// FakeAzure is an external connecter that manages external resources in
+// memory, as if the Azure API created them instantly.
+type FakeAzure struct {
+	// Latency of each call to the fake Azure API.
+	Latency time.Duration
+
+	resources sync.Map
+}
+
+// Connect returns a client of the fake Azure API.
+func (a *FakeAzure) Connect(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+	return managed.ExternalClientFns{
+		ObserveFn: a.observe,
+		CreateFn:  a.create,
+		UpdateFn:  a.update,
+		DeleteFn:  a.delete,
+	}, nil
+}
+
+func (a *FakeAzure) observe(_ context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	time.Sleep(a.Latency)
+	if _, ok := a.resources.Load(mg.GetName()); !ok {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	mg.SetConditions(xpv1.Available())
+	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+}
+
+func (a *FakeAzure) create(_ context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	time.Sleep(a.Latency)
+	a.resources.Store(mg.GetName(), true)
+	return managed.ExternalCreation{}, nil
+}
+
+func (a *FakeAzure) update(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+	time.Sleep(a.Latency)
+	return managed.ExternalUpdate{}, nil
+}
+
+func (a *FakeAzure) delete(_ context.Context, mg resource.Managed) error {
+	time.Sleep(a.Latency)
+	a.resources.Delete(mg.GetName())
+	return nil
+}
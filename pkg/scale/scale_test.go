@@ -0,0 +1,92 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scale
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+var (
+	resources = flag.Int("scale-resources", 100, "Number of managed resources the scale test reconciles.")
+	workers   = flag.Int("scale-workers", 10, "Number of concurrent reconciles of the scale test.")
+	latency   = flag.Duration("scale-latency", 0, "Latency of each call to the fake Azure API of the scale test.")
+)
+
+func TestRun(t *testing.T) {
+	o := Options{Resources: *resources, Workers: *workers, Latency: *latency}
+	res, err := Run(context.Background(), o)
+	if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+		t.Fatalf("Run(...): -want error, +got error:\n%s", diff)
+	}
+	t.Logf("%d resources, %d workers: %s", o.Resources, o.Workers, res)
+
+	// Each resource is reconciled at least twice; once to create its external
+	// resource and once to observe that it is ready.
+	if res.Reconciles < 2*o.Resources {
+		t.Errorf("Run(...): want at least %d reconciles, got %d", 2*o.Resources, res.Reconciles)
+	}
+	if res.MaxQueueDepth > o.Resources {
+		t.Errorf("Run(...): want max queue depth of at most %d, got %d", o.Resources, res.MaxQueueDepth)
+	}
+}
+
+func BenchmarkRun(b *testing.B) {
+	for _, w := range []int{1, 10} {
+		b.Run(fmt.Sprintf("Workers%d", w), func(b *testing.B) {
+			res, err := Run(context.Background(), Options{Resources: b.N, Workers: w, Latency: *latency})
+			if err != nil {
+				b.Fatal(err)
+			}
+			b.ReportMetric(res.Throughput(), "reconciles/s")
+			b.ReportMetric(float64(res.MaxQueueDepth), "max-queue-depth")
+			b.ReportMetric(float64(res.AllocatedBytes)/float64(b.N), "B/resource")
+		})
+	}
+}
+
+func BenchmarkReconcile(b *testing.B) {
+	// Reconcile a ready resource, as a controller does at every poll.
+	ctx := context.Background()
+	kube, s, err := setup(ctx, 1)
+	if err != nil {
+		b.Fatal(err)
+	}
+	r := NewReconciler(kube, s, &FakeAzure{Latency: *latency})
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: NewRedis(0).GetName()}}
+	for i := 0; i < maxReconciles; i++ {
+		if rs, err := r.Reconcile(ctx, req); err == nil && rs.RequeueAfter > 0 {
+			break
+		}
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := r.Reconcile(ctx, req); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
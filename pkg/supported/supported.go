@@ -0,0 +1,114 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package supported rejects managed resources of kinds that the Azure
+// Resource Manager endpoint of their ProviderConfig does not support, e.g.
+// that of an Azure Stack Hub, when they are created rather than when their
+// controller fails to create their Azure resource.
+package supported
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	admissionv1 "k8s.io/api/admission/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+)
+
+// WebhookPath is the path at which the validating webhook is served.
+const WebhookPath = "/validate-supported-kinds"
+
+// Error strings.
+const (
+	errDecode      = "cannot decode object"
+	errGetPC       = "cannot get referenced ProviderConfig"
+	errUnsupported = "%s is not supported by the endpoint %s of ProviderConfig %q, which supports %s"
+)
+
+// Kind returns true if the supplied kind, as kind.group, is supported by the
+// supplied endpoint. Endpoints that do not list their supported kinds
+// support every kind.
+func Kind(e *v1beta1.Endpoint, kind string) bool {
+	if e == nil || len(e.SupportedKinds) == 0 {
+		return true
+	}
+	for _, k := range e.SupportedKinds {
+		if strings.EqualFold(strings.TrimSpace(k), kind) {
+			return true
+		}
+	}
+	return false
+}
+
+// A Validator is an admission handler that rejects the creation of managed
+// resources of kinds the endpoint of their ProviderConfig does not support.
+type Validator struct {
+	client client.Reader
+}
+
+// NewValidator returns a Validator that reads ProviderConfigs using the
+// supplied client.
+func NewValidator(c client.Reader) *Validator {
+	return &Validator{client: c}
+}
+
+// object is the part of a managed resource the Validator reads.
+type object struct {
+	Spec struct {
+		ProviderConfigReference *struct {
+			Name string `json:"name"`
+		} `json:"providerConfigRef,omitempty"`
+	} `json:"spec"`
+}
+
+// Handle the supplied admission request. Only managed resources that are
+// being created are validated, and only if their ProviderConfig exists.
+func (v *Validator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Create {
+		return admission.Allowed("")
+	}
+	o := &object{}
+	if err := json.Unmarshal(req.Object.Raw, o); err != nil {
+		return admission.Errored(http.StatusBadRequest, errors.Wrap(err, errDecode))
+	}
+	ref := o.Spec.ProviderConfigReference
+	if ref == nil {
+		return admission.Allowed("")
+	}
+	pc := &v1beta1.ProviderConfig{}
+	if err := v.client.Get(ctx, types.NamespacedName{Name: ref.Name}, pc); err != nil {
+		if kerrors.IsNotFound(err) {
+			return admission.Allowed("")
+		}
+		return admission.Errored(http.StatusInternalServerError, errors.Wrap(err, errGetPC))
+	}
+	kind := schema.GroupKind{Group: req.Kind.Group, Kind: req.Kind.Kind}.String()
+	e := pc.Spec.Endpoint
+	if Kind(e, kind) {
+		return admission.Allowed("")
+	}
+	return admission.Denied(fmt.Sprintf(errUnsupported, kind, e.ResourceManagerURL, pc.GetName(), strings.Join(e.SupportedKinds, ", ")))
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package supported
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	admissionv1 "k8s.io/api/admission/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	cachev1beta1 "github.com/crossplane/provider-azure/apis/cache/v1beta1"
+	networkv1beta1 "github.com/crossplane/provider-azure/apis/network/v1beta1"
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+)
+
+var _ admission.Handler = &Validator{}
+
+func TestHandle(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	request := func(op admissionv1.Operation, group, kind string) admission.Request {
+		cr := &cachev1beta1.Redis{ObjectMeta: metav1.ObjectMeta{Name: "cool"}}
+		cr.SetProviderConfigReference(&xpv1.Reference{Name: "cool-pc"})
+		raw, _ := json.Marshal(cr)
+		return admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: op,
+			Kind:      metav1.GroupVersionKind{Group: group, Version: "v1beta1", Kind: kind},
+			Object:    runtime.RawExtension{Raw: raw},
+		}}
+	}
+	pc := func(kinds ...string) client.Reader {
+		return &test.MockClient{MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+			o.(*v1beta1.ProviderConfig).Spec.Endpoint = &v1beta1.Endpoint{
+				ResourceManagerURL: "https://management.local.azurestack.external/",
+				SupportedKinds:     kinds,
+			}
+			return nil
+		})}
+	}
+
+	type want struct {
+		allowed bool
+		code    int32
+	}
+
+	cases := map[string]struct {
+		reason string
+		client client.Reader
+		req    admission.Request
+		want   want
+	}{
+		"Update": {
+			reason: "Updates should be allowed without being validated.",
+			client: pc("Subnet.network.azure.crossplane.io"),
+			req:    request(admissionv1.Update, cachev1beta1.Group, cachev1beta1.RedisKind),
+			want:   want{allowed: true},
+		},
+		"AllKindsSupported": {
+			reason: "Creates should be allowed if the endpoint does not list its supported kinds.",
+			client: pc(),
+			req:    request(admissionv1.Create, cachev1beta1.Group, cachev1beta1.RedisKind),
+			want:   want{allowed: true},
+		},
+		"Supported": {
+			reason: "Creates of supported kinds should be allowed, regardless of case.",
+			client: pc("subnet.network.azure.crossplane.io"),
+			req:    request(admissionv1.Create, networkv1beta1.Group, networkv1beta1.SubnetKind),
+			want:   want{allowed: true},
+		},
+		"Unsupported": {
+			reason: "Creates of kinds the endpoint does not support should be denied.",
+			client: pc("Subnet.network.azure.crossplane.io"),
+			req:    request(admissionv1.Create, cachev1beta1.Group, cachev1beta1.RedisKind),
+			want:   want{code: http.StatusForbidden},
+		},
+		"ProviderConfigNotFound": {
+			reason: "Creates of resources whose ProviderConfig does not exist should be allowed.",
+			client: &test.MockClient{MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "cool-pc"))},
+			req:    request(admissionv1.Create, cachev1beta1.Group, cachev1beta1.RedisKind),
+			want:   want{allowed: true},
+		},
+		"GetProviderConfigError": {
+			reason: "Errors getting the ProviderConfig should be returned.",
+			client: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			req:    request(admissionv1.Create, cachev1beta1.Group, cachev1beta1.RedisKind),
+			want:   want{code: http.StatusInternalServerError},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := NewValidator(tc.client).Handle(context.Background(), tc.req)
+			if diff := cmp.Diff(tc.want.allowed, got.Allowed); diff != "" {
+				t.Errorf("\n%s\nHandle(...): -want allowed, +got allowed:\n%s\n%v", tc.reason, diff, got.Result)
+			}
+			if got.Allowed {
+				return
+			}
+			if diff := cmp.Diff(tc.want.code, got.Result.Code); diff != "" {
+				t.Errorf("\n%s\nHandle(...): -want code, +got code:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
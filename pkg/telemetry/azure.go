@@ -0,0 +1,119 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package telemetry
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/go-autorest/tracing"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/semconv"
+	"go.opentelemetry.io/otel/trace"
+
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/metrics"
+)
+
+// HeaderRequestID is the header of the ID Azure assigns to a request.
+const HeaderRequestID = "x-ms-request-id"
+
+// Enable traces the requests of every Azure SDK client that does not have its
+// own Sender, and the SDK operations that send them, e.g.
+// SubnetsClient.CreateOrUpdate. It registers a go-autorest tracer, whose
+// transport the clients send their requests through. There can only be one,
+// so the requests are sent through the transports the supplied functions
+// wrap around the transport of the clients, e.g. to record metrics.
+func Enable(wrap ...func(http.RoundTripper) http.RoundTripper) {
+	tracing.Register(&tracer{wrap: wrap})
+}
+
+type tracer struct {
+	wrap []func(http.RoundTripper) http.RoundTripper
+}
+
+func (t *tracer) NewTransport(base *http.Transport) http.RoundTripper {
+	var rt http.RoundTripper = base
+	for _, fn := range t.wrap {
+		rt = fn(rt)
+	}
+	return NewTransport(rt)
+}
+
+func (t *tracer) StartSpan(ctx context.Context, name string) context.Context {
+	ctx, _ = start(ctx, name)
+	return ctx
+}
+
+func (t *tracer) EndSpan(ctx context.Context, httpStatusCode int, err error) {
+	span := trace.SpanFromContext(ctx)
+	if httpStatusCode > 0 {
+		span.SetAttributes(semconv.HTTPStatusCodeKey.Int(httpStatusCode))
+	}
+	end(span, err)
+}
+
+// NewTransport returns a transport that traces the requests it sends through
+// the supplied transport, with the ID of the Azure resource they are sent to
+// and the correlation ID of their Azure Resource Manager operation.
+func NewTransport(base http.RoundTripper) http.RoundTripper {
+	return &transport{base: base}
+}
+
+type transport struct {
+	base http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// The query of a request is not recorded, since that of a SAS URL
+	// includes its signature.
+	ctx, span := start(req.Context(), req.Method+" "+metrics.API(req), trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.HTTPMethodKey.String(req.Method),
+		semconv.HTTPHostKey.String(req.URL.Host),
+		AttributeResourceID.String(ResourceID(req)),
+	))
+	rsp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		end(span, err)
+		return rsp, err
+	}
+	id := rsp.Header.Get(azure.HeaderCorrelationID)
+	if id == "" {
+		id = req.Header.Get(azure.HeaderCorrelationID)
+	}
+	span.SetAttributes(
+		semconv.HTTPStatusCodeKey.Int(rsp.StatusCode),
+		AttributeCorrelationID.String(id),
+		AttributeRequestID.String(rsp.Header.Get(HeaderRequestID)),
+	)
+	if rsp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, http.StatusText(rsp.StatusCode))
+	}
+	span.End()
+	return rsp, nil
+}
+
+// ResourceID returns the ID of the Azure resource the supplied Azure Resource
+// Manager request is sent to, i.e. the path of its URL, or an empty string if
+// it is not sent to Azure Resource Manager.
+func ResourceID(req *http.Request) string {
+	if metrics.Subscription(req) == "" {
+		return ""
+	}
+	return strings.TrimSuffix(req.URL.Path, "/")
+}
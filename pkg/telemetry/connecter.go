@@ -0,0 +1,99 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package telemetry
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// NewConnecter returns an ExternalConnecter that traces the connections of
+// its clients and their operations on the external resources of managed
+// resources of the supplied kind, e.g. Subnet.network.azure.crossplane.io.
+// The Azure SDK requests of an operation are traced as its children, if
+// Enable was called.
+func NewConnecter(c managed.ExternalConnecter, kind string) managed.ExternalConnecter {
+	return &connecter{ExternalConnecter: c, kind: kind}
+}
+
+type connecter struct {
+	managed.ExternalConnecter
+	kind string
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	ctx, span := startOperation(ctx, c.kind, "Connect", mg)
+	e, err := c.ExternalConnecter.Connect(ctx, mg)
+	end(span, err)
+	if err != nil {
+		return nil, err
+	}
+	return &external{ExternalClient: e, kind: c.kind}, nil
+}
+
+// startOperation starts a span of the supplied operation on the supplied
+// managed resource of the supplied kind, as a child of the span of the
+// reconcile of the managed resource, if any.
+func startOperation(ctx context.Context, kind, op string, mg resource.Managed) (context.Context, trace.Span) {
+	if s, ok := reconciles.Load(key(kind, types.NamespacedName{Namespace: mg.GetNamespace(), Name: mg.GetName()})); ok {
+		ctx = trace.ContextWithSpan(ctx, s.(trace.Span))
+	}
+	return start(ctx, op, trace.WithAttributes(
+		AttributeKind.String(kind),
+		AttributeName.String(mg.GetName()),
+		AttributeExternalName.String(meta.GetExternalName(mg)),
+	))
+}
+
+type external struct {
+	managed.ExternalClient
+	kind string
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	ctx, span := startOperation(ctx, e.kind, "Observe", mg)
+	o, err := e.ExternalClient.Observe(ctx, mg)
+	end(span, err)
+	return o, err
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	ctx, span := startOperation(ctx, e.kind, "Create", mg)
+	c, err := e.ExternalClient.Create(ctx, mg)
+	end(span, err)
+	return c, err
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	ctx, span := startOperation(ctx, e.kind, "Update", mg)
+	u, err := e.ExternalClient.Update(ctx, mg)
+	end(span, err)
+	return u, err
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	ctx, span := startOperation(ctx, e.kind, "Delete", mg)
+	err := e.ExternalClient.Delete(ctx, mg)
+	end(span, err)
+	return err
+}
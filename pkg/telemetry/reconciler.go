@@ -0,0 +1,50 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package telemetry
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// A Reconciler traces each reconcile of the reconciler it wraps as a span.
+type Reconciler struct {
+	wrapped reconcile.Reconciler
+	kind    string
+}
+
+// NewReconciler returns a Reconciler that traces the reconciles of managed
+// resources of the supplied kind, e.g. Subnet.network.azure.crossplane.io, by
+// the supplied reconciler. The spans of the clients NewConnecter returns for
+// the same kind are children of the span of the reconcile they are part of.
+func NewReconciler(kind string, r reconcile.Reconciler) *Reconciler {
+	return &Reconciler{wrapped: r, kind: kind}
+}
+
+// Reconcile the supplied request with the wrapped reconciler.
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	ctx, span := start(ctx, "Reconcile "+r.kind, trace.WithAttributes(AttributeKind.String(r.kind), AttributeName.String(req.Name)))
+	k := key(r.kind, req.NamespacedName)
+	reconciles.Store(k, span)
+	defer reconciles.Delete(k)
+
+	res, err := r.wrapped.Reconcile(ctx, req)
+	end(span, err)
+	return res, err
+}
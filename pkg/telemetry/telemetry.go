@@ -0,0 +1,118 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package telemetry traces the reconciles of managed resources, the
+// operations their controllers perform on their external resources, and the
+// Azure API requests those send, so that a slow reconcile can be traced to
+// the Azure Resource Manager operation at fault. Spans are recorded using the
+// global OpenTelemetry TracerProvider, and are only exported once Setup has
+// configured an OTLP exporter.
+package telemetry
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpgrpc"
+	"go.opentelemetry.io/otel/label"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/semconv"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// InstrumentationName is the name of the tracer spans are recorded with.
+const InstrumentationName = "github.com/crossplane/provider-azure"
+
+// ServiceName is the name of the service spans are exported as.
+const ServiceName = "provider-azure"
+
+// Attributes of spans.
+const (
+	AttributeKind          = label.Key("crossplane.kind")
+	AttributeName          = label.Key("crossplane.name")
+	AttributeExternalName  = label.Key("crossplane.external_name")
+	AttributeResourceID    = label.Key("azure.resource_id")
+	AttributeCorrelationID = label.Key("azure.correlation_id")
+	AttributeRequestID     = label.Key("azure.request_id")
+)
+
+// Error strings.
+const (
+	errExporter = "cannot create OTLP exporter"
+)
+
+// Options configure the export of spans.
+type Options struct {
+	// Endpoint is the host:port of the OTLP gRPC receiver, e.g. that of an
+	// OpenTelemetry collector, to export spans to.
+	Endpoint string
+
+	// Insecure exports spans without TLS.
+	Insecure bool
+
+	// SampleRatio is the fraction of reconciles that are traced.
+	SampleRatio float64
+}
+
+// Setup exports the spans of a sample of reconciles to an OTLP receiver. The
+// returned function flushes the spans that were not yet exported, and stops
+// exporting.
+func Setup(ctx context.Context, o Options) (func(context.Context) error, error) {
+	do := []otlpgrpc.Option{otlpgrpc.WithEndpoint(o.Endpoint)}
+	if o.Insecure {
+		do = append(do, otlpgrpc.WithInsecure())
+	}
+	exp, err := otlp.NewExporter(ctx, otlpgrpc.NewDriver(do...))
+	if err != nil {
+		return nil, errors.Wrap(err, errExporter)
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithConfig(sdktrace.Config{DefaultSampler: sdktrace.ParentBased(sdktrace.TraceIDRatioBased(o.SampleRatio))}),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.ServiceNameKey.String(ServiceName))),
+		sdktrace.WithBatcher(exp),
+	)
+	otel.SetTracerProvider(tp)
+	return tp.Shutdown, nil
+}
+
+func start(ctx context.Context, name string, o ...trace.SpanOption) (context.Context, trace.Span) {
+	return otel.Tracer(InstrumentationName).Start(ctx, name, o...)
+}
+
+// end the supplied span, which failed if the supplied error is not nil.
+func end(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// The managed resource reconciler does not pass the context of a reconcile on
+// to the clients of external resources, so the spans of reconciles are
+// registered by the managed resource they reconcile. A managed resource is
+// never reconciled by more than one worker at a time.
+var reconciles sync.Map
+
+func key(kind string, nn types.NamespacedName) string {
+	return kind + "/" + nn.String()
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package telemetry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/label"
+	"go.opentelemetry.io/otel/oteltest"
+	"go.opentelemetry.io/otel/semconv"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-azure/apis/network/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+// record spans with a test TracerProvider. The returned function restores
+// the previous TracerProvider.
+func record() (*oteltest.StandardSpanRecorder, func()) {
+	sr := &oteltest.StandardSpanRecorder{}
+	before := otel.GetTracerProvider()
+	otel.SetTracerProvider(oteltest.NewTracerProvider(oteltest.WithSpanRecorder(sr)))
+	return sr, func() { otel.SetTracerProvider(before) }
+}
+
+func attributes(s *oteltest.Span) map[label.Key]string {
+	a := map[label.Key]string{}
+	for k, v := range s.Attributes() {
+		a[k] = v.Emit()
+	}
+	return a
+}
+
+func TestReconcile(t *testing.T) {
+	sr, restore := record()
+	defer restore()
+
+	sn := &v1beta1.Subnet{}
+	sn.SetName("cool-subnet")
+	meta.SetExternalName(sn, "cool-external")
+
+	c := NewConnecter(managed.ExternalConnectorFn(func(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+		return &managed.ExternalClientFns{
+			ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+				return managed.ExternalObservation{ResourceExists: true}, nil
+			},
+		}, nil
+	}), v1beta1.SubnetGroupKind)
+
+	// Like the managed resource reconciler, the wrapped reconciler does not
+	// use the context it is passed.
+	r := NewReconciler(v1beta1.SubnetGroupKind, reconcile.Func(func(_ context.Context, _ reconcile.Request) (reconcile.Result, error) {
+		e, err := c.Connect(context.Background(), sn)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		_, err = e.Observe(context.Background(), sn)
+		return reconcile.Result{}, err
+	}))
+	if _, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "cool-subnet"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	spans := map[string]*oteltest.Span{}
+	for _, s := range sr.Completed() {
+		spans[s.Name()] = s
+	}
+	rs, ok := spans["Reconcile "+v1beta1.SubnetGroupKind]
+	if !ok {
+		t.Fatalf("Reconcile(...): no span of the reconcile in %v", spans)
+	}
+	for _, op := range []string{"Connect", "Observe"} {
+		s, ok := spans[op]
+		if !ok {
+			t.Fatalf("Reconcile(...): no span of %s in %v", op, spans)
+		}
+		if diff := cmp.Diff(rs.SpanContext().SpanID, s.ParentSpanID()); diff != "" {
+			t.Errorf("Reconcile(...): %s: -want parent, +got parent:\n%s", op, diff)
+		}
+		if diff := cmp.Diff("cool-external", attributes(s)[AttributeExternalName]); diff != "" {
+			t.Errorf("Reconcile(...): %s: -want external name, +got external name:\n%s", op, diff)
+		}
+	}
+}
+
+func TestTransport(t *testing.T) {
+	sr, restore := record()
+	defer restore()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set(azure.HeaderCorrelationID, "cool-correlation")
+		w.Header().Set(HeaderRequestID, "cool-request")
+		w.WriteHeader(http.StatusConflict)
+	}))
+	defer srv.Close()
+
+	id := "/subscriptions/cool-sub/resourceGroups/cool-rg/providers/Microsoft.Network/virtualNetworks/cool-vnet/subnets/cool-subnet"
+	req, _ := http.NewRequest(http.MethodPut, srv.URL+id+"?api-version=2019-06-01", nil)
+	rsp, err := NewTransport(http.DefaultTransport).RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = rsp.Body.Close()
+
+	spans := sr.Completed()
+	if len(spans) != 1 {
+		t.Fatalf("RoundTrip(...): want 1 span, got %d", len(spans))
+	}
+	want := map[label.Key]label.Value{
+		AttributeResourceID:       label.StringValue(id),
+		AttributeCorrelationID:    label.StringValue("cool-correlation"),
+		AttributeRequestID:        label.StringValue("cool-request"),
+		semconv.HTTPStatusCodeKey: label.IntValue(http.StatusConflict),
+		semconv.HTTPMethodKey:     label.StringValue(http.MethodPut),
+		semconv.HTTPHostKey:       label.StringValue(req.URL.Host),
+	}
+	if diff := cmp.Diff(want, spans[0].Attributes(), cmp.AllowUnexported(label.Value{})); diff != "" {
+		t.Errorf("RoundTrip(...): -want attributes, +got attributes:\n%s", diff)
+	}
+	if diff := cmp.Diff("PUT Microsoft.Network", spans[0].Name()); diff != "" {
+		t.Errorf("RoundTrip(...): -want name, +got name:\n%s", diff)
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tenancy supports sharing this provider between tenants that are
+// separated by namespace.
+package tenancy
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// Annotations of a namespace that select the ProviderConfig, or the
+// deprecated Provider, of managed resources claimed from the namespace that do
+// not reference one.
+const (
+	AnnotationKeyDefaultProviderConfig = "azure.crossplane.io/default-provider-config"
+	AnnotationKeyDefaultProvider       = "azure.crossplane.io/default-provider"
+)
+
+// LabelKeyClaimNamespace is the label Crossplane sets on managed resources
+// that are created for a claim, holding the namespace of the claim.
+const LabelKeyClaimNamespace = "crossplane.io/claim-namespace"
+
+// Error strings.
+const (
+	errGetNamespace  = "cannot get namespace of claim"
+	errUpdateManaged = "cannot update managed resource with default provider"
+)
+
+// A DefaultProviderInitializer sets the ProviderConfig or Provider reference
+// of managed resources that have neither to the default of the namespace of
+// their claim, if that namespace has one.
+type DefaultProviderInitializer struct {
+	client client.Client
+}
+
+// NewDefaultProviderInitializer returns a new DefaultProviderInitializer.
+func NewDefaultProviderInitializer(c client.Client) *DefaultProviderInitializer {
+	return &DefaultProviderInitializer{client: c}
+}
+
+// Initialize the provider reference of the supplied managed resource. It is a
+// no-op for resources that already reference a ProviderConfig or Provider, and
+// for resources that were not created for a claim.
+func (i *DefaultProviderInitializer) Initialize(ctx context.Context, mg resource.Managed) error {
+	if mg.GetProviderConfigReference() != nil || mg.GetProviderReference() != nil {
+		return nil
+	}
+	name := mg.GetLabels()[LabelKeyClaimNamespace]
+	if name == "" {
+		return nil
+	}
+	ns := &corev1.Namespace{}
+	if err := i.client.Get(ctx, types.NamespacedName{Name: name}, ns); err != nil {
+		return errors.Wrap(resource.IgnoreNotFound(err), errGetNamespace)
+	}
+	a := ns.GetAnnotations()
+	switch {
+	case a[AnnotationKeyDefaultProviderConfig] != "":
+		mg.SetProviderConfigReference(&xpv1.Reference{Name: a[AnnotationKeyDefaultProviderConfig]})
+	case a[AnnotationKeyDefaultProvider] != "":
+		mg.SetProviderReference(&xpv1.Reference{Name: a[AnnotationKeyDefaultProvider]})
+	default:
+		return nil
+	}
+	return errors.Wrap(i.client.Update(ctx, mg), errUpdateManaged)
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tenancy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+var _ managed.Initializer = &DefaultProviderInitializer{}
+
+func TestInitialize(t *testing.T) {
+	errBoom := errors.New("boom")
+	claimed := metav1.ObjectMeta{Labels: map[string]string{LabelKeyClaimNamespace: "team-a"}}
+	namespace := func(annotations map[string]string) test.MockGetFn {
+		return test.NewMockGetFn(nil, func(o client.Object) error {
+			o.(*corev1.Namespace).SetAnnotations(annotations)
+			return nil
+		})
+	}
+
+	type want struct {
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		client client.Client
+		mg     resource.Managed
+		want   want
+	}{
+		"HasProviderConfig": {
+			reason: "Resources that reference a ProviderConfig should not be changed",
+			mg: &fake.Managed{
+				ObjectMeta:               claimed,
+				ProviderConfigReferencer: fake.ProviderConfigReferencer{Ref: &xpv1.Reference{Name: "cool"}},
+			},
+			want: want{mg: &fake.Managed{
+				ObjectMeta:               claimed,
+				ProviderConfigReferencer: fake.ProviderConfigReferencer{Ref: &xpv1.Reference{Name: "cool"}},
+			}},
+		},
+		"NotClaimed": {
+			reason: "Resources that were not created for a claim should not be changed",
+			mg:     &fake.Managed{},
+			want:   want{mg: &fake.Managed{}},
+		},
+		"NamespaceNotFound": {
+			reason: "Resources whose claim namespace does not exist should not be changed",
+			client: &test.MockClient{MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "team-a"))},
+			mg:     &fake.Managed{ObjectMeta: claimed},
+			want:   want{mg: &fake.Managed{ObjectMeta: claimed}},
+		},
+		"GetNamespaceError": {
+			reason: "Errors getting the claim namespace should be returned",
+			client: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			mg:     &fake.Managed{ObjectMeta: claimed},
+			want: want{
+				mg:  &fake.Managed{ObjectMeta: claimed},
+				err: errors.Wrap(errBoom, errGetNamespace),
+			},
+		},
+		"NoDefault": {
+			reason: "Resources whose claim namespace has no default should not be changed",
+			client: &test.MockClient{MockGet: namespace(nil)},
+			mg:     &fake.Managed{ObjectMeta: claimed},
+			want:   want{mg: &fake.Managed{ObjectMeta: claimed}},
+		},
+		"DefaultProviderConfig": {
+			reason: "The default ProviderConfig of the claim namespace should be preferred and persisted",
+			client: &test.MockClient{
+				MockGet: namespace(map[string]string{
+					AnnotationKeyDefaultProviderConfig: "team-a",
+					AnnotationKeyDefaultProvider:       "legacy",
+				}),
+				MockUpdate: test.NewMockUpdateFn(nil),
+			},
+			mg: &fake.Managed{ObjectMeta: claimed},
+			want: want{mg: &fake.Managed{
+				ObjectMeta:               claimed,
+				ProviderConfigReferencer: fake.ProviderConfigReferencer{Ref: &xpv1.Reference{Name: "team-a"}},
+			}},
+		},
+		"DefaultProvider": {
+			reason: "The default Provider of the claim namespace should be used when it has no default ProviderConfig",
+			client: &test.MockClient{
+				MockGet:    namespace(map[string]string{AnnotationKeyDefaultProvider: "legacy"}),
+				MockUpdate: test.NewMockUpdateFn(nil),
+			},
+			mg: &fake.Managed{ObjectMeta: claimed},
+			want: want{mg: &fake.Managed{
+				ObjectMeta:         claimed,
+				ProviderReferencer: fake.ProviderReferencer{Ref: &xpv1.Reference{Name: "legacy"}},
+			}},
+		},
+		"UpdateError": {
+			reason: "Errors persisting the default should be returned",
+			client: &test.MockClient{
+				MockGet:    namespace(map[string]string{AnnotationKeyDefaultProviderConfig: "team-a"}),
+				MockUpdate: test.NewMockUpdateFn(errBoom),
+			},
+			mg: &fake.Managed{ObjectMeta: claimed},
+			want: want{
+				mg: &fake.Managed{
+					ObjectMeta:               claimed,
+					ProviderConfigReferencer: fake.ProviderConfigReferencer{Ref: &xpv1.Reference{Name: "team-a"}},
+				},
+				err: errors.Wrap(errBoom, errUpdateManaged),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := NewDefaultProviderInitializer(tc.client).Initialize(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nInitialize(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg); diff != "" {
+				t.Errorf("\n%s\nInitialize(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
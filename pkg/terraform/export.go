@@ -0,0 +1,456 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package terraform exports the managed resources of this provider as
+// Terraform import blocks, so that they can be adopted by, or audited against,
+// a Terraform configuration using the azurerm provider.
+package terraform
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	cachev1beta1 "github.com/crossplane/provider-azure/apis/cache/v1beta1"
+	cognitiveservicesv1alpha1 "github.com/crossplane/provider-azure/apis/cognitiveservices/v1alpha1"
+	computev1alpha3 "github.com/crossplane/provider-azure/apis/compute/v1alpha3"
+	databasev1alpha3 "github.com/crossplane/provider-azure/apis/database/v1alpha3"
+	databasev1beta1 "github.com/crossplane/provider-azure/apis/database/v1beta1"
+	eventhubv1alpha1 "github.com/crossplane/provider-azure/apis/eventhub/v1alpha1"
+	kubernetesv1alpha1 "github.com/crossplane/provider-azure/apis/kubernetes/v1alpha1"
+	kustov1alpha1 "github.com/crossplane/provider-azure/apis/kusto/v1alpha1"
+	monitorv1alpha1 "github.com/crossplane/provider-azure/apis/monitor/v1alpha1"
+	networkv1beta1 "github.com/crossplane/provider-azure/apis/network/v1beta1"
+	storagev1alpha3 "github.com/crossplane/provider-azure/apis/storage/v1alpha3"
+	storagesyncv1alpha1 "github.com/crossplane/provider-azure/apis/storagesync/v1alpha1"
+	"github.com/crossplane/provider-azure/apis/v1alpha3"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+// Error strings.
+const (
+	errList         = "cannot list %s resources"
+	errSubscription = "cannot get subscription of %s %q"
+	errWrite        = "cannot write import block"
+)
+
+// An IDFn returns the Azure resource ID of the supplied managed resource in
+// the supplied subscription.
+type IDFn func(subscription string, mg resource.Managed) string
+
+// A Kind of managed resource that can be exported, along with the type of the
+// azurerm Terraform resource it corresponds to.
+type Kind struct {
+	List resource.ManagedList
+	Type string
+	ID   IDFn
+}
+
+// Kinds returns every kind of managed resource that can be exported.
+func Kinds() []Kind {
+	return []Kind{
+		{
+			List: &v1alpha3.ResourceGroupList{},
+			Type: "azurerm_resource_group",
+			ID: func(s string, mg resource.Managed) string {
+				return ResourceGroupID(s, meta.GetExternalName(mg))
+			},
+		},
+		{
+			List: &cachev1beta1.RedisList{},
+			Type: "azurerm_redis_cache",
+			ID: func(s string, mg resource.Managed) string {
+				cr := mg.(*cachev1beta1.Redis)
+				return ResourceID(s, cr.Spec.ForProvider.ResourceGroupName, "Microsoft.Cache/Redis", meta.GetExternalName(cr))
+			},
+		},
+		{
+			List: &cognitiveservicesv1alpha1.OpenAIDeploymentList{},
+			Type: "azurerm_cognitive_deployment",
+			ID: func(s string, mg resource.Managed) string {
+				cr := mg.(*cognitiveservicesv1alpha1.OpenAIDeployment)
+				p := cr.Spec.ForProvider
+				return ResourceID(s, p.ResourceGroupName, "Microsoft.CognitiveServices/accounts", p.AccountName, "deployments", meta.GetExternalName(cr))
+			},
+		},
+		{
+			List: &computev1alpha3.AKSClusterList{},
+			Type: "azurerm_kubernetes_cluster",
+			ID: func(s string, mg resource.Managed) string {
+				cr := mg.(*computev1alpha3.AKSCluster)
+				return ResourceID(s, cr.Spec.ResourceGroupName, "Microsoft.ContainerService/managedClusters", meta.GetExternalName(cr))
+			},
+		},
+		{
+			List: &computev1alpha3.DedicatedHostGroupList{},
+			Type: "azurerm_dedicated_host_group",
+			ID: func(s string, mg resource.Managed) string {
+				cr := mg.(*computev1alpha3.DedicatedHostGroup)
+				return ResourceID(s, cr.Spec.ForProvider.ResourceGroupName, "Microsoft.Compute/hostGroups", meta.GetExternalName(cr))
+			},
+		},
+		{
+			List: &computev1alpha3.DedicatedHostList{},
+			Type: "azurerm_dedicated_host",
+			ID: func(s string, mg resource.Managed) string {
+				cr := mg.(*computev1alpha3.DedicatedHost)
+				return ResourceID(s, cr.Spec.ForProvider.ResourceGroupName, "Microsoft.Compute/hostGroups", cr.Spec.ForProvider.HostGroupName, "hosts", meta.GetExternalName(cr))
+			},
+		},
+		{
+			List: &computev1alpha3.CapacityReservationGroupList{},
+			Type: "azurerm_capacity_reservation_group",
+			ID: func(s string, mg resource.Managed) string {
+				cr := mg.(*computev1alpha3.CapacityReservationGroup)
+				return ResourceID(s, cr.Spec.ForProvider.ResourceGroupName, "Microsoft.Compute/capacityReservationGroups", meta.GetExternalName(cr))
+			},
+		},
+		{
+			List: &computev1alpha3.CapacityReservationList{},
+			Type: "azurerm_capacity_reservation",
+			ID: func(s string, mg resource.Managed) string {
+				cr := mg.(*computev1alpha3.CapacityReservation)
+				return ResourceID(s, cr.Spec.ForProvider.ResourceGroupName, "Microsoft.Compute/capacityReservationGroups", cr.Spec.ForProvider.CapacityReservationGroupName, "capacityReservations", meta.GetExternalName(cr))
+			},
+		},
+		{
+			List: &databasev1beta1.MySQLServerList{},
+			Type: "azurerm_mysql_server",
+			ID: func(s string, mg resource.Managed) string {
+				cr := mg.(*databasev1beta1.MySQLServer)
+				return ResourceID(s, cr.Spec.ForProvider.ResourceGroupName, "Microsoft.DBforMySQL/servers", meta.GetExternalName(cr))
+			},
+		},
+		{
+			List: &databasev1beta1.PostgreSQLServerList{},
+			Type: "azurerm_postgresql_server",
+			ID: func(s string, mg resource.Managed) string {
+				cr := mg.(*databasev1beta1.PostgreSQLServer)
+				return ResourceID(s, cr.Spec.ForProvider.ResourceGroupName, "Microsoft.DBforPostgreSQL/servers", meta.GetExternalName(cr))
+			},
+		},
+		{
+			List: &databasev1alpha3.MySQLServerFirewallRuleList{},
+			Type: "azurerm_mysql_firewall_rule",
+			ID: func(s string, mg resource.Managed) string {
+				cr := mg.(*databasev1alpha3.MySQLServerFirewallRule)
+				return ResourceID(s, cr.Spec.ForProvider.ResourceGroupName, "Microsoft.DBforMySQL/servers", cr.Spec.ForProvider.ServerName, "firewallRules", meta.GetExternalName(cr))
+			},
+		},
+		{
+			List: &databasev1alpha3.PostgreSQLServerFirewallRuleList{},
+			Type: "azurerm_postgresql_firewall_rule",
+			ID: func(s string, mg resource.Managed) string {
+				cr := mg.(*databasev1alpha3.PostgreSQLServerFirewallRule)
+				return ResourceID(s, cr.Spec.ForProvider.ResourceGroupName, "Microsoft.DBforPostgreSQL/servers", cr.Spec.ForProvider.ServerName, "firewallRules", meta.GetExternalName(cr))
+			},
+		},
+		{
+			List: &databasev1alpha3.MySQLServerVirtualNetworkRuleList{},
+			Type: "azurerm_mysql_virtual_network_rule",
+			ID: func(s string, mg resource.Managed) string {
+				cr := mg.(*databasev1alpha3.MySQLServerVirtualNetworkRule)
+				return ResourceID(s, cr.Spec.ResourceGroupName, "Microsoft.DBforMySQL/servers", cr.Spec.ServerName, "virtualNetworkRules", meta.GetExternalName(cr))
+			},
+		},
+		{
+			List: &databasev1alpha3.PostgreSQLServerVirtualNetworkRuleList{},
+			Type: "azurerm_postgresql_virtual_network_rule",
+			ID: func(s string, mg resource.Managed) string {
+				cr := mg.(*databasev1alpha3.PostgreSQLServerVirtualNetworkRule)
+				return ResourceID(s, cr.Spec.ResourceGroupName, "Microsoft.DBforPostgreSQL/servers", cr.Spec.ServerName, "virtualNetworkRules", meta.GetExternalName(cr))
+			},
+		},
+		{
+			List: &databasev1alpha3.CosmosDBAccountList{},
+			Type: "azurerm_cosmosdb_account",
+			ID: func(s string, mg resource.Managed) string {
+				cr := mg.(*databasev1alpha3.CosmosDBAccount)
+				return ResourceID(s, cr.Spec.ForProvider.ResourceGroupName, "Microsoft.DocumentDB/databaseAccounts", meta.GetExternalName(cr))
+			},
+		},
+		{
+			List: &eventhubv1alpha1.SchemaRegistryGroupList{},
+			Type: "azurerm_eventhub_namespace_schema_group",
+			ID: func(s string, mg resource.Managed) string {
+				cr := mg.(*eventhubv1alpha1.SchemaRegistryGroup)
+				p := cr.Spec.ForProvider
+				return ResourceID(s, p.ResourceGroupName, "Microsoft.EventHub/namespaces", p.NamespaceName, "schemagroups", meta.GetExternalName(cr))
+			},
+		},
+		{
+			List: &kubernetesv1alpha1.ConnectedClusterList{},
+			Type: "azurerm_arc_kubernetes_cluster",
+			ID: func(s string, mg resource.Managed) string {
+				cr := mg.(*kubernetesv1alpha1.ConnectedCluster)
+				return ResourceID(s, cr.Spec.ForProvider.ResourceGroupName, "Microsoft.Kubernetes/connectedClusters", meta.GetExternalName(cr))
+			},
+		},
+		{
+			List: &kustov1alpha1.KustoClusterList{},
+			Type: "azurerm_kusto_cluster",
+			ID: func(s string, mg resource.Managed) string {
+				cr := mg.(*kustov1alpha1.KustoCluster)
+				return ResourceID(s, cr.Spec.ForProvider.ResourceGroupName, "Microsoft.Kusto/clusters", meta.GetExternalName(cr))
+			},
+		},
+		{
+			List: &kustov1alpha1.KustoDatabaseList{},
+			Type: "azurerm_kusto_database",
+			ID: func(s string, mg resource.Managed) string {
+				cr := mg.(*kustov1alpha1.KustoDatabase)
+				p := cr.Spec.ForProvider
+				return ResourceID(s, p.ResourceGroupName, "Microsoft.Kusto/clusters", p.ClusterName, "databases", meta.GetExternalName(cr))
+			},
+		},
+		{
+			List: &kustov1alpha1.KustoScriptList{},
+			Type: "azurerm_kusto_script",
+			ID: func(s string, mg resource.Managed) string {
+				cr := mg.(*kustov1alpha1.KustoScript)
+				p := cr.Spec.ForProvider
+				return ResourceID(s, p.ResourceGroupName, "Microsoft.Kusto/clusters", p.ClusterName, "databases", p.DatabaseName, "scripts", meta.GetExternalName(cr))
+			},
+		},
+		{
+			List: &monitorv1alpha1.AzureMonitorPrivateLinkScopeList{},
+			Type: "azurerm_monitor_private_link_scope",
+			ID: func(s string, mg resource.Managed) string {
+				cr := mg.(*monitorv1alpha1.AzureMonitorPrivateLinkScope)
+				return ResourceID(s, cr.Spec.ForProvider.ResourceGroupName, "microsoft.insights/privateLinkScopes", meta.GetExternalName(cr))
+			},
+		},
+		{
+			List: &monitorv1alpha1.AzureMonitorPrivateLinkScopedResourceList{},
+			Type: "azurerm_monitor_private_link_scoped_service",
+			ID: func(s string, mg resource.Managed) string {
+				cr := mg.(*monitorv1alpha1.AzureMonitorPrivateLinkScopedResource)
+				p := cr.Spec.ForProvider
+				return ResourceID(s, p.ResourceGroupName, "microsoft.insights/privateLinkScopes", p.PrivateLinkScopeName, "scopedResources", meta.GetExternalName(cr))
+			},
+		},
+		{
+			List: &monitorv1alpha1.AzureMonitorWorkspaceList{},
+			Type: "azurerm_monitor_workspace",
+			ID: func(s string, mg resource.Managed) string {
+				cr := mg.(*monitorv1alpha1.AzureMonitorWorkspace)
+				return ResourceID(s, cr.Spec.ForProvider.ResourceGroupName, "Microsoft.Monitor/accounts", meta.GetExternalName(cr))
+			},
+		},
+		{
+			List: &monitorv1alpha1.DataCollectionEndpointList{},
+			Type: "azurerm_monitor_data_collection_endpoint",
+			ID: func(s string, mg resource.Managed) string {
+				cr := mg.(*monitorv1alpha1.DataCollectionEndpoint)
+				return ResourceID(s, cr.Spec.ForProvider.ResourceGroupName, "Microsoft.Insights/dataCollectionEndpoints", meta.GetExternalName(cr))
+			},
+		},
+		{
+			List: &monitorv1alpha1.DataCollectionRuleList{},
+			Type: "azurerm_monitor_data_collection_rule",
+			ID: func(s string, mg resource.Managed) string {
+				cr := mg.(*monitorv1alpha1.DataCollectionRule)
+				return ResourceID(s, cr.Spec.ForProvider.ResourceGroupName, "Microsoft.Insights/dataCollectionRules", meta.GetExternalName(cr))
+			},
+		},
+		{
+			List: &monitorv1alpha1.DataCollectionRuleAssociationList{},
+			Type: "azurerm_monitor_data_collection_rule_association",
+			ID: func(_ string, mg resource.Managed) string {
+				cr := mg.(*monitorv1alpha1.DataCollectionRuleAssociation)
+				return cr.Spec.ForProvider.TargetResourceID + "/providers/Microsoft.Insights/dataCollectionRuleAssociations/" + meta.GetExternalName(cr)
+			},
+		},
+		{
+			List: &networkv1beta1.VirtualNetworkList{},
+			Type: "azurerm_virtual_network",
+			ID: func(s string, mg resource.Managed) string {
+				cr := mg.(*networkv1beta1.VirtualNetwork)
+				return ResourceID(s, cr.Spec.ForProvider.ResourceGroupName, "Microsoft.Network/virtualNetworks", meta.GetExternalName(cr))
+			},
+		},
+		{
+			List: &networkv1beta1.SubnetList{},
+			Type: "azurerm_subnet",
+			ID: func(s string, mg resource.Managed) string {
+				cr := mg.(*networkv1beta1.Subnet)
+				return ResourceID(s, cr.Spec.ForProvider.ResourceGroupName, "Microsoft.Network/virtualNetworks", cr.Spec.ForProvider.VirtualNetworkName, "subnets", meta.GetExternalName(cr))
+			},
+		},
+		{
+			List: &storagev1alpha3.AccountList{},
+			Type: "azurerm_storage_account",
+			ID: func(s string, mg resource.Managed) string {
+				cr := mg.(*storagev1alpha3.Account)
+				return ResourceID(s, cr.Spec.ResourceGroupName, "Microsoft.Storage/storageAccounts", meta.GetExternalName(cr))
+			},
+		},
+		{
+			List: &storagesyncv1alpha1.StorageSyncServiceList{},
+			Type: "azurerm_storage_sync",
+			ID: func(s string, mg resource.Managed) string {
+				cr := mg.(*storagesyncv1alpha1.StorageSyncService)
+				return ResourceID(s, cr.Spec.ForProvider.ResourceGroupName, "Microsoft.StorageSync/storageSyncServices", meta.GetExternalName(cr))
+			},
+		},
+		{
+			List: &storagesyncv1alpha1.SyncGroupList{},
+			Type: "azurerm_storage_sync_group",
+			ID: func(s string, mg resource.Managed) string {
+				cr := mg.(*storagesyncv1alpha1.SyncGroup)
+				return ResourceID(s, cr.Spec.ForProvider.ResourceGroupName, "Microsoft.StorageSync/storageSyncServices", cr.Spec.ForProvider.StorageSyncServiceName, "syncGroups", meta.GetExternalName(cr))
+			},
+		},
+		{
+			List: &storagesyncv1alpha1.CloudEndpointList{},
+			Type: "azurerm_storage_sync_cloud_endpoint",
+			ID: func(s string, mg resource.Managed) string {
+				cr := mg.(*storagesyncv1alpha1.CloudEndpoint)
+				p := cr.Spec.ForProvider
+				return ResourceID(s, p.ResourceGroupName, "Microsoft.StorageSync/storageSyncServices", p.StorageSyncServiceName, "syncGroups", p.SyncGroupName, "cloudEndpoints", meta.GetExternalName(cr))
+			},
+		},
+	}
+}
+
+// ResourceGroupID returns the Azure resource ID of the supplied resource group.
+func ResourceGroupID(subscription, group string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s", subscription, group)
+}
+
+// ResourceID returns the Azure resource ID of a resource of the supplied
+// provider namespace and type, e.g. "Microsoft.Cache/Redis". Child resources
+// are identified by appending type and name pairs to the supplied names.
+func ResourceID(subscription, group, providerType string, names ...string) string {
+	id := ResourceGroupID(subscription, group) + "/providers/" + providerType
+	for _, n := range names {
+		id += "/" + n
+	}
+	return id
+}
+
+// An ImportBlock imports an existing Azure resource to a Terraform address.
+type ImportBlock struct {
+	// To is the address of the Terraform resource, e.g.
+	// azurerm_redis_cache.example.
+	To string
+
+	// ID is the Azure resource ID of the resource to import.
+	ID string
+}
+
+// WriteTo writes the import block in HCL to the supplied writer.
+func (b ImportBlock) WriteTo(w io.Writer) (int64, error) {
+	n, err := fmt.Fprintf(w, "import {\n  to = %s\n  id = %q\n}\n", b.To, b.ID)
+	return int64(n), err
+}
+
+var invalidNameChars = regexp.MustCompile(`[^A-Za-z0-9_-]`)
+
+// Address returns a Terraform address of the supplied resource type for the
+// supplied Kubernetes object name. Characters that are not valid in Terraform
+// identifiers are replaced with an underscore.
+func Address(resourceType, name string) string {
+	n := invalidNameChars.ReplaceAllString(name, "_")
+	if n == "" || (n[0] >= '0' && n[0] <= '9') || n[0] == '-' {
+		n = "_" + n
+	}
+	return resourceType + "." + n
+}
+
+// An Exporter exports managed resources as Terraform import blocks.
+type Exporter struct {
+	kube  client.Client
+	kinds []Kind
+
+	// subscriptions caches the subscription ID by provider config name.
+	subscriptions map[string]string
+}
+
+// NewExporter returns an Exporter that exports all supported kinds of managed
+// resources.
+func NewExporter(c client.Client) *Exporter {
+	return &Exporter{kube: c, kinds: Kinds(), subscriptions: map[string]string{}}
+}
+
+// Export returns an import block for every managed resource that is ready,
+// i.e. known to exist in Azure. Blocks are sorted by their address.
+func (e *Exporter) Export(ctx context.Context) ([]ImportBlock, error) {
+	blocks := make([]ImportBlock, 0)
+	for _, k := range e.kinds {
+		l := k.List.DeepCopyObject().(resource.ManagedList)
+		if err := e.kube.List(ctx, l); err != nil {
+			return nil, errors.Wrapf(err, errList, k.Type)
+		}
+		for _, mg := range l.GetItems() {
+			if mg.GetCondition(xpv1.TypeReady).Status != corev1.ConditionTrue {
+				continue
+			}
+			s, err := e.subscription(ctx, mg)
+			if err != nil {
+				return nil, errors.Wrapf(err, errSubscription, k.Type, mg.GetName())
+			}
+			blocks = append(blocks, ImportBlock{To: Address(k.Type, mg.GetName()), ID: k.ID(s, mg)})
+		}
+	}
+	sort.Slice(blocks, func(i, j int) bool { return blocks[i].To < blocks[j].To })
+	return blocks, nil
+}
+
+// Write writes the supplied import blocks to the supplied writer, separated
+// by blank lines.
+func Write(w io.Writer, blocks []ImportBlock) error {
+	for i, b := range blocks {
+		if i > 0 {
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return errors.Wrap(err, errWrite)
+			}
+		}
+		if _, err := b.WriteTo(w); err != nil {
+			return errors.Wrap(err, errWrite)
+		}
+	}
+	return nil
+}
+
+func (e *Exporter) subscription(ctx context.Context, mg resource.Managed) (string, error) {
+	key := ""
+	if ref := mg.GetProviderConfigReference(); ref != nil {
+		key = ref.Name
+	}
+	if s, ok := e.subscriptions[key]; ok && key != "" {
+		return s, nil
+	}
+	creds, _, err := azure.GetAuthInfo(ctx, e.kube, mg)
+	if err != nil {
+		return "", err
+	}
+	s := creds[azure.CredentialsKeySubscriptionID]
+	if key != "" {
+		e.subscriptions[key] = s
+	}
+	return s, nil
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package terraform
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAddress(t *testing.T) {
+	cases := map[string]struct {
+		name string
+		want string
+	}{
+		"Valid":         {name: "cool-redis_1", want: "azurerm_redis_cache.cool-redis_1"},
+		"InvalidChars":  {name: "cool.redis", want: "azurerm_redis_cache.cool_redis"},
+		"LeadingNumber": {name: "1redis", want: "azurerm_redis_cache._1redis"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := Address("azurerm_redis_cache", tc.name)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Address(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestResourceID(t *testing.T) {
+	want := "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet/subnets/subnet"
+	got := ResourceID("sub", "rg", "Microsoft.Network/virtualNetworks", "vnet", "subnets", "subnet")
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ResourceID(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	blocks := []ImportBlock{
+		{To: "azurerm_resource_group.a", ID: "/subscriptions/sub/resourceGroups/a"},
+		{To: "azurerm_resource_group.b", ID: "/subscriptions/sub/resourceGroups/b"},
+	}
+	want := `import {
+  to = azurerm_resource_group.a
+  id = "/subscriptions/sub/resourceGroups/a"
+}
+
+import {
+  to = azurerm_resource_group.b
+  id = "/subscriptions/sub/resourceGroups/b"
+}
+`
+	b := &bytes.Buffer{}
+	if err := Write(b, blocks); err != nil {
+		t.Fatalf("Write(...): %s", err)
+	}
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("Write(...): -want, +got:\n%s", diff)
+	}
+}
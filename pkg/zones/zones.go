@@ -0,0 +1,88 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package zones rejects managed resources that request availability zones
+// that their location does not support when they are created or updated,
+// rather than when their controller fails to create their Azure resource.
+package zones
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/pkg/errors"
+	admissionv1 "k8s.io/api/admission/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+// WebhookPath is the path at which the validating webhook is served.
+const WebhookPath = "/validate-zones"
+
+// Error strings.
+const (
+	errDecode = "cannot decode object"
+)
+
+// A Validator is an admission handler that rejects managed resources whose
+// spec.forProvider.zones are invalid or not supported by their
+// spec.forProvider.location. AKSClusters, whose parameters are not nested
+// under forProvider, are validated by their spec.availabilityZones and
+// spec.location.
+type Validator struct{}
+
+// NewValidator returns a Validator.
+func NewValidator() *Validator {
+	return &Validator{}
+}
+
+// object is the part of a managed resource the Validator reads.
+type object struct {
+	Spec struct {
+		ForProvider struct {
+			Location string   `json:"location,omitempty"`
+			Zones    []string `json:"zones,omitempty"`
+		} `json:"forProvider"`
+
+		Location          string   `json:"location,omitempty"`
+		AvailabilityZones []string `json:"availabilityZones,omitempty"`
+	} `json:"spec"`
+}
+
+// Handle the supplied admission request. Resources that don't specify their
+// location are allowed, since it may be defaulted later.
+func (v *Validator) Handle(_ context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return admission.Allowed("")
+	}
+	o := &object{}
+	if err := json.Unmarshal(req.Object.Raw, o); err != nil {
+		return admission.Errored(http.StatusBadRequest, errors.Wrap(err, errDecode))
+	}
+	location, zones := o.Spec.ForProvider.Location, o.Spec.ForProvider.Zones
+	if location == "" {
+		location, zones = o.Spec.Location, o.Spec.AvailabilityZones
+	}
+	if location == "" {
+		return admission.Allowed("")
+	}
+	if err := azure.ValidateZones(location, zones); err != nil {
+		return admission.Denied(err.Error())
+	}
+	return admission.Allowed("")
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package zones
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/crossplane/provider-azure/apis/cache/v1beta1"
+	computev1alpha3 "github.com/crossplane/provider-azure/apis/compute/v1alpha3"
+)
+
+var _ admission.Handler = &Validator{}
+
+func TestHandle(t *testing.T) {
+	request := func(op admissionv1.Operation, location string, zones ...string) admission.Request {
+		cr := &v1beta1.Redis{ObjectMeta: metav1.ObjectMeta{Name: "cool"}}
+		cr.Spec.ForProvider.Location = location
+		cr.Spec.ForProvider.Zones = zones
+		raw, _ := json.Marshal(cr)
+		return admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: op,
+			Kind:      metav1.GroupVersionKind{Group: v1beta1.Group, Version: "v1beta1", Kind: v1beta1.RedisKind},
+			Object:    runtime.RawExtension{Raw: raw},
+		}}
+	}
+
+	aksRequest := func(location string, zones ...string) admission.Request {
+		cr := &computev1alpha3.AKSCluster{ObjectMeta: metav1.ObjectMeta{Name: "cool"}}
+		cr.Spec.Location = location
+		cr.Spec.AvailabilityZones = zones
+		raw, _ := json.Marshal(cr)
+		return admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: admissionv1.Create,
+			Kind:      metav1.GroupVersionKind{Group: computev1alpha3.Group, Version: computev1alpha3.Version, Kind: computev1alpha3.AKSClusterKind},
+			Object:    runtime.RawExtension{Raw: raw},
+		}}
+	}
+
+	type want struct {
+		allowed bool
+		code    int32
+	}
+	cases := map[string]struct {
+		reason string
+		req    admission.Request
+		want   want
+	}{
+		"Delete": {
+			reason: "Deletes should be allowed without being validated.",
+			req:    request(admissionv1.Delete, "westus", "1"),
+			want:   want{allowed: true},
+		},
+		"NoLocation": {
+			reason: "Resources without a location should be allowed, since it may be defaulted later.",
+			req:    request(admissionv1.Create, "", "1"),
+			want:   want{allowed: true},
+		},
+		"NoZones": {
+			reason: "Resources that don't request zones should be allowed in any location.",
+			req:    request(admissionv1.Create, "westus"),
+			want:   want{allowed: true},
+		},
+		"ZonalLocation": {
+			reason: "Zones should be allowed in locations that support them.",
+			req:    request(admissionv1.Create, "West Europe", "1", "2"),
+			want:   want{allowed: true},
+		},
+		"UnknownLocation": {
+			reason: "Zones should be allowed in locations that are not known not to support them.",
+			req:    request(admissionv1.Update, "italynorth", "1"),
+			want:   want{allowed: true},
+		},
+		"NonZonalLocation": {
+			reason: "Zones should be denied in locations that are known not to support them.",
+			req:    request(admissionv1.Create, "West US", "1"),
+			want:   want{code: http.StatusForbidden},
+		},
+		"InvalidZone": {
+			reason: "Zones other than 1, 2 or 3 should be denied.",
+			req:    request(admissionv1.Update, "westeurope", "4"),
+			want:   want{code: http.StatusForbidden},
+		},
+		"AKSClusterZonalLocation": {
+			reason: "Availability zones of AKS clusters should be allowed in locations that support them.",
+			req:    aksRequest("westeurope", "1", "2", "3"),
+			want:   want{allowed: true},
+		},
+		"AKSClusterNonZonalLocation": {
+			reason: "Availability zones of AKS clusters should be denied in locations that are known not to support them.",
+			req:    aksRequest("westus", "1"),
+			want:   want{code: http.StatusForbidden},
+		},
+		"DecodeError": {
+			reason: "Objects that cannot be decoded should be errored.",
+			req: admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				Object:    runtime.RawExtension{Raw: []byte("{")},
+			}},
+			want: want{code: http.StatusBadRequest},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := NewValidator().Handle(context.Background(), tc.req)
+			if diff := cmp.Diff(tc.want.allowed, got.Allowed); diff != "" {
+				t.Errorf("\n%s\nHandle(...): -want allowed, +got allowed:\n%s\n%v", tc.reason, diff, got.Result)
+			}
+			if got.Allowed {
+				return
+			}
+			if diff := cmp.Diff(tc.want.code, got.Result.Code); diff != "" {
+				t.Errorf("\n%s\nHandle(...): -want code, +got code:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}